@@ -30,10 +30,12 @@ const (
 var nameReplacer = strings.NewReplacer(".", "_", " ", "")
 
 const (
-	defaultMaxQueues   = 50
-	defaultMaxTopics   = 50
-	defaultURL         = "http://127.0.0.1:8161"
-	defaultHTTPTimeout = time.Second
+	defaultMaxQueues       = 50
+	defaultMaxTopics       = 50
+	defaultMaxDestinations = 100
+	defaultURL             = "http://127.0.0.1:8161"
+	defaultJolokiaPath     = "/api/jolokia"
+	defaultHTTPTimeout     = time.Second
 )
 
 // New creates Example with default values.
@@ -48,13 +50,15 @@ func New() *ActiveMQ {
 			},
 		},
 
-		MaxQueues: defaultMaxQueues,
-		MaxTopics: defaultMaxTopics,
+		MaxQueues:       defaultMaxQueues,
+		MaxTopics:       defaultMaxTopics,
+		MaxDestinations: defaultMaxDestinations,
+		JolokiaPath:     defaultJolokiaPath,
 	}
 
 	return &ActiveMQ{
 		Config:       config,
-		charts:       &Charts{},
+		charts:       totalCharts.Copy(),
 		activeQueues: make(map[string]bool),
 		activeTopics: make(map[string]bool),
 	}
@@ -68,6 +72,12 @@ type Config struct {
 	MaxTopics    int    `yaml:"max_topics"`
 	QueuesFilter string `yaml:"queues_filter"`
 	TopicsFilter string `yaml:"topics_filter"`
+	// DestinationsFilter and MaxDestinations are applied to both queues and topics,
+	// the filtered out destinations are still counted in the totals.
+	DestinationsFilter matcher.SimpleExpr `yaml:"destinations_filter"`
+	MaxDestinations    int                `yaml:"max_destinations"`
+	// JolokiaPath is the Jolokia endpoint path, the broker MBean metrics are not collected if it is empty.
+	JolokiaPath string `yaml:"jolokia_path"`
 }
 
 // ActiveMQ ActiveMQ module.
@@ -80,9 +90,22 @@ type ActiveMQ struct {
 	activeTopics map[string]bool
 	queuesFilter matcher.Matcher
 	topicsFilter matcher.Matcher
+	destsFilter  matcher.Matcher
 	charts       *Charts
+	// destsLimitHit is set when the max_destinations limit is reached, it is used to warn only once.
+	destsLimitHit bool
+	// jolokia is the Jolokia availability, it is checked until the first successful (or 404/403) response.
+	jolokia jolokiaState
 }
 
+type jolokiaState int
+
+const (
+	jolokiaUnknown jolokiaState = iota
+	jolokiaAvailable
+	jolokiaUnavailable
+)
+
 // Cleanup makes cleanup.
 func (ActiveMQ) Cleanup() {}
 
@@ -116,13 +139,25 @@ func (a *ActiveMQ) Init() bool {
 		a.topicsFilter = matcher.WithCache(f)
 	}
 
+	if !a.DestinationsFilter.Empty() {
+		f, err := a.DestinationsFilter.Parse()
+		if err != nil {
+			a.Errorf("error on creating destinations filter : %v", err)
+			return false
+		}
+		a.destsFilter = matcher.WithCache(f)
+	}
+
 	client, err := web.NewHTTPClient(a.Client)
 	if err != nil {
 		a.Error(err)
 		return false
 	}
 
-	a.apiClient = newAPIClient(client, a.Request, a.Webadmin)
+	a.apiClient = newAPIClient(client, a.Request, a.Webadmin, a.JolokiaPath)
+	if a.JolokiaPath == "" {
+		a.jolokia = jolokiaUnavailable
+	}
 
 	return true
 }
@@ -159,13 +194,100 @@ func (a *ActiveMQ) Collect() map[string]int64 {
 
 	a.processQueues(queues, metrics)
 	a.processTopics(topics, metrics)
+	a.processTotals(queues, topics, metrics)
+	a.collectBroker(metrics)
 
 	return metrics
 }
 
+// collectBroker collects the broker MBean metrics via Jolokia. If Jolokia responds with 404 or 403,
+// only the Console API metrics are collected. Other errors are retried on the next data collection.
+func (a *ActiveMQ) collectBroker(metrics map[string]int64) {
+	if a.jolokia == jolokiaUnavailable {
+		return
+	}
+
+	broker, err := a.apiClient.getBroker()
+	if err != nil {
+		if a.jolokia == jolokiaUnknown && isNotFoundOrForbidden(err) {
+			a.jolokia = jolokiaUnavailable
+			a.Warningf("Jolokia is not available, broker metrics are not collected: %v", err)
+			return
+		}
+		a.Error(err)
+		return
+	}
+
+	if a.jolokia == jolokiaUnknown {
+		a.jolokia = jolokiaAvailable
+		if err := a.charts.Add(*brokerCharts.Copy()...); err != nil {
+			a.Warning(err)
+		}
+		chart := a.charts.Get(totalMessagesChartID)
+		if err := chart.AddDim(&module.Dim{ID: "total_expired", Name: "expired", Algo: module.Incremental}); err != nil {
+			a.Warning(err)
+		}
+		chart.MarkNotCreated()
+	}
+
+	// the broker counters don't drop when a destination is deleted (unlike the sum of the destinations stats)
+	metrics["total_enqueued"] = broker.TotalEnqueueCount
+	metrics["total_dequeued"] = broker.TotalDequeueCount
+	metrics["total_unprocessed"] = broker.TotalMessageCount
+
+	if expired, err := a.apiClient.getExpired(); err != nil {
+		a.Error(err)
+	} else {
+		metrics["total_expired"] = expired
+	}
+
+	metrics["broker_store_usage"] = broker.StorePercentUsage
+	metrics["broker_memory_usage"] = broker.MemoryPercentUsage
+	metrics["broker_temp_usage"] = broker.TempPercentUsage
+	metrics["broker_store_limit"] = broker.StoreLimit
+	metrics["broker_memory_limit"] = broker.MemoryLimit
+	metrics["broker_temp_limit"] = broker.TempLimit
+	metrics["broker_connections"] = broker.CurrentConnectionsCount
+	metrics["broker_consumers"] = broker.TotalConsumerCount
+}
+
+// processTotals sums up the stats of all the destinations, including the filtered out ones.
+// The totals are replaced with the broker MBean counters if Jolokia is available.
+func (a *ActiveMQ) processTotals(queues *queues, topics *topics, metrics map[string]int64) {
+	var enqueued, dequeued int64
+
+	for _, q := range queues.Items {
+		if !strings.Contains(q.Name, keyAdvisory) {
+			enqueued += q.Stats.EnqueueCount
+			dequeued += q.Stats.DequeueCount
+		}
+	}
+	for _, t := range topics.Items {
+		if !strings.Contains(t.Name, keyAdvisory) {
+			enqueued += t.Stats.EnqueueCount
+			dequeued += t.Stats.DequeueCount
+		}
+	}
+
+	metrics["total_enqueued"] = enqueued
+	metrics["total_dequeued"] = dequeued
+	metrics["total_unprocessed"] = enqueued - dequeued
+}
+
+// destinationsLimitReached reports whether the max_destinations limit doesn't allow to add a new destination.
+func (a *ActiveMQ) destinationsLimitReached() bool {
+	if a.MaxDestinations == 0 || len(a.activeQueues)+len(a.activeTopics) < a.MaxDestinations {
+		return false
+	}
+	if !a.destsLimitHit {
+		a.destsLimitHit = true
+		a.Warningf("destinations limit (%d) is reached, new queues and topics are not charted", a.MaxDestinations)
+	}
+	return true
+}
+
 func (a *ActiveMQ) processQueues(queues *queues, metrics map[string]int64) {
 	var (
-		count   = len(a.activeQueues)
 		updated = make(map[string]bool)
 		unp     int
 	)
@@ -176,12 +298,16 @@ func (a *ActiveMQ) processQueues(queues *queues, metrics map[string]int64) {
 		}
 
 		if !a.activeQueues[q.Name] {
-			if a.MaxQueues != 0 && count > a.MaxQueues {
+			if !a.filterQueues(q.Name) {
+				continue
+			}
+
+			if a.MaxQueues != 0 && len(a.activeQueues) >= a.MaxQueues {
 				unp++
 				continue
 			}
 
-			if !a.filterQueues(q.Name) {
+			if a.destinationsLimitReached() {
 				continue
 			}
 
@@ -213,7 +339,6 @@ func (a *ActiveMQ) processQueues(queues *queues, metrics map[string]int64) {
 
 func (a *ActiveMQ) processTopics(topics *topics, metrics map[string]int64) {
 	var (
-		count   = len(a.activeTopics)
 		updated = make(map[string]bool)
 		unp     int
 	)
@@ -224,12 +349,16 @@ func (a *ActiveMQ) processTopics(topics *topics, metrics map[string]int64) {
 		}
 
 		if !a.activeTopics[t.Name] {
-			if a.MaxTopics != 0 && count > a.MaxTopics {
+			if !a.filterTopics(t.Name) {
+				continue
+			}
+
+			if a.MaxTopics != 0 && len(a.activeTopics) >= a.MaxTopics {
 				unp++
 				continue
 			}
 
-			if !a.filterTopics(t.Name) {
+			if a.destinationsLimitReached() {
 				continue
 			}
 
@@ -261,6 +390,9 @@ func (a *ActiveMQ) processTopics(topics *topics, metrics map[string]int64) {
 }
 
 func (a ActiveMQ) filterQueues(line string) bool {
+	if !a.filterDestinations(line) {
+		return false
+	}
 	if a.queuesFilter == nil {
 		return true
 	}
@@ -268,12 +400,22 @@ func (a ActiveMQ) filterQueues(line string) bool {
 }
 
 func (a ActiveMQ) filterTopics(line string) bool {
+	if !a.filterDestinations(line) {
+		return false
+	}
 	if a.topicsFilter == nil {
 		return true
 	}
 	return a.topicsFilter.MatchString(line)
 }
 
+func (a ActiveMQ) filterDestinations(line string) bool {
+	if a.destsFilter == nil {
+		return true
+	}
+	return a.destsFilter.MatchString(line)
+}
+
 func (a *ActiveMQ) addQueueTopicCharts(name, typ string) {
 	rname := nameReplacer.Replace(name)
 
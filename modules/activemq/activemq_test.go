@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -131,6 +132,48 @@ var (
 	}
 )
 
+var brokerData = `{
+  "request": {
+    "mbean": "org.apache.activemq:brokerName=*,type=Broker",
+    "type": "read"
+  },
+  "value": {
+    "org.apache.activemq:brokerName=localhost,type=Broker": {
+      "StorePercentUsage": 97,
+      "MemoryPercentUsage": 12,
+      "TempPercentUsage": 0,
+      "StoreLimit": 107374182400,
+      "MemoryLimit": 1073741824,
+      "TempLimit": 53687091200,
+      "CurrentConnectionsCount": 5,
+      "TotalConsumerCount": 7,
+      "TotalEnqueueCount": 120,
+      "TotalDequeueCount": 100,
+      "TotalMessageCount": 15
+    }
+  },
+  "timestamp": 1665000000,
+  "status": 200
+}`
+
+var expiredData = `{
+  "request": {
+    "mbean": "org.apache.activemq:brokerName=*,destinationName=*,destinationType=*,type=Broker",
+    "attribute": "ExpiredCount",
+    "type": "read"
+  },
+  "value": {
+    "org.apache.activemq:brokerName=localhost,destinationName=sandra,destinationType=Queue,type=Broker": {
+      "ExpiredCount": 3
+    },
+    "org.apache.activemq:brokerName=localhost,destinationName=AAA,destinationType=Topic,type=Broker": {
+      "ExpiredCount": 2
+    }
+  },
+  "timestamp": 1665000000,
+  "status": 200
+}`
+
 func TestNew(t *testing.T) {
 	job := New()
 
@@ -139,6 +182,7 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, defaultHTTPTimeout, job.Client.Timeout.Duration)
 	assert.Equal(t, defaultMaxQueues, job.MaxQueues)
 	assert.Equal(t, defaultMaxTopics, job.MaxTopics)
+	assert.Equal(t, defaultMaxDestinations, job.MaxDestinations)
 }
 
 func TestActiveMQ_Init(t *testing.T) {
@@ -230,10 +274,13 @@ func TestActiveMQ_Collect(t *testing.T) {
 				"queues_sandra_unprocessed": 1,
 				"topics_AAA_consumers":      1,
 				"topics_AAAA_enqueued":      2,
+				"total_enqueued":            8,
+				"total_dequeued":            4,
+				"total_unprocessed":         4,
 			},
 			numQueues: 2,
 			numTopics: 2,
-			numCharts: 12,
+			numCharts: 14,
 		},
 		{
 			expected: map[string]int64{
@@ -261,10 +308,13 @@ func TestActiveMQ_Collect(t *testing.T) {
 				"topics_AAA_unprocessed":    1,
 				"topics_AAAA_consumers":     2,
 				"topics_BBB_consumers":      1,
+				"total_enqueued":            14,
+				"total_dequeued":            9,
+				"total_unprocessed":         5,
 			},
 			numQueues: 3,
 			numTopics: 3,
-			numCharts: 18,
+			numCharts: 20,
 		},
 		{
 			expected: map[string]int64{
@@ -284,10 +334,13 @@ func TestActiveMQ_Collect(t *testing.T) {
 				"topics_AAA_enqueued":       4,
 				"topics_AAA_dequeued":       3,
 				"topics_AAAA_dequeued":      3,
+				"total_enqueued":            16,
+				"total_dequeued":            12,
+				"total_unprocessed":         4,
 			},
 			numQueues: 2,
 			numTopics: 2,
-			numCharts: 18,
+			numCharts: 20,
 		},
 	}
 
@@ -300,6 +353,163 @@ func TestActiveMQ_Collect(t *testing.T) {
 	}
 }
 
+func TestActiveMQ_CollectDestinationsFilterAndLimit(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/webadmin/xml/queues.jsp":
+					_, _ = w.Write([]byte(queuesData[1]))
+				case "/webadmin/xml/topics.jsp":
+					_, _ = w.Write([]byte(topicsData[1]))
+				}
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.HTTP.Request = web.Request{URL: ts.URL}
+	job.Webadmin = "webadmin"
+	job.DestinationsFilter = matcher.SimpleExpr{Includes: []string{"* *"}, Excludes: []string{"* sandra"}}
+	job.MaxDestinations = 3
+
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+	require.NotNil(t, mx)
+
+	assert.NotContains(t, mx, "queues_sandra_enqueued")
+	assert.Contains(t, mx, "queues_Test_enqueued")
+	assert.Contains(t, mx, "queues_Test2_enqueued")
+	assert.Len(t, job.activeQueues, 2)
+	assert.Len(t, job.activeTopics, 1)
+	assert.True(t, job.destsLimitHit)
+	// the filtered out and over the limit destinations are counted in the totals
+	assert.Equal(t, int64(14), mx["total_enqueued"])
+	assert.Equal(t, int64(9), mx["total_dequeued"])
+	assert.Equal(t, int64(5), mx["total_unprocessed"])
+}
+
+func TestActiveMQ_CollectBroker(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/webadmin/xml/queues.jsp":
+					_, _ = w.Write([]byte(queuesData[0]))
+				case "/webadmin/xml/topics.jsp":
+					_, _ = w.Write([]byte(topicsData[0]))
+				case "/api/jolokia" + pathJolokiaBroker:
+					_, _ = w.Write([]byte(brokerData))
+				case "/api/jolokia" + pathJolokiaDestinationsExpired:
+					_, _ = w.Write([]byte(expiredData))
+				}
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.HTTP.Request = web.Request{URL: ts.URL}
+	job.Webadmin = "webadmin"
+
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+	require.NotNil(t, mx)
+
+	expected := map[string]int64{
+		"broker_store_usage":  97,
+		"broker_memory_usage": 12,
+		"broker_temp_usage":   0,
+		"broker_store_limit":  107374182400,
+		"broker_memory_limit": 1073741824,
+		"broker_temp_limit":   53687091200,
+		"broker_connections":  5,
+		"broker_consumers":    7,
+		"total_enqueued":      120,
+		"total_dequeued":      100,
+		"total_unprocessed":   15,
+		"total_expired":       5,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	assert.Equal(t, jolokiaAvailable, job.jolokia)
+	for _, chart := range brokerCharts {
+		assert.Truef(t, job.Charts().Has(chart.ID), "chart '%s' is not added", chart.ID)
+	}
+	assert.True(t, job.Charts().Get(totalMessagesChartID).HasDim("total_expired"))
+}
+
+func TestActiveMQ_CollectJolokiaNotAvailable(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/webadmin/xml/queues.jsp":
+					_, _ = w.Write([]byte(queuesData[0]))
+				case "/webadmin/xml/topics.jsp":
+					_, _ = w.Write([]byte(topicsData[0]))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.HTTP.Request = web.Request{URL: ts.URL}
+	job.Webadmin = "webadmin"
+
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+	require.NotNil(t, mx)
+
+	assert.NotContains(t, mx, "broker_store_usage")
+	assert.NotContains(t, mx, "total_expired")
+	assert.Equal(t, int64(8), mx["total_enqueued"], "the totals are the sum of the destinations stats")
+	assert.Equal(t, jolokiaUnavailable, job.jolokia)
+	for _, chart := range brokerCharts {
+		assert.Falsef(t, job.Charts().Has(chart.ID), "chart '%s' is added", chart.ID)
+	}
+}
+
+func TestActiveMQ_CollectJolokiaTemporaryError(t *testing.T) {
+	brokerFails := true
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/webadmin/xml/queues.jsp":
+					_, _ = w.Write([]byte(queuesData[0]))
+				case "/webadmin/xml/topics.jsp":
+					_, _ = w.Write([]byte(topicsData[0]))
+				case "/api/jolokia" + pathJolokiaBroker:
+					if brokerFails {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					_, _ = w.Write([]byte(brokerData))
+				}
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.HTTP.Request = web.Request{URL: ts.URL}
+	job.Webadmin = "webadmin"
+
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+	require.NotNil(t, mx)
+	assert.NotContains(t, mx, "broker_store_usage")
+	assert.Equal(t, jolokiaUnknown, job.jolokia)
+
+	brokerFails = false
+	mx = job.Collect()
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(97), mx["broker_store_usage"])
+	assert.Equal(t, jolokiaAvailable, job.jolokia)
+}
+
 func TestActiveMQ_404(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(404)
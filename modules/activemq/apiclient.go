@@ -3,7 +3,9 @@
 package activemq
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"github.com/netdata/go.d.plugin/pkg/web"
 	"io"
@@ -12,6 +14,20 @@ import (
 	"path"
 )
 
+// statusError is returned if the server (or Jolokia) responds with an unexpected status code.
+type statusError struct {
+	msg  string
+	code int
+}
+
+func (e *statusError) Error() string { return e.msg }
+
+// isNotFoundOrForbidden reports whether the error is caused by the 404 or the 403 status code.
+func isNotFoundOrForbidden(err error) bool {
+	var e *statusError
+	return errors.As(err, &e) && (e.code == http.StatusNotFound || e.code == http.StatusForbidden)
+}
+
 type topics struct {
 	XMLName xml.Name `xml:"topics"`
 	Items   []topic  `xml:"topic"`
@@ -42,20 +58,65 @@ type stats struct {
 	DequeueCount  int64    `xml:"dequeueCount,attr"`
 }
 
-const pathStats = "/%s/xml/%s.jsp"
+// brokerStats are the broker MBean attributes, the limits are in bytes.
+type brokerStats struct {
+	StorePercentUsage       int64 `json:"StorePercentUsage"`
+	MemoryPercentUsage      int64 `json:"MemoryPercentUsage"`
+	TempPercentUsage        int64 `json:"TempPercentUsage"`
+	StoreLimit              int64 `json:"StoreLimit"`
+	MemoryLimit             int64 `json:"MemoryLimit"`
+	TempLimit               int64 `json:"TempLimit"`
+	CurrentConnectionsCount int64 `json:"CurrentConnectionsCount"`
+	TotalConsumerCount      int64 `json:"TotalConsumerCount"`
+	TotalEnqueueCount       int64 `json:"TotalEnqueueCount"`
+	TotalDequeueCount       int64 `json:"TotalDequeueCount"`
+	TotalMessageCount       int64 `json:"TotalMessageCount"`
+}
+
+// destinationStats are the destination MBean attributes.
+type destinationStats struct {
+	ExpiredCount int64 `json:"ExpiredCount"`
+}
+
+type jolokiaReadResponse struct {
+	Status int                    `json:"status"`
+	Error  string                 `json:"error"`
+	Value  map[string]brokerStats `json:"value"`
+}
+
+type jolokiaReadDestinationsResponse struct {
+	Status int                         `json:"status"`
+	Error  string                      `json:"error"`
+	Value  map[string]destinationStats `json:"value"`
+}
+
+const (
+	pathStats = "/%s/xml/%s.jsp"
+	// pathJolokiaBroker is the Jolokia read request of the broker MBean attributes, the broker name is a wildcard,
+	// so the response value is the map of the matched MBeans.
+	pathJolokiaBroker = "/read/org.apache.activemq:type=Broker,brokerName=*/" +
+		"StorePercentUsage,MemoryPercentUsage,TempPercentUsage,StoreLimit,MemoryLimit,TempLimit," +
+		"CurrentConnectionsCount,TotalConsumerCount,TotalEnqueueCount,TotalDequeueCount,TotalMessageCount"
+	// pathJolokiaDestinationsExpired is the Jolokia read request of the expired messages counter of all the queues
+	// and topics, the broker has no such total counter.
+	pathJolokiaDestinationsExpired = "/read/org.apache.activemq:type=Broker,brokerName=*,destinationType=*,destinationName=*/" +
+		"ExpiredCount"
+)
 
-func newAPIClient(client *http.Client, request web.Request, webadmin string) *apiClient {
+func newAPIClient(client *http.Client, request web.Request, webadmin, jolokiaPath string) *apiClient {
 	return &apiClient{
-		httpClient: client,
-		request:    request,
-		webadmin:   webadmin,
+		httpClient:  client,
+		request:     request,
+		webadmin:    webadmin,
+		jolokiaPath: jolokiaPath,
 	}
 }
 
 type apiClient struct {
-	httpClient *http.Client
-	request    web.Request
-	webadmin   string
+	httpClient  *http.Client
+	request     web.Request
+	webadmin    string
+	jolokiaPath string
 }
 
 func (a *apiClient) getQueues() (*queues, error) {
@@ -104,6 +165,77 @@ func (a *apiClient) getTopics() (*topics, error) {
 	return &topics, nil
 }
 
+func (a *apiClient) getBroker() (*brokerStats, error) {
+	req, err := a.createRequest(a.jolokiaPath + pathJolokiaBroker)
+	if err != nil {
+		return nil, fmt.Errorf("error on creating request '%s' : %v", a.request.URL, err)
+	}
+
+	resp, err := a.doRequestOK(req)
+
+	defer closeBody(resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var read jolokiaReadResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&read); err != nil {
+		return nil, fmt.Errorf("error on decoding resp from %s : %s", req.URL, err)
+	}
+
+	if read.Status != http.StatusOK {
+		msg := fmt.Sprintf("%s returned Jolokia status %d : %s", req.URL, read.Status, read.Error)
+		return nil, &statusError{msg: msg, code: read.Status}
+	}
+
+	// only one broker is expected, the broker MBean name is not known in advance
+	for _, broker := range read.Value {
+		return &broker, nil
+	}
+
+	return nil, fmt.Errorf("%s returned no broker MBeans", req.URL)
+}
+
+// getExpired returns the sum of the expired messages of all the destinations.
+func (a *apiClient) getExpired() (int64, error) {
+	req, err := a.createRequest(a.jolokiaPath + pathJolokiaDestinationsExpired)
+	if err != nil {
+		return 0, fmt.Errorf("error on creating request '%s' : %v", a.request.URL, err)
+	}
+
+	resp, err := a.doRequestOK(req)
+
+	defer closeBody(resp)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var read jolokiaReadDestinationsResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&read); err != nil {
+		return 0, fmt.Errorf("error on decoding resp from %s : %s", req.URL, err)
+	}
+
+	// Jolokia responds with 404 if the pattern matches no MBeans (no destinations)
+	if read.Status == http.StatusNotFound {
+		return 0, nil
+	}
+	if read.Status != http.StatusOK {
+		msg := fmt.Sprintf("%s returned Jolokia status %d : %s", req.URL, read.Status, read.Error)
+		return 0, &statusError{msg: msg, code: read.Status}
+	}
+
+	var expired int64
+	for _, dest := range read.Value {
+		expired += dest.ExpiredCount
+	}
+
+	return expired, nil
+}
+
 func (a apiClient) doRequestOK(req *http.Request) (*http.Response, error) {
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
@@ -111,7 +243,8 @@ func (a apiClient) doRequestOK(req *http.Request) (*http.Response, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return resp, fmt.Errorf("%s returned HTTP status %d", req.URL, resp.StatusCode)
+		msg := fmt.Sprintf("%s returned HTTP status %d", req.URL, resp.StatusCode)
+		return resp, &statusError{msg: msg, code: resp.StatusCode}
 	}
 
 	return resp, err
@@ -9,6 +9,8 @@ type (
 	Charts = module.Charts
 	// Dims is an alias for module.Dims
 	Dims = module.Dims
+	// Vars is an alias for module.Vars
+	Vars = module.Vars
 )
 
 var charts = Charts{
@@ -44,3 +46,71 @@ var charts = Charts{
 		},
 	},
 }
+
+const totalMessagesChartID = "total_messages"
+
+// totalCharts are the broker level charts, all the destinations are counted regardless of the filters and limits.
+var totalCharts = Charts{
+	{
+		ID:    totalMessagesChartID,
+		Title: "Total Messages",
+		Units: "messages/s",
+		Fam:   "totals",
+		Ctx:   "activemq.total_messages",
+		Dims: Dims{
+			{ID: "total_enqueued", Name: "enqueued", Algo: module.Incremental},
+			{ID: "total_dequeued", Name: "dequeued", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "total_unprocessed_messages",
+		Title: "Total Unprocessed Messages",
+		Units: "messages",
+		Fam:   "totals",
+		Ctx:   "activemq.total_unprocessed_messages",
+		Dims: Dims{
+			{ID: "total_unprocessed", Name: "unprocessed"},
+		},
+	},
+}
+
+// brokerCharts are the broker MBean charts, they are added if Jolokia is available.
+var brokerCharts = Charts{
+	{
+		ID:    "broker_usage",
+		Title: "Broker Store, Memory and Temp Usage",
+		Units: "percentage",
+		Fam:   "broker",
+		Ctx:   "activemq.broker_usage",
+		Dims: Dims{
+			{ID: "broker_store_usage", Name: "store"},
+			{ID: "broker_memory_usage", Name: "memory"},
+			{ID: "broker_temp_usage", Name: "temp"},
+		},
+		Vars: Vars{
+			{ID: "broker_store_limit"},
+			{ID: "broker_memory_limit"},
+			{ID: "broker_temp_limit"},
+		},
+	},
+	{
+		ID:    "broker_connections",
+		Title: "Broker Connections",
+		Units: "connections",
+		Fam:   "broker",
+		Ctx:   "activemq.broker_connections",
+		Dims: Dims{
+			{ID: "broker_connections", Name: "connections"},
+		},
+	},
+	{
+		ID:    "broker_consumers",
+		Title: "Broker Consumers",
+		Units: "consumers",
+		Fam:   "broker",
+		Ctx:   "activemq.broker_consumers",
+		Dims: Dims{
+			{ID: "broker_consumers", Name: "consumers"},
+		},
+	},
+}
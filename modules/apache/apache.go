@@ -29,13 +29,16 @@ func New() *Apache {
 				},
 			},
 		},
-		charts: &module.Charts{},
-		once:   &sync.Once{},
+		charts:    &module.Charts{},
+		once:      &sync.Once{},
+		balancers: make(map[string]map[string]bool),
 	}
 }
 
 type Config struct {
-	web.HTTP `yaml:",inline"`
+	web.HTTP            `yaml:",inline"`
+	CollectWorkersTable bool   `yaml:"collect_workers_table"`
+	BalancerURL         string `yaml:"balancer_url"`
 }
 
 type Apache struct {
@@ -47,6 +50,9 @@ type Apache struct {
 
 	httpClient *http.Client
 	once       *sync.Once
+
+	// balancer name -> member ID
+	balancers map[string]map[string]bool
 }
 
 func (a *Apache) Init() bool {
@@ -63,6 +69,9 @@ func (a *Apache) Init() bool {
 	a.httpClient = httpClient
 
 	a.Debugf("using URL %s", a.URL)
+	if a.BalancerURL != "" {
+		a.Debugf("using balancer-manager URL %s", a.BalancerURL)
+	}
 	a.Debugf("using timeout: %s", a.Timeout.Duration)
 	return true
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/netdata/go.d.plugin/pkg/web"
@@ -15,18 +16,22 @@ import (
 )
 
 var (
-	dataSimpleStatusMPMEvent, _     = os.ReadFile("testdata/simple-status-mpm-event.txt")
-	dataExtendedStatusMPMEvent, _   = os.ReadFile("testdata/extended-status-mpm-event.txt")
-	dataExtendedStatusMPMPrefork, _ = os.ReadFile("testdata/extended-status-mpm-prefork.txt")
-	dataLighttpdStatus, _           = os.ReadFile("testdata/lighttpd-status.txt")
+	dataSimpleStatusMPMEvent, _       = os.ReadFile("testdata/simple-status-mpm-event.txt")
+	dataExtendedStatusMPMEvent, _     = os.ReadFile("testdata/extended-status-mpm-event.txt")
+	dataExtendedStatusMPMPrefork, _   = os.ReadFile("testdata/extended-status-mpm-prefork.txt")
+	dataLighttpdStatus, _             = os.ReadFile("testdata/lighttpd-status.txt")
+	dataExtendedStatusMPMEventHTML, _ = os.ReadFile("testdata/extended-status-mpm-event.html")
+	dataBalancerManager, _            = os.ReadFile("testdata/balancer-manager.html")
 )
 
 func Test_testDataIsValid(t *testing.T) {
 	for name, data := range map[string][]byte{
-		"dataSimpleStatusMPMEvent":     dataSimpleStatusMPMEvent,
-		"dataExtendedStatusMPMEvent":   dataExtendedStatusMPMEvent,
-		"dataExtendedStatusMPMPrefork": dataExtendedStatusMPMPrefork,
-		"dataLighttpdStatus":           dataLighttpdStatus,
+		"dataSimpleStatusMPMEvent":       dataSimpleStatusMPMEvent,
+		"dataExtendedStatusMPMEvent":     dataExtendedStatusMPMEvent,
+		"dataExtendedStatusMPMPrefork":   dataExtendedStatusMPMPrefork,
+		"dataLighttpdStatus":             dataLighttpdStatus,
+		"dataExtendedStatusMPMEventHTML": dataExtendedStatusMPMEventHTML,
+		"dataBalancerManager":            dataBalancerManager,
 	} {
 		require.NotNilf(t, data, name)
 
@@ -247,6 +252,131 @@ func TestApache_Collect(t *testing.T) {
 	}
 }
 
+func TestApache_Collect_WorkersTable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.RawQuery == "auto" {
+				_, _ = w.Write(dataExtendedStatusMPMEvent)
+			} else {
+				_, _ = w.Write(dataExtendedStatusMPMEventHTML)
+			}
+		}))
+	defer srv.Close()
+
+	apache := New()
+	apache.URL = srv.URL + "/server-status?auto"
+	apache.CollectWorkersTable = true
+	require.True(t, apache.Init())
+	require.True(t, apache.Check())
+
+	mx := apache.Collect()
+
+	// the 'K' (keepalive) worker is not processing a request
+	assert.Equal(t, int64(47), mx["oldest_request_duration"])
+	assert.True(t, apache.Charts().Has(chartOldestRequestDuration.ID))
+}
+
+func TestApache_Collect_WorkersTableNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(dataExtendedStatusMPMEvent)
+		}))
+	defer srv.Close()
+
+	apache := New()
+	apache.URL = srv.URL + "/server-status?auto"
+	apache.CollectWorkersTable = true
+	require.True(t, apache.Init())
+
+	mx := apache.Collect()
+
+	require.NotNil(t, mx)
+	assert.NotContains(t, mx, "oldest_request_duration")
+}
+
+func TestApache_Collect_Balancers(t *testing.T) {
+	balancerData := dataBalancerManager
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/balancer-manager":
+				_, _ = w.Write(balancerData)
+			default:
+				_, _ = w.Write(dataSimpleStatusMPMEvent)
+			}
+		}))
+	defer srv.Close()
+
+	apache := New()
+	apache.URL = srv.URL + "/server-status?auto"
+	apache.BalancerURL = srv.URL + "/balancer-manager"
+	require.True(t, apache.Init())
+	require.True(t, apache.Check())
+
+	mx := apache.Collect()
+
+	expected := map[string]int64{
+		"balancer_app_members_ok":                        1,
+		"balancer_app_members_error":                     1,
+		"balancer_app_members_drain":                     1,
+		"balancer_app_members_disabled":                  0,
+		"balancer_app_member_http_10.0.0.1_8080_busy":    3,
+		"balancer_app_member_http_10.0.0.1_8080_elected": 1207,
+		"balancer_app_member_http_10.0.0.1_8080_to":      1258291,
+		"balancer_app_member_http_10.0.0.1_8080_from":    47185920,
+		"balancer_app_member_http_10.0.0.2_8080_busy":    0,
+		"balancer_app_member_http_10.0.0.2_8080_elected": 310,
+		"balancer_app_member_http_10.0.0.2_8080_to":      319488,
+		"balancer_app_member_http_10.0.0.2_8080_from":    10276044,
+		"balancer_app_member_http_10.0.0.3_8080_busy":    1,
+		"balancer_app_member_http_10.0.0.3_8080_elected": 95,
+		"balancer_app_member_http_10.0.0.3_8080_to":      98304,
+		"balancer_app_member_http_10.0.0.3_8080_from":    3040870,
+		"balancer_api_members_ok":                        1,
+		"balancer_api_members_error":                     0,
+		"balancer_api_members_drain":                     0,
+		"balancer_api_members_disabled":                  1,
+		"balancer_api_member_http_10.0.1.1_9000_busy":    2,
+		"balancer_api_member_http_10.0.1.1_9000_elected": 58,
+		"balancer_api_member_http_10.0.1.1_9000_to":      12288,
+		"balancer_api_member_http_10.0.1.1_9000_from":    0,
+		"balancer_api_member_http_10.0.1.2_9000_busy":    0,
+		"balancer_api_member_http_10.0.1.2_9000_elected": 0,
+		"balancer_api_member_http_10.0.1.2_9000_to":      0,
+		"balancer_api_member_http_10.0.1.2_9000_from":    0,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	for _, id := range []string{"balancer_app_members_status", "balancer_api_members_busy"} {
+		assert.Truef(t, apache.Charts().Has(id), "chart '%s'", id)
+	}
+	assert.Len(t, apache.Charts().Get("balancer_app_members_busy").Dims, 3)
+
+	// the 'api' balancer is gone
+	balancerData = []byte(strings.Split(string(dataBalancerManager), "<h3>LoadBalancer Status for <a href='/balancer-manager?b=api")[0])
+	_ = apache.Collect()
+
+	assert.True(t, apache.Charts().Get("balancer_api_members_busy").Obsolete)
+	assert.False(t, apache.Charts().Get("balancer_app_members_busy").Obsolete)
+}
+
+func Test_parseBalancerSize(t *testing.T) {
+	tests := map[string]int64{
+		"  0 ": 0,
+		"512 ": 512,
+		" 12K": 12 * 1024,
+		"1.5M": 1572864,
+		"2.0G": 2 * 1024 * 1024 * 1024,
+		"":     0,
+		"n/a":  0,
+	}
+
+	for value, want := range tests {
+		assert.Equalf(t, want, parseBalancerSize(value), "value '%s'", value)
+	}
+}
+
 func caseMPMEventSimpleStatus(t *testing.T) (*Apache, func()) {
 	t.Helper()
 	srv := httptest.NewServer(http.HandlerFunc(
@@ -2,7 +2,11 @@
 
 package apache
 
-import "github.com/netdata/go.d.plugin/agent/module"
+import (
+	"fmt"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
 
 const (
 	prioRequests = module.Priority + iota
@@ -15,6 +19,12 @@ const (
 	prioBytesPerSec
 	prioBytesPerReq
 	prioUptime
+	prioOldestRequestDuration
+
+	prioBalancerMembersStatus
+	prioBalancerMembersBusy
+	prioBalancerMembersRequests
+	prioBalancerMembersTraffic
 )
 
 var baseCharts = module.Charts{
@@ -175,6 +185,17 @@ var (
 			{ID: "bytes_per_req", Name: "size", Div: 1024 * 100000},
 		},
 	}
+	chartOldestRequestDuration = module.Chart{
+		ID:       "oldest_request_duration",
+		Title:    "Oldest Request Being Processed Duration",
+		Units:    "seconds",
+		Fam:      "requests",
+		Ctx:      "apache.oldest_request_duration",
+		Priority: prioOldestRequestDuration,
+		Dims: module.Dims{
+			{ID: "oldest_request_duration", Name: "duration"},
+		},
+	}
 	chartUptime = module.Chart{
 		ID:       "uptime",
 		Title:    "Uptime",
@@ -187,3 +208,84 @@ var (
 		},
 	}
 )
+
+// mod_proxy_balancer
+var balancerChartsTmpl = module.Charts{
+	{
+		ID:       "balancer_%s_members_status",
+		Title:    "Balancer Members Status",
+		Units:    "members",
+		Fam:      "balancers",
+		Ctx:      "apache.balancer_members_status",
+		Type:     module.Stacked,
+		Priority: prioBalancerMembersStatus,
+		Dims: module.Dims{
+			{ID: "balancer_%s_members_ok", Name: "ok"},
+			{ID: "balancer_%s_members_error", Name: "error"},
+			{ID: "balancer_%s_members_drain", Name: "drain"},
+			{ID: "balancer_%s_members_disabled", Name: "disabled"},
+		},
+	},
+	{
+		ID:       "balancer_%s_members_busy",
+		Title:    "Balancer Members Busy Requests",
+		Units:    "requests",
+		Fam:      "balancers",
+		Ctx:      "apache.balancer_members_busy",
+		Type:     module.Stacked,
+		Priority: prioBalancerMembersBusy,
+	},
+	{
+		ID:       "balancer_%s_members_requests",
+		Title:    "Balancer Members Requests",
+		Units:    "requests/s",
+		Fam:      "balancers",
+		Ctx:      "apache.balancer_members_requests",
+		Type:     module.Stacked,
+		Priority: prioBalancerMembersRequests,
+	},
+	{
+		ID:       "balancer_%s_members_sent",
+		Title:    "Balancer Members Sent Traffic",
+		Units:    "bytes/s",
+		Fam:      "balancers",
+		Ctx:      "apache.balancer_members_sent",
+		Type:     module.Stacked,
+		Priority: prioBalancerMembersTraffic,
+	},
+	{
+		ID:       "balancer_%s_members_received",
+		Title:    "Balancer Members Received Traffic",
+		Units:    "bytes/s",
+		Fam:      "balancers",
+		Ctx:      "apache.balancer_members_received",
+		Type:     module.Stacked,
+		Priority: prioBalancerMembersTraffic + 1,
+	},
+}
+
+// balancerMemberDims are the per member dimensions of the balancer charts.
+var balancerMemberDims = []struct {
+	chartID string
+	metric  string
+	algo    module.DimAlgo
+}{
+	{chartID: "balancer_%s_members_busy", metric: "busy"},
+	{chartID: "balancer_%s_members_requests", metric: "elected", algo: module.Incremental},
+	{chartID: "balancer_%s_members_sent", metric: "to", algo: module.Incremental},
+	{chartID: "balancer_%s_members_received", metric: "from", algo: module.Incremental},
+}
+
+func newBalancerCharts(name string) *module.Charts {
+	charts := balancerChartsTmpl.Copy()
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, name)
+		chart.Labels = []module.Label{
+			{Key: "balancer", Value: name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, name)
+		}
+	}
+	return charts
+}
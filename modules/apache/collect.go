@@ -25,7 +25,24 @@ func (a *Apache) collect() (map[string]int64, error) {
 		return nil, fmt.Errorf("nothing was collected from %s", a.URL)
 	}
 
-	a.once.Do(func() { a.charts = newCharts(status) })
+	a.once.Do(func() {
+		a.charts = newCharts(status)
+		if a.CollectWorkersTable && status.Total.Accesses != nil {
+			_ = a.charts.Add(chartOldestRequestDuration.Copy())
+		}
+	})
+
+	if a.CollectWorkersTable && status.Total.Accesses != nil {
+		if err := a.collectWorkersTable(mx); err != nil {
+			a.Warning(err)
+		}
+	}
+
+	if a.BalancerURL != "" {
+		if err := a.collectBalancers(mx); err != nil {
+			a.Warning(err)
+		}
+	}
 
 	return mx, nil
 }
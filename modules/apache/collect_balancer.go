@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package apache
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+const balancerStatusPrefix = "LoadBalancer Status for"
+
+type (
+	balancer struct {
+		name    string
+		members []balancerMember
+	}
+	balancerMember struct {
+		url     string
+		status  string
+		elected int64
+		busy    int64
+		to      int64
+		from    int64
+	}
+)
+
+// collectBalancers collects mod_proxy_balancer members status, busy requests and traffic from the balancer-manager
+// page. The page is HTML only, it has a status table per balancer.
+func (a *Apache) collectBalancers(mx map[string]int64) error {
+	balancers, err := a.scrapeBalancers()
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, b := range balancers {
+		seen[b.name] = true
+		members, ok := a.balancers[b.name]
+		if !ok {
+			members = make(map[string]bool)
+			a.balancers[b.name] = members
+			a.addBalancerCharts(b.name)
+		}
+
+		px := "balancer_" + b.name + "_"
+		for _, state := range []string{"ok", "error", "drain", "disabled"} {
+			mx[px+"members_"+state] = 0
+		}
+
+		seenMembers := make(map[string]bool)
+		for _, m := range b.members {
+			id := balancerMemberID(m.url)
+			seenMembers[id] = true
+			if !members[id] {
+				members[id] = true
+				a.addBalancerMemberDims(b.name, id, m.url)
+			}
+
+			mx[px+"members_"+balancerMemberState(m.status)]++
+			mpx := px + "member_" + id + "_"
+			mx[mpx+"busy"] = m.busy
+			mx[mpx+"elected"] = m.elected
+			mx[mpx+"to"] = m.to
+			mx[mpx+"from"] = m.from
+		}
+
+		for id := range members {
+			if !seenMembers[id] {
+				delete(members, id)
+				a.removeBalancerMemberDims(b.name, id)
+			}
+		}
+	}
+
+	for name := range a.balancers {
+		if !seen[name] {
+			delete(a.balancers, name)
+			a.removeBalancerCharts(name)
+		}
+	}
+
+	return nil
+}
+
+func (a *Apache) scrapeBalancers() ([]balancer, error) {
+	r := a.Request
+	r.URL = a.BalancerURL
+
+	req, err := web.NewHTTPRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error on HTTP request '%s': %v", req.URL, err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("'%s' returned HTTP status code: %d", req.URL, resp.StatusCode)
+	}
+
+	return parseBalancerManager(resp.Body)
+}
+
+func parseBalancerManager(r io.Reader) ([]balancer, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := strings.Split(string(body), balancerStatusPrefix)
+	if len(sections) < 2 {
+		return nil, fmt.Errorf("no '%s' sections found", balancerStatusPrefix)
+	}
+
+	var balancers []balancer
+	for _, section := range sections[1:] {
+		// ' <a href='/balancer-manager?b=app&amp;nonce=...'>balancer://app</a> [p9e2a1b8d_app]</h3>'
+		title, _, _ := strings.Cut(section, "</h3>")
+		name := strings.TrimPrefix(strings.TrimSpace(htmlText(title)), "balancer://")
+		name, _, _ = strings.Cut(name, " ")
+		if name == "" {
+			continue
+		}
+		balancers = append(balancers, balancer{name: name, members: parseBalancerMembers(section)})
+	}
+
+	return balancers, nil
+}
+
+func parseBalancerMembers(section string) []balancerMember {
+	var members []balancerMember
+	var idx map[string]int
+
+	for _, row := range reTableRow.FindAllStringSubmatch(section, -1) {
+		var cells []string
+		for _, cell := range reTableCell.FindAllStringSubmatch(row[1], -1) {
+			cells = append(cells, strings.TrimSpace(htmlText(cell[1])))
+		}
+
+		if idx == nil {
+			if indexOf(cells, "Worker URL") != -1 {
+				idx = make(map[string]int)
+				for _, col := range []string{"Worker URL", "Status", "Elected", "Busy", "To", "From"} {
+					idx[col] = indexOf(cells, col)
+				}
+			}
+			continue
+		}
+
+		get := func(col string) string {
+			if i := idx[col]; i >= 0 && i < len(cells) {
+				return cells[i]
+			}
+			return ""
+		}
+		if get("Worker URL") == "" {
+			continue
+		}
+		members = append(members, balancerMember{
+			url:     get("Worker URL"),
+			status:  get("Status"),
+			elected: parseBalancerInt(get("Elected")),
+			busy:    parseBalancerInt(get("Busy")),
+			to:      parseBalancerSize(get("To")),
+			from:    parseBalancerSize(get("From")),
+		})
+	}
+
+	return members
+}
+
+// balancerMemberState returns the member state based on the status flags ('Init Ok', 'Init Err', 'Init Drn Ok', etc.).
+func balancerMemberState(status string) string {
+	flags := make(map[string]bool)
+	for _, f := range strings.Fields(status) {
+		flags[f] = true
+	}
+	switch {
+	case flags["Err"] || flags["HcFl"]:
+		return "error"
+	case flags["Drn"]:
+		return "drain"
+	case flags["Dis"] || flags["Stop"]:
+		return "disabled"
+	default:
+		return "ok"
+	}
+}
+
+func parseBalancerInt(value string) int64 {
+	v, _ := strconv.ParseInt(value, 10, 64)
+	return v
+}
+
+// parseBalancerSize parses the apr_strfsize() formatted size ('  0 ', '312K', '1.2M', etc.).
+func parseBalancerSize(value string) int64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	mul := 1.0
+	if i := strings.IndexByte("KMGTPE", value[len(value)-1]); i != -1 {
+		value = value[:len(value)-1]
+		for ; i >= 0; i-- {
+			mul *= 1024
+		}
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return int64(v * mul)
+}
+
+func htmlText(s string) string {
+	return reHTMLTag.ReplaceAllString(s, "")
+}
+
+// balancerMemberID converts the member URL to the ID used in the dimension IDs.
+func balancerMemberID(url string) string {
+	return strings.NewReplacer("://", "_", ":", "_", "/", "_").Replace(url)
+}
+
+func (a *Apache) addBalancerCharts(name string) {
+	charts := newBalancerCharts(name)
+	if err := a.charts.Add(*charts...); err != nil {
+		a.Warningf("failed to add balancer '%s' charts: %v", name, err)
+	}
+}
+
+func (a *Apache) removeBalancerCharts(name string) {
+	for _, tmpl := range balancerChartsTmpl {
+		if chart := a.charts.Get(fmt.Sprintf(tmpl.ID, name)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (a *Apache) addBalancerMemberDims(name, id, url string) {
+	for _, v := range balancerMemberDims {
+		chart := a.charts.Get(fmt.Sprintf(v.chartID, name))
+		if chart == nil {
+			continue
+		}
+		dim := &module.Dim{
+			ID:   fmt.Sprintf("balancer_%s_member_%s_%s", name, id, v.metric),
+			Name: url,
+			Algo: v.algo,
+		}
+		if err := chart.AddDim(dim); err != nil {
+			a.Warning(err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
+
+func (a *Apache) removeBalancerMemberDims(name, id string) {
+	for _, v := range balancerMemberDims {
+		chart := a.charts.Get(fmt.Sprintf(v.chartID, name))
+		if chart == nil {
+			continue
+		}
+		dimID := fmt.Sprintf("balancer_%s_member_%s_%s", name, id, v.metric)
+		if err := chart.MarkDimRemove(dimID, true); err != nil {
+			a.Warning(err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
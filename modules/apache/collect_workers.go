@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package apache
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+var (
+	reTableRow  = regexp.MustCompile(`(?s)<tr>(.*?)</tr>`)
+	reTableCell = regexp.MustCompile(`(?s)<t[dh][^>]*>(.*?)</t[dh]>`)
+	reHTMLTag   = regexp.MustCompile(`<[^>]+>`)
+)
+
+// workerProcessingModes are the worker modes ('M' column) in which the worker is processing a request:
+// reading request, sending reply, DNS lookup and logging.
+var workerProcessingModes = map[string]bool{"R": true, "W": true, "D": true, "L": true}
+
+// collectWorkersTable collects the duration of the oldest request being processed. The worker table is available only
+// in the HTML output of mod_status with ExtendedStatus On.
+func (a *Apache) collectWorkersTable(mx map[string]int64) error {
+	oldest, err := a.scrapeOldestRequestDuration()
+	if err != nil {
+		return err
+	}
+
+	mx["oldest_request_duration"] = oldest
+
+	return nil
+}
+
+func (a *Apache) scrapeOldestRequestDuration() (int64, error) {
+	r := a.Request
+	r.URL = strings.TrimSuffix(r.URL, "?auto")
+
+	req, err := web.NewHTTPRequest(r)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error on HTTP request '%s': %v", req.URL, err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("'%s' returned HTTP status code: %d", req.URL, resp.StatusCode)
+	}
+
+	return parseOldestRequestDuration(resp.Body)
+}
+
+// parseOldestRequestDuration returns the max 'SS' (seconds since beginning of most recent request) of the workers
+// that are processing a request.
+func parseOldestRequestDuration(r io.Reader) (int64, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+
+	modeIdx, ssIdx := -1, -1
+	var oldest int64
+
+	for _, row := range reTableRow.FindAllStringSubmatch(string(body), -1) {
+		var cells []string
+		for _, cell := range reTableCell.FindAllStringSubmatch(row[1], -1) {
+			cells = append(cells, strings.TrimSpace(reHTMLTag.ReplaceAllString(cell[1], "")))
+		}
+
+		if modeIdx == -1 {
+			modeIdx, ssIdx = indexOf(cells, "M"), indexOf(cells, "SS")
+			if modeIdx == -1 || ssIdx == -1 {
+				modeIdx, ssIdx = -1, -1
+			}
+			continue
+		}
+		if len(cells) <= modeIdx || len(cells) <= ssIdx {
+			continue
+		}
+		if !workerProcessingModes[cells[modeIdx]] {
+			continue
+		}
+		if v, err := strconv.ParseInt(cells[ssIdx], 10, 64); err == nil && v > oldest {
+			oldest = v
+		}
+	}
+
+	if modeIdx == -1 {
+		return 0, fmt.Errorf("workers table not found (ExtendedStatus is Off?)")
+	}
+
+	return oldest, nil
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -4,6 +4,7 @@ package bind
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,6 +25,7 @@ func init() {
 const (
 	defaultURL         = "http://127.0.0.1:8653/json/v1"
 	defaultHTTPTimeout = time.Second * 2
+	defaultMaxZones    = 100
 )
 
 // New creates Bind with default values.
@@ -37,22 +39,29 @@ func New() *Bind {
 				Timeout: web.Duration{Duration: defaultHTTPTimeout},
 			},
 		},
+		MaxZones: defaultMaxZones,
 	}
 
 	return &Bind{
 		Config: config,
 		charts: &Charts{},
+		zones:  make(map[string]bool),
 	}
 }
 
 type bindAPIClient interface {
 	serverStats() (*serverStats, error)
+	zoneStats() ([]zoneStats, error)
 }
 
 // Config is the Bind module configuration.
 type Config struct {
 	web.HTTP   `yaml:",inline"`
 	PermitView string `yaml:"permit_view"`
+	PermitZone string `yaml:"permit_zone"`
+	MaxZones   int    `yaml:"max_zones"`
+	// Format is the statistics format ('json' or 'xml'), it is detected from the URL or the Content-Type if not set.
+	Format string `yaml:"format"`
 }
 
 // Bind Bind module.
@@ -62,7 +71,12 @@ type Bind struct {
 
 	bindAPIClient
 	permitView matcher.Matcher
+	permitZone matcher.Matcher
 	charts     *Charts
+	// zones are the charted zones, the key is the zone metrics prefix.
+	zones map[string]bool
+	// zonesLimitHit is set when the max_zones limit is reached, it is used to warn only once.
+	zonesLimitHit bool
 }
 
 // Cleanup makes cleanup.
@@ -81,15 +95,21 @@ func (b *Bind) Init() bool {
 		return false
 	}
 
-	switch {
-	case strings.HasSuffix(b.URL, "/xml/v3"): // BIND 9.9+
-		b.bindAPIClient = newXML3Client(client, b.Request)
-	case strings.HasSuffix(b.URL, "/json/v1"): // BIND 9.10+
-		b.bindAPIClient = newJSONClient(client, b.Request)
+	format := b.Format
+	switch format {
+	case formatJSON, formatXML:
+	case "":
+		switch {
+		case strings.HasSuffix(b.URL, "/xml/v3"): // BIND 9.9+
+			format = formatXML
+		case strings.HasSuffix(b.URL, "/json/v1"): // BIND 9.10+
+			format = formatJSON
+		}
 	default:
-		b.Errorf("URL %s is wrong, supported endpoints: `/xml/v3`, `/json/v1`", b.URL)
+		b.Errorf("format '%s' is wrong, supported formats: '%s', '%s'", b.Format, formatJSON, formatXML)
 		return false
 	}
+	b.bindAPIClient = newAPIClient(client, b.Request, format)
 
 	if b.PermitView != "" {
 		m, err := matcher.NewSimplePatternsMatcher(b.PermitView)
@@ -100,6 +120,15 @@ func (b *Bind) Init() bool {
 		b.permitView = matcher.WithCache(m)
 	}
 
+	if b.PermitZone != "" {
+		m, err := matcher.NewSimplePatternsMatcher(b.PermitZone)
+		if err != nil {
+			b.Errorf("error on creating permitZone matcher : %v", err)
+			return false
+		}
+		b.permitZone = matcher.WithCache(m)
+	}
+
 	return true
 }
 
@@ -124,6 +153,15 @@ func (b *Bind) Collect() map[string]int64 {
 	}
 	b.collectServerStats(metrics, s)
 
+	if b.permitZone != nil {
+		zones, err := b.zoneStats()
+		if err != nil {
+			b.Error(err)
+		} else {
+			b.collectZoneStats(metrics, zones)
+		}
+	}
+
 	return metrics
 }
 
@@ -239,6 +277,9 @@ func (b *Bind) collectServerStats(metrics map[string]int64, stats *serverStats)
 				chartKey = keyResolverNumFetch
 				dimName = "queries"
 				algo = module.Absolute
+			case key == "Prefetch":
+				chartKey = keyResolverPrefetch
+				dimName = "prefetches"
 			case strings.HasPrefix(key, "QryRTT"):
 				// TODO: not ordered
 				chartKey = keyResolverRTT
@@ -302,5 +343,149 @@ func (b *Bind) collectServerStats(metrics map[string]int64, stats *serverStats)
 			metrics[name+"_CacheHits"] = r.CacheStats["CacheHits"]
 			metrics[name+"_CacheMisses"] = r.CacheStats["CacheMisses"]
 		}
+
+		if len(r.Cache) > 0 {
+			chartID := fmt.Sprintf(keyResolverCacheRRsets, name)
+
+			if !b.charts.Has(chartID) {
+				chart = charts[keyResolverCacheRRsets].Copy()
+				chart.ID = chartID
+				chart.Fam = fmt.Sprintf(chart.Fam, name)
+				_ = b.charts.Add(chart)
+			}
+
+			chart = b.charts.Get(chartID)
+
+			for key, val := range r.Cache {
+				dimID := fmt.Sprintf("%s_cache_%s", name, key)
+				if !chart.HasDim(dimID) {
+					_ = chart.AddDim(&Dim{ID: dimID, Name: key})
+					chart.MarkNotCreated()
+				}
+				metrics[dimID] = val
+			}
+		}
+	}
+}
+
+// zoneCounters are the charted zone counters, the missing counters are reported as zero.
+var zoneCounters = []string{
+	"QrySuccess", "QryReferral", "QryNxrrset", "QryNXDOMAIN",
+	"QrySERVFAIL", "QryFORMERR", "QryFailure",
+	"XfrReqDone", "XfrRej",
+}
+
+var zoneIDReplacer = strings.NewReplacer(".", "_", " ", "_")
+
+func (b *Bind) collectZoneStats(metrics map[string]int64, zones []zoneStats) {
+	seen := make(map[string]bool)
+	views := make(map[string]bool)
+	viewMetrics := make(map[string]int64)
+
+	// the order matters when the max_zones limit is reached
+	sort.Slice(zones, func(i, j int) bool {
+		if zones[i].View != zones[j].View {
+			return zones[i].View < zones[j].View
+		}
+		return zones[i].Name < zones[j].Name
+	})
+
+	var permitted []zoneStats
+	for _, zone := range zones {
+		if b.permitZone.MatchString(zone.Name) {
+			permitted = append(permitted, zone)
+			seen[zonePrefix(zone)] = true
+		}
+	}
+
+	// the gone zones are removed first to free up space for the new ones
+	for prefix := range b.zones {
+		if !seen[prefix] {
+			delete(b.zones, prefix)
+			b.removeZoneCharts(prefix)
+		}
+	}
+
+	for _, zone := range permitted {
+		views[zone.View] = true
+
+		// the view totals include all the permitted zones, regardless of the max_zones limit
+		viewPrefix := "view_" + zone.View + "_zones_"
+		for _, name := range zoneCounters {
+			viewMetrics[viewPrefix+name] += zone.Counters[name]
+		}
+
+		prefix := zonePrefix(zone)
+		if !b.zones[prefix] {
+			if b.MaxZones > 0 && len(b.zones) >= b.MaxZones {
+				if !b.zonesLimitHit {
+					b.zonesLimitHit = true
+					b.Warningf("zones limit (%d) is reached, new zones are not collected", b.MaxZones)
+				}
+				continue
+			}
+			b.zones[prefix] = true
+			b.addZoneCharts(zone.View, zone.Name, prefix)
+		}
+
+		for _, name := range zoneCounters {
+			metrics[prefix+name] = zone.Counters[name]
+		}
+	}
+
+	// the per view aggregation makes sense only when there are multiple views
+	if len(views) < 2 {
+		return
+	}
+	for k, v := range viewMetrics {
+		metrics[k] = v
+	}
+	for view := range views {
+		for _, key := range []string{keyViewZoneQueries, keyViewZoneQueryFailures} {
+			chartID := fmt.Sprintf(key, zoneIDReplacer.Replace(view))
+			if b.charts.Has(chartID) {
+				continue
+			}
+			chart := charts[key].Copy()
+			chart.ID = chartID
+			chart.Fam = fmt.Sprintf(chart.Fam, view)
+			for _, dim := range chart.Dims {
+				dim.ID = fmt.Sprintf(dim.ID, "view_"+view+"_zones_")
+			}
+			_ = b.charts.Add(chart)
+		}
+	}
+}
+
+func zonePrefix(zone zoneStats) string {
+	return "zone_" + zone.View + "_" + zone.Name + "_"
+}
+
+func (b *Bind) addZoneCharts(view, zone, prefix string) {
+	id := zoneIDReplacer.Replace(view + "_" + zone)
+
+	for _, key := range []string{keyZoneQueries, keyZoneQueryFailures, keyZoneTransfers} {
+		chart := charts[key].Copy()
+		chart.ID = fmt.Sprintf(key, id)
+		chart.Fam = fmt.Sprintf(chart.Fam, zone)
+		chart.Labels = []module.Label{
+			{Key: "view", Value: view},
+			{Key: "zone", Value: zone},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, prefix)
+		}
+		_ = b.charts.Add(chart)
+	}
+}
+
+func (b *Bind) removeZoneCharts(prefix string) {
+	id := zoneIDReplacer.Replace(strings.TrimSuffix(strings.TrimPrefix(prefix, "zone_"), "_"))
+
+	for _, key := range []string{keyZoneQueries, keyZoneQueryFailures, keyZoneTransfers} {
+		if chart := b.charts.Get(fmt.Sprintf(key, id)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
 	}
 }
@@ -34,11 +34,19 @@ const (
 	keyInQTypes            = "in_qtypes"
 	keyInSockStats         = "in_sockstats"
 
-	keyResolverStats     = "view_resolver_stats_%s"
-	keyResolverRTT       = "view_resolver_rtt_%s"
-	keyResolverInQTypes  = "view_resolver_qtypes_%s"
-	keyResolverCacheHits = "view_resolver_cachehits_%s"
-	keyResolverNumFetch  = "view_resolver_numfetch_%s"
+	keyResolverStats       = "view_resolver_stats_%s"
+	keyResolverRTT         = "view_resolver_rtt_%s"
+	keyResolverInQTypes    = "view_resolver_qtypes_%s"
+	keyResolverCacheHits   = "view_resolver_cachehits_%s"
+	keyResolverNumFetch    = "view_resolver_numfetch_%s"
+	keyResolverPrefetch    = "view_resolver_prefetch_%s"
+	keyResolverCacheRRsets = "view_resolver_cache_rrsets_%s"
+
+	keyViewZoneQueries       = "view_zone_queries_%s"
+	keyViewZoneQueryFailures = "view_zone_query_failures_%s"
+	keyZoneQueries           = "zone_queries_%s"
+	keyZoneQueryFailures     = "zone_query_failures_%s"
+	keyZoneTransfers         = "zone_transfers_%s"
 )
 
 var charts = map[string]Chart{
@@ -193,4 +201,93 @@ var charts = map[string]Chart{
 			{ID: "%s_CacheMisses", Name: "misses", Algo: module.Incremental, Mul: -1},
 		},
 	},
+	keyResolverPrefetch: {
+		ID:       keyResolverPrefetch,
+		Title:    "Resolver Prefetches",
+		Units:    "prefetches/s",
+		Fam:      "view %s",
+		Ctx:      "bind.resolver_prefetches",
+		Priority: basePriority + 29,
+	},
+	keyResolverCacheRRsets: {
+		ID:       keyResolverCacheRRsets,
+		Title:    "Resolver Cache RRsets by Type",
+		Units:    "rrsets",
+		Fam:      "view %s",
+		Ctx:      "bind.resolver_cache_rrsets",
+		Type:     module.Stacked,
+		Priority: basePriority + 29,
+	},
+
+	keyViewZoneQueries: {
+		ID:       keyViewZoneQueries,
+		Title:    "View Zones Queries",
+		Units:    "queries/s",
+		Fam:      "view %s",
+		Ctx:      "bind.view_zone_queries",
+		Type:     module.Stacked,
+		Priority: basePriority + 27,
+		Dims: Dims{
+			{ID: "%sQrySuccess", Name: "success", Algo: module.Incremental},
+			{ID: "%sQryReferral", Name: "referral", Algo: module.Incremental},
+			{ID: "%sQryNxrrset", Name: "nxrrset", Algo: module.Incremental},
+			{ID: "%sQryNXDOMAIN", Name: "nxdomain", Algo: module.Incremental},
+		},
+	},
+	keyViewZoneQueryFailures: {
+		ID:       keyViewZoneQueryFailures,
+		Title:    "View Zones Query Failures",
+		Units:    "failures/s",
+		Fam:      "view %s",
+		Ctx:      "bind.view_zone_query_failures",
+		Type:     module.Stacked,
+		Priority: basePriority + 28,
+		Dims: Dims{
+			{ID: "%sQrySERVFAIL", Name: "servfail", Algo: module.Incremental},
+			{ID: "%sQryFORMERR", Name: "formerr", Algo: module.Incremental},
+			{ID: "%sQryFailure", Name: "failure", Algo: module.Incremental},
+		},
+	},
+
+	keyZoneQueries: {
+		ID:       keyZoneQueries,
+		Title:    "Zone Queries",
+		Units:    "queries/s",
+		Fam:      "zone %s",
+		Ctx:      "bind.zone_queries",
+		Type:     module.Stacked,
+		Priority: basePriority + 30,
+		Dims: Dims{
+			{ID: "%sQrySuccess", Name: "success", Algo: module.Incremental},
+			{ID: "%sQryReferral", Name: "referral", Algo: module.Incremental},
+			{ID: "%sQryNxrrset", Name: "nxrrset", Algo: module.Incremental},
+			{ID: "%sQryNXDOMAIN", Name: "nxdomain", Algo: module.Incremental},
+		},
+	},
+	keyZoneQueryFailures: {
+		ID:       keyZoneQueryFailures,
+		Title:    "Zone Query Failures",
+		Units:    "failures/s",
+		Fam:      "zone %s",
+		Ctx:      "bind.zone_query_failures",
+		Type:     module.Stacked,
+		Priority: basePriority + 31,
+		Dims: Dims{
+			{ID: "%sQrySERVFAIL", Name: "servfail", Algo: module.Incremental},
+			{ID: "%sQryFORMERR", Name: "formerr", Algo: module.Incremental},
+			{ID: "%sQryFailure", Name: "failure", Algo: module.Incremental},
+		},
+	},
+	keyZoneTransfers: {
+		ID:       keyZoneTransfers,
+		Title:    "Zone Transfer Requests",
+		Units:    "requests/s",
+		Fam:      "zone %s",
+		Ctx:      "bind.zone_transfers",
+		Priority: basePriority + 32,
+		Dims: Dims{
+			{ID: "%sXfrReqDone", Name: "done", Algo: module.Incremental},
+			{ID: "%sXfrRej", Name: "rejected", Algo: module.Incremental},
+		},
+	},
 }
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package bind
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+const (
+	formatJSON = "json"
+	formatXML  = "xml"
+)
+
+func newAPIClient(client *http.Client, request web.Request, format string) *apiClient {
+	return &apiClient{httpClient: client, request: request, format: format}
+}
+
+// apiClient is the statistics channel client. The response format is either set or detected from the Content-Type.
+type apiClient struct {
+	httpClient *http.Client
+	request    web.Request
+	format     string
+}
+
+func (c apiClient) serverStats() (*serverStats, error) {
+	var stats *serverStats
+	err := c.doOKDecode("/server", func(format string, body io.Reader) (err error) {
+		if format == formatXML {
+			stats, err = decodeXML3ServerStats(body)
+		} else {
+			stats, err = decodeJSONServerStats(body)
+		}
+		return err
+	})
+	return stats, err
+}
+
+func (c apiClient) zoneStats() ([]zoneStats, error) {
+	var zones []zoneStats
+	err := c.doOKDecode("/zones", func(format string, body io.Reader) (err error) {
+		if format == formatXML {
+			zones, err = decodeXML3ZoneStats(body)
+		} else {
+			zones, err = decodeJSONZoneStats(body)
+		}
+		return err
+	})
+	return zones, err
+}
+
+func (c apiClient) doOKDecode(urlPath string, decode func(format string, body io.Reader) error) error {
+	req := c.request.Copy()
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return fmt.Errorf("error on parsing URL: %v", err)
+	}
+
+	u.Path = path.Join(u.Path, urlPath)
+	req.URL = u.String()
+
+	httpReq, err := web.NewHTTPRequest(req)
+	if err != nil {
+		return fmt.Errorf("error on creating HTTP request: %v", err)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("error on request : %v", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned HTTP status %d", httpReq.URL, resp.StatusCode)
+	}
+
+	format := c.format
+	if format == "" {
+		if format = formatFromContentType(resp.Header.Get("Content-Type")); format == "" {
+			return fmt.Errorf("%s returned unsupported Content-Type '%s'", httpReq.URL, resp.Header.Get("Content-Type"))
+		}
+	}
+
+	if err = decode(format, resp.Body); err != nil {
+		return fmt.Errorf("error on decoding response from %s : %v", httpReq.URL, err)
+	}
+	return nil
+}
+
+func formatFromContentType(contentType string) string {
+	switch {
+	case strings.Contains(contentType, "json"):
+		return formatJSON
+	case strings.Contains(contentType, "xml"):
+		return formatXML
+	}
+	return ""
+}
@@ -4,13 +4,8 @@ package bind
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
-	"net/url"
-	"path"
-
-	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
 type serverStats = jsonServerStats
@@ -27,51 +22,58 @@ type jsonView struct {
 	Resolver jsonViewResolver
 }
 
-type jsonViewResolver struct {
-	Stats      map[string]int64
-	QTypes     map[string]int64
-	CacheStats map[string]int64
+type jsonZonesStats struct {
+	Views map[string]struct {
+		Zones []jsonZone
+	}
 }
 
-func newJSONClient(client *http.Client, request web.Request) *jsonClient {
-	return &jsonClient{httpClient: client, request: request}
+type jsonZone struct {
+	Name   string
+	Class  string
+	RCodes map[string]int64
 }
 
-type jsonClient struct {
-	httpClient *http.Client
-	request    web.Request
+// zoneStats is the zone statistics ('zone-statistics' must be enabled for the zone),
+// the counters are the name server statistics counters of the zone.
+type zoneStats struct {
+	View     string
+	Name     string
+	Counters map[string]int64
 }
 
-func (c jsonClient) serverStats() (*serverStats, error) {
-	req := c.request.Copy()
-	u, err := url.Parse(req.URL)
-	if err != nil {
-		return nil, fmt.Errorf("error on parsing URL: %v", err)
-	}
-
-	u.Path = path.Join(u.Path, "/server")
-	req.URL = u.String()
-
-	httpReq, err := web.NewHTTPRequest(req)
-	if err != nil {
-		return nil, fmt.Errorf("error on creating HTTP request: %v", err)
-	}
+type jsonViewResolver struct {
+	Stats      map[string]int64
+	QTypes     map[string]int64
+	CacheStats map[string]int64
+	// Cache is the number of the cached RRsets by type.
+	Cache map[string]int64
+}
 
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("error on request : %v", err)
+func decodeJSONServerStats(r io.Reader) (*serverStats, error) {
+	stats := &jsonServerStats{}
+	if err := json.NewDecoder(r).Decode(stats); err != nil {
+		return nil, err
 	}
-	defer closeBody(resp)
+	return stats, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%s returned HTTP status %d", httpReq.URL, resp.StatusCode)
+func decodeJSONZoneStats(r io.Reader) ([]zoneStats, error) {
+	var stats jsonZonesStats
+	if err := json.NewDecoder(r).Decode(&stats); err != nil {
+		return nil, err
 	}
 
-	stats := &jsonServerStats{}
-	if err = json.NewDecoder(resp.Body).Decode(stats); err != nil {
-		return nil, fmt.Errorf("error on decoding response from %s : %v", httpReq.URL, err)
+	var zones []zoneStats
+	for viewName, view := range stats.Views {
+		for _, zone := range view.Zones {
+			if zone.Class != "" && zone.Class != "IN" {
+				continue
+			}
+			zones = append(zones, zoneStats{View: viewName, Name: zone.Name, Counters: zone.RCodes})
+		}
 	}
-	return stats, nil
+	return zones, nil
 }
 
 func closeBody(resp *http.Response) {
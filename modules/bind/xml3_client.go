@@ -4,12 +4,7 @@ package bind
 
 import (
 	"encoding/xml"
-	"fmt"
-	"net/http"
-	"net/url"
-	"path"
-
-	"github.com/netdata/go.d.plugin/pkg/web"
+	"io"
 )
 
 type xml3Stats struct {
@@ -32,47 +27,58 @@ type xml3CounterGroup struct {
 type xml3View struct {
 	Name          string             `xml:"name,attr"`
 	CounterGroups []xml3CounterGroup `xml:"counters"`
+	Cache         []struct {
+		Name    string `xml:"name"`
+		Counter int64  `xml:"counter"`
+	} `xml:"cache>rrset"`
 }
 
-func newXML3Client(client *http.Client, request web.Request) *xml3Client {
-	return &xml3Client{httpClient: client, request: request}
+type xml3ZonesStats struct {
+	Views []struct {
+		Name  string     `xml:"name,attr"`
+		Zones []xml3Zone `xml:"zones>zone"`
+	} `xml:"views>view"`
 }
 
-type xml3Client struct {
-	httpClient *http.Client
-	request    web.Request
+type xml3Zone struct {
+	Name          string             `xml:"name,attr"`
+	Class         string             `xml:"rdataclass,attr"`
+	CounterGroups []xml3CounterGroup `xml:"counters"`
 }
 
-func (c xml3Client) serverStats() (*serverStats, error) {
-	req := c.request.Copy()
-	u, err := url.Parse(req.URL)
-	if err != nil {
-		return nil, fmt.Errorf("error on parsing URL: %v", err)
-	}
-
-	u.Path = path.Join(u.Path, "/server")
-	req.URL = u.String()
-
-	httpReq, err := web.NewHTTPRequest(req)
-	if err != nil {
-		return nil, fmt.Errorf("error on creating HTTP request: %v", err)
-	}
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("error on request : %v", err)
+func decodeXML3ServerStats(r io.Reader) (*serverStats, error) {
+	stats := xml3Stats{}
+	if err := xml.NewDecoder(r).Decode(&stats); err != nil {
+		return nil, err
 	}
-	defer closeBody(resp)
+	return convertXML(stats), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("%s returned HTTP status %d", httpReq.URL, resp.StatusCode)
+func decodeXML3ZoneStats(r io.Reader) ([]zoneStats, error) {
+	stats := xml3ZonesStats{}
+	if err := xml.NewDecoder(r).Decode(&stats); err != nil {
+		return nil, err
 	}
 
-	stats := xml3Stats{}
-	if err = xml.NewDecoder(resp.Body).Decode(&stats); err != nil {
-		return nil, fmt.Errorf("error on decoding response from %s : %v", httpReq.URL, err)
+	var zones []zoneStats
+	for _, view := range stats.Views {
+		for _, zone := range view.Zones {
+			if zone.Class != "" && zone.Class != "IN" {
+				continue
+			}
+			z := zoneStats{View: view.Name, Name: zone.Name, Counters: make(map[string]int64)}
+			for _, group := range zone.CounterGroups {
+				if group.Type != "rcode" {
+					continue
+				}
+				for _, v := range group.Counters {
+					z.Counters[v.Name] = v.Value
+				}
+			}
+			zones = append(zones, z)
+		}
 	}
-	return convertXML(stats), nil
+	return zones, nil
 }
 
 func convertXML(xmlStats xml3Stats) *serverStats {
@@ -111,8 +117,12 @@ func convertXML(xmlStats xml3Stats) *serverStats {
 				Stats:      make(map[string]int64),
 				QTypes:     make(map[string]int64),
 				CacheStats: make(map[string]int64),
+				Cache:      make(map[string]int64),
 			},
 		}
+		for _, rrset := range view.Cache {
+			stats.Views[view.Name].Resolver.Cache[rrset.Name] = rrset.Counter
+		}
 		for _, viewGroup := range view.CounterGroups {
 			switch viewGroup.Type {
 			default:
@@ -48,6 +48,7 @@ type Cassandra struct {
 	charts *module.Charts
 
 	prom prometheus.Prometheus
+	pms  prometheus.Series
 
 	validateMetrics bool
 	mx              *cassandraMetrics
@@ -4,8 +4,12 @@ package cassandra
 
 import (
 	"errors"
-	"github.com/netdata/go.d.plugin/pkg/prometheus"
 	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/prometheus"
+	"github.com/netdata/go.d.plugin/pkg/stm"
+
+	"github.com/prometheus/prometheus/model/labels"
 )
 
 const (
@@ -14,7 +18,7 @@ const (
 )
 
 func (c *Cassandra) collect() (map[string]int64, error) {
-	pms, err := c.prom.ScrapeSeries()
+	pms, err := c.scrapeSeries()
 	if err != nil {
 		return nil, err
 	}
@@ -26,10 +30,10 @@ func (c *Cassandra) collect() (map[string]int64, error) {
 		c.validateMetrics = false
 	}
 
-	mx := make(map[string]int64)
-
 	c.resetMetrics()
 	c.collectMetrics(pms)
+
+	mx := stm.ToMap(c.mx)
 	c.processMetric(mx)
 
 	return mx, nil
@@ -37,8 +41,8 @@ func (c *Cassandra) collect() (map[string]int64, error) {
 
 func (c *Cassandra) resetMetrics() {
 	cm := newCassandraMetrics()
-	for key, p := range c.mx.threadPools {
-		cm.threadPools[key] = &threadPoolMetrics{
+	for key, p := range c.mx.ThreadPools {
+		cm.ThreadPools[key] = &threadPoolMetrics{
 			name:      p.name,
 			hasCharts: p.hasCharts,
 		}
@@ -47,93 +51,41 @@ func (c *Cassandra) resetMetrics() {
 }
 
 func (c *Cassandra) processMetric(mx map[string]int64) {
-	c.mx.clientReqTotalLatencyReads.write(mx, "client_request_total_latency_reads")
-	c.mx.clientReqTotalLatencyWrites.write(mx, "client_request_total_latency_writes")
-	c.mx.clientReqLatencyReads.write(mx, "client_request_latency_reads")
-	c.mx.clientReqLatencyWrites.write(mx, "client_request_latency_writes")
-	c.mx.clientReqTimeoutsReads.write(mx, "client_request_timeouts_reads")
-	c.mx.clientReqTimeoutsWrites.write(mx, "client_request_timeouts_writes")
-	c.mx.clientReqUnavailablesReads.write(mx, "client_request_unavailables_reads")
-	c.mx.clientReqUnavailablesWrites.write(mx, "client_request_unavailables_writes")
-	c.mx.clientReqFailuresReads.write(mx, "client_request_failures_reads")
-	c.mx.clientReqFailuresWrites.write(mx, "client_request_failures_writes")
-
-	c.mx.clientReqReadLatencyP50.write(mx, "client_request_read_latency_p50")
-	c.mx.clientReqReadLatencyP75.write(mx, "client_request_read_latency_p75")
-	c.mx.clientReqReadLatencyP95.write(mx, "client_request_read_latency_p95")
-	c.mx.clientReqReadLatencyP98.write(mx, "client_request_read_latency_p98")
-	c.mx.clientReqReadLatencyP99.write(mx, "client_request_read_latency_p99")
-	c.mx.clientReqReadLatencyP999.write(mx, "client_request_read_latency_p999")
-	c.mx.clientReqWriteLatencyP50.write(mx, "client_request_write_latency_p50")
-	c.mx.clientReqWriteLatencyP75.write(mx, "client_request_write_latency_p75")
-	c.mx.clientReqWriteLatencyP95.write(mx, "client_request_write_latency_p95")
-	c.mx.clientReqWriteLatencyP98.write(mx, "client_request_write_latency_p98")
-	c.mx.clientReqWriteLatencyP99.write(mx, "client_request_write_latency_p99")
-	c.mx.clientReqWriteLatencyP999.write(mx, "client_request_write_latency_p999")
-
-	c.mx.rowCacheHits.write(mx, "row_cache_hits")
-	c.mx.rowCacheMisses.write(mx, "row_cache_misses")
-	c.mx.rowCacheSize.write(mx, "row_cache_size")
-	if c.mx.rowCacheHits.isSet && c.mx.rowCacheMisses.isSet {
-		if s := c.mx.rowCacheHits.value + c.mx.rowCacheMisses.value; s > 0 {
-			mx["row_cache_hit_ratio"] = int64((c.mx.rowCacheHits.value * 100 / s) * 1000)
+	if c.mx.RowCacheHits.isSet && c.mx.RowCacheMisses.isSet {
+		if s := c.mx.RowCacheHits.value + c.mx.RowCacheMisses.value; s > 0 {
+			mx["row_cache_hit_ratio"] = int64((c.mx.RowCacheHits.value * 100 / s) * 1000)
 		} else {
 			mx["row_cache_hit_ratio"] = 0
 		}
 	}
-	if c.mx.rowCacheCapacity.isSet && c.mx.rowCacheSize.isSet {
-		if s := c.mx.rowCacheCapacity.value; s > 0 {
-			mx["row_cache_utilization"] = int64((c.mx.rowCacheSize.value * 100 / s) * 1000)
+	if c.mx.RowCacheCapacity.isSet && c.mx.RowCacheSize.isSet {
+		if s := c.mx.RowCacheCapacity.value; s > 0 {
+			mx["row_cache_utilization"] = int64((c.mx.RowCacheSize.value * 100 / s) * 1000)
 		} else {
 			mx["row_cache_utilization"] = 0
 		}
 	}
 
-	c.mx.keyCacheHits.write(mx, "key_cache_hits")
-	c.mx.keyCacheMisses.write(mx, "key_cache_misses")
-	c.mx.keyCacheSize.write(mx, "key_cache_size")
-	if c.mx.keyCacheHits.isSet && c.mx.keyCacheMisses.isSet {
-		if s := c.mx.keyCacheHits.value + c.mx.keyCacheMisses.value; s > 0 {
-			mx["key_cache_hit_ratio"] = int64((c.mx.keyCacheHits.value * 100 / s) * 1000)
+	if c.mx.KeyCacheHits.isSet && c.mx.KeyCacheMisses.isSet {
+		if s := c.mx.KeyCacheHits.value + c.mx.KeyCacheMisses.value; s > 0 {
+			mx["key_cache_hit_ratio"] = int64((c.mx.KeyCacheHits.value * 100 / s) * 1000)
 		} else {
 			mx["key_cache_hit_ratio"] = 0
 		}
 	}
-	if c.mx.keyCacheCapacity.isSet && c.mx.keyCacheSize.isSet {
-		if s := c.mx.keyCacheCapacity.value; s > 0 {
-			mx["key_cache_utilization"] = int64((c.mx.keyCacheSize.value * 100 / s) * 1000)
+	if c.mx.KeyCacheCapacity.isSet && c.mx.KeyCacheSize.isSet {
+		if s := c.mx.KeyCacheCapacity.value; s > 0 {
+			mx["key_cache_utilization"] = int64((c.mx.KeyCacheSize.value * 100 / s) * 1000)
 		} else {
 			mx["key_cache_utilization"] = 0
 		}
 	}
 
-	c.mx.droppedMessages.write1k(mx, "dropped_messages")
-
-	c.mx.storageLoad.write(mx, "storage_load")
-	c.mx.storageExceptions.write(mx, "storage_exceptions")
-
-	c.mx.compactionBytesCompacted.write(mx, "compaction_bytes_compacted")
-	c.mx.compactionPendingTasks.write(mx, "compaction_pending_tasks")
-	c.mx.compactionCompletedTasks.write(mx, "compaction_completed_tasks")
-
-	c.mx.jvmMemoryHeapUsed.write(mx, "jvm_memory_heap_used")
-	c.mx.jvmMemoryNonHeapUsed.write(mx, "jvm_memory_nonheap_used")
-	c.mx.jvmGCParNewCount.write(mx, "jvm_gc_parnew_count")
-	c.mx.jvmGCParNewTime.write1k(mx, "jvm_gc_parnew_time")
-	c.mx.jvmGCCMSCount.write(mx, "jvm_gc_cms_count")
-	c.mx.jvmGCCMSTime.write1k(mx, "jvm_gc_cms_time")
-
-	for _, p := range c.mx.threadPools {
+	for _, p := range c.mx.ThreadPools {
 		if !p.hasCharts {
 			p.hasCharts = true
 			c.addThreadPoolCharts(p)
 		}
-
-		px := "thread_pool_" + p.name + "_"
-		p.activeTasks.write(mx, px+"active_tasks")
-		p.pendingTasks.write(mx, px+"pending_tasks")
-		p.blockedTasks.write(mx, px+"blocked_tasks")
-		p.totalBlockedTasks.write(mx, px+"total_blocked_tasks")
 	}
 }
 
@@ -157,15 +109,15 @@ func (c *Cassandra) collectClientRequestMetrics(pms prometheus.Series) {
 
 		switch name {
 		case "TotalLatency":
-			rw.read, rw.write = &c.mx.clientReqTotalLatencyReads, &c.mx.clientReqTotalLatencyWrites
+			rw.read, rw.write = &c.mx.ClientReqTotalLatencyReads, &c.mx.ClientReqTotalLatencyWrites
 		case "Latency":
-			rw.read, rw.write = &c.mx.clientReqLatencyReads, &c.mx.clientReqLatencyWrites
+			rw.read, rw.write = &c.mx.ClientReqLatencyReads, &c.mx.ClientReqLatencyWrites
 		case "Timeouts":
-			rw.read, rw.write = &c.mx.clientReqTimeoutsReads, &c.mx.clientReqTimeoutsWrites
+			rw.read, rw.write = &c.mx.ClientReqTimeoutsReads, &c.mx.ClientReqTimeoutsWrites
 		case "Unavailables":
-			rw.read, rw.write = &c.mx.clientReqUnavailablesReads, &c.mx.clientReqUnavailablesWrites
+			rw.read, rw.write = &c.mx.ClientReqUnavailablesReads, &c.mx.ClientReqUnavailablesWrites
 		case "Failures":
-			rw.read, rw.write = &c.mx.clientReqFailuresReads, &c.mx.clientReqFailuresWrites
+			rw.read, rw.write = &c.mx.ClientReqFailuresReads, &c.mx.ClientReqFailuresWrites
 		default:
 			continue
 		}
@@ -178,45 +130,29 @@ func (c *Cassandra) collectClientRequestMetrics(pms prometheus.Series) {
 		}
 	}
 
-	rw = struct{ read, write *metricValue }{}
-
-	for _, pm := range pms.FindByNames(
-		metric+"_50thpercentile",
-		metric+"_75thpercentile",
-		metric+"_95thpercentile",
-		metric+"_98thpercentile",
-		metric+"_99thpercentile",
-		metric+"_999thpercentile",
-	) {
-		name := pm.Labels.Get("name")
-		scope := pm.Labels.Get("scope")
-
-		if name != "Latency" {
+	for _, m := range percentileSummaries(pms, metric) {
+		if m.Labels().Get("name") != "Latency" {
 			continue
 		}
 
-		switch {
-		case strings.HasSuffix(pm.Name(), "_50thpercentile"):
-			rw.read, rw.write = &c.mx.clientReqReadLatencyP50, &c.mx.clientReqWriteLatencyP50
-		case strings.HasSuffix(pm.Name(), "_75thpercentile"):
-			rw.read, rw.write = &c.mx.clientReqReadLatencyP75, &c.mx.clientReqWriteLatencyP75
-		case strings.HasSuffix(pm.Name(), "_95thpercentile"):
-			rw.read, rw.write = &c.mx.clientReqReadLatencyP95, &c.mx.clientReqWriteLatencyP95
-		case strings.HasSuffix(pm.Name(), "_98thpercentile"):
-			rw.read, rw.write = &c.mx.clientReqReadLatencyP98, &c.mx.clientReqWriteLatencyP98
-		case strings.HasSuffix(pm.Name(), "_99thpercentile"):
-			rw.read, rw.write = &c.mx.clientReqReadLatencyP99, &c.mx.clientReqWriteLatencyP99
-		case strings.HasSuffix(pm.Name(), "_999thpercentile"):
-			rw.read, rw.write = &c.mx.clientReqReadLatencyP999, &c.mx.clientReqWriteLatencyP999
+		var ps struct{ p50, p75, p95, p98, p99, p999 *metricValue }
+		switch m.Labels().Get("scope") {
+		case "Read":
+			ps.p50, ps.p75, ps.p95 = &c.mx.ClientReqReadLatencyP50, &c.mx.ClientReqReadLatencyP75, &c.mx.ClientReqReadLatencyP95
+			ps.p98, ps.p99, ps.p999 = &c.mx.ClientReqReadLatencyP98, &c.mx.ClientReqReadLatencyP99, &c.mx.ClientReqReadLatencyP999
+		case "Write":
+			ps.p50, ps.p75, ps.p95 = &c.mx.ClientReqWriteLatencyP50, &c.mx.ClientReqWriteLatencyP75, &c.mx.ClientReqWriteLatencyP95
+			ps.p98, ps.p99, ps.p999 = &c.mx.ClientReqWriteLatencyP98, &c.mx.ClientReqWriteLatencyP99, &c.mx.ClientReqWriteLatencyP999
 		default:
 			continue
 		}
 
-		switch scope {
-		case "Read":
-			rw.read.add(pm.Value)
-		case "Write":
-			rw.write.add(pm.Value)
+		for q, mv := range map[float64]*metricValue{
+			0.5: ps.p50, 0.75: ps.p75, 0.95: ps.p95, 0.98: ps.p98, 0.99: ps.p99, 0.999: ps.p999,
+		} {
+			if v, ok := m.Summary().Quantile(q); ok {
+				mv.add(v)
+			}
 		}
 	}
 }
@@ -231,9 +167,9 @@ func (c *Cassandra) collectCacheMetrics(pms prometheus.Series) {
 
 		switch scope {
 		case "KeyCache":
-			hm.hits, hm.misses = &c.mx.keyCacheHits, &c.mx.keyCacheMisses
+			hm.hits, hm.misses = &c.mx.KeyCacheHits, &c.mx.KeyCacheMisses
 		case "RowCache":
-			hm.hits, hm.misses = &c.mx.rowCacheHits, &c.mx.rowCacheMisses
+			hm.hits, hm.misses = &c.mx.RowCacheHits, &c.mx.RowCacheMisses
 		default:
 			continue
 		}
@@ -253,9 +189,9 @@ func (c *Cassandra) collectCacheMetrics(pms prometheus.Series) {
 
 		switch scope {
 		case "KeyCache":
-			cs.cap, cs.size = &c.mx.keyCacheCapacity, &c.mx.keyCacheSize
+			cs.cap, cs.size = &c.mx.KeyCacheCapacity, &c.mx.KeyCacheSize
 		case "RowCache":
-			cs.cap, cs.size = &c.mx.rowCacheCapacity, &c.mx.rowCacheSize
+			cs.cap, cs.size = &c.mx.RowCacheCapacity, &c.mx.RowCacheSize
 		default:
 			continue
 		}
@@ -279,9 +215,9 @@ func (c *Cassandra) collectThreadPoolsMetrics(pms prometheus.Series) {
 
 		switch name {
 		case "ActiveTasks":
-			pool.activeTasks.add(pm.Value)
+			pool.ActiveTasks.add(pm.Value)
 		case "PendingTasks":
-			pool.pendingTasks.add(pm.Value)
+			pool.PendingTasks.add(pm.Value)
 		}
 	}
 	for _, pm := range pms.FindByName(metric + suffixCount) {
@@ -291,11 +227,11 @@ func (c *Cassandra) collectThreadPoolsMetrics(pms prometheus.Series) {
 
 		switch name {
 		case "CompletedTasks":
-			pool.totalBlockedTasks.add(pm.Value)
+			pool.TotalBlockedTasks.add(pm.Value)
 		case "TotalBlockedTasks":
-			pool.totalBlockedTasks.add(pm.Value)
+			pool.TotalBlockedTasks.add(pm.Value)
 		case "CurrentlyBlockedTasks":
-			pool.blockedTasks.add(pm.Value)
+			pool.BlockedTasks.add(pm.Value)
 		}
 	}
 }
@@ -308,9 +244,9 @@ func (c *Cassandra) collectStorageMetrics(pms prometheus.Series) {
 
 		switch name {
 		case "Load":
-			c.mx.storageLoad.add(pm.Value)
+			c.mx.StorageLoad.add(pm.Value)
 		case "Exceptions":
-			c.mx.storageExceptions.add(pm.Value)
+			c.mx.StorageExceptions.add(pm.Value)
 		}
 	}
 }
@@ -319,7 +255,7 @@ func (c *Cassandra) collectDroppedMessagesMetrics(pms prometheus.Series) {
 	const metric = "org_apache_cassandra_metrics_droppedmessage"
 
 	for _, pm := range pms.FindByName(metric + suffixCount) {
-		c.mx.droppedMessages.add(pm.Value)
+		c.mx.DroppedMessages.add(pm.Value)
 	}
 }
 
@@ -332,9 +268,9 @@ func (c *Cassandra) collectJVMMetrics(pms prometheus.Series) {
 
 		switch area {
 		case "heap":
-			c.mx.jvmMemoryHeapUsed.add(pm.Value)
+			c.mx.JvmMemoryHeapUsed.add(pm.Value)
 		case "nonheap":
-			c.mx.jvmMemoryNonHeapUsed.add(pm.Value)
+			c.mx.JvmMemoryNonHeapUsed.add(pm.Value)
 		}
 	}
 
@@ -343,9 +279,9 @@ func (c *Cassandra) collectJVMMetrics(pms prometheus.Series) {
 
 		switch gc {
 		case "ParNew":
-			c.mx.jvmGCParNewCount.add(pm.Value)
+			c.mx.JvmGCParNewCount.add(pm.Value)
 		case "ConcurrentMarkSweep":
-			c.mx.jvmGCCMSCount.add(pm.Value)
+			c.mx.JvmGCCMSCount.add(pm.Value)
 		}
 	}
 
@@ -354,9 +290,9 @@ func (c *Cassandra) collectJVMMetrics(pms prometheus.Series) {
 
 		switch gc {
 		case "ParNew":
-			c.mx.jvmGCParNewTime.add(pm.Value)
+			c.mx.JvmGCParNewTime.add(pm.Value)
 		case "ConcurrentMarkSweep":
-			c.mx.jvmGCCMSTime.add(pm.Value)
+			c.mx.JvmGCCMSTime.add(pm.Value)
 		}
 	}
 }
@@ -369,9 +305,9 @@ func (c *Cassandra) collectCompactionMetrics(pms prometheus.Series) {
 
 		switch name {
 		case "CompletedTasks":
-			c.mx.compactionCompletedTasks.add(pm.Value)
+			c.mx.CompactionCompletedTasks.add(pm.Value)
 		case "PendingTasks":
-			c.mx.compactionPendingTasks.add(pm.Value)
+			c.mx.CompactionPendingTasks.add(pm.Value)
 		}
 	}
 	for _, pm := range pms.FindByName(metric + suffixCount) {
@@ -379,20 +315,63 @@ func (c *Cassandra) collectCompactionMetrics(pms prometheus.Series) {
 
 		switch name {
 		case "BytesCompacted":
-			c.mx.compactionBytesCompacted.add(pm.Value)
+			c.mx.CompactionBytesCompacted.add(pm.Value)
 		}
 	}
 }
 
 func (c *Cassandra) getThreadPoolMetrics(name string) *threadPoolMetrics {
-	pool, ok := c.mx.threadPools[name]
+	pool, ok := c.mx.ThreadPools[name]
 	if !ok {
 		pool = &threadPoolMetrics{name: name}
-		c.mx.threadPools[name] = pool
+		c.mx.ThreadPools[name] = pool
 	}
 	return pool
 }
 
+// scrapeSeries keeps only the Cassandra and JVM series, the series buffer is reused between scrapes.
+func (c *Cassandra) scrapeSeries() (prometheus.Series, error) {
+	c.pms.Reset()
+
+	err := c.prom.ScrapeSeriesFunc(func(s prometheus.SeriesSample) {
+		if name := s.Name(); strings.HasPrefix(name, "org_apache_cassandra_metrics") || strings.HasPrefix(name, "jvm_") {
+			c.pms.Add(s.Copy())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.pms.Sort()
+
+	return c.pms, nil
+}
+
+var percentileSuffixes = map[string]string{
+	"_50thpercentile":  "0.5",
+	"_75thpercentile":  "0.75",
+	"_95thpercentile":  "0.95",
+	"_98thpercentile":  "0.98",
+	"_99thpercentile":  "0.99",
+	"_999thpercentile": "0.999",
+}
+
+// percentileSummaries groups the JMX exporter "metric_NNthpercentile" series into summaries.
+func percentileSummaries(pms prometheus.Series, metric string) []prometheus.Metric {
+	var series prometheus.Series
+	for suffix, quantile := range percentileSuffixes {
+		for _, pm := range pms.FindByName(metric + suffix) {
+			lbs := make(labels.Labels, 0, len(pm.Labels)+1)
+			lbs = append(lbs, labels.Label{Name: labels.MetricName, Value: metric})
+			lbs = append(lbs, pm.Labels[1:]...)
+			lbs = append(lbs, labels.Label{Name: "quantile", Value: quantile})
+			series.Add(prometheus.SeriesSample{Labels: lbs, Value: pm.Value})
+		}
+	}
+	series.Sort()
+	return series.Summaries(metric)
+}
+
 func isCassandraMetrics(pms prometheus.Series) bool {
 	for _, pm := range pms {
 		if strings.HasPrefix(pm.Name(), "org_apache_cassandra_metrics") {
@@ -8,76 +8,76 @@ package cassandra
 
 func newCassandraMetrics() *cassandraMetrics {
 	return &cassandraMetrics{
-		threadPools: make(map[string]*threadPoolMetrics),
+		ThreadPools: make(map[string]*threadPoolMetrics),
 	}
 }
 
 type cassandraMetrics struct {
-	clientReqTotalLatencyReads  metricValue
-	clientReqTotalLatencyWrites metricValue
-	clientReqLatencyReads       metricValue
-	clientReqLatencyWrites      metricValue
-	clientReqTimeoutsReads      metricValue
-	clientReqTimeoutsWrites     metricValue
-	clientReqUnavailablesReads  metricValue
-	clientReqUnavailablesWrites metricValue
-	clientReqFailuresReads      metricValue
-	clientReqFailuresWrites     metricValue
-
-	clientReqReadLatencyP50   metricValue
-	clientReqReadLatencyP75   metricValue
-	clientReqReadLatencyP95   metricValue
-	clientReqReadLatencyP98   metricValue
-	clientReqReadLatencyP99   metricValue
-	clientReqReadLatencyP999  metricValue
-	clientReqWriteLatencyP50  metricValue
-	clientReqWriteLatencyP75  metricValue
-	clientReqWriteLatencyP95  metricValue
-	clientReqWriteLatencyP98  metricValue
-	clientReqWriteLatencyP99  metricValue
-	clientReqWriteLatencyP999 metricValue
-
-	rowCacheHits     metricValue
-	rowCacheMisses   metricValue
-	rowCacheCapacity metricValue
-	rowCacheSize     metricValue
-	keyCacheHits     metricValue
-	keyCacheMisses   metricValue
-	keyCacheCapacity metricValue
-	keyCacheSize     metricValue
+	ClientReqTotalLatencyReads  metricValue `stm:"client_request_total_latency_reads"`
+	ClientReqTotalLatencyWrites metricValue `stm:"client_request_total_latency_writes"`
+	ClientReqLatencyReads       metricValue `stm:"client_request_latency_reads"`
+	ClientReqLatencyWrites      metricValue `stm:"client_request_latency_writes"`
+	ClientReqTimeoutsReads      metricValue `stm:"client_request_timeouts_reads"`
+	ClientReqTimeoutsWrites     metricValue `stm:"client_request_timeouts_writes"`
+	ClientReqUnavailablesReads  metricValue `stm:"client_request_unavailables_reads"`
+	ClientReqUnavailablesWrites metricValue `stm:"client_request_unavailables_writes"`
+	ClientReqFailuresReads      metricValue `stm:"client_request_failures_reads"`
+	ClientReqFailuresWrites     metricValue `stm:"client_request_failures_writes"`
+
+	ClientReqReadLatencyP50   metricValue `stm:"client_request_read_latency_p50"`
+	ClientReqReadLatencyP75   metricValue `stm:"client_request_read_latency_p75"`
+	ClientReqReadLatencyP95   metricValue `stm:"client_request_read_latency_p95"`
+	ClientReqReadLatencyP98   metricValue `stm:"client_request_read_latency_p98"`
+	ClientReqReadLatencyP99   metricValue `stm:"client_request_read_latency_p99"`
+	ClientReqReadLatencyP999  metricValue `stm:"client_request_read_latency_p999"`
+	ClientReqWriteLatencyP50  metricValue `stm:"client_request_write_latency_p50"`
+	ClientReqWriteLatencyP75  metricValue `stm:"client_request_write_latency_p75"`
+	ClientReqWriteLatencyP95  metricValue `stm:"client_request_write_latency_p95"`
+	ClientReqWriteLatencyP98  metricValue `stm:"client_request_write_latency_p98"`
+	ClientReqWriteLatencyP99  metricValue `stm:"client_request_write_latency_p99"`
+	ClientReqWriteLatencyP999 metricValue `stm:"client_request_write_latency_p999"`
+
+	RowCacheHits     metricValue `stm:"row_cache_hits"`
+	RowCacheMisses   metricValue `stm:"row_cache_misses"`
+	RowCacheCapacity metricValue
+	RowCacheSize     metricValue `stm:"row_cache_size"`
+	KeyCacheHits     metricValue `stm:"key_cache_hits"`
+	KeyCacheMisses   metricValue `stm:"key_cache_misses"`
+	KeyCacheCapacity metricValue
+	KeyCacheSize     metricValue `stm:"key_cache_size"`
 
 	// https://cassandra.apache.org/doc/latest/cassandra/operating/metrics.html#dropped-metrics
-	droppedMessages metricValue
+	DroppedMessages metricValue `stm:"dropped_messages,1000"`
 
 	// https://cassandra.apache.org/doc/latest/cassandra/operating/metrics.html#storage-metrics
-	storageLoad       metricValue
-	storageExceptions metricValue
+	StorageLoad       metricValue `stm:"storage_load"`
+	StorageExceptions metricValue `stm:"storage_exceptions"`
 
 	// https://cassandra.apache.org/doc/latest/cassandra/operating/metrics.html#compaction-metrics
-	compactionBytesCompacted metricValue
-	compactionPendingTasks   metricValue
-	compactionCompletedTasks metricValue
+	CompactionBytesCompacted metricValue `stm:"compaction_bytes_compacted"`
+	CompactionPendingTasks   metricValue `stm:"compaction_pending_tasks"`
+	CompactionCompletedTasks metricValue `stm:"compaction_completed_tasks"`
 
 	// https://cassandra.apache.org/doc/latest/cassandra/operating/metrics.html#memory
-	jvmMemoryHeapUsed    metricValue
-	jvmMemoryNonHeapUsed metricValue
+	JvmMemoryHeapUsed    metricValue `stm:"jvm_memory_heap_used"`
+	JvmMemoryNonHeapUsed metricValue `stm:"jvm_memory_nonheap_used"`
 	// https://cassandra.apache.org/doc/latest/cassandra/operating/metrics.html#garbagecollector
-	jvmGCParNewCount metricValue
-	jvmGCParNewTime  metricValue
-	jvmGCCMSCount    metricValue
-	jvmGCCMSTime     metricValue
+	JvmGCParNewCount metricValue `stm:"jvm_gc_parnew_count"`
+	JvmGCParNewTime  metricValue `stm:"jvm_gc_parnew_time,1000"`
+	JvmGCCMSCount    metricValue `stm:"jvm_gc_cms_count"`
+	JvmGCCMSTime     metricValue `stm:"jvm_gc_cms_time,1000"`
 
-	threadPools map[string]*threadPoolMetrics
+	ThreadPools map[string]*threadPoolMetrics `stm:"thread_pool"`
 }
 
 type threadPoolMetrics struct {
 	name      string
 	hasCharts bool
 
-	activeTasks       metricValue
-	pendingTasks      metricValue
-	blockedTasks      metricValue
-	totalBlockedTasks metricValue
+	ActiveTasks       metricValue `stm:"active_tasks"`
+	PendingTasks      metricValue `stm:"pending_tasks"`
+	BlockedTasks      metricValue `stm:"blocked_tasks"`
+	TotalBlockedTasks metricValue `stm:"total_blocked_tasks"`
 }
 
 type metricValue struct {
@@ -90,14 +90,9 @@ func (mv *metricValue) add(v float64) {
 	mv.value += v
 }
 
-func (mv *metricValue) write(mx map[string]int64, key string) {
+// WriteTo implements stm.Value, the value is written only if it is set.
+func (mv metricValue) WriteTo(rv map[string]int64, key string, mul, div int) {
 	if mv.isSet {
-		mx[key] = int64(mv.value)
-	}
-}
-
-func (mv *metricValue) write1k(mx map[string]int64, key string) {
-	if mv.isSet {
-		mx[key] = int64(mv.value * 1000)
+		rv[key] = int64(mv.value * float64(mul) / float64(div))
 	}
 }
@@ -3,6 +3,9 @@
 package chrony
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/netdata/go.d.plugin/agent/module"
 )
 
@@ -146,3 +149,153 @@ var charts = module.Charts{
 		},
 	},
 }
+
+var (
+	serverStatsCharts = module.Charts{
+		serverStatsNTPPacketsChart.Copy(),
+		serverStatsCommandPacketsChart.Copy(),
+		serverStatsClientLogRecordsDroppedChart.Copy(),
+	}
+
+	serverStatsNTPPacketsChart = module.Chart{
+		ID:    "serverstats_ntp_packets",
+		Title: "NTP packets",
+		Units: "packets/s",
+		Fam:   "serverstats",
+		Ctx:   "chrony.serverstats_ntp_packets",
+		Dims: module.Dims{
+			{ID: "serverstats_ntp_packets_received", Name: "received", Algo: module.Incremental},
+			{ID: "serverstats_ntp_packets_dropped", Name: "dropped", Algo: module.Incremental},
+		},
+	}
+	serverStatsCommandPacketsChart = module.Chart{
+		ID:    "serverstats_command_packets",
+		Title: "Command packets",
+		Units: "packets/s",
+		Fam:   "serverstats",
+		Ctx:   "chrony.serverstats_command_packets",
+		Dims: module.Dims{
+			{ID: "serverstats_command_packets_received", Name: "received", Algo: module.Incremental},
+			{ID: "serverstats_command_packets_dropped", Name: "dropped", Algo: module.Incremental},
+		},
+	}
+	serverStatsClientLogRecordsDroppedChart = module.Chart{
+		ID:    "serverstats_client_log_records_dropped",
+		Title: "Client log records dropped",
+		Units: "records/s",
+		Fam:   "serverstats",
+		Ctx:   "chrony.serverstats_client_log_records_dropped",
+		Dims: module.Dims{
+			{ID: "serverstats_client_log_records_dropped", Name: "dropped", Algo: module.Incremental},
+		},
+	}
+	serverStatsNKEConnectionsChart = module.Chart{
+		ID:    "serverstats_nke_connections",
+		Title: "NTS-KE connections",
+		Units: "connections/s",
+		Fam:   "serverstats",
+		Ctx:   "chrony.serverstats_nke_connections",
+		Dims: module.Dims{
+			{ID: "serverstats_nke_connections_accepted", Name: "accepted", Algo: module.Incremental},
+			{ID: "serverstats_nke_connections_dropped", Name: "dropped", Algo: module.Incremental},
+		},
+	}
+)
+
+var (
+	sourceChartsTmpl = module.Charts{
+		sourceOffsetChartTmpl.Copy(),
+		sourceJitterChartTmpl.Copy(),
+		sourceReachabilityChartTmpl.Copy(),
+		sourceStateChartTmpl.Copy(),
+	}
+
+	sourceOffsetChartTmpl = module.Chart{
+		ID:    "source_%s_offset",
+		Title: "Source offset of the last sample",
+		Units: "seconds",
+		Fam:   "sources",
+		Ctx:   "chrony.source_offset",
+		Dims: module.Dims{
+			{ID: "source_%s_offset", Name: "offset", Div: scaleFactor},
+		},
+	}
+	sourceJitterChartTmpl = module.Chart{
+		ID:    "source_%s_jitter",
+		Title: "Source jitter (standard deviation of the samples)",
+		Units: "seconds",
+		Fam:   "sources",
+		Ctx:   "chrony.source_jitter",
+		Dims: module.Dims{
+			{ID: "source_%s_jitter", Name: "jitter", Div: scaleFactor},
+		},
+	}
+	sourceReachabilityChartTmpl = module.Chart{
+		ID:    "source_%s_reachability",
+		Title: "Source reachability register",
+		Units: "value",
+		Fam:   "sources",
+		Ctx:   "chrony.source_reachability",
+		Dims: module.Dims{
+			{ID: "source_%s_reachability", Name: "reachability"},
+		},
+	}
+	sourceStateChartTmpl = module.Chart{
+		ID:    "source_%s_state",
+		Title: "Source selection state",
+		Units: "state",
+		Fam:   "sources",
+		Ctx:   "chrony.source_state",
+		Dims: module.Dims{
+			{ID: "source_%s_state", Name: "state"},
+		},
+	}
+)
+
+func newSourceCharts(name string) *module.Charts {
+	charts := sourceChartsTmpl.Copy()
+	id := sourceID(name)
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, id)
+		c.Labels = []module.Label{
+			{Key: "source", Value: name},
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, id)
+		}
+	}
+	return charts
+}
+
+func (c *Chrony) addSourceCharts(name string) {
+	charts := newSourceCharts(name)
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+func (c *Chrony) removeSourceCharts(name string) {
+	for _, tmpl := range sourceChartsTmpl {
+		chart := c.Charts().Get(fmt.Sprintf(tmpl.ID, sourceID(name)))
+		if chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+func (c *Chrony) addServerStatsCharts(hasNKE bool) {
+	charts := serverStatsCharts.Copy()
+	if hasNKE {
+		_ = charts.Add(serverStatsNKEConnectionsChart.Copy())
+	}
+	if err := c.Charts().Add(*charts...); err != nil {
+		c.Warning(err)
+	}
+}
+
+var sourceIDReplacer = strings.NewReplacer(".", "_", ":", "_", " ", "_")
+
+func sourceID(name string) string {
+	return sourceIDReplacer.Replace(name)
+}
@@ -24,6 +24,7 @@ func New() *Chrony {
 		},
 		charts:    charts.Copy(),
 		newClient: newChronyClient,
+		sources:   make(map[string]bool),
 	}
 }
 
@@ -41,10 +42,19 @@ type (
 
 		newClient func(c Config) (chronyClient, error)
 		client    chronyClient
+
+		sources map[string]bool
+
+		serverStatsDisabled  bool
+		serverStatsCollected bool
 	}
 	chronyClient interface {
 		Tracking() (*chrony.ReplyTracking, error)
 		Activity() (*chrony.ReplyActivity, error)
+		SourcesCount() (int, error)
+		SourceData(index int) (*chrony.ReplySourceData, error)
+		SourceStats(index int) (*chrony.ReplySourceStats, error)
+		ServerStats() (*serverStats, error)
 		Close()
 	}
 )
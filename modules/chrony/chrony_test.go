@@ -3,12 +3,18 @@
 package chrony
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"net"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/facebook/time/ntp/chrony"
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/web"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -136,6 +142,50 @@ func TestChrony_Collect(t *testing.T) {
 	}{
 		"tracking: success, activity: success": {
 			prepare: func() *Chrony { return prepareChronyWithMock(&mockClient{}) },
+			expected: map[string]int64{
+				"burst_offline_sources":                  3,
+				"burst_online_sources":                   4,
+				"current_correction":                     154872,
+				"frequency":                              51051185607,
+				"last_offset":                            3095,
+				"leap_status_delete_second":              0,
+				"leap_status_insert_second":              1,
+				"leap_status_normal":                     0,
+				"leap_status_unsynchronised":             0,
+				"offline_sources":                        2,
+				"online_sources":                         8,
+				"ref_measurement_time":                   63793323616,
+				"residual_frequency":                     -571789,
+				"rms_offset":                             130089,
+				"root_delay":                             59576179,
+				"root_dispersion":                        1089275,
+				"skew":                                   41821926,
+				"stratum":                                4,
+				"unresolved_sources":                     1,
+				"update_interval":                        1044219238281,
+				"source_192_0_2_1_offset":                -125000,
+				"source_192_0_2_1_jitter":                37500,
+				"source_192_0_2_1_reachability":          255,
+				"source_192_0_2_1_state":                 0,
+				"source_2001_db8__1_offset":              500000,
+				"source_2001_db8__1_jitter":              125000,
+				"source_2001_db8__1_reachability":        127,
+				"source_2001_db8__1_state":               4,
+				"source_GPS_offset":                      250000000,
+				"source_GPS_jitter":                      62500000,
+				"source_GPS_reachability":                15,
+				"source_GPS_state":                       2,
+				"serverstats_ntp_packets_received":       1500,
+				"serverstats_ntp_packets_dropped":        20,
+				"serverstats_command_packets_received":   300,
+				"serverstats_command_packets_dropped":    2,
+				"serverstats_client_log_records_dropped": 5,
+				"serverstats_nke_connections_accepted":   12,
+				"serverstats_nke_connections_dropped":    1,
+			},
+		},
+		"tracking: success, activity: success, sources: fail": {
+			prepare: func() *Chrony { return prepareChronyWithMock(&mockClient{errOnSources: true}) },
 			expected: map[string]int64{
 				"burst_offline_sources":      3,
 				"burst_online_sources":       4,
@@ -208,6 +258,195 @@ func TestChrony_Collect(t *testing.T) {
 	}
 }
 
+func TestChrony_Collect_SourcesRotation(t *testing.T) {
+	m := &mockClient{sources: defaultMockSources[:2]}
+	c := prepareChronyWithMock(m)
+	require.True(t, c.Init())
+
+	require.NotNil(t, c.Collect())
+	assert.Len(t, *c.Charts(), len(charts)+2*len(sourceChartsTmpl)+len(serverStatsCharts)+1)
+	chart := c.Charts().Get("source_2001_db8__1_offset")
+	require.NotNil(t, chart)
+	assert.Equal(t, []module.Label{{Key: "source", Value: "2001:db8::1"}}, chart.Labels)
+
+	m.sources = []mockSource{defaultMockSources[0], defaultMockSources[2]}
+	mx := c.Collect()
+	require.NotNil(t, mx)
+
+	assert.Contains(t, mx, "source_GPS_offset")
+	assert.NotContains(t, mx, "source_2001_db8__1_offset")
+	assert.True(t, c.Charts().Has("source_GPS_offset"))
+	for _, tmpl := range sourceChartsTmpl {
+		assert.Truef(t, c.Charts().Get(fmt.Sprintf(tmpl.ID, "2001_db8__1")).Obsolete, "chart '%s' is not removed", tmpl.ID)
+		assert.Falsef(t, c.Charts().Get(fmt.Sprintf(tmpl.ID, "192_0_2_1")).Obsolete, "chart '%s' is removed", tmpl.ID)
+	}
+	assert.Equal(t, map[string]bool{"192.0.2.1": true, "GPS": true}, c.sources)
+}
+
+func TestChrony_Collect_ServerStats(t *testing.T) {
+	tests := map[string]struct {
+		err          error
+		wantDisabled bool
+		wantCharts   bool
+	}{
+		"success": {
+			wantCharts: true,
+		},
+		"unauthorized": {
+			err:          errUnauthorized,
+			wantDisabled: true,
+		},
+		"error": {
+			err: errors.New("mockClient.ServerStats call error"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := prepareChronyWithMock(&mockClient{errOnServerStats: test.err})
+			require.True(t, c.Init())
+
+			for i := 0; i < 2; i++ {
+				mx := c.Collect()
+				require.NotNil(t, mx)
+
+				_, ok := mx["serverstats_ntp_packets_received"]
+				assert.Equal(t, test.wantCharts, ok)
+			}
+
+			assert.Equal(t, test.wantDisabled, c.serverStatsDisabled)
+			assert.Equal(t, test.wantCharts, c.Charts().Has("serverstats_ntp_packets"))
+			assert.Equal(t, test.wantCharts, c.Charts().Has("serverstats_nke_connections"))
+		})
+	}
+}
+
+func TestDecodeServerStats(t *testing.T) {
+	counters := []uint32{1500, 12, 300, 20, 1, 2, 5, 7, 0, 0, 0}
+
+	newReply := func(status chrony.ResponseStatusType, reply chrony.ReplyType, data interface{}) []byte {
+		var buf bytes.Buffer
+		head := chrony.ReplyHead{Version: 6, PKTType: 2, Command: 54, Reply: reply, Status: status}
+		_ = binary.Write(&buf, binary.BigEndian, head)
+		if data != nil {
+			_ = binary.Write(&buf, binary.BigEndian, data)
+		}
+		return buf.Bytes()
+	}
+	counters64 := make([]struct{ High, Low uint32 }, 0, len(counters))
+	for _, v := range counters {
+		counters64 = append(counters64, struct{ High, Low uint32 }{High: 0, Low: v})
+	}
+	counters64[0].High = 1
+
+	tests := map[string]struct {
+		data     []byte
+		expected *serverStats
+		wantErr  error
+	}{
+		"v1": {
+			data: newReply(0, replyServerStats, chrony.ServerStats{
+				NTPHits: 1500, CMDHits: 300, NTPDrops: 20, CMDDrops: 2, LogDrops: 5,
+			}),
+			expected: &serverStats{NTPHits: 1500, CMDHits: 300, NTPDrops: 20, CMDDrops: 2, LogDrops: 5},
+		},
+		"v2": {
+			data: newReply(0, replyServerStats2, counters[:8]),
+			expected: &serverStats{
+				NTPHits: 1500, NKEHits: 12, CMDHits: 300, NTPDrops: 20, NKEDrops: 1, CMDDrops: 2, LogDrops: 5, HasNKE: true,
+			},
+		},
+		"v3": {
+			data: newReply(0, replyServerStats3, counters),
+			expected: &serverStats{
+				NTPHits: 1500, NKEHits: 12, CMDHits: 300, NTPDrops: 20, NKEDrops: 1, CMDDrops: 2, LogDrops: 5, HasNKE: true,
+			},
+		},
+		"v4": {
+			data: newReply(0, replyServerStats4, counters64),
+			expected: &serverStats{
+				NTPHits: 1<<32 + 1500, NKEHits: 12, CMDHits: 300, NTPDrops: 20, NKEDrops: 1, CMDDrops: 2, LogDrops: 5, HasNKE: true,
+			},
+		},
+		"unauthorized": {
+			data:    newReply(statusUnauth, replyServerStats4, nil),
+			wantErr: errUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			stats, err := decodeServerStats(test.data)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, stats)
+			}
+		})
+	}
+
+	_, err := decodeServerStats(newReply(0, 99, nil))
+	assert.Error(t, err)
+}
+
+func TestNewChronyClient_UnixSocket(t *testing.T) {
+	tests := map[string]struct {
+		noReply bool
+	}{
+		"chronyd replies": {},
+		"chronyd doesn't reply": {
+			noReply: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			address := filepath.Join(t.TempDir(), "chronyd.sock")
+			srv, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: address, Net: "unixgram"})
+			require.NoError(t, err)
+			defer func() { _ = srv.Close() }()
+
+			if !test.noReply {
+				go serveActivity(srv)
+			}
+
+			client, err := newChronyClient(Config{Address: address, Timeout: web.Duration{Duration: time.Millisecond * 500}})
+			require.NoError(t, err)
+
+			localPath := client.(*simpleClient).localPath
+			assert.FileExists(t, localPath)
+
+			activity, err := client.Activity()
+			if test.noReply {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int32(3), activity.Online)
+				assert.Equal(t, int32(1), activity.Offline)
+			}
+
+			client.Close()
+			assert.NoFileExists(t, localPath)
+		})
+	}
+}
+
+func serveActivity(srv *net.UnixConn) {
+	buf := make([]byte, 1024)
+	for {
+		_, addr, err := srv.ReadFromUnix(buf)
+		if err != nil {
+			return
+		}
+		var reply bytes.Buffer
+		_ = binary.Write(&reply, binary.BigEndian, chrony.ReplyHead{Version: 6, PKTType: 2, Command: 44, Reply: 12})
+		_ = binary.Write(&reply, binary.BigEndian, chrony.Activity{Online: 3, Offline: 1})
+		_, _ = srv.WriteToUnix(reply.Bytes(), addr)
+	}
+}
+
 func prepareChronyWithMock(m *mockClient) *Chrony {
 	c := New()
 	if m == nil {
@@ -221,7 +460,52 @@ func prepareChronyWithMock(m *mockClient) *Chrony {
 type mockClient struct {
 	errOnTracking bool
 	errOnActivity bool
-	closeCalled   bool
+	errOnSources  bool
+	// errOnServerStats is the ServerStats call error, e.g. errUnauthorized.
+	errOnServerStats error
+	closeCalled      bool
+	// sources are the mock sources, the default ones are used if it is nil.
+	sources []mockSource
+}
+
+type mockSource struct {
+	data  chrony.SourceData
+	stats chrony.SourceStats
+}
+
+var defaultMockSources = []mockSource{
+	{
+		data: chrony.SourceData{
+			IPAddr:       net.ParseIP("192.0.2.1").To4(),
+			Stratum:      2,
+			State:        chrony.SourceStateSync,
+			Mode:         chrony.SourceModeClient,
+			Reachability: 255,
+			LatestMeas:   -0.000125,
+		},
+		stats: chrony.SourceStats{StandardDeviation: 0.0000375},
+	},
+	{
+		data: chrony.SourceData{
+			IPAddr:       net.ParseIP("2001:db8::1"),
+			Stratum:      3,
+			State:        chrony.SourceStateCandidate,
+			Mode:         chrony.SourceModeClient,
+			Reachability: 127,
+			LatestMeas:   0.0005,
+		},
+		stats: chrony.SourceStats{StandardDeviation: 0.000125},
+	},
+	{
+		data: chrony.SourceData{
+			IPAddr:       net.IP{'G', 'P', 'S', 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			State:        chrony.SourceStateFalseTicket,
+			Mode:         chrony.SourceModeRef,
+			Reachability: 15,
+			LatestMeas:   0.25,
+		},
+		stats: chrony.SourceStats{StandardDeviation: 0.0625},
+	},
 }
 
 func (m mockClient) Tracking() (*chrony.ReplyTracking, error) {
@@ -265,6 +549,53 @@ func (m mockClient) Activity() (*chrony.ReplyActivity, error) {
 	return &reply, nil
 }
 
+func (m mockClient) SourcesCount() (int, error) {
+	if m.errOnSources {
+		return 0, errors.New("mockClient.SourcesCount call error")
+	}
+	return len(m.mockSources()), nil
+}
+
+func (m mockClient) SourceData(index int) (*chrony.ReplySourceData, error) {
+	sources := m.mockSources()
+	if index >= len(sources) {
+		return nil, errors.New("mockClient.SourceData invalid index")
+	}
+	return &chrony.ReplySourceData{SourceData: sources[index].data}, nil
+}
+
+func (m mockClient) SourceStats(index int) (*chrony.ReplySourceStats, error) {
+	sources := m.mockSources()
+	if index >= len(sources) {
+		return nil, errors.New("mockClient.SourceStats invalid index")
+	}
+	return &chrony.ReplySourceStats{SourceStats: sources[index].stats}, nil
+}
+
+func (m mockClient) ServerStats() (*serverStats, error) {
+	if m.errOnServerStats != nil {
+		return nil, m.errOnServerStats
+	}
+	reply := serverStats{
+		NTPHits:  1500,
+		NKEHits:  12,
+		CMDHits:  300,
+		NTPDrops: 20,
+		NKEDrops: 1,
+		CMDDrops: 2,
+		LogDrops: 5,
+		HasNKE:   true,
+	}
+	return &reply, nil
+}
+
+func (m mockClient) mockSources() []mockSource {
+	if m.sources == nil {
+		return defaultMockSources
+	}
+	return m.sources
+}
+
 func (m *mockClient) Close() {
 	m.closeCalled = true
 }
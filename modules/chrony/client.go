@@ -3,28 +3,101 @@
 package chrony
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/facebook/time/ntp/chrony"
 )
 
 func newChronyClient(c Config) (chronyClient, error) {
+	if isUnixSocketAddress(c.Address) {
+		return newUnixSocketClient(c)
+	}
+
 	conn, err := net.DialTimeout("udp", c.Address, c.Timeout.Duration)
 	if err != nil {
 		return nil, err
 	}
 
-	client := &simpleClient{
-		conn:   conn,
-		client: &chrony.Client{Connection: conn},
+	return newSimpleClient(conn, c.Timeout.Duration, ""), nil
+}
+
+func isUnixSocketAddress(address string) bool {
+	return strings.HasPrefix(address, "/")
+}
+
+var clientSocketSeq uint32
+
+// newUnixSocketClient connects to the chronyd command socket. chronyd sends the reply to the client socket address,
+// so the client socket is bound in the chronyd socket directory (like chronyc does) and made writable for chronyd.
+func newUnixSocketClient(c Config) (chronyClient, error) {
+	dir := filepath.Dir(c.Address)
+	localPath := filepath.Join(dir, fmt.Sprintf("netdata.%d.%d.sock", os.Getpid(), atomic.AddUint32(&clientSocketSeq, 1)))
+	_ = os.Remove(localPath)
+
+	conn, err := net.DialUnix("unixgram",
+		&net.UnixAddr{Name: localPath, Net: "unixgram"},
+		&net.UnixAddr{Name: c.Address, Net: "unixgram"},
+	)
+	if err != nil {
+		_ = os.Remove(localPath)
+		if errors.Is(err, os.ErrPermission) {
+			return nil, fmt.Errorf("%v (write access to '%s' is required, run netdata in the chrony group)", err, dir)
+		}
+		return nil, err
+	}
+
+	if err := os.Chmod(localPath, 0666); err != nil {
+		_ = conn.Close()
+		_ = os.Remove(localPath)
+		return nil, err
+	}
+
+	return newSimpleClient(conn, c.Timeout.Duration, localPath), nil
+}
+
+func newSimpleClient(conn net.Conn, timeout time.Duration, localPath string) *simpleClient {
+	conn = &deadlineConn{Conn: conn, timeout: timeout}
+	return &simpleClient{
+		conn:      conn,
+		client:    &chrony.Client{Connection: conn},
+		localPath: localPath,
 	}
-	return client, nil
 }
 
 type simpleClient struct {
 	conn   net.Conn
 	client *chrony.Client
+	// localPath is the client socket path, it is set if the Unix domain socket is used.
+	localPath string
+}
+
+// deadlineConn sets the deadline on every read and write, chronyd doesn't reply if it can't send to the client socket.
+type deadlineConn struct {
+	net.Conn
+	timeout time.Duration
+}
+
+func (c *deadlineConn) Read(b []byte) (int, error) {
+	if c.timeout > 0 {
+		_ = c.SetReadDeadline(time.Now().Add(c.timeout))
+	}
+	return c.Conn.Read(b)
+}
+
+func (c *deadlineConn) Write(b []byte) (int, error) {
+	if c.timeout > 0 {
+		_ = c.SetWriteDeadline(time.Now().Add(c.timeout))
+	}
+	return c.Conn.Write(b)
 }
 
 func (sc *simpleClient) Tracking() (*chrony.ReplyTracking, error) {
@@ -53,9 +126,164 @@ func (sc *simpleClient) Activity() (*chrony.ReplyActivity, error) {
 	return activity, nil
 }
 
+func (sc *simpleClient) SourcesCount() (int, error) {
+	reply, err := sc.client.Communicate(chrony.NewSourcesPacket())
+	if err != nil {
+		return 0, err
+	}
+
+	sources, ok := reply.(*chrony.ReplySources)
+	if !ok {
+		return 0, fmt.Errorf("unexpected reply type, want=%T, got=%T", &chrony.ReplySources{}, reply)
+	}
+	return sources.NSources, nil
+}
+
+func (sc *simpleClient) SourceData(index int) (*chrony.ReplySourceData, error) {
+	reply, err := sc.client.Communicate(chrony.NewSourceDataPacket(int32(index)))
+	if err != nil {
+		return nil, err
+	}
+
+	sourceData, ok := reply.(*chrony.ReplySourceData)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply type, want=%T, got=%T", &chrony.ReplySourceData{}, reply)
+	}
+	return sourceData, nil
+}
+
+func (sc *simpleClient) SourceStats(index int) (*chrony.ReplySourceStats, error) {
+	reply, err := sc.client.Communicate(chrony.NewSourceStatsPacket(int32(index)))
+	if err != nil {
+		return nil, err
+	}
+
+	sourceStats, ok := reply.(*chrony.ReplySourceStats)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply type, want=%T, got=%T", &chrony.ReplySourceStats{}, reply)
+	}
+	return sourceStats, nil
+}
+
+// ServerStats sends the 'serverstats' request. The reply is decoded here because the library
+// doesn't support the reply versions of chrony 4.1+.
+func (sc *simpleClient) ServerStats() (*serverStats, error) {
+	req := chrony.NewServerStatsPacket()
+	sc.client.Sequence++
+	req.SetSequence(sc.client.Sequence)
+
+	if err := binary.Write(sc.conn, binary.BigEndian, req); err != nil {
+		return nil, err
+	}
+
+	resp := make([]byte, 1024)
+	n, err := sc.conn.Read(resp)
+	if err != nil {
+		return nil, err
+	}
+	return decodeServerStats(resp[:n])
+}
+
 func (sc *simpleClient) Close() {
 	if sc.conn != nil {
 		_ = sc.conn.Close()
 		sc.conn = nil
 	}
+	if sc.localPath != "" {
+		_ = os.Remove(sc.localPath)
+		sc.localPath = ""
+	}
+}
+
+// https://gitlab.com/chrony/chrony/-/blob/master/candm.h
+const (
+	statusUnauth chrony.ResponseStatusType = 2
+
+	replyServerStats  chrony.ReplyType = 14
+	replyServerStats2 chrony.ReplyType = 22
+	replyServerStats3 chrony.ReplyType = 24
+	replyServerStats4 chrony.ReplyType = 25
+)
+
+var errUnauthorized = errors.New("unauthorized")
+
+// serverStats is the 'serverstats' reply.
+type serverStats struct {
+	NTPHits  uint64
+	NKEHits  uint64
+	CMDHits  uint64
+	NTPDrops uint64
+	NKEDrops uint64
+	CMDDrops uint64
+	LogDrops uint64
+	// HasNKE is false if the NTS-KE counters are not reported (chrony < 4.0).
+	HasNKE bool
+}
+
+func decodeServerStats(data []byte) (*serverStats, error) {
+	r := bytes.NewReader(data)
+
+	var head chrony.ReplyHead
+	if err := binary.Read(r, binary.BigEndian, &head); err != nil {
+		return nil, err
+	}
+	if head.Status == statusUnauth {
+		return nil, errUnauthorized
+	}
+	if head.Status != 0 {
+		return nil, fmt.Errorf("got status %s (%d)", head.Status, head.Status)
+	}
+
+	switch head.Reply {
+	case replyServerStats:
+		var v chrony.ServerStats
+		if err := binary.Read(r, binary.BigEndian, &v); err != nil {
+			return nil, err
+		}
+		return &serverStats{
+			NTPHits:  uint64(v.NTPHits),
+			CMDHits:  uint64(v.CMDHits),
+			NTPDrops: uint64(v.NTPDrops),
+			CMDDrops: uint64(v.CMDDrops),
+			LogDrops: uint64(v.LogDrops),
+		}, nil
+	case replyServerStats2, replyServerStats3:
+		// the 3rd version appends new counters to the 2nd one
+		var v chrony.ServerStats2
+		if err := binary.Read(r, binary.BigEndian, &v); err != nil {
+			return nil, err
+		}
+		return &serverStats{
+			NTPHits:  uint64(v.NTPHits),
+			NKEHits:  uint64(v.NKEHits),
+			CMDHits:  uint64(v.CMDHits),
+			NTPDrops: uint64(v.NTPDrops),
+			NKEDrops: uint64(v.NKEDrops),
+			CMDDrops: uint64(v.CMDDrops),
+			LogDrops: uint64(v.LogDrops),
+			HasNKE:   true,
+		}, nil
+	case replyServerStats4:
+		// the counters are 64-bit (high and low 32-bit halves), the order is the same as in the 2nd version
+		var v [7]struct{ High, Low uint32 }
+		if err := binary.Read(r, binary.BigEndian, &v); err != nil {
+			return nil, err
+		}
+		var c [7]uint64
+		for i, n := range v {
+			c[i] = uint64(n.High)<<32 | uint64(n.Low)
+		}
+		return &serverStats{
+			NTPHits:  c[0],
+			NKEHits:  c[1],
+			CMDHits:  c[2],
+			NTPDrops: c[3],
+			NKEDrops: c[4],
+			CMDDrops: c[5],
+			LogDrops: c[6],
+			HasNKE:   true,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported serverstats reply type %d", head.Reply)
+	}
 }
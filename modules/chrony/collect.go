@@ -3,8 +3,12 @@
 package chrony
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/facebook/time/ntp/chrony"
 )
 
 const scaleFactor = 1000000000
@@ -26,6 +30,12 @@ func (c *Chrony) collect() (map[string]int64, error) {
 	if err := c.collectActivity(mx); err != nil {
 		return mx, err
 	}
+	if err := c.collectSources(mx); err != nil {
+		return mx, err
+	}
+	if err := c.collectServerStats(mx); err != nil {
+		return mx, err
+	}
 
 	return mx, nil
 }
@@ -82,6 +92,92 @@ func (c *Chrony) collectActivity(mx map[string]int64) error {
 	return nil
 }
 
+func (c *Chrony) collectSources(mx map[string]int64) error {
+	n, err := c.client.SourcesCount()
+	if err != nil {
+		return fmt.Errorf("error on collecting sources: %v", err)
+	}
+
+	seen := make(map[string]bool)
+
+	for i := 0; i < n; i++ {
+		data, err := c.client.SourceData(i)
+		if err != nil {
+			return fmt.Errorf("error on collecting source (index %d) data: %v", i, err)
+		}
+		stats, err := c.client.SourceStats(i)
+		if err != nil {
+			return fmt.Errorf("error on collecting source (index %d) stats: %v", i, err)
+		}
+
+		name := sourceName(data.SourceData)
+		seen[name] = true
+
+		if !c.sources[name] {
+			c.sources[name] = true
+			c.addSourceCharts(name)
+		}
+
+		px := "source_" + sourceID(name) + "_"
+		mx[px+"offset"] = int64(data.LatestMeas * scaleFactor)
+		mx[px+"jitter"] = int64(stats.StandardDeviation * scaleFactor)
+		mx[px+"reachability"] = int64(data.Reachability)
+		// 0: selected, 1: unreachable, 2: falseticker, 3: jittery, 4: combined, 5: not combined (outlier)
+		mx[px+"state"] = int64(data.State)
+	}
+
+	// pool sources are replaced when they become unreachable
+	for name := range c.sources {
+		if !seen[name] {
+			delete(c.sources, name)
+			c.removeSourceCharts(name)
+		}
+	}
+
+	return nil
+}
+
+func (c *Chrony) collectServerStats(mx map[string]int64) error {
+	if c.serverStatsDisabled {
+		return nil
+	}
+
+	stats, err := c.client.ServerStats()
+	if err != nil {
+		if errors.Is(err, errUnauthorized) {
+			c.serverStatsDisabled = true
+			c.Info("the serverstats request is not authorized by chronyd, disabling serverstats collection")
+			return nil
+		}
+		return fmt.Errorf("error on collecting serverstats: %v", err)
+	}
+
+	if !c.serverStatsCollected {
+		c.serverStatsCollected = true
+		c.addServerStatsCharts(stats.HasNKE)
+	}
+
+	mx["serverstats_ntp_packets_received"] = int64(stats.NTPHits)
+	mx["serverstats_ntp_packets_dropped"] = int64(stats.NTPDrops)
+	mx["serverstats_command_packets_received"] = int64(stats.CMDHits)
+	mx["serverstats_command_packets_dropped"] = int64(stats.CMDDrops)
+	mx["serverstats_client_log_records_dropped"] = int64(stats.LogDrops)
+	if stats.HasNKE {
+		mx["serverstats_nke_connections_accepted"] = int64(stats.NKEHits)
+		mx["serverstats_nke_connections_dropped"] = int64(stats.NKEDrops)
+	}
+
+	return nil
+}
+
+func sourceName(data chrony.SourceData) string {
+	// the address of a reference clock is its refid
+	if data.Mode == chrony.SourceModeRef && len(data.IPAddr) >= 4 {
+		return chrony.RefidToString(binary.BigEndian.Uint32(data.IPAddr[:4]))
+	}
+	return data.IPAddr.String()
+}
+
 func boolToInt(v bool) int64 {
 	if v {
 		return 1
@@ -7,11 +7,17 @@ import (
 	"strings"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 )
 
 const (
 	prioDNSQueryStatus = module.Priority + iota
 	prioDNSQueryTime
+	prioDNSQueryTimeHistogram
+	prioDNSQueryTimeCumulativeHistogram
+	prioDNSServersQueryTime
+	prioDNSSECValid
+	prioDNSSECRRSIGExpiry
 )
 
 var (
@@ -20,45 +26,153 @@ var (
 		dnsQueryTimeChartTmpl.Copy(),
 	}
 	dnsQueryStatusChartTmpl = module.Chart{
-		ID:       "server_%s_record_%s_query_status",
+		ID:       "server_%s_%s_query_status",
 		Title:    "DNS Query Status",
 		Units:    "status",
 		Fam:      "query status",
 		Ctx:      "dns_query.query_status",
 		Priority: prioDNSQueryStatus,
 		Dims: module.Dims{
-			{ID: "server_%s_record_%s_query_status_success", Name: "success"},
-			{ID: "server_%s_record_%s_query_status_network_error", Name: "network_error"},
-			{ID: "server_%s_record_%s_query_status_dns_error", Name: "dns_error"},
+			{ID: "%squery_status_success", Name: "success"},
+			{ID: "%squery_status_network_error", Name: "network_error"},
+			{ID: "%squery_status_dns_error", Name: "dns_error"},
 		},
 	}
 	dnsQueryTimeChartTmpl = module.Chart{
-		ID:       "server_%s_record_%s_query_time",
+		ID:       "server_%s_%s_query_time",
 		Title:    "DNS Query Time",
 		Units:    "seconds",
 		Fam:      "query time",
 		Ctx:      "dns_query.query_time",
 		Priority: prioDNSQueryTime,
 		Dims: module.Dims{
-			{ID: "server_%s_record_%s_query_time", Name: "query_time", Div: 1e9},
+			{ID: "%squery_time", Name: "query_time", Div: 1e9},
 		},
 	}
+	dnsQueryTimeHistogramChartTmpl = module.Chart{
+		ID:       "server_%s_%s_query_time_histogram",
+		Title:    "DNS Query Time Histogram",
+		Units:    "queries/s",
+		Fam:      "query time",
+		Ctx:      "dns_query.query_time_histogram",
+		Priority: prioDNSQueryTimeHistogram,
+	}
+	dnsQueryTimeCumulativeHistogramChartTmpl = module.Chart{
+		ID:       "server_%s_%s_query_time_cumulative_histogram",
+		Title:    "DNS Query Time Cumulative Histogram",
+		Units:    "queries/s",
+		Fam:      "query time",
+		Ctx:      "dns_query.query_time_cumulative_histogram",
+		Priority: prioDNSQueryTimeCumulativeHistogram,
+	}
+	dnssecChartsTmpl = module.Charts{
+		dnssecValidChartTmpl.Copy(),
+		dnssecRRSIGExpiryChartTmpl.Copy(),
+	}
+	dnssecValidChartTmpl = module.Chart{
+		ID:       "server_%s_%s_dnssec_valid",
+		Title:    "DNSSEC Answer Validity",
+		Units:    "boolean",
+		Fam:      "dnssec",
+		Ctx:      "dns_query.dnssec_valid",
+		Priority: prioDNSSECValid,
+		Dims: module.Dims{
+			{ID: "%sdnssec_valid", Name: "valid"},
+		},
+	}
+	dnssecRRSIGExpiryChartTmpl = module.Chart{
+		ID:       "server_%s_%s_dnssec_rrsig_expiry",
+		Title:    "DNSSEC RRSIG Time Until Expiration",
+		Units:    "days",
+		Fam:      "dnssec",
+		Ctx:      "dns_query.dnssec_rrsig_expiry",
+		Priority: prioDNSSECRRSIGExpiry,
+		Dims: module.Dims{
+			{ID: "%sdnssec_rrsig_expiry", Name: "expiry", Div: 86400},
+		},
+	}
+	dnsServersQueryTimeChartTmpl = module.Chart{
+		ID:       "%s_servers_query_time",
+		Title:    "DNS Query Time Per Server",
+		Units:    "seconds",
+		Fam:      "query time",
+		Ctx:      "dns_query.servers_query_time",
+		Priority: prioDNSServersQueryTime,
+	}
 )
 
-func newDNSServerCharts(server, network, rtype string) *module.Charts {
-	charts := dnsChartsTmpl.Copy()
+func newDNSServerCharts(server, network string, q query) *module.Charts {
+	return newQueryCharts(dnsChartsTmpl, server, network, q)
+}
+
+func newDNSSECCharts(server, network string, q query) *module.Charts {
+	return newQueryCharts(dnssecChartsTmpl, server, network, q)
+}
+
+func newQueryCharts(tmpl module.Charts, server, network string, q query) *module.Charts {
+	charts := tmpl.Copy()
 
 	for _, chart := range *charts {
-		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(server, ".", "_"), rtype)
-		chart.Labels = []module.Label{
-			{Key: "server", Value: server},
-			{Key: "network", Value: network},
-			{Key: "record_type", Value: rtype},
-		}
+		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(server, ".", "_"), q.id())
+		chart.Labels = newQueryLabels(server, network, q)
 		for _, d := range chart.Dims {
-			d.ID = fmt.Sprintf(d.ID, server, rtype)
+			d.ID = fmt.Sprintf(d.ID, q.metricsPrefix(server))
 		}
 	}
 
 	return charts
 }
+
+// addQueryStatusDim adds the optional status (cert_error, unexpected_answer) dimension to the query status chart.
+func addQueryStatusDim(charts *module.Charts, server string, q query, status string) {
+	chart := charts.Get(fmt.Sprintf(dnsQueryStatusChartTmpl.ID, strings.ReplaceAll(server, ".", "_"), q.id()))
+	if chart == nil {
+		return
+	}
+	_ = chart.AddDim(&module.Dim{ID: q.metricsPrefix(server) + "query_status_" + status, Name: status})
+}
+
+func newQueryTimeHistogramCharts(server, network string, q query, buckets []float64) *module.Charts {
+	key := q.metricsPrefix(server) + "query_time_hist"
+	charts := module.Charts{
+		dnsQueryTimeHistogramChartTmpl.Copy(),
+		dnsQueryTimeCumulativeHistogramChartTmpl.Copy(),
+	}
+	charts[0].Dims = metrics.HistogramBucketDims(key, buckets)
+	charts[1].Dims = metrics.HistogramBucketDims(key+"_cdf", buckets)
+
+	for _, chart := range charts {
+		chart.ID = fmt.Sprintf(chart.ID, strings.ReplaceAll(server, ".", "_"), q.id())
+		chart.Labels = newQueryLabels(server, network, q)
+	}
+
+	return &charts
+}
+
+// newServersQueryTimeChart creates the chart to compare the query time of the servers, a dimension per server.
+func newServersQueryTimeChart(servers []string, q query) *module.Chart {
+	chart := dnsServersQueryTimeChartTmpl.Copy()
+
+	chart.ID = fmt.Sprintf(chart.ID, q.id())
+	chart.Labels = []module.Label{{Key: "record_type", Value: q.rtypeName}}
+	if q.domain != "" {
+		chart.Labels = append(chart.Labels, module.Label{Key: "domain", Value: q.domain})
+	}
+	for _, srv := range servers {
+		chart.Dims = append(chart.Dims, &module.Dim{ID: q.metricsPrefix(srv) + "query_time", Name: srv, Div: 1e9})
+	}
+
+	return chart
+}
+
+func newQueryLabels(server, network string, q query) []module.Label {
+	labels := []module.Label{
+		{Key: "server", Value: server},
+		{Key: "network", Value: network},
+		{Key: "record_type", Value: q.rtypeName},
+	}
+	if q.domain != "" {
+		labels = append(labels, module.Label{Key: "domain", Value: q.domain})
+	}
+	return labels
+}
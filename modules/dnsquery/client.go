@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
+	"github.com/netdata/go.d.plugin/pkg/web"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	protocolDNS = "dns"
+	protocolDoT = "dot"
+	protocolDoH = "doh"
+)
+
+const dohContentType = "application/dns-message"
+
+func newDNSClient(cfg Config) (dnsClient, error) {
+	switch cfg.Protocol {
+	case protocolDoT:
+		tlsConfig, err := tlscfg.NewTLSConfig(cfg.TLSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error on creating TLS config: %v", err)
+		}
+		return &dns.Client{
+			Net:         "tcp-tls",
+			ReadTimeout: cfg.Timeout.Duration,
+			TLSConfig:   tlsConfig,
+		}, nil
+	case protocolDoH:
+		httpClient, err := web.NewHTTPClient(web.Client{Timeout: cfg.Timeout, TLSConfig: cfg.TLSConfig})
+		if err != nil {
+			return nil, err
+		}
+		return &dohClient{httpClient: httpClient, path: cfg.DoHPath}, nil
+	default:
+		return &dns.Client{
+			Net:         cfg.Network,
+			ReadTimeout: cfg.Timeout.Duration,
+		}, nil
+	}
+}
+
+// dohClient is the DNS over HTTPS (RFC 8484) client, it uses the POST method.
+type dohClient struct {
+	httpClient *http.Client
+	path       string
+}
+
+func (c *dohClient) Exchange(msg *dns.Msg, address string) (*dns.Msg, time.Duration, error) {
+	// RFC 8484 (4.1): the DNS ID should be 0 in every DNS request, it makes the responses more cache-friendly
+	msg.Id = 0
+
+	bs, err := msg.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "https://"+address+c.path, bytes.NewReader(bs))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("'%s' returned HTTP status code: %d", req.URL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	rtt := time.Since(start)
+
+	var answer dns.Msg
+	if err := answer.Unpack(body); err != nil {
+		return nil, 0, fmt.Errorf("error on unpacking the '%s' response: %v", req.URL, err)
+	}
+
+	return &answer, rtt, nil
+}
+
+// isCertVerificationError reports whether the error is the server certificate verification error.
+func isCertVerificationError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid)
+}
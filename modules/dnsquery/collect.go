@@ -13,56 +13,136 @@ import (
 )
 
 func (d *DNSQuery) collect() (map[string]int64, error) {
-	if d.dnsClient == nil {
-		d.dnsClient = d.newDNSClient(d.Network, d.Timeout.Duration)
-	}
-
 	mx := make(map[string]int64)
-	domain := randomDomain(d.Domains)
-	d.Debugf("current domain : %s", domain)
+	randDomain := randomDomain(d.Domains)
+	d.Debugf("current domain : %s", randDomain)
 
 	var wg sync.WaitGroup
 	var mux sync.RWMutex
 	for _, srv := range d.Servers {
-		for rtypeName, rtype := range d.recordTypes {
+		for _, q := range d.queries {
+			domain := q.domain
+			if domain == "" {
+				domain = randDomain
+			}
 			wg.Add(1)
-			go func(srv, rtypeName string, rtype uint16, wg *sync.WaitGroup) {
+			go func(srv, domain, rtypeName string, rtype uint16, px string, wg *sync.WaitGroup) {
 				defer wg.Done()
 
 				msg := new(dns.Msg)
 				msg.SetQuestion(dns.Fqdn(domain), rtype)
+				if d.CheckDNSSEC {
+					setDNSSECOK(msg)
+				}
 				address := net.JoinHostPort(srv, strconv.Itoa(d.Port))
 
 				resp, rtt, err := d.dnsClient.Exchange(msg, address)
 
+				var dnssec *dnssecResult
+				if d.CheckDNSSEC && err == nil && resp != nil {
+					res := d.checkDNSSEC(resp, rtype, address)
+					dnssec = &res
+				}
+
 				mux.Lock()
 				defer mux.Unlock()
 
-				px := "server_" + srv + "_record_" + rtypeName + "_"
-
-				mx[px+"query_status_success"] = 0
-				mx[px+"query_status_network_error"] = 0
-				mx[px+"query_status_dns_error"] = 0
+				for _, status := range d.queryStatuses() {
+					mx[px+"query_status_"+status] = 0
+				}
 
+				status := d.queryStatus(resp, err, domain, rtypeName, rtype)
 				if err != nil {
 					d.Debugf("error on querying %s after %s query for %s : %s", srv, rtypeName, domain, err)
-					mx[px+"query_status_network_error"] = 1
-				} else if resp != nil && resp.Rcode != dns.RcodeSuccess {
-					d.Debugf("invalid answer from %s after %s query for %s", srv, rtypeName, domain)
-					mx[px+"query_status_dns_error"] = 1
-				} else {
-					mx[px+"query_status_success"] = 1
-					mx["server_"+srv+"_record_"+rtypeName+"_query_time"] = rtt.Nanoseconds()
+				} else if status != queryStatusSuccess {
+					d.Debugf("invalid answer (%s) from %s after %s query for %s", status, srv, rtypeName, domain)
+				}
+				mx[px+"query_status_"+status] = 1
+
+				if status == queryStatusSuccess {
+					mx[px+"query_time"] = rtt.Nanoseconds()
+					if h, ok := d.queryTimeHists[px]; ok {
+						h.Observe(rtt.Seconds())
+					}
 				}
-			}(srv, rtypeName, rtype, &wg)
+
+				if dnssec != nil {
+					mx[px+"dnssec_valid"] = boolToInt(dnssec.valid)
+					if !dnssec.rrsigExpiry.IsZero() {
+						mx[px+"dnssec_rrsig_expiry"] = int64(time.Until(dnssec.rrsigExpiry).Seconds())
+					}
+				}
+			}(srv, domain, q.rtypeName, q.rtype, q.metricsPrefix(srv), &wg)
 		}
 	}
 	wg.Wait()
 
+	for px, h := range d.queryTimeHists {
+		h.WriteTo(mx, px+"query_time_hist", 1e9, 1)
+	}
+
 	return mx, nil
 }
 
-func randomDomain(domains []string) string {
+func (d *DNSQuery) queryStatus(resp *dns.Msg, err error, domain, rtypeName string, rtype uint16) string {
+	if err != nil {
+		if isCertVerificationError(err) {
+			return queryStatusCertError
+		}
+		return queryStatusNetworkError
+	}
+	if resp == nil {
+		return queryStatusSuccess
+	}
+
+	exp := d.findExpectation(domain, rtypeName)
+	if exp == nil {
+		if resp.Rcode != dns.RcodeSuccess {
+			return queryStatusDNSError
+		}
+		return queryStatusSuccess
+	}
+
+	// the server failed to answer (SERVFAIL, REFUSED, etc.), it is not an unexpected answer
+	if resp.Rcode != exp.rcode && resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return queryStatusDNSError
+	}
+	if err := exp.check(resp, rtype); err != nil {
+		d.Debugf("unexpected answer for %s %s query: %v", domain, rtypeName, err)
+		return queryStatusUnexpectedAnswer
+	}
+	return queryStatusSuccess
+}
+
+// queryStatuses returns the query statuses that are charted.
+func (d *DNSQuery) queryStatuses() []string {
+	return append([]string{queryStatusSuccess, queryStatusNetworkError, queryStatusDNSError}, d.optionalQueryStatuses()...)
+}
+
+// optionalQueryStatuses returns the query statuses that depend on the configuration.
+func (d *DNSQuery) optionalQueryStatuses() []string {
+	var statuses []string
+	if d.Protocol == protocolDoT || d.Protocol == protocolDoH {
+		statuses = append(statuses, queryStatusCertError)
+	}
+	if len(d.expectations) > 0 {
+		statuses = append(statuses, queryStatusUnexpectedAnswer)
+	}
+	return statuses
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+func metricsPrefix(server, rtype string) string {
+	return "server_" + server + "_record_" + rtype + "_"
+}
+
+func randomDomain(domains []Domain) string {
 	rand.Seed(time.Now().UnixNano())
-	return domains[rand.Intn(len(domains))]
+	return domains[rand.Intn(len(domains))].Name
 }
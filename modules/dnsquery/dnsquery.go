@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/miekg/dns"
@@ -25,26 +27,46 @@ func New() *DNSQuery {
 		Config: Config{
 			Timeout:     web.Duration{Duration: time.Second * 2},
 			Network:     "udp",
+			Protocol:    protocolDNS,
 			RecordTypes: []string{"A"},
-			Port:        53,
-		},
-		newDNSClient: func(network string, timeout time.Duration) dnsClient {
-			return &dns.Client{
-				Net:         network,
-				ReadTimeout: timeout,
-			}
+			DoHPath:     "/dns-query",
 		},
+		newDNSClient: newDNSClient,
 	}
 }
 
 type Config struct {
-	Domains     []string
+	Domains     []Domain
 	Servers     []string
 	Network     string
 	RecordType  string   `yaml:"record_type"`
 	RecordTypes []string `yaml:"record_types"`
-	Port        int
-	Timeout     web.Duration
+	// Protocol is the DNS transport: 'dns' (plain DNS over 'network'), 'dot' (DNS over TLS) or 'doh' (DNS over HTTPS).
+	Protocol string `yaml:"protocol"`
+	// Port is the DNS server port, the default depends on the protocol: 53 (dns), 853 (dot) or 443 (doh).
+	Port    int
+	Timeout web.Duration
+	// DoHPath is the DNS over HTTPS endpoint path.
+	DoHPath string `yaml:"doh_path"`
+	// QueryTimeHistogram is the query time histogram buckets in seconds, the histogram is disabled if not set.
+	QueryTimeHistogram []float64 `yaml:"query_time_histogram"`
+	// Expectations is the per domain answer expectations, the query status is 'success' only if the answer meets them.
+	Expectations []Expectation `yaml:"expectations"`
+	// CheckDNSSEC enables the DNSSEC check of the answers (the DO bit is set in the queries).
+	CheckDNSSEC bool `yaml:"check_dnssec"`
+
+	tlscfg.TLSConfig `yaml:",inline"`
+}
+
+type Expectation struct {
+	Domain string `yaml:"domain"`
+	// RecordTypes is the record types the expectation applies to, all the record types if not set.
+	RecordTypes []string `yaml:"record_types"`
+	// Rcode is the expected response code (e.g. 'NXDOMAIN'), 'NOERROR' if not set.
+	Rcode      string `yaml:"rcode"`
+	MinAnswers int    `yaml:"min_answers"`
+	// AnswerMatch is the pattern (matcher syntax) every answer record data of the queried type must match.
+	AnswerMatch string `yaml:"answer_match"`
 }
 
 type (
@@ -55,8 +77,12 @@ type (
 
 		charts *module.Charts
 
-		newDNSClient func(network string, duration time.Duration) dnsClient
-		recordTypes  map[string]uint16
+		newDNSClient func(cfg Config) (dnsClient, error)
+		queries      []query
+		// queryTimeHists is the query time histograms, the key is the server and record type metrics prefix.
+		queryTimeHists map[string]metrics.Histogram
+		// expectations is the answer expectations, the key is the lower-case domain without the trailing dot.
+		expectations map[string][]*expectation
 
 		dnsClient dnsClient
 	}
@@ -72,12 +98,32 @@ func (d *DNSQuery) Init() bool {
 		return false
 	}
 
-	rt, err := d.initRecordTypes()
+	queries, err := d.initQueries()
+	if err != nil {
+		d.Errorf("init queries: %v", err)
+		return false
+	}
+	d.queries = queries
+
+	d.queryTimeHists = d.initQueryTimeHistograms()
+
+	exps, err := d.initExpectations()
+	if err != nil {
+		d.Errorf("init expectations: %v", err)
+		return false
+	}
+	d.expectations = exps
+
+	if d.Port == 0 {
+		d.Port = defaultPort(d.Protocol)
+	}
+
+	client, err := d.newDNSClient(d.Config)
 	if err != nil {
-		d.Errorf("init record type: %v", err)
+		d.Errorf("init DNS client: %v", err)
 		return false
 	}
-	d.recordTypes = rt
+	d.dnsClient = client
 
 	charts, err := d.initCharts()
 	if err != nil {
@@ -3,7 +3,13 @@
 package dnsquery
 
 import (
+	"crypto"
+	"crypto/tls"
 	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -13,6 +19,7 @@ import (
 	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 func TestNew(t *testing.T) {
@@ -27,7 +34,7 @@ func TestDNSQuery_Init(t *testing.T) {
 		"success when all set": {
 			wantFail: false,
 			config: Config{
-				Domains:     []string{"example.com"},
+				Domains:     []Domain{{Name: "example.com"}},
 				Servers:     []string{"192.0.2.0"},
 				Network:     "udp",
 				RecordTypes: []string{"A"},
@@ -38,7 +45,7 @@ func TestDNSQuery_Init(t *testing.T) {
 		"success when using deprecated record_type": {
 			wantFail: false,
 			config: Config{
-				Domains:    []string{"example.com"},
+				Domains:    []Domain{{Name: "example.com"}},
 				Servers:    []string{"192.0.2.0"},
 				Network:    "udp",
 				RecordType: "A",
@@ -46,6 +53,30 @@ func TestDNSQuery_Init(t *testing.T) {
 				Timeout:    web.Duration{Duration: time.Second},
 			},
 		},
+		"success when query time histogram set": {
+			wantFail: false,
+			config: Config{
+				Domains:            []Domain{{Name: "example.com"}},
+				Servers:            []string{"192.0.2.0"},
+				Network:            "udp",
+				RecordTypes:        []string{"A"},
+				Port:               53,
+				Timeout:            web.Duration{Duration: time.Second},
+				QueryTimeHistogram: []float64{0.01, 0.001, 0.1},
+			},
+		},
+		"fail when query time histogram has duplicate buckets": {
+			wantFail: true,
+			config: Config{
+				Domains:            []Domain{{Name: "example.com"}},
+				Servers:            []string{"192.0.2.0"},
+				Network:            "udp",
+				RecordTypes:        []string{"A"},
+				Port:               53,
+				Timeout:            web.Duration{Duration: time.Second},
+				QueryTimeHistogram: []float64{0.01, 0.01},
+			},
+		},
 		"fail with default": {
 			wantFail: true,
 			config:   New().Config,
@@ -64,7 +95,7 @@ func TestDNSQuery_Init(t *testing.T) {
 		"fail when servers not set": {
 			wantFail: true,
 			config: Config{
-				Domains:     []string{"example.com"},
+				Domains:     []Domain{{Name: "example.com"}},
 				Servers:     nil,
 				Network:     "udp",
 				RecordTypes: []string{"A"},
@@ -75,7 +106,7 @@ func TestDNSQuery_Init(t *testing.T) {
 		"fail when network is invalid": {
 			wantFail: true,
 			config: Config{
-				Domains:     []string{"example.com"},
+				Domains:     []Domain{{Name: "example.com"}},
 				Servers:     []string{"192.0.2.0"},
 				Network:     "gcp",
 				RecordTypes: []string{"A"},
@@ -83,10 +114,22 @@ func TestDNSQuery_Init(t *testing.T) {
 				Timeout:     web.Duration{Duration: time.Second},
 			},
 		},
+		"fail when protocol is invalid": {
+			wantFail: true,
+			config: Config{
+				Domains:     []Domain{{Name: "example.com"}},
+				Servers:     []string{"192.0.2.0"},
+				Network:     "udp",
+				Protocol:    "doq",
+				RecordTypes: []string{"A"},
+				Port:        53,
+				Timeout:     web.Duration{Duration: time.Second},
+			},
+		},
 		"fail when record_type is invalid": {
 			wantFail: true,
 			config: Config{
-				Domains:     []string{"example.com"},
+				Domains:     []Domain{{Name: "example.com"}},
 				Servers:     []string{"192.0.2.0"},
 				Network:     "udp",
 				RecordTypes: []string{"B"},
@@ -143,12 +186,50 @@ func TestDNSQuery_Check(t *testing.T) {
 func TestDNSQuery_Charts(t *testing.T) {
 	dq := New()
 
-	dq.Domains = []string{"google.com"}
+	dq.Domains = []Domain{{Name: "google.com"}}
 	dq.Servers = []string{"192.0.2.0", "192.0.2.1"}
 	require.True(t, dq.Init())
 
 	assert.NotNil(t, dq.Charts())
-	assert.Len(t, *dq.Charts(), len(dnsChartsTmpl)*len(dq.Servers))
+	// +1 is the servers query time comparison chart
+	assert.Len(t, *dq.Charts(), len(dnsChartsTmpl)*len(dq.Servers)+1)
+}
+
+func TestDNSQuery_Charts_QueryTimeHistogram(t *testing.T) {
+	dq := New()
+
+	dq.Domains = []Domain{{Name: "google.com"}}
+	dq.Servers = []string{"192.0.2.0", "192.0.2.1"}
+	dq.QueryTimeHistogram = []float64{0.5, 2}
+	require.True(t, dq.Init())
+
+	assert.Len(t, *dq.Charts(), (len(dnsChartsTmpl)+2)*len(dq.Servers)+1)
+
+	chart := dq.Charts().Get("server_192_0_2_0_record_A_query_time_histogram")
+	require.NotNil(t, chart)
+	var ids, names []string
+	for _, dim := range chart.Dims {
+		ids, names = append(ids, dim.ID), append(names, dim.Name)
+	}
+	assert.Equal(t, []string{
+		"server_192.0.2.0_record_A_query_time_hist_bucket_1",
+		"server_192.0.2.0_record_A_query_time_hist_bucket_2",
+		"server_192.0.2.0_record_A_query_time_hist_bucket_inf",
+	}, ids)
+	assert.Equal(t, []string{"0.5", "2", "+Inf"}, names)
+
+	chart = dq.Charts().Get("server_192_0_2_0_record_A_query_time_cumulative_histogram")
+	require.NotNil(t, chart)
+	ids, names = nil, nil
+	for _, dim := range chart.Dims {
+		ids, names = append(ids, dim.ID), append(names, dim.Name)
+	}
+	assert.Equal(t, []string{
+		"server_192.0.2.0_record_A_query_time_hist_cdf_bucket_1",
+		"server_192.0.2.0_record_A_query_time_hist_cdf_bucket_2",
+		"server_192.0.2.0_record_A_query_time_hist_cdf_bucket_inf",
+	}, ids)
+	assert.Equal(t, []string{"0.5", "2", "+Inf"}, names)
 }
 
 func TestDNSQuery_Collect(t *testing.T) {
@@ -169,6 +250,28 @@ func TestDNSQuery_Collect(t *testing.T) {
 				"server_192.0.2.1_record_A_query_time":                 1000000000,
 			},
 		},
+		"success when DNS query successful with query time histogram": {
+			prepare: func() *DNSQuery {
+				dq := caseDNSClientOK()
+				dq.Servers = []string{"192.0.2.0"}
+				dq.QueryTimeHistogram = []float64{2, 0.5}
+				return dq
+			},
+			wantMetrics: map[string]int64{
+				"server_192.0.2.0_record_A_query_status_dns_error":         0,
+				"server_192.0.2.0_record_A_query_status_network_error":     0,
+				"server_192.0.2.0_record_A_query_status_success":           1,
+				"server_192.0.2.0_record_A_query_time":                     1000000000,
+				"server_192.0.2.0_record_A_query_time_hist_bucket_1":       0,
+				"server_192.0.2.0_record_A_query_time_hist_bucket_2":       1,
+				"server_192.0.2.0_record_A_query_time_hist_bucket_inf":     0,
+				"server_192.0.2.0_record_A_query_time_hist_cdf_bucket_1":   0,
+				"server_192.0.2.0_record_A_query_time_hist_cdf_bucket_2":   1,
+				"server_192.0.2.0_record_A_query_time_hist_cdf_bucket_inf": 1,
+				"server_192.0.2.0_record_A_query_time_hist_count":          1,
+				"server_192.0.2.0_record_A_query_time_hist_sum":            1000000000,
+			},
+		},
 		"fail when DNS query returns an error": {
 			prepare: caseDNSClientErr,
 			wantMetrics: map[string]int64{
@@ -195,33 +298,384 @@ func TestDNSQuery_Collect(t *testing.T) {
 	}
 }
 
+func TestDNSQuery_Collect_PerDomainRecordTypes(t *testing.T) {
+	dq := New()
+	dq.Domains = []Domain{
+		{Name: "example.com", Types: []string{"A", "MX"}},
+		{Name: "example.org"},
+	}
+	dq.Servers = []string{"192.0.2.0", "192.0.2.1"}
+	dq.newDNSClient = func(Config) (dnsClient, error) {
+		return mockDNSClient{}, nil
+	}
+	require.True(t, dq.Init())
+
+	// 3 queries (example.com A and MX, example.org A), 2 charts per query per server and a comparison chart per query
+	assert.Len(t, *dq.Charts(), 3*len(dnsChartsTmpl)*len(dq.Servers)+3)
+
+	chart := dq.Charts().Get("server_192_0_2_0_domain_example_com_record_MX_query_time")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "domain", Value: "example.com"})
+
+	chart = dq.Charts().Get("domain_example_org_record_A_servers_query_time")
+	require.NotNil(t, chart)
+	require.Len(t, chart.Dims, 2)
+	assert.Equal(t, "server_192.0.2.1_domain_example.org_record_A_query_time", chart.Dims[1].ID)
+
+	mx := dq.Collect()
+
+	for _, srv := range dq.Servers {
+		for _, v := range []string{"example.com_record_A", "example.com_record_MX", "example.org_record_A"} {
+			px := "server_" + srv + "_domain_" + v + "_"
+			assert.Equal(t, int64(1), mx[px+"query_status_success"], px)
+			assert.Equal(t, int64(1000000000), mx[px+"query_time"], px)
+		}
+	}
+	assert.NotContains(t, mx, "server_192.0.2.0_record_A_query_time")
+}
+
+func TestDomain_UnmarshalYAML(t *testing.T) {
+	var cfg Config
+	data := "domains:\n  - example.com\n  - name: example.org\n    types: [A, AAAA]\n"
+	require.NoError(t, yaml.Unmarshal([]byte(data), &cfg))
+
+	assert.Equal(t, []Domain{
+		{Name: "example.com"},
+		{Name: "example.org", Types: []string{"A", "AAAA"}},
+	}, cfg.Domains)
+}
+
+func TestDNSQuery_Collect_DoTDoH(t *testing.T) {
+	doh := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req dns.Msg
+		if r.Method != http.MethodPost || r.URL.Path != "/dns-query" ||
+			r.Header.Get("Content-Type") != dohContentType || req.Unpack(body) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp := new(dns.Msg)
+		resp.SetReply(&req)
+		bs, _ := resp.Pack()
+		w.Header().Set("Content-Type", dohContentType)
+		_, _ = w.Write(bs)
+	}))
+	defer doh.Close()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: doh.TLS.Certificates})
+	require.NoError(t, err)
+	dot := &dns.Server{
+		Listener: ln,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			resp := new(dns.Msg)
+			resp.SetReply(r)
+			_ = w.WriteMsg(resp)
+		}),
+	}
+	go func() { _ = dot.ActivateAndServe() }()
+	defer func() { _ = dot.Shutdown() }()
+
+	port := func(addr net.Addr) int { return addr.(*net.TCPAddr).Port }
+
+	tests := map[string]struct {
+		protocol   string
+		port       int
+		skipVerify bool
+		wantStatus string
+	}{
+		"dot success":    {protocol: protocolDoT, port: port(ln.Addr()), skipVerify: true, wantStatus: "success"},
+		"dot cert error": {protocol: protocolDoT, port: port(ln.Addr()), wantStatus: "cert_error"},
+		"doh success":    {protocol: protocolDoH, port: port(doh.Listener.Addr()), skipVerify: true, wantStatus: "success"},
+		"doh cert error": {protocol: protocolDoH, port: port(doh.Listener.Addr()), wantStatus: "cert_error"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dq := New()
+			dq.Domains = []Domain{{Name: "example.com"}}
+			dq.Servers = []string{"127.0.0.1"}
+			dq.Protocol = test.protocol
+			dq.Port = test.port
+			dq.InsecureSkipVerify = test.skipVerify
+			require.True(t, dq.Init())
+
+			chart := dq.Charts().Get("server_127_0_0_1_record_A_query_status")
+			require.NotNil(t, chart)
+			assert.True(t, chart.HasDim("server_127.0.0.1_record_A_query_status_cert_error"))
+
+			mx := dq.Collect()
+
+			for _, status := range []string{"success", "network_error", "dns_error", "cert_error"} {
+				key := "server_127.0.0.1_record_A_query_status_" + status
+				require.Containsf(t, mx, key, "key '%s'", key)
+				assert.Equalf(t, boolToInt(status == test.wantStatus), mx[key], "key '%s'", key)
+			}
+		})
+	}
+}
+
+func TestDNSQuery_Collect_Expectations(t *testing.T) {
+	answer := func(rcode int, rrs ...string) *dns.Msg {
+		msg := new(dns.Msg)
+		msg.Rcode = rcode
+		for _, v := range rrs {
+			rr, err := dns.NewRR(v)
+			require.NoError(t, err)
+			msg.Answer = append(msg.Answer, rr)
+		}
+		return msg
+	}
+
+	tests := map[string]struct {
+		expectation Expectation
+		response    *dns.Msg
+		wantStatus  string
+	}{
+		"expected rcode": {
+			expectation: Expectation{Domain: "example.com", Rcode: "nxdomain"},
+			response:    answer(dns.RcodeNameError),
+			wantStatus:  "success",
+		},
+		"unexpected rcode": {
+			expectation: Expectation{Domain: "example.com", Rcode: "NXDOMAIN"},
+			response:    answer(dns.RcodeSuccess, "example.com. 60 IN A 192.0.2.1"),
+			wantStatus:  "unexpected_answer",
+		},
+		"server failure": {
+			expectation: Expectation{Domain: "example.com", Rcode: "NXDOMAIN"},
+			response:    answer(dns.RcodeServerFailure),
+			wantStatus:  "dns_error",
+		},
+		"enough answers": {
+			expectation: Expectation{Domain: "example.com", MinAnswers: 2},
+			response:    answer(dns.RcodeSuccess, "example.com. 60 IN A 192.0.2.1", "example.com. 60 IN A 192.0.2.2"),
+			wantStatus:  "success",
+		},
+		"not enough answers": {
+			expectation: Expectation{Domain: "example.com", MinAnswers: 2},
+			response:    answer(dns.RcodeSuccess, "example.com. 60 IN A 192.0.2.1"),
+			wantStatus:  "unexpected_answer",
+		},
+		"answer matches": {
+			expectation: Expectation{Domain: "example.com.", AnswerMatch: "= 192.0.2.1"},
+			response:    answer(dns.RcodeSuccess, "example.com. 60 IN CNAME www.example.com.", "www.example.com. 60 IN A 192.0.2.1"),
+			wantStatus:  "success",
+		},
+		"answer doesn't match": {
+			expectation: Expectation{Domain: "example.com", AnswerMatch: "~ ^192\\.0\\.2\\."},
+			response:    answer(dns.RcodeSuccess, "example.com. 60 IN A 192.0.2.1", "example.com. 60 IN A 203.0.113.1"),
+			wantStatus:  "unexpected_answer",
+		},
+		"no answers to match": {
+			expectation: Expectation{Domain: "example.com", AnswerMatch: "= 192.0.2.1"},
+			response:    answer(dns.RcodeSuccess),
+			wantStatus:  "unexpected_answer",
+		},
+		"expectation record type case": {
+			expectation: Expectation{Domain: "example.com", RecordTypes: []string{"a"}, Rcode: "NXDOMAIN"},
+			response:    answer(dns.RcodeNameError),
+			wantStatus:  "success",
+		},
+		"expectation for other record type": {
+			expectation: Expectation{Domain: "example.com", RecordTypes: []string{"AAAA"}, Rcode: "NXDOMAIN"},
+			response:    answer(dns.RcodeNameError),
+			wantStatus:  "dns_error",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dq := New()
+			dq.Domains = []Domain{{Name: "example.com"}}
+			dq.Servers = []string{"192.0.2.0"}
+			dq.Expectations = []Expectation{test.expectation}
+			dq.newDNSClient = func(Config) (dnsClient, error) {
+				return mockDNSClient{response: test.response}, nil
+			}
+			require.True(t, dq.Init())
+
+			mx := dq.Collect()
+
+			for _, status := range []string{"success", "network_error", "dns_error", "unexpected_answer"} {
+				key := "server_192.0.2.0_record_A_query_status_" + status
+				require.Containsf(t, mx, key, "key '%s'", key)
+				assert.Equalf(t, boolToInt(status == test.wantStatus), mx[key], "key '%s'", key)
+			}
+		})
+	}
+}
+
+func TestDNSQuery_Init_ExpectationsNG(t *testing.T) {
+	tests := map[string]Expectation{
+		"domain not set":        {Rcode: "NXDOMAIN"},
+		"domain not in domains": {Domain: "example.org"},
+		"unknown rcode":         {Domain: "example.com", Rcode: "NOPE"},
+		"bad answer match":      {Domain: "example.com", AnswerMatch: "192.0.2.1"},
+		"unknown record type":   {Domain: "example.com", RecordTypes: []string{"B"}},
+	}
+
+	for name, exp := range tests {
+		t.Run(name, func(t *testing.T) {
+			dq := New()
+			dq.Domains = []Domain{{Name: "example.com"}}
+			dq.Servers = []string{"192.0.2.0"}
+			dq.Expectations = []Expectation{exp}
+
+			assert.False(t, dq.Init())
+		})
+	}
+}
+
+func TestDNSQuery_Collect_DNSSEC(t *testing.T) {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	require.NoError(t, err)
+
+	a, err := dns.NewRR("example.com. 60 IN A 192.0.2.1")
+	require.NoError(t, err)
+
+	now := time.Now()
+	sign := func(expiration time.Time, rrset ...dns.RR) *dns.RRSIG {
+		sig := &dns.RRSIG{
+			Hdr:        dns.RR_Header{Name: rrset[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 60},
+			KeyTag:     key.KeyTag(),
+			SignerName: key.Hdr.Name,
+			Algorithm:  key.Algorithm,
+			Inception:  uint32(now.Add(-time.Hour).Unix()),
+			Expiration: uint32(expiration.Unix()),
+		}
+		require.NoError(t, sig.Sign(priv.(crypto.Signer), rrset))
+		return sig
+	}
+	validSig := sign(now.Add(time.Hour*24*10), a)
+	expiredSig := sign(now.Add(-time.Minute), a)
+
+	forged, err := dns.NewRR("example.com. 60 IN A 203.0.113.1")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		answer     func(*dns.Msg)
+		wantValid  int64
+		wantExpiry bool
+	}{
+		"AD flag set by the resolver": {
+			answer:     func(m *dns.Msg) { m.AuthenticatedData = true; m.Answer = []dns.RR{a, validSig} },
+			wantValid:  1,
+			wantExpiry: true,
+		},
+		"authoritative answer with a valid signature": {
+			answer:     func(m *dns.Msg) { m.Authoritative = true; m.Answer = []dns.RR{a, validSig} },
+			wantValid:  1,
+			wantExpiry: true,
+		},
+		"authoritative answer with an expired signature": {
+			answer:     func(m *dns.Msg) { m.Authoritative = true; m.Answer = []dns.RR{a, expiredSig} },
+			wantValid:  0,
+			wantExpiry: true,
+		},
+		"authoritative answer with a forged record": {
+			answer:     func(m *dns.Msg) { m.Authoritative = true; m.Answer = []dns.RR{forged, validSig} },
+			wantValid:  0,
+			wantExpiry: true,
+		},
+		"unsigned answer": {
+			answer:    func(m *dns.Msg) { m.Answer = []dns.RR{a} },
+			wantValid: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dq := New()
+			dq.Domains = []Domain{{Name: "example.com"}}
+			dq.Servers = []string{"192.0.2.0"}
+			dq.CheckDNSSEC = true
+			dq.newDNSClient = func(Config) (dnsClient, error) {
+				return mockDNSClientFunc(func(msg *dns.Msg) *dns.Msg {
+					resp := new(dns.Msg)
+					resp.SetReply(msg)
+					if opt := msg.IsEdns0(); opt == nil || !opt.Do() {
+						return resp
+					}
+					if msg.Question[0].Qtype == dns.TypeDNSKEY {
+						resp.Answer = []dns.RR{key}
+					} else {
+						test.answer(resp)
+					}
+					return resp
+				}), nil
+			}
+			require.True(t, dq.Init())
+			require.NotNil(t, dq.Charts().Get("server_192_0_2_0_record_A_dnssec_valid"))
+			require.NotNil(t, dq.Charts().Get("server_192_0_2_0_record_A_dnssec_rrsig_expiry"))
+
+			mx := dq.Collect()
+
+			assert.Equal(t, int64(1), mx["server_192.0.2.0_record_A_query_status_success"])
+			assert.Equal(t, test.wantValid, mx["server_192.0.2.0_record_A_dnssec_valid"])
+			if !test.wantExpiry {
+				assert.NotContains(t, mx, "server_192.0.2.0_record_A_dnssec_rrsig_expiry")
+				return
+			}
+			expiry := mx["server_192.0.2.0_record_A_dnssec_rrsig_expiry"]
+			if test.wantValid == 1 {
+				// the valid signature expires in 10 days
+				assert.InDelta(t, 10*24*3600, expiry, 10)
+			}
+		})
+	}
+}
+
+func TestDNSQuery_Init_DefaultPort(t *testing.T) {
+	for protocol, wantPort := range map[string]int{protocolDNS: 53, protocolDoT: 853, protocolDoH: 443} {
+		dq := New()
+		dq.Domains = []Domain{{Name: "example.com"}}
+		dq.Servers = []string{"192.0.2.0"}
+		dq.Protocol = protocol
+		require.True(t, dq.Init())
+		assert.Equal(t, wantPort, dq.Port)
+	}
+}
+
 func caseDNSClientOK() *DNSQuery {
 	dq := New()
-	dq.Domains = []string{"example.com"}
+	dq.Domains = []Domain{{Name: "example.com"}}
 	dq.Servers = []string{"192.0.2.0", "192.0.2.1"}
-	dq.newDNSClient = func(_ string, _ time.Duration) dnsClient {
-		return mockDNSClient{errOnExchange: false}
+	dq.newDNSClient = func(Config) (dnsClient, error) {
+		return mockDNSClient{errOnExchange: false}, nil
 	}
 	return dq
 }
 
 func caseDNSClientErr() *DNSQuery {
 	dq := New()
-	dq.Domains = []string{"example.com"}
+	dq.Domains = []Domain{{Name: "example.com"}}
 	dq.Servers = []string{"192.0.2.0", "192.0.2.1"}
-	dq.newDNSClient = func(_ string, _ time.Duration) dnsClient {
-		return mockDNSClient{errOnExchange: true}
+	dq.newDNSClient = func(Config) (dnsClient, error) {
+		return mockDNSClient{errOnExchange: true}, nil
 	}
 	return dq
 }
 
+type mockDNSClientFunc func(msg *dns.Msg) *dns.Msg
+
+func (m mockDNSClientFunc) Exchange(msg *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	return m(msg), time.Millisecond, nil
+}
+
 type mockDNSClient struct {
 	errOnExchange bool
+	response      *dns.Msg
 }
 
 func (m mockDNSClient) Exchange(_ *dns.Msg, _ string) (response *dns.Msg, rtt time.Duration, err error) {
 	if m.errOnExchange {
 		return nil, time.Second, errors.New("mock.Exchange() error")
 	}
-	return nil, time.Second, nil
+	return m.response, time.Second, nil
 }
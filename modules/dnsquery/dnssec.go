@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const dnssecUDPSize = 4096
+
+// dnssecResult is the DNSSEC check result of the answer.
+type dnssecResult struct {
+	valid bool
+	// rrsigExpiry is the earliest expiration of the answer RRset signatures, zero if there are no signatures.
+	rrsigExpiry time.Time
+}
+
+// setDNSSECOK sets the DNSSEC OK (DO) bit and asks for the AD (authenticated data) flag in the response (RFC 6840).
+func setDNSSECOK(msg *dns.Msg) {
+	msg.SetEdns0(dnssecUDPSize, true)
+	msg.AuthenticatedData = true
+}
+
+// checkDNSSEC checks the answer of the queried type. The answer is valid if:
+//   - the AD flag is set (validated by the resolver), or
+//   - the answer is authoritative and the RRset signatures are verified with the zone DNSKEY records queried from the
+//     same server. Note that the DNSKEY records themselves are not validated (no chain of trust to the root).
+func (d *DNSQuery) checkDNSSEC(resp *dns.Msg, qtype uint16, address string) dnssecResult {
+	var res dnssecResult
+
+	rrset, sigs := answerRRsetAndSigs(resp, qtype)
+	for _, sig := range sigs {
+		if exp := rrsigExpiration(sig); res.rrsigExpiry.IsZero() || exp.Before(res.rrsigExpiry) {
+			res.rrsigExpiry = exp
+		}
+	}
+
+	if resp.AuthenticatedData {
+		res.valid = true
+		return res
+	}
+	if !resp.Authoritative || len(rrset) == 0 || len(sigs) == 0 {
+		return res
+	}
+
+	if err := d.verifyRRSIGs(rrset, sigs, address); err != nil {
+		d.Debugf("DNSSEC verification of %s %s answer from %s: %v",
+			rrset[0].Header().Name, dns.TypeToString[qtype], address, err)
+		return res
+	}
+	res.valid = true
+	return res
+}
+
+func (d *DNSQuery) verifyRRSIGs(rrset []dns.RR, sigs []*dns.RRSIG, address string) error {
+	keys := make(map[string][]*dns.DNSKEY)
+
+	for _, sig := range sigs {
+		if !sig.ValidityPeriod(time.Now()) {
+			return fmt.Errorf("RRSIG (key tag %d) is not in the validity period", sig.KeyTag)
+		}
+
+		zoneKeys, ok := keys[sig.SignerName]
+		if !ok {
+			var err error
+			if zoneKeys, err = d.queryDNSKEYs(sig.SignerName, address); err != nil {
+				return err
+			}
+			keys[sig.SignerName] = zoneKeys
+		}
+
+		var verified bool
+		for _, key := range zoneKeys {
+			if key.KeyTag() == sig.KeyTag && key.Algorithm == sig.Algorithm && sig.Verify(key, rrset) == nil {
+				verified = true
+				break
+			}
+		}
+		if !verified {
+			return fmt.Errorf("RRSIG (key tag %d) is not verified by any '%s' DNSKEY", sig.KeyTag, sig.SignerName)
+		}
+	}
+
+	return nil
+}
+
+func (d *DNSQuery) queryDNSKEYs(zone, address string) ([]*dns.DNSKEY, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(zone, dns.TypeDNSKEY)
+	setDNSSECOK(msg)
+
+	resp, _, err := d.dnsClient.Exchange(msg, address)
+	if err != nil {
+		return nil, fmt.Errorf("'%s' DNSKEY query: %v", zone, err)
+	}
+	if resp == nil || resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("'%s' DNSKEY query: invalid answer", zone)
+	}
+
+	var keys []*dns.DNSKEY
+	for _, rr := range resp.Answer {
+		if key, ok := rr.(*dns.DNSKEY); ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no DNSKEY records for '" + zone + "'")
+	}
+	return keys, nil
+}
+
+// answerRRsetAndSigs returns the answer records of the type and the signatures covering them.
+func answerRRsetAndSigs(resp *dns.Msg, qtype uint16) (rrset []dns.RR, sigs []*dns.RRSIG) {
+	for _, rr := range resp.Answer {
+		switch v := rr.(type) {
+		case *dns.RRSIG:
+			if v.TypeCovered == qtype {
+				sigs = append(sigs, v)
+			}
+		default:
+			if rr.Header().Rrtype == qtype {
+				rrset = append(rrset, rr)
+			}
+		}
+	}
+	return rrset, sigs
+}
+
+// rrsigExpiration converts the RRSIG expiration (the serial number arithmetic, RFC 1982) to the time.
+func rrsigExpiration(sig *dns.RRSIG) time.Time {
+	// the same conversion as in RRSIG.ValidityPeriod
+	utc := time.Now().UTC().Unix()
+	modi := (int64(sig.Expiration) - utc) / year68
+	return time.Unix(int64(sig.Expiration)+modi*year68, 0)
+}
+
+const year68 = 1 << 31
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	queryStatusSuccess          = "success"
+	queryStatusNetworkError     = "network_error"
+	queryStatusDNSError         = "dns_error"
+	queryStatusCertError        = "cert_error"
+	queryStatusUnexpectedAnswer = "unexpected_answer"
+)
+
+// expectation is the parsed Expectation.
+type expectation struct {
+	recordTypes map[string]bool
+	rcode       int
+	minAnswers  int
+	answerMatch matcher.Matcher
+}
+
+func (d *DNSQuery) initExpectations() (map[string][]*expectation, error) {
+	if len(d.Expectations) == 0 {
+		return nil, nil
+	}
+
+	domains := make(map[string]bool)
+	for _, v := range d.Domains {
+		domains[normalizeDomain(v.Name)] = true
+	}
+
+	exps := make(map[string][]*expectation)
+	for _, v := range d.Expectations {
+		if v.Domain == "" {
+			return nil, errors.New("expectation: 'domain' not set")
+		}
+		domain := normalizeDomain(v.Domain)
+		if !domains[domain] {
+			return nil, fmt.Errorf("expectation: domain '%s' is not in the 'domains' list", v.Domain)
+		}
+
+		exp := &expectation{rcode: dns.RcodeSuccess, minAnswers: v.MinAnswers}
+		if v.Rcode != "" {
+			rcode, ok := dns.StringToRcode[strings.ToUpper(v.Rcode)]
+			if !ok {
+				return nil, fmt.Errorf("expectation '%s': unknown rcode '%s'", v.Domain, v.Rcode)
+			}
+			exp.rcode = rcode
+		}
+		if v.AnswerMatch != "" {
+			m, err := matcher.Parse(v.AnswerMatch)
+			if err != nil {
+				return nil, fmt.Errorf("expectation '%s': answer match: %v", v.Domain, err)
+			}
+			exp.answerMatch = m
+		}
+		if len(v.RecordTypes) > 0 {
+			exp.recordTypes = make(map[string]bool)
+			for _, rtype := range v.RecordTypes {
+				rtype = strings.ToUpper(rtype)
+				if _, err := parseRecordType(rtype); err != nil {
+					return nil, fmt.Errorf("expectation '%s': %v", v.Domain, err)
+				}
+				exp.recordTypes[rtype] = true
+			}
+		}
+
+		exps[domain] = append(exps[domain], exp)
+	}
+
+	return exps, nil
+}
+
+// findExpectation returns the domain and record type expectation, nil if there is none.
+// The record types are compared case-insensitively.
+func (d *DNSQuery) findExpectation(domain, rtypeName string) *expectation {
+	for _, exp := range d.expectations[normalizeDomain(domain)] {
+		if exp.recordTypes == nil || exp.recordTypes[strings.ToUpper(rtypeName)] {
+			return exp
+		}
+	}
+	return nil
+}
+
+// check returns an error if the response doesn't meet the expectation.
+// All the answer records of the queried type must match the 'answer_match' (CNAME records are skipped).
+func (e *expectation) check(resp *dns.Msg, qtype uint16) error {
+	if resp.Rcode != e.rcode {
+		return fmt.Errorf("rcode '%s', expected '%s'", dns.RcodeToString[resp.Rcode], dns.RcodeToString[e.rcode])
+	}
+	if len(resp.Answer) < e.minAnswers {
+		return fmt.Errorf("%d answer records, expected at least %d", len(resp.Answer), e.minAnswers)
+	}
+	if e.answerMatch == nil {
+		return nil
+	}
+
+	var matched int
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype != qtype {
+			continue
+		}
+		rdata := answerRDATA(rr)
+		if !e.answerMatch.MatchString(rdata) {
+			return fmt.Errorf("answer '%s' doesn't match", rdata)
+		}
+		matched++
+	}
+	if matched == 0 {
+		return errors.New("no answer records to match")
+	}
+
+	return nil
+}
+
+// answerRDATA returns the answer record data in the presentation format (e.g. '192.0.2.1' for an A record).
+func answerRDATA(rr dns.RR) string {
+	return strings.TrimSpace(strings.TrimPrefix(rr.String(), rr.Header().String()))
+}
+
+func normalizeDomain(domain string) string {
+	return strings.ToLower(strings.TrimSuffix(domain, "."))
+}
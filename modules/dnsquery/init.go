@@ -5,7 +5,9 @@ package dnsquery
 import (
 	"errors"
 	"fmt"
+
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 
 	"github.com/miekg/dns"
 )
@@ -23,6 +25,14 @@ func (d *DNSQuery) verifyConfig() error {
 		return fmt.Errorf("wrong network transport : %s", d.Network)
 	}
 
+	switch d.Protocol {
+	case "":
+		d.Protocol = protocolDNS
+	case protocolDNS, protocolDoT, protocolDoH:
+	default:
+		return fmt.Errorf("wrong protocol : %s", d.Protocol)
+	}
+
 	if d.RecordType != "" {
 		d.Warning("'record_type' config option is deprecated, use 'record_types' instead")
 		d.RecordTypes = append(d.RecordTypes, d.RecordType)
@@ -32,38 +42,88 @@ func (d *DNSQuery) verifyConfig() error {
 		return errors.New("no record types specified")
 	}
 
+	if err := metrics.ValidateBuckets(d.QueryTimeHistogram); err != nil {
+		return fmt.Errorf("query time histogram: %v", err)
+	}
+
 	return nil
 }
 
-func (d *DNSQuery) initRecordTypes() (map[string]uint16, error) {
-	types := make(map[string]uint16)
-	for _, v := range d.RecordTypes {
-		rtype, err := parseRecordType(v)
-		if err != nil {
-			return nil, err
-		}
-		types[v] = rtype
+func (d *DNSQuery) initQueryTimeHistograms() map[string]metrics.Histogram {
+	if len(d.QueryTimeHistogram) == 0 {
+		return nil
+	}
 
+	hists := make(map[string]metrics.Histogram)
+	for _, srv := range d.Servers {
+		for _, q := range d.queries {
+			hists[q.metricsPrefix(srv)] = metrics.NewHistogramWithCDF(d.QueryTimeHistogram)
+		}
 	}
 
-	return types, nil
+	return hists
 }
 
 func (d *DNSQuery) initCharts() (*module.Charts, error) {
 	var charts module.Charts
 
 	for _, srv := range d.Servers {
-		for _, rtype := range d.RecordTypes {
-			cs := newDNSServerCharts(srv, d.Network, rtype)
+		for _, q := range d.queries {
+			cs := newDNSServerCharts(srv, d.transport(), q)
+			for _, status := range d.optionalQueryStatuses() {
+				addQueryStatusDim(cs, srv, q, status)
+			}
 			if err := charts.Add(*cs...); err != nil {
 				return nil, err
 			}
+			if d.CheckDNSSEC {
+				if err := charts.Add(*newDNSSECCharts(srv, d.transport(), q)...); err != nil {
+					return nil, err
+				}
+			}
+			if len(d.QueryTimeHistogram) == 0 {
+				continue
+			}
+			if err := charts.Add(*newQueryTimeHistogramCharts(srv, d.transport(), q, d.QueryTimeHistogram)...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(d.Servers) > 1 {
+		for _, q := range d.queries {
+			if err := charts.Add(newServersQueryTimeChart(d.Servers, q)); err != nil {
+				return nil, err
+			}
 		}
 	}
 
 	return &charts, nil
 }
 
+// transport returns the network label value.
+func (d *DNSQuery) transport() string {
+	switch d.Protocol {
+	case protocolDoT:
+		return "tcp-tls"
+	case protocolDoH:
+		return "https"
+	default:
+		return d.Network
+	}
+}
+
+func defaultPort(protocol string) int {
+	switch protocol {
+	case protocolDoT:
+		return 853
+	case protocolDoH:
+		return 443
+	default:
+		return 53
+	}
+}
+
 func parseRecordType(recordType string) (uint16, error) {
 	var rtype uint16
 
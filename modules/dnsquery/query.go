@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dnsquery
+
+import (
+	"errors"
+	"strings"
+)
+
+// Domain is the domain to query. It is either a name (the flat form, the 'record_types' are queried)
+// or a name with the record types to query:
+//
+//	domains:
+//	  - example.com
+//	  - name: example.org
+//	    types: [A, AAAA, MX]
+type Domain struct {
+	Name  string   `yaml:"name"`
+	Types []string `yaml:"types"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, it allows the domain to be set as a name.
+func (d *Domain) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err == nil {
+		d.Name = name
+		return nil
+	}
+	type plain Domain
+	return unmarshal((*plain)(d))
+}
+
+// query is the DNS query executed against every server on every data collection.
+type query struct {
+	// domain is the domain to query, a random domain of the 'domains' list is queried if not set.
+	domain    string
+	rtypeName string
+	rtype     uint16
+}
+
+// initQueries creates the queries. If no domain has the record types set, a random domain is queried on every
+// data collection (the 'record_types' of it). Otherwise, every domain is queried on every data collection
+// (its own record types, the 'record_types' if not set).
+func (d *DNSQuery) initQueries() ([]query, error) {
+	perDomain := false
+	for _, v := range d.Domains {
+		if v.Name == "" {
+			return nil, errors.New("domain name not set")
+		}
+		perDomain = perDomain || len(v.Types) > 0
+	}
+
+	if !perDomain {
+		var queries []query
+		for _, rtypeName := range d.RecordTypes {
+			rtype, err := parseRecordType(rtypeName)
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, query{rtypeName: rtypeName, rtype: rtype})
+		}
+		return queries, nil
+	}
+
+	var queries []query
+	for _, v := range d.Domains {
+		types := v.Types
+		if len(types) == 0 {
+			types = d.RecordTypes
+		}
+		for _, rtypeName := range types {
+			rtype, err := parseRecordType(rtypeName)
+			if err != nil {
+				return nil, err
+			}
+			queries = append(queries, query{domain: v.Name, rtypeName: rtypeName, rtype: rtype})
+		}
+	}
+	return queries, nil
+}
+
+// metricsPrefix returns the query metrics prefix for the server.
+func (q query) metricsPrefix(server string) string {
+	if q.domain == "" {
+		return metricsPrefix(server, q.rtypeName)
+	}
+	return "server_" + server + "_domain_" + q.domain + "_record_" + q.rtypeName + "_"
+}
+
+// id returns the query ID used in the chart IDs.
+func (q query) id() string {
+	if q.domain == "" {
+		return "record_" + q.rtypeName
+	}
+	return "domain_" + idReplacer.Replace(q.domain) + "_record_" + q.rtypeName
+}
+
+var idReplacer = strings.NewReplacer(".", "_", ":", "_")
@@ -2,23 +2,38 @@
 
 package docker
 
-import "github.com/netdata/go.d.plugin/agent/module"
+import (
+	"fmt"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
 
 const (
 	prioContainersState = module.Priority + iota
 	prioContainersHealthy
 	prioContainersUnhealthy
+	prioContainersHealthStatus
 	prioImagesCount
 	prioImagesSize
+	prioImagesAge
+
+	prioContainerHealthStatus
+	prioContainerCPUUsage
+	prioContainerMemUsage
+	prioContainerMemUtilization
+	prioContainerNetIO
+	prioContainerBlockIO
 )
 
 var charts = module.Charts{
 	containersStateChart.Copy(),
 	containersHealthyChart.Copy(),
 	containersUnhealthyChart.Copy(),
+	containersHealthStatusChart.Copy(),
 
 	imagesCountChart.Copy(),
 	imagesSizeChart.Copy(),
+	imagesAgeChart.Copy(),
 }
 
 var (
@@ -58,6 +73,21 @@ var (
 			{ID: "unhealthy_containers", Name: "unhealthy"},
 		},
 	}
+	containersHealthStatusChart = module.Chart{
+		ID:       "containers_health_status",
+		Title:    "Number of running containers in different health check states",
+		Units:    "containers",
+		Fam:      "containers",
+		Ctx:      "docker.containers_health_status",
+		Priority: prioContainersHealthStatus,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "containers_health_status_healthy", Name: "healthy"},
+			{ID: "containers_health_status_unhealthy", Name: "unhealthy"},
+			{ID: "containers_health_status_starting", Name: "starting"},
+			{ID: "containers_health_status_none", Name: "none"},
+		},
+	}
 )
 
 var (
@@ -85,4 +115,134 @@ var (
 			{ID: "images_size", Name: "size"},
 		},
 	}
+	imagesAgeChart = module.Chart{
+		ID:       "images_age",
+		Title:    "Oldest and newest images age",
+		Units:    "days",
+		Fam:      "images",
+		Ctx:      "docker.images_age",
+		Priority: prioImagesAge,
+		Dims: module.Dims{
+			{ID: "images_oldest_age", Name: "oldest", Div: 86400},
+			{ID: "images_newest_age", Name: "newest", Div: 86400},
+		},
+	}
 )
+
+var (
+	containerStatsChartsTmpl = module.Charts{
+		containerCPUUsageChartTmpl.Copy(),
+		containerMemUsageChartTmpl.Copy(),
+		containerMemUtilizationChartTmpl.Copy(),
+		containerNetIOChartTmpl.Copy(),
+		containerBlockIOChartTmpl.Copy(),
+	}
+
+	containerHealthStatusChartTmpl = module.Chart{
+		ID:       "container_%s_health_status",
+		Title:    "Container health check status",
+		Units:    "status",
+		Fam:      "containers",
+		Ctx:      "docker.container_health_status",
+		Priority: prioContainerHealthStatus,
+		Dims: module.Dims{
+			{ID: "container_%s_health_status_healthy", Name: "healthy"},
+			{ID: "container_%s_health_status_unhealthy", Name: "unhealthy"},
+			{ID: "container_%s_health_status_starting", Name: "starting"},
+			{ID: "container_%s_health_status_none", Name: "none"},
+		},
+	}
+	containerCPUUsageChartTmpl = module.Chart{
+		ID:       "container_%s_cpu_usage",
+		Title:    "Container CPU usage",
+		Units:    "percentage",
+		Fam:      "containers",
+		Ctx:      "docker.container_cpu_usage",
+		Priority: prioContainerCPUUsage,
+		Dims: module.Dims{
+			{ID: "container_%s_cpu_usage", Name: "used", Div: precision},
+		},
+	}
+	containerMemUsageChartTmpl = module.Chart{
+		ID:       "container_%s_mem_usage",
+		Title:    "Container memory usage",
+		Units:    "B",
+		Fam:      "containers",
+		Ctx:      "docker.container_mem_usage",
+		Priority: prioContainerMemUsage,
+		Dims: module.Dims{
+			{ID: "container_%s_mem_usage", Name: "used"},
+		},
+	}
+	containerMemUtilizationChartTmpl = module.Chart{
+		ID:       "container_%s_mem_utilization",
+		Title:    "Container memory utilization",
+		Units:    "percentage",
+		Fam:      "containers",
+		Ctx:      "docker.container_mem_utilization",
+		Priority: prioContainerMemUtilization,
+		Dims: module.Dims{
+			{ID: "container_%s_mem_utilization", Name: "utilization", Div: precision},
+		},
+	}
+	containerNetIOChartTmpl = module.Chart{
+		ID:       "container_%s_net_io",
+		Title:    "Container network traffic",
+		Units:    "B/s",
+		Fam:      "containers",
+		Ctx:      "docker.container_net_io",
+		Type:     module.Area,
+		Priority: prioContainerNetIO,
+		Dims: module.Dims{
+			{ID: "container_%s_net_rx", Name: "received", Algo: module.Incremental},
+			{ID: "container_%s_net_tx", Name: "sent", Algo: module.Incremental, Mul: -1},
+		},
+	}
+	containerBlockIOChartTmpl = module.Chart{
+		ID:       "container_%s_block_io",
+		Title:    "Container block I/O",
+		Units:    "B/s",
+		Fam:      "containers",
+		Ctx:      "docker.container_block_io",
+		Type:     module.Area,
+		Priority: prioContainerBlockIO,
+		Dims: module.Dims{
+			{ID: "container_%s_block_io_read", Name: "read", Algo: module.Incremental},
+			{ID: "container_%s_block_io_write", Name: "write", Algo: module.Incremental, Mul: -1},
+		},
+	}
+)
+
+// addContainerCharts adds the container health status chart, and the stats charts if 'collect_container_stats' is enabled.
+func (d *Docker) addContainerCharts(name, image string) {
+	charts := module.Charts{containerHealthStatusChartTmpl.Copy()}
+	if d.CollectContainerStats {
+		charts = append(charts, *containerStatsChartsTmpl.Copy()...)
+	}
+
+	for _, chart := range charts {
+		chart.ID = fmt.Sprintf(chart.ID, name)
+		chart.Labels = []module.Label{
+			{Key: "container_name", Value: name},
+			{Key: "image", Value: image},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, name)
+		}
+	}
+
+	if err := d.Charts().Add(charts...); err != nil {
+		d.Warning(err)
+	}
+}
+
+func (d *Docker) removeContainerCharts(name string) {
+	for _, tmpl := range append(module.Charts{&containerHealthStatusChartTmpl}, containerStatsChartsTmpl...) {
+		chart := d.Charts().Get(fmt.Sprintf(tmpl.ID, name))
+		if chart == nil {
+			continue
+		}
+		chart.MarkRemove()
+		chart.MarkNotCreated()
+	}
+}
@@ -4,6 +4,8 @@ package docker
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
@@ -22,14 +24,31 @@ func (d *Docker) collect() (map[string]int64, error) {
 
 	mx := make(map[string]int64)
 
-	if err := d.collectInfo(mx); err != nil {
-		return nil, err
+	if d.selector == nil {
+		if err := d.collectInfo(mx); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := d.collectContainersState(mx); err != nil {
+			return nil, err
+		}
 	}
-	if err := d.collectContainersHealth(mx); err != nil {
+
+	running, err := d.listRunningContainers()
+	if err != nil {
 		return nil, err
 	}
-	if err := d.collectImages(mx); err != nil {
-		return nil, err
+	running = d.selectContainers(running)
+
+	d.collectContainersHealth(mx, running)
+
+	if d.CollectImages {
+		if err := d.collectImages(mx); err != nil {
+			return nil, err
+		}
+	}
+	if d.CollectContainerStats {
+		d.collectContainersStats(mx, running)
 	}
 
 	return mx, nil
@@ -51,6 +70,45 @@ func (d *Docker) collectInfo(mx map[string]int64) error {
 	return nil
 }
 
+// collectContainersState counts the containers states itself (instead of using the Info counters)
+// to take into account only the containers selected by the 'container_selector'.
+func (d *Docker) collectContainersState(mx map[string]int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
+	defer cancel()
+
+	containers, err := d.client.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return err
+	}
+
+	mx["running_containers"] = 0
+	mx["paused_containers"] = 0
+	mx["exited_containers"] = 0
+	if d.CountIgnoredContainers {
+		mx["ignored_containers"] = 0
+	}
+
+	for _, cntr := range containers {
+		if !d.selector.match(cntr) {
+			if d.CountIgnoredContainers {
+				mx["ignored_containers"]++
+			}
+			continue
+		}
+		// the same as the Info counters: everything that is not running or paused is stopped
+		switch cntr.State {
+		case "running":
+			mx["running_containers"]++
+		case "paused":
+			mx["paused_containers"]++
+		default:
+			mx["exited_containers"]++
+		}
+	}
+
+	return nil
+}
+
 func (d *Docker) collectImages(mx map[string]int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
 	defer cancel()
@@ -64,39 +122,126 @@ func (d *Docker) collectImages(mx map[string]int64) error {
 	mx["images_dangling"] = 0
 	mx["images_active"] = 0
 
+	var oldest, newest int64
 	for _, v := range images {
 		mx["images_size"] += v.Size
-		if v.Containers == 0 {
+		if isDanglingImage(v) {
 			mx["images_dangling"]++
 		} else {
 			mx["images_active"]++
 		}
+		if oldest == 0 || v.Created < oldest {
+			oldest = v.Created
+		}
+		if v.Created > newest {
+			newest = v.Created
+		}
+	}
+
+	if len(images) > 0 {
+		now := time.Now().Unix()
+		mx["images_oldest_age"] = now - oldest
+		mx["images_newest_age"] = now - newest
 	}
 
 	return nil
 }
 
-func (d *Docker) collectContainersHealth(mx map[string]int64) error {
-	ctx1, cancel1 := context.WithTimeout(context.Background(), d.Timeout.Duration)
-	defer cancel1()
+// isDanglingImage returns true if the image has no tags (the same as the 'dangling=true' images filter).
+// Note that ImageSummary.Containers can't be used to find the unused images, it is -1 (not calculated)
+// if the images are not listed by the 'system df'.
+func isDanglingImage(img types.ImageSummary) bool {
+	for _, tag := range img.RepoTags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	healthStatusHealthy   = "healthy"
+	healthStatusUnhealthy = "unhealthy"
+	healthStatusStarting  = "starting"
+	healthStatusNone      = "none"
+)
 
-	args := filters.NewArgs(filters.KeyValuePair{Key: "health", Value: "healthy"})
-	healthy, err := d.client.ContainerList(ctx1, types.ContainerListOptions{Filters: args})
-	if err != nil {
-		return err
+var healthStatuses = []string{healthStatusHealthy, healthStatusUnhealthy, healthStatusStarting, healthStatusNone}
+
+func (d *Docker) collectContainersHealth(mx map[string]int64, running []types.Container) {
+	for _, v := range healthStatuses {
+		mx["containers_health_status_"+v] = 0
 	}
 
-	ctx2, cancel2 := context.WithTimeout(context.Background(), d.Timeout.Duration)
-	defer cancel2()
+	seen := make(map[string]bool)
+	for _, cntr := range running {
+		name := containerName(cntr)
+		seen[name] = true
+
+		state, ok := d.containers[name]
+		if !ok || state.id != cntr.ID {
+			if ok {
+				d.removeContainerCharts(name)
+			}
+			d.containers[name] = &containerState{id: cntr.ID}
+			d.addContainerCharts(name, cntr.Image)
+		}
 
-	args = filters.NewArgs(filters.KeyValuePair{Key: "health", Value: "unhealthy"})
-	unhealthy, err := d.client.ContainerList(ctx2, types.ContainerListOptions{Filters: args})
-	if err != nil {
-		return err
+		health := containerHealthStatus(cntr)
+		mx["containers_health_status_"+health]++
+
+		px := "container_" + name + "_"
+		for _, v := range healthStatuses {
+			mx[px+"health_status_"+v] = boolToInt(health == v)
+		}
+	}
+
+	for name := range d.containers {
+		if !seen[name] {
+			delete(d.containers, name)
+			d.removeContainerCharts(name)
+		}
 	}
 
-	mx["healthy_containers"] = int64(len(healthy))
-	mx["unhealthy_containers"] = int64(len(unhealthy))
+	mx["healthy_containers"] = mx["containers_health_status_"+healthStatusHealthy]
+	mx["unhealthy_containers"] = mx["containers_health_status_"+healthStatusUnhealthy]
+}
 
-	return nil
+func (d *Docker) listRunningContainers() ([]types.Container, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
+	defer cancel()
+
+	args := filters.NewArgs(filters.KeyValuePair{Key: "status", Value: "running"})
+
+	return d.client.ContainerList(ctx, types.ContainerListOptions{Filters: args})
+}
+
+// containerHealthStatus returns the container health check status parsed from the container status
+// (e.g. 'Up 5 minutes (healthy)', 'Up 3 seconds (health: starting)'), 'none' if there is no health check.
+func containerHealthStatus(cntr types.Container) string {
+	switch {
+	case strings.HasSuffix(cntr.Status, "(healthy)"):
+		return healthStatusHealthy
+	case strings.HasSuffix(cntr.Status, "(unhealthy)"):
+		return healthStatusUnhealthy
+	case strings.HasSuffix(cntr.Status, "(health: starting)"):
+		return healthStatusStarting
+	default:
+		return healthStatusNone
+	}
+}
+
+// selectContainers returns the containers selected by the 'container_selector', all the containers if it is not set.
+func (d *Docker) selectContainers(containers []types.Container) []types.Container {
+	if d.selector == nil {
+		return containers
+	}
+
+	var selected []types.Container
+	for _, cntr := range containers {
+		if d.selector.match(cntr) {
+			selected = append(selected, cntr)
+		}
+	}
+	return selected
 }
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+)
+
+const precision = 1000
+
+// containerState is the running container collection state.
+type containerState struct {
+	id string
+	// prevCPU is the previous data collection CPU stats, the one-shot stats have no 'precpu_stats'.
+	prevCPU *types.CPUStats
+}
+
+func (d *Docker) collectContainersStats(mx map[string]int64, containers []types.Container) {
+	stats := d.queryContainersStats(containers)
+
+	for _, cntr := range containers {
+		name := containerName(cntr)
+		state, ok := d.containers[name]
+		if !ok {
+			continue
+		}
+		st, ok := stats[cntr.ID]
+		if !ok {
+			continue
+		}
+
+		px := "container_" + name + "_"
+
+		if state.prevCPU != nil {
+			mx[px+"cpu_usage"] = int64(calcCPUPercent(*state.prevCPU, st.CPUStats) * precision)
+		}
+		cpu := st.CPUStats
+		state.prevCPU = &cpu
+
+		used := memUsageNoCache(st.MemoryStats)
+		mx[px+"mem_usage"] = int64(used)
+		if st.MemoryStats.Limit > 0 {
+			mx[px+"mem_utilization"] = int64(float64(used) * 100 / float64(st.MemoryStats.Limit) * precision)
+		}
+
+		mx[px+"net_rx"], mx[px+"net_tx"] = 0, 0
+		for _, v := range st.Networks {
+			mx[px+"net_rx"] += int64(v.RxBytes)
+			mx[px+"net_tx"] += int64(v.TxBytes)
+		}
+
+		mx[px+"block_io_read"], mx[px+"block_io_write"] = 0, 0
+		for _, v := range st.BlkioStats.IoServiceBytesRecursive {
+			switch strings.ToLower(v.Op) {
+			case "read":
+				mx[px+"block_io_read"] += int64(v.Value)
+			case "write":
+				mx[px+"block_io_write"] += int64(v.Value)
+			}
+		}
+	}
+}
+
+// queryContainersStats queries the containers stats using at most 'container_stats_concurrency' workers.
+// The containers with failed stats queries are not in the result.
+func (d *Docker) queryContainersStats(containers []types.Container) map[string]*types.StatsJSON {
+	stats := make(map[string]*types.StatsJSON)
+	if len(containers) == 0 {
+		return stats
+	}
+
+	workers := d.ContainerStatsConcurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(containers) {
+		workers = len(containers)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ids := make(chan string)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range ids {
+				st, err := d.queryContainerStats(id)
+				if err != nil {
+					d.Warningf("container '%s' stats: %v", id, err)
+					continue
+				}
+				mu.Lock()
+				stats[id] = st
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, cntr := range containers {
+		ids <- cntr.ID
+	}
+	close(ids)
+	wg.Wait()
+
+	return stats
+}
+
+func (d *Docker) queryContainerStats(id string) (*types.StatsJSON, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout.Duration)
+	defer cancel()
+
+	resp, err := d.client.ContainerStatsOneShot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var st types.StatsJSON
+	if err := json.NewDecoder(resp.Body).Decode(&st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// calcCPUPercent calculates the CPU usage percentage the same way as 'docker stats' does.
+func calcCPUPercent(prev, cur types.CPUStats) float64 {
+	if cur.CPUUsage.TotalUsage < prev.CPUUsage.TotalUsage || cur.SystemUsage <= prev.SystemUsage {
+		return 0
+	}
+
+	cpuDelta := float64(cur.CPUUsage.TotalUsage - prev.CPUUsage.TotalUsage)
+	systemDelta := float64(cur.SystemUsage - prev.SystemUsage)
+
+	onlineCPUs := float64(cur.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(cur.CPUUsage.PercpuUsage))
+	}
+
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+// memUsageNoCache returns the memory usage without the page cache the same way as 'docker stats' does.
+func memUsageNoCache(mem types.MemoryStats) uint64 {
+	// cgroup v1
+	if v, ok := mem.Stats["total_inactive_file"]; ok && v < mem.Usage {
+		return mem.Usage - v
+	}
+	// cgroup v2
+	if v, ok := mem.Stats["inactive_file"]; ok && v < mem.Usage {
+		return mem.Usage - v
+	}
+	return mem.Usage
+}
+
+func containerName(cntr types.Container) string {
+	if len(cntr.Names) == 0 {
+		// should not happen
+		return cntr.ID
+	}
+	return strings.TrimPrefix(cntr.Names[0], "/")
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/docker/docker/api/types"
@@ -22,10 +23,13 @@ func init() {
 func New() *Docker {
 	return &Docker{
 		Config: Config{
-			Address: docker.DefaultDockerHost,
-			Timeout: web.Duration{Duration: time.Second * 5},
+			Address:                   docker.DefaultDockerHost,
+			Timeout:                   web.Duration{Duration: time.Second * 5},
+			ContainerStatsConcurrency: 10,
+			CollectImages:             true,
 		},
-		charts: charts.Copy(),
+		charts:     charts.Copy(),
+		containers: make(map[string]*containerState),
 		newClient: func(cfg Config) (dockerClient, error) {
 			return docker.NewClientWithOpts(docker.WithHost(cfg.Address))
 		},
@@ -33,8 +37,15 @@ func New() *Docker {
 }
 
 type Config struct {
-	Timeout web.Duration `yaml:"timeout"`
-	Address string       `yaml:"address"`
+	Timeout                   web.Duration `yaml:"timeout"`
+	Address                   string       `yaml:"address"`
+	CollectImages             bool         `yaml:"collect_images"`
+	CollectContainerStats     bool         `yaml:"collect_container_stats"`
+	ContainerStatsConcurrency int          `yaml:"container_stats_concurrency"`
+	// ContainerSelector filters the containers by name and labels, see containerSelector.
+	ContainerSelector matcher.SimpleExpr `yaml:"container_selector"`
+	// CountIgnoredContainers counts the containers filtered out by the selector as 'ignored' in the containers state.
+	CountIgnoredContainers bool `yaml:"count_ignored_containers"`
 }
 
 type (
@@ -46,16 +57,43 @@ type (
 
 		newClient func(Config) (dockerClient, error)
 		client    dockerClient
+
+		selector *containerSelector
+
+		// containers is the running (selected) containers, the key is the container name.
+		containers map[string]*containerState
 	}
 	dockerClient interface {
 		Info(context.Context) (types.Info, error)
 		ImageList(context.Context, types.ImageListOptions) ([]types.ImageSummary, error)
 		ContainerList(context.Context, types.ContainerListOptions) ([]types.Container, error)
+		ContainerStatsOneShot(context.Context, string) (types.ContainerStats, error)
 		Close() error
 	}
 )
 
 func (d *Docker) Init() bool {
+	if !d.CollectImages {
+		for _, id := range []string{imagesCountChart.ID, imagesSizeChart.ID, imagesAgeChart.ID} {
+			_ = d.Charts().Remove(id)
+		}
+	}
+
+	if !d.ContainerSelector.Empty() {
+		sel, err := newContainerSelector(d.ContainerSelector)
+		if err != nil {
+			d.Errorf("init container selector: %v", err)
+			return false
+		}
+		d.selector = sel
+
+		if d.CountIgnoredContainers {
+			if err := d.Charts().Get(containersStateChart.ID).AddDim(&module.Dim{ID: "ignored_containers", Name: "ignored"}); err != nil {
+				d.Warning(err)
+			}
+		}
+	}
+
 	return true
 }
 
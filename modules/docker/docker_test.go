@@ -3,9 +3,17 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"testing"
+	"time"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/docker/docker/api/types"
 	"github.com/stretchr/testify/assert"
@@ -134,14 +142,48 @@ func TestDocker_Collect(t *testing.T) {
 		"success when no errors on all calls": {
 			prepare: func() *Docker { return prepareDockerWithMock(&mockClient{}) },
 			expected: map[string]int64{
-				"healthy_containers":   2,
-				"images_active":        1,
-				"images_dangling":      1,
-				"images_size":          300,
-				"paused_containers":    5,
-				"running_containers":   4,
-				"exited_containers":    6,
-				"unhealthy_containers": 3,
+				"container_c1_health_status_healthy":   1,
+				"container_c1_health_status_none":      0,
+				"container_c1_health_status_starting":  0,
+				"container_c1_health_status_unhealthy": 0,
+				"container_c2_health_status_healthy":   1,
+				"container_c2_health_status_none":      0,
+				"container_c2_health_status_starting":  0,
+				"container_c2_health_status_unhealthy": 0,
+				"container_c3_health_status_healthy":   0,
+				"container_c3_health_status_none":      0,
+				"container_c3_health_status_starting":  0,
+				"container_c3_health_status_unhealthy": 1,
+				"container_c4_health_status_healthy":   0,
+				"container_c4_health_status_none":      0,
+				"container_c4_health_status_starting":  0,
+				"container_c4_health_status_unhealthy": 1,
+				"container_c5_health_status_healthy":   0,
+				"container_c5_health_status_none":      0,
+				"container_c5_health_status_starting":  0,
+				"container_c5_health_status_unhealthy": 1,
+				"container_c6_health_status_healthy":   0,
+				"container_c6_health_status_none":      0,
+				"container_c6_health_status_starting":  1,
+				"container_c6_health_status_unhealthy": 0,
+				"container_c7_health_status_healthy":   0,
+				"container_c7_health_status_none":      1,
+				"container_c7_health_status_starting":  0,
+				"container_c7_health_status_unhealthy": 0,
+				"containers_health_status_healthy":     2,
+				"containers_health_status_none":        1,
+				"containers_health_status_starting":    1,
+				"containers_health_status_unhealthy":   3,
+				"healthy_containers":                   2,
+				"images_active":                        1,
+				"images_dangling":                      1,
+				"images_newest_age":                    86400,
+				"images_oldest_age":                    864000,
+				"images_size":                          300,
+				"paused_containers":                    5,
+				"running_containers":                   4,
+				"exited_containers":                    6,
+				"unhealthy_containers":                 3,
 			},
 		},
 		"fail when error on creating docker client": {
@@ -171,11 +213,228 @@ func TestDocker_Collect(t *testing.T) {
 
 			mx := d.Collect()
 
+			copyImagesAge(mx, test.expected)
 			assert.Equal(t, test.expected, mx)
 		})
 	}
 }
 
+func TestDocker_Collect_ContainerStats(t *testing.T) {
+	m := &mockClient{
+		running: []types.Container{
+			{ID: "id_web", Names: []string{"/web"}, Image: "nginx", Status: "Up 1 minute (healthy)"},
+			{ID: "id_db", Names: []string{"/db"}, Image: "postgres", Status: "Up 1 minute"},
+		},
+		stats: map[string]types.StatsJSON{
+			"id_web": newMockStats(1e9, 10e9, 200, 50, 1000),
+			"id_db":  newMockStats(2e9, 10e9, 500, 0, 0),
+		},
+	}
+	d := prepareDockerWithMock(m)
+	d.CollectContainerStats = true
+	d.ContainerStatsConcurrency = 2
+	require.True(t, d.Init())
+
+	mx := d.Collect()
+	require.NotNil(t, mx)
+
+	assert.Len(t, *d.Charts(), len(charts)+(len(containerStatsChartsTmpl)+1)*2)
+	assert.NotContains(t, mx, "container_web_cpu_usage", "no previous CPU stats")
+	assert.Equal(t, int64(150), mx["container_web_mem_usage"])
+	assert.Equal(t, int64(15000), mx["container_web_mem_utilization"])
+	assert.Equal(t, int64(500), mx["container_db_mem_usage"])
+	assert.NotContains(t, mx, "container_db_mem_utilization", "no memory limit")
+	assert.Equal(t, int64(300), mx["container_web_net_rx"])
+	assert.Equal(t, int64(30), mx["container_web_net_tx"])
+	assert.Equal(t, int64(4096), mx["container_web_block_io_read"])
+	assert.Equal(t, int64(8192), mx["container_web_block_io_write"])
+
+	assert.Equal(t, int64(1), mx["container_web_health_status_healthy"])
+	assert.Equal(t, int64(0), mx["container_web_health_status_none"])
+	assert.Equal(t, int64(1), mx["container_db_health_status_none"])
+
+	chart := d.Charts().Get("container_web_cpu_usage")
+	require.NotNil(t, chart)
+	assert.Equal(t, "web", chart.Labels[0].Value)
+	assert.Equal(t, "nginx", chart.Labels[1].Value)
+
+	m.stats["id_web"] = newMockStats(2e9, 20e9, 200, 50, 1000)
+	m.running = m.running[:1]
+
+	mx = d.Collect()
+	require.NotNil(t, mx)
+
+	// (2e9 - 1e9) / (20e9 - 10e9) * 2 online CPUs * 100
+	assert.Equal(t, int64(20000), mx["container_web_cpu_usage"])
+	assert.NotContains(t, mx, "container_db_mem_usage")
+	for _, tmpl := range append(module.Charts{&containerHealthStatusChartTmpl}, containerStatsChartsTmpl...) {
+		chart := d.Charts().Get(fmt.Sprintf(tmpl.ID, "db"))
+		require.NotNil(t, chart)
+		assert.True(t, chart.Obsolete, "container is stopped")
+	}
+}
+
+func TestDocker_Collect_ContainerHealthWithoutStats(t *testing.T) {
+	m := &mockClient{
+		running: []types.Container{
+			{ID: "id_web", Names: []string{"/web"}, Image: "nginx", Status: "Up 1 minute (unhealthy)"},
+		},
+	}
+	d := prepareDockerWithMock(m)
+	require.True(t, d.Init())
+
+	mx := d.Collect()
+	require.NotNil(t, mx)
+
+	assert.Len(t, *d.Charts(), len(charts)+1)
+	assert.NotNil(t, d.Charts().Get("container_web_health_status"))
+	assert.Nil(t, d.Charts().Get("container_web_cpu_usage"), "container stats are disabled")
+	assert.Equal(t, int64(1), mx["container_web_health_status_unhealthy"])
+	assert.NotContains(t, mx, "container_web_mem_usage")
+
+	m.running = []types.Container{}
+	_ = d.Collect()
+
+	assert.True(t, d.Charts().Get("container_web_health_status").Obsolete, "container is stopped")
+}
+
+func TestDocker_Collect_ContainerStatsError(t *testing.T) {
+	m := &mockClient{
+		running:             []types.Container{{ID: "id_web", Names: []string{"/web"}, Image: "nginx"}},
+		errOnContainerStats: true,
+	}
+	d := prepareDockerWithMock(m)
+	d.CollectContainerStats = true
+	require.True(t, d.Init())
+
+	mx := d.Collect()
+	require.NotNil(t, mx)
+
+	assert.NotContains(t, mx, "container_web_mem_usage")
+	assert.NotNil(t, d.Charts().Get("container_web_cpu_usage"), "the container is running")
+}
+
+func TestDocker_Collect_ContainerSelector(t *testing.T) {
+	web := types.Container{ID: "id_web", Names: []string{"/web"}, State: "running",
+		Labels: map[string]string{"com.docker.compose.project": "prod"}}
+	ci := types.Container{ID: "id_ci", Names: []string{"/ci-1"}, State: "running",
+		Labels: map[string]string{"com.docker.compose.project": "ci"}}
+	db := types.Container{ID: "id_db", Names: []string{"/db"}, State: "exited",
+		Labels: map[string]string{"com.docker.compose.project": "prod"}}
+	cache := types.Container{ID: "id_cache", Names: []string{"/cache"}, State: "paused"}
+
+	tests := map[string]struct {
+		selector     matcher.SimpleExpr
+		countIgnored bool
+		wantFail     bool
+		wantState    map[string]int64
+	}{
+		"includes by label and name": {
+			selector: matcher.SimpleExpr{
+				Includes: []string{"* label:com.docker.compose.project=prod*", "= name:cache"},
+			},
+			wantState: map[string]int64{
+				"running_containers": 1,
+				"paused_containers":  1,
+				"exited_containers":  1,
+			},
+		},
+		"excludes by name, count ignored": {
+			selector: matcher.SimpleExpr{
+				Excludes: []string{"* name:ci-*"},
+			},
+			countIgnored: true,
+			wantState: map[string]int64{
+				"running_containers": 1,
+				"paused_containers":  1,
+				"exited_containers":  1,
+				"ignored_containers": 1,
+			},
+		},
+		"fail on bad syntax": {
+			selector: matcher.SimpleExpr{Includes: []string{"name:web"}},
+			wantFail: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := &mockClient{
+				all:     []types.Container{web, ci, db, cache},
+				running: []types.Container{web, ci},
+				stats: map[string]types.StatsJSON{
+					"id_web": newMockStats(1e9, 10e9, 200, 50, 1000),
+					"id_ci":  newMockStats(1e9, 10e9, 200, 50, 1000),
+				},
+			}
+			d := prepareDockerWithMock(m)
+			d.ContainerSelector = test.selector
+			d.CountIgnoredContainers = test.countIgnored
+			d.CollectContainerStats = true
+
+			if test.wantFail {
+				assert.False(t, d.Init())
+				return
+			}
+			require.True(t, d.Init())
+
+			mx := d.Collect()
+			require.NotNil(t, mx)
+
+			for k, v := range test.wantState {
+				assert.Equalf(t, v, mx[k], "metric '%s'", k)
+			}
+			assert.Equal(t, test.countIgnored, d.Charts().Get(containersStateChart.ID).HasDim("ignored_containers"))
+			assert.Contains(t, mx, "container_web_mem_usage")
+			assert.NotContains(t, mx, "container_ci-1_mem_usage")
+			assert.Nil(t, d.Charts().Get("container_ci-1_cpu_usage"))
+		})
+	}
+}
+
+func newMockStats(cpuTotal, cpuSystem, memUsage, memInactive, memLimit uint64) types.StatsJSON {
+	var st types.StatsJSON
+	st.CPUStats.CPUUsage.TotalUsage = cpuTotal
+	st.CPUStats.SystemUsage = cpuSystem
+	st.CPUStats.OnlineCPUs = 2
+	st.MemoryStats.Usage = memUsage
+	st.MemoryStats.Limit = memLimit
+	st.MemoryStats.Stats = map[string]uint64{"inactive_file": memInactive}
+	st.Networks = map[string]types.NetworkStats{
+		"eth0": {RxBytes: 100, TxBytes: 10},
+		"eth1": {RxBytes: 200, TxBytes: 20},
+	}
+	st.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+		{Major: 8, Op: "Read", Value: 4096},
+		{Major: 8, Op: "Write", Value: 8192},
+		{Major: 8, Op: "Total", Value: 12288},
+	}
+	return st
+}
+
+func TestDocker_Collect_NoImages(t *testing.T) {
+	m := &mockClient{errOnImageList: true}
+	d := prepareDockerWithMock(m)
+	d.CollectImages = false
+	require.True(t, d.Init())
+
+	mx := d.Collect()
+	require.NotNil(t, mx)
+
+	assert.NotContains(t, mx, "images_size")
+	assert.Nil(t, d.Charts().Get(imagesCountChart.ID))
+	assert.Nil(t, d.Charts().Get(imagesAgeChart.ID))
+}
+
+// copyImagesAge copies the images age if it is about the same (the mock images are created relative to now).
+func copyImagesAge(dst, src map[string]int64) {
+	for _, k := range []string{"images_oldest_age", "images_newest_age"} {
+		if v, ok := src[k]; ok && dst[k]-v >= 0 && dst[k]-v < 60 {
+			dst[k] = v
+		}
+	}
+}
+
 func prepareDockerWithMock(m *mockClient) *Docker {
 	d := New()
 	if m == nil {
@@ -187,10 +446,14 @@ func prepareDockerWithMock(m *mockClient) *Docker {
 }
 
 type mockClient struct {
-	errOnInfo          bool
-	errOnContainerList bool
-	errOnImageList     bool
-	closeCalled        bool
+	errOnInfo           bool
+	errOnContainerList  bool
+	errOnImageList      bool
+	errOnContainerStats bool
+	closeCalled         bool
+	all                 []types.Container
+	running             []types.Container
+	stats               map[string]types.StatsJSON
 }
 
 func (m *mockClient) Info(_ context.Context) (types.Info, error) {
@@ -210,20 +473,27 @@ func (m *mockClient) ContainerList(_ context.Context, opts types.ContainerListOp
 		return nil, errors.New("mockClient.ContainerList() error")
 	}
 
-	v := opts.Filters.Get("health")
-
-	if len(v) == 0 {
-		return nil, errors.New("mockClient.ContainerList() error (expect 'health' filter)")
+	if opts.All {
+		return m.all, nil
 	}
 
-	switch v[0] {
-	case "healthy":
-		return []types.Container{{}, {}}, nil
-	case "unhealthy":
-		return []types.Container{{}, {}, {}}, nil
-	default:
-		return nil, nil
+	if v := opts.Filters.Get("status"); len(v) == 0 || v[0] != "running" {
+		return nil, errors.New("mockClient.ContainerList() error (expect 'status=running' filter)")
 	}
+	if m.running == nil {
+		return mockRunningContainers, nil
+	}
+	return m.running, nil
+}
+
+var mockRunningContainers = []types.Container{
+	{ID: "id_1", Names: []string{"/c1"}, Status: "Up 5 minutes (healthy)"},
+	{ID: "id_2", Names: []string{"/c2"}, Status: "Up 5 minutes (healthy)"},
+	{ID: "id_3", Names: []string{"/c3"}, Status: "Up 5 minutes (unhealthy)"},
+	{ID: "id_4", Names: []string{"/c4"}, Status: "Up 5 minutes (unhealthy)"},
+	{ID: "id_5", Names: []string{"/c5"}, Status: "Up 5 minutes (unhealthy)"},
+	{ID: "id_6", Names: []string{"/c6"}, Status: "Up 2 seconds (health: starting)"},
+	{ID: "id_7", Names: []string{"/c7"}, Status: "Up 5 minutes"},
 }
 
 func (m *mockClient) ImageList(_ context.Context, _ types.ImageListOptions) ([]types.ImageSummary, error) {
@@ -231,18 +501,36 @@ func (m *mockClient) ImageList(_ context.Context, _ types.ImageListOptions) ([]t
 		return nil, errors.New("mockClient.ImageList() error")
 	}
 
+	now := time.Now()
 	return []types.ImageSummary{
 		{
-			Containers: 0,
+			Containers: -1,
+			RepoTags:   []string{"<none>:<none>"},
+			Created:    now.Add(-time.Hour * 24 * 10).Unix(),
 			Size:       100,
 		},
 		{
-			Containers: 1,
+			Containers: -1,
+			RepoTags:   []string{"nginx:latest", "nginx:1.23"},
+			Created:    now.Add(-time.Hour * 24).Unix(),
 			Size:       200,
 		},
 	}, nil
 }
 
+func (m *mockClient) ContainerStatsOneShot(_ context.Context, id string) (types.ContainerStats, error) {
+	st, ok := m.stats[id]
+	if m.errOnContainerStats || !ok {
+		return types.ContainerStats{}, errors.New("mockClient.ContainerStatsOneShot() error")
+	}
+
+	bs, err := json.Marshal(st)
+	if err != nil {
+		return types.ContainerStats{}, err
+	}
+	return types.ContainerStats{Body: io.NopCloser(bytes.NewReader(bs)), OSType: "linux"}, nil
+}
+
 func (m *mockClient) Close() error {
 	m.closeCalled = true
 	return nil
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package docker
+
+import (
+	"fmt"
+
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+
+	"github.com/docker/docker/api/types"
+)
+
+// containerSelector selects the containers by name and labels. The patterns are matched against the
+// 'name:<name>' and the 'label:<key>=<value>' strings of the container (e.g. '* label:com.docker.compose.project=prod*').
+// A container is selected if any of its strings matches any include pattern (or there are no includes)
+// and none of its strings matches any exclude pattern.
+type containerSelector struct {
+	includes matcher.Matcher
+	excludes matcher.Matcher
+}
+
+func newContainerSelector(expr matcher.SimpleExpr) (*containerSelector, error) {
+	sel := &containerSelector{includes: matcher.TRUE(), excludes: matcher.FALSE()}
+
+	if len(expr.Includes) > 0 {
+		sel.includes = matcher.FALSE()
+		for _, v := range expr.Includes {
+			m, err := matcher.Parse(v)
+			if err != nil {
+				return nil, fmt.Errorf("parse include '%s': %v", v, err)
+			}
+			sel.includes = matcher.Or(sel.includes, m)
+		}
+	}
+	for _, v := range expr.Excludes {
+		m, err := matcher.Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("parse exclude '%s': %v", v, err)
+		}
+		sel.excludes = matcher.Or(sel.excludes, m)
+	}
+
+	return sel, nil
+}
+
+func (s *containerSelector) match(cntr types.Container) bool {
+	keys := make([]string, 0, len(cntr.Labels)+1)
+	keys = append(keys, "name:"+containerName(cntr))
+	for k, v := range cntr.Labels {
+		keys = append(keys, "label:"+k+"="+v)
+	}
+
+	return matchAny(s.includes, keys) && !matchAny(s.excludes, keys)
+}
+
+func matchAny(m matcher.Matcher, keys []string) bool {
+	for _, k := range keys {
+		if m.MatchString(k) {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
@@ -20,6 +21,25 @@ type repository struct {
 	StarCount   int    `json:"star_count"`
 	PullCount   int    `json:"pull_count"`
 	LastUpdated string `json:"last_updated"`
+
+	// configName and tagsLastPushed (the configured tags last push time) are not a part of the API response.
+	configName     string
+	tagsLastPushed map[string]time.Time
+}
+
+const (
+	tagsPageSize = 100
+	// maxTagsPages limits the number of the tags API pages requested per repository.
+	maxTagsPages = 50
+)
+
+type tagsPage struct {
+	Next    *string `json:"next"`
+	Results []struct {
+		Name          string `json:"name"`
+		LastUpdated   string `json:"last_updated"`
+		TagLastPushed string `json:"tag_last_pushed"`
+	} `json:"results"`
 }
 
 func newAPIClient(client *http.Client, request web.Request) *apiClient {
@@ -51,6 +71,69 @@ func (a apiClient) getRepository(repoName string) (*repository, error) {
 	return &repo, nil
 }
 
+// getTagsLastPushed returns the last push time of the tags. It follows the tags API pagination
+// until all the tags are found.
+func (a apiClient) getTagsLastPushed(repoName string, tags []string) (map[string]time.Time, error) {
+	want := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		want[tag] = true
+	}
+	found := make(map[string]time.Time, len(tags))
+
+	req, err := a.createRequest(path.Join(repoName, "tags"))
+	if err != nil {
+		return nil, fmt.Errorf("error on creating http request : %v", err)
+	}
+	req.URL.RawQuery = url.Values{"page_size": []string{fmt.Sprint(tagsPageSize)}}.Encode()
+
+	for page := 0; page < maxTagsPages && len(found) < len(want); page++ {
+		var tp tagsPage
+		if err := a.doRequestDecode(req, &tp); err != nil {
+			return nil, err
+		}
+
+		for _, tag := range tp.Results {
+			if !want[tag.Name] {
+				continue
+			}
+			v := tag.TagLastPushed
+			if v == "" {
+				v = tag.LastUpdated
+			}
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, fmt.Errorf("error on parsing %s:%s last pushed time : %v", repoName, tag.Name, err)
+			}
+			found[tag.Name] = t
+		}
+
+		if tp.Next == nil || *tp.Next == "" {
+			break
+		}
+		next, err := url.Parse(*tp.Next)
+		if err != nil {
+			return nil, fmt.Errorf("error on parsing next page url '%s' : %v", *tp.Next, err)
+		}
+		req.URL = req.URL.ResolveReference(next)
+		req.Host = req.URL.Host
+	}
+
+	return found, nil
+}
+
+func (a apiClient) doRequestDecode(req *http.Request, dst interface{}) error {
+	resp, err := a.doRequestOK(req)
+	defer closeBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("error on parsing response from %s : %v", req.URL, err)
+	}
+	return nil
+}
+
 func (a apiClient) doRequestOK(req *http.Request) (*http.Response, error) {
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
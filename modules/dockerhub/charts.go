@@ -3,6 +3,7 @@
 package dockerhub
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -62,6 +63,36 @@ var charts = Charts{
 	},
 }
 
+var pullRateLimitCharts = Charts{
+	{
+		ID:    "pull_rate_limit",
+		Title: "Pull Rate Limit",
+		Units: "pulls",
+		Fam:   "pull rate limit",
+		Dims: Dims{
+			{ID: "pull_rate_limit_limit", Name: "limit"},
+			{ID: "pull_rate_limit_remaining", Name: "remaining"},
+		},
+	},
+	{
+		ID:    "pull_rate_limit_reset",
+		Title: "Pull Rate Limit Window And Time Until Reset",
+		Units: "seconds",
+		Fam:   "pull rate limit",
+		Dims: Dims{
+			{ID: "pull_rate_limit_window", Name: "window"},
+			{ID: "pull_rate_limit_reset", Name: "reset"},
+		},
+	},
+}
+
+var tagsLastPushedChartTmpl = module.Chart{
+	ID:    "tags_last_pushed_%s",
+	Title: "Time Since Tag Last Pushed",
+	Units: "days",
+	Fam:   "last updated",
+}
+
 func addReposToCharts(repositories []string, cs *Charts) {
 	for _, name := range repositories {
 		dimName := strings.Replace(name, "/", "_", -1)
@@ -88,3 +119,24 @@ func addReposToCharts(repositories []string, cs *Charts) {
 		})
 	}
 }
+
+func addTagsCharts(repositories []string, tags map[string][]string, cs *Charts) {
+	for _, name := range repositories {
+		if len(tags[name]) == 0 {
+			continue
+		}
+		chart := tagsLastPushedChartTmpl.Copy()
+		chart.ID = fmt.Sprintf(chart.ID, strings.Replace(name, "/", "_", -1))
+		chart.Labels = []module.Label{
+			{Key: "repository", Value: name},
+		}
+		for _, tag := range tags[name] {
+			_ = chart.AddDim(&Dim{
+				ID:   fmt.Sprintf("tag_last_pushed_%s:%s", name, tag),
+				Name: tag,
+				Div:  86400,
+			})
+		}
+		_ = cs.Add(chart)
+	}
+}
@@ -32,23 +32,56 @@ func (dh *DockerHub) collect() (map[string]int64, error) {
 			dh.Errorf("error on parsing %s/%s : %v", repo.User, repo.Name, err)
 			continue
 		}
+		for tag, t := range repo.tagsLastPushed {
+			mx[fmt.Sprintf("tag_last_pushed_%s:%s", repo.configName, tag)] = int64(time.Since(t).Seconds())
+		}
 		pullSum += repo.PullCount
 		parsed++
 	}
 	close(ch)
 
-	if parsed == reposNum {
+	if reposNum > 0 && parsed == reposNum {
 		mx["pull_sum"] = int64(pullSum)
 	}
 
+	if dh.rateLimitClient != nil {
+		dh.collectPullRateLimit(mx)
+	}
+
 	return mx, nil
 }
 
+func (dh *DockerHub) collectPullRateLimit(mx map[string]int64) {
+	rl, err := dh.rateLimitClient.getPullRateLimit()
+	if err != nil {
+		dh.Errorf("error on getting pull rate limit : %v", err)
+		return
+	}
+
+	mx["pull_rate_limit_limit"] = rl.limit
+	mx["pull_rate_limit_remaining"] = rl.remaining
+	mx["pull_rate_limit_window"] = rl.window
+	if rl.reset >= 0 {
+		mx["pull_rate_limit_reset"] = rl.reset
+	}
+}
+
 func (dh *DockerHub) collectRepo(repoName string, ch chan *repository) {
 	repo, err := dh.client.getRepository(repoName)
 	if err != nil {
 		dh.Error(err)
 	}
+	if repo != nil && len(dh.Tags[repoName]) > 0 {
+		repo.configName = repoName
+		if repo.tagsLastPushed, err = dh.client.getTagsLastPushed(repoName, dh.Tags[repoName]); err != nil {
+			dh.Errorf("error on getting %s tags : %v", repoName, err)
+		}
+		for _, tag := range dh.Tags[repoName] {
+			if _, ok := repo.tagsLastPushed[tag]; !ok && err == nil {
+				dh.Warningf("tag '%s:%s' not found", repoName, tag)
+			}
+		}
+	}
 	ch <- repo
 }
 
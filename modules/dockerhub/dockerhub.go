@@ -40,6 +40,11 @@ func New() *DockerHub {
 			},
 		},
 	}
+	config.PullRateLimit = PullRateLimit{
+		Reference:   defaultRateLimitReference,
+		AuthURL:     defaultRateLimitAuthURL,
+		RegistryURL: defaultRateLimitRegistryURL,
+	}
 	return &DockerHub{
 		Config: config,
 	}
@@ -49,13 +54,17 @@ func New() *DockerHub {
 type Config struct {
 	web.HTTP     `yaml:",inline"`
 	Repositories []string
+	// Tags is the list of tags per repository to collect the time since the tag was last pushed for.
+	Tags          map[string][]string `yaml:"tags"`
+	PullRateLimit PullRateLimit       `yaml:"pull_rate_limit"`
 }
 
 // DockerHub DockerHub module.
 type DockerHub struct {
 	module.Base
-	Config `yaml:",inline"`
-	client *apiClient
+	Config          `yaml:",inline"`
+	client          *apiClient
+	rateLimitClient *rateLimitClient
 }
 
 // Cleanup makes cleanup.
@@ -68,11 +77,18 @@ func (dh *DockerHub) Init() bool {
 		return false
 	}
 
-	if len(dh.Repositories) == 0 {
-		dh.Error("repositories parameter is not set")
+	if len(dh.Repositories) == 0 && !dh.PullRateLimit.Enabled {
+		dh.Error("repositories parameter is not set and pull rate limit monitoring is disabled")
 		return false
 	}
 
+	for repo := range dh.Tags {
+		if !contains(dh.Repositories, repo) {
+			dh.Errorf("tags are set for the '%s' repository that is not in the repositories list", repo)
+			return false
+		}
+	}
+
 	client, err := web.NewHTTPClient(dh.Client)
 	if err != nil {
 		dh.Errorf("error on creating http client : %v", err)
@@ -80,6 +96,15 @@ func (dh *DockerHub) Init() bool {
 	}
 	dh.client = newAPIClient(client, dh.Request)
 
+	if dh.PullRateLimit.Enabled {
+		rlClient, err := newRateLimitClient(client, dh.Request, dh.PullRateLimit)
+		if err != nil {
+			dh.Errorf("error on creating pull rate limit client : %v", err)
+			return false
+		}
+		dh.rateLimitClient = rlClient
+	}
+
 	return true
 }
 
@@ -90,8 +115,15 @@ func (dh DockerHub) Check() bool {
 
 // Charts creates Charts.
 func (dh DockerHub) Charts() *Charts {
-	cs := charts.Copy()
-	addReposToCharts(dh.Repositories, cs)
+	cs := &Charts{}
+	if len(dh.Repositories) > 0 {
+		cs = charts.Copy()
+		addReposToCharts(dh.Repositories, cs)
+		addTagsCharts(dh.Repositories, dh.Tags, cs)
+	}
+	if dh.PullRateLimit.Enabled {
+		_ = cs.Add(*pullRateLimitCharts.Copy()...)
+	}
 	return cs
 }
 
@@ -106,3 +138,12 @@ func (dh *DockerHub) Collect() map[string]int64 {
 
 	return mx
 }
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
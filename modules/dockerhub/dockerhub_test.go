@@ -3,11 +3,13 @@
 package dockerhub
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -116,6 +118,63 @@ func TestDockerHub_Collect(t *testing.T) {
 	assert.Equal(t, expected, collected)
 }
 
+func TestDockerHub_Collect_Tags(t *testing.T) {
+	pushed := time.Now().Add(-time.Hour * 48).UTC()
+	var tagsRequests int
+	var ts *httptest.Server
+	ts = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/name1/repo1":
+					_, _ = w.Write(repo1Data)
+				case "/name1/repo1/tags":
+					tagsRequests++
+					assert.Equal(t, "100", r.URL.Query().Get("page_size"))
+					if r.URL.Query().Get("page") == "" {
+						_, _ = fmt.Fprintf(w, `{"next": "%s/name1/repo1/tags?page=2&page_size=100", "results": [
+{"name": "latest", "last_updated": "%s", "tag_last_pushed": "%s"},
+{"name": "dev", "last_updated": "%s", "tag_last_pushed": "%s"}]}`,
+							ts.URL,
+							pushed.Format(time.RFC3339Nano), pushed.Format(time.RFC3339Nano),
+							pushed.Format(time.RFC3339Nano), pushed.Format(time.RFC3339Nano))
+						return
+					}
+					_, _ = fmt.Fprintf(w, `{"next": null, "results": [{"name": "1.0", "last_updated": "%s"}]}`,
+						pushed.Add(-time.Hour*24).Format(time.RFC3339Nano))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.URL = ts.URL
+	job.Repositories = []string{"name1/repo1"}
+	job.Tags = map[string][]string{"name1/repo1": {"latest", "1.0", "missing"}}
+	require.True(t, job.Init())
+
+	chart := job.Charts().Get("tags_last_pushed_name1_repo1")
+	require.NotNil(t, chart)
+	assert.Len(t, chart.Dims, 3)
+
+	mx := job.Collect()
+
+	assert.Equal(t, 2, tagsRequests)
+	assert.InDelta(t, 48*3600, mx["tag_last_pushed_name1/repo1:latest"], 10)
+	assert.InDelta(t, 72*3600, mx["tag_last_pushed_name1/repo1:1.0"], 10)
+	assert.NotContains(t, mx, "tag_last_pushed_name1/repo1:dev")
+	assert.NotContains(t, mx, "tag_last_pushed_name1/repo1:missing")
+}
+
+func TestDockerHub_Init_TagsUnknownRepository(t *testing.T) {
+	job := New()
+	job.Repositories = []string{"name1/repo1"}
+	job.Tags = map[string][]string{"name2/repo2": {"latest"}}
+
+	assert.False(t, job.Init())
+}
+
 func TestDockerHub_InvalidData(t *testing.T) {
 	ts := httptest.NewServer(
 		http.HandlerFunc(
@@ -144,3 +203,143 @@ func TestDockerHub_404(t *testing.T) {
 	require.True(t, job.Init())
 	assert.False(t, job.Check())
 }
+
+func TestDockerHub_Collect_PullRateLimit(t *testing.T) {
+	tests := map[string]struct {
+		username string
+		password string
+		headers  map[string]string
+		expected map[string]int64
+	}{
+		"anonymous": {
+			headers: map[string]string{
+				"ratelimit-limit":     "100;w=21600",
+				"ratelimit-remaining": "76;w=21600",
+			},
+			expected: map[string]int64{
+				"pull_rate_limit_limit":     100,
+				"pull_rate_limit_remaining": 76,
+				"pull_rate_limit_window":    21600,
+				"pull_rate_limit_reset":     21600,
+			},
+		},
+		"authenticated with reset": {
+			username: "user",
+			password: "token",
+			headers: map[string]string{
+				"ratelimit-limit":     "200;w=21600",
+				"ratelimit-remaining": "0;w=21600",
+				"ratelimit-reset":     "3600",
+			},
+			expected: map[string]int64{
+				"pull_rate_limit_limit":     200,
+				"pull_rate_limit_remaining": 0,
+				"pull_rate_limit_window":    21600,
+				"pull_rate_limit_reset":     3600,
+			},
+		},
+		"no rate limit headers": {
+			expected: map[string]int64{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var tokenRequests int
+			ts := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						switch r.URL.Path {
+						case "/token":
+							tokenRequests++
+							assert.Equal(t, "registry.docker.io", r.URL.Query().Get("service"))
+							assert.Equal(t, "repository:library/alpine:pull", r.URL.Query().Get("scope"))
+							token := "anonymous"
+							if user, pass, ok := r.BasicAuth(); ok && user == test.username && pass == test.password {
+								token = "authenticated"
+							}
+							_, _ = w.Write([]byte(`{"token": "` + token + `", "expires_in": 300}`))
+						case "/v2/library/alpine/manifests/3.17":
+							assert.Equal(t, http.MethodHead, r.Method)
+							want := "Bearer anonymous"
+							if test.username != "" {
+								want = "Bearer authenticated"
+							}
+							if r.Header.Get("Authorization") != want {
+								w.WriteHeader(http.StatusUnauthorized)
+								return
+							}
+							for k, v := range test.headers {
+								w.Header().Set(k, v)
+							}
+							if v, ok := test.expected["pull_rate_limit_remaining"]; ok && v == 0 {
+								w.WriteHeader(http.StatusTooManyRequests)
+							}
+						default:
+							w.WriteHeader(http.StatusNotFound)
+						}
+					}))
+			defer ts.Close()
+
+			job := New()
+			job.PullRateLimit.Enabled = true
+			job.PullRateLimit.Reference = "alpine:3.17"
+			job.PullRateLimit.AuthURL = ts.URL + "/token"
+			job.PullRateLimit.RegistryURL = ts.URL
+			job.PullRateLimit.Username = test.username
+			job.PullRateLimit.Password = test.password
+			require.True(t, job.Init())
+			assert.Len(t, *job.Charts(), len(pullRateLimitCharts))
+
+			assert.Equal(t, test.expected, job.Collect())
+			assert.Equal(t, test.expected, job.Collect())
+			assert.Equal(t, 1, tokenRequests, "the token is reused until it expires")
+		})
+	}
+}
+
+func TestRateLimitClient_estimateReset(t *testing.T) {
+	var c rateLimitClient
+	now := time.Now()
+
+	rl := &pullRateLimit{limit: 100, remaining: 76, window: 3600}
+	assert.Equal(t, int64(3600), c.estimateReset(rl, now), "the window starts when the limit is seen for the first time")
+
+	rl.remaining = 70
+	assert.Equal(t, int64(3000), c.estimateReset(rl, now.Add(time.Minute*10)))
+
+	rl.remaining = 100
+	assert.Equal(t, int64(3600), c.estimateReset(rl, now.Add(time.Minute*20)), "the remaining pulls increased")
+
+	rl.remaining = 90
+	assert.Equal(t, int64(3600), c.estimateReset(rl, now.Add(time.Minute*80)), "the window is over")
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := map[string]struct {
+		wantRepo string
+		wantRef  string
+		wantErr  bool
+	}{
+		"ratelimitpreview/test:latest":      {wantRepo: "ratelimitpreview/test", wantRef: "latest"},
+		"alpine":                            {wantRepo: "library/alpine", wantRef: "latest"},
+		"alpine:3.17":                       {wantRepo: "library/alpine", wantRef: "3.17"},
+		"user/repo@sha256:0123456789abcdef": {wantRepo: "user/repo", wantRef: "sha256:0123456789abcdef"},
+		"":                                  {wantErr: true},
+		"alpine:":                           {wantErr: true},
+	}
+
+	for reference, test := range tests {
+		t.Run(reference, func(t *testing.T) {
+			repo, ref, err := parseImageReference(reference)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.wantRepo, repo)
+			assert.Equal(t, test.wantRef, ref)
+		})
+	}
+}
@@ -0,0 +1,266 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package dockerhub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+const (
+	defaultRateLimitReference   = "ratelimitpreview/test:latest"
+	defaultRateLimitAuthURL     = "https://auth.docker.io/token"
+	defaultRateLimitRegistryURL = "https://registry-1.docker.io"
+
+	// defaultTokenExpiresIn is the token lifetime if the auth server doesn't return it (the Docker Registry token spec).
+	defaultTokenExpiresIn = time.Second * 60
+)
+
+// PullRateLimit is the Docker Hub pull rate limit monitoring configuration.
+// https://docs.docker.com/docker-hub/download-rate-limit/#how-can-i-check-my-current-rate
+type PullRateLimit struct {
+	Enabled bool `yaml:"enabled"`
+	// Reference is the image the manifest is requested for. The HEAD manifest requests don't count against the limit.
+	Reference string `yaml:"reference"`
+	// Username and Password (or an access token) are used to get the authenticated token,
+	// the anonymous token (the limit is per IP address) is used if not set.
+	Username    string `yaml:"username"`
+	Password    string `yaml:"password"`
+	AuthURL     string `yaml:"auth_url"`
+	RegistryURL string `yaml:"registry_url"`
+}
+
+type pullRateLimit struct {
+	limit     int64
+	remaining int64
+	// window is the rate limit window in seconds (the 'w' parameter).
+	window int64
+	// reset is the time until the limit resets in seconds, -1 if unknown.
+	reset int64
+}
+
+type authToken struct {
+	Token       string `json:"token"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func newRateLimitClient(client *http.Client, request web.Request, cfg PullRateLimit) (*rateLimitClient, error) {
+	repo, ref, err := parseImageReference(cfg.Reference)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitClient{
+		httpClient: client,
+		request:    request,
+		cfg:        cfg,
+		repo:       repo,
+		ref:        ref,
+	}, nil
+}
+
+type rateLimitClient struct {
+	httpClient *http.Client
+	// request is the base request (headers, proxy auth).
+	request web.Request
+	cfg     PullRateLimit
+	repo    string
+	ref     string
+
+	token        string
+	tokenExpires time.Time
+
+	// windowStart and prevRemaining are used to estimate the time until the limit resets.
+	windowStart   time.Time
+	prevRemaining int64
+}
+
+func (c *rateLimitClient) getPullRateLimit() (*pullRateLimit, error) {
+	if c.token == "" || time.Now().After(c.tokenExpires) {
+		if err := c.refreshToken(); err != nil {
+			return nil, fmt.Errorf("error on getting auth token : %v", err)
+		}
+	}
+
+	req, err := c.newRequest(http.MethodHead, c.cfg.RegistryURL)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Path = fmt.Sprintf("/v2/%s/manifests/%s", c.repo, c.ref)
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", strings.Join([]string{
+		"application/vnd.docker.distribution.manifest.list.v2+json",
+		"application/vnd.docker.distribution.manifest.v2+json",
+		"application/vnd.oci.image.index.v1+json",
+		"application/vnd.oci.image.manifest.v1+json",
+	}, ", "))
+
+	resp, err := c.httpClient.Do(req)
+	defer closeBody(resp)
+	if err != nil {
+		return nil, fmt.Errorf("error on request: %v", err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.token = ""
+	}
+	// the headers are returned on 429 (Too Many Requests) as well
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusTooManyRequests {
+		return nil, fmt.Errorf("%s returned HTTP status %d", req.URL, resp.StatusCode)
+	}
+
+	rl, err := parseRateLimitHeaders(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+	if rl.reset < 0 && rl.window > 0 {
+		rl.reset = c.estimateReset(rl, time.Now())
+	}
+
+	return rl, nil
+}
+
+// estimateReset estimates the time until the limit resets in seconds, Docker Hub doesn't send 'ratelimit-reset'.
+// The window is assumed to start when the limit is seen for the first time, it restarts when it is over
+// or when the remaining pulls increase (the limit was reset).
+func (c *rateLimitClient) estimateReset(rl *pullRateLimit, now time.Time) int64 {
+	window := time.Duration(rl.window) * time.Second
+	if c.windowStart.IsZero() || now.Sub(c.windowStart) >= window || rl.remaining > c.prevRemaining {
+		c.windowStart = now
+	}
+	c.prevRemaining = rl.remaining
+
+	return int64(c.windowStart.Add(window).Sub(now).Round(time.Second) / time.Second)
+}
+
+func (c *rateLimitClient) refreshToken() error {
+	req, err := c.newRequest(http.MethodGet, c.cfg.AuthURL)
+	if err != nil {
+		return err
+	}
+	req.URL.RawQuery = url.Values{
+		"service": []string{"registry.docker.io"},
+		"scope":   []string{"repository:" + c.repo + ":pull"},
+	}.Encode()
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	defer closeBody(resp)
+	if err != nil {
+		return fmt.Errorf("error on request: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned HTTP status %d", req.URL, resp.StatusCode)
+	}
+
+	var tok authToken
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return fmt.Errorf("error on parsing response from %s : %v", req.URL, err)
+	}
+
+	c.token = tok.Token
+	if c.token == "" {
+		c.token = tok.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("no token in response from %s", req.URL)
+	}
+
+	expiresIn := time.Duration(tok.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = defaultTokenExpiresIn
+	}
+	// refresh a bit earlier to not use an expired token
+	c.tokenExpires = time.Now().Add(expiresIn * 9 / 10)
+
+	return nil
+}
+
+func (c *rateLimitClient) newRequest(method, rawURL string) (*http.Request, error) {
+	r := c.request.Copy()
+	r.URL = rawURL
+	r.Method = method
+	r.Body = ""
+	// the basic auth of the repositories API request is not for the registry
+	r.Username, r.Password = "", ""
+
+	req, err := web.NewHTTPRequest(r)
+	if err != nil {
+		return nil, fmt.Errorf("error on creating http request : %v", err)
+	}
+	return req, nil
+}
+
+// parseRateLimitHeaders parses the 'ratelimit-limit: 100;w=21600' and 'ratelimit-remaining: 76;w=21600' headers.
+func parseRateLimitHeaders(h http.Header) (*pullRateLimit, error) {
+	limitHeader, remainingHeader := h.Get("ratelimit-limit"), h.Get("ratelimit-remaining")
+	if limitHeader == "" || remainingHeader == "" {
+		return nil, errors.New("no rate limit headers in the response (the pull rate limit is not applied?)")
+	}
+
+	limit, window, err := parseRateLimitHeader(limitHeader)
+	if err != nil {
+		return nil, fmt.Errorf("error on parsing 'ratelimit-limit' header '%s' : %v", limitHeader, err)
+	}
+	remaining, _, err := parseRateLimitHeader(remainingHeader)
+	if err != nil {
+		return nil, fmt.Errorf("error on parsing 'ratelimit-remaining' header '%s' : %v", remainingHeader, err)
+	}
+
+	rl := &pullRateLimit{limit: limit, remaining: remaining, window: window, reset: -1}
+	if v := h.Get("ratelimit-reset"); v != "" {
+		if reset, err := strconv.ParseInt(v, 10, 64); err == nil {
+			rl.reset = reset
+		}
+	}
+
+	return rl, nil
+}
+
+func parseRateLimitHeader(value string) (n, window int64, err error) {
+	parts := strings.Split(value, ";")
+
+	if n, err = strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64); err != nil {
+		return 0, 0, err
+	}
+	for _, p := range parts[1:] {
+		if v := strings.TrimSpace(p); strings.HasPrefix(v, "w=") {
+			if window, err = strconv.ParseInt(strings.TrimPrefix(v, "w="), 10, 64); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	return n, window, nil
+}
+
+// parseImageReference splits the image reference into the repository ('library/' is prepended to the official
+// images) and the tag or digest ('latest' if not set).
+func parseImageReference(reference string) (repo, ref string, err error) {
+	if reference == "" {
+		return "", "", errors.New("image reference is empty")
+	}
+
+	repo, ref = reference, "latest"
+	if i := strings.Index(repo, "@"); i != -1 {
+		repo, ref = repo[:i], repo[i+1:]
+	} else if i := strings.LastIndex(repo, ":"); i != -1 && !strings.Contains(repo[i:], "/") {
+		repo, ref = repo[:i], repo[i+1:]
+	}
+	if repo == "" || ref == "" {
+		return "", "", fmt.Errorf("invalid image reference '%s'", reference)
+	}
+	if !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+
+	return repo, ref, nil
+}
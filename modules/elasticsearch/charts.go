@@ -3,6 +3,8 @@
 package elasticsearch
 
 import (
+	"fmt"
+
 	"github.com/netdata/go.d.plugin/agent/module"
 )
 
@@ -413,9 +415,107 @@ var (
 				{ID: "node_breakers_parent_tripped", Name: "parent", Algo: module.Incremental},
 			},
 		},
+		{
+			ID:    "breakers_usage",
+			Title: "Circuit Breaker Estimated Size To Limit",
+			Units: "percentage",
+			Fam:   "circuit breakers",
+			Ctx:   "elasticsearch.breakers_usage",
+			Dims: Dims{
+				{ID: "node_breakers_request_usage_percent", Name: "requests", Div: precision},
+				{ID: "node_breakers_fielddata_usage_percent", Name: "fielddata", Div: precision},
+				{ID: "node_breakers_in_flight_requests_usage_percent", Name: "in_flight_requests", Div: precision},
+				{ID: "node_breakers_model_inference_usage_percent", Name: "model_inference", Div: precision},
+				{ID: "node_breakers_accounting_usage_percent", Name: "accounting", Div: precision},
+				{ID: "node_breakers_parent_usage_percent", Name: "parent", Div: precision},
+			},
+		},
+		// Discovery
+		{
+			ID:    "node_cluster_state_queue",
+			Title: "Cluster State Queue",
+			Units: "states",
+			Fam:   "discovery",
+			Ctx:   "elasticsearch.node_cluster_state_queue",
+			Dims: Dims{
+				{ID: "node_discovery_cluster_state_queue_pending", Name: "pending"},
+				{ID: "node_discovery_cluster_state_queue_committed", Name: "committed"},
+			},
+		},
+		{
+			ID:    "node_published_cluster_states",
+			Title: "Published Cluster States",
+			Units: "states/s",
+			Fam:   "discovery",
+			Ctx:   "elasticsearch.node_published_cluster_states",
+			Type:  module.Stacked,
+			Dims: Dims{
+				{ID: "node_discovery_published_cluster_states_full_states", Name: "full", Algo: module.Incremental},
+				{ID: "node_discovery_published_cluster_states_compatible_diffs", Name: "compatible_diffs", Algo: module.Incremental},
+				{ID: "node_discovery_published_cluster_states_incompatible_diffs", Name: "incompatible_diffs", Algo: module.Incremental},
+			},
+		},
 	}
 )
 
+var nodeClusterStateUpdateCharts = Charts{
+	{
+		ID:    "node_cluster_state_updates",
+		Title: "Cluster State Updates",
+		Units: "updates/s",
+		Fam:   "discovery",
+		Ctx:   "elasticsearch.node_cluster_state_updates",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "node_discovery_cluster_state_update_success_count", Name: "success", Algo: module.Incremental},
+			{ID: "node_discovery_cluster_state_update_failure_count", Name: "failure", Algo: module.Incremental},
+			{ID: "node_discovery_cluster_state_update_unchanged_count", Name: "unchanged", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "node_cluster_state_update_time",
+		Title: "Cluster State Update Computation Time",
+		Units: "milliseconds",
+		Fam:   "discovery",
+		Ctx:   "elasticsearch.node_cluster_state_update_time",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "node_discovery_cluster_state_update_success_computation_time_millis", Name: "success", Algo: module.Incremental},
+			{ID: "node_discovery_cluster_state_update_failure_computation_time_millis", Name: "failure", Algo: module.Incremental},
+			{ID: "node_discovery_cluster_state_update_unchanged_computation_time_millis", Name: "unchanged", Algo: module.Incremental},
+		},
+	},
+}
+
+var nodeIndexingPressureCharts = Charts{
+	{
+		ID:    "node_indexing_pressure_memory",
+		Title: "Indexing Pressure Memory",
+		Units: "bytes",
+		Fam:   "indexing pressure",
+		Ctx:   "elasticsearch.node_indexing_pressure_memory",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "node_indexing_pressure_memory_current_coordinating_in_bytes", Name: "coordinating"},
+			{ID: "node_indexing_pressure_memory_current_primary_in_bytes", Name: "primary"},
+			{ID: "node_indexing_pressure_memory_current_replica_in_bytes", Name: "replica"},
+		},
+	},
+	{
+		ID:    "node_indexing_pressure_rejections",
+		Title: "Indexing Pressure Rejections",
+		Units: "rejections/s",
+		Fam:   "indexing pressure",
+		Ctx:   "elasticsearch.node_indexing_pressure_rejections",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "node_indexing_pressure_memory_total_coordinating_rejections", Name: "coordinating", Algo: module.Incremental},
+			{ID: "node_indexing_pressure_memory_total_primary_rejections", Name: "primary", Algo: module.Incremental},
+			{ID: "node_indexing_pressure_memory_total_replica_rejections", Name: "replica", Algo: module.Incremental},
+		},
+	},
+}
+
 var nodeIndicesStatsCharts = Charts{
 	{
 		ID:    "node_index_health",
@@ -581,3 +681,127 @@ var clusterStatsCharts = Charts{
 		},
 	},
 }
+
+var masterNodeCharts = Charts{
+	{
+		ID:    "node_master_status",
+		Title: "Elected Master Status",
+		Units: "status",
+		Fam:   "discovery",
+		Ctx:   "elasticsearch.node_master_status",
+		Dims: Dims{
+			{ID: "node_master_elected", Name: "elected"},
+			{ID: "node_master_not_elected", Name: "not_elected"},
+		},
+	},
+	{
+		ID:    "node_master_transitions",
+		Title: "Elected Master Changes Since Start",
+		Units: "transitions",
+		Fam:   "discovery",
+		Ctx:   "elasticsearch.node_master_transitions",
+		Dims: Dims{
+			{ID: "node_master_transitions", Name: "transitions"},
+		},
+	},
+}
+
+var pendingTasksCharts = Charts{
+	{
+		ID:    "cluster_pending_tasks_queue",
+		Title: "Cluster Pending Tasks Queue",
+		Units: "tasks",
+		Fam:   "cluster pending tasks",
+		Ctx:   "elasticsearch.cluster_pending_tasks_queue",
+		Dims: Dims{
+			{ID: "cluster_pending_tasks_count", Name: "pending"},
+		},
+	},
+	{
+		ID:    "cluster_pending_tasks_max_wait_time",
+		Title: "Cluster Pending Tasks Max Time In Queue",
+		Units: "milliseconds",
+		Fam:   "cluster pending tasks",
+		Ctx:   "elasticsearch.cluster_pending_tasks_max_wait_time",
+		Dims: Dims{
+			{ID: "cluster_pending_tasks_max_time_in_queue_millis", Name: "max"},
+		},
+	},
+}
+
+var snapshotsCharts = Charts{
+	{
+		ID:    "cluster_snapshots_in_progress",
+		Title: "Cluster Snapshots In Progress",
+		Units: "snapshots",
+		Fam:   "cluster snapshots",
+		Ctx:   "elasticsearch.cluster_snapshots_in_progress",
+		Type:  module.Stacked,
+	},
+	{
+		ID:    "cluster_snapshots_last_success_ago",
+		Title: "Cluster Time Since Last Successful Snapshot",
+		Units: "seconds",
+		Fam:   "cluster snapshots",
+		Ctx:   "elasticsearch.cluster_snapshots_last_success_ago",
+	},
+}
+
+var indexChartsTmpl = Charts{
+	{
+		ID:    "index_%s_docs_count",
+		Title: "Index Docs Count",
+		Units: "docs",
+		Fam:   "index stats",
+		Ctx:   "elasticsearch.index_docs_count",
+		Dims: Dims{
+			{ID: "index_%s_primaries_docs_count", Name: "docs"},
+		},
+	},
+	{
+		ID:    "index_%s_store_size",
+		Title: "Index Store Size",
+		Units: "bytes",
+		Fam:   "index stats",
+		Ctx:   "elasticsearch.index_store_size",
+		Dims: Dims{
+			{ID: "index_%s_total_store_size_in_bytes", Name: "size"},
+		},
+	},
+	{
+		ID:    "index_%s_indexing_operations",
+		Title: "Index Indexing Operations",
+		Units: "operations/s",
+		Fam:   "index stats",
+		Ctx:   "elasticsearch.index_indexing",
+		Dims: Dims{
+			{ID: "index_%s_primaries_indexing_index_total", Name: "index", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "index_%s_search_operations",
+		Title: "Index Search Operations",
+		Units: "operations/s",
+		Fam:   "index stats",
+		Ctx:   "elasticsearch.index_search",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "index_%s_total_search_query_total", Name: "queries", Algo: module.Incremental},
+			{ID: "index_%s_total_search_fetch_total", Name: "fetches", Algo: module.Incremental},
+		},
+	},
+}
+
+func newIndexCharts(index string) *Charts {
+	charts := indexChartsTmpl.Copy()
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, index)
+		chart.Labels = []module.Label{
+			{Key: "index", Value: index},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, index)
+		}
+	}
+	return charts
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -18,33 +19,90 @@ import (
 	"github.com/netdata/go.d.plugin/agent/module"
 )
 
+const precision = 100
+
 const (
 	urlPathLocalNodeStats = "/_nodes/_local/stats"
 	urlPathIndicesStats   = "/_cat/indices"
+	urlPathIndexStats     = "/_stats/docs,store,indexing,search"
 	urlPathClusterHealth  = "/_cluster/health"
 	urlPathClusterStats   = "/_cluster/stats"
+	urlPathPendingTasks   = "/_cluster/pending_tasks"
+	urlPathMasterNode     = "/_cluster/state/master_node"
 )
 
 func (es *Elasticsearch) collect() (map[string]int64, error) {
 	ms := es.scrapeElasticsearch()
-	if ms.empty() {
+	if ms.empty() && !es.DoSnapshots {
 		return nil, nil
 	}
 
 	collected := make(map[string]int64)
 	es.collectLocalNodeStats(collected, ms)
+	es.collectMasterNode(collected, ms)
 	es.collectClusterHealth(collected, ms)
 	es.collectClusterStats(collected, ms)
 	es.collectLocalIndicesStats(collected, ms)
+	es.collectIndicesStats(collected, ms)
+	es.collectPendingTasks(collected, ms)
+	es.collectSnapshots(collected)
 
 	return collected, nil
 }
 
-func (Elasticsearch) collectLocalNodeStats(collected map[string]int64, ms *esMetrics) {
+func (es *Elasticsearch) collectLocalNodeStats(collected map[string]int64, ms *esMetrics) {
 	if !ms.hasLocalNodeStats() {
 		return
 	}
-	merge(collected, stm.ToMap(ms.LocalNodeStats), "node")
+	stats := ms.LocalNodeStats
+	merge(collected, stm.ToMap(stats), "node")
+
+	for name, b := range map[string]esBreaker{
+		"request":            stats.Breakers.Request,
+		"fielddata":          stats.Breakers.FieldData,
+		"in_flight_requests": stats.Breakers.InFlightRequests,
+		"model_inference":    stats.Breakers.ModelInference,
+		"accounting":         stats.Breakers.Accounting,
+		"parent":             stats.Breakers.Parent,
+	} {
+		// the limit is -1 if the breaker is disabled
+		if b.LimitSizeInBytes > 0 {
+			collected["node_breakers_"+name+"_usage_percent"] = int64(b.EstimatedSizeInBytes * 100 * precision / b.LimitSizeInBytes)
+		}
+	}
+
+	if stats.IndexingPressure != nil && !es.hasIndexingPressureCharts {
+		es.hasIndexingPressureCharts = true
+		if err := es.Charts().Add(*nodeIndexingPressureCharts.Copy()...); err != nil {
+			es.Warning(err)
+		}
+	}
+	if stats.Discovery.ClusterStateUpdate != nil && !es.hasClusterStateUpdateCharts {
+		es.hasClusterStateUpdateCharts = true
+		if err := es.Charts().Add(*nodeClusterStateUpdateCharts.Copy()...); err != nil {
+			es.Warning(err)
+		}
+	}
+}
+
+// collectMasterNode collects whether the local node sees an elected master
+// and counts the elected master changes since the start.
+func (es *Elasticsearch) collectMasterNode(collected map[string]int64, ms *esMetrics) {
+	if !ms.hasMasterNode() {
+		return
+	}
+	id := ms.MasterNode.MasterNode
+	if id != "" {
+		if es.masterNodeID != "" && es.masterNodeID != id {
+			es.Infof("elected master node changed: '%s' => '%s'", es.masterNodeID, id)
+			es.masterTransitions++
+		}
+		es.masterNodeID = id
+	}
+
+	collected["node_master_elected"] = boolToInt(id != "")
+	collected["node_master_not_elected"] = boolToInt(id == "")
+	collected["node_master_transitions"] = es.masterTransitions
 }
 
 func (Elasticsearch) collectClusterHealth(collected map[string]int64, ms *esMetrics) {
@@ -62,6 +120,20 @@ func (Elasticsearch) collectClusterStats(collected map[string]int64, ms *esMetri
 	merge(collected, stm.ToMap(ms.ClusterStats), "cluster")
 }
 
+func (Elasticsearch) collectPendingTasks(collected map[string]int64, ms *esMetrics) {
+	if !ms.hasPendingTasks() {
+		return
+	}
+	var maxTime int64
+	for _, task := range ms.PendingTasks.Tasks {
+		if task.TimeInQueueMillis > maxTime {
+			maxTime = task.TimeInQueueMillis
+		}
+	}
+	collected["cluster_pending_tasks_count"] = int64(len(ms.PendingTasks.Tasks))
+	collected["cluster_pending_tasks_max_time_in_queue_millis"] = maxTime
+}
+
 func (es *Elasticsearch) collectLocalIndicesStats(mx map[string]int64, ms *esMetrics) {
 	if !ms.hasLocalIndicesStats() {
 		return
@@ -86,6 +158,63 @@ func (es *Elasticsearch) collectLocalIndicesStats(mx map[string]int64, ms *esMet
 	}
 }
 
+func (es *Elasticsearch) collectIndicesStats(mx map[string]int64, ms *esMetrics) {
+	if !ms.hasIndicesStats() {
+		return
+	}
+
+	var indices []string
+	for index := range ms.IndicesStats {
+		if es.indicesMatcher == nil || es.indicesMatcher.MatchString(index) {
+			indices = append(indices, index)
+		}
+	}
+	sort.Strings(indices)
+
+	seen := make(map[string]bool)
+	for _, index := range indices {
+		seen[index] = true
+	}
+	for index := range es.indicesWithCharts {
+		if !seen[index] {
+			delete(es.indicesWithCharts, index)
+			es.removeIndexCharts(index)
+		}
+	}
+
+	var skipped int
+	for _, index := range indices {
+		if !es.indicesWithCharts[index] {
+			if es.MaxIndices > 0 && len(es.indicesWithCharts) >= es.MaxIndices {
+				skipped++
+				continue
+			}
+			es.indicesWithCharts[index] = true
+			es.addIndexCharts(index)
+		}
+		merge(mx, stm.ToMap(ms.IndicesStats[index]), "index_"+index)
+	}
+	if skipped > 0 {
+		es.Debugf("%d indices were not processed due to max_indices limit (%d)", skipped, es.MaxIndices)
+	}
+}
+
+func (es *Elasticsearch) addIndexCharts(index string) {
+	charts := newIndexCharts(index)
+	if err := es.Charts().Add(*charts...); err != nil {
+		es.Warningf("add index '%s' charts: %v", index, err)
+	}
+}
+
+func (es *Elasticsearch) removeIndexCharts(index string) {
+	for _, tmpl := range indexChartsTmpl {
+		if chart := es.Charts().Get(fmt.Sprintf(tmpl.ID, index)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
 func (es *Elasticsearch) addIndexToCharts(index string) {
 	for _, chart := range *es.Charts() {
 		dim := module.Dim{Name: index}
@@ -170,6 +299,13 @@ func convertIndexStoreSizeToBytes(size string) int64 {
 	return int64(num)
 }
 
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
 func strToInt(s string) int64 {
 	v, _ := strconv.Atoi(s)
 	return int64(v)
@@ -195,6 +331,18 @@ func (es Elasticsearch) scrapeElasticsearch() *esMetrics {
 		wg.Add(1)
 		go func() { defer wg.Done(); es.scrapeLocalIndicesStats(ms) }()
 	}
+	if es.DoIndices {
+		wg.Add(1)
+		go func() { defer wg.Done(); es.scrapeIndicesStats(ms) }()
+	}
+	if es.DoMasterNode {
+		wg.Add(1)
+		go func() { defer wg.Done(); es.scrapeMasterNode(ms) }()
+	}
+	if es.DoPendingTasks {
+		wg.Add(1)
+		go func() { defer wg.Done(); es.scrapePendingTasks(ms) }()
+	}
 	wg.Wait()
 	return ms
 }
@@ -253,6 +401,50 @@ func (es *Elasticsearch) scrapeLocalIndicesStats(ms *esMetrics) {
 	ms.LocalIndicesStats = removeSystemIndices(stats)
 }
 
+func (es Elasticsearch) scrapeIndicesStats(ms *esMetrics) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathIndexStats
+	req.URL.RawQuery = "level=indices"
+
+	var stats struct {
+		Indices map[string]esIndexDetailedStats
+	}
+	if err := es.doOKDecode(req, &stats); err != nil {
+		es.Warning(err)
+		return
+	}
+	if stats.Indices == nil {
+		stats.Indices = make(map[string]esIndexDetailedStats)
+	}
+	ms.IndicesStats = stats.Indices
+}
+
+func (es Elasticsearch) scrapePendingTasks(ms *esMetrics) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathPendingTasks
+
+	var tasks esPendingTasks
+	if err := es.doOKDecode(req, &tasks); err != nil {
+		es.Warning(err)
+		return
+	}
+	ms.PendingTasks = &tasks
+}
+
+func (es Elasticsearch) scrapeMasterNode(ms *esMetrics) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathMasterNode
+	// the local node state, the request fails without an elected master otherwise
+	req.URL.RawQuery = "local=true"
+
+	var master esMasterNode
+	if err := es.doOKDecode(req, &master); err != nil {
+		es.Warning(err)
+		return
+	}
+	ms.MasterNode = &master
+}
+
 func (es Elasticsearch) pingElasticsearch() error {
 	req, _ := web.NewHTTPRequest(es.Request)
 
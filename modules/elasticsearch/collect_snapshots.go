@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package elasticsearch
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+const (
+	urlPathSnapshotRepos  = "/_snapshot"
+	urlPathSnapshotStatus = "/_snapshot/_status"
+	urlPathCatSnapshots   = "/_cat/snapshots"
+)
+
+type snapshotRepo struct {
+	inProgress  int64
+	lastSuccess time.Time // zero if there are no successful snapshots
+}
+
+// collectSnapshots collects the snapshot repositories metrics. Listing snapshots is expensive,
+// the repositories are queried once per 'snapshots_every', the last results are used in between.
+func (es *Elasticsearch) collectSnapshots(mx map[string]int64) {
+	if !es.DoSnapshots {
+		return
+	}
+
+	now := time.Now()
+	if es.snapshotRepos == nil || now.Sub(es.snapshotsTime) >= es.SnapshotsEvery.Duration {
+		repos, err := es.scrapeSnapshots()
+		if err != nil {
+			es.Warning(err)
+			return
+		}
+		// a failed scrape is retried on the next data collection
+		es.snapshotsTime = now
+		es.updateSnapshotRepos(repos)
+	}
+
+	for name, repo := range es.snapshotRepos {
+		mx[snapshotRepoDimID(name, "in_progress")] = repo.inProgress
+		if !repo.lastSuccess.IsZero() {
+			mx[snapshotRepoDimID(name, "last_success_ago")] = int64(now.Sub(repo.lastSuccess).Seconds())
+		}
+	}
+}
+
+func (es *Elasticsearch) updateSnapshotRepos(repos map[string]*snapshotRepo) {
+	if es.snapshotRepos == nil {
+		es.snapshotRepos = make(map[string]*snapshotRepo)
+	}
+	for name, repo := range repos {
+		if _, ok := es.snapshotRepos[name]; !ok {
+			es.addSnapshotRepoToCharts(name)
+		}
+		es.snapshotRepos[name] = repo
+	}
+	for name := range es.snapshotRepos {
+		if _, ok := repos[name]; !ok {
+			delete(es.snapshotRepos, name)
+			es.removeSnapshotRepoFromCharts(name)
+		}
+	}
+}
+
+func (es *Elasticsearch) scrapeSnapshots() (map[string]*snapshotRepo, error) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathSnapshotRepos
+
+	var resp map[string]struct{ Type string }
+	if err := es.doOKDecode(req, &resp); err != nil {
+		return nil, err
+	}
+
+	repos := make(map[string]*snapshotRepo)
+	for name := range resp {
+		repos[name] = &snapshotRepo{}
+	}
+	if len(repos) == 0 {
+		return repos, nil
+	}
+
+	req, _ = web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathSnapshotStatus
+
+	var status struct {
+		Snapshots []struct {
+			Repository string
+			State      string
+		}
+	}
+	if err := es.doOKDecode(req, &status); err != nil {
+		return nil, err
+	}
+	for _, s := range status.Snapshots {
+		if repo, ok := repos[s.Repository]; ok {
+			repo.inProgress++
+		}
+	}
+
+	for name, repo := range repos {
+		t, err := es.scrapeLastSuccessfulSnapshot(name)
+		if err != nil {
+			return nil, err
+		}
+		repo.lastSuccess = t
+	}
+	return repos, nil
+}
+
+func (es *Elasticsearch) scrapeLastSuccessfulSnapshot(repo string) (time.Time, error) {
+	req, _ := web.NewHTTPRequest(es.Request)
+	req.URL.Path = urlPathCatSnapshots + "/" + url.PathEscape(repo)
+	req.URL.RawQuery = "format=json&h=id,status,end_epoch"
+
+	var snapshots []struct {
+		ID       string
+		Status   string
+		EndEpoch string `json:"end_epoch"`
+	}
+	if err := es.doOKDecode(req, &snapshots); err != nil {
+		return time.Time{}, err
+	}
+
+	var last int64
+	for _, s := range snapshots {
+		if s.Status != "SUCCESS" {
+			continue
+		}
+		if v, err := strconv.ParseInt(s.EndEpoch, 10, 64); err == nil && v > last {
+			last = v
+		}
+	}
+	if last == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(last, 0), nil
+}
+
+func (es *Elasticsearch) addSnapshotRepoToCharts(repo string) {
+	for _, chart := range *es.Charts() {
+		dim := module.Dim{Name: repo}
+		switch chart.ID {
+		case "cluster_snapshots_in_progress":
+			dim.ID = snapshotRepoDimID(repo, "in_progress")
+		case "cluster_snapshots_last_success_ago":
+			dim.ID = snapshotRepoDimID(repo, "last_success_ago")
+		default:
+			continue
+		}
+		if err := chart.AddDim(&dim); err != nil {
+			es.Warningf("add snapshot repository '%s': %v", repo, err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
+
+func (es *Elasticsearch) removeSnapshotRepoFromCharts(repo string) {
+	for _, chart := range *es.Charts() {
+		var id string
+		switch chart.ID {
+		case "cluster_snapshots_in_progress":
+			id = snapshotRepoDimID(repo, "in_progress")
+		case "cluster_snapshots_last_success_ago":
+			id = snapshotRepoDimID(repo, "last_success_ago")
+		default:
+			continue
+		}
+		if err := chart.MarkDimRemove(id, true); err != nil {
+			es.Warningf("remove snapshot repository '%s': %v", repo, err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
+
+func snapshotRepoDimID(repo, metric string) string {
+	return fmt.Sprintf("cluster_snapshots_repo_%s_%s", repo, metric)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -35,8 +36,19 @@ func New() *Elasticsearch {
 			DoClusterStats:  true,
 			DoClusterHealth: true,
 			DoIndicesStats:  false,
+			DoIndices:       false,
+			Indices: matcher.SimpleExpr{
+				// system indices
+				Excludes: []string{"* .*"},
+			},
+			MaxIndices:     100,
+			DoMasterNode:   false,
+			DoPendingTasks: false,
+			DoSnapshots:    false,
+			SnapshotsEvery: web.Duration{Duration: time.Minute * 5},
 		},
-		collectedIndices: make(map[string]bool),
+		collectedIndices:  make(map[string]bool),
+		indicesWithCharts: make(map[string]bool),
 	}
 }
 
@@ -47,6 +59,15 @@ type (
 		DoClusterHealth bool `yaml:"collect_cluster_health"`
 		DoClusterStats  bool `yaml:"collect_cluster_stats"`
 		DoIndicesStats  bool `yaml:"collect_indices_stats"`
+
+		DoIndices  bool               `yaml:"collect_indices"`
+		Indices    matcher.SimpleExpr `yaml:"indices"`
+		MaxIndices int                `yaml:"max_indices"`
+
+		DoMasterNode   bool         `yaml:"collect_master_node"`
+		DoPendingTasks bool         `yaml:"collect_pending_tasks"`
+		DoSnapshots    bool         `yaml:"collect_snapshots"`
+		SnapshotsEvery web.Duration `yaml:"snapshots_every"`
 	}
 	Elasticsearch struct {
 		module.Base
@@ -55,6 +76,17 @@ type (
 		httpClient       *http.Client
 		charts           *module.Charts
 		collectedIndices map[string]bool
+
+		hasIndexingPressureCharts   bool
+		hasClusterStateUpdateCharts bool
+		masterNodeID                string
+		masterTransitions           int64
+
+		indicesMatcher    matcher.Matcher
+		indicesWithCharts map[string]bool
+
+		snapshotRepos map[string]*snapshotRepo
+		snapshotsTime time.Time
 	}
 )
 
@@ -79,6 +111,13 @@ func (es *Elasticsearch) Init() bool {
 	}
 	es.httpClient = httpClient
 
+	m, err := es.initIndicesMatcher()
+	if err != nil {
+		es.Errorf("init indices matcher: %v", err)
+		return false
+	}
+	es.indicesMatcher = m
+
 	charts, err := es.initCharts()
 	if err != nil {
 		es.Errorf("init charts: %v", err)
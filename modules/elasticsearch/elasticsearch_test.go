@@ -3,11 +3,14 @@
 package elasticsearch
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
@@ -21,6 +24,13 @@ var (
 	v790ClusterHealth, _   = os.ReadFile("testdata/v7.9.0/cluster_health.json")
 	v790ClusterStats, _    = os.ReadFile("testdata/v7.9.0/cluster_stats.json")
 	v790CatIndicesStats, _ = os.ReadFile("testdata/v7.9.0/cat_indices_stats.json")
+	v790IndicesStats, _    = os.ReadFile("testdata/v7.9.0/indices_stats.json")
+	v790PendingTasks, _    = os.ReadFile("testdata/v7.9.0/pending_tasks.json")
+	v790SnapshotRepos, _   = os.ReadFile("testdata/v7.9.0/snapshot_repos.json")
+	v790SnapshotStatus, _  = os.ReadFile("testdata/v7.9.0/snapshot_status.json")
+	v790CatSnapshotsFS, _  = os.ReadFile("testdata/v7.9.0/cat_snapshots_my-fs-repo.json")
+	v790CatSnapshotsS3, _  = os.ReadFile("testdata/v7.9.0/cat_snapshots_my-s3-repo.json")
+	v790MasterNode, _      = os.ReadFile("testdata/v7.9.0/cluster_state_master_node.json")
 	v790Info, _            = os.ReadFile("testdata/v7.9.0/info.json")
 )
 
@@ -30,6 +40,13 @@ func Test_testDataIsCorrectlyReadAndValid(t *testing.T) {
 		"v790ClusterHealth":   v790ClusterHealth,
 		"v790ClusterStats":    v790ClusterStats,
 		"v790CatIndicesStats": v790CatIndicesStats,
+		"v790IndicesStats":    v790IndicesStats,
+		"v790PendingTasks":    v790PendingTasks,
+		"v790SnapshotRepos":   v790SnapshotRepos,
+		"v790SnapshotStatus":  v790SnapshotStatus,
+		"v790CatSnapshotsFS":  v790CatSnapshotsFS,
+		"v790CatSnapshotsS3":  v790CatSnapshotsS3,
+		"v790MasterNode":      v790MasterNode,
 		"v790Info":            v790Info,
 	} {
 		require.NotNilf(t, data, name)
@@ -119,6 +136,25 @@ func TestElasticsearch_Init(t *testing.T) {
 				DoIndicesStats:  true,
 			},
 		},
+		"only indices": {
+			wantNumOfCharts: 0,
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:38001"},
+				},
+				DoIndices: true,
+			},
+		},
+		"only pending_tasks and snapshots": {
+			wantNumOfCharts: numOfCharts(pendingTasksCharts, snapshotsCharts),
+			config: Config{
+				HTTP: web.HTTP{
+					Request: web.Request{URL: "http://127.0.0.1:38001"},
+				},
+				DoPendingTasks: true,
+				DoSnapshots:    true,
+			},
+		},
 		"URL not set": {
 			wantFail: true,
 			config: Config{
@@ -206,6 +242,7 @@ func TestElasticsearch_Collect(t *testing.T) {
 			prepare: func() *Elasticsearch {
 				es := New()
 				es.DoNodeStats = true
+				es.DoMasterNode = true
 				es.DoClusterHealth = true
 				es.DoClusterStats = true
 				es.DoIndicesStats = true
@@ -241,13 +278,42 @@ func TestElasticsearch_Collect(t *testing.T) {
 				"cluster_relocating_shards":                                    1,
 				"cluster_status":                                               0,
 				"cluster_unassigned_shards":                                    1,
+				"node_breakers_accounting_estimated_size_in_bytes":             1,
+				"node_breakers_accounting_limit_size_in_bytes":                 1073741824,
 				"node_breakers_accounting_tripped":                             1,
+				"node_breakers_accounting_usage_percent":                       0,
+				"node_breakers_fielddata_estimated_size_in_bytes":              1,
+				"node_breakers_fielddata_limit_size_in_bytes":                  429496729,
 				"node_breakers_fielddata_tripped":                              1,
+				"node_breakers_fielddata_usage_percent":                        0,
+				"node_breakers_in_flight_requests_estimated_size_in_bytes":     1,
+				"node_breakers_in_flight_requests_limit_size_in_bytes":         1073741824,
 				"node_breakers_in_flight_requests_tripped":                     1,
+				"node_breakers_in_flight_requests_usage_percent":               0,
+				"node_breakers_model_inference_estimated_size_in_bytes":        1,
+				"node_breakers_model_inference_limit_size_in_bytes":            536870912,
 				"node_breakers_model_inference_tripped":                        1,
+				"node_breakers_model_inference_usage_percent":                  0,
+				"node_breakers_parent_estimated_size_in_bytes":                 364215296,
+				"node_breakers_parent_limit_size_in_bytes":                     1020054732,
 				"node_breakers_parent_tripped":                                 1,
+				"node_breakers_parent_usage_percent":                           3570,
+				"node_breakers_request_estimated_size_in_bytes":                1,
+				"node_breakers_request_limit_size_in_bytes":                    644245094,
 				"node_breakers_request_tripped":                                1,
+				"node_breakers_request_usage_percent":                          0,
+				"node_discovery_cluster_state_queue_committed":                 1,
+				"node_discovery_cluster_state_queue_pending":                   1,
+				"node_discovery_published_cluster_states_compatible_diffs":     1,
+				"node_discovery_published_cluster_states_full_states":          2,
+				"node_discovery_published_cluster_states_incompatible_diffs":   1,
 				"node_http_current_open":                                       3,
+				"node_indexing_pressure_memory_current_coordinating_in_bytes":  1,
+				"node_indexing_pressure_memory_current_primary_in_bytes":       1,
+				"node_indexing_pressure_memory_current_replica_in_bytes":       1,
+				"node_indexing_pressure_memory_total_coordinating_rejections":  1,
+				"node_indexing_pressure_memory_total_primary_rejections":       1,
+				"node_indexing_pressure_memory_total_replica_rejections":       1,
 				"node_indices_fielddata_evictions":                             1,
 				"node_indices_fielddata_memory_size_in_bytes":                  1,
 				"node_indices_flush_total":                                     1,
@@ -303,6 +369,9 @@ func TestElasticsearch_Collect(t *testing.T) {
 				"node_jvm_mem_heap_committed_in_bytes":                         1073741824,
 				"node_jvm_mem_heap_used_in_bytes":                              363166720,
 				"node_jvm_mem_heap_used_percent":                               33,
+				"node_master_elected":                                          1,
+				"node_master_not_elected":                                      0,
+				"node_master_transitions":                                      0,
 				"node_process_max_file_descriptors":                            1048576,
 				"node_process_open_file_descriptors":                           258,
 				"node_thread_pool_analyze_queue":                               1,
@@ -345,98 +414,131 @@ func TestElasticsearch_Collect(t *testing.T) {
 			prepare: func() *Elasticsearch {
 				es := New()
 				es.DoNodeStats = true
+				es.DoMasterNode = true
 				es.DoClusterHealth = false
 				es.DoClusterStats = false
 				es.DoIndicesStats = false
 				return es
 			},
 			wantCollected: map[string]int64{
-				"node_breakers_accounting_tripped":                       1,
-				"node_breakers_fielddata_tripped":                        1,
-				"node_breakers_in_flight_requests_tripped":               1,
-				"node_breakers_model_inference_tripped":                  1,
-				"node_breakers_parent_tripped":                           1,
-				"node_breakers_request_tripped":                          1,
-				"node_http_current_open":                                 3,
-				"node_indices_fielddata_evictions":                       1,
-				"node_indices_fielddata_memory_size_in_bytes":            1,
-				"node_indices_flush_total":                               1,
-				"node_indices_flush_total_time_in_millis":                1,
-				"node_indices_indexing_index_current":                    1,
-				"node_indices_indexing_index_time_in_millis":             1,
-				"node_indices_indexing_index_total":                      1,
-				"node_indices_refresh_total":                             1,
-				"node_indices_refresh_total_time_in_millis":              1,
-				"node_indices_search_fetch_current":                      1,
-				"node_indices_search_fetch_time_in_millis":               1,
-				"node_indices_search_fetch_total":                        1,
-				"node_indices_search_query_current":                      1,
-				"node_indices_search_query_time_in_millis":               1,
-				"node_indices_search_query_total":                        1,
-				"node_indices_segments_count":                            1,
-				"node_indices_segments_doc_values_memory_in_bytes":       1,
-				"node_indices_segments_fixed_bit_set_memory_in_bytes":    1,
-				"node_indices_segments_index_writer_memory_in_bytes":     1,
-				"node_indices_segments_memory_in_bytes":                  1,
-				"node_indices_segments_norms_memory_in_bytes":            1,
-				"node_indices_segments_points_memory_in_bytes":           1,
-				"node_indices_segments_stored_fields_memory_in_bytes":    1,
-				"node_indices_segments_term_vectors_memory_in_bytes":     1,
-				"node_indices_segments_terms_memory_in_bytes":            1,
-				"node_indices_segments_version_map_memory_in_bytes":      1,
-				"node_indices_translog_operations":                       1,
-				"node_indices_translog_size_in_bytes":                    1,
-				"node_indices_translog_uncommitted_operations":           1,
-				"node_indices_translog_uncommitted_size_in_bytes":        1,
-				"node_jvm_buffer_pools_direct_count":                     15,
-				"node_jvm_buffer_pools_direct_total_capacity_in_bytes":   6321124,
-				"node_jvm_buffer_pools_direct_used_in_bytes":             6321125,
-				"node_jvm_buffer_pools_mapped_count":                     1,
-				"node_jvm_buffer_pools_mapped_total_capacity_in_bytes":   1,
-				"node_jvm_buffer_pools_mapped_used_in_bytes":             1,
-				"node_jvm_gc_collectors_old_collection_count":            1,
-				"node_jvm_gc_collectors_old_collection_time_in_millis":   1,
-				"node_jvm_gc_collectors_young_collection_count":          16,
-				"node_jvm_gc_collectors_young_collection_time_in_millis": 184,
-				"node_jvm_mem_heap_committed_in_bytes":                   1073741824,
-				"node_jvm_mem_heap_used_in_bytes":                        363166720,
-				"node_jvm_mem_heap_used_percent":                         33,
-				"node_process_max_file_descriptors":                      1048576,
-				"node_process_open_file_descriptors":                     258,
-				"node_thread_pool_analyze_queue":                         1,
-				"node_thread_pool_analyze_rejected":                      1,
-				"node_thread_pool_fetch_shard_started_queue":             1,
-				"node_thread_pool_fetch_shard_started_rejected":          1,
-				"node_thread_pool_fetch_shard_store_queue":               1,
-				"node_thread_pool_fetch_shard_store_rejected":            1,
-				"node_thread_pool_flush_queue":                           1,
-				"node_thread_pool_flush_rejected":                        1,
-				"node_thread_pool_force_merge_queue":                     1,
-				"node_thread_pool_force_merge_rejected":                  1,
-				"node_thread_pool_generic_queue":                         1,
-				"node_thread_pool_generic_rejected":                      1,
-				"node_thread_pool_get_queue":                             1,
-				"node_thread_pool_get_rejected":                          1,
-				"node_thread_pool_listener_queue":                        1,
-				"node_thread_pool_listener_rejected":                     1,
-				"node_thread_pool_management_queue":                      1,
-				"node_thread_pool_management_rejected":                   1,
-				"node_thread_pool_refresh_queue":                         1,
-				"node_thread_pool_refresh_rejected":                      1,
-				"node_thread_pool_search_queue":                          1,
-				"node_thread_pool_search_rejected":                       1,
-				"node_thread_pool_search_throttled_queue":                1,
-				"node_thread_pool_search_throttled_rejected":             1,
-				"node_thread_pool_snapshot_queue":                        1,
-				"node_thread_pool_snapshot_rejected":                     1,
-				"node_thread_pool_warmer_queue":                          1,
-				"node_thread_pool_warmer_rejected":                       1,
-				"node_thread_pool_write_queue":                           1,
-				"node_thread_pool_write_rejected":                        1,
-				"node_transport_rx_count":                                1,
-				"node_transport_rx_size_in_bytes":                        1,
-				"node_transport_tx_count":                                1,
-				"node_transport_tx_size_in_bytes":                        1,
+				"node_breakers_accounting_estimated_size_in_bytes":            1,
+				"node_breakers_accounting_limit_size_in_bytes":                1073741824,
+				"node_breakers_accounting_tripped":                            1,
+				"node_breakers_accounting_usage_percent":                      0,
+				"node_breakers_fielddata_estimated_size_in_bytes":             1,
+				"node_breakers_fielddata_limit_size_in_bytes":                 429496729,
+				"node_breakers_fielddata_tripped":                             1,
+				"node_breakers_fielddata_usage_percent":                       0,
+				"node_breakers_in_flight_requests_estimated_size_in_bytes":    1,
+				"node_breakers_in_flight_requests_limit_size_in_bytes":        1073741824,
+				"node_breakers_in_flight_requests_tripped":                    1,
+				"node_breakers_in_flight_requests_usage_percent":              0,
+				"node_breakers_model_inference_estimated_size_in_bytes":       1,
+				"node_breakers_model_inference_limit_size_in_bytes":           536870912,
+				"node_breakers_model_inference_tripped":                       1,
+				"node_breakers_model_inference_usage_percent":                 0,
+				"node_breakers_parent_estimated_size_in_bytes":                364215296,
+				"node_breakers_parent_limit_size_in_bytes":                    1020054732,
+				"node_breakers_parent_tripped":                                1,
+				"node_breakers_parent_usage_percent":                          3570,
+				"node_breakers_request_estimated_size_in_bytes":               1,
+				"node_breakers_request_limit_size_in_bytes":                   644245094,
+				"node_breakers_request_tripped":                               1,
+				"node_breakers_request_usage_percent":                         0,
+				"node_discovery_cluster_state_queue_committed":                1,
+				"node_discovery_cluster_state_queue_pending":                  1,
+				"node_discovery_published_cluster_states_compatible_diffs":    1,
+				"node_discovery_published_cluster_states_full_states":         2,
+				"node_discovery_published_cluster_states_incompatible_diffs":  1,
+				"node_http_current_open":                                      3,
+				"node_indexing_pressure_memory_current_coordinating_in_bytes": 1,
+				"node_indexing_pressure_memory_current_primary_in_bytes":      1,
+				"node_indexing_pressure_memory_current_replica_in_bytes":      1,
+				"node_indexing_pressure_memory_total_coordinating_rejections": 1,
+				"node_indexing_pressure_memory_total_primary_rejections":      1,
+				"node_indexing_pressure_memory_total_replica_rejections":      1,
+				"node_indices_fielddata_evictions":                            1,
+				"node_indices_fielddata_memory_size_in_bytes":                 1,
+				"node_indices_flush_total":                                    1,
+				"node_indices_flush_total_time_in_millis":                     1,
+				"node_indices_indexing_index_current":                         1,
+				"node_indices_indexing_index_time_in_millis":                  1,
+				"node_indices_indexing_index_total":                           1,
+				"node_indices_refresh_total":                                  1,
+				"node_indices_refresh_total_time_in_millis":                   1,
+				"node_indices_search_fetch_current":                           1,
+				"node_indices_search_fetch_time_in_millis":                    1,
+				"node_indices_search_fetch_total":                             1,
+				"node_indices_search_query_current":                           1,
+				"node_indices_search_query_time_in_millis":                    1,
+				"node_indices_search_query_total":                             1,
+				"node_indices_segments_count":                                 1,
+				"node_indices_segments_doc_values_memory_in_bytes":            1,
+				"node_indices_segments_fixed_bit_set_memory_in_bytes":         1,
+				"node_indices_segments_index_writer_memory_in_bytes":          1,
+				"node_indices_segments_memory_in_bytes":                       1,
+				"node_indices_segments_norms_memory_in_bytes":                 1,
+				"node_indices_segments_points_memory_in_bytes":                1,
+				"node_indices_segments_stored_fields_memory_in_bytes":         1,
+				"node_indices_segments_term_vectors_memory_in_bytes":          1,
+				"node_indices_segments_terms_memory_in_bytes":                 1,
+				"node_indices_segments_version_map_memory_in_bytes":           1,
+				"node_indices_translog_operations":                            1,
+				"node_indices_translog_size_in_bytes":                         1,
+				"node_indices_translog_uncommitted_operations":                1,
+				"node_indices_translog_uncommitted_size_in_bytes":             1,
+				"node_jvm_buffer_pools_direct_count":                          15,
+				"node_jvm_buffer_pools_direct_total_capacity_in_bytes":        6321124,
+				"node_jvm_buffer_pools_direct_used_in_bytes":                  6321125,
+				"node_jvm_buffer_pools_mapped_count":                          1,
+				"node_jvm_buffer_pools_mapped_total_capacity_in_bytes":        1,
+				"node_jvm_buffer_pools_mapped_used_in_bytes":                  1,
+				"node_jvm_gc_collectors_old_collection_count":                 1,
+				"node_jvm_gc_collectors_old_collection_time_in_millis":        1,
+				"node_jvm_gc_collectors_young_collection_count":               16,
+				"node_jvm_gc_collectors_young_collection_time_in_millis":      184,
+				"node_jvm_mem_heap_committed_in_bytes":                        1073741824,
+				"node_jvm_mem_heap_used_in_bytes":                             363166720,
+				"node_jvm_mem_heap_used_percent":                              33,
+				"node_master_elected":                                         1,
+				"node_master_not_elected":                                     0,
+				"node_master_transitions":                                     0,
+				"node_process_max_file_descriptors":                           1048576,
+				"node_process_open_file_descriptors":                          258,
+				"node_thread_pool_analyze_queue":                              1,
+				"node_thread_pool_analyze_rejected":                           1,
+				"node_thread_pool_fetch_shard_started_queue":                  1,
+				"node_thread_pool_fetch_shard_started_rejected":               1,
+				"node_thread_pool_fetch_shard_store_queue":                    1,
+				"node_thread_pool_fetch_shard_store_rejected":                 1,
+				"node_thread_pool_flush_queue":                                1,
+				"node_thread_pool_flush_rejected":                             1,
+				"node_thread_pool_force_merge_queue":                          1,
+				"node_thread_pool_force_merge_rejected":                       1,
+				"node_thread_pool_generic_queue":                              1,
+				"node_thread_pool_generic_rejected":                           1,
+				"node_thread_pool_get_queue":                                  1,
+				"node_thread_pool_get_rejected":                               1,
+				"node_thread_pool_listener_queue":                             1,
+				"node_thread_pool_listener_rejected":                          1,
+				"node_thread_pool_management_queue":                           1,
+				"node_thread_pool_management_rejected":                        1,
+				"node_thread_pool_refresh_queue":                              1,
+				"node_thread_pool_refresh_rejected":                           1,
+				"node_thread_pool_search_queue":                               1,
+				"node_thread_pool_search_rejected":                            1,
+				"node_thread_pool_search_throttled_queue":                     1,
+				"node_thread_pool_search_throttled_rejected":                  1,
+				"node_thread_pool_snapshot_queue":                             1,
+				"node_thread_pool_snapshot_rejected":                          1,
+				"node_thread_pool_warmer_queue":                               1,
+				"node_thread_pool_warmer_rejected":                            1,
+				"node_thread_pool_write_queue":                                1,
+				"node_thread_pool_write_rejected":                             1,
+				"node_transport_rx_count":                                     1,
+				"node_transport_rx_size_in_bytes":                             1,
+				"node_transport_tx_count":                                     1,
+				"node_transport_tx_size_in_bytes":                             1,
 			},
 		},
 		"v790: only cluster_health": {
@@ -516,6 +618,28 @@ func TestElasticsearch_Collect(t *testing.T) {
 				"node_indices_stats_my-index-000003_index_store_size_in_bytes": 208,
 			},
 		},
+		"v790: only indices": {
+			prepare: func() *Elasticsearch {
+				es := New()
+				es.DoNodeStats = false
+				es.DoClusterHealth = false
+				es.DoClusterStats = false
+				es.DoIndices = true
+				return es
+			},
+			wantCollected: map[string]int64{
+				"index_my-index-000001_primaries_docs_count":           10,
+				"index_my-index-000001_primaries_indexing_index_total": 10,
+				"index_my-index-000001_total_search_fetch_total":       50,
+				"index_my-index-000001_total_search_query_total":       100,
+				"index_my-index-000001_total_store_size_in_bytes":      4096,
+				"index_my-index-000002_primaries_docs_count":           20,
+				"index_my-index-000002_primaries_indexing_index_total": 20,
+				"index_my-index-000002_total_search_fetch_total":       100,
+				"index_my-index-000002_total_search_query_total":       200,
+				"index_my-index-000002_total_store_size_in_bytes":      8192,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -534,6 +658,158 @@ func TestElasticsearch_Collect(t *testing.T) {
 	}
 }
 
+func TestElasticsearch_Collect_Indices(t *testing.T) {
+	es, cleanup := prepareElasticsearch(t, func() *Elasticsearch {
+		es := New()
+		es.DoNodeStats = false
+		es.DoClusterHealth = false
+		es.DoClusterStats = false
+		es.DoIndices = true
+		es.Indices.Includes = []string{"* my-index-*"}
+		es.MaxIndices = 1
+		return es
+	})
+	defer cleanup()
+
+	collected := es.Collect()
+
+	assert.Contains(t, collected, "index_my-index-000001_primaries_docs_count")
+	assert.NotContains(t, collected, "index_my-index-000002_primaries_docs_count", "max_indices limit")
+	assert.NotContains(t, collected, "index_.my-system-index-000001_primaries_docs_count", "system index")
+	assert.True(t, es.Charts().Has("index_my-index-000001_docs_count"))
+	assert.Len(t, *es.Charts(), len(indexChartsTmpl))
+	ensureCollectedHasAllChartsDimsVarsIDs(t, es, collected)
+
+	// the index is deleted, its charts are removed and the limit allows to add another one
+	es.indicesMatcher, _ = (&matcher.SimpleExpr{Includes: []string{"= my-index-000002"}}).Parse()
+	collected = es.Collect()
+
+	assert.Contains(t, collected, "index_my-index-000002_primaries_docs_count")
+	assert.NotContains(t, collected, "index_my-index-000001_primaries_docs_count")
+	for _, tmpl := range indexChartsTmpl {
+		assert.True(t, es.Charts().Get(fmt.Sprintf(tmpl.ID, "my-index-000001")).Obsolete)
+		assert.True(t, es.Charts().Has(fmt.Sprintf(tmpl.ID, "my-index-000002")))
+	}
+	ensureCollectedHasAllChartsDimsVarsIDs(t, es, collected)
+}
+
+func TestElasticsearch_Collect_PendingTasksAndSnapshots(t *testing.T) {
+	es, cleanup := prepareElasticsearch(t, func() *Elasticsearch {
+		es := New()
+		es.DoNodeStats = false
+		es.DoClusterHealth = false
+		es.DoClusterStats = false
+		es.DoPendingTasks = true
+		es.DoSnapshots = true
+		return es
+	})
+	defer cleanup()
+
+	collected := es.Collect()
+
+	lastSuccessAgo := int64(time.Since(time.Unix(1601395000, 0)).Seconds())
+	assert.EqualValues(t, 3, collected["cluster_pending_tasks_count"])
+	assert.EqualValues(t, 858, collected["cluster_pending_tasks_max_time_in_queue_millis"])
+	assert.EqualValues(t, 1, collected["cluster_snapshots_repo_my-fs-repo_in_progress"])
+	assert.EqualValues(t, 0, collected["cluster_snapshots_repo_my-s3-repo_in_progress"])
+	assert.InDelta(t, lastSuccessAgo, collected["cluster_snapshots_repo_my-fs-repo_last_success_ago"], 1)
+	assert.NotContains(t, collected, "cluster_snapshots_repo_my-s3-repo_last_success_ago", "no successful snapshots")
+
+	for _, id := range []string{"cluster_snapshots_in_progress", "cluster_snapshots_last_success_ago"} {
+		assert.Len(t, es.Charts().Get(id).Dims, 2)
+	}
+
+	// the repositories are queried once per 'snapshots_every', the last results are reported in between
+	es.snapshotRepos["my-fs-repo"].inProgress = 5
+	collected = es.Collect()
+	assert.EqualValues(t, 5, collected["cluster_snapshots_repo_my-fs-repo_in_progress"])
+
+	es.snapshotsTime = time.Time{}
+	collected = es.Collect()
+	assert.EqualValues(t, 1, collected["cluster_snapshots_repo_my-fs-repo_in_progress"])
+}
+
+func TestElasticsearch_Collect_SnapshotsError(t *testing.T) {
+	var fail bool
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case urlPathLocalNodeStats:
+				_, _ = w.Write(v790NodesLocalStats)
+			case urlPathSnapshotRepos:
+				if fail {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				_, _ = w.Write([]byte(`{}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	es := New()
+	es.URL = srv.URL
+	es.DoClusterHealth = false
+	es.DoClusterStats = false
+	es.DoSnapshots = true
+	require.True(t, es.Init())
+
+	fail = true
+	_ = es.Collect()
+	assert.True(t, es.snapshotsTime.IsZero(), "the failed scrape is retried on the next data collection")
+
+	fail = false
+	_ = es.Collect()
+	assert.False(t, es.snapshotsTime.IsZero())
+}
+
+func TestElasticsearch_Collect_MasterNode(t *testing.T) {
+	var masterNode string
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case urlPathLocalNodeStats:
+				_, _ = w.Write(v790NodesLocalStats)
+			case urlPathMasterNode:
+				if masterNode == "" {
+					_, _ = w.Write([]byte(`{"cluster_name": "docker-cluster"}`))
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"cluster_name": "docker-cluster", "master_node": "%s"}`, masterNode)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	es := New()
+	es.URL = srv.URL
+	es.DoClusterHealth = false
+	es.DoClusterStats = false
+	es.DoMasterNode = true
+	require.True(t, es.Init())
+
+	for i, step := range []struct {
+		master          string
+		wantElected     int64
+		wantTransitions int64
+	}{
+		{master: "node1", wantElected: 1, wantTransitions: 0},
+		{master: "node1", wantElected: 1, wantTransitions: 0},
+		{master: "", wantElected: 0, wantTransitions: 0},
+		{master: "node2", wantElected: 1, wantTransitions: 1},
+		{master: "node1", wantElected: 1, wantTransitions: 2},
+	} {
+		masterNode = step.master
+		collected := es.Collect()
+
+		assert.Equalf(t, step.wantElected, collected["node_master_elected"], "step %d", i)
+		assert.Equalf(t, 1-step.wantElected, collected["node_master_not_elected"], "step %d", i)
+		assert.Equalf(t, step.wantTransitions, collected["node_master_transitions"], "step %d", i)
+	}
+}
+
 func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, es *Elasticsearch, collected map[string]int64) {
 	for _, chart := range *es.Charts() {
 		if chart.Obsolete {
@@ -612,6 +888,20 @@ func prepareElasticsearchEndpoint() *httptest.Server {
 				_, _ = w.Write(v790ClusterStats)
 			case urlPathIndicesStats:
 				_, _ = w.Write(v790CatIndicesStats)
+			case urlPathIndexStats:
+				_, _ = w.Write(v790IndicesStats)
+			case urlPathPendingTasks:
+				_, _ = w.Write(v790PendingTasks)
+			case urlPathMasterNode:
+				_, _ = w.Write(v790MasterNode)
+			case urlPathSnapshotRepos:
+				_, _ = w.Write(v790SnapshotRepos)
+			case urlPathSnapshotStatus:
+				_, _ = w.Write(v790SnapshotStatus)
+			case urlPathCatSnapshots + "/my-fs-repo":
+				_, _ = w.Write(v790CatSnapshotsFS)
+			case urlPathCatSnapshots + "/my-s3-repo":
+				_, _ = w.Write(v790CatSnapshotsS3)
 			case "/":
 				_, _ = w.Write(v790Info)
 			default:
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -15,7 +16,8 @@ func (es Elasticsearch) validateConfig() error {
 	if es.URL == "" {
 		return errors.New("URL not set")
 	}
-	if !(es.DoNodeStats || es.DoClusterHealth || es.DoClusterStats || es.DoIndicesStats) {
+	if !(es.DoNodeStats || es.DoClusterHealth || es.DoClusterStats || es.DoIndicesStats || es.DoIndices ||
+		es.DoMasterNode || es.DoPendingTasks || es.DoSnapshots) {
 		return errors.New("all API calls are disabled")
 	}
 	if _, err := web.NewHTTPRequest(es.Request); err != nil {
@@ -28,6 +30,13 @@ func (es Elasticsearch) initHTTPClient() (*http.Client, error) {
 	return web.NewHTTPClient(es.Client)
 }
 
+func (es Elasticsearch) initIndicesMatcher() (matcher.Matcher, error) {
+	if !es.DoIndices || es.Indices.Empty() {
+		return nil, nil
+	}
+	return es.Indices.Parse()
+}
+
 func (es Elasticsearch) initCharts() (*Charts, error) {
 	charts := module.Charts{}
 	if es.DoNodeStats {
@@ -50,7 +59,23 @@ func (es Elasticsearch) initCharts() (*Charts, error) {
 			return nil, err
 		}
 	}
-	if len(charts) == 0 {
+	if es.DoMasterNode {
+		if err := charts.Add(*masterNodeCharts.Copy()...); err != nil {
+			return nil, err
+		}
+	}
+	if es.DoPendingTasks {
+		if err := charts.Add(*pendingTasksCharts.Copy()...); err != nil {
+			return nil, err
+		}
+	}
+	if es.DoSnapshots {
+		if err := charts.Add(*snapshotsCharts.Copy()...); err != nil {
+			return nil, err
+		}
+	}
+	// per index charts are added dynamically
+	if len(charts) == 0 && !es.DoIndices {
 		return nil, errors.New("zero charts")
 	}
 	return &charts, nil
@@ -13,11 +13,18 @@ type esMetrics struct {
 	ClusterStats *esClusterStats
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cat-indices.html
 	LocalIndicesStats []esIndexStats
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-stats.html
+	IndicesStats map[string]esIndexDetailedStats
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-pending.html
+	PendingTasks *esPendingTasks
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/cluster-state.html
+	MasterNode *esMasterNode
 }
 
 func (m esMetrics) empty() bool {
 	switch {
-	case m.hasLocalNodeStats(), m.hasClusterHealth(), m.hasClusterStats(), m.hasLocalIndicesStats():
+	case m.hasLocalNodeStats(), m.hasClusterHealth(), m.hasClusterStats(), m.hasLocalIndicesStats(), m.hasIndicesStats(),
+		m.hasPendingTasks(), m.hasMasterNode():
 		return false
 	}
 	return true
@@ -27,6 +34,9 @@ func (m esMetrics) hasLocalNodeStats() bool    { return m.LocalNodeStats != nil
 func (m esMetrics) hasClusterHealth() bool     { return m.ClusterHealth != nil }
 func (m esMetrics) hasClusterStats() bool      { return m.ClusterStats != nil }
 func (m esMetrics) hasLocalIndicesStats() bool { return len(m.LocalIndicesStats) > 0 }
+func (m esMetrics) hasIndicesStats() bool      { return m.IndicesStats != nil }
+func (m esMetrics) hasPendingTasks() bool      { return m.PendingTasks != nil }
+func (m esMetrics) hasMasterNode() bool        { return m.MasterNode != nil }
 
 // TODO: make metrics less verbose
 
@@ -185,25 +195,57 @@ type esNodeStats struct {
 		CurrentOpen float64 `stm:"current_open" json:"current_open"`
 	} `stm:"http"`
 	Breakers struct {
-		Request struct {
-			Tripped float64 `stm:"tripped"`
-		} `stm:"request"`
-		FieldData struct {
-			Tripped float64 `stm:"tripped"`
-		} `stm:"fielddata"`
-		InFlightRequests struct {
-			Tripped float64 `stm:"tripped"`
-		} `stm:"in_flight_requests" json:"in_flight_requests"`
-		ModelInference struct {
-			Tripped float64 `stm:"tripped"`
-		} `stm:"model_inference" json:"model_inference"`
-		Accounting struct {
-			Tripped float64 `stm:"tripped"`
-		} `stm:"accounting"`
-		Parent struct {
-			Tripped float64 `stm:"tripped"`
-		} `stm:"parent"`
+		Request          esBreaker `stm:"request"`
+		FieldData        esBreaker `stm:"fielddata"`
+		InFlightRequests esBreaker `stm:"in_flight_requests" json:"in_flight_requests"`
+		ModelInference   esBreaker `stm:"model_inference" json:"model_inference"`
+		Accounting       esBreaker `stm:"accounting"`
+		Parent           esBreaker `stm:"parent"`
 	} `stm:"breakers"`
+	// available since v7.9
+	IndexingPressure *struct {
+		Memory struct {
+			Current struct {
+				CoordinatingInBytes float64 `stm:"coordinating_in_bytes" json:"coordinating_in_bytes"`
+				PrimaryInBytes      float64 `stm:"primary_in_bytes" json:"primary_in_bytes"`
+				ReplicaInBytes      float64 `stm:"replica_in_bytes" json:"replica_in_bytes"`
+			} `stm:"current"`
+			Total struct {
+				CoordinatingRejections float64 `stm:"coordinating_rejections" json:"coordinating_rejections"`
+				PrimaryRejections      float64 `stm:"primary_rejections" json:"primary_rejections"`
+				ReplicaRejections      float64 `stm:"replica_rejections" json:"replica_rejections"`
+			} `stm:"total"`
+		} `stm:"memory"`
+	} `stm:"indexing_pressure" json:"indexing_pressure"`
+	Discovery struct {
+		ClusterStateQueue struct {
+			Pending   float64 `stm:"pending"`
+			Committed float64 `stm:"committed"`
+		} `stm:"cluster_state_queue" json:"cluster_state_queue"`
+		PublishedClusterStates struct {
+			FullStates        float64 `stm:"full_states" json:"full_states"`
+			IncompatibleDiffs float64 `stm:"incompatible_diffs" json:"incompatible_diffs"`
+			CompatibleDiffs   float64 `stm:"compatible_diffs" json:"compatible_diffs"`
+		} `stm:"published_cluster_states" json:"published_cluster_states"`
+		// available since v7.16
+		ClusterStateUpdate *struct {
+			Unchanged esClusterStateUpdateStats `stm:"unchanged"`
+			Success   esClusterStateUpdateStats `stm:"success"`
+			Failure   esClusterStateUpdateStats `stm:"failure"`
+		} `stm:"cluster_state_update" json:"cluster_state_update"`
+	} `stm:"discovery"`
+}
+
+type esClusterStateUpdateStats struct {
+	Count                  float64 `stm:"count"`
+	ComputationTimeMillis  float64 `stm:"computation_time_millis" json:"computation_time_millis"`
+	NotificationTimeMillis float64 `stm:"notification_time_millis" json:"notification_time_millis"`
+}
+
+type esBreaker struct {
+	LimitSizeInBytes     float64 `stm:"limit_size_in_bytes" json:"limit_size_in_bytes"`
+	EstimatedSizeInBytes float64 `stm:"estimated_size_in_bytes" json:"estimated_size_in_bytes"`
+	Tripped              float64 `stm:"tripped"`
 }
 
 type esClusterHealth struct {
@@ -262,3 +304,34 @@ type esIndexStats struct {
 	DocsCount string `json:"docs.count"`
 	StoreSize string `json:"store.size"`
 }
+
+type esIndexDetailedStats struct {
+	Primaries struct {
+		Docs struct {
+			Count float64 `stm:"count"`
+		} `stm:"docs"`
+		Indexing struct {
+			IndexTotal float64 `stm:"index_total" json:"index_total"`
+		} `stm:"indexing"`
+	} `stm:"primaries"`
+	Total struct {
+		Store struct {
+			SizeInBytes float64 `stm:"size_in_bytes" json:"size_in_bytes"`
+		} `stm:"store"`
+		Search struct {
+			QueryTotal float64 `stm:"query_total" json:"query_total"`
+			FetchTotal float64 `stm:"fetch_total" json:"fetch_total"`
+		} `stm:"search"`
+	} `stm:"total"`
+}
+
+type esPendingTasks struct {
+	Tasks []struct {
+		TimeInQueueMillis int64 `json:"time_in_queue_millis"`
+	}
+}
+
+type esMasterNode struct {
+	// the field is missing if there is no elected master
+	MasterNode string `json:"master_node"`
+}
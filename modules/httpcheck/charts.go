@@ -11,6 +11,9 @@ const (
 	prioResponseLength
 	prioResponseStatus
 	prioResponseInStatusDuration
+	prioResponseExtractedValue
+	prioCertExpiry
+	prioRedirects
 )
 
 var httpCheckCharts = module.Charts{
@@ -75,3 +78,39 @@ var responseInStatusDurationChart = module.Chart{
 		{ID: "in_state", Name: "time"},
 	},
 }
+
+var responseExtractedValueChart = module.Chart{
+	ID:       "response_extracted_value",
+	Title:    "HTTP Response Extracted Value",
+	Units:    "value",
+	Fam:      "response",
+	Ctx:      "httpcheck.response_extracted_value",
+	Priority: prioResponseExtractedValue,
+	Dims: module.Dims{
+		{ID: "extracted_value", Div: extractPrecision},
+	},
+}
+
+var certExpiryChart = module.Chart{
+	ID:       "cert_expiry",
+	Title:    "HTTPS Certificate Days Until Expiry",
+	Units:    "days",
+	Fam:      "certificate",
+	Ctx:      "httpcheck.cert_expiry",
+	Priority: prioCertExpiry,
+	Dims: module.Dims{
+		{ID: "cert_expiry_days", Name: "days", Div: certExpiryPrecision},
+	},
+}
+
+var redirectsChart = module.Chart{
+	ID:       "redirects",
+	Title:    "HTTP Redirects Followed",
+	Units:    "redirects",
+	Fam:      "response",
+	Ctx:      "httpcheck.redirects",
+	Priority: prioRedirects,
+	Dims: module.Dims{
+		{ID: "redirects"},
+	},
+}
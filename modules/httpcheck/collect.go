@@ -30,6 +30,7 @@ func (hc *HTTPCheck) collect() (map[string]int64, error) {
 	}
 
 	var mx metrics
+	hc.redirects, hc.tooManyRedirects = 0, false
 
 	start := time.Now()
 	resp, err := hc.client.Do(req)
@@ -41,6 +42,8 @@ func (hc *HTTPCheck) collect() (map[string]int64, error) {
 		hc.collectErrResponse(&mx, err)
 	} else {
 		mx.ResponseTime = durationToMs(dur)
+		mx.Redirects = hc.redirects
+		hc.collectCertExpiry(&mx, resp)
 		hc.collectOKResponse(&mx, resp)
 	}
 
@@ -79,11 +82,28 @@ func (hc *HTTPCheck) collectErrResponse(mx *metrics, err error) {
 func (hc *HTTPCheck) collectOKResponse(mx *metrics, resp *http.Response) {
 	hc.Debugf("endpoint '%s' returned %d (%s) HTTP status code", hc.URL, resp.StatusCode, resp.Status)
 
-	if !hc.acceptedStatuses[resp.StatusCode] {
+	if hc.tooManyRedirects {
+		hc.Warningf("endpoint '%s' stopped after %d redirects", hc.URL, hc.MaxRedirects)
+		mx.Status.BadRedirect = true
+		return
+	}
+
+	if hc.reFinalURL != nil && resp.Request != nil && !hc.reFinalURL.MatchString(resp.Request.URL.String()) {
+		hc.Warningf("endpoint '%s' final URL '%s' didn't match", hc.URL, resp.Request.URL)
+		mx.Status.BadRedirect = true
+		return
+	}
+
+	if !hc.isAcceptedStatus(resp.StatusCode) {
 		mx.Status.BadStatusCode = true
 		return
 	}
 
+	if !hc.matchHeaders(resp.Header) {
+		mx.Status.BadHeader = true
+		return
+	}
+
 	bs, err := io.ReadAll(resp.Body)
 	if err != nil && err != io.EOF {
 		hc.Warningf("error on reading body : %v", err)
@@ -98,9 +118,76 @@ func (hc *HTTPCheck) collectOKResponse(mx *metrics, resp *http.Response) {
 		return
 	}
 
+	if hc.extractor != nil {
+		v, err := hc.extractor.extract(bs)
+		if err != nil {
+			hc.Warningf("error on extracting value from the response: %v", err)
+			mx.Status.BadExtract = true
+			return
+		}
+		mx.ExtractedValue = &v
+	}
+
 	mx.Status.Success = true
 }
 
+// checkRedirect is the client redirect policy. The last response is returned instead of an error
+// when the redirect is not followed, so it's checked as usual (e.g. 3xx status code can be accepted).
+func (hc *HTTPCheck) checkRedirect(_ *http.Request, via []*http.Request) error {
+	if hc.NotFollowRedirect {
+		return http.ErrUseLastResponse
+	}
+	if len(via) > hc.MaxRedirects {
+		hc.tooManyRedirects = true
+		return http.ErrUseLastResponse
+	}
+	hc.redirects = len(via)
+	return nil
+}
+
+// collectCertExpiry collects the days until the leaf certificate expiry. The certificate is available
+// regardless of the chain verification (e.g. self-signed with 'tls_skip_verify').
+// If the request is redirected, the certificate of the configured URL host (the first hop) is used.
+func (hc *HTTPCheck) collectCertExpiry(mx *metrics, resp *http.Response) {
+	for resp.Request != nil && resp.Request.Response != nil {
+		resp = resp.Request.Response
+	}
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		return
+	}
+	days := time.Until(resp.TLS.PeerCertificates[0].NotAfter).Hours() / 24
+	mx.CertExpiryDays = &days
+}
+
+func (hc *HTTPCheck) isAcceptedStatus(code int) bool {
+	for _, r := range hc.acceptedStatuses {
+		if code >= r.from && code <= r.to {
+			return true
+		}
+	}
+	return false
+}
+
+func (hc *HTTPCheck) matchHeaders(header http.Header) bool {
+	for _, hm := range hc.headersMatch {
+		values, found := header[hm.key]
+		if found && hm.valMatcher != nil {
+			found = false
+			for _, v := range values {
+				if hm.valMatcher.MatchString(v) {
+					found = true
+					break
+				}
+			}
+		}
+		if found == hm.exclude {
+			hc.Debugf("endpoint '%s' header '%s' match failed (exclude: %v)", hc.URL, hm.key, hm.exclude)
+			return false
+		}
+	}
+	return true
+}
+
 func decodeReqError(err error) reqErrCode {
 	if err == nil {
 		panic("nil error")
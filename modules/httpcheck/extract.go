@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package httpcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// valueExtractor extracts a number from the response body using either a regex named capture group
+// or a JSON path.
+type valueExtractor struct {
+	name     string
+	re       *regexp.Regexp
+	group    int
+	jsonPath []string
+}
+
+func newValueExtractor(cfg ResponseExtract) (*valueExtractor, error) {
+	switch {
+	case cfg.Regex != "" && cfg.JSONPath != "":
+		return nil, errors.New("'regex' and 'json_path' are mutually exclusive")
+	case cfg.Regex != "":
+		re, err := regexp.Compile(cfg.Regex)
+		if err != nil {
+			return nil, err
+		}
+		for i, name := range re.SubexpNames() {
+			if name != "" {
+				return &valueExtractor{name: name, re: re, group: i}, nil
+			}
+		}
+		return nil, fmt.Errorf("regex '%s' has no named capture group", cfg.Regex)
+	case cfg.JSONPath != "":
+		path := strings.TrimPrefix(strings.TrimPrefix(cfg.JSONPath, "$"), ".")
+		if path == "" {
+			return nil, fmt.Errorf("invalid json path '%s'", cfg.JSONPath)
+		}
+		return &valueExtractor{name: cfg.JSONPath, jsonPath: strings.Split(path, ".")}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (e *valueExtractor) extract(body []byte) (float64, error) {
+	if e.re != nil {
+		return e.extractRegex(body)
+	}
+	return e.extractJSON(body)
+}
+
+func (e *valueExtractor) extractRegex(body []byte) (float64, error) {
+	match := e.re.FindSubmatch(body)
+	if match == nil {
+		return 0, fmt.Errorf("regex '%s' didn't match the response", e.re)
+	}
+	return parseExtractedValue(string(match[e.group]))
+}
+
+func (e *valueExtractor) extractJSON(body []byte) (float64, error) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return 0, fmt.Errorf("error on decoding response as JSON: %v", err)
+	}
+
+	for i, key := range e.jsonPath {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = node[key]; !ok {
+				return 0, fmt.Errorf("json path '%s' not found", strings.Join(e.jsonPath[:i+1], "."))
+			}
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return 0, fmt.Errorf("json path '%s': invalid array index", strings.Join(e.jsonPath[:i+1], "."))
+			}
+			v = node[idx]
+		default:
+			return 0, fmt.Errorf("json path '%s' not found", strings.Join(e.jsonPath[:i+1], "."))
+		}
+	}
+
+	switch value := v.(type) {
+	case float64:
+		return value, nil
+	case string:
+		return parseExtractedValue(value)
+	default:
+		return 0, fmt.Errorf("json path '%s' value '%v' is not a number", strings.Join(e.jsonPath, "."), v)
+	}
+}
+
+func parseExtractedValue(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("extracted value '%s' is not a number", s)
+	}
+	return v, nil
+}
@@ -29,17 +29,32 @@ func New() *HTTPCheck {
 					Timeout: web.Duration{Duration: time.Second},
 				},
 			},
-			AcceptedStatuses: []int{200},
+			AcceptedStatuses: []string{"200"},
+			MaxRedirects:     10,
 		},
-		acceptedStatuses: make(map[int]bool),
 	}
 }
 
-type Config struct {
-	web.HTTP         `yaml:",inline"`
-	AcceptedStatuses []int  `yaml:"status_accepted"`
-	ResponseMatch    string `yaml:"response_match"`
-}
+type (
+	Config struct {
+		web.HTTP         `yaml:",inline"`
+		AcceptedStatuses []string        `yaml:"status_accepted"`
+		ResponseMatch    string          `yaml:"response_match"`
+		ResponseExtract  ResponseExtract `yaml:"response_extract"`
+		HeadersMatch     []HeaderMatch   `yaml:"headers_match"`
+		MaxRedirects     int             `yaml:"max_redirects"`
+		FinalURLMatch    string          `yaml:"final_url_match"`
+	}
+	HeaderMatch struct {
+		Exclude bool   `yaml:"exclude"`
+		Key     string `yaml:"key"`
+		Value   string `yaml:"value"`
+	}
+	ResponseExtract struct {
+		Regex    string `yaml:"regex"`
+		JSONPath string `yaml:"json_path"`
+	}
+)
 
 type (
 	HTTPCheck struct {
@@ -49,10 +64,16 @@ type (
 
 		charts *module.Charts
 
-		acceptedStatuses map[int]bool
+		acceptedStatuses []statusRange
+		headersMatch     []headerMatch
 		reResponse       *regexp.Regexp
+		extractor        *valueExtractor
+		reFinalURL       *regexp.Regexp
 		client           client
 		metrics          metrics
+
+		redirects        int  // redirects followed during the current check
+		tooManyRedirects bool // more than 'max_redirects' redirects during the current check
 	}
 	client interface {
 		Do(*http.Request) (*http.Response, error)
@@ -65,13 +86,19 @@ func (hc *HTTPCheck) Init() bool {
 		return false
 	}
 
-	hc.charts = hc.initCharts()
+	extractor, err := newValueExtractor(hc.ResponseExtract)
+	if err != nil {
+		hc.Errorf("init response extract: %v", err)
+		return false
+	}
+	hc.extractor = extractor
 
 	httpClient, err := hc.initHTTPClient()
 	if err != nil {
 		hc.Errorf("init HTTP client: %v", err)
 		return false
 	}
+	httpClient.CheckRedirect = hc.checkRedirect
 	hc.client = httpClient
 
 	re, err := hc.initResponseMatchRegexp()
@@ -81,9 +108,28 @@ func (hc *HTTPCheck) Init() bool {
 	}
 	hc.reResponse = re
 
-	for _, v := range hc.AcceptedStatuses {
-		hc.acceptedStatuses[v] = true
+	reFinalURL, err := hc.initFinalURLMatchRegexp()
+	if err != nil {
+		hc.Errorf("init final URL match regexp: %v", err)
+		return false
+	}
+	hc.reFinalURL = reFinalURL
+
+	statuses, err := hc.initAcceptedStatuses()
+	if err != nil {
+		hc.Errorf("init accepted statuses: %v", err)
+		return false
 	}
+	hc.acceptedStatuses = statuses
+
+	hm, err := hc.initHeadersMatch()
+	if err != nil {
+		hc.Errorf("init headers match: %v", err)
+		return false
+	}
+	hc.headersMatch = hm
+
+	hc.charts = hc.initCharts()
 
 	hc.Debugf("using URL %s", hc.URL)
 	hc.Debugf("using HTTP timeout %s", hc.Timeout.Duration)
@@ -91,6 +137,17 @@ func (hc *HTTPCheck) Init() bool {
 	if hc.reResponse != nil {
 		hc.Debugf("using response match regexp %s", hc.reResponse)
 	}
+	if hc.NotFollowRedirect {
+		hc.Debugf("not following redirects")
+	} else {
+		hc.Debugf("using max redirects %d", hc.MaxRedirects)
+	}
+	if hc.reFinalURL != nil {
+		hc.Debugf("using final URL match regexp %s", hc.reFinalURL)
+	}
+	if hc.extractor != nil {
+		hc.Debugf("using response extract '%s'", hc.extractor.name)
+	}
 
 	return true
 }
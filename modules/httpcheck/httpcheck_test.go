@@ -6,13 +6,16 @@ import (
 	"bytes"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/stm"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -246,6 +249,361 @@ func TestHTTPCheck_Collect_InState(t *testing.T) {
 	)
 }
 
+func TestHTTPCheck_Collect_ResponseExtract(t *testing.T) {
+	tests := map[string]struct {
+		extract    ResponseExtract
+		body       string
+		wantStatus status
+		wantValue  *float64
+	}{
+		"regex": {
+			extract:    ResponseExtract{Regex: `queue_depth=(?P<queue_depth>\d+)`},
+			body:       "status=ok queue_depth=42",
+			wantStatus: status{Success: true},
+			wantValue:  ptr(42.0),
+		},
+		"regex no match": {
+			extract:    ResponseExtract{Regex: `queue_depth=(?P<queue_depth>\d+)`},
+			body:       "status=ok",
+			wantStatus: status{BadExtract: true},
+		},
+		"json path": {
+			extract:    ResponseExtract{JSONPath: "$.queues.1.depth"},
+			body:       `{"queues": [{"depth": 1}, {"depth": 12.5}]}`,
+			wantStatus: status{Success: true},
+			wantValue:  ptr(12.5),
+		},
+		"json path numeric string": {
+			extract:    ResponseExtract{JSONPath: "queue.depth"},
+			body:       `{"queue": {"depth": "7"}}`,
+			wantStatus: status{Success: true},
+			wantValue:  ptr(7.0),
+		},
+		"json path not found": {
+			extract:    ResponseExtract{JSONPath: "queue.size"},
+			body:       `{"queue": {"depth": 7}}`,
+			wantStatus: status{BadExtract: true},
+		},
+		"json path not a number": {
+			extract:    ResponseExtract{JSONPath: "queue"},
+			body:       `{"queue": {"depth": 7}}`,
+			wantStatus: status{BadExtract: true},
+		},
+		"invalid json": {
+			extract:    ResponseExtract{JSONPath: "queue"},
+			body:       `queue`,
+			wantStatus: status{BadExtract: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := New()
+			job.URL = testURL
+			job.ResponseExtract = test.extract
+			require.True(t, job.Init())
+			require.True(t, job.Charts().Has(responseExtractedValueChart.ID))
+
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       nopCloser{bytes.NewBufferString(test.body)},
+			}
+			job.client = newClientFunc(resp, nil)
+
+			assert.Equal(
+				t,
+				stm.ToMap(metrics{
+					Status:         test.wantStatus,
+					ResponseLength: len(test.body),
+					ExtractedValue: test.wantValue,
+				}),
+				job.Collect(),
+			)
+		})
+	}
+}
+
+func TestHTTPCheck_Init_ResponseExtractNG(t *testing.T) {
+	tests := map[string]ResponseExtract{
+		"no named group":    {Regex: `depth=(\d+)`},
+		"invalid regex":     {Regex: `(?P<depth>\d+`},
+		"regex and json":    {Regex: `(?P<depth>\d+)`, JSONPath: "depth"},
+		"invalid json path": {JSONPath: "$."},
+	}
+
+	for name, extract := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := New()
+			job.URL = testURL
+			job.ResponseExtract = extract
+
+			assert.False(t, job.Init())
+		})
+	}
+}
+
+func TestHTTPCheck_Collect_AcceptedStatuses(t *testing.T) {
+	tests := map[string]struct {
+		accepted []string
+		code     int
+		wantOK   bool
+	}{
+		"code":             {accepted: []string{"200"}, code: 200, wantOK: true},
+		"code mismatch":    {accepted: []string{"200"}, code: 204, wantOK: false},
+		"class":            {accepted: []string{"2xx"}, code: 204, wantOK: true},
+		"class mismatch":   {accepted: []string{"2xx"}, code: 301, wantOK: false},
+		"range":            {accepted: []string{"200-204"}, code: 204, wantOK: true},
+		"range mismatch":   {accepted: []string{"200-204"}, code: 206, wantOK: false},
+		"several formats":  {accepted: []string{"2xx", "301-302", "404"}, code: 404, wantOK: true},
+		"several mismatch": {accepted: []string{"2xx", "301-302", "404"}, code: 303, wantOK: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := New()
+			job.URL = testURL
+			job.AcceptedStatuses = test.accepted
+			require.True(t, job.Init())
+
+			job.client = newClientFunc(&http.Response{StatusCode: test.code, Body: nopCloser{bytes.NewBufferString("")}}, nil)
+
+			mx := job.Collect()
+			if test.wantOK {
+				assert.Equal(t, int64(1), mx["success"])
+			} else {
+				assert.Equal(t, int64(1), mx["bad_status"])
+			}
+		})
+	}
+}
+
+func TestHTTPCheck_Init_AcceptedStatusesNG(t *testing.T) {
+	for _, v := range []string{"", "2xxx", "6xx", "204-200", "abc", "200-", "99"} {
+		job := New()
+		job.URL = testURL
+		job.AcceptedStatuses = []string{v}
+
+		assert.Falsef(t, job.Init(), "status '%s'", v)
+	}
+}
+
+func TestConfig_AcceptedStatusesYAML(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte("status_accepted: [200, 2xx, 300-302]"), &cfg))
+
+	assert.Equal(t, []string{"200", "2xx", "300-302"}, cfg.AcceptedStatuses)
+}
+
+func TestHTTPCheck_Collect_HeadersMatch(t *testing.T) {
+	header := http.Header{
+		"Content-Type": {"application/json; charset=utf-8"},
+		"X-Cache":      {"MISS", "HIT from cdn"},
+		"Location":     {"https://example.com/"},
+	}
+
+	tests := map[string]struct {
+		match  []HeaderMatch
+		wantOK bool
+	}{
+		"exists":           {match: []HeaderMatch{{Key: "location"}}, wantOK: true},
+		"not exists":       {match: []HeaderMatch{{Key: "X-Debug"}}, wantOK: false},
+		"exclude exists":   {match: []HeaderMatch{{Key: "X-Debug", Exclude: true}}, wantOK: true},
+		"exact":            {match: []HeaderMatch{{Key: "Location", Value: "= https://example.com/"}}, wantOK: true},
+		"exact mismatch":   {match: []HeaderMatch{{Key: "Location", Value: "= https://example.org/"}}, wantOK: false},
+		"regex any value":  {match: []HeaderMatch{{Key: "X-Cache", Value: "~ ^HIT"}}, wantOK: true},
+		"glob":             {match: []HeaderMatch{{Key: "Content-Type", Value: "* application/json*"}}, wantOK: true},
+		"exclude matched":  {match: []HeaderMatch{{Key: "Content-Type", Value: "* text/html*", Exclude: true}}, wantOK: true},
+		"exclude mismatch": {match: []HeaderMatch{{Key: "Content-Type", Value: "* application/*", Exclude: true}}, wantOK: false},
+		"all must match": {
+			match:  []HeaderMatch{{Key: "Location"}, {Key: "X-Cache", Value: "= HIT"}},
+			wantOK: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := New()
+			job.URL = testURL
+			job.HeadersMatch = test.match
+			require.True(t, job.Init())
+			require.NotNil(t, job.Charts().Get(responseStatusChart.ID).GetDim("bad_header"))
+
+			resp := &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       nopCloser{bytes.NewBufferString("")},
+			}
+			job.client = newClientFunc(resp, nil)
+
+			assert.Equal(t, stm.ToMap(metrics{Status: status{Success: test.wantOK, BadHeader: !test.wantOK}}), job.Collect())
+		})
+	}
+}
+
+func TestHTTPCheck_Init_HeadersMatchNG(t *testing.T) {
+	tests := map[string]HeaderMatch{
+		"no key":        {Value: "= value"},
+		"invalid value": {Key: "X-Cache", Value: "value"},
+		"invalid regex": {Key: "X-Cache", Value: "~ (HIT"},
+	}
+
+	for name, hm := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := New()
+			job.URL = testURL
+			job.HeadersMatch = []HeaderMatch{hm}
+
+			assert.False(t, job.Init())
+		})
+	}
+}
+
+func TestHTTPCheck_Collect_CertExpiry(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// the httptest server certificate is self-signed
+	job := New()
+	job.URL = srv.URL
+	job.InsecureSkipVerify = true
+	require.True(t, job.Init())
+	require.True(t, job.Charts().Has(certExpiryChart.ID))
+
+	mx := job.Collect()
+
+	require.Contains(t, mx, "cert_expiry_days")
+	want := time.Until(srv.Certificate().NotAfter).Hours() / 24 * certExpiryPrecision
+	assert.InDelta(t, want, float64(mx["cert_expiry_days"]), certExpiryPrecision)
+	assert.Equal(t, int64(1), mx["success"])
+}
+
+func TestHTTPCheck_Collect_CertExpiryRedirect(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer target.Close()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusFound)
+	}))
+	defer srv.Close()
+
+	job := New()
+	job.URL = srv.URL
+	job.InsecureSkipVerify = true
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+
+	require.Contains(t, mx, "cert_expiry_days")
+	want := time.Until(srv.Certificate().NotAfter).Hours() / 24 * certExpiryPrecision
+	assert.InDelta(t, want, float64(mx["cert_expiry_days"]), certExpiryPrecision)
+}
+
+func TestHTTPCheck_Collect_CertExpiryPlainHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	job := New()
+	job.URL = srv.URL
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+
+	require.NotNil(t, mx)
+	assert.NotContains(t, mx, "cert_expiry_days")
+	assert.False(t, job.Charts().Has(certExpiryChart.ID))
+}
+
+func TestHTTPCheck_Collect_Redirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/older", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/older", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/cdn", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/error.html", http.StatusFound)
+	})
+	mux.HandleFunc("/error.html", func(w http.ResponseWriter, r *http.Request) {})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	tests := map[string]struct {
+		path          string
+		prepare       func(job *HTTPCheck)
+		wantRedirects int64
+		wantStatus    string
+	}{
+		"redirects followed": {
+			path:          "/old",
+			wantRedirects: 2,
+			wantStatus:    "success",
+		},
+		"redirect loop": {
+			path:          "/loop",
+			prepare:       func(job *HTTPCheck) { job.MaxRedirects = 3 },
+			wantRedirects: 3,
+			wantStatus:    "bad_redirect",
+		},
+		"max redirects exceeded": {
+			path:          "/old",
+			prepare:       func(job *HTTPCheck) { job.MaxRedirects = 1 },
+			wantRedirects: 1,
+			wantStatus:    "bad_redirect",
+		},
+		"final URL match": {
+			path:          "/old",
+			prepare:       func(job *HTTPCheck) { job.FinalURLMatch = "/new$" },
+			wantRedirects: 2,
+			wantStatus:    "success",
+		},
+		"final URL mismatch": {
+			path:          "/cdn",
+			prepare:       func(job *HTTPCheck) { job.FinalURLMatch = "/cdn$" },
+			wantRedirects: 1,
+			wantStatus:    "bad_redirect",
+		},
+		"not follow redirects": {
+			path:          "/old",
+			prepare:       func(job *HTTPCheck) { job.NotFollowRedirect = true },
+			wantRedirects: 0,
+			wantStatus:    "bad_status",
+		},
+		"not follow redirects and 3xx accepted": {
+			path: "/old",
+			prepare: func(job *HTTPCheck) {
+				job.NotFollowRedirect = true
+				job.AcceptedStatuses = []string{"301"}
+			},
+			wantRedirects: 0,
+			wantStatus:    "success",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := New()
+			job.URL = srv.URL + test.path
+			if test.prepare != nil {
+				test.prepare(job)
+			}
+			require.True(t, job.Init())
+			assert.Equal(t, !job.NotFollowRedirect, job.Charts().Has(redirectsChart.ID))
+
+			mx := job.Collect()
+
+			require.NotNil(t, mx)
+			assert.Equal(t, test.wantRedirects, mx["redirects"])
+			assert.Equalf(t, int64(1), mx[test.wantStatus], "status '%s'", test.wantStatus)
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
 type clientFunc func(r *http.Request) (*http.Response, error)
 
 func (f clientFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
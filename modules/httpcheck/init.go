@@ -4,17 +4,35 @@ package httpcheck
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/textproto"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
+type (
+	// statusRange is an inclusive range of the accepted HTTP status codes.
+	statusRange struct{ from, to int }
+	headerMatch struct {
+		exclude    bool
+		key        string
+		valMatcher matcher.Matcher
+	}
+)
+
 func (hc *HTTPCheck) validateConfig() error {
 	if hc.URL == "" {
 		return errors.New("'url' not set")
 	}
+	if hc.MaxRedirects < 0 {
+		return fmt.Errorf("invalid 'max_redirects': %d", hc.MaxRedirects)
+	}
 	return nil
 }
 
@@ -29,9 +47,97 @@ func (hc *HTTPCheck) initResponseMatchRegexp() (*regexp.Regexp, error) {
 	return regexp.Compile(hc.ResponseMatch)
 }
 
+func (hc *HTTPCheck) initFinalURLMatchRegexp() (*regexp.Regexp, error) {
+	if hc.FinalURLMatch == "" {
+		return nil, nil
+	}
+	return regexp.Compile(hc.FinalURLMatch)
+}
+
+// initAcceptedStatuses parses the accepted statuses, the supported formats are '200', '2xx' and '200-204'.
+func (hc *HTTPCheck) initAcceptedStatuses() ([]statusRange, error) {
+	var ranges []statusRange
+	for _, v := range hc.AcceptedStatuses {
+		v = strings.TrimSpace(v)
+		var r statusRange
+		var err error
+
+		switch {
+		case len(v) == 3 && strings.HasSuffix(strings.ToLower(v), "xx"):
+			var class int
+			if class, err = strconv.Atoi(v[:1]); err == nil {
+				r = statusRange{from: class * 100, to: class*100 + 99}
+			}
+		case strings.Contains(v, "-"):
+			from, to, _ := strings.Cut(v, "-")
+			if r.from, err = strconv.Atoi(strings.TrimSpace(from)); err == nil {
+				r.to, err = strconv.Atoi(strings.TrimSpace(to))
+			}
+		default:
+			if r.from, err = strconv.Atoi(v); err == nil {
+				r.to = r.from
+			}
+		}
+
+		if err != nil || r.from < 100 || r.to > 599 || r.from > r.to {
+			return nil, fmt.Errorf("invalid accepted status '%s'", v)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+func (hc *HTTPCheck) initHeadersMatch() ([]headerMatch, error) {
+	var hms []headerMatch
+	for _, v := range hc.HeadersMatch {
+		if v.Key == "" {
+			return nil, errors.New("header match: 'key' not set")
+		}
+		hm := headerMatch{
+			exclude: v.Exclude,
+			key:     textproto.CanonicalMIMEHeaderKey(v.Key),
+		}
+		if v.Value != "" {
+			m, err := matcher.Parse(v.Value)
+			if err != nil {
+				return nil, fmt.Errorf("header match '%s': %v", v.Key, err)
+			}
+			hm.valMatcher = m
+		}
+		hms = append(hms, hm)
+	}
+	return hms, nil
+}
+
 func (hc *HTTPCheck) initCharts() *module.Charts {
 	charts := httpCheckCharts.Copy()
 
+	if !hc.NotFollowRedirect {
+		if chart := charts.Get(responseStatusChart.ID); chart != nil {
+			_ = chart.AddDim(&module.Dim{ID: "bad_redirect"})
+		}
+		_ = charts.Add(redirectsChart.Copy())
+	}
+
+	if len(hc.headersMatch) > 0 {
+		if chart := charts.Get(responseStatusChart.ID); chart != nil {
+			_ = chart.AddDim(&module.Dim{ID: "bad_header"})
+		}
+	}
+
+	if hc.extractor != nil {
+		if chart := charts.Get(responseStatusChart.ID); chart != nil {
+			_ = chart.AddDim(&module.Dim{ID: "bad_extract"})
+		}
+		chart := responseExtractedValueChart.Copy()
+		chart.Dims[0].Name = hc.extractor.name
+		_ = charts.Add(chart)
+	}
+
+	if strings.HasPrefix(strings.ToLower(hc.URL), "https://") {
+		_ = charts.Add(certExpiryChart.Copy())
+	}
+
 	for _, chart := range *charts {
 		chart.Labels = []module.Label{
 			{Key: "url", Value: hc.URL},
@@ -2,11 +2,19 @@
 
 package httpcheck
 
+const (
+	extractPrecision    = 1000
+	certExpiryPrecision = 1000
+)
+
 type metrics struct {
-	Status         status `stm:""`
-	InState        int    `stm:"in_state"`
-	ResponseTime   int    `stm:"time"`
-	ResponseLength int    `stm:"length"`
+	Status         status   `stm:""`
+	InState        int      `stm:"in_state"`
+	ResponseTime   int      `stm:"time"`
+	ResponseLength int      `stm:"length"`
+	Redirects      int      `stm:"redirects"`
+	ExtractedValue *float64 `stm:"extracted_value,1000,1"`
+	CertExpiryDays *float64 `stm:"cert_expiry_days,1000,1"`
 }
 
 type status struct {
@@ -18,5 +26,8 @@ type status struct {
 	//BodyReadError     bool `stm:"body_read_error"`
 	BadContent    bool `stm:"bad_content"`
 	BadStatusCode bool `stm:"bad_status"`
+	BadRedirect   bool `stm:"bad_redirect"`  // More than 'max_redirects' redirects or the final URL didn't match 'final_url_match'
+	BadHeader     bool `stm:"bad_header"`    // Response headers didn't match (only if 'headers_match' is set)
+	BadExtract    bool `stm:"bad_extract"`   // Failed to extract the value from the body (only if 'response_extract' is set)
 	NoConnection  bool `stm:"no_connection"` // All other errors basically
 }
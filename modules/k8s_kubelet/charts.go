@@ -2,7 +2,11 @@
 
 package k8s_kubelet
 
-import "github.com/netdata/go.d.plugin/agent/module"
+import (
+	"fmt"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
 
 type (
 	// Charts is an alias for module.Charts
@@ -192,6 +196,36 @@ var charts = Charts{
 			{ID: "kubelet_pleg_relist_latency_099", Name: "0.99"},
 		},
 	},
+	{
+		ID:    "kubelet_pods_started",
+		Title: "Started Pods",
+		Units: "pods/s",
+		Fam:   "lifecycle",
+		Ctx:   "k8s_kubelet.kubelet_pods_started",
+		Dims: Dims{
+			{ID: "kubelet_started_pods", Name: "started", Algo: module.Incremental},
+			{ID: "kubelet_started_pods_errors", Name: "failed", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "kubelet_containers_started",
+		Title: "Started Containers",
+		Units: "containers/s",
+		Fam:   "lifecycle",
+		Ctx:   "k8s_kubelet.kubelet_containers_started",
+		Dims: Dims{
+			{ID: "kubelet_started_containers", Name: "started", Algo: module.Incremental},
+			{ID: "kubelet_started_containers_errors", Name: "failed", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "kubelet_evictions",
+		Title: "Pod Evictions By Signal",
+		Units: "evictions/s",
+		Fam:   "lifecycle",
+		Ctx:   "k8s_kubelet.kubelet_evictions",
+		Type:  module.Stacked,
+	},
 	{
 		ID:    "kubelet_token_requests",
 		Title: "Token() Requests To The Alternate Token Source",
@@ -234,3 +268,75 @@ func newVolumeManagerChart(name string) *Chart {
 		},
 	}
 }
+
+var pvcChartsTmpl = Charts{
+	{
+		ID:    "kubelet_pvc_%s_space_usage",
+		Title: "PVC Space Usage",
+		Units: "B",
+		Fam:   "volume stats",
+		Ctx:   "k8s_kubelet.kubelet_pvc_space_usage",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "kubelet_volume_stats_%s_available_bytes", Name: "available"},
+			{ID: "kubelet_volume_stats_%s_used_bytes", Name: "used"},
+		},
+	},
+	{
+		ID:    "kubelet_pvc_%s_space_utilization",
+		Title: "PVC Space Utilization",
+		Units: "percentage",
+		Fam:   "volume stats",
+		Ctx:   "k8s_kubelet.kubelet_pvc_space_utilization",
+		Dims: Dims{
+			{ID: "kubelet_volume_stats_%s_used_perc", Name: "used", Div: 1000},
+		},
+	},
+	{
+		ID:    "kubelet_pvc_%s_inodes_usage",
+		Title: "PVC Inodes Usage",
+		Units: "inodes",
+		Fam:   "volume stats",
+		Ctx:   "k8s_kubelet.kubelet_pvc_inodes_usage",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "kubelet_volume_stats_%s_inodes_free", Name: "free"},
+			{ID: "kubelet_volume_stats_%s_inodes_used", Name: "used"},
+		},
+	},
+	{
+		ID:    "kubelet_pvc_%s_inodes_utilization",
+		Title: "PVC Inodes Utilization",
+		Units: "percentage",
+		Fam:   "volume stats",
+		Ctx:   "k8s_kubelet.kubelet_pvc_inodes_utilization",
+		Dims: Dims{
+			{ID: "kubelet_volume_stats_%s_inodes_used_perc", Name: "used", Div: 1000},
+		},
+	},
+}
+
+func newPVCCharts(namespace, pvc string) *Charts {
+	key := namespace + "_" + pvc
+	cs := pvcChartsTmpl.Copy()
+	for _, chart := range *cs {
+		chart.ID = fmt.Sprintf(chart.ID, key)
+		chart.Labels = []module.Label{
+			{Key: "namespace", Value: namespace},
+			{Key: "persistentvolumeclaim", Value: pvc},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, key)
+		}
+	}
+	return cs
+}
+
+func (k *Kubelet) removePVCCharts(key string) {
+	for _, tmpl := range pvcChartsTmpl {
+		if chart := k.charts.Get(fmt.Sprintf(tmpl.ID, key)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 func (k *Kubelet) collect() (map[string]int64, error) {
-	raw, err := k.prom.ScrapeSeries()
+	raw, err := k.scrapeSeries()
 
 	if err != nil {
 		return nil, err
@@ -26,6 +26,7 @@ func (k *Kubelet) collect() (map[string]int64, error) {
 	k.collectAPIServer(raw, mx)
 	k.collectKubelet(raw, mx)
 	k.collectVolumeManager(raw, mx)
+	k.collectVolumeStats(raw, mx)
 
 	return stm.ToMap(mx), nil
 }
@@ -91,6 +92,76 @@ func (k *Kubelet) collectVolumeManager(raw prometheus.Series, mx *metrics) {
 	mx.VolumeManager.Plugins = vmPlugins
 }
 
+func (k *Kubelet) collectVolumeStats(raw prometheus.Series, mx *metrics) {
+	/*
+		# HELP kubelet_volume_stats_capacity_bytes [ALPHA] Capacity in bytes of the volume
+		# TYPE kubelet_volume_stats_capacity_bytes gauge
+		kubelet_volume_stats_capacity_bytes{namespace="default",persistentvolumeclaim="data-postgres-0"} 1.0434699264e+10
+	*/
+	ms := raw.FindByNames(
+		"kubelet_volume_stats_capacity_bytes",
+		"kubelet_volume_stats_available_bytes",
+		"kubelet_volume_stats_used_bytes",
+		"kubelet_volume_stats_inodes",
+		"kubelet_volume_stats_inodes_free",
+		"kubelet_volume_stats_inodes_used",
+	)
+	for _, metric := range ms {
+		namespace := metric.Labels.Get("namespace")
+		pvc := metric.Labels.Get("persistentvolumeclaim")
+
+		if namespace == "" || pvc == "" {
+			continue
+		}
+		if k.volumeStatsNamespaces != nil && !k.volumeStatsNamespaces.MatchString(namespace) {
+			continue
+		}
+
+		key := namespace + "_" + pvc
+		stats, ok := mx.Kubelet.VolumeStats[key]
+		if !ok {
+			stats = &volumeStats{}
+			mx.Kubelet.VolumeStats[key] = stats
+		}
+
+		switch metric.Name() {
+		case "kubelet_volume_stats_capacity_bytes":
+			stats.CapacityBytes.Set(metric.Value)
+		case "kubelet_volume_stats_available_bytes":
+			stats.AvailableBytes.Set(metric.Value)
+		case "kubelet_volume_stats_used_bytes":
+			stats.UsedBytes.Set(metric.Value)
+		case "kubelet_volume_stats_inodes":
+			stats.Inodes.Set(metric.Value)
+		case "kubelet_volume_stats_inodes_free":
+			stats.InodesFree.Set(metric.Value)
+		case "kubelet_volume_stats_inodes_used":
+			stats.InodesUsed.Set(metric.Value)
+		}
+
+		if !k.collectedPVCs[key] {
+			k.collectedPVCs[key] = true
+			_ = k.charts.Add(*newPVCCharts(namespace, pvc)...)
+		}
+	}
+
+	for _, stats := range mx.Kubelet.VolumeStats {
+		if stats.CapacityBytes > 0 {
+			stats.UsedPerc.Set(stats.UsedBytes.Value() * 100 / stats.CapacityBytes.Value())
+		}
+		if stats.Inodes > 0 {
+			stats.InodesUsedPerc.Set(stats.InodesUsed.Value() * 100 / stats.Inodes.Value())
+		}
+	}
+
+	for key := range k.collectedPVCs {
+		if _, ok := mx.Kubelet.VolumeStats[key]; !ok {
+			delete(k.collectedPVCs, key)
+			k.removePVCCharts(key)
+		}
+	}
+}
+
 func (k *Kubelet) collectKubelet(raw prometheus.Series, mx *metrics) {
 	value := raw.FindByName("kubelet_node_config_error").Max()
 	mx.Kubelet.NodeConfigError.Set(value)
@@ -130,6 +201,45 @@ func (k *Kubelet) collectKubelet(raw prometheus.Series, mx *metrics) {
 	k.collectDockerOperationsErrors(raw, mx)
 	k.collectPLEGRelisting(raw, mx)
 	k.collectLogsUsagePerPod(raw, mx)
+	k.collectStartedPodsContainers(raw, mx)
+	k.collectEvictions(raw, mx)
+}
+
+func (k *Kubelet) collectStartedPodsContainers(raw prometheus.Series, mx *metrics) {
+	/*
+		# HELP kubelet_started_containers_errors_total [ALPHA] Cumulative number of errors when starting containers
+		# TYPE kubelet_started_containers_errors_total counter
+		kubelet_started_containers_errors_total{code="CreateContainerConfigError",container_type="container"} 2
+	*/
+	mx.Kubelet.StartedPods.Set(sumValues(raw.FindByName("kubelet_started_pods_total")))
+	mx.Kubelet.StartedPodsErrors.Set(sumValues(raw.FindByName("kubelet_started_pods_errors_total")))
+	mx.Kubelet.StartedContainers.Set(sumValues(raw.FindByName("kubelet_started_containers_total")))
+	mx.Kubelet.StartedContainersErrors.Set(sumValues(raw.FindByName("kubelet_started_containers_errors_total")))
+}
+
+func sumValues(ms prometheus.Series) (sum float64) {
+	for _, m := range ms {
+		sum += m.Value
+	}
+	return sum
+}
+
+func (k *Kubelet) collectEvictions(raw prometheus.Series, mx *metrics) {
+	chart := k.charts.Get("kubelet_evictions")
+
+	// kubelet_evictions{eviction_signal="memory.available"}
+	for _, metric := range raw.FindByName("kubelet_evictions") {
+		signal := metric.Labels.Get("eviction_signal")
+		if signal == "" {
+			continue
+		}
+		dimID := "kubelet_evictions_" + signal
+		if !chart.HasDim(dimID) {
+			_ = chart.AddDim(&Dim{ID: dimID, Name: signal, Algo: module.Incremental})
+			chart.MarkNotCreated()
+		}
+		mx.Kubelet.Evictions[signal] = mtx.Gauge(metric.Value)
+	}
 }
 
 func (k *Kubelet) collectAPIServer(raw prometheus.Series, mx *metrics) {
@@ -183,6 +293,36 @@ func (k *Kubelet) collectPLEGRelisting(raw prometheus.Series, mx *metrics) {
 			mx.Kubelet.PLEG.Relist.Latency.Quantile099.Set(metric.Value)
 		}
 	}
+
+	k.collectPLEGRelistHistogram(raw, "kubelet_pleg_relist_interval_seconds", &mx.Kubelet.PLEG.Relist.Interval)
+	k.collectPLEGRelistHistogram(raw, "kubelet_pleg_relist_duration_seconds", &mx.Kubelet.PLEG.Relist.Latency)
+}
+
+// collectPLEGRelistHistogram collects the quantiles (in microseconds) of the observations made since
+// the previous data collection. The histograms replaced the '*_microseconds' summaries in newer kubelet versions.
+func (k *Kubelet) collectPLEGRelistHistogram(raw prometheus.Series, name string, quantiles *plegQuantiles) {
+	hists := raw.Histograms(name)
+	if len(hists) == 0 || hists[0].Histogram() == nil {
+		return
+	}
+
+	hist := *hists[0].Histogram()
+	prev, ok := k.plegRelistHists[name]
+	k.plegRelistHists[name] = hist
+	if !ok {
+		return
+	}
+
+	delta := hist.Delta(prev)
+	for q, gauge := range map[float64]*mtx.Gauge{
+		0.5:  &quantiles.Quantile05,
+		0.9:  &quantiles.Quantile09,
+		0.99: &quantiles.Quantile099,
+	} {
+		if v := delta.Quantile(q); !math.IsNaN(v) && !math.IsInf(v, 0) {
+			gauge.Set(v * 1e6)
+		}
+	}
 }
 
 func (k *Kubelet) collectStorageDataKeyGenerationLatencies(raw prometheus.Series, mx *metrics) {
@@ -3,9 +3,10 @@
 package k8s_kubelet
 
 import (
-	"os"
+	"net/http"
 	"time"
 
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/prometheus"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
@@ -37,12 +38,15 @@ func New() *Kubelet {
 			},
 		},
 		TokenPath: "/var/run/secrets/kubernetes.io/serviceaccount/token",
+		TokenTTL:  web.Duration{Duration: time.Minute * 5},
 	}
 
 	return &Kubelet{
 		Config:             config,
 		charts:             charts.Copy(),
 		collectedVMPlugins: make(map[string]bool),
+		collectedPVCs:      make(map[string]bool),
+		plegRelistHists:    make(map[string]prometheus.Histogram),
 	}
 }
 
@@ -50,16 +54,30 @@ type (
 	Config struct {
 		web.HTTP  `yaml:",inline"`
 		TokenPath string `yaml:"token_path"`
+		// TokenTTL is the time after which the service account token is re-read (the bound tokens expire), 0 disables it.
+		// The token is re-read on 401 Unauthorized regardless of the TTL.
+		TokenTTL web.Duration `yaml:"token_ttl"`
+		// VolumeStatsNamespaces is the namespaces filter of the persistent volume claims volume stats.
+		VolumeStatsNamespaces matcher.SimpleExpr `yaml:"volume_stats_namespaces"`
 	}
 
 	Kubelet struct {
 		module.Base
 		Config `yaml:",inline"`
 
-		prom   prometheus.Prometheus
-		charts *Charts
+		prom       prometheus.Prometheus
+		httpClient *http.Client
+		charts     *Charts
+		// token is the service account token, tokenRead is the time it was read
+		token     string
+		tokenRead time.Time
 		// volume_manager_total_volumes
 		collectedVMPlugins map[string]bool
+		// kubelet_volume_stats_*
+		volumeStatsNamespaces matcher.Matcher
+		collectedPVCs         map[string]bool
+		// kubelet_pleg_relist_{duration,interval}_seconds previous data collection values
+		plegRelistHists map[string]prometheus.Histogram
 	}
 )
 
@@ -68,11 +86,17 @@ func (Kubelet) Cleanup() {}
 
 // Init makes initialization.
 func (k *Kubelet) Init() bool {
-	b, err := os.ReadFile(k.TokenPath)
-	if err != nil {
+	if err := k.readToken(); err != nil {
 		k.Warningf("error on reading service account token from '%s': %v", k.TokenPath, err)
-	} else {
-		k.Request.Headers["Authorization"] = "Bearer " + string(b)
+	}
+
+	if !k.VolumeStatsNamespaces.Empty() {
+		m, err := k.VolumeStatsNamespaces.Parse()
+		if err != nil {
+			k.Errorf("error on creating volume stats namespaces matcher: %v", err)
+			return false
+		}
+		k.volumeStatsNamespaces = matcher.WithCache(m)
 	}
 
 	client, err := web.NewHTTPClient(k.Client)
@@ -81,6 +105,7 @@ func (k *Kubelet) Init() bool {
 		return false
 	}
 
+	k.httpClient = client
 	k.prom = prometheus.New(client, k.Request)
 	return true
 }
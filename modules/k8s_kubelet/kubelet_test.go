@@ -3,10 +3,16 @@
 package k8s_kubelet
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +54,78 @@ func TestKubelet_Init_ReadServiceAccountToken(t *testing.T) {
 	assert.Equal(t, "Bearer "+string(testTokenData), job.Request.Headers["Authorization"])
 }
 
+func TestKubelet_Collect_ServiceAccountTokenRotation(t *testing.T) {
+	tests := map[string]struct {
+		ttl          time.Duration
+		wantRequests int
+	}{
+		"re-read on 401": {
+			ttl:          0,
+			wantRequests: 2,
+		},
+		"re-read on TTL": {
+			ttl:          time.Nanosecond,
+			wantRequests: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tokenPath := filepath.Join(t.TempDir(), "token")
+			require.NoError(t, os.WriteFile(tokenPath, []byte("token1"), 0600))
+
+			validToken := "token1"
+			var requests int
+			ts := httptest.NewServer(
+				http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						requests++
+						if r.Header.Get("Authorization") != "Bearer "+validToken {
+							w.WriteHeader(http.StatusUnauthorized)
+							return
+						}
+						_, _ = w.Write(testMetricsData)
+					}))
+			defer ts.Close()
+
+			job := New()
+			job.URL = ts.URL + "/metrics"
+			job.TokenPath = tokenPath
+			job.TokenTTL.Duration = test.ttl
+			require.True(t, job.Init())
+			require.NotNil(t, job.Collect())
+
+			// the token is rotated, the old one is not valid anymore
+			validToken = "token2"
+			require.NoError(t, os.WriteFile(tokenPath, []byte("token2"), 0600))
+
+			requests = 0
+			assert.NotNil(t, job.Collect())
+			assert.Equal(t, test.wantRequests, requests)
+			assert.Equal(t, "Bearer token2", job.Request.Headers["Authorization"])
+		})
+	}
+}
+
+func TestKubelet_Collect_ServiceAccountTokenNotRotated(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusUnauthorized)
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.URL = ts.URL + "/metrics"
+	job.TokenPath = "testdata/token.txt"
+	require.True(t, job.Init())
+
+	assert.Nil(t, job.Collect())
+	assert.Equal(t, 1, requests, "no retry if the token file has the same token")
+}
+
 func TestKubelet_InitErrorOnCreatingClientWrongTLSCA(t *testing.T) {
 	job := New()
 	job.Client.TLSConfig.TLSCA = "testdata/tls"
@@ -109,6 +187,8 @@ func TestKubelet_Collect(t *testing.T) {
 		"apiserver_storage_data_key_generation_failures_total":                       0,
 		"apiserver_storage_envelope_transformation_cache_misses_total":               0,
 		"kubelet_docker_operations_create_container":                                 19,
+		"kubelet_evictions_memory.available":                                         3,
+		"kubelet_evictions_nodefs.available":                                         1,
 		"kubelet_docker_operations_errors_inspect_container":                         14,
 		"kubelet_docker_operations_errors_remove_container":                          4,
 		"kubelet_docker_operations_info":                                             2,
@@ -136,6 +216,10 @@ func TestKubelet_Collect(t *testing.T) {
 		"kubelet_pleg_relist_latency_05":                                             12741,
 		"kubelet_pleg_relist_latency_09":                                             16211,
 		"kubelet_pleg_relist_latency_099":                                            31234,
+		"kubelet_started_containers":                                                 34,
+		"kubelet_started_containers_errors":                                          3,
+		"kubelet_started_pods":                                                       10,
+		"kubelet_started_pods_errors":                                                1,
 		"kubelet_running_container":                                                  9,
 		"kubelet_running_pod":                                                        9,
 		"kubelet_runtime_operations_container_status":                                90,
@@ -170,11 +254,115 @@ func TestKubelet_Collect(t *testing.T) {
 		"volume_manager_plugin_kubernetes.io/host-path_state_desired":                15,
 		"volume_manager_plugin_kubernetes.io/secret_state_actual":                    4,
 		"volume_manager_plugin_kubernetes.io/secret_state_desired":                   4,
+		"kubelet_volume_stats_default_data-postgres-0_available_bytes":               524288000,
+		"kubelet_volume_stats_default_data-postgres-0_capacity_bytes":                10737418240,
+		"kubelet_volume_stats_default_data-postgres-0_inodes":                        655360,
+		"kubelet_volume_stats_default_data-postgres-0_inodes_free":                   652000,
+		"kubelet_volume_stats_default_data-postgres-0_inodes_used":                   3360,
+		"kubelet_volume_stats_default_data-postgres-0_inodes_used_perc":              512,
+		"kubelet_volume_stats_default_data-postgres-0_used_bytes":                    10213130240,
+		"kubelet_volume_stats_default_data-postgres-0_used_perc":                     95117,
+		"kubelet_volume_stats_monitoring_prometheus-db_available_bytes":              2684354560,
+		"kubelet_volume_stats_monitoring_prometheus-db_capacity_bytes":               5368709120,
+		"kubelet_volume_stats_monitoring_prometheus-db_inodes":                       327680,
+		"kubelet_volume_stats_monitoring_prometheus-db_inodes_free":                  327000,
+		"kubelet_volume_stats_monitoring_prometheus-db_inodes_used":                  680,
+		"kubelet_volume_stats_monitoring_prometheus-db_inodes_used_perc":             207,
+		"kubelet_volume_stats_monitoring_prometheus-db_used_bytes":                   2684354560,
+		"kubelet_volume_stats_monitoring_prometheus-db_used_perc":                    50000,
 	}
 
 	assert.Equal(t, expected, job.Collect())
 }
 
+func TestKubelet_Collect_VolumeStatsNamespaces(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(testMetricsData)
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.URL = ts.URL + "/metrics"
+	job.VolumeStatsNamespaces = matcher.SimpleExpr{Includes: []string{"= monitoring"}}
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+	require.NotNil(t, mx)
+
+	assert.Contains(t, mx, "kubelet_volume_stats_monitoring_prometheus-db_used_perc")
+	assert.NotContains(t, mx, "kubelet_volume_stats_default_data-postgres-0_used_perc")
+	assert.NotNil(t, job.Charts().Get("kubelet_pvc_monitoring_prometheus-db_space_utilization"))
+	assert.Nil(t, job.Charts().Get("kubelet_pvc_default_data-postgres-0_space_utilization"))
+}
+
+func TestKubelet_Collect_RemovesGonePVCCharts(t *testing.T) {
+	data := testMetricsData
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(data)
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.URL = ts.URL + "/metrics"
+	require.True(t, job.Init())
+	require.NotNil(t, job.Collect())
+
+	chart := job.Charts().Get("kubelet_pvc_default_data-postgres-0_space_usage")
+	require.NotNil(t, chart)
+	assert.False(t, chart.Obsolete)
+
+	var lines []string
+	for _, line := range strings.Split(string(testMetricsData), "\n") {
+		if !strings.Contains(line, `persistentvolumeclaim="data-postgres-0"`) {
+			lines = append(lines, line)
+		}
+	}
+	data = []byte(strings.Join(lines, "\n"))
+	require.NotNil(t, job.Collect())
+
+	assert.True(t, chart.Obsolete)
+	assert.False(t, job.Charts().Get("kubelet_pvc_monitoring_prometheus-db_space_usage").Obsolete)
+}
+
+func TestKubelet_Collect_PLEGRelistHistograms(t *testing.T) {
+	var observations int
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				// every collection adds 10 relist observations: 5 <= 5ms, 4 <= 10ms, 1 <= 1s
+				observations += 10
+				n := observations / 10
+				_, _ = fmt.Fprintf(w, `# HELP kubelet_pleg_relist_duration_seconds [ALPHA] Duration in seconds for relisting pods in PLEG.
+# TYPE kubelet_pleg_relist_duration_seconds histogram
+kubelet_pleg_relist_duration_seconds_bucket{le="0.005"} %d
+kubelet_pleg_relist_duration_seconds_bucket{le="0.01"} %d
+kubelet_pleg_relist_duration_seconds_bucket{le="1"} %d
+kubelet_pleg_relist_duration_seconds_bucket{le="+Inf"} %d
+kubelet_pleg_relist_duration_seconds_sum %f
+kubelet_pleg_relist_duration_seconds_count %d
+`, 5*n, 9*n, 10*n, 10*n, 0.1*float64(n), 10*n)
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.URL = ts.URL + "/metrics"
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(0), mx["kubelet_pleg_relist_latency_05"], "no previous data collection")
+
+	mx = job.Collect()
+	require.NotNil(t, mx)
+	assert.Equal(t, int64(5000), mx["kubelet_pleg_relist_latency_05"])
+	assert.Equal(t, int64(10000), mx["kubelet_pleg_relist_latency_09"])
+	assert.Equal(t, int64(901000), mx["kubelet_pleg_relist_latency_099"])
+}
+
 func TestKubelet_Collect_ReceiveInvalidResponse(t *testing.T) {
 	ts := httptest.NewServer(
 		http.HandlerFunc(
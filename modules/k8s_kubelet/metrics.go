@@ -15,6 +15,8 @@ func newMetrics() *metrics {
 	mx.Kubelet.Docker.Operations = make(map[string]mtx.Gauge)
 	mx.Kubelet.Docker.OperationsErrors = make(map[string]mtx.Gauge)
 	mx.Kubelet.PodLogFileSystemUsage = make(map[string]mtx.Gauge)
+	mx.Kubelet.VolumeStats = make(map[string]*volumeStats)
+	mx.Kubelet.Evictions = make(map[string]mtx.Gauge)
 
 	return &mx
 }
@@ -78,19 +80,16 @@ type kubeletMetrics struct {
 	RunningPodCount       mtx.Gauge `stm:"running_pod"`
 	PLEG                  struct {
 		Relist struct {
-			Interval struct {
-				Quantile05  mtx.Gauge `stm:"05"`
-				Quantile09  mtx.Gauge `stm:"09"`
-				Quantile099 mtx.Gauge `stm:"099"`
-			} `stm:"interval"`
-			Latency struct {
-				Quantile05  mtx.Gauge `stm:"05"`
-				Quantile09  mtx.Gauge `stm:"09"`
-				Quantile099 mtx.Gauge `stm:"099"`
-			} `stm:"latency"`
+			Interval plegQuantiles `stm:"interval"`
+			Latency  plegQuantiles `stm:"latency"`
 		} `stm:"relist"`
 	} `stm:"pleg"`
-	Runtime struct {
+	StartedPods             mtx.Gauge            `stm:"started_pods"`
+	StartedPodsErrors       mtx.Gauge            `stm:"started_pods_errors"`
+	StartedContainers       mtx.Gauge            `stm:"started_containers"`
+	StartedContainersErrors mtx.Gauge            `stm:"started_containers_errors"`
+	Evictions               map[string]mtx.Gauge `stm:"evictions"`
+	Runtime                 struct {
 		Operations       map[string]mtx.Gauge `stm:"operations"`
 		OperationsErrors map[string]mtx.Gauge `stm:"operations_errors"`
 	} `stm:"runtime"`
@@ -98,7 +97,25 @@ type kubeletMetrics struct {
 		Operations       map[string]mtx.Gauge `stm:"operations"`
 		OperationsErrors map[string]mtx.Gauge `stm:"operations_errors"`
 	} `stm:"docker"`
-	PodLogFileSystemUsage map[string]mtx.Gauge `stm:"log_file_system_usage"`
+	PodLogFileSystemUsage map[string]mtx.Gauge    `stm:"log_file_system_usage"`
+	VolumeStats           map[string]*volumeStats `stm:"volume_stats"`
+}
+
+type plegQuantiles struct {
+	Quantile05  mtx.Gauge `stm:"05"`
+	Quantile09  mtx.Gauge `stm:"09"`
+	Quantile099 mtx.Gauge `stm:"099"`
+}
+
+type volumeStats struct {
+	CapacityBytes  mtx.Gauge `stm:"capacity_bytes"`
+	AvailableBytes mtx.Gauge `stm:"available_bytes"`
+	UsedBytes      mtx.Gauge `stm:"used_bytes"`
+	UsedPerc       mtx.Gauge `stm:"used_perc,1000,1"`
+	Inodes         mtx.Gauge `stm:"inodes"`
+	InodesFree     mtx.Gauge `stm:"inodes_free"`
+	InodesUsed     mtx.Gauge `stm:"inodes_used"`
+	InodesUsedPerc mtx.Gauge `stm:"inodes_used_perc,1000,1"`
 }
 
 type volumeManagerMetrics struct {
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_kubelet
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/prometheus"
+)
+
+func (k *Kubelet) readToken() error {
+	b, err := os.ReadFile(k.TokenPath)
+	if err != nil {
+		return err
+	}
+
+	k.token, k.tokenRead = string(b), time.Now()
+	k.Request.Headers["Authorization"] = "Bearer " + k.token
+	return nil
+}
+
+// refreshToken re-reads the service account token, it returns true if the token has changed.
+// The token is refreshed only if it was read on initialization (the module runs in a pod).
+func (k *Kubelet) refreshToken() bool {
+	if k.token == "" {
+		return false
+	}
+
+	prev := k.token
+	if err := k.readToken(); err != nil {
+		k.Warningf("error on re-reading service account token from '%s': %v", k.TokenPath, err)
+		return false
+	}
+	if k.token == prev {
+		return false
+	}
+
+	k.Debugf("service account token from '%s' has changed", k.TokenPath)
+	k.prom = prometheus.New(k.httpClient, k.Request)
+	return true
+}
+
+func (k *Kubelet) isTokenExpired() bool {
+	return k.token != "" && k.TokenTTL.Duration > 0 && time.Since(k.tokenRead) >= k.TokenTTL.Duration
+}
+
+// scrapeSeries scrapes the metrics, the request is retried with the re-read token if the token has expired
+// (401 Unauthorized) and the token file has a new one.
+func (k *Kubelet) scrapeSeries() (prometheus.Series, error) {
+	if k.isTokenExpired() {
+		k.refreshToken()
+	}
+
+	raw, err := k.prom.ScrapeSeries()
+
+	var se *prometheus.StatusCodeError
+	if errors.As(err, &se) && se.StatusCode == http.StatusUnauthorized && k.refreshToken() {
+		return k.prom.ScrapeSeries()
+	}
+	return raw, err
+}
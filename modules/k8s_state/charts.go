@@ -26,6 +26,7 @@ const (
 	prioNodeAllocatablePodsUsage
 	prioNodeConditions
 	prioNodeSchedulability
+	prioNodeTaints
 	prioNodePodsReadiness
 	prioNodePodsReadinessState
 	prioNodePodsCondition
@@ -54,22 +55,43 @@ const (
 	prioPodContainerTerminatedStateReason
 )
 
+const (
+	prioDeploymentReplicas = 50500 + iota
+	prioDeploymentReplicasReadiness
+	prioStatefulSetReplicas
+	prioStatefulSetReplicasReadiness
+)
+
+const (
+	prioPVCsPhase = 50600 + iota
+	prioPVCsPendingOverThreshold
+	prioPVCsNamespacePhase
+	prioPVCsNamespacePendingOverThreshold
+	prioPVCPhase
+	prioPVCRequestedStorage
+	prioPVCPendingDuration
+)
+
 const (
 	labelKeyPrefix = "k8s_"
 	//labelKeyLabelPrefix      = labelKeyPrefix + "label_"
 	//labelKeyAnnotationPrefix = labelKeyPrefix + "annotation_"
-	labelKeyClusterID      = labelKeyPrefix + "cluster_id"
-	labelKeyClusterName    = labelKeyPrefix + "cluster_name"
-	labelKeyNamespace      = labelKeyPrefix + "namespace"
-	labelKeyKind           = labelKeyPrefix + "kind"
-	labelKeyPodName        = labelKeyPrefix + "pod_name"
-	labelKeyNodeName       = labelKeyPrefix + "node_name"
-	labelKeyPodUID         = labelKeyPrefix + "pod_uid"
-	labelKeyControllerKind = labelKeyPrefix + "controller_kind"
-	labelKeyControllerName = labelKeyPrefix + "controller_name"
-	labelKeyContainerName  = labelKeyPrefix + "container_name"
-	labelKeyContainerID    = labelKeyPrefix + "container_id"
-	labelKeyQoSClass       = labelKeyPrefix + "qos_class"
+	labelKeyClusterID       = labelKeyPrefix + "cluster_id"
+	labelKeyClusterName     = labelKeyPrefix + "cluster_name"
+	labelKeyNamespace       = labelKeyPrefix + "namespace"
+	labelKeyKind            = labelKeyPrefix + "kind"
+	labelKeyPodName         = labelKeyPrefix + "pod_name"
+	labelKeyNodeName        = labelKeyPrefix + "node_name"
+	labelKeyPodUID          = labelKeyPrefix + "pod_uid"
+	labelKeyControllerKind  = labelKeyPrefix + "controller_kind"
+	labelKeyControllerName  = labelKeyPrefix + "controller_name"
+	labelKeyContainerName   = labelKeyPrefix + "container_name"
+	labelKeyContainerID     = labelKeyPrefix + "container_id"
+	labelKeyQoSClass        = labelKeyPrefix + "qos_class"
+	labelKeyDeploymentName  = labelKeyPrefix + "deployment_name"
+	labelKeyStatefulSetName = labelKeyPrefix + "statefulset_name"
+	labelKeyPVCName         = labelKeyPrefix + "pvc_name"
+	labelKeyStorageClass    = labelKeyPrefix + "storage_class"
 )
 
 var baseCharts = module.Charts{
@@ -89,6 +111,7 @@ var nodeChartsTmpl = module.Charts{
 	nodeAllocatablePodsUsageChartTmpl.Copy(),
 	nodeConditionsChartTmpl.Copy(),
 	nodeSchedulabilityChartTmpl.Copy(),
+	nodeTaintsChartTmpl.Copy(),
 	nodePodsReadinessChartTmpl.Copy(),
 	nodePodsReadinessStateChartTmpl.Copy(),
 	nodePodsConditionChartTmpl.Copy(),
@@ -257,6 +280,19 @@ var (
 			{ID: "node_%s_schedulability_unschedulable", Name: "unschedulable"},
 		},
 	}
+	nodeTaintsChartTmpl = module.Chart{
+		ID:       "node_%s.taints",
+		Title:    "Taints",
+		Units:    "taints",
+		Fam:      "node schedulability",
+		Ctx:      "k8s_state.node_taints",
+		Priority: prioNodeTaints,
+		Dims: module.Dims{
+			{ID: "node_%s_taints_no_schedule", Name: "no_schedule"},
+			{ID: "node_%s_taints_prefer_no_schedule", Name: "prefer_no_schedule"},
+			{ID: "node_%s_taints_no_execute", Name: "no_execute"},
+		},
+	}
 	// pods readiness
 	nodePodsReadinessChartTmpl = module.Chart{
 		ID:       "node_%s.pods_readiness",
@@ -739,6 +775,281 @@ func (ks *KubeState) addContainerTerminatedStateReasonToChart(ps *podState, cs *
 	c.MarkNotCreated()
 }
 
+var workloadChartsTmpl = module.Charts{
+	workloadReplicasChartTmpl.Copy(),
+	workloadReplicasReadinessChartTmpl.Copy(),
+}
+
+var (
+	// the first '%s' is the workload kind
+	workloadReplicasChartTmpl = module.Chart{
+		ID:    "%s_%s.replicas",
+		Title: "Replicas",
+		Units: "replicas",
+		Fam:   "%s replicas",
+		Ctx:   "k8s_state.%s_replicas",
+		Dims: module.Dims{
+			{ID: "%s_%s_replicas_desired", Name: "desired"},
+			{ID: "%s_%s_replicas_ready", Name: "ready"},
+			{ID: "%s_%s_replicas_updated", Name: "updated"},
+			{ID: "%s_%s_replicas_available", Name: "available"},
+		},
+	}
+	workloadReplicasReadinessChartTmpl = module.Chart{
+		ID:    "%s_%s.replicas_readiness",
+		Title: "Replicas readiness",
+		Units: "%",
+		Fam:   "%s replicas",
+		Ctx:   "k8s_state.%s_replicas_readiness",
+		Dims: module.Dims{
+			{ID: "%s_%s_replicas_readiness", Name: "ready", Div: precision},
+		},
+	}
+)
+
+func (ks *KubeState) newWorkloadCharts(ws *workloadState) *module.Charts {
+	charts := workloadChartsTmpl.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, ws.kind, replaceDots(ws.id()))
+		c.Fam = fmt.Sprintf(c.Fam, ws.kind)
+		c.Ctx = fmt.Sprintf(c.Ctx, ws.kind)
+		c.Priority = workloadChartPriority(ws.kind, c.Ctx)
+		c.Labels = ks.newWorkloadChartLabels(ws)
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, ws.kind, ws.id())
+		}
+	}
+	return charts
+}
+
+func workloadChartPriority(kind workloadKind, ctx string) int {
+	readiness := strings.HasSuffix(ctx, "_readiness")
+	switch {
+	case kind == workloadKindDeployment && readiness:
+		return prioDeploymentReplicasReadiness
+	case kind == workloadKindDeployment:
+		return prioDeploymentReplicas
+	case readiness:
+		return prioStatefulSetReplicasReadiness
+	default:
+		return prioStatefulSetReplicas
+	}
+}
+
+func (ks *KubeState) newWorkloadChartLabels(ws *workloadState) []module.Label {
+	nameKey := labelKeyDeploymentName
+	if ws.kind == workloadKindStatefulSet {
+		nameKey = labelKeyStatefulSetName
+	}
+	return []module.Label{
+		{Key: labelKeyNamespace, Value: ws.namespace, Source: module.LabelSourceK8s},
+		{Key: nameKey, Value: ws.name, Source: module.LabelSourceK8s},
+		{Key: labelKeyKind, Value: string(ws.kind), Source: module.LabelSourceK8s},
+		{Key: labelKeyClusterID, Value: ks.kubeClusterID, Source: module.LabelSourceK8s},
+		{Key: labelKeyClusterName, Value: ks.kubeClusterName, Source: module.LabelSourceK8s},
+	}
+}
+
+func (ks *KubeState) addWorkloadCharts(ws *workloadState) {
+	charts := ks.newWorkloadCharts(ws)
+	if err := ks.Charts().Add(*charts...); err != nil {
+		ks.Warning(err)
+	}
+}
+
+func (ks *KubeState) removeWorkloadCharts(ws *workloadState) {
+	prefix := fmt.Sprintf("%s_%s.", ws.kind, replaceDots(ws.id()))
+	for _, c := range *ks.Charts() {
+		if strings.HasPrefix(c.ID, prefix) {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
+
+var pvcsCharts = module.Charts{
+	pvcsPhaseChart.Copy(),
+	pvcsPendingOverThresholdChart.Copy(),
+}
+
+var (
+	pvcsPhaseChart = module.Chart{
+		ID:       "pvcs_phase",
+		Title:    "PersistentVolumeClaims phase",
+		Units:    "pvcs",
+		Fam:      "pvcs phase",
+		Ctx:      "k8s_state.pvcs_phase",
+		Priority: prioPVCsPhase,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "pvcs_phase_bound", Name: "bound"},
+			{ID: "pvcs_phase_pending", Name: "pending"},
+			{ID: "pvcs_phase_lost", Name: "lost"},
+		},
+	}
+	pvcsPendingOverThresholdChart = module.Chart{
+		ID:       "pvcs_pending_over_threshold",
+		Title:    "PersistentVolumeClaims pending longer than the threshold",
+		Units:    "pvcs",
+		Fam:      "pvcs phase",
+		Ctx:      "k8s_state.pvcs_pending_over_threshold",
+		Priority: prioPVCsPendingOverThreshold,
+		Dims: module.Dims{
+			{ID: "pvcs_pending_over_threshold", Name: "pending"},
+		},
+	}
+)
+
+var pvcsNamespaceChartsTmpl = module.Charts{
+	pvcsNamespacePhaseChartTmpl.Copy(),
+	pvcsNamespacePendingOverThresholdChartTmpl.Copy(),
+}
+
+var (
+	pvcsNamespacePhaseChartTmpl = module.Chart{
+		ID:       "pvcs_namespace_%s.phase",
+		Title:    "Namespace PersistentVolumeClaims phase",
+		Units:    "pvcs",
+		Fam:      "pvcs phase",
+		Ctx:      "k8s_state.pvcs_namespace_phase",
+		Priority: prioPVCsNamespacePhase,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "pvcs_namespace_%s_phase_bound", Name: "bound"},
+			{ID: "pvcs_namespace_%s_phase_pending", Name: "pending"},
+			{ID: "pvcs_namespace_%s_phase_lost", Name: "lost"},
+		},
+	}
+	pvcsNamespacePendingOverThresholdChartTmpl = module.Chart{
+		ID:       "pvcs_namespace_%s.pending_over_threshold",
+		Title:    "Namespace PersistentVolumeClaims pending longer than the threshold",
+		Units:    "pvcs",
+		Fam:      "pvcs phase",
+		Ctx:      "k8s_state.pvcs_namespace_pending_over_threshold",
+		Priority: prioPVCsNamespacePendingOverThreshold,
+		Dims: module.Dims{
+			{ID: "pvcs_namespace_%s_pending_over_threshold", Name: "pending"},
+		},
+	}
+)
+
+func (ks *KubeState) newPVCsNamespaceCharts(namespace string) *module.Charts {
+	charts := pvcsNamespaceChartsTmpl.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, replaceDots(namespace))
+		c.Labels = []module.Label{
+			{Key: labelKeyNamespace, Value: namespace, Source: module.LabelSourceK8s},
+			{Key: labelKeyClusterID, Value: ks.kubeClusterID, Source: module.LabelSourceK8s},
+			{Key: labelKeyClusterName, Value: ks.kubeClusterName, Source: module.LabelSourceK8s},
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, namespace)
+		}
+	}
+	return charts
+}
+
+func (ks *KubeState) addPVCsNamespaceCharts(namespace string) {
+	charts := ks.newPVCsNamespaceCharts(namespace)
+	if err := ks.Charts().Add(*charts...); err != nil {
+		ks.Warning(err)
+	}
+}
+
+func (ks *KubeState) removePVCsNamespaceCharts(namespace string) {
+	prefix := fmt.Sprintf("pvcs_namespace_%s.", replaceDots(namespace))
+	for _, c := range *ks.Charts() {
+		if strings.HasPrefix(c.ID, prefix) {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
+
+var pvcChartsTmpl = module.Charts{
+	pvcPhaseChartTmpl.Copy(),
+	pvcRequestedStorageChartTmpl.Copy(),
+	pvcPendingDurationChartTmpl.Copy(),
+}
+
+var (
+	pvcPhaseChartTmpl = module.Chart{
+		ID:       "pvc_%s.phase",
+		Title:    "PersistentVolumeClaim phase",
+		Units:    "state",
+		Fam:      "pvc phase",
+		Ctx:      "k8s_state.pvc_phase",
+		Priority: prioPVCPhase,
+		Dims: module.Dims{
+			{ID: "pvc_%s_phase_bound", Name: "bound"},
+			{ID: "pvc_%s_phase_pending", Name: "pending"},
+			{ID: "pvc_%s_phase_lost", Name: "lost"},
+		},
+	}
+	pvcRequestedStorageChartTmpl = module.Chart{
+		ID:       "pvc_%s.requested_storage",
+		Title:    "PersistentVolumeClaim requested storage",
+		Units:    "bytes",
+		Fam:      "pvc storage",
+		Ctx:      "k8s_state.pvc_requested_storage",
+		Priority: prioPVCRequestedStorage,
+		Dims: module.Dims{
+			{ID: "pvc_%s_requested_storage", Name: "requested"},
+		},
+	}
+	pvcPendingDurationChartTmpl = module.Chart{
+		ID:       "pvc_%s.pending_duration",
+		Title:    "PersistentVolumeClaim pending duration",
+		Units:    "seconds",
+		Fam:      "pvc phase",
+		Ctx:      "k8s_state.pvc_pending_duration",
+		Priority: prioPVCPendingDuration,
+		Dims: module.Dims{
+			{ID: "pvc_%s_pending_duration", Name: "pending"},
+		},
+	}
+)
+
+func (ks *KubeState) newPVCCharts(ps *pvcState) *module.Charts {
+	charts := pvcChartsTmpl.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, replaceDots(ps.id()))
+		c.Labels = ks.newPVCChartLabels(ps)
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, ps.id())
+		}
+	}
+	return charts
+}
+
+func (ks *KubeState) newPVCChartLabels(ps *pvcState) []module.Label {
+	return []module.Label{
+		{Key: labelKeyNamespace, Value: ps.namespace, Source: module.LabelSourceK8s},
+		{Key: labelKeyPVCName, Value: ps.name, Source: module.LabelSourceK8s},
+		{Key: labelKeyStorageClass, Value: ps.storageClassName, Source: module.LabelSourceK8s},
+		{Key: labelKeyKind, Value: "pvc", Source: module.LabelSourceK8s},
+		{Key: labelKeyClusterID, Value: ks.kubeClusterID, Source: module.LabelSourceK8s},
+		{Key: labelKeyClusterName, Value: ks.kubeClusterName, Source: module.LabelSourceK8s},
+	}
+}
+
+func (ks *KubeState) addPVCCharts(ps *pvcState) {
+	charts := ks.newPVCCharts(ps)
+	if err := ks.Charts().Add(*charts...); err != nil {
+		ks.Warning(err)
+	}
+}
+
+func (ks *KubeState) removePVCCharts(ps *pvcState) {
+	prefix := fmt.Sprintf("pvc_%s.", replaceDots(ps.id()))
+	for _, c := range *ks.Charts() {
+		if strings.HasPrefix(c.ID, prefix) {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
+
 var discoveryStatusChart = module.Chart{
 	ID:       "discovery_discoverers_state",
 	Title:    "Running discoverers state",
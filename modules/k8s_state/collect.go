@@ -32,7 +32,12 @@ func (ks *KubeState) collect() (map[string]int64, error) {
 
 		ks.kubeClusterID = ks.getKubeClusterID()
 		ks.kubeClusterName = ks.getKubeClusterName()
-		if chart := ks.Charts().Get(discoveryStatusChart.ID); chart != nil {
+		if ks.CollectPVCs {
+			if err := ks.Charts().Add(*pvcsCharts.Copy()...); err != nil {
+				ks.Warning(err)
+			}
+		}
+		for _, chart := range *ks.Charts() {
 			chart.Labels = []module.Label{
 				{Key: labelKeyClusterID, Value: ks.kubeClusterID, Source: module.LabelSourceK8s},
 				{Key: labelKeyClusterName, Value: ks.kubeClusterName, Source: module.LabelSourceK8s},
@@ -63,6 +68,36 @@ func (ks *KubeState) collectKubeState(mx map[string]int64) {
 	}
 	ks.collectPodsState(mx)
 	ks.collectNodesState(mx)
+	ks.collectWorkloadsState(mx)
+	if ks.CollectPVCs {
+		ks.collectPVCsState(mx)
+	}
+}
+
+func (ks *KubeState) collectWorkloadsState(mx map[string]int64) {
+	for src, ws := range ks.state.workloads {
+		if ws.deleted {
+			delete(ks.state.workloads, src)
+			ks.removeWorkloadCharts(ws)
+			continue
+		}
+		if ws.new {
+			ws.new = false
+			ks.addWorkloadCharts(ws)
+		}
+
+		px := fmt.Sprintf("%s_%s_", ws.kind, ws.id())
+
+		mx[px+"replicas_desired"] = ws.desiredReplicas
+		mx[px+"replicas_ready"] = ws.readyReplicas
+		mx[px+"replicas_updated"] = ws.updatedReplicas
+		mx[px+"replicas_available"] = ws.availableReplicas
+		// scaled to zero is fully ready
+		mx[px+"replicas_readiness"] = 100 * precision
+		if ws.desiredReplicas > 0 {
+			mx[px+"replicas_readiness"] = calcPercentage(ws.readyReplicas, ws.desiredReplicas)
+		}
+	}
 }
 
 func (ks *KubeState) collectPodsState(mx map[string]int64) {
@@ -215,6 +250,9 @@ func (ks *KubeState) collectNodesState(mx map[string]int64) {
 		mx[px+"pods_cond_containersready"] = ns.stats.podsCondContainersReady
 		mx[px+"schedulability_schedulable"] = boolToInt(!ns.unSchedulable)
 		mx[px+"schedulability_unschedulable"] = boolToInt(ns.unSchedulable)
+		mx[px+"taints_no_schedule"] = ns.taintsNoSchedule
+		mx[px+"taints_prefer_no_schedule"] = ns.taintsPreferNoSchedule
+		mx[px+"taints_no_execute"] = ns.taintsNoExecute
 		mx[px+"alloc_pods_available"] = ns.allocatablePods - ns.stats.pods
 		mx[px+"alloc_pods_allocated"] = ns.stats.pods
 		mx[px+"alloc_cpu_requests_util"] = calcPercentage(ns.stats.reqCPU, ns.allocatableCPU)
@@ -256,6 +294,87 @@ func condStatusToInt(cs corev1.ConditionStatus) int64 {
 	}
 }
 
+// pvcsPhaseStats is the PersistentVolumeClaims phase aggregate.
+type pvcsPhaseStats struct {
+	bound                int64
+	pending              int64
+	lost                 int64
+	pendingOverThreshold int64
+}
+
+func (ps pvcsPhaseStats) writeTo(mx map[string]int64, px string) {
+	mx[px+"phase_bound"] = ps.bound
+	mx[px+"phase_pending"] = ps.pending
+	mx[px+"phase_lost"] = ps.lost
+	mx[px+"pending_over_threshold"] = ps.pendingOverThreshold
+}
+
+func (ks *KubeState) collectPVCsState(mx map[string]int64) {
+	now := time.Now()
+	var total pvcsPhaseStats
+	perNamespace := make(map[string]*pvcsPhaseStats)
+
+	for src, ps := range ks.state.pvcs {
+		if ps.deleted {
+			delete(ks.state.pvcs, src)
+			ks.removePVCCharts(ps)
+			continue
+		}
+		if ps.new {
+			ps.new = false
+			ks.addPVCCharts(ps)
+		}
+
+		ns, ok := perNamespace[ps.namespace]
+		if !ok {
+			ns = &pvcsPhaseStats{}
+			perNamespace[ps.namespace] = ns
+		}
+
+		px := fmt.Sprintf("pvc_%s_", ps.id())
+
+		var pendingDuration time.Duration
+		switch ps.phase {
+		case corev1.ClaimBound:
+			total.bound++
+			ns.bound++
+		case corev1.ClaimPending:
+			total.pending++
+			ns.pending++
+			pendingDuration = now.Sub(ps.creationTime)
+			if pendingDuration > ks.PVCPendingThreshold.Duration {
+				total.pendingOverThreshold++
+				ns.pendingOverThreshold++
+			}
+		case corev1.ClaimLost:
+			total.lost++
+			ns.lost++
+		}
+
+		mx[px+"phase_bound"] = boolToInt(ps.phase == corev1.ClaimBound)
+		mx[px+"phase_pending"] = boolToInt(ps.phase == corev1.ClaimPending)
+		mx[px+"phase_lost"] = boolToInt(ps.phase == corev1.ClaimLost)
+		mx[px+"requested_storage"] = ps.reqStorage
+		mx[px+"pending_duration"] = int64(pendingDuration.Seconds())
+	}
+
+	total.writeTo(mx, "pvcs_")
+
+	for name := range ks.pvcNamespaces {
+		if _, ok := perNamespace[name]; !ok {
+			delete(ks.pvcNamespaces, name)
+			ks.removePVCsNamespaceCharts(name)
+		}
+	}
+	for name, ns := range perNamespace {
+		if !ks.pvcNamespaces[name] {
+			ks.pvcNamespaces[name] = true
+			ks.addPVCsNamespaceCharts(name)
+		}
+		ns.writeTo(mx, fmt.Sprintf("pvcs_namespace_%s_", name))
+	}
+}
+
 func calcPercentage(value, total int64) int64 {
 	if total == 0 {
 		return 0
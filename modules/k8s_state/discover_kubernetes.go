@@ -10,6 +10,7 @@ import (
 
 	"github.com/netdata/go.d.plugin/logger"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,18 +20,28 @@ import (
 	"k8s.io/client-go/util/workqueue"
 )
 
-func newKubeDiscovery(client kubernetes.Interface, l *logger.Logger) *kubeDiscovery {
+func newKubeDiscovery(client kubernetes.Interface, cfg discoveryConfig, l *logger.Logger) *kubeDiscovery {
 	return &kubeDiscovery{
 		client:  client,
+		cfg:     cfg,
 		Logger:  l,
 		readyCh: make(chan struct{}),
 		stopCh:  make(chan struct{}),
 	}
 }
 
+// discoveryConfig enables the optional discoverers, they require additional RBAC rules.
+type discoveryConfig struct {
+	// workloads enables the Deployment and StatefulSet discoverers.
+	workloads bool
+	// pvcs enables the PersistentVolumeClaim discoverer.
+	pvcs bool
+}
+
 type kubeDiscovery struct {
 	*logger.Logger
 	client      kubernetes.Interface
+	cfg         discoveryConfig
 	discoverers []discoverer
 	readyCh     chan struct{}
 	stopCh      chan struct{}
@@ -126,10 +137,50 @@ func (d *kubeDiscovery) setupDiscoverers(ctx context.Context) []discoverer {
 		},
 	}
 
-	return []discoverer{
+	discoverers := []discoverer{
 		newNodeDiscoverer(cache.NewSharedInformer(nodeWatcher, &corev1.Node{}, resyncPeriod), d.Logger),
 		newPodDiscoverer(cache.NewSharedInformer(podWatcher, &corev1.Pod{}, resyncPeriod), d.Logger),
 	}
+
+	if d.cfg.workloads {
+		discoverers = append(discoverers, d.setupWorkloadDiscoverers(ctx)...)
+	}
+	if d.cfg.pvcs {
+		discoverers = append(discoverers, d.setupPVCDiscoverer(ctx))
+	}
+
+	return discoverers
+}
+
+// setupWorkloadDiscoverers requires list/watch on 'apps/deployments' and 'apps/statefulsets'.
+func (d *kubeDiscovery) setupWorkloadDiscoverers(ctx context.Context) []discoverer {
+	deploy := d.client.AppsV1().Deployments(corev1.NamespaceAll)
+	deployWatcher := &cache.ListWatch{
+		ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return deploy.List(ctx, options) },
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return deploy.Watch(ctx, options) },
+	}
+
+	sts := d.client.AppsV1().StatefulSets(corev1.NamespaceAll)
+	stsWatcher := &cache.ListWatch{
+		ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return sts.List(ctx, options) },
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return sts.Watch(ctx, options) },
+	}
+
+	return []discoverer{
+		newDeploymentDiscoverer(cache.NewSharedInformer(deployWatcher, &appsv1.Deployment{}, resyncPeriod), d.Logger),
+		newStatefulSetDiscoverer(cache.NewSharedInformer(stsWatcher, &appsv1.StatefulSet{}, resyncPeriod), d.Logger),
+	}
+}
+
+// setupPVCDiscoverer requires list/watch on 'persistentvolumeclaims'.
+func (d *kubeDiscovery) setupPVCDiscoverer(ctx context.Context) discoverer {
+	pvc := d.client.CoreV1().PersistentVolumeClaims(corev1.NamespaceAll)
+	pvcWatcher := &cache.ListWatch{
+		ListFunc:  func(options metav1.ListOptions) (runtime.Object, error) { return pvc.List(ctx, options) },
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) { return pvc.Watch(ctx, options) },
+	}
+
+	return newPVCDiscoverer(cache.NewSharedInformer(pvcWatcher, &corev1.PersistentVolumeClaim{}, resyncPeriod), d.Logger)
 }
 
 func enqueue(queue *workqueue.Type, obj interface{}) {
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+
+	"github.com/netdata/go.d.plugin/logger"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newPVCDiscoverer(si cache.SharedInformer, l *logger.Logger) *pvcDiscoverer {
+	if si == nil {
+		panic("nil pvc shared informer")
+	}
+
+	queue := workqueue.NewNamed("pvc")
+	si.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { enqueue(queue, obj) },
+		UpdateFunc: func(_, obj interface{}) { enqueue(queue, obj) },
+		DeleteFunc: func(obj interface{}) { enqueue(queue, obj) },
+	})
+
+	return &pvcDiscoverer{
+		Logger:   l,
+		informer: si,
+		queue:    queue,
+		readyCh:  make(chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+type pvcResource struct {
+	src string
+	val interface{}
+}
+
+func (r pvcResource) source() string         { return r.src }
+func (r pvcResource) kind() kubeResourceKind { return kubeResourcePVC }
+func (r pvcResource) value() interface{}     { return r.val }
+
+type pvcDiscoverer struct {
+	*logger.Logger
+	informer cache.SharedInformer
+	queue    *workqueue.Type
+	readyCh  chan struct{}
+	stopCh   chan struct{}
+}
+
+func (d *pvcDiscoverer) run(ctx context.Context, in chan<- resource) {
+	d.Info("pvc_discoverer is started")
+	defer func() { close(d.stopCh); d.Info("pvc_discoverer is stopped") }()
+
+	defer d.queue.ShutDown()
+
+	go d.informer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), d.informer.HasSynced) {
+		return
+	}
+
+	go d.runDiscover(ctx, in)
+	close(d.readyCh)
+
+	<-ctx.Done()
+}
+
+func (d *pvcDiscoverer) ready() bool   { return isChanClosed(d.readyCh) }
+func (d *pvcDiscoverer) stopped() bool { return isChanClosed(d.stopCh) }
+
+func (d *pvcDiscoverer) runDiscover(ctx context.Context, in chan<- resource) {
+	for {
+		item, shutdown := d.queue.Get()
+		if shutdown {
+			return
+		}
+
+		func() {
+			defer d.queue.Done(item)
+
+			key := item.(string)
+			ns, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				return
+			}
+
+			item, exists, err := d.informer.GetStore().GetByKey(key)
+			if err != nil {
+				return
+			}
+
+			r := &pvcResource{src: pvcSource(ns, name)}
+			if exists {
+				r.val = item
+			}
+			send(ctx, in, r)
+		}()
+	}
+}
+
+func pvcSource(namespace, name string) string {
+	return "k8s/pvc/" + namespace + "/" + name
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	"context"
+
+	"github.com/netdata/go.d.plugin/logger"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newStatefulSetDiscoverer(si cache.SharedInformer, l *logger.Logger) *statefulSetDiscoverer {
+	if si == nil {
+		panic("nil statefulset shared informer")
+	}
+
+	queue := workqueue.NewNamed("statefulset")
+	si.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { enqueue(queue, obj) },
+		UpdateFunc: func(_, obj interface{}) { enqueue(queue, obj) },
+		DeleteFunc: func(obj interface{}) { enqueue(queue, obj) },
+	})
+
+	return &statefulSetDiscoverer{
+		Logger:   l,
+		informer: si,
+		queue:    queue,
+		readyCh:  make(chan struct{}),
+		stopCh:   make(chan struct{}),
+	}
+}
+
+type statefulSetResource struct {
+	src string
+	val interface{}
+}
+
+func (r statefulSetResource) source() string         { return r.src }
+func (r statefulSetResource) kind() kubeResourceKind { return kubeResourceStatefulSet }
+func (r statefulSetResource) value() interface{}     { return r.val }
+
+type statefulSetDiscoverer struct {
+	*logger.Logger
+	informer cache.SharedInformer
+	queue    *workqueue.Type
+	readyCh  chan struct{}
+	stopCh   chan struct{}
+}
+
+func (d *statefulSetDiscoverer) run(ctx context.Context, in chan<- resource) {
+	d.Info("statefulset_discoverer is started")
+	defer func() { close(d.stopCh); d.Info("statefulset_discoverer is stopped") }()
+
+	defer d.queue.ShutDown()
+
+	go d.informer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), d.informer.HasSynced) {
+		return
+	}
+
+	go d.runDiscover(ctx, in)
+	close(d.readyCh)
+
+	<-ctx.Done()
+}
+
+func (d *statefulSetDiscoverer) ready() bool   { return isChanClosed(d.readyCh) }
+func (d *statefulSetDiscoverer) stopped() bool { return isChanClosed(d.stopCh) }
+
+func (d *statefulSetDiscoverer) runDiscover(ctx context.Context, in chan<- resource) {
+	for {
+		item, shutdown := d.queue.Get()
+		if shutdown {
+			return
+		}
+
+		func() {
+			defer d.queue.Done(item)
+
+			key := item.(string)
+			ns, name, err := cache.SplitMetaNamespaceKey(key)
+			if err != nil {
+				return
+			}
+
+			item, exists, err := d.informer.GetStore().GetByKey(key)
+			if err != nil {
+				return
+			}
+
+			r := &statefulSetResource{src: statefulSetSource(ns, name)}
+			if exists {
+				r.val = item
+			}
+			send(ctx, in, r)
+		}()
+	}
+}
+
+func statefulSetSource(namespace, name string) string {
+	return "k8s/statefulset/" + namespace + "/" + name
+}
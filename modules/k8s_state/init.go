@@ -3,6 +3,8 @@
 package k8s_state
 
 import (
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -11,5 +13,20 @@ func (ks KubeState) initClient() (kubernetes.Interface, error) {
 }
 
 func (ks *KubeState) initDiscoverer(client kubernetes.Interface) discoverer {
-	return newKubeDiscovery(client, ks.Logger)
+	cfg := discoveryConfig{
+		workloads: ks.CollectWorkloads,
+		pvcs:      ks.CollectPVCs,
+	}
+	return newKubeDiscovery(client, cfg, ks.Logger)
+}
+
+func (ks KubeState) initNamespacesMatcher() (matcher.Matcher, error) {
+	if ks.Namespaces.Empty() {
+		return matcher.TRUE(), nil
+	}
+	m, err := ks.Namespaces.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return matcher.WithCache(m), nil
 }
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
+	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"k8s.io/client-go/kubernetes"
 )
@@ -23,16 +25,32 @@ func init() {
 
 func New() *KubeState {
 	return &KubeState{
+		Config: Config{
+			PVCPendingThreshold: web.Duration{Duration: time.Minute * 5},
+		},
 		initDelay:     time.Second * 3,
 		newKubeClient: newKubeClient,
 		charts:        baseCharts.Copy(),
 		once:          &sync.Once{},
 		wg:            &sync.WaitGroup{},
 		state:         newKubeState(),
+		pvcNamespaces: make(map[string]bool),
 	}
 }
 
 type (
+	Config struct {
+		// Namespaces is the namespaces filter of the namespaced resources (pods, deployments, statefulsets, pvcs).
+		Namespaces matcher.SimpleExpr `yaml:"namespaces"`
+		// CollectWorkloads enables Deployments and StatefulSets collection,
+		// it requires list/watch on 'apps/deployments' and 'apps/statefulsets'.
+		CollectWorkloads bool `yaml:"collect_workloads"`
+		// CollectPVCs enables PersistentVolumeClaims collection, it requires list/watch on 'persistentvolumeclaims'.
+		CollectPVCs bool `yaml:"collect_pvcs"`
+		// PVCPendingThreshold is the time a PVC can be in the Pending phase before it is counted as stuck.
+		PVCPendingThreshold web.Duration `yaml:"pvc_pending_threshold"`
+	}
+
 	discoverer interface {
 		run(ctx context.Context, in chan<- resource)
 		ready() bool
@@ -41,6 +59,7 @@ type (
 
 	KubeState struct {
 		module.Base
+		Config `yaml:",inline"`
 
 		newKubeClient func() (kubernetes.Interface, error)
 
@@ -59,10 +78,22 @@ type (
 
 		kubeClusterID   string
 		kubeClusterName string
+
+		nsMatcher matcher.Matcher
+
+		// pvcNamespaces are the namespaces that have the PVCs aggregate charts.
+		pvcNamespaces map[string]bool
 	}
 )
 
 func (ks *KubeState) Init() bool {
+	m, err := ks.initNamespacesMatcher()
+	if err != nil {
+		ks.Errorf("namespaces matcher initialization: %v", err)
+		return false
+	}
+	ks.nsMatcher = m
+
 	client, err := ks.initClient()
 	if err != nil {
 		ks.Errorf("client initialization: %v", err)
@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -167,8 +169,9 @@ func TestKubeState_Collect(t *testing.T) {
 	type (
 		testCaseStep func(t *testing.T, ks *KubeState)
 		testCase     struct {
-			client kubernetes.Interface
-			steps  []testCaseStep
+			client  kubernetes.Interface
+			prepare func(ks *KubeState)
+			steps   []testCaseStep
 		}
 	)
 
@@ -205,6 +208,9 @@ func TestKubeState_Collect(t *testing.T) {
 						"node_node01_cond_ready":                       1,
 						"node_node01_schedulability_schedulable":       1,
 						"node_node01_schedulability_unschedulable":     0,
+						"node_node01_taints_no_execute":                0,
+						"node_node01_taints_no_schedule":               0,
+						"node_node01_taints_prefer_no_schedule":        0,
 						"node_node01_containers":                       0,
 						"node_node01_containers_state_running":         0,
 						"node_node01_containers_state_terminated":      0,
@@ -331,6 +337,9 @@ func TestKubeState_Collect(t *testing.T) {
 						"node_node01_cond_ready":                                  1,
 						"node_node01_schedulability_schedulable":                  1,
 						"node_node01_schedulability_unschedulable":                0,
+						"node_node01_taints_no_execute":                           0,
+						"node_node01_taints_no_schedule":                          0,
+						"node_node01_taints_prefer_no_schedule":                   0,
 						"node_node01_containers":                                  2,
 						"node_node01_containers_state_running":                    2,
 						"node_node01_containers_state_terminated":                 0,
@@ -397,6 +406,41 @@ func TestKubeState_Collect(t *testing.T) {
 				}
 			},
 		},
+		"Node with taints and provider conditions": {
+			create: func(t *testing.T) testCase {
+				node := newNode("node01")
+				node.Spec.Taints = []corev1.Taint{
+					{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoExecute},
+					{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+				}
+				node.Status.Conditions[0].Status = corev1.ConditionFalse
+				node.Status.Conditions = append(node.Status.Conditions,
+					corev1.NodeCondition{Type: "KernelDeadlock", Status: corev1.ConditionTrue},
+				)
+				client := fake.NewSimpleClientset(node)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+
+					assert.Equal(t, int64(0), mx["node_node01_cond_ready"])
+					assert.Equal(t, int64(1), mx["node_node01_cond_kerneldeadlock"])
+					assert.Equal(t, int64(2), mx["node_node01_taints_no_schedule"])
+					assert.Equal(t, int64(1), mx["node_node01_taints_prefer_no_schedule"])
+					assert.Equal(t, int64(1), mx["node_node01_taints_no_execute"])
+
+					chart := ks.Charts().Get("node_node01.condition_status")
+					require.NotNil(t, chart)
+					assert.True(t, chart.HasDim("node_node01_cond_kerneldeadlock"))
+				}
+
+				return testCase{
+					client: client,
+					steps:  []testCaseStep{step1},
+				}
+			},
+		},
 		"delete a Pod in runtime": {
 			create: func(t *testing.T) testCase {
 				ctx := context.Background()
@@ -435,6 +479,9 @@ func TestKubeState_Collect(t *testing.T) {
 						"node_node01_cond_ready":                       1,
 						"node_node01_schedulability_schedulable":       1,
 						"node_node01_schedulability_unschedulable":     0,
+						"node_node01_taints_no_execute":                0,
+						"node_node01_taints_no_schedule":               0,
+						"node_node01_taints_prefer_no_schedule":        0,
 						"node_node01_containers":                       0,
 						"node_node01_containers_state_running":         0,
 						"node_node01_containers_state_terminated":      0,
@@ -553,6 +600,9 @@ func TestKubeState_Collect(t *testing.T) {
 						"node_node01_cond_ready":                                  1,
 						"node_node01_schedulability_schedulable":                  1,
 						"node_node01_schedulability_unschedulable":                0,
+						"node_node01_taints_no_execute":                           0,
+						"node_node01_taints_no_schedule":                          0,
+						"node_node01_taints_prefer_no_schedule":                   0,
 						"node_node01_containers":                                  4,
 						"node_node01_containers_state_running":                    4,
 						"node_node01_containers_state_terminated":                 0,
@@ -654,6 +704,244 @@ func TestKubeState_Collect(t *testing.T) {
 				}
 			},
 		},
+		"Deployment and StatefulSet": {
+			create: func(t *testing.T) testCase {
+				deploy := newDeployment("default", "deploy01")
+				sts := newStatefulSet("default", "sts01")
+				sts.Spec.Replicas = nil // defaults to 1
+				sts.Status = appsv1.StatefulSetStatus{ReadyReplicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1}
+				client := fake.NewSimpleClientset(
+					deploy,
+					sts,
+				)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					expected := map[string]int64{
+						"discovery_node_discoverer_state":                1,
+						"discovery_pod_discoverer_state":                 1,
+						"deployment_default_deploy01_replicas_available": 2,
+						"deployment_default_deploy01_replicas_desired":   3,
+						"deployment_default_deploy01_replicas_readiness": 66666,
+						"deployment_default_deploy01_replicas_ready":     2,
+						"deployment_default_deploy01_replicas_updated":   3,
+						"statefulset_default_sts01_replicas_available":   1,
+						"statefulset_default_sts01_replicas_desired":     1,
+						"statefulset_default_sts01_replicas_readiness":   100000,
+						"statefulset_default_sts01_replicas_ready":       1,
+						"statefulset_default_sts01_replicas_updated":     1,
+					}
+					assert.Equal(t, expected, mx)
+					assert.Equal(t,
+						len(workloadChartsTmpl)*2+len(baseCharts),
+						len(*ks.Charts()),
+					)
+
+					chart := ks.Charts().Get("deployment_default_deploy01.replicas")
+					require.NotNil(t, chart)
+					assert.Equal(t, "k8s_state.deployment_replicas", chart.Ctx)
+					assert.True(t, isLabelValueSet(chart, labelKeyDeploymentName))
+					chart = ks.Charts().Get("statefulset_default_sts01.replicas")
+					require.NotNil(t, chart)
+					assert.Equal(t, "k8s_state.statefulset_replicas", chart.Ctx)
+					assert.True(t, isLabelValueSet(chart, labelKeyStatefulSetName))
+				}
+
+				return testCase{
+					client:  client,
+					prepare: func(ks *KubeState) { ks.CollectWorkloads = true },
+					steps:   []testCaseStep{step1},
+				}
+			},
+		},
+		"Deployment without collect_workloads": {
+			create: func(t *testing.T) testCase {
+				client := fake.NewSimpleClientset(
+					newDeployment("default", "deploy01"),
+				)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					assert.NotContains(t, mx, "deployment_default_deploy01_replicas_desired")
+					assert.Equal(t, len(baseCharts), len(*ks.Charts()))
+				}
+
+				return testCase{
+					client: client,
+					steps:  []testCaseStep{step1},
+				}
+			},
+		},
+		"delete a Deployment in runtime": {
+			create: func(t *testing.T) testCase {
+				ctx := context.Background()
+				deploy := newDeployment("default", "deploy01")
+				client := fake.NewSimpleClientset(
+					deploy,
+				)
+				step1 := func(t *testing.T, ks *KubeState) {
+					_ = ks.Collect()
+					_ = client.AppsV1().Deployments(deploy.Namespace).Delete(ctx, deploy.Name, metav1.DeleteOptions{})
+				}
+
+				step2 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					expected := map[string]int64{
+						"discovery_node_discoverer_state": 1,
+						"discovery_pod_discoverer_state":  1,
+					}
+
+					assert.Equal(t, expected, mx)
+					assert.Equal(t,
+						len(workloadChartsTmpl)+len(baseCharts),
+						len(*ks.Charts()),
+					)
+					assert.Equal(t,
+						len(workloadChartsTmpl),
+						calcObsoleteCharts(*ks.Charts()),
+					)
+				}
+
+				return testCase{
+					client:  client,
+					prepare: func(ks *KubeState) { ks.CollectWorkloads = true },
+					steps:   []testCaseStep{step1, step2},
+				}
+			},
+		},
+		"PVCs": {
+			create: func(t *testing.T) testCase {
+				ctx := context.Background()
+				pvcBound := newPVC("default", "pvc01", corev1.ClaimBound)
+				pvcPending := newPVC("default", "pvc02", corev1.ClaimPending)
+				pvcPending.CreationTimestamp = metav1.Time{Time: time.Now().Add(-time.Minute * 10)}
+				pvcSystem := newPVC("kube-system", "pvc03", corev1.ClaimBound)
+				client := fake.NewSimpleClientset(
+					pvcBound,
+					pvcPending,
+					pvcSystem,
+				)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					expected := map[string]int64{
+						"discovery_node_discoverer_state":                   1,
+						"discovery_pod_discoverer_state":                    1,
+						"pvc_default_pvc01_pending_duration":                0,
+						"pvc_default_pvc01_phase_bound":                     1,
+						"pvc_default_pvc01_phase_lost":                      0,
+						"pvc_default_pvc01_phase_pending":                   0,
+						"pvc_default_pvc01_requested_storage":               1073741824,
+						"pvc_default_pvc02_pending_duration":                600,
+						"pvc_default_pvc02_phase_bound":                     0,
+						"pvc_default_pvc02_phase_lost":                      0,
+						"pvc_default_pvc02_phase_pending":                   1,
+						"pvc_default_pvc02_requested_storage":               1073741824,
+						"pvc_kube-system_pvc03_pending_duration":            0,
+						"pvc_kube-system_pvc03_phase_bound":                 1,
+						"pvc_kube-system_pvc03_phase_lost":                  0,
+						"pvc_kube-system_pvc03_phase_pending":               0,
+						"pvc_kube-system_pvc03_requested_storage":           1073741824,
+						"pvcs_pending_over_threshold":                       1,
+						"pvcs_phase_bound":                                  2,
+						"pvcs_phase_lost":                                   0,
+						"pvcs_phase_pending":                                1,
+						"pvcs_namespace_default_pending_over_threshold":     1,
+						"pvcs_namespace_default_phase_bound":                1,
+						"pvcs_namespace_default_phase_lost":                 0,
+						"pvcs_namespace_default_phase_pending":              1,
+						"pvcs_namespace_kube-system_pending_over_threshold": 0,
+						"pvcs_namespace_kube-system_phase_bound":            1,
+						"pvcs_namespace_kube-system_phase_lost":             0,
+						"pvcs_namespace_kube-system_phase_pending":          0,
+					}
+					assert.GreaterOrEqual(t, mx["pvc_default_pvc02_pending_duration"], int64(600))
+					expected["pvc_default_pvc02_pending_duration"] = mx["pvc_default_pvc02_pending_duration"]
+					assert.Equal(t, expected, mx)
+					assert.Equal(t,
+						len(pvcChartsTmpl)*3+len(pvcsNamespaceChartsTmpl)*2+len(pvcsCharts)+len(baseCharts),
+						len(*ks.Charts()),
+					)
+
+					chart := ks.Charts().Get("pvcs_namespace_kube-system.phase")
+					require.NotNil(t, chart)
+					assert.True(t, isLabelValueSet(chart, labelKeyNamespace))
+
+					_ = client.CoreV1().PersistentVolumeClaims(pvcBound.Namespace).Delete(ctx, pvcBound.Name, metav1.DeleteOptions{})
+					_ = client.CoreV1().PersistentVolumeClaims(pvcSystem.Namespace).Delete(ctx, pvcSystem.Name, metav1.DeleteOptions{})
+				}
+
+				step2 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+
+					assert.Equal(t, int64(0), mx["pvcs_phase_bound"])
+					assert.Equal(t, int64(1), mx["pvcs_phase_pending"])
+					assert.Equal(t, int64(0), mx["pvcs_namespace_default_phase_bound"])
+					assert.NotContains(t, mx, "pvcs_namespace_kube-system_phase_bound")
+					assert.Equal(t,
+						len(pvcChartsTmpl)*2+len(pvcsNamespaceChartsTmpl),
+						calcObsoleteCharts(*ks.Charts()),
+					)
+				}
+
+				return testCase{
+					client:  client,
+					prepare: func(ks *KubeState) { ks.CollectPVCs = true },
+					steps:   []testCaseStep{step1, step2},
+				}
+			},
+		},
+		"PVCs without collect_pvcs": {
+			create: func(t *testing.T) testCase {
+				client := fake.NewSimpleClientset(
+					newPVC("default", "pvc01", corev1.ClaimBound),
+				)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					mx := ks.Collect()
+					assert.NotContains(t, mx, "pvcs_phase_bound")
+					assert.NotContains(t, mx, "pvc_default_pvc01_phase_bound")
+					assert.Equal(t, len(baseCharts), len(*ks.Charts()))
+				}
+
+				return testCase{
+					client: client,
+					steps:  []testCaseStep{step1},
+				}
+			},
+		},
+		"Namespaces filter": {
+			create: func(t *testing.T) testCase {
+				pod := newPod("", "pod01")
+				pod.Namespace = "kube-system"
+				client := fake.NewSimpleClientset(
+					pod,
+					newDeployment("default", "deploy01"),
+					newDeployment("kube-system", "deploy01"),
+					newStatefulSet("kube-system", "sts01"),
+					newPVC("kube-system", "pvc01", corev1.ClaimBound),
+				)
+
+				step1 := func(t *testing.T, ks *KubeState) {
+					_ = ks.Collect()
+
+					assert.Equal(t,
+						len(workloadChartsTmpl)+len(baseCharts),
+						len(*ks.Charts()),
+					)
+					assert.NotNil(t, ks.Charts().Get("deployment_default_deploy01.replicas"))
+				}
+
+				return testCase{
+					client: client,
+					prepare: func(ks *KubeState) {
+						ks.Namespaces = matcher.SimpleExpr{Includes: []string{"= default"}}
+						ks.CollectWorkloads = true
+					},
+					steps: []testCaseStep{step1},
+				}
+			},
+		},
 	}
 
 	for name, creator := range tests {
@@ -662,6 +950,9 @@ func TestKubeState_Collect(t *testing.T) {
 
 			ks := New()
 			ks.newKubeClient = func() (kubernetes.Interface, error) { return test.client, nil }
+			if test.prepare != nil {
+				test.prepare(ks)
+			}
 
 			require.True(t, ks.Init())
 			require.True(t, ks.Check())
@@ -790,6 +1081,64 @@ func newPod(nodeName, name string) *corev1.Pod {
 	}
 }
 
+func newPVC(namespace, name string, phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: mustQuantity("1Gi"),
+				},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func newDeployment(namespace, name string) *appsv1.Deployment {
+	replicas := int32(3)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:     2,
+			UpdatedReplicas:   3,
+			AvailableReplicas: 2,
+		},
+	}
+}
+
+func newStatefulSet(namespace, name string) *appsv1.StatefulSet {
+	replicas := int32(3)
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.StatefulSetStatus{
+			ReadyReplicas:     2,
+			UpdatedReplicas:   3,
+			AvailableReplicas: 2,
+		},
+	}
+}
+
 type brokenInfoKubeClient struct {
 	kubernetes.Interface
 }
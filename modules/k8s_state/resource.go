@@ -5,6 +5,7 @@ package k8s_state
 import (
 	"fmt"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -19,6 +20,9 @@ type kubeResourceKind uint8
 const (
 	kubeResourceNode kubeResourceKind = iota + 1
 	kubeResourcePod
+	kubeResourceDeployment
+	kubeResourceStatefulSet
+	kubeResourcePVC
 )
 
 func toNode(i interface{}) (*corev1.Node, error) {
@@ -42,3 +46,36 @@ func toPod(i interface{}) (*corev1.Pod, error) {
 		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &corev1.Pod{}, resource(nil))
 	}
 }
+
+func toDeployment(i interface{}) (*appsv1.Deployment, error) {
+	switch v := i.(type) {
+	case *appsv1.Deployment:
+		return v, nil
+	case resource:
+		return toDeployment(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.Deployment{}, resource(nil))
+	}
+}
+
+func toStatefulSet(i interface{}) (*appsv1.StatefulSet, error) {
+	switch v := i.(type) {
+	case *appsv1.StatefulSet:
+		return v, nil
+	case resource:
+		return toStatefulSet(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &appsv1.StatefulSet{}, resource(nil))
+	}
+}
+
+func toPVC(i interface{}) (*corev1.PersistentVolumeClaim, error) {
+	switch v := i.(type) {
+	case *corev1.PersistentVolumeClaim:
+		return v, nil
+	case resource:
+		return toPVC(v.value())
+	default:
+		return nil, fmt.Errorf("unexpected type: %T (expected %T or %T)", v, &corev1.PersistentVolumeClaim{}, resource(nil))
+	}
+}
@@ -11,9 +11,11 @@ import (
 
 func newKubeState() *kubeState {
 	return &kubeState{
-		Mutex: &sync.Mutex{},
-		nodes: make(map[string]*nodeState),
-		pods:  make(map[string]*podState),
+		Mutex:     &sync.Mutex{},
+		nodes:     make(map[string]*nodeState),
+		pods:      make(map[string]*podState),
+		workloads: make(map[string]*workloadState),
+		pvcs:      make(map[string]*pvcState),
 	}
 }
 
@@ -44,8 +46,10 @@ func newContainerState() *containerState {
 
 type kubeState struct {
 	*sync.Mutex
-	nodes map[string]*nodeState
-	pods  map[string]*podState
+	nodes     map[string]*nodeState
+	pods      map[string]*podState
+	workloads map[string]*workloadState
+	pvcs      map[string]*pvcState
 }
 
 type (
@@ -53,14 +57,18 @@ type (
 		new     bool
 		deleted bool
 
-		name            string
-		unSchedulable   bool
-		labels          map[string]string
-		creationTime    time.Time
-		allocatableCPU  int64
-		allocatableMem  int64
-		allocatablePods int64
-		conditions      map[string]*nodeStateCondition
+		name          string
+		unSchedulable bool
+		// https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/
+		taintsNoSchedule       int64
+		taintsPreferNoSchedule int64
+		taintsNoExecute        int64
+		labels                 map[string]string
+		creationTime           time.Time
+		allocatableCPU         int64
+		allocatableMem         int64
+		allocatablePods        int64
+		conditions             map[string]*nodeStateCondition
 
 		stats nodeStateStats
 	}
@@ -163,3 +171,47 @@ type (
 		active bool
 	}
 )
+
+type workloadKind string
+
+const (
+	workloadKindDeployment  workloadKind = "deployment"
+	workloadKindStatefulSet workloadKind = "statefulset"
+)
+
+type (
+	workloadState struct {
+		new     bool
+		deleted bool
+
+		kind         workloadKind
+		name         string
+		namespace    string
+		creationTime time.Time
+
+		// https://kubernetes.io/docs/concepts/workloads/controllers/deployment/#deployment-status
+		desiredReplicas   int64
+		readyReplicas     int64
+		updatedReplicas   int64
+		availableReplicas int64
+	}
+)
+
+func (ws workloadState) id() string { return ws.namespace + "_" + ws.name }
+
+type (
+	pvcState struct {
+		new     bool
+		deleted bool
+
+		name             string
+		namespace        string
+		storageClassName string
+		creationTime     time.Time
+		// https://kubernetes.io/docs/concepts/storage/persistent-volumes/#phase
+		phase      corev1.PersistentVolumeClaimPhase
+		reqStorage int64
+	}
+)
+
+func (ps pvcState) id() string { return ps.namespace + "_" + ps.name }
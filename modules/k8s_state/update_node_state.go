@@ -2,6 +2,10 @@
 
 package k8s_state
 
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
 func (ks *KubeState) updateNodeState(r resource) {
 	if r.value() == nil {
 		if ns, ok := ks.state.nodes[r.source()]; ok {
@@ -37,6 +41,18 @@ func (ks *KubeState) updateNodeState(r resource) {
 
 	ns.unSchedulable = node.Spec.Unschedulable
 
+	ns.taintsNoSchedule, ns.taintsPreferNoSchedule, ns.taintsNoExecute = 0, 0, 0
+	for _, t := range node.Spec.Taints {
+		switch t.Effect {
+		case corev1.TaintEffectNoSchedule:
+			ns.taintsNoSchedule++
+		case corev1.TaintEffectPreferNoSchedule:
+			ns.taintsPreferNoSchedule++
+		case corev1.TaintEffectNoExecute:
+			ns.taintsNoExecute++
+		}
+	}
+
 	for _, c := range node.Status.Conditions {
 		if v, ok := ns.conditions[string(c.Type)]; !ok {
 			ns.conditions[string(c.Type)] = &nodeStateCondition{new: true, status: c.Status}
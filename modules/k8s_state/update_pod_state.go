@@ -22,6 +22,10 @@ func (ks *KubeState) updatePodState(r resource) {
 		return
 	}
 
+	if !ks.nsMatcher.MatchString(pod.Namespace) {
+		return
+	}
+
 	ps, ok := ks.state.pods[r.source()]
 	if !ok {
 		ps = newPodState()
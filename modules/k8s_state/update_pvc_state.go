@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+func (ks *KubeState) updatePVCState(r resource) {
+	if r.value() == nil {
+		if ps, ok := ks.state.pvcs[r.source()]; ok {
+			ps.deleted = true
+		}
+		return
+	}
+
+	pvc, err := toPVC(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	if !ks.nsMatcher.MatchString(pvc.Namespace) {
+		return
+	}
+
+	ps, ok := ks.state.pvcs[r.source()]
+	if !ok {
+		ps = &pvcState{new: true}
+		ks.state.pvcs[r.source()] = ps
+	}
+	// the pvc can be re-created (with the same name) before the deletion is collected
+	ps.deleted = false
+
+	if ps.new {
+		ps.name = pvc.Name
+		ps.namespace = pvc.Namespace
+		if pvc.Spec.StorageClassName != nil {
+			ps.storageClassName = *pvc.Spec.StorageClassName
+		}
+	}
+	ps.creationTime = pvc.CreationTimestamp.Time
+	ps.phase = pvc.Status.Phase
+	ps.reqStorage = 0
+	if v, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		ps.reqStorage = v.Value()
+	}
+}
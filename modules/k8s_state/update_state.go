@@ -14,6 +14,12 @@ func (ks *KubeState) runUpdateState(in <-chan resource) {
 				ks.updateNodeState(r)
 			case kubeResourcePod:
 				ks.updatePodState(r)
+			case kubeResourceDeployment:
+				ks.updateDeploymentState(r)
+			case kubeResourceStatefulSet:
+				ks.updateStatefulSetState(r)
+			case kubeResourcePVC:
+				ks.updatePVCState(r)
 			}
 			ks.state.Unlock()
 		}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package k8s_state
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (ks *KubeState) updateDeploymentState(r resource) {
+	if r.value() == nil {
+		ks.markWorkloadDeleted(r)
+		return
+	}
+
+	deploy, err := toDeployment(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	ws := ks.getWorkloadState(r, workloadKindDeployment, deploy.ObjectMeta)
+	if ws == nil {
+		return
+	}
+
+	// the default is 1 if not set
+	ws.desiredReplicas = 1
+	if deploy.Spec.Replicas != nil {
+		ws.desiredReplicas = int64(*deploy.Spec.Replicas)
+	}
+	ws.readyReplicas = int64(deploy.Status.ReadyReplicas)
+	ws.updatedReplicas = int64(deploy.Status.UpdatedReplicas)
+	ws.availableReplicas = int64(deploy.Status.AvailableReplicas)
+}
+
+func (ks *KubeState) updateStatefulSetState(r resource) {
+	if r.value() == nil {
+		ks.markWorkloadDeleted(r)
+		return
+	}
+
+	sts, err := toStatefulSet(r)
+	if err != nil {
+		ks.Warning(err)
+		return
+	}
+
+	ws := ks.getWorkloadState(r, workloadKindStatefulSet, sts.ObjectMeta)
+	if ws == nil {
+		return
+	}
+
+	ws.desiredReplicas = 1
+	if sts.Spec.Replicas != nil {
+		ws.desiredReplicas = int64(*sts.Spec.Replicas)
+	}
+	ws.readyReplicas = int64(sts.Status.ReadyReplicas)
+	ws.updatedReplicas = int64(sts.Status.UpdatedReplicas)
+	ws.availableReplicas = int64(sts.Status.AvailableReplicas)
+}
+
+func (ks *KubeState) markWorkloadDeleted(r resource) {
+	if ws, ok := ks.state.workloads[r.source()]; ok {
+		ws.deleted = true
+	}
+}
+
+// getWorkloadState returns the workload state, it creates a new one if the workload is not known.
+// It returns nil if the workload namespace is filtered out.
+func (ks *KubeState) getWorkloadState(r resource, kind workloadKind, meta metav1.ObjectMeta) *workloadState {
+	if !ks.nsMatcher.MatchString(meta.Namespace) {
+		return nil
+	}
+
+	ws, ok := ks.state.workloads[r.source()]
+	if !ok {
+		ws = &workloadState{
+			new:       true,
+			kind:      kind,
+			name:      meta.Name,
+			namespace: meta.Namespace,
+		}
+		ks.state.workloads[r.source()] = ws
+	}
+	// the workload can be re-created (with the same name) before the deletion is collected
+	ws.deleted = false
+	ws.creationTime = meta.CreationTimestamp.Time
+
+	return ws
+}
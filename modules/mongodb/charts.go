@@ -3,6 +3,8 @@
 package mongo
 
 import (
+	"fmt"
+
 	"github.com/netdata/go.d.plugin/agent/module"
 )
 
@@ -34,6 +36,8 @@ var replCharts = module.Charts{
 	chartReplLag,
 	chartReplHeartbeatLatency,
 	chartReplPing,
+	chartReplMemberLag,
+	chartReplMemberState,
 }
 
 var shardCharts = module.Charts{
@@ -41,6 +45,7 @@ var shardCharts = module.Charts{
 	chartShardDatabases,
 	chartShardCollections,
 	chartShardChunks,
+	chartShardBalancer,
 }
 
 var (
@@ -283,6 +288,61 @@ var chartFlowControl = module.Chart{
 	},
 }
 
+var (
+	chartElectionsCalled = module.Chart{
+		ID:    "election_calls",
+		Title: "Elections Called by Reason",
+		Units: "elections/s",
+		Fam:   "elections",
+		Ctx:   "mongodb.election_calls",
+		Dims: module.Dims{
+			{ID: "election_step_up_cmd_called", Name: "step_up_cmd", Algo: module.Incremental},
+			{ID: "election_priority_takeover_called", Name: "priority_takeover", Algo: module.Incremental},
+			{ID: "election_catch_up_takeover_called", Name: "catch_up_takeover", Algo: module.Incremental},
+			{ID: "election_election_timeout_called", Name: "election_timeout", Algo: module.Incremental},
+			{ID: "election_freeze_timeout_called", Name: "freeze_timeout", Algo: module.Incremental},
+		},
+	}
+	chartElectionsSuccessful = module.Chart{
+		ID:    "election_successful",
+		Title: "Successful Elections by Reason",
+		Units: "elections/s",
+		Fam:   "elections",
+		Ctx:   "mongodb.election_successful",
+		Dims: module.Dims{
+			{ID: "election_step_up_cmd_successful", Name: "step_up_cmd", Algo: module.Incremental},
+			{ID: "election_priority_takeover_successful", Name: "priority_takeover", Algo: module.Incremental},
+			{ID: "election_catch_up_takeover_successful", Name: "catch_up_takeover", Algo: module.Incremental},
+			{ID: "election_election_timeout_successful", Name: "election_timeout", Algo: module.Incremental},
+			{ID: "election_freeze_timeout_successful", Name: "freeze_timeout", Algo: module.Incremental},
+		},
+	}
+	chartElectionStepDowns = module.Chart{
+		ID:    "election_step_downs",
+		Title: "Primary Step Downs Caused by a Higher Term",
+		Units: "step downs/s",
+		Fam:   "elections",
+		Ctx:   "mongodb.election_step_downs",
+		Dims: module.Dims{
+			{ID: "election_step_downs_higher_term", Name: "higher_term", Algo: module.Incremental},
+		},
+	}
+	chartElectionCatchUps = module.Chart{
+		ID:    "election_catch_ups",
+		Title: "Newly Elected Primary Catch Ups",
+		Units: "catch ups/s",
+		Fam:   "elections",
+		Ctx:   "mongodb.election_catch_ups",
+		Dims: module.Dims{
+			{ID: "election_catch_ups_succeeded", Name: "succeeded", Algo: module.Incremental},
+			{ID: "election_catch_ups_already_caught_up", Name: "already_caught_up", Algo: module.Incremental},
+			{ID: "election_catch_ups_skipped", Name: "skipped", Algo: module.Incremental},
+			{ID: "election_catch_ups_timed_out", Name: "timed_out", Algo: module.Incremental},
+			{ID: "election_catch_ups_failed_with_error", Name: "failed", Algo: module.Incremental},
+		},
+	}
+)
+
 var (
 	chartWiredTigerBlockManager = module.Chart{
 		ID:    "wiredtiger_blocks",
@@ -314,6 +374,21 @@ var (
 		},
 	}
 
+	// the read/written are bytes/s, the tracked dirty is bytes, the app threads evicted is pages/s
+	chartWiredTigerCacheActivity = module.Chart{
+		ID:    "wiredtiger_cache_activity",
+		Title: "Wired Tiger Cache Activity",
+		Units: "events",
+		Fam:   "wiredtiger",
+		Ctx:   "mongodb.wiredtiger_cache_activity",
+		Dims: module.Dims{
+			{ID: "wiredtiger_cache_read", Name: "read into cache", Algo: module.Incremental},
+			{ID: "wiredtiger_cache_write", Name: "written from cache", Algo: module.Incremental},
+			{ID: "wiredtiger_cache_dirty", Name: "tracked dirty"},
+			{ID: "wiredtiger_cache_app_threads_evicted", Name: "app threads evicted", Algo: module.Incremental},
+		},
+	}
+
 	chartWiredTigerCapacity = module.Chart{
 		ID:    "wiredtiger_capacity",
 		Title: "Wired Tiger Capacity Waiting",
@@ -474,6 +549,20 @@ var (
 			{ID: "wiredtiger_transaction_rolled_back", Name: "rolled back", Algo: module.Incremental},
 		},
 	}
+
+	chartWiredTigerTickets = module.Chart{
+		ID:    "wiredtiger_tickets",
+		Title: "Wired Tiger Concurrent Transactions Tickets",
+		Units: "tickets",
+		Fam:   "wiredtiger",
+		Ctx:   "mongodb.wiredtiger_tickets",
+		Dims: module.Dims{
+			{ID: "wiredtiger_concurrent_transactions_read_available", Name: "read available"},
+			{ID: "wiredtiger_concurrent_transactions_read_out", Name: "read out"},
+			{ID: "wiredtiger_concurrent_transactions_write_available", Name: "write available"},
+			{ID: "wiredtiger_concurrent_transactions_write_out", Name: "write out"},
+		},
+	}
 )
 
 var (
@@ -530,6 +619,10 @@ const (
 	replicationLagDimPrefix              = "operational_lag_"
 	replicationHeartbeatLatencyDimPrefix = "heartbeat_latency_"
 	replicationNodePingDimPrefix         = "ping_"
+	replicationMemberLag                 = "replication_member_lag"
+	replicationMemberState               = "replication_member_state"
+	replicationMemberLagDimPrefix        = "member_lag_"
+	replicationMemberStateDimPrefix      = "member_state_"
 )
 
 var (
@@ -556,6 +649,23 @@ var (
 		Fam:   "replica set",
 		Ctx:   "mongodb." + replicationNodePing,
 	}
+
+	chartReplMemberLag = &module.Chart{
+		ID:    replicationMemberLag,
+		Title: "Replica Member Lag Behind Primary",
+		Units: "seconds",
+		Fam:   "replica set",
+		Ctx:   "mongodb." + replicationMemberLag,
+	}
+
+	// https://www.mongodb.com/docs/manual/reference/replica-states/
+	chartReplMemberState = &module.Chart{
+		ID:    replicationMemberState,
+		Title: "Replica Member State",
+		Units: "state",
+		Fam:   "replica set",
+		Ctx:   "mongodb." + replicationMemberState,
+	}
 )
 
 var (
@@ -607,3 +717,111 @@ var (
 		Type:  module.Stacked,
 	}
 )
+
+var (
+	chartShardBalancer = &module.Chart{
+		ID:    "shard_balancer_state",
+		Title: "Balancer State",
+		Units: "state",
+		Fam:   "shard stats",
+		Ctx:   "mongodb.shard_balancer_state",
+		Dims: module.Dims{
+			{ID: "shard_balancer_enabled", Name: "enabled"},
+			{ID: "shard_balancer_running", Name: "running"},
+		},
+	}
+
+	shardConnPoolChartsTmpl = module.Charts{
+		chartShardConnPoolTmpl.Copy(),
+		chartShardConnPoolCreatedTmpl.Copy(),
+	}
+
+	chartShardConnPoolTmpl = module.Chart{
+		ID:    "shard_conn_pool_%s",
+		Title: "Shard Connection Pool",
+		Units: "connections",
+		Fam:   "shard conn pools",
+		Ctx:   "mongodb.shard_conn_pool",
+		Type:  module.Stacked,
+		Dims: module.Dims{
+			{ID: "shard_conn_pool_%s_in_use", Name: "in_use"},
+			{ID: "shard_conn_pool_%s_available", Name: "available"},
+			{ID: "shard_conn_pool_%s_refreshing", Name: "refreshing"},
+		},
+	}
+	chartShardConnPoolCreatedTmpl = module.Chart{
+		ID:    "shard_conn_pool_created_%s",
+		Title: "Shard Connection Pool Created Connections",
+		Units: "connections/s",
+		Fam:   "shard conn pools",
+		Ctx:   "mongodb.shard_conn_pool_created",
+		Dims: module.Dims{
+			{ID: "shard_conn_pool_%s_created", Name: "created", Algo: module.Incremental},
+		},
+	}
+)
+
+func newShardConnPoolCharts(shard string) *module.Charts {
+	charts := shardConnPoolChartsTmpl.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, shard)
+		c.Labels = []module.Label{
+			{Key: "shard_id", Value: shard},
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, shard)
+		}
+	}
+	return charts
+}
+
+var (
+	topCollectionChartsTmpl = module.Charts{
+		chartTopCollectionLockTimeTmpl.Copy(),
+		chartTopCollectionOperationsTmpl.Copy(),
+	}
+
+	chartTopCollectionLockTimeTmpl = module.Chart{
+		ID:    "top_collection_%s_lock_time",
+		Title: "Collection Lock Time",
+		Units: "milliseconds/s",
+		Fam:   "top collections",
+		Ctx:   "mongodb.top_collection_lock_time",
+		Type:  module.Stacked,
+		Dims: module.Dims{
+			{ID: "top_%s_read_lock_time", Name: "read", Div: 1000},
+			{ID: "top_%s_write_lock_time", Name: "write", Div: 1000},
+		},
+	}
+	chartTopCollectionOperationsTmpl = module.Chart{
+		ID:    "top_collection_%s_operations",
+		Title: "Collection Operations",
+		Units: "operations/s",
+		Fam:   "top collections",
+		Ctx:   "mongodb.top_collection_operations",
+		Type:  module.Stacked,
+		Dims: module.Dims{
+			{ID: "top_%s_queries", Name: "queries", Div: topPrecision},
+			{ID: "top_%s_getmore", Name: "getmore", Div: topPrecision},
+			{ID: "top_%s_insert", Name: "insert", Div: topPrecision},
+			{ID: "top_%s_update", Name: "update", Div: topPrecision},
+			{ID: "top_%s_remove", Name: "remove", Div: topPrecision},
+			{ID: "top_%s_commands", Name: "commands", Div: topPrecision},
+		},
+	}
+)
+
+func newTopCollectionCharts(name, db, coll string) *module.Charts {
+	charts := topCollectionChartsTmpl.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, name)
+		c.Labels = []module.Label{
+			{Key: "database", Value: db},
+			{Key: "collection", Value: coll},
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, name)
+		}
+	}
+	return charts
+}
@@ -18,6 +18,13 @@ func (m *Mongo) collect() (map[string]int64, error) {
 		return ms, fmt.Errorf("couldn't collecting dbstats metrics: %v", err)
 	}
 
+	if m.CollectTopMetrics && !m.mongoCollector.isMongos() {
+		// 'top' is not supported by mongos, a failure doesn't prevent collecting the rest of the metrics
+		if err := m.collectTop(ms); err != nil {
+			m.Warningf("couldn't collecting top metrics: %v", err)
+		}
+	}
+
 	if m.mongoCollector.isReplicaSet() {
 		// if we have replica set based on the serverStatus response
 		// we add once the charts during runtime
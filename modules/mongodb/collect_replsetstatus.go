@@ -4,6 +4,7 @@ package mongo
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 )
@@ -28,61 +29,63 @@ func (m *Mongo) collectReplSetStatus(ms map[string]int64) error {
 	m.removeReplicaSetMembers(currentMembers)
 	m.replSetMembers = currentMembers
 
+	// the primary optime is the reference point for the members lag, there is no primary during an election
+	var primaryOptime *time.Time
+	for i, member := range status.Members {
+		if member.State == replSetMemberStatePrimary {
+			primaryOptime = &status.Members[i].OptimeDate
+			break
+		}
+	}
+
 	for _, member := range status.Members {
 		if member.LastHeartbeatRecv != nil {
 			id := replicationHeartbeatLatencyDimPrefix + member.Name
 			ms[id] = status.Date.Sub(*member.LastHeartbeatRecv).Milliseconds()
-
-			if !m.replSetDimsEnabled[id] {
-				m.replSetDimsEnabled[id] = true
-
-				if chart := m.charts.Get(replicationHeartbeatLatency); chart != nil {
-					if err := chart.AddDim(&module.Dim{ID: id, Name: member.Name}); err != nil {
-						m.Warningf("failed to add dim: %v", err)
-					} else {
-						chart.MarkNotCreated()
-					}
-				}
-			}
+			m.addReplSetMemberDim(replicationHeartbeatLatency, id, member.Name)
 		}
 
 		id := replicationLagDimPrefix + member.Name
 		// Replica set time diff between current time and time when last entry from the oplog was applied
 		ms[id] = status.Date.Sub(member.OptimeDate).Milliseconds()
-
-		if !m.replSetDimsEnabled[id] {
-			m.replSetDimsEnabled[id] = true
-
-			if chart := m.charts.Get(replicationLag); chart != nil {
-				if err := chart.AddDim(&module.Dim{ID: id, Name: member.Name}); err != nil {
-					m.Warningf("failed to add dim: %v", err)
-				} else {
-					chart.MarkNotCreated()
-				}
-			}
-		}
+		m.addReplSetMemberDim(replicationLag, id, member.Name)
 
 		if member.PingMs != nil {
 			id := replicationNodePingDimPrefix + member.Name
 			ms[id] = *member.PingMs
+			m.addReplSetMemberDim(replicationNodePing, id, member.Name)
+		}
 
-			if !m.replSetDimsEnabled[id] {
-				m.replSetDimsEnabled[id] = true
-
-				if chart := m.charts.Get(replicationNodePing); chart != nil {
-					if err := chart.AddDim(&module.Dim{ID: id, Name: member.Name}); err != nil {
-						m.Warningf("failed to add dim: %v", err)
-					} else {
-						chart.MarkNotCreated()
-					}
-				}
-			}
+		// arbiters have no optime
+		if primaryOptime != nil && !member.OptimeDate.IsZero() {
+			id := replicationMemberLagDimPrefix + member.Name
+			ms[id] = int64(primaryOptime.Sub(member.OptimeDate).Seconds())
+			m.addReplSetMemberDim(replicationMemberLag, id, member.Name)
 		}
+
+		id = replicationMemberStateDimPrefix + member.Name
+		ms[id] = int64(member.State)
+		m.addReplSetMemberDim(replicationMemberState, id, member.Name)
 	}
 
 	return nil
 }
 
+func (m *Mongo) addReplSetMemberDim(chartID, id, name string) {
+	if m.replSetDimsEnabled[id] {
+		return
+	}
+	m.replSetDimsEnabled[id] = true
+
+	if chart := m.charts.Get(chartID); chart != nil {
+		if err := chart.AddDim(&module.Dim{ID: id, Name: name}); err != nil {
+			m.Warningf("failed to add dim: %v", err)
+		} else {
+			chart.MarkNotCreated()
+		}
+	}
+}
+
 // removeReplicaSetMember removes dimensions for not existing
 // replica set members
 func (m *Mongo) removeReplicaSetMembers(newMembers []string) {
@@ -92,6 +95,8 @@ func (m *Mongo) removeReplicaSetMembers(newMembers []string) {
 			{replicationLag, replicationLagDimPrefix},
 			{replicationHeartbeatLatency, replicationHeartbeatLatencyDimPrefix},
 			{replicationNodePing, replicationNodePingDimPrefix},
+			{replicationMemberLag, replicationMemberLagDimPrefix},
+			{replicationMemberState, replicationMemberStateDimPrefix},
 		} {
 			id := v.dimPrefix + name
 			if !m.replSetDimsEnabled[id] {
@@ -35,6 +35,10 @@ func (m *Mongo) collectServerStatus(ms map[string]int64) error {
 // unavailable metrics.
 func (m *Mongo) addOptionalCharts(status *serverStatus) {
 	m.metricExists(status.FlowControl, &chartFlowControl)
+	m.metricExists(status.ElectionMetrics, &chartElectionsCalled)
+	m.metricExists(status.ElectionMetrics, &chartElectionsSuccessful)
+	m.metricExists(status.ElectionMetrics, &chartElectionStepDowns)
+	m.metricExists(status.ElectionMetrics, &chartElectionCatchUps)
 
 	if status.Transactions != nil {
 		m.metricExists(status.Transactions, &chartTransactionsCurrent)
@@ -59,6 +63,7 @@ func (m *Mongo) addOptionalCharts(status *serverStatus) {
 	if status.WiredTiger != nil {
 		m.metricExists(status.WiredTiger.BlockManager, &chartWiredTigerBlockManager)
 		m.metricExists(status.WiredTiger.Cache, &chartWiredTigerCache)
+		m.metricExists(status.WiredTiger.Cache, &chartWiredTigerCacheActivity)
 		m.metricExists(status.WiredTiger.Capacity, &chartWiredTigerCapacity)
 		m.metricExists(status.WiredTiger.Connection, &chartWiredTigerConnection)
 		m.metricExists(status.WiredTiger.Cursor, &chartWiredTigerCursor)
@@ -67,6 +72,7 @@ func (m *Mongo) addOptionalCharts(status *serverStatus) {
 		m.metricExists(status.WiredTiger.Log, &chartWiredTigerLogOps)
 		m.metricExists(status.WiredTiger.Log, &chartWiredTigerLogBytes)
 		m.metricExists(status.WiredTiger.Transaction, &chartWiredTigerTransactions)
+		m.metricExists(status.WiredTiger.ConcurrentTransactions, &chartWiredTigerTickets)
 	}
 }
 
@@ -3,7 +3,9 @@
 package mongo
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 )
@@ -34,19 +36,105 @@ func (m *Mongo) collectShard(ms map[string]int64) error {
 	ms["shard_collections_partitioned"] = collectionsPartitioning.Partitioned
 	ms["shard_collections_unpartitioned"] = collectionsPartitioning.UnPartitioned
 
-	// chunks per shard node
-	chunksPerShard, err := m.mongoCollector.shardChunks()
+	// chunks per shard node, the aggregation over config.chunks is expensive on large clusters
+	if now := time.Now(); m.shardChunks == nil || now.Sub(m.shardChunksTime) >= m.ShardChunksEvery.Duration {
+		chunksPerShard, err := m.mongoCollector.shardChunks()
+		if err != nil {
+			return err
+		}
+		m.shardChunks, m.shardChunksTime = chunksPerShard, now
+		m.updateShardChunkChartDims(chunksPerShard)
+	}
+	for shard, count := range m.shardChunks {
+		ms["shard_chucks_per_node_"+shard] = count
+	}
+
+	// the balancer state and the connection pools are not critical, the other shard metrics are collected anyway
+	if err := m.collectShardBalancer(ms); err != nil {
+		m.Errorf("failed to collect the shard balancer state: %v", err)
+	}
+	if err := m.collectShardConnPools(ms); err != nil {
+		m.Errorf("failed to collect the shard connection pools: %v", err)
+	}
+
+	return nil
+}
+
+func (m *Mongo) collectShardBalancer(ms map[string]int64) error {
+	balancer, err := m.mongoCollector.balancerStatus()
 	if err != nil {
 		return err
 	}
-	m.updateShardChunkChartDims(chunksPerShard)
-	for shard, count := range chunksPerShard {
-		ms["shard_chucks_per_node_"+shard] = count
+	ms["shard_balancer_enabled"] = 0
+	ms["shard_balancer_running"] = 0
+	if balancer.Mode != "off" {
+		ms["shard_balancer_enabled"] = 1
 	}
+	if balancer.InBalancerRound {
+		ms["shard_balancer_running"] = 1
+	}
+	return nil
+}
 
+// collectShardConnPools collects the connection pools per shard (mongos to the shard members).
+func (m *Mongo) collectShardConnPools(ms map[string]int64) error {
+	shards, err := m.mongoCollector.shardList()
+	if err != nil {
+		return err
+	}
+	poolStats, err := m.mongoCollector.connPoolStats()
+	if err != nil {
+		return err
+	}
+	m.updateShardConnPoolCharts(shards)
+	for _, shard := range shards {
+		var stats connPoolHostStats
+		for _, host := range shard.hosts() {
+			v := poolStats.Hosts[host]
+			stats.InUse += v.InUse
+			stats.Available += v.Available
+			stats.Created += v.Created
+			stats.Refreshing += v.Refreshing
+		}
+		px := "shard_conn_pool_" + shard.ID + "_"
+		ms[px+"in_use"] = stats.InUse
+		ms[px+"available"] = stats.Available
+		ms[px+"created"] = stats.Created
+		ms[px+"refreshing"] = stats.Refreshing
+	}
 	return nil
 }
 
+func (m *Mongo) updateShardConnPoolCharts(shards []shardInfo) {
+	seen := make(map[string]bool)
+	for _, shard := range shards {
+		seen[shard.ID] = true
+		if m.shardConnPoolCharts[shard.ID] {
+			continue
+		}
+		m.shardConnPoolCharts[shard.ID] = true
+
+		charts := newShardConnPoolCharts(shard.ID)
+		if err := m.charts.Add(*charts...); err != nil {
+			m.Warningf("failed to add shard '%s' connection pool charts: %v", shard.ID, err)
+		}
+	}
+
+	for id := range m.shardConnPoolCharts {
+		if seen[id] {
+			continue
+		}
+		delete(m.shardConnPoolCharts, id)
+
+		for _, tmpl := range shardConnPoolChartsTmpl {
+			if chart := m.charts.Get(fmt.Sprintf(tmpl.ID, id)); chart != nil {
+				chart.MarkRemove()
+				chart.MarkNotCreated()
+			}
+		}
+	}
+}
+
 func (m *Mongo) updateShardChunkChartDims(chunksPerShard map[string]int64) {
 	chart := m.charts.Get("shard_chucks_per_node")
 	if chart == nil {
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mongo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const topPrecision = 1000
+
+// topCollection holds the per second rates of a collection calculated from the `top` command counters.
+type topCollection struct {
+	db    string
+	coll  string
+	rates map[string]int64
+}
+
+// collectTop collects the `top` command metrics of the 'top_metrics_top_n' busiest (by the total lock time) collections.
+// The command is executed once per 'top_metrics_every', the rates are calculated between two executions
+// and reported until the next one.
+func (m *Mongo) collectTop(ms map[string]int64) error {
+	now := time.Now()
+	if m.topStats == nil || now.Sub(m.topStatsTime) >= m.TopMetricsEvery.Duration {
+		stats, err := m.mongoCollector.top()
+		if err != nil {
+			return err
+		}
+		if m.topStats != nil {
+			m.updateTopCollections(stats, now.Sub(m.topStatsTime).Seconds())
+		}
+		m.topStats, m.topStatsTime = stats, now
+	}
+
+	for name, coll := range m.topCollections {
+		for k, v := range coll.rates {
+			ms["top_"+name+"_"+k] = v
+		}
+	}
+	return nil
+}
+
+func (m *Mongo) updateTopCollections(stats map[string]topCollectionStats, elapsed float64) {
+	if elapsed <= 0 {
+		return
+	}
+
+	type busyColl struct {
+		ns        string
+		totalTime int64
+	}
+	var busy []busyColl
+	for ns, cur := range stats {
+		db, _, ok := strings.Cut(ns, ".")
+		if !ok || (m.databasesMatcher != nil && !m.databasesMatcher.MatchString(db)) {
+			continue
+		}
+		// the counters of a new or re-created collection can't be compared with the previous ones
+		prev, ok := m.topStats[ns]
+		if !ok || cur.Total.Time < prev.Total.Time {
+			continue
+		}
+		busy = append(busy, busyColl{ns: ns, totalTime: cur.Total.Time - prev.Total.Time})
+	}
+
+	sort.Slice(busy, func(i, j int) bool {
+		if busy[i].totalTime == busy[j].totalTime {
+			return busy[i].ns < busy[j].ns
+		}
+		return busy[i].totalTime > busy[j].totalTime
+	})
+	if len(busy) > m.TopMetricsTopN {
+		busy = busy[:m.TopMetricsTopN]
+	}
+
+	rate := func(cur, prev topCounter) int64 {
+		return int64(float64(cur.Count-prev.Count) * topPrecision / elapsed)
+	}
+	lockRate := func(cur, prev topCounter) int64 {
+		return int64(float64(cur.Time-prev.Time) / elapsed)
+	}
+
+	seen := make(map[string]bool)
+	for _, b := range busy {
+		cur, prev := stats[b.ns], m.topStats[b.ns]
+		name := topCollectionName(b.ns)
+		seen[name] = true
+
+		coll, ok := m.topCollections[name]
+		if !ok {
+			db, c, _ := strings.Cut(b.ns, ".")
+			coll = &topCollection{db: db, coll: c}
+			m.topCollections[name] = coll
+			m.addTopCollectionCharts(name, coll)
+		}
+		coll.rates = map[string]int64{
+			"read_lock_time":  lockRate(cur.ReadLock, prev.ReadLock),
+			"write_lock_time": lockRate(cur.WriteLock, prev.WriteLock),
+			"queries":         rate(cur.Queries, prev.Queries),
+			"getmore":         rate(cur.GetMore, prev.GetMore),
+			"insert":          rate(cur.Insert, prev.Insert),
+			"update":          rate(cur.Update, prev.Update),
+			"remove":          rate(cur.Remove, prev.Remove),
+			"commands":        rate(cur.Commands, prev.Commands),
+		}
+	}
+
+	for name := range m.topCollections {
+		if !seen[name] {
+			delete(m.topCollections, name)
+			m.removeTopCollectionCharts(name)
+		}
+	}
+}
+
+func (m *Mongo) addTopCollectionCharts(name string, coll *topCollection) {
+	charts := newTopCollectionCharts(name, coll.db, coll.coll)
+	if err := m.charts.Add(*charts...); err != nil {
+		m.Warningf("failed to add collection '%s.%s' top charts: %v", coll.db, coll.coll, err)
+	}
+}
+
+func (m *Mongo) removeTopCollectionCharts(name string) {
+	for _, tmpl := range topCollectionChartsTmpl {
+		if chart := m.charts.Get(fmt.Sprintf(tmpl.ID, name)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
+// topCollectionName converts the namespace ('<db>.<collection>') to the name used in the chart and dimension IDs.
+func topCollectionName(ns string) string {
+	return strings.NewReplacer(".", "_", " ", "_").Replace(ns)
+}
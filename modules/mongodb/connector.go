@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -23,6 +24,10 @@ type connector interface {
 	shardDatabasesPartitioning() (*partitionedResult, error)
 	shardCollectionsPartitioning() (*partitionedResult, error)
 	shardChunks() (map[string]int64, error)
+	shardList() ([]shardInfo, error)
+	balancerStatus() (*balancerStatus, error)
+	connPoolStats() (*connPoolStats, error)
+	top() (map[string]topCollectionStats, error)
 	initClient(uri string, timeout time.Duration) error
 	close() error
 }
@@ -211,6 +216,75 @@ func (m *mongoCollector) shardChunks() (map[string]int64, error) {
 	return result, err
 }
 
+// shardList returns the shards of the cluster (the `config.shards` collection).
+func (m *mongoCollector) shardList() ([]shardInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*m.Timeout)
+	defer cancel()
+
+	cursor, err := m.Client.Database("config").Collection("shards").Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var shards []shardInfo
+	if err = cursor.All(ctx, &shards); err != nil {
+		return nil, err
+	}
+	return shards, nil
+}
+
+// balancerStatus returns the output of the `balancerStatus` command (mongos only).
+func (m *mongoCollector) balancerStatus() (*balancerStatus, error) {
+	var status balancerStatus
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*m.Timeout)
+	defer cancel()
+	err := m.Client.Database("admin").RunCommand(ctx, bson.D{{Key: "balancerStatus", Value: 1}}).Decode(&status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// connPoolStats returns the output of the `connPoolStats` command.
+func (m *mongoCollector) connPoolStats() (*connPoolStats, error) {
+	var stats connPoolStats
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*m.Timeout)
+	defer cancel()
+	err := m.Client.Database("admin").RunCommand(ctx, bson.D{{Key: "connPoolStats", Value: 1}}).Decode(&stats)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// top returns the per collection stats ('<db>.<collection>' => stats) of the `top` command (mongod only).
+func (m *mongoCollector) top() (map[string]topCollectionStats, error) {
+	var resp struct {
+		Totals map[string]bson.RawValue `bson:"totals"`
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*m.Timeout)
+	defer cancel()
+	err := m.Client.Database("admin").RunCommand(ctx, bson.D{{Key: "top", Value: 1}}).Decode(&resp)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]topCollectionStats, len(resp.Totals))
+	for ns, v := range resp.Totals {
+		// 'totals' contains the 'note' string field
+		if v.Type != bsontype.EmbeddedDocument {
+			continue
+		}
+		var s topCollectionStats
+		if err := v.Unmarshal(&s); err != nil {
+			return nil, fmt.Errorf("'%s' stats: %v", ns, err)
+		}
+		stats[ns] = s
+	}
+	return stats, nil
+}
+
 // initClient initialises the database client if is not initialised.
 func (m *mongoCollector) initClient(uri string, timeout time.Duration) error {
 	if m.Client != nil {
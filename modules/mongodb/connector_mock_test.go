@@ -29,6 +29,10 @@ type mockMongo struct {
 	shardDbPartitionResponse  string
 	shardColPartitionResponse string
 	chunksShardNum            int
+	shardListResponse         string
+	balancerStatusResponse    string
+	connPoolStatsResponse     string
+	topResponse               string
 	mongos                    bool
 }
 
@@ -38,7 +42,7 @@ func (m *mockMongo) initClient(_ string, _ time.Duration) error {
 
 func (m *mockMongo) serverStatus() (*serverStatus, error) {
 	var status serverStatus
-	err := json.Unmarshal([]byte(m.serverStatusResponse), &status)
+	err := bson.UnmarshalExtJSON([]byte(m.serverStatusResponse), false, &status)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +107,48 @@ func (m *mockMongo) shardChunks() (map[string]int64, error) {
 	return res, nil
 }
 
+func (m *mockMongo) shardList() ([]shardInfo, error) {
+	var shards []shardInfo
+	if err := json.Unmarshal([]byte(m.shardListResponse), &shards); err != nil {
+		return nil, err
+	}
+	return shards, nil
+}
+
+func (m *mockMongo) balancerStatus() (*balancerStatus, error) {
+	var status balancerStatus
+	if err := json.Unmarshal([]byte(m.balancerStatusResponse), &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func (m *mockMongo) connPoolStats() (*connPoolStats, error) {
+	var stats connPoolStats
+	if err := json.Unmarshal([]byte(m.connPoolStatsResponse), &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (m *mockMongo) top() (map[string]topCollectionStats, error) {
+	var resp struct {
+		Totals map[string]json.RawMessage `json:"totals"`
+	}
+	if err := json.Unmarshal([]byte(m.topResponse), &resp); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]topCollectionStats)
+	for ns, v := range resp.Totals {
+		var s topCollectionStats
+		if err := json.Unmarshal(v, &s); err != nil {
+			continue // 'note'
+		}
+		stats[ns] = s
+	}
+	return stats, nil
+}
+
 func (m *mockMongo) dbAggregate(_ context.Context, _ *mongo.Client, collection string, _ []bson.D) ([]aggrResults, error) {
 	var res []aggrResults
 	var response string
@@ -137,7 +183,7 @@ func (m *mockMongoServerStatusOnly) listDatabaseNames() ([]string, error) {
 
 func (m *mockMongoServerStatusOnly) serverStatus() (*serverStatus, error) {
 	var status serverStatus
-	err := json.Unmarshal([]byte(m.serverStatusResponse), &status)
+	err := bson.UnmarshalExtJSON([]byte(m.serverStatusResponse), false, &status)
 	if err != nil {
 		return nil, err
 	}
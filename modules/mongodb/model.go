@@ -2,10 +2,15 @@
 
 package mongo
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 const (
 	mongos = "mongos"
+
+	replSetMemberStatePrimary = 1
 )
 
 type serverStatus struct {
@@ -20,14 +25,15 @@ type serverStatus struct {
 	// available in newer or specific builds of mongo
 	// for example, in he hosted version of mongoDB(atlas)
 	// these are not available
-	Transactions *Transactions         `bson:"transactions" stm:"transactions"`
-	GlobalLock   *GlobalLock           `bson:"globalLock" stm:"glock"`
-	Tcmalloc     *ServerStatusTcmalloc `bson:"tcmalloc" stm:"tcmalloc"`
-	Locks        *Locks                `bson:"locks" stm:"locks"`
-	FlowControl  *FlowControl          `bson:"flowControl" stm:"flow"`
-	WiredTiger   *WiredTiger           `bson:"wiredTiger" stm:"wiredtiger"`
-	Repl         interface{}           `bson:"repl"`
-	Process      string                `bson:"process"` // mongod|mongos
+	Transactions    *Transactions         `bson:"transactions" stm:"transactions"`
+	GlobalLock      *GlobalLock           `bson:"globalLock" stm:"glock"`
+	Tcmalloc        *ServerStatusTcmalloc `bson:"tcmalloc" stm:"tcmalloc"`
+	Locks           *Locks                `bson:"locks" stm:"locks"`
+	FlowControl     *FlowControl          `bson:"flowControl" stm:"flow"`
+	ElectionMetrics *ElectionMetrics      `bson:"electionMetrics" stm:"election"`
+	WiredTiger      *WiredTiger           `bson:"wiredTiger" stm:"wiredtiger"`
+	Repl            interface{}           `bson:"repl"`
+	Process         string                `bson:"process"` // mongod|mongos
 }
 
 type Opcounters struct {
@@ -159,6 +165,27 @@ type FlowControl struct {
 	TimeAcquiringMicros *int64 `bson:"timeAcquiringMicros" stm:"time_acquiring_micros"`
 }
 
+// ElectionMetrics is available since v4.2.1
+type ElectionMetrics struct {
+	StepUpCmd        *ElectionReason `bson:"stepUpCmd" stm:"step_up_cmd"`
+	PriorityTakeover *ElectionReason `bson:"priorityTakeover" stm:"priority_takeover"`
+	CatchUpTakeover  *ElectionReason `bson:"catchUpTakeover" stm:"catch_up_takeover"`
+	ElectionTimeout  *ElectionReason `bson:"electionTimeout" stm:"election_timeout"`
+	FreezeTimeout    *ElectionReason `bson:"freezeTimeout" stm:"freeze_timeout"`
+
+	NumStepDownsCausedByHigherTerm *int64 `bson:"numStepDownsCausedByHigherTerm" stm:"step_downs_higher_term"`
+	NumCatchUpsSucceeded           *int64 `bson:"numCatchUpsSucceeded" stm:"catch_ups_succeeded"`
+	NumCatchUpsAlreadyCaughtUp     *int64 `bson:"numCatchUpsAlreadyCaughtUp" stm:"catch_ups_already_caught_up"`
+	NumCatchUpsSkipped             *int64 `bson:"numCatchUpsSkipped" stm:"catch_ups_skipped"`
+	NumCatchUpsTimedOut            *int64 `bson:"numCatchUpsTimedOut" stm:"catch_ups_timed_out"`
+	NumCatchUpsFailedWithError     *int64 `bson:"numCatchUpsFailedWithError" stm:"catch_ups_failed_with_error"`
+}
+
+type ElectionReason struct {
+	Called     *int64 `bson:"called" stm:"called"`
+	Successful *int64 `bson:"successful" stm:"successful"`
+}
+
 type WiredTiger struct {
 	BlockManager *struct {
 		BytesRead                    int `bson:"bytes read" stm:"read"`
@@ -173,6 +200,9 @@ type WiredTiger struct {
 		BytesAllocatedForUpdates int `bson:"bytes allocated for updates" stm:"alloccated"`
 		BytesReadIntoCache       int `bson:"bytes read into cache" stm:"read"`
 		BytesWrittenFromCache    int `bson:"bytes written from cache" stm:"write"`
+
+		TrackedDirtyBytesInTheCache      int `bson:"tracked dirty bytes in the cache" stm:"dirty"`
+		PagesEvictedByApplicationThreads int `bson:"pages evicted by application threads" stm:"app_threads_evicted"`
 	} `bson:"cache" stm:"cache"`
 	Capacity *struct {
 		TimeWaitingDueToTotalCapacityUsecs int `bson:"time waiting due to total capacity (usecs)" stm:"wait_capacity"`
@@ -261,6 +291,17 @@ type WiredTiger struct {
 		TransactionsCommitted  int `bson:"transactions committed" stm:"committed"`
 		TransactionsRolledBack int `bson:"transactions rolled back" stm:"rolled_back"`
 	} `bson:"transaction" stm:"transaction"`
+	// read/write tickets, the number of concurrent operations allowed into the storage engine
+	ConcurrentTransactions *struct {
+		Read  WiredTigerTickets `bson:"read" stm:"read"`
+		Write WiredTigerTickets `bson:"write" stm:"write"`
+	} `bson:"concurrentTransactions" stm:"concurrent_transactions"`
+}
+
+type WiredTigerTickets struct {
+	Out          int `bson:"out" stm:"out"`
+	Available    int `bson:"available" stm:"available"`
+	TotalTickets int `bson:"totalTickets" stm:"total"`
 }
 
 type dbStats struct {
@@ -314,3 +355,51 @@ type shardNodesResult struct {
 	ShardAware   int64
 	ShardUnaware int64
 }
+
+type shardInfo struct {
+	ID   string `bson:"_id" json:"_id"`
+	Host string `bson:"host" json:"host"` // <replica set>/<host1>,<host2>,...
+}
+
+// hosts returns the shard member hosts.
+func (s shardInfo) hosts() []string {
+	host := s.Host
+	if i := strings.IndexByte(host, '/'); i != -1 {
+		host = host[i+1:]
+	}
+	return strings.Split(host, ",")
+}
+
+type balancerStatus struct {
+	Mode            string `bson:"mode"` // full|off
+	InBalancerRound bool   `bson:"inBalancerRound"`
+}
+
+type connPoolStats struct {
+	Hosts map[string]connPoolHostStats `bson:"hosts"`
+}
+
+type connPoolHostStats struct {
+	InUse      int64 `bson:"inUse"`
+	Available  int64 `bson:"available"`
+	Created    int64 `bson:"created"`
+	Refreshing int64 `bson:"refreshing"`
+}
+
+// topCollectionStats is a collection entry of the `top` command output ('totals.<db>.<collection>').
+type topCollectionStats struct {
+	Total     topCounter `bson:"total" json:"total"`
+	ReadLock  topCounter `bson:"readLock" json:"readLock"`
+	WriteLock topCounter `bson:"writeLock" json:"writeLock"`
+	Queries   topCounter `bson:"queries" json:"queries"`
+	GetMore   topCounter `bson:"getmore" json:"getmore"`
+	Insert    topCounter `bson:"insert" json:"insert"`
+	Update    topCounter `bson:"update" json:"update"`
+	Remove    topCounter `bson:"remove" json:"remove"`
+	Commands  topCounter `bson:"commands" json:"commands"`
+}
+
+type topCounter struct {
+	Time  int64 `bson:"time" json:"time"` // microseconds
+	Count int64 `bson:"count" json:"count"`
+}
@@ -8,12 +8,18 @@ import (
 
 	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/netdata/go.d.plugin/pkg/matcher"
+	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
 type Config struct {
-	URI       string             `yaml:"uri"`
-	Timeout   time.Duration      `yaml:"timeout"`
-	Databases matcher.SimpleExpr `yaml:"databases"`
+	URI              string             `yaml:"uri"`
+	Timeout          time.Duration      `yaml:"timeout"`
+	Databases        matcher.SimpleExpr `yaml:"databases"`
+	ShardChunksEvery web.Duration       `yaml:"shard_chunks_every"`
+
+	CollectTopMetrics bool         `yaml:"collect_top_metrics"`
+	TopMetricsTopN    int          `yaml:"top_metrics_top_n"`
+	TopMetricsEvery   web.Duration `yaml:"top_metrics_every"`
 }
 
 func init() {
@@ -25,8 +31,11 @@ func init() {
 func New() *Mongo {
 	return &Mongo{
 		Config: Config{
-			Timeout: 1,
-			URI:     "mongodb://localhost:27017",
+			Timeout:          1,
+			URI:              "mongodb://localhost:27017",
+			ShardChunksEvery: web.Duration{Duration: time.Minute * 5},
+			TopMetricsTopN:   10,
+			TopMetricsEvery:  web.Duration{Duration: time.Minute},
 			Databases: matcher.SimpleExpr{
 				Includes: []string{},
 				Excludes: []string{},
@@ -36,6 +45,8 @@ func New() *Mongo {
 		optionalChartsEnabled: make(map[string]bool),
 		discoveredDBs:         make([]string, 0),
 		shardNodesDims:        make(map[string]bool),
+		shardConnPoolCharts:   make(map[string]bool),
+		topCollections:        make(map[string]*topCollection),
 		mongoCollector:        &mongoCollector{},
 		addReplChartsOnce:     sync.Once{},
 		addShardChartsOnce:    sync.Once{},
@@ -53,6 +64,12 @@ type Mongo struct {
 	optionalChartsEnabled map[string]bool
 	discoveredDBs         []string
 	shardNodesDims        map[string]bool
+	shardChunks           map[string]int64
+	shardChunksTime       time.Time
+	shardConnPoolCharts   map[string]bool
+	topStats              map[string]topCollectionStats
+	topStatsTime          time.Time
+	topCollections        map[string]*topCollection
 	chartsDbStats         *module.Charts
 	replSetMembers        []string
 	replSetDimsEnabled    map[string]bool
@@ -76,6 +93,11 @@ func (m *Mongo) Init() bool {
 		m.databasesMatcher = mMatcher
 	}
 
+	if m.CollectTopMetrics && m.TopMetricsTopN <= 0 {
+		m.Errorf("'top_metrics_top_n' must be positive, got %d", m.TopMetricsTopN)
+		return false
+	}
+
 	var err error
 	m.charts, err = m.initCharts()
 	if err != nil {
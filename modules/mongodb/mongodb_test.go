@@ -3,14 +3,17 @@
 package mongo
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/netdata/go.d.plugin/modules/mongodb/testdata/v5.0.0"
 	"github.com/netdata/go.d.plugin/pkg/matcher"
+	"github.com/netdata/go.d.plugin/pkg/web"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,6 +114,7 @@ func TestMongo_ChartsOptional(t *testing.T) {
 		"tcmalloc",
 		"tcmalloc_generic",
 		"wiredtiger_cache",
+		"wiredtiger_cache_activity",
 		"wiredtiger_capacity",
 		"wiredtiger_connection",
 		"wiredtiger_cursor",
@@ -119,6 +123,7 @@ func TestMongo_ChartsOptional(t *testing.T) {
 		"wiredtiger_log_ops",
 		"wiredtiger_log_ops_size",
 		"wiredtiger_transactions",
+		"wiredtiger_tickets",
 	} {
 		assert.NotContainsf(t, IDs, id, msg)
 	}
@@ -145,6 +150,70 @@ func TestMongo_Success(t *testing.T) {
 	assert.True(t, m.Check(), "check should success with the mocker serverStatus response")
 }
 
+func TestMongo_Collect_WiredTiger(t *testing.T) {
+	tests := map[string]struct {
+		serverStatus string
+		wantCharts   bool
+		wantMetrics  map[string]int64
+	}{
+		"WiredTiger storage engine": {
+			serverStatus: v5_0_0.ServerStatus,
+			wantCharts:   true,
+			wantMetrics: map[string]int64{
+				"wiredtiger_cache_dirty":                             1449,
+				"wiredtiger_cache_app_threads_evicted":               0,
+				"wiredtiger_concurrent_transactions_read_out":        1,
+				"wiredtiger_concurrent_transactions_read_available":  127,
+				"wiredtiger_concurrent_transactions_read_total":      128,
+				"wiredtiger_concurrent_transactions_write_out":       0,
+				"wiredtiger_concurrent_transactions_write_available": 128,
+				"wiredtiger_concurrent_transactions_write_total":     128,
+			},
+		},
+		"other storage engine": {
+			serverStatus: serverStatusWithoutWiredTiger(t),
+			wantCharts:   false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := New()
+			m.mongoCollector = &mockMongo{serverStatusResponse: test.serverStatus}
+			require.True(t, m.Init())
+
+			ms := m.Collect()
+			require.NotNil(t, ms)
+
+			for _, id := range []string{
+				"wiredtiger_cache",
+				"wiredtiger_cache_activity",
+				"wiredtiger_tickets",
+			} {
+				assert.Equal(t, test.wantCharts, m.charts.Has(id), id)
+			}
+			for k, v := range test.wantMetrics {
+				assert.Equal(t, v, ms[k], k)
+			}
+			if !test.wantCharts {
+				for k := range ms {
+					assert.False(t, strings.HasPrefix(k, "wiredtiger_"), k)
+				}
+			}
+		})
+	}
+}
+
+func serverStatusWithoutWiredTiger(t *testing.T) string {
+	var status map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(v5_0_0.ServerStatus), &status))
+	delete(status, "wiredTiger")
+	status["storageEngine"] = map[string]interface{}{"name": "inMemory"}
+	bs, err := json.Marshal(status)
+	require.NoError(t, err)
+	return string(bs)
+}
+
 func TestMongo_Collect_DbStats(t *testing.T) {
 	m := New()
 	m.mongoCollector = &mockMongo{
@@ -283,6 +352,8 @@ func TestMongo_Collect_ReplSetStatusAddRemove(t *testing.T) {
 	assert.True(t, m.charts.Get(replicationLag).HasDim(replicationLagDimPrefix+"node1"), msg)
 	assert.True(t, m.charts.Get(replicationHeartbeatLatency).HasDim(replicationHeartbeatLatencyDimPrefix+"node1"), msg)
 	assert.True(t, m.charts.Get(replicationNodePing).HasDim(replicationNodePingDimPrefix+"node1"), msg)
+	assert.True(t, m.charts.Get(replicationMemberLag).HasDim(replicationMemberLagDimPrefix+"node1"), msg)
+	assert.True(t, m.charts.Get(replicationMemberState).HasDim(replicationMemberStateDimPrefix+"node1"), msg)
 
 	m.mongoCollector = &mockMongo{
 		serverStatusResponse:      "{}",
@@ -303,6 +374,97 @@ func TestMongo_Collect_ReplSetStatusAddRemove(t *testing.T) {
 	assert.True(t, m.charts.Get(replicationLag).GetDim(replicationLagDimPrefix+"node1").Obsolete, msg)
 	assert.True(t, m.charts.Get(replicationHeartbeatLatency).GetDim(replicationHeartbeatLatencyDimPrefix+"node1").Obsolete, msg)
 	assert.True(t, m.charts.Get(replicationNodePing).GetDim(replicationNodePingDimPrefix+"node1").Obsolete, msg)
+	assert.True(t, m.charts.Get(replicationMemberLag).GetDim(replicationMemberLagDimPrefix+"node1").Obsolete, msg)
+	assert.True(t, m.charts.Get(replicationMemberState).GetDim(replicationMemberStateDimPrefix+"node1").Obsolete, msg)
+}
+
+func TestMongo_Collect_ReplSetStatusMembersLagAndState(t *testing.T) {
+	m := New()
+	m.mongoCollector = &mockMongo{
+		serverStatusResponse:      "{}",
+		dbStatsResponse:           "{}",
+		listDatabaseNamesResponse: []string{},
+		replicaSet:                true,
+		replicaSetResponse:        v5_0_0.ReplSetGetStatusLag,
+	}
+	m.URI = "mongodb://localhost"
+	require.True(t, m.Init())
+	ms := m.Collect()
+
+	assert.EqualValues(t, 10, ms[replicationMemberLagDimPrefix+"node1"])
+	assert.EqualValues(t, 0, ms[replicationMemberLagDimPrefix+"node2"])
+	assert.NotContains(t, ms, replicationMemberLagDimPrefix+"node3", "arbiters have no optime")
+	assert.EqualValues(t, 2, ms[replicationMemberStateDimPrefix+"node1"])
+	assert.EqualValues(t, 1, ms[replicationMemberStateDimPrefix+"node2"])
+	assert.EqualValues(t, 7, ms[replicationMemberStateDimPrefix+"node3"])
+	assert.Len(t, m.charts.Get(replicationMemberLag).Dims, 2)
+	assert.Len(t, m.charts.Get(replicationMemberState).Dims, 3)
+}
+
+func TestMongo_Collect_ElectionMetrics(t *testing.T) {
+	m := New()
+	m.mongoCollector = &mockMongo{
+		serverStatusResponse: `{"electionMetrics": {"stepUpCmd": {"called": 2, "successful": 1}, "numCatchUpsSkipped": 3}}`,
+		dbStatsResponse:      "{}",
+		replicaSet:           false,
+	}
+	m.URI = "mongodb://localhost"
+	require.True(t, m.Init())
+	ms := m.Collect()
+
+	assert.EqualValues(t, 2, ms["election_step_up_cmd_called"])
+	assert.EqualValues(t, 1, ms["election_step_up_cmd_successful"])
+	assert.EqualValues(t, 3, ms["election_catch_ups_skipped"])
+	for _, chart := range []module.Chart{chartElectionsCalled, chartElectionsSuccessful, chartElectionStepDowns, chartElectionCatchUps} {
+		assert.Truef(t, m.charts.Has(chart.ID), "%s chart should have been added", chart.ID)
+	}
+}
+
+func TestMongo_Collect_TopMetrics(t *testing.T) {
+	m := New()
+	mockClient := &mockMongo{
+		serverStatusResponse:      "{}",
+		listDatabaseNamesResponse: []string{},
+		dbStatsResponse:           "{}",
+		topResponse:               v5_0_0.Top,
+	}
+	m.mongoCollector = mockClient
+	m.CollectTopMetrics = true
+	m.TopMetricsTopN = 1
+	m.TopMetricsEvery = web.Duration{}
+	m.Config.Databases.Includes = []string{"=app"}
+	require.True(t, m.Init())
+
+	// the rates are calculated between two 'top' executions
+	ms := m.Collect()
+	assert.NotContains(t, ms, "top_app_users_queries")
+	assert.Empty(t, m.topCollections)
+
+	mockClient.topResponse = v5_0_0.TopNext
+	m.topStatsTime = m.topStatsTime.Add(-time.Second * 10)
+	ms = m.Collect()
+
+	assert.Len(t, m.topCollections, 1)
+	for _, id := range []string{"top_collection_app_users_lock_time", "top_collection_app_users_operations"} {
+		require.True(t, m.charts.Has(id), "%s chart should have been added", id)
+	}
+	assert.Equal(t, "users", m.charts.Get("top_collection_app_users_operations").Labels[1].Value)
+	assert.False(t, m.charts.Has("top_collection_app_orders_operations"))
+	assert.InDelta(t, 60000, ms["top_app_users_read_lock_time"], 100)
+	assert.InDelta(t, 40000, ms["top_app_users_write_lock_time"], 100)
+	assert.InDelta(t, 70000, ms["top_app_users_queries"], 100)
+	assert.InDelta(t, 15000, ms["top_app_users_insert"], 100)
+	assert.InDelta(t, 5000, ms["top_app_users_update"], 100)
+	assert.InDelta(t, 10000, ms["top_app_users_commands"], 100)
+	assert.NotContains(t, ms, "top_admin_system_version_queries")
+
+	// the collection is dropped
+	mockClient.topResponse = `{"totals": {"app.orders": {"total": {"time": 250000, "count": 250}}}}`
+	ms = m.Collect()
+	assert.NotContains(t, ms, "top_app_users_queries")
+	assert.True(t, m.charts.Get("top_collection_app_users_lock_time").Obsolete)
+	assert.True(t, m.charts.Get("top_collection_app_users_operations").Obsolete)
+	assert.True(t, m.charts.Has("top_collection_app_orders_operations"))
 }
 
 func TestMongo_Collect_Shard(t *testing.T) {
@@ -317,6 +479,9 @@ func TestMongo_Collect_Shard(t *testing.T) {
 		shardDbPartitionResponse:  v5_0_0.ShardDatabases,
 		shardColPartitionResponse: v5_0_0.ShardCollections,
 		chunksShardNum:            2,
+		shardListResponse:         v5_0_0.ShardList,
+		balancerStatusResponse:    v5_0_0.BalancerStatus,
+		connPoolStatsResponse:     v5_0_0.ConnPoolStats,
 	}
 	mockClient.connector = &mongoCollector{aggregationFunc: mockClient.dbAggregate}
 	m.mongoCollector = mockClient
@@ -329,7 +494,25 @@ func TestMongo_Collect_Shard(t *testing.T) {
 		assert.True(t, m.charts.Has(chart.ID), msg, chart.ID)
 		assert.Len(t, m.charts.Get(chart.ID).Dims, 2)
 	}
-	assert.Len(t, ms, 8)
+	for _, id := range []string{"shard_conn_pool_shard1", "shard_conn_pool_created_shard1", "shard_conn_pool_shard2", "shard_conn_pool_created_shard2"} {
+		assert.True(t, m.charts.Has(id), msg, id)
+	}
+	assert.Len(t, ms, 18)
+	assert.EqualValues(t, 1, ms["shard_balancer_enabled"])
+	assert.EqualValues(t, 1, ms["shard_balancer_running"])
+	assert.EqualValues(t, 3, ms["shard_conn_pool_shard1_in_use"])
+	assert.EqualValues(t, 5, ms["shard_conn_pool_shard1_available"])
+	assert.EqualValues(t, 11, ms["shard_conn_pool_shard1_created"])
+	assert.EqualValues(t, 3, ms["shard_conn_pool_shard2_in_use"])
+
+	// the chunks are queried once per 'shard_chunks_every', a shard is removed
+	mockClient.chunksShardNum = 1
+	mockClient.shardListResponse = `[{"_id": "shard1", "host": "shard1/localhost:27018,localhost:27019"}]`
+	ms = m.Collect()
+	assert.EqualValues(t, 2, ms["shard_chucks_per_node_shard2"])
+	assert.True(t, m.charts.Get("shard_conn_pool_shard2").Obsolete)
+	assert.True(t, m.charts.Get("shard_conn_pool_created_shard2").Obsolete)
+	assert.False(t, m.charts.Get("shard_conn_pool_shard1").Obsolete)
 }
 
 func TestMongo_Collect_Shard_Fail(t *testing.T) {
@@ -354,6 +537,7 @@ func TestMongo_Collect_Shard_Fail(t *testing.T) {
 	m.mongoCollector = mockClient
 	m.Config.Databases.Includes = []string{"* *"}
 	m.URI = "mongodb://localhost"
+	m.ShardChunksEvery = web.Duration{}
 	require.True(t, m.Init())
 
 	ms := m.Collect()
@@ -377,6 +561,40 @@ func TestMongo_Collect_Shard_Fail(t *testing.T) {
 
 }
 
+func TestMongo_Collect_Shard_BalancerAndConnPoolsFail(t *testing.T) {
+	m := New()
+	mockClient := &mockMongo{
+		serverStatusResponse:      "{}",
+		listDatabaseNamesResponse: []string{},
+		dbStatsResponse:           "{}",
+		replicaSetResponse:        "{}",
+		mongos:                    true,
+		shardNodesResponse:        v5_0_0.ShardNodes,
+		shardDbPartitionResponse:  v5_0_0.ShardDatabases,
+		shardColPartitionResponse: v5_0_0.ShardCollections,
+		chunksShardNum:            2,
+		shardListResponse:         v5_0_0.ShardList,
+		balancerStatusResponse:    "invalid",
+		connPoolStatsResponse:     v5_0_0.ConnPoolStats,
+	}
+	mockClient.connector = &mongoCollector{aggregationFunc: mockClient.dbAggregate}
+	m.mongoCollector = mockClient
+	m.URI = "mongodb://localhost"
+	require.True(t, m.Init())
+
+	ms := m.Collect()
+	assert.NotContains(t, ms, "shard_balancer_enabled")
+	assert.EqualValues(t, 3, ms["shard_conn_pool_shard1_in_use"])
+	assert.Contains(t, ms, "shard_nodes_count_aware")
+
+	mockClient.balancerStatusResponse = v5_0_0.BalancerStatus
+	mockClient.connPoolStatsResponse = "invalid"
+	ms = m.Collect()
+	assert.EqualValues(t, 1, ms["shard_balancer_enabled"])
+	assert.NotContains(t, ms, "shard_conn_pool_shard1_in_use")
+	assert.Contains(t, ms, "shard_nodes_count_aware")
+}
+
 func TestMongo_ShardUpdateNodeChart(t *testing.T) {
 	m := New()
 	mockClient := &mockMongo{
@@ -394,6 +612,7 @@ func TestMongo_ShardUpdateNodeChart(t *testing.T) {
 	m.mongoCollector = mockClient
 	m.Config.Databases.Includes = []string{"* *"}
 	m.URI = "mongodb://localhost"
+	m.ShardChunksEvery = web.Duration{}
 	require.True(t, m.Init())
 	_ = m.Collect()
 	assert.Len(t, m.charts.Get("shard_chucks_per_node").Dims, 2)
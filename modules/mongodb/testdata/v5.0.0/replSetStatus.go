@@ -62,3 +62,31 @@ const ReplSetGetStatusNode2 = `
   ]
 }
 `
+
+const ReplSetGetStatusLag = `
+{
+  "date": "2000-01-01T00:01:00.000Z",
+  "members": [
+    {
+      "name": "node1",
+      "state": 2,
+      "optimeDate": "2000-01-01T00:00:45.000Z",
+      "lastHeartbeat": "2000-01-01T00:01:00.000Z",
+      "lastHeartbeatRecv": "2000-01-01T00:01:00.000Z",
+      "pingMs": 0
+    },
+    {
+      "name": "node2",
+      "state": 1,
+      "optimeDate": "2000-01-01T00:00:55.000Z"
+    },
+    {
+      "name": "node3",
+      "state": 7,
+      "lastHeartbeat": "2000-01-01T00:01:00.000Z",
+      "lastHeartbeatRecv": "2000-01-01T00:01:00.000Z",
+      "pingMs": 0
+    }
+  ]
+}
+`
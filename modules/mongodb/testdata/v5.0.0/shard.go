@@ -40,5 +40,57 @@ var (
     "count": 2
   }
 ]
+`
+
+	ShardList = `
+[
+  {
+    "_id": "shard1",
+    "host": "shard1/localhost:27018,localhost:27019"
+  },
+  {
+    "_id": "shard2",
+    "host": "shard2/localhost:27020"
+  }
+]
+`
+
+	BalancerStatus = `
+{
+  "mode": "full",
+  "inBalancerRound": true,
+  "numBalancerRounds": 10,
+  "ok": 1
+}
+`
+
+	ConnPoolStats = `
+{
+  "numClientConnections": 0,
+  "totalInUse": 6,
+  "totalAvailable": 9,
+  "totalCreated": 21,
+  "totalRefreshing": 0,
+  "hosts": {
+    "localhost:27018": {
+      "inUse": 1,
+      "available": 2,
+      "created": 3,
+      "refreshing": 0
+    },
+    "localhost:27019": {
+      "inUse": 2,
+      "available": 3,
+      "created": 8,
+      "refreshing": 0
+    },
+    "localhost:27020": {
+      "inUse": 3,
+      "available": 4,
+      "created": 10,
+      "refreshing": 0
+    }
+  }
+}
 `
 )
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package v5_0_0
+
+var (
+	Top = `
+{
+  "totals": {
+    "note": "all times in microseconds",
+    "app.users": {
+      "total": {"time": 100000, "count": 100},
+      "readLock": {"time": 60000, "count": 80},
+      "writeLock": {"time": 40000, "count": 20},
+      "queries": {"time": 50000, "count": 70},
+      "getmore": {"time": 0, "count": 0},
+      "insert": {"time": 30000, "count": 15},
+      "update": {"time": 10000, "count": 5},
+      "remove": {"time": 0, "count": 0},
+      "commands": {"time": 10000, "count": 10}
+    },
+    "app.orders": {
+      "total": {"time": 50000, "count": 50},
+      "readLock": {"time": 50000, "count": 50},
+      "writeLock": {"time": 0, "count": 0},
+      "queries": {"time": 50000, "count": 50},
+      "getmore": {"time": 0, "count": 0},
+      "insert": {"time": 0, "count": 0},
+      "update": {"time": 0, "count": 0},
+      "remove": {"time": 0, "count": 0},
+      "commands": {"time": 0, "count": 0}
+    },
+    "admin.system.version": {
+      "total": {"time": 10, "count": 1},
+      "readLock": {"time": 10, "count": 1},
+      "writeLock": {"time": 0, "count": 0},
+      "queries": {"time": 10, "count": 1},
+      "getmore": {"time": 0, "count": 0},
+      "insert": {"time": 0, "count": 0},
+      "update": {"time": 0, "count": 0},
+      "remove": {"time": 0, "count": 0},
+      "commands": {"time": 0, "count": 0}
+    }
+  },
+  "ok": 1
+}
+`
+	TopNext = `
+{
+  "totals": {
+    "note": "all times in microseconds",
+    "app.users": {
+      "total": {"time": 1100000, "count": 1100},
+      "readLock": {"time": 660000, "count": 880},
+      "writeLock": {"time": 440000, "count": 220},
+      "queries": {"time": 550000, "count": 770},
+      "getmore": {"time": 0, "count": 0},
+      "insert": {"time": 330000, "count": 165},
+      "update": {"time": 110000, "count": 55},
+      "remove": {"time": 0, "count": 0},
+      "commands": {"time": 110000, "count": 110}
+    },
+    "app.orders": {
+      "total": {"time": 150000, "count": 150},
+      "readLock": {"time": 150000, "count": 150},
+      "writeLock": {"time": 0, "count": 0},
+      "queries": {"time": 150000, "count": 150},
+      "getmore": {"time": 0, "count": 0},
+      "insert": {"time": 0, "count": 0},
+      "update": {"time": 0, "count": 0},
+      "remove": {"time": 0, "count": 0},
+      "commands": {"time": 0, "count": 0}
+    },
+    "admin.system.version": {
+      "total": {"time": 20, "count": 2},
+      "readLock": {"time": 20, "count": 2},
+      "writeLock": {"time": 0, "count": 0},
+      "queries": {"time": 20, "count": 2},
+      "getmore": {"time": 0, "count": 0},
+      "insert": {"time": 0, "count": 0},
+      "update": {"time": 0, "count": 0},
+      "remove": {"time": 0, "count": 0},
+      "commands": {"time": 0, "count": 0}
+    }
+  },
+  "ok": 1
+}
+`
+)
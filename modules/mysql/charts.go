@@ -52,6 +52,8 @@ const (
 	prioProcessListFetchQueryDuration
 	prioProcessListQueries
 	prioProcessListLongestQueryDuration
+	prioQueryResponseTime
+	prioDatabasesSize
 	prioInnoDBDeadlocks
 	prioQCacheOperations
 	prioQCacheQueries
@@ -62,7 +64,10 @@ const (
 	prioGaleraQueue
 	prioGaleraConflicts
 	prioGaleraFlowControl
+	prioGaleraFlowControlMessages
+	prioGaleraCertDepsDistance
 	prioGaleraClusterStatus
+	prioGaleraNodeState
 	prioGaleraClusterState
 	prioGaleraClusterSize
 	prioGaleraClusterWeight
@@ -73,6 +78,7 @@ const (
 	prioSlaveSecondsBehindMaster
 	prioSlaveSQLIOThreadRunningState
 	prioUserStatsCPUTime
+	prioUserStatsBusyTime
 	prioUserStatsRows
 	prioUserStatsCommands
 	prioUserStatsDeniedCommands
@@ -688,7 +694,10 @@ var chartsGalera = module.Charts{
 	chartGaleraQueue.Copy(),
 	chartGaleraConflicts.Copy(),
 	chartGaleraFlowControl.Copy(),
+	chartGaleraFlowControlMessages.Copy(),
+	chartGaleraCertDepsDistance.Copy(),
 	chartGaleraClusterStatus.Copy(),
+	chartGaleraNodeState.Copy(),
 	chartGaleraClusterState.Copy(),
 	chartGaleraClusterSize.Copy(),
 	chartGaleraClusterWeight.Copy(),
@@ -760,6 +769,29 @@ var (
 			{ID: "wsrep_flow_control_paused_ns", Name: "paused", Algo: module.Incremental, Div: 1000000},
 		},
 	}
+	chartGaleraFlowControlMessages = module.Chart{
+		ID:       "galera_flow_control_messages",
+		Title:    "Flow Control Messages",
+		Units:    "messages/s",
+		Fam:      "galera",
+		Ctx:      "mysql.galera_flow_control_messages",
+		Priority: prioGaleraFlowControlMessages,
+		Dims: module.Dims{
+			{ID: "wsrep_flow_control_recv", Name: "received", Algo: module.Incremental},
+			{ID: "wsrep_flow_control_sent", Name: "sent", Algo: module.Incremental, Mul: -1},
+		},
+	}
+	chartGaleraCertDepsDistance = module.Chart{
+		ID:       "galera_cert_deps_distance",
+		Title:    "Average Distance Between Writesets That Can Be Applied In Parallel",
+		Units:    "writesets",
+		Fam:      "galera",
+		Ctx:      "mysql.galera_cert_deps_distance",
+		Priority: prioGaleraCertDepsDistance,
+		Dims: module.Dims{
+			{ID: "wsrep_cert_deps_distance", Name: "distance", Div: 1000},
+		},
+	}
 	chartGaleraClusterStatus = module.Chart{
 		ID:       "galera_cluster_status",
 		Title:    "Cluster Component Status",
@@ -773,6 +805,18 @@ var (
 			{ID: "wsrep_cluster_status_disconnected", Name: "disconnected"},
 		},
 	}
+	chartGaleraNodeState = module.Chart{
+		ID:       "galera_node_state",
+		Title:    "Node Cluster Status Code and Readiness",
+		Units:    "state",
+		Fam:      "galera",
+		Ctx:      "mysql.galera_node_state",
+		Priority: prioGaleraNodeState,
+		Dims: module.Dims{
+			{ID: "wsrep_cluster_status_code", Name: "cluster_status"},
+			{ID: "wsrep_ready", Name: "ready"},
+		},
+	}
 	chartGaleraClusterState = module.Chart{
 		ID:       "galera_cluster_state",
 		Title:    "Cluster Component State",
@@ -857,6 +901,26 @@ var (
 	}
 )
 
+var chartQueryResponseTime = module.Chart{
+	ID:       "query_response_time",
+	Title:    "Query Response Time Distribution",
+	Units:    "queries/s",
+	Fam:      "queries",
+	Ctx:      "mysql.query_response_time",
+	Type:     module.Stacked,
+	Priority: prioQueryResponseTime,
+}
+
+var chartDatabasesSize = module.Chart{
+	ID:       "databases_size",
+	Title:    "Databases Size (Data and Indexes)",
+	Units:    "B",
+	Fam:      "databases",
+	Ctx:      "mysql.databases_size",
+	Type:     module.Stacked,
+	Priority: prioDatabasesSize,
+}
+
 var chartsMyISAM = module.Charts{
 	chartMyISAMKeyCacheBlocks.Copy(),
 	chartMyISAMKeyCacheRequests.Copy(),
@@ -981,24 +1045,9 @@ func newSlaveReplConnCharts(conn string) *module.Charts {
 	return cs
 }
 
-func newMariaDBUserStatisticsCharts(user string) *module.Charts {
-	lcUser := strings.ToLower(user)
-	charts := chartsTmplUserStats.Copy()
-	for _, c := range *charts {
-		c.ID = fmt.Sprintf(c.ID, lcUser)
-		c.Labels = []module.Label{
-			{Key: "user", Value: user},
-		}
-		for _, d := range c.Dims {
-			d.ID = fmt.Sprintf(d.ID, lcUser)
-		}
-	}
-	return charts
-}
-
-func newPerconaUserStatisticsCharts(user string) *module.Charts {
+func newUserStatisticsCharts(tmpl module.Charts, user string) *module.Charts {
 	lcUser := strings.ToLower(user)
-	charts := chartsTmplPerconaUserStats.Copy()
+	charts := tmpl.Copy()
 	for _, c := range *charts {
 		c.ID = fmt.Sprintf(c.ID, lcUser)
 		c.Labels = []module.Label{
@@ -1036,6 +1085,11 @@ var (
 		chartTmplUserStatsLostConnections.Copy(),
 		chartTmplUserStatsDeniedConnections.Copy(),
 	}
+	chartsTmplPerfSchemaUserStats = module.Charts{
+		chartTmplUserStatsBusyTime.Copy(),
+		chartTmplPerfSchemaUserStatsRowsOperations.Copy(),
+		chartTmplUserStatsCreatedConnections.Copy(),
+	}
 
 	chartUserStatsCPU = module.Chart{
 		ID:       "userstats_cpu_%s",
@@ -1048,6 +1102,17 @@ var (
 			{ID: "userstats_%s_cpu_time", Name: "used", Mul: 100, Div: 1000, Algo: module.Incremental},
 		},
 	}
+	chartTmplUserStatsBusyTime = module.Chart{
+		ID:       "userstats_busy_time_%s",
+		Title:    "User Busy Time",
+		Units:    "percentage",
+		Fam:      "user busy time",
+		Ctx:      "mysql.userstats_busy_time",
+		Priority: prioUserStatsBusyTime,
+		Dims: module.Dims{
+			{ID: "userstats_%s_busy_time", Name: "busy", Mul: 100, Div: 1000, Algo: module.Incremental},
+		},
+	}
 	chartTmplUserStatsRowsOperations = module.Chart{
 		ID:       "userstats_rows_%s",
 		Title:    "User Rows Operations",
@@ -1077,6 +1142,19 @@ var (
 			{ID: "userstats_%s_rows_updated", Name: "updated", Algo: module.Incremental},
 		},
 	}
+	chartTmplPerfSchemaUserStatsRowsOperations = module.Chart{
+		ID:       "userstats_rows_%s",
+		Title:    "User Rows Operations",
+		Units:    "operations/s",
+		Fam:      "user operations",
+		Ctx:      "mysql.userstats_rows",
+		Type:     module.Stacked,
+		Priority: prioUserStatsRows,
+		Dims: module.Dims{
+			{ID: "userstats_%s_rows_read", Name: "read", Algo: module.Incremental},
+			{ID: "userstats_%s_rows_sent", Name: "sent", Algo: module.Incremental},
+		},
+	}
 	chartTmplUserStatsCommands = module.Chart{
 		ID:       "userstats_commands_%s",
 		Title:    "User Commands",
@@ -1184,16 +1262,105 @@ func (m *MySQL) addSlaveReplicationConnCharts(conn string) {
 	}
 }
 
+func (m *MySQL) removeSlaveReplicationConnCharts(conn string) {
+	var charts *module.Charts
+	if conn == "" {
+		charts = chartsSlaveReplication.Copy()
+	} else {
+		charts = newSlaveReplConnCharts(conn)
+	}
+	for _, c := range *charts {
+		if chart := m.Charts().Get(c.ID); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+}
+
 func (m *MySQL) addUserStatisticsCharts(user string) {
-	if m.isPercona {
-		if err := m.Charts().Add(*newPerconaUserStatisticsCharts(user)...); err != nil {
+	var tmpl module.Charts
+	switch {
+	case m.isPercona:
+		tmpl = chartsTmplPerconaUserStats
+	case m.userStatsFromPerfSchema:
+		tmpl = chartsTmplPerfSchemaUserStats
+	default:
+		tmpl = chartsTmplUserStats
+	}
+	// the USER_STATISTICS busy time is collected only if 'collect_user_statistics_extended' is enabled
+	if m.CollectUserStatisticsExtended && !m.userStatsFromPerfSchema {
+		tmpl = append(module.Charts{chartTmplUserStatsBusyTime.Copy()}, tmpl...)
+	}
+	charts := newUserStatisticsCharts(tmpl, user)
+	if err := m.Charts().Add(*charts...); err != nil {
+		m.Warning(err)
+	}
+}
+
+func (m *MySQL) removeUserStatisticsCharts(user string) {
+	for _, chart := range *m.Charts() {
+		if !strings.HasPrefix(chart.ID, "userstats_") {
+			continue
+		}
+		for _, l := range chart.Labels {
+			if l.Key == "user" && l.Value == user {
+				chart.MarkRemove()
+				chart.MarkNotCreated()
+			}
+		}
+	}
+}
+
+func (m *MySQL) addQueryResponseTimeDim(bucket string) {
+	chart := m.Charts().Get(chartQueryResponseTime.ID)
+	if chart == nil {
+		chart = chartQueryResponseTime.Copy()
+		if err := m.Charts().Add(chart); err != nil {
 			m.Warning(err)
+			return
 		}
-	} else {
-		if err := m.Charts().Add(*newMariaDBUserStatisticsCharts(user)...); err != nil {
+	}
+
+	name := bucket
+	if bucket == "inf" {
+		name = "+Inf"
+	}
+	dim := &module.Dim{ID: "query_response_time_bucket_" + bucket, Name: name, Algo: module.Incremental}
+	if err := chart.AddDim(dim); err != nil {
+		m.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (m *MySQL) addDatabaseSizeDim(db string) {
+	chart := m.Charts().Get(chartDatabasesSize.ID)
+	if chart == nil {
+		chart = chartDatabasesSize.Copy()
+		if err := m.Charts().Add(chart); err != nil {
 			m.Warning(err)
+			return
 		}
 	}
+
+	dim := &module.Dim{ID: "database_" + db + "_size", Name: db}
+	if err := chart.AddDim(dim); err != nil {
+		m.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (m *MySQL) removeDatabaseSizeDim(db string) {
+	chart := m.Charts().Get(chartDatabasesSize.ID)
+	if chart == nil {
+		return
+	}
+	if err := chart.MarkDimRemove("database_"+db+"_size", true); err != nil {
+		m.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
 }
 
 func (m *MySQL) addInnoDBOSLogCharts() {
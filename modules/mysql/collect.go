@@ -24,7 +24,14 @@ func (m *MySQL) collect() (map[string]int64, error) {
 			return nil, fmt.Errorf("error on collecting version: %v", err)
 		}
 		// https://mariadb.com/kb/en/user-statistics/
-		m.doUserStatistics = m.isPercona || m.isMariaDB && m.version.GTE(semver.Version{Major: 10, Minor: 1, Patch: 1})
+		// https://docs.percona.com/percona-server/8.0/diagnostics/user_stats.html
+		// MySQL has no USER_STATISTICS, the performance_schema account and statement summaries are used instead
+		// if 'collect_user_statistics_extended' is enabled.
+		m.userStatsFromPerfSchema = m.CollectUserStatisticsExtended && !m.isPercona && !m.isMariaDB &&
+			m.version.GTE(semver.Version{Major: 8})
+		m.doUserStatistics = m.isPercona ||
+			m.isMariaDB && m.version.GTE(semver.Version{Major: 10, Minor: 1, Patch: 1}) ||
+			m.userStatsFromPerfSchema
 	}
 
 	mx := make(map[string]int64)
@@ -82,6 +89,25 @@ func (m *MySQL) collect() (map[string]int64, error) {
 		}
 	}
 
+	if m.CollectQueryResponseTime && m.doQueryResponseTime {
+		if err := m.collectQueryResponseTime(mx); err != nil {
+			m.Errorf("error on collecting query response time: %v", err)
+		}
+	}
+
+	if m.CollectDatabasesSize {
+		if now.Sub(m.dbSizeLastTime) >= m.DatabasesSizeEvery.Duration {
+			m.dbSizeLastTime = now
+			if err := m.collectDatabasesSize(); err != nil {
+				m.Errorf("error on collecting databases size: %v", err)
+			}
+		}
+		// the sizes are queried every 'databases_size_every', the last values are reported in between
+		for db, size := range m.dbSizes {
+			mx["database_"+db+"_size"] = size
+		}
+	}
+
 	if err := m.collectProcessListStatistics(mx); err != nil {
 		m.Errorf("error on collecting process list statistics: %v", err)
 	}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mysql
+
+// Table Schema:
+// (MariaDB) https://mariadb.com/kb/en/information-schema-tables-table/
+// (MySql) https://dev.mysql.com/doc/refman/8.0/en/information-schema-tables-table.html
+const queryDatabasesSize = `
+SELECT
+  table_schema AS db,
+  SUM(data_length + index_length) AS size
+FROM
+  information_schema.tables
+GROUP BY
+  table_schema;`
+
+func (m *MySQL) collectDatabasesSize() error {
+	q := queryDatabasesSize
+	m.Debugf("executing query: '%s'", q)
+
+	sizes := make(map[string]int64)
+	var db string
+	_, err := m.collectQuery(q, func(column, value string, _ bool) {
+		switch column {
+		case "db":
+			db = value
+		case "size":
+			if m.dbSizeMatcher == nil || m.dbSizeMatcher.MatchString(db) {
+				sizes[db] = parseInt(value)
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for db := range sizes {
+		if _, ok := m.dbSizes[db]; !ok {
+			m.addDatabaseSizeDim(db)
+		}
+	}
+	for db := range m.dbSizes {
+		if _, ok := sizes[db]; !ok {
+			m.removeDatabaseSizeDim(db)
+		}
+	}
+	m.dbSizes = sizes
+
+	return nil
+}
@@ -45,6 +45,9 @@ func (m *MySQL) collectGlobalStatus(mx map[string]int64) error {
 				mx[name+"_primary"] = boolToInt(value == "PRIMARY")
 				mx[name+"_non_primary"] = boolToInt(value == "NON-PRIMARY")
 				mx[name+"_disconnected"] = boolToInt(value == "DISCONNECTED")
+				mx[name+"_code"] = parseInt(convertWsrepClusterStatus(value))
+			case "wsrep_cert_deps_distance":
+				mx[name] = int64(parseFloat(value) * 1000)
 			default:
 				mx[strings.ToLower(name)] = parseInt(value)
 			}
@@ -77,6 +80,20 @@ func convertWsrepReady(val string) string {
 	}
 }
 
+func convertWsrepClusterStatus(val string) string {
+	// https://github.com/codership/wsrep-API/blob/eab2d5d5a31672c0b7d116ef1629ff18392fd7d0/wsrep_api.h (wsrep_view_status_t)
+	switch val {
+	case "PRIMARY":
+		return "0"
+	case "NON-PRIMARY":
+		return "1"
+	case "DISCONNECTED":
+		return "2"
+	default:
+		return "-1"
+	}
+}
+
 func boolToInt(v bool) int64 {
 	if v {
 		return 1
@@ -205,6 +222,9 @@ var globalStatusKeys = map[string]bool{
 	"wsrep_local_bf_aborts":                 true,
 	"wsrep_local_cert_failures":             true,
 	"wsrep_flow_control_paused_ns":          true,
+	"wsrep_flow_control_sent":               true,
+	"wsrep_flow_control_recv":               true,
+	"wsrep_cert_deps_distance":              true,
 	"wsrep_cluster_weight":                  true,
 	"wsrep_cluster_size":                    true,
 	"wsrep_local_state":                     true,
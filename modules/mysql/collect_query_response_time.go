@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mysql
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Table Schema:
+// (MariaDB) https://mariadb.com/kb/en/information-schema-query_response_time-table/
+// (Percona) https://docs.percona.com/percona-server/5.7/diagnostics/response_time_distribution.html
+// TIME is the upper bound of the bucket in seconds (the last bucket is 'TOO LONG'), COUNT is the number of queries
+// in the bucket (not cumulative).
+const queryQueryResponseTime = "SELECT TIME, COUNT FROM INFORMATION_SCHEMA.QUERY_RESPONSE_TIME;"
+
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html#error_er_unknown_table
+const errCodeUnknownTable = 1109
+
+func (m *MySQL) collectQueryResponseTime(mx map[string]int64) error {
+	q := queryQueryResponseTime
+	m.Debugf("executing query: '%s'", q)
+
+	var bucket string
+	counts := make(map[string]int64)
+	var buckets []string
+
+	_, err := m.collectQuery(q, func(column, value string, _ bool) {
+		switch column {
+		case "TIME":
+			bucket = queryResponseTimeBucket(value)
+			buckets = append(buckets, bucket)
+		case "COUNT":
+			counts[bucket] = parseInt(value)
+		}
+	})
+	if err != nil {
+		var myErr *mysql.MySQLError
+		if errors.As(err, &myErr) && myErr.Number == errCodeUnknownTable {
+			m.Warning("INFORMATION_SCHEMA.QUERY_RESPONSE_TIME table not found (query_response_time plugin is not installed), " +
+				"disabling query response time collection")
+			m.doQueryResponseTime = false
+			return nil
+		}
+		return err
+	}
+
+	// FLUSH QUERY_RESPONSE_TIME (or query_response_time_flush) resets the counts, the charted values must not decrease.
+	var flushed bool
+	for b, v := range counts {
+		if v < m.qrtLast[b] {
+			flushed = true
+			break
+		}
+	}
+
+	for _, b := range buckets {
+		v := counts[b]
+		if flushed {
+			m.qrtTotal[b] += v
+		} else {
+			m.qrtTotal[b] += v - m.qrtLast[b]
+		}
+		m.qrtLast[b] = v
+
+		if !m.collectedQRTBuckets[b] {
+			m.collectedQRTBuckets[b] = true
+			m.addQueryResponseTimeDim(b)
+		}
+		mx["query_response_time_bucket_"+b] = m.qrtTotal[b]
+	}
+
+	return nil
+}
+
+// queryResponseTimeBucket converts the TIME column value ('      0.000001', 'TOO LONG') to the bucket name.
+func queryResponseTimeBucket(value string) string {
+	value = strings.TrimSpace(value)
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return "inf"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -10,16 +10,22 @@ import (
 
 const (
 	queryShowSlaveStatus     = "SHOW SLAVE STATUS;"
+	queryShowReplicaStatus   = "SHOW REPLICA STATUS;"
 	queryShowAllSlavesStatus = "SHOW ALL SLAVES STATUS;"
 )
 
 func (m *MySQL) collectSlaveStatus(mx map[string]int64) error {
 	// https://mariadb.com/docs/reference/es/sql-statements/SHOW_ALL_SLAVES_STATUS/
 	mariaDBMinVer := semver.Version{Major: 10, Minor: 2, Patch: 0}
+	// https://dev.mysql.com/doc/refman/8.0/en/show-replica-status.html (SHOW SLAVE STATUS is removed in 8.4)
+	mysqlMinVer := semver.Version{Major: 8, Minor: 0, Patch: 22}
 	var q string
-	if m.isMariaDB && m.version.GTE(mariaDBMinVer) {
+	switch {
+	case m.isMariaDB && m.version.GTE(mariaDBMinVer):
 		q = queryShowAllSlavesStatus
-	} else {
+	case !m.isMariaDB && m.version.GTE(mysqlMinVer):
+		q = queryShowReplicaStatus
+	default:
 		q = queryShowSlaveStatus
 	}
 	m.Debugf("executing query: '%s'", q)
@@ -31,18 +37,21 @@ func (m *MySQL) collectSlaveStatus(mx map[string]int64) error {
 		ioRunning    int64
 	}{}
 
+	seen := make(map[string]bool)
+
 	_, err := m.collectQuery(q, func(column, value string, lineEnd bool) {
 		switch column {
 		case "Connection_name", "Channel_Name":
 			v.name = value
-		case "Seconds_Behind_Master":
+		case "Seconds_Behind_Master", "Seconds_Behind_Source":
 			v.behindMaster = parseInt(value)
-		case "Slave_SQL_Running":
+		case "Slave_SQL_Running", "Replica_SQL_Running":
 			v.sqlRunning = parseInt(convertSlaveSQLRunning(value))
-		case "Slave_IO_Running":
+		case "Slave_IO_Running", "Replica_IO_Running":
 			v.ioRunning = parseInt(convertSlaveIORunning(value))
 		}
 		if lineEnd {
+			seen[v.name] = true
 			if !m.collectedReplConns[v.name] {
 				m.collectedReplConns[v.name] = true
 				m.addSlaveReplicationConnCharts(v.name)
@@ -53,7 +62,17 @@ func (m *MySQL) collectSlaveStatus(mx map[string]int64) error {
 			mx["slave_io_running"+s] = v.ioRunning
 		}
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	for name := range m.collectedReplConns {
+		if !seen[name] {
+			delete(m.collectedReplConns, name)
+			m.removeSlaveReplicationConnCharts(name)
+		}
+	}
+	return nil
 }
 
 func convertSlaveSQLRunning(value string) string {
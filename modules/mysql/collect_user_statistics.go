@@ -8,24 +8,80 @@ import (
 
 const queryShowUserStatistics = "SHOW USER_STATISTICS;"
 
+// Table Schema:
+// (MySQL) https://dev.mysql.com/doc/refman/8.0/en/performance-schema-accounts-table.html
+// (MySQL) https://dev.mysql.com/doc/refman/8.0/en/performance-schema-statement-summary-tables.html
+// The column names match the USER_STATISTICS ones, TIMER_WAIT is in picoseconds.
+const queryUserStatisticsPerfSchema = `
+SELECT 
+  a.USER AS User, 
+  a.TOTAL_CONNECTIONS AS Total_connections, 
+  s.ROWS_EXAMINED AS Rows_read, 
+  s.ROWS_SENT AS Rows_sent, 
+  s.TIMER_WAIT / 1000000000000 AS Busy_time 
+FROM 
+  (
+    SELECT 
+      USER, 
+      SUM(TOTAL_CONNECTIONS) AS TOTAL_CONNECTIONS 
+    FROM 
+      performance_schema.accounts 
+    WHERE 
+      USER IS NOT NULL 
+    GROUP BY 
+      USER
+  ) a 
+  LEFT JOIN (
+    SELECT 
+      USER, 
+      SUM(SUM_ROWS_EXAMINED) AS ROWS_EXAMINED, 
+      SUM(SUM_ROWS_SENT) AS ROWS_SENT, 
+      SUM(SUM_TIMER_WAIT) AS TIMER_WAIT 
+    FROM 
+      performance_schema.events_statements_summary_by_user_by_event_name 
+    WHERE 
+      USER IS NOT NULL 
+    GROUP BY 
+      USER
+  ) s ON s.USER = a.USER;`
+
 func (m *MySQL) collectUserStatistics(mx map[string]int64) error {
 	// https://mariadb.com/kb/en/user-statistics/
 	// https://mariadb.com/kb/en/information-schema-user_statistics-table/
 	q := queryShowUserStatistics
+	if m.userStatsFromPerfSchema {
+		q = queryUserStatisticsPerfSchema
+	}
 	m.Debugf("executing query: '%s'", q)
 
+	seen := make(map[string]bool)
 	var user, prefix string
+	var skip bool
 	_, err := m.collectQuery(q, func(column, value string, _ bool) {
-		switch column {
-		case "User":
+		if column == "User" {
 			user = value
 			prefix = "userstats_" + user + "_"
+			if skip = m.userStatsMatcher != nil && !m.userStatsMatcher.MatchString(user); skip {
+				return
+			}
+			seen[user] = true
 			if !m.collectedUsers[user] {
 				m.collectedUsers[user] = true
 				m.addUserStatisticsCharts(user)
 			}
+			return
+		}
+		if skip {
+			return
+		}
+
+		switch column {
 		case "Cpu_time":
 			mx[strings.ToLower(prefix+column)] = int64(parseFloat(value) * 1000)
+		case "Busy_time":
+			if m.CollectUserStatisticsExtended {
+				mx[strings.ToLower(prefix+column)] = int64(parseFloat(value) * 1000)
+			}
 		case
 			"Total_connections",
 			"Lost_connections",
@@ -47,5 +103,16 @@ func (m *MySQL) collectUserStatistics(mx map[string]int64) error {
 			mx[strings.ToLower(prefix+column)] = parseInt(value)
 		}
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	for user := range m.collectedUsers {
+		if !seen[user] {
+			delete(m.collectedUsers, user)
+			m.removeUserStatisticsCharts(user)
+		}
+	}
+
+	return nil
 }
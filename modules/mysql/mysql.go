@@ -13,6 +13,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
@@ -25,8 +26,9 @@ func init() {
 func New() *MySQL {
 	return &MySQL{
 		Config: Config{
-			DSN:     "root@tcp(localhost:3306)/",
-			Timeout: web.Duration{Duration: time.Second},
+			DSN:                "root@tcp(localhost:3306)/",
+			Timeout:            web.Duration{Duration: time.Second},
+			DatabasesSizeEvery: web.Duration{Duration: time.Minute * 5},
 		},
 
 		charts:                         baseCharts.Copy(),
@@ -39,8 +41,13 @@ func New() *MySQL {
 		addTableOpenCacheOverflowsOnce: &sync.Once{},
 		doSlaveStatus:                  true,
 		doUserStatistics:               true,
+		doQueryResponseTime:            true,
 		collectedReplConns:             make(map[string]bool),
 		collectedUsers:                 make(map[string]bool),
+		collectedQRTBuckets:            make(map[string]bool),
+		qrtLast:                        make(map[string]int64),
+		qrtTotal:                       make(map[string]int64),
+		dbSizes:                        make(map[string]int64),
 
 		recheckGlobalVarsEvery: time.Minute * 10,
 	}
@@ -51,6 +58,15 @@ type Config struct {
 	MyCNF       string       `yaml:"my.cnf"`
 	UpdateEvery int          `yaml:"update_every"`
 	Timeout     web.Duration `yaml:"timeout"`
+
+	CollectUserStatisticsExtended bool               `yaml:"collect_user_statistics_extended"`
+	UserStatisticsUsers           matcher.SimpleExpr `yaml:"user_statistics_users"`
+
+	CollectQueryResponseTime bool `yaml:"collect_query_response_time"`
+
+	CollectDatabasesSize   bool               `yaml:"collect_databases_size"`
+	DatabasesSizeEvery     web.Duration       `yaml:"databases_size_every"`
+	DatabasesSizeDatabases matcher.SimpleExpr `yaml:"databases_size_databases"`
 }
 
 type MySQL struct {
@@ -77,6 +93,19 @@ type MySQL struct {
 	collectedReplConns map[string]bool
 	doUserStatistics   bool
 	collectedUsers     map[string]bool
+	// userStatsFromPerfSchema is set if the user statistics come from the performance_schema (MySQL).
+	userStatsFromPerfSchema bool
+	userStatsMatcher        matcher.Matcher
+
+	doQueryResponseTime bool
+	collectedQRTBuckets map[string]bool
+	// qrtLast are the bucket counts of the last query, qrtTotal are the charted (never decreasing) counts.
+	qrtLast  map[string]int64
+	qrtTotal map[string]int64
+
+	dbSizeMatcher  matcher.Matcher
+	dbSizes        map[string]int64
+	dbSizeLastTime time.Time
 
 	recheckGlobalVarsTime    time.Time
 	recheckGlobalVarsEvery   time.Duration
@@ -110,6 +139,24 @@ func (m *MySQL) Init() bool {
 	cfg.Passwd = strings.Repeat("*", len(cfg.Passwd))
 	m.safeDSN = cfg.FormatDSN()
 
+	if !m.UserStatisticsUsers.Empty() {
+		mr, err := m.UserStatisticsUsers.Parse()
+		if err != nil {
+			m.Errorf("error on creating 'user_statistics_users' matcher: %v", err)
+			return false
+		}
+		m.userStatsMatcher = matcher.WithCache(mr)
+	}
+
+	if !m.DatabasesSizeDatabases.Empty() {
+		mr, err := m.DatabasesSizeDatabases.Parse()
+		if err != nil {
+			m.Errorf("error on creating 'databases_size_databases' matcher: %v", err)
+			return false
+		}
+		m.dbSizeMatcher = matcher.WithCache(mr)
+	}
+
 	m.Debugf("using DSN [%s]", m.DSN)
 	return true
 }
@@ -13,19 +13,23 @@ import (
 	"testing"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/blang/semver/v4"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 var (
-	dataMySQLV8030Version, _                = os.ReadFile("testdata/mysql/v8.0.30/version.txt")
-	dataMySQLV8030GlobalStatus, _           = os.ReadFile("testdata/mysql/v8.0.30/global_status.txt")
-	dataMySQLV8030GlobalVariables, _        = os.ReadFile("testdata/mysql/v8.0.30/global_variables.txt")
-	dataMySQLV8030SlaveStatusMultiSource, _ = os.ReadFile("testdata/mysql/v8.0.30/slave_status_multi_source.txt")
-	dataMySQLV8030ProcessList, _            = os.ReadFile("testdata/mysql/v8.0.30/process_list.txt")
+	dataMySQLV8030Version, _                  = os.ReadFile("testdata/mysql/v8.0.30/version.txt")
+	dataMySQLV8030GlobalStatus, _             = os.ReadFile("testdata/mysql/v8.0.30/global_status.txt")
+	dataMySQLV8030GlobalVariables, _          = os.ReadFile("testdata/mysql/v8.0.30/global_variables.txt")
+	dataMySQLV8030ReplicaStatusMultiSource, _ = os.ReadFile("testdata/mysql/v8.0.30/replica_status_multi_source.txt")
+	dataMySQLV8030ProcessList, _              = os.ReadFile("testdata/mysql/v8.0.30/process_list.txt")
+	dataMySQLV8030UserStatistics, _           = os.ReadFile("testdata/mysql/v8.0.30/user_statistics.txt")
+	dataMySQLV8030DatabasesSize, _            = os.ReadFile("testdata/mysql/v8.0.30/databases_size.txt")
 
 	dataPerconaV8029Version, _         = os.ReadFile("testdata/percona/v8.0.29/version.txt")
 	dataPerconaV8029GlobalStatus, _    = os.ReadFile("testdata/percona/v8.0.29/global_status.txt")
@@ -33,6 +37,12 @@ var (
 	dataPerconaV8029UserStatistics, _  = os.ReadFile("testdata/percona/v8.0.29/user_statistics.txt")
 	dataPerconaV8029ProcessList, _     = os.ReadFile("testdata/percona/v8.0.29/process_list.txt")
 
+	dataPerconaPXCV8029Version, _         = os.ReadFile("testdata/percona/v8.0.29-pxc-cluster/version.txt")
+	dataPerconaPXCV8029GlobalStatus, _    = os.ReadFile("testdata/percona/v8.0.29-pxc-cluster/global_status.txt")
+	dataPerconaPXCV8029GlobalVariables, _ = os.ReadFile("testdata/percona/v8.0.29-pxc-cluster/global_variables.txt")
+	dataPerconaPXCV8029UserStatistics, _  = os.ReadFile("testdata/percona/v8.0.29-pxc-cluster/user_statistics.txt")
+	dataPerconaPXCV8029ProcessList, _     = os.ReadFile("testdata/percona/v8.0.29-pxc-cluster/process_list.txt")
+
 	dataMariaV5564Version, _         = os.ReadFile("testdata/mariadb/v5.5.64/version.txt")
 	dataMariaV5564GlobalStatus, _    = os.ReadFile("testdata/mariadb/v5.5.64/global_status.txt")
 	dataMariaV5564GlobalVariables, _ = os.ReadFile("testdata/mariadb/v5.5.64/global_variables.txt")
@@ -45,6 +55,7 @@ var (
 	dataMariaV1084AllSlavesStatusMultiSource, _  = os.ReadFile("testdata/mariadb/v10.8.4/all_slaves_status_multi_source.txt")
 	dataMariaV1084UserStatistics, _              = os.ReadFile("testdata/mariadb/v10.8.4/user_statistics.txt")
 	dataMariaV1084ProcessList, _                 = os.ReadFile("testdata/mariadb/v10.8.4/process_list.txt")
+	dataMariaV1084QueryResponseTime, _           = os.ReadFile("testdata/mariadb/v10.8.4/query_response_time.txt")
 
 	dataMariaGaleraClusterV1084Version, _         = os.ReadFile("testdata/mariadb/v10.8.4-galera-cluster/version.txt")
 	dataMariaGaleraClusterV1084GlobalStatus, _    = os.ReadFile("testdata/mariadb/v10.8.4-galera-cluster/global_status.txt")
@@ -55,17 +66,24 @@ var (
 
 func Test_testDataIsValid(t *testing.T) {
 	for name, data := range map[string][]byte{
-		"dataMySQLV8030Version":                dataMySQLV8030Version,
-		"dataMySQLV8030GlobalStatus":           dataMySQLV8030GlobalStatus,
-		"dataMySQLV8030GlobalVariables":        dataMySQLV8030GlobalVariables,
-		"dataMySQLV8030SlaveStatusMultiSource": dataMySQLV8030SlaveStatusMultiSource,
-		"dataMySQLV8030ProcessList":            dataMySQLV8030ProcessList,
-
-		"dataPerconaV8029Version":         dataPerconaV8029Version,
-		"dataPerconaV8029GlobalStatus":    dataPerconaV8029GlobalStatus,
-		"dataPerconaV8029GlobalVariables": dataPerconaV8029GlobalVariables,
-		"dataPerconaV8029UserStatistics":  dataPerconaV8029UserStatistics,
-		"dataPerconaV8029ProcessList":     dataPerconaV8029ProcessList,
+		"dataMySQLV8030Version":                  dataMySQLV8030Version,
+		"dataMySQLV8030GlobalStatus":             dataMySQLV8030GlobalStatus,
+		"dataMySQLV8030GlobalVariables":          dataMySQLV8030GlobalVariables,
+		"dataMySQLV8030ReplicaStatusMultiSource": dataMySQLV8030ReplicaStatusMultiSource,
+		"dataMySQLV8030ProcessList":              dataMySQLV8030ProcessList,
+		"dataMySQLV8030UserStatistics":           dataMySQLV8030UserStatistics,
+		"dataMySQLV8030DatabasesSize":            dataMySQLV8030DatabasesSize,
+
+		"dataPerconaV8029Version":            dataPerconaV8029Version,
+		"dataPerconaV8029GlobalStatus":       dataPerconaV8029GlobalStatus,
+		"dataPerconaV8029GlobalVariables":    dataPerconaV8029GlobalVariables,
+		"dataPerconaV8029UserStatistics":     dataPerconaV8029UserStatistics,
+		"dataPerconaV8029ProcessList":        dataPerconaV8029ProcessList,
+		"dataPerconaPXCV8029Version":         dataPerconaPXCV8029Version,
+		"dataPerconaPXCV8029GlobalStatus":    dataPerconaPXCV8029GlobalStatus,
+		"dataPerconaPXCV8029GlobalVariables": dataPerconaPXCV8029GlobalVariables,
+		"dataPerconaPXCV8029UserStatistics":  dataPerconaPXCV8029UserStatistics,
+		"dataPerconaPXCV8029ProcessList":     dataPerconaPXCV8029ProcessList,
 
 		"dataMariaV5564Version":         dataMariaV5564Version,
 		"dataMariaV5564GlobalStatus":    dataMariaV5564GlobalStatus,
@@ -79,6 +97,7 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataMariaV1084AllSlavesStatusMultiSource":  dataMariaV1084AllSlavesStatusMultiSource,
 		"dataMariaV1084UserStatistics":              dataMariaV1084UserStatistics,
 		"dataMariaV1084ProcessList":                 dataMariaV1084ProcessList,
+		"dataMariaV1084QueryResponseTime":           dataMariaV1084QueryResponseTime,
 
 		"dataMariaGaleraClusterV1084Version":         dataMariaGaleraClusterV1084Version,
 		"dataMariaGaleraClusterV1084GlobalStatus":    dataMariaGaleraClusterV1084GlobalStatus,
@@ -550,6 +569,7 @@ func TestMySQL_Collect(t *testing.T) {
 						"userstats_root_total_connections":        1,
 						"userstats_root_update_commands":          0,
 						"wsrep_cluster_size":                      0,
+						"wsrep_cluster_status_code":               2,
 						"wsrep_cluster_status_disconnected":       1,
 						"wsrep_cluster_status_non_primary":        0,
 						"wsrep_cluster_status_primary":            0,
@@ -731,6 +751,7 @@ func TestMySQL_Collect(t *testing.T) {
 						"userstats_root_total_connections":        1,
 						"userstats_root_update_commands":          0,
 						"wsrep_cluster_size":                      0,
+						"wsrep_cluster_status_code":               2,
 						"wsrep_cluster_status_disconnected":       1,
 						"wsrep_cluster_status_non_primary":        0,
 						"wsrep_cluster_status_primary":            0,
@@ -915,6 +936,7 @@ func TestMySQL_Collect(t *testing.T) {
 						"userstats_root_total_connections":        1,
 						"userstats_root_update_commands":          0,
 						"wsrep_cluster_size":                      0,
+						"wsrep_cluster_status_code":               2,
 						"wsrep_cluster_status_disconnected":       1,
 						"wsrep_cluster_status_non_primary":        0,
 						"wsrep_cluster_status_primary":            0,
@@ -1093,6 +1115,7 @@ func TestMySQL_Collect(t *testing.T) {
 						"userstats_root_total_connections":        1,
 						"userstats_root_update_commands":          0,
 						"wsrep_cluster_size":                      0,
+						"wsrep_cluster_status_code":               2,
 						"wsrep_cluster_status_disconnected":       1,
 						"wsrep_cluster_status_non_primary":        0,
 						"wsrep_cluster_status_primary":            0,
@@ -1270,13 +1293,17 @@ func TestMySQL_Collect(t *testing.T) {
 						"userstats_root_select_commands":          0,
 						"userstats_root_total_connections":        1,
 						"userstats_root_update_commands":          0,
+						"wsrep_cert_deps_distance":                1000,
 						"wsrep_cluster_size":                      3,
+						"wsrep_cluster_status_code":               0,
 						"wsrep_cluster_status_disconnected":       0,
 						"wsrep_cluster_status_non_primary":        0,
 						"wsrep_cluster_status_primary":            1,
 						"wsrep_cluster_weight":                    3,
 						"wsrep_connected":                         1,
 						"wsrep_flow_control_paused_ns":            0,
+						"wsrep_flow_control_recv":                 0,
+						"wsrep_flow_control_sent":                 0,
 						"wsrep_local_bf_aborts":                   0,
 						"wsrep_local_cert_failures":               0,
 						"wsrep_local_recv_queue":                  0,
@@ -1308,7 +1335,7 @@ func TestMySQL_Collect(t *testing.T) {
 					mockExpect(t, m, queryShowVersion, dataMySQLV8030Version)
 					mockExpect(t, m, queryShowGlobalStatus, dataMySQLV8030GlobalStatus)
 					mockExpect(t, m, queryShowGlobalVariables, dataMySQLV8030GlobalVariables)
-					mockExpect(t, m, queryShowSlaveStatus, dataMySQLV8030SlaveStatusMultiSource)
+					mockExpect(t, m, queryShowReplicaStatus, dataMySQLV8030ReplicaStatusMultiSource)
 					mockExpect(t, m, queryShowProcessList, dataMySQLV8030ProcessList)
 				},
 				check: func(t *testing.T, my *MySQL) {
@@ -1443,7 +1470,7 @@ func TestMySQL_Collect(t *testing.T) {
 					mockExpect(t, m, queryShowVersion, dataPerconaV8029Version)
 					mockExpect(t, m, queryShowGlobalStatus, dataPerconaV8029GlobalStatus)
 					mockExpect(t, m, queryShowGlobalVariables, dataPerconaV8029GlobalVariables)
-					mockExpect(t, m, queryShowSlaveStatus, nil)
+					mockExpect(t, m, queryShowReplicaStatus, nil)
 					mockExpect(t, m, queryShowUserStatistics, dataPerconaV8029UserStatistics)
 					mockExpect(t, m, queryShowProcessList, dataPerconaV8029ProcessList)
 				},
@@ -1589,6 +1616,192 @@ func TestMySQL_Collect(t *testing.T) {
 						"userstats_root_update_commands":          0,
 					}
 
+					copyProcessListQueryDuration(mx, expected)
+					require.Equal(t, expected, mx)
+					ensureCollectedHasAllChartsDimsVarsIDs(t, my, mx)
+				},
+			},
+		},
+		"Percona-XtraDBCluster[v8.0.29]: success on all queries": {
+			{
+				prepareMock: func(t *testing.T, m sqlmock.Sqlmock) {
+					mockExpect(t, m, queryShowVersion, dataPerconaPXCV8029Version)
+					mockExpect(t, m, queryShowGlobalStatus, dataPerconaPXCV8029GlobalStatus)
+					mockExpect(t, m, queryShowGlobalVariables, dataPerconaPXCV8029GlobalVariables)
+					mockExpect(t, m, queryShowReplicaStatus, nil)
+					mockExpect(t, m, queryShowUserStatistics, dataPerconaPXCV8029UserStatistics)
+					mockExpect(t, m, queryShowProcessList, dataPerconaPXCV8029ProcessList)
+				},
+				check: func(t *testing.T, my *MySQL) {
+					mx := my.Collect()
+
+					expected := map[string]int64{
+						"aborted_connects":                        1,
+						"binlog_cache_disk_use":                   0,
+						"binlog_cache_use":                        0,
+						"binlog_stmt_cache_disk_use":              0,
+						"binlog_stmt_cache_use":                   0,
+						"bytes_received":                          682970,
+						"bytes_sent":                              33668405,
+						"com_delete":                              0,
+						"com_insert":                              0,
+						"com_replace":                             0,
+						"com_select":                              1687,
+						"com_update":                              0,
+						"connection_errors_accept":                0,
+						"connection_errors_internal":              0,
+						"connection_errors_max_connections":       0,
+						"connection_errors_peer_address":          0,
+						"connection_errors_select":                0,
+						"connection_errors_tcpwrap":               0,
+						"connections":                             13,
+						"created_tmp_disk_tables":                 1683,
+						"created_tmp_files":                       5,
+						"created_tmp_tables":                      5054,
+						"handler_commit":                          576,
+						"handler_delete":                          0,
+						"handler_prepare":                         0,
+						"handler_read_first":                      1724,
+						"handler_read_key":                        3439,
+						"handler_read_next":                       4147,
+						"handler_read_prev":                       0,
+						"handler_read_rnd":                        0,
+						"handler_read_rnd_next":                   2983285,
+						"handler_rollback":                        0,
+						"handler_savepoint":                       0,
+						"handler_savepoint_rollback":              0,
+						"handler_update":                          317,
+						"handler_write":                           906501,
+						"innodb_buffer_pool_bytes_data":           18399232,
+						"innodb_buffer_pool_bytes_dirty":          49152,
+						"innodb_buffer_pool_pages_data":           1123,
+						"innodb_buffer_pool_pages_dirty":          3,
+						"innodb_buffer_pool_pages_flushed":        205,
+						"innodb_buffer_pool_pages_free":           7064,
+						"innodb_buffer_pool_pages_misc":           5,
+						"innodb_buffer_pool_pages_total":          8192,
+						"innodb_buffer_pool_read_ahead":           0,
+						"innodb_buffer_pool_read_ahead_evicted":   0,
+						"innodb_buffer_pool_read_ahead_rnd":       0,
+						"innodb_buffer_pool_read_requests":        109817,
+						"innodb_buffer_pool_reads":                978,
+						"innodb_buffer_pool_wait_free":            0,
+						"innodb_buffer_pool_write_requests":       77412,
+						"innodb_data_fsyncs":                      50,
+						"innodb_data_pending_fsyncs":              0,
+						"innodb_data_pending_reads":               0,
+						"innodb_data_pending_writes":              0,
+						"innodb_data_read":                        16094208,
+						"innodb_data_reads":                       1002,
+						"innodb_data_writes":                      288,
+						"innodb_data_written":                     3420160,
+						"innodb_log_waits":                        0,
+						"innodb_log_write_requests":               651,
+						"innodb_log_writes":                       47,
+						"innodb_os_log_fsyncs":                    13,
+						"innodb_os_log_pending_fsyncs":            0,
+						"innodb_os_log_pending_writes":            0,
+						"innodb_os_log_written":                   45568,
+						"innodb_row_lock_current_waits":           0,
+						"innodb_rows_deleted":                     0,
+						"innodb_rows_inserted":                    5055,
+						"innodb_rows_read":                        5055,
+						"innodb_rows_updated":                     0,
+						"key_blocks_not_flushed":                  0,
+						"key_blocks_unused":                       6698,
+						"key_blocks_used":                         0,
+						"key_read_requests":                       0,
+						"key_reads":                               0,
+						"key_write_requests":                      0,
+						"key_writes":                              0,
+						"max_connections":                         151,
+						"max_used_connections":                    3,
+						"open_files":                              2,
+						"open_tables":                             77,
+						"opened_files":                            2,
+						"opened_tables":                           158,
+						"process_list_fetch_query_duration":       0,
+						"process_list_longest_query_duration":     9,
+						"process_list_queries_count_system":       0,
+						"process_list_queries_count_user":         2,
+						"queries":                                 6748,
+						"questions":                               6746,
+						"select_full_join":                        0,
+						"select_full_range_join":                  0,
+						"select_range":                            0,
+						"select_range_check":                      0,
+						"select_scan":                             8425,
+						"slow_queries":                            0,
+						"sort_merge_passes":                       0,
+						"sort_range":                              0,
+						"sort_scan":                               1681,
+						"table_locks_immediate":                   3371,
+						"table_locks_waited":                      0,
+						"table_open_cache":                        4000,
+						"table_open_cache_overflows":              0,
+						"thread_cache_misses":                     2307,
+						"threads_cached":                          1,
+						"threads_connected":                       2,
+						"threads_created":                         3,
+						"threads_running":                         2,
+						"userstats_netdata_access_denied":         0,
+						"userstats_netdata_binlog_bytes_written":  0,
+						"userstats_netdata_commit_transactions":   0,
+						"userstats_netdata_cpu_time":              0,
+						"userstats_netdata_denied_connections":    0,
+						"userstats_netdata_empty_queries":         0,
+						"userstats_netdata_lost_connections":      0,
+						"userstats_netdata_other_commands":        1,
+						"userstats_netdata_rollback_transactions": 0,
+						"userstats_netdata_rows_fetched":          1,
+						"userstats_netdata_rows_updated":          0,
+						"userstats_netdata_select_commands":       1,
+						"userstats_netdata_total_connections":     1,
+						"userstats_netdata_update_commands":       0,
+						"userstats_root_access_denied":            0,
+						"userstats_root_binlog_bytes_written":     0,
+						"userstats_root_commit_transactions":      0,
+						"userstats_root_cpu_time":                 151,
+						"userstats_root_denied_connections":       1,
+						"userstats_root_empty_queries":            36,
+						"userstats_root_lost_connections":         0,
+						"userstats_root_other_commands":           110,
+						"userstats_root_rollback_transactions":    0,
+						"userstats_root_rows_fetched":             1,
+						"userstats_root_rows_updated":             0,
+						"userstats_root_select_commands":          37,
+						"userstats_root_total_connections":        2,
+						"userstats_root_update_commands":          0,
+						"wsrep_cert_deps_distance":                23564,
+						"wsrep_cluster_size":                      3,
+						"wsrep_cluster_status_code":               0,
+						"wsrep_cluster_status_disconnected":       0,
+						"wsrep_cluster_status_non_primary":        0,
+						"wsrep_cluster_status_primary":            1,
+						"wsrep_cluster_weight":                    3,
+						"wsrep_connected":                         1,
+						"wsrep_flow_control_paused_ns":            1519317456,
+						"wsrep_flow_control_recv":                 23,
+						"wsrep_flow_control_sent":                 7,
+						"wsrep_local_bf_aborts":                   2,
+						"wsrep_local_cert_failures":               3,
+						"wsrep_local_recv_queue":                  2,
+						"wsrep_local_send_queue":                  0,
+						"wsrep_local_state_donor":                 0,
+						"wsrep_local_state_error":                 0,
+						"wsrep_local_state_joined":                0,
+						"wsrep_local_state_joiner":                0,
+						"wsrep_local_state_synced":                1,
+						"wsrep_local_state_undefined":             0,
+						"wsrep_open_transactions":                 1,
+						"wsrep_ready":                             1,
+						"wsrep_received":                          12915,
+						"wsrep_received_bytes":                    8423152,
+						"wsrep_replicated":                        6012,
+						"wsrep_replicated_bytes":                  3896408,
+						"wsrep_thread_count":                      9,
+					}
+
 					copyProcessListQueryDuration(mx, expected)
 					require.Equal(t, expected, mx)
 					ensureCollectedHasAllChartsDimsVarsIDs(t, my, mx)
@@ -1620,6 +1833,277 @@ func TestMySQL_Collect(t *testing.T) {
 	}
 }
 
+func TestMySQL_collectUserStatistics(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.isMariaDB = true
+	my.UserStatisticsUsers = matcher.SimpleExpr{Excludes: []string{"= root"}}
+	require.True(t, my.Init())
+
+	mockExpect(t, mock, queryShowUserStatistics, dataMariaV1084UserStatistics)
+	mx := make(map[string]int64)
+	require.NoError(t, my.collectUserStatistics(mx))
+
+	assert.Contains(t, mx, "userstats_netdata_cpu_time")
+	assert.NotContains(t, mx, "userstats_root_cpu_time")
+	require.NotNil(t, my.Charts().Get("userstats_cpu_netdata"))
+	assert.Nil(t, my.Charts().Get("userstats_cpu_root"))
+
+	// the user is gone
+	mockExpect(t, mock, queryShowUserStatistics, nil)
+	mx = make(map[string]int64)
+	require.NoError(t, my.collectUserStatistics(mx))
+
+	assert.Empty(t, mx)
+	for _, chart := range *my.Charts() {
+		if strings.HasPrefix(chart.ID, "userstats_") {
+			assert.Truef(t, chart.Obsolete, "chart '%s' is not obsolete", chart.ID)
+		}
+	}
+	assert.Empty(t, my.collectedUsers)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_collectSlaveStatus(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.version = &semver.Version{Major: 8, Minor: 0, Patch: 30}
+	require.True(t, my.Init())
+
+	mockExpect(t, mock, queryShowReplicaStatus, dataMySQLV8030ReplicaStatusMultiSource)
+	mx := make(map[string]int64)
+	require.NoError(t, my.collectSlaveStatus(mx))
+
+	for _, id := range []string{"slave_behind_master1", "slave_thread_running_master2"} {
+		require.NotNilf(t, my.Charts().Get(id), "chart '%s' is not created", id)
+	}
+	assert.Nil(t, my.Charts().Get("slave_behind"))
+
+	// the channels are gone
+	mockExpect(t, mock, queryShowReplicaStatus, nil)
+	mx = make(map[string]int64)
+	require.NoError(t, my.collectSlaveStatus(mx))
+
+	assert.Empty(t, mx)
+	for _, chart := range *my.Charts() {
+		if strings.HasPrefix(chart.ID, "slave_") {
+			assert.Truef(t, chart.Obsolete, "chart '%s' is not obsolete", chart.ID)
+		}
+	}
+	assert.Empty(t, my.collectedReplConns)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_collectQueryResponseTime(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.CollectQueryResponseTime = true
+	require.True(t, my.Init())
+
+	mockExpect(t, mock, queryQueryResponseTime, dataMariaV1084QueryResponseTime)
+	mx := make(map[string]int64)
+	require.NoError(t, my.collectQueryResponseTime(mx))
+
+	expected := map[string]int64{
+		"query_response_time_bucket_0.000001": 0,
+		"query_response_time_bucket_0.00001":  17,
+		"query_response_time_bucket_0.0001":   4023,
+		"query_response_time_bucket_0.001":    1105,
+		"query_response_time_bucket_0.01":     218,
+		"query_response_time_bucket_0.1":      27,
+		"query_response_time_bucket_1":        3,
+		"query_response_time_bucket_10":       1,
+		"query_response_time_bucket_100":      0,
+		"query_response_time_bucket_1000":     0,
+		"query_response_time_bucket_10000":    0,
+		"query_response_time_bucket_100000":   0,
+		"query_response_time_bucket_1000000":  0,
+		"query_response_time_bucket_inf":      0,
+	}
+	assert.Equal(t, expected, mx)
+	chart := my.Charts().Get(chartQueryResponseTime.ID)
+	require.NotNil(t, chart)
+	assert.Len(t, chart.Dims, len(expected))
+
+	// FLUSH QUERY_RESPONSE_TIME
+	mock.ExpectQuery(queryQueryResponseTime).WillReturnRows(
+		sqlmock.NewRows([]string{"TIME", "COUNT"}).
+			AddRow("      0.000010", "2").
+			AddRow("      0.000100", "5").
+			AddRow("TOO LONG", "0"),
+	).RowsWillBeClosed()
+	mx = make(map[string]int64)
+	require.NoError(t, my.collectQueryResponseTime(mx))
+
+	assert.EqualValues(t, 19, mx["query_response_time_bucket_0.00001"])
+	assert.EqualValues(t, 4028, mx["query_response_time_bucket_0.0001"])
+	assert.EqualValues(t, 0, mx["query_response_time_bucket_inf"])
+
+	// no flush
+	mock.ExpectQuery(queryQueryResponseTime).WillReturnRows(
+		sqlmock.NewRows([]string{"TIME", "COUNT"}).
+			AddRow("      0.000010", "3").
+			AddRow("      0.000100", "9").
+			AddRow("TOO LONG", "1"),
+	).RowsWillBeClosed()
+	mx = make(map[string]int64)
+	require.NoError(t, my.collectQueryResponseTime(mx))
+
+	assert.EqualValues(t, 20, mx["query_response_time_bucket_0.00001"])
+	assert.EqualValues(t, 4032, mx["query_response_time_bucket_0.0001"])
+	assert.EqualValues(t, 1, mx["query_response_time_bucket_inf"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_collectQueryResponseTime_PluginNotInstalled(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.CollectQueryResponseTime = true
+	require.True(t, my.Init())
+
+	mock.ExpectQuery(queryQueryResponseTime).WillReturnError(&mysql.MySQLError{
+		Number:  1109,
+		Message: "Unknown table 'QUERY_RESPONSE_TIME' in information_schema",
+	})
+	mx := make(map[string]int64)
+	assert.NoError(t, my.collectQueryResponseTime(mx))
+
+	assert.False(t, my.doQueryResponseTime)
+	assert.Empty(t, mx)
+	assert.Nil(t, my.Charts().Get(chartQueryResponseTime.ID))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_collectDatabasesSize(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.CollectDatabasesSize = true
+	my.DatabasesSizeDatabases = matcher.SimpleExpr{
+		Includes: []string{"* *"},
+		Excludes: []string{"* *_schema"},
+	}
+	require.True(t, my.Init())
+
+	mockExpect(t, mock, queryDatabasesSize, dataMySQLV8030DatabasesSize)
+	require.NoError(t, my.collectDatabasesSize())
+
+	assert.Equal(t, map[string]int64{"mysql": 2637824, "netdata": 153092096, "sys": 16384}, my.dbSizes)
+	chart := my.Charts().Get(chartDatabasesSize.ID)
+	require.NotNil(t, chart)
+	for _, db := range []string{"mysql", "netdata", "sys"} {
+		assert.Truef(t, chart.HasDim("database_"+db+"_size"), "chart has no dim for database '%s'", db)
+	}
+	assert.False(t, chart.HasDim("database_information_schema_size"))
+
+	// 'netdata' is dropped, 'shop' is created
+	mock.ExpectQuery(queryDatabasesSize).WillReturnRows(
+		sqlmock.NewRows([]string{"db", "size"}).
+			AddRow("mysql", "2637824").
+			AddRow("shop", "81920").
+			AddRow("sys", "16384"),
+	).RowsWillBeClosed()
+	require.NoError(t, my.collectDatabasesSize())
+
+	assert.Equal(t, map[string]int64{"mysql": 2637824, "shop": 81920, "sys": 16384}, my.dbSizes)
+	require.True(t, chart.HasDim("database_shop_size"))
+	dim := chart.GetDim("database_netdata_size")
+	require.NotNil(t, dim)
+	assert.True(t, dim.Obsolete)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_Collect_CollectUserStatisticsExtended(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	my := New()
+	my.db = db
+	my.CollectUserStatisticsExtended = true
+	require.True(t, my.Init())
+
+	mockExpect(t, mock, queryShowVersion, dataMySQLV8030Version)
+	mockExpect(t, mock, queryShowGlobalStatus, dataMySQLV8030GlobalStatus)
+	mockExpect(t, mock, queryShowGlobalVariables, dataMySQLV8030GlobalVariables)
+	mockExpect(t, mock, queryShowReplicaStatus, dataMySQLV8030ReplicaStatusMultiSource)
+	mockExpect(t, mock, queryUserStatisticsPerfSchema, dataMySQLV8030UserStatistics)
+	mockExpect(t, mock, queryShowProcessList, dataMySQLV8030ProcessList)
+
+	mx := my.Collect()
+
+	assert.EqualValues(t, 153, mx["userstats_netdata_busy_time"])
+	assert.EqualValues(t, 2310, mx["userstats_netdata_rows_read"])
+	assert.EqualValues(t, 29, mx["userstats_root_rows_sent"])
+	assert.EqualValues(t, 2, mx["userstats_root_total_connections"])
+	assert.NotNil(t, my.Charts().Get("userstats_busy_time_netdata"))
+	ensureCollectedHasAllChartsDimsVarsIDs(t, my, mx)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQL_collectUserStatistics_BusyTime(t *testing.T) {
+	for name, enabled := range map[string]bool{"enabled": true, "disabled": false} {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(
+				sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+			)
+			require.NoError(t, err)
+			defer func() { _ = db.Close() }()
+
+			my := New()
+			my.db = db
+			my.isMariaDB = true
+			my.CollectUserStatisticsExtended = enabled
+			require.True(t, my.Init())
+
+			mockExpect(t, mock, queryShowUserStatistics, dataMariaV1084UserStatistics)
+			mx := make(map[string]int64)
+			require.NoError(t, my.collectUserStatistics(mx))
+
+			assert.Contains(t, mx, "userstats_netdata_cpu_time")
+			if enabled {
+				assert.Contains(t, mx, "userstats_netdata_busy_time")
+				assert.NotNil(t, my.Charts().Get("userstats_busy_time_netdata"))
+			} else {
+				assert.NotContains(t, mx, "userstats_netdata_busy_time")
+				assert.Nil(t, my.Charts().Get("userstats_busy_time_netdata"))
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, mySQL *MySQL, collected map[string]int64) {
 	for _, chart := range *mySQL.Charts() {
 		if mySQL.isMariaDB {
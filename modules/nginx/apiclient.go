@@ -4,6 +4,7 @@ package nginx
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -78,6 +79,44 @@ func (a apiClient) getStubStatus() (*stubStatus, error) {
 	return status, nil
 }
 
+// getPlusAPIVersions returns the NGINX Plus API versions supported by the server, the request URL is expected to be
+// the API base URL.
+func (a apiClient) getPlusAPIVersions() ([]int, error) {
+	var versions []int
+	if err := a.getJSON("", &versions); err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%s returned no API versions", a.request.URL)
+	}
+	return versions, nil
+}
+
+// getJSON decodes the response of the request to the path relative to the request URL.
+func (a apiClient) getJSON(path string, dst interface{}) error {
+	r := a.request
+	if path != "" {
+		r.URL = strings.TrimSuffix(r.URL, "/") + "/" + path
+	}
+
+	req, err := web.NewHTTPRequest(r)
+	if err != nil {
+		return fmt.Errorf("error on creating request : %v", err)
+	}
+
+	resp, err := a.doRequestOK(req)
+	defer closeBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return fmt.Errorf("error on decoding response from %s : %v", req.URL, err)
+	}
+
+	return nil
+}
+
 func (a apiClient) doRequestOK(req *http.Request) (*http.Response, error) {
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
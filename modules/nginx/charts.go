@@ -2,17 +2,30 @@
 
 package nginx
 
-import "github.com/netdata/go.d.plugin/agent/module"
+import (
+	"fmt"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
 
 type (
 	// Charts is an alias for module.Charts
 	Charts = module.Charts
+	// Chart is an alias for module.Chart
+	Chart = module.Chart
 	// Dims is an alias for module.Dims
 	Dims = module.Dims
 )
 
 var charts = Charts{
-	{
+	connectionsChart.Copy(),
+	connectionsStatusesChart.Copy(),
+	connectionsAcceptedHandledChart.Copy(),
+	requestsChart.Copy(),
+}
+
+var (
+	connectionsChart = Chart{
 		ID:    "connections",
 		Title: "Active Client Connections Including Waiting Connections",
 		Units: "connections",
@@ -21,8 +34,8 @@ var charts = Charts{
 		Dims: Dims{
 			{ID: "active"},
 		},
-	},
-	{
+	}
+	connectionsStatusesChart = Chart{
 		ID:    "connections_statuses",
 		Title: "Active Connections Per Status",
 		Units: "connections",
@@ -33,8 +46,8 @@ var charts = Charts{
 			{ID: "writing"},
 			{ID: "waiting", Name: "idle"},
 		},
-	},
-	{
+	}
+	connectionsAcceptedHandledChart = Chart{
 		ID:    "connections_accepted_handled",
 		Title: "Accepted And Handled Connections",
 		Units: "connections/s",
@@ -44,8 +57,8 @@ var charts = Charts{
 			{ID: "accepts", Name: "accepted", Algo: module.Incremental},
 			{ID: "handled", Algo: module.Incremental},
 		},
-	},
-	{
+	}
+	requestsChart = Chart{
 		ID:    "requests",
 		Title: "Client Requests",
 		Units: "requests/s",
@@ -54,5 +67,213 @@ var charts = Charts{
 		Dims: Dims{
 			{ID: "requests", Algo: module.Incremental},
 		},
+	}
+)
+
+var connectionsUtilizationChart = Chart{
+	ID:    "connections_utilization",
+	Title: "Active Connections Utilization",
+	Units: "percentage",
+	Fam:   "connections",
+	Ctx:   "nginx.connections_utilization",
+	Dims: Dims{
+		{ID: "connections_utilization", Name: "utilization", Div: precision},
+	},
+}
+
+// addUtilizationCharts adds the utilization chart and exposes the maximum number of connections
+// as the connections chart variable.
+func (n *Nginx) addUtilizationCharts() error {
+	chart := n.charts.Get(connectionsChart.ID)
+	if chart == nil {
+		return fmt.Errorf("chart '%s' not found", connectionsChart.ID)
+	}
+	if err := chart.AddVar(&module.Var{ID: "max_connections"}); err != nil {
+		return err
+	}
+
+	return n.charts.Add(connectionsUtilizationChart.Copy())
+}
+
+func newEndpointsStatusChart(endpoints []*endpoint) *Chart {
+	chart := &Chart{
+		ID:    "endpoints_status",
+		Title: "Stub Status Endpoints Status",
+		Units: "status",
+		Fam:   "endpoints",
+		Ctx:   "nginx.endpoints_status",
+	}
+	for _, e := range endpoints {
+		chart.Dims = append(chart.Dims, &module.Dim{ID: endpointUpKey(e.url), Name: e.url})
+	}
+	return chart
+}
+
+// NGINX Plus API charts.
+var plusCharts = Charts{
+	{
+		ID:    "ssl_handshakes",
+		Title: "SSL Handshakes",
+		Units: "handshakes/s",
+		Fam:   "ssl",
+		Ctx:   "nginx.ssl_handshakes",
+		Dims: Dims{
+			{ID: "ssl_handshakes", Name: "successful", Algo: module.Incremental},
+			{ID: "ssl_handshakes_failed", Name: "failed", Algo: module.Incremental},
+		},
 	},
+	{
+		ID:    "ssl_session_reuses",
+		Title: "SSL Session Reuses",
+		Units: "sessions/s",
+		Fam:   "ssl",
+		Ctx:   "nginx.ssl_session_reuses",
+		Dims: Dims{
+			{ID: "ssl_session_reuses", Name: "reused", Algo: module.Incremental},
+		},
+	},
+}
+
+var serverZoneChartsTmpl = Charts{
+	{
+		ID:    "server_zone_%s_requests",
+		Title: "Server Zone Requests",
+		Units: "requests/s",
+		Fam:   "server zones",
+		Ctx:   "nginx.server_zone_requests",
+		Dims: Dims{
+			{ID: "server_zone_%s_requests", Name: "requests", Algo: module.Incremental},
+			{ID: "server_zone_%s_discarded", Name: "discarded", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "server_zone_%s_responses",
+		Title: "Server Zone Responses",
+		Units: "responses/s",
+		Fam:   "server zones",
+		Ctx:   "nginx.server_zone_responses",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "server_zone_%s_responses_1xx", Name: "1xx", Algo: module.Incremental},
+			{ID: "server_zone_%s_responses_2xx", Name: "2xx", Algo: module.Incremental},
+			{ID: "server_zone_%s_responses_3xx", Name: "3xx", Algo: module.Incremental},
+			{ID: "server_zone_%s_responses_4xx", Name: "4xx", Algo: module.Incremental},
+			{ID: "server_zone_%s_responses_5xx", Name: "5xx", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "server_zone_%s_traffic",
+		Title: "Server Zone Traffic",
+		Units: "bytes/s",
+		Fam:   "server zones",
+		Ctx:   "nginx.server_zone_traffic",
+		Type:  module.Area,
+		Dims: Dims{
+			{ID: "server_zone_%s_received", Name: "received", Algo: module.Incremental},
+			{ID: "server_zone_%s_sent", Name: "sent", Algo: module.Incremental, Mul: -1},
+		},
+	},
+	{
+		ID:    "server_zone_%s_processing",
+		Title: "Server Zone Processing Requests",
+		Units: "requests",
+		Fam:   "server zones",
+		Ctx:   "nginx.server_zone_processing",
+		Dims: Dims{
+			{ID: "server_zone_%s_processing", Name: "processing"},
+		},
+	},
+}
+
+var upstreamChartsTmpl = Charts{
+	{
+		ID:    "upstream_%s_peers_state",
+		Title: "Upstream Peers State",
+		Units: "peers",
+		Fam:   "upstreams",
+		Ctx:   "nginx.upstream_peers_state",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "upstream_%s_peers_up", Name: "up"},
+			{ID: "upstream_%s_peers_draining", Name: "draining"},
+			{ID: "upstream_%s_peers_down", Name: "down"},
+			{ID: "upstream_%s_peers_unavail", Name: "unavail"},
+			{ID: "upstream_%s_peers_checking", Name: "checking"},
+			{ID: "upstream_%s_peers_unhealthy", Name: "unhealthy"},
+		},
+	},
+	{
+		ID:    "upstream_%s_requests",
+		Title: "Upstream Requests",
+		Units: "requests/s",
+		Fam:   "upstreams",
+		Ctx:   "nginx.upstream_requests",
+		Dims: Dims{
+			{ID: "upstream_%s_requests", Name: "requests", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "upstream_%s_responses",
+		Title: "Upstream Responses",
+		Units: "responses/s",
+		Fam:   "upstreams",
+		Ctx:   "nginx.upstream_responses",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "upstream_%s_responses_1xx", Name: "1xx", Algo: module.Incremental},
+			{ID: "upstream_%s_responses_2xx", Name: "2xx", Algo: module.Incremental},
+			{ID: "upstream_%s_responses_3xx", Name: "3xx", Algo: module.Incremental},
+			{ID: "upstream_%s_responses_4xx", Name: "4xx", Algo: module.Incremental},
+			{ID: "upstream_%s_responses_5xx", Name: "5xx", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "upstream_%s_active",
+		Title: "Upstream Active Connections",
+		Units: "connections",
+		Fam:   "upstreams",
+		Ctx:   "nginx.upstream_active",
+		Dims: Dims{
+			{ID: "upstream_%s_active", Name: "active"},
+		},
+	},
+	{
+		ID:    "upstream_%s_fails",
+		Title: "Upstream Peers Failures",
+		Units: "fails/s",
+		Fam:   "upstreams",
+		Ctx:   "nginx.upstream_fails",
+		Dims: Dims{
+			{ID: "upstream_%s_fails", Name: "communication", Algo: module.Incremental},
+			{ID: "upstream_%s_health_checks_fails", Name: "health_checks", Algo: module.Incremental},
+		},
+	},
+}
+
+func newServerZoneCharts(name string) *Charts {
+	charts := serverZoneChartsTmpl.Copy()
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, name)
+		chart.Labels = []module.Label{
+			{Key: "server_zone", Value: name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, name)
+		}
+	}
+	return charts
+}
+
+func newUpstreamCharts(name string) *Charts {
+	charts := upstreamChartsTmpl.Copy()
+	for _, chart := range *charts {
+		chart.ID = fmt.Sprintf(chart.ID, name)
+		chart.Labels = []module.Label{
+			{Key: "upstream", Value: name},
+		}
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, name)
+		}
+	}
+	return charts
 }
@@ -3,15 +3,105 @@
 package nginx
 
 import (
+	"fmt"
+
 	"github.com/netdata/go.d.plugin/pkg/stm"
 )
 
-func (n *Nginx) collect() (map[string]int64, error) {
-	status, err := n.apiClient.getStubStatus()
+// endpoint is a stub_status URL, the last successfully collected status is kept to not break
+// the sum of the counters when the endpoint is temporarily unavailable.
+type endpoint struct {
+	url       string
+	apiClient *apiClient
+	last      *stubStatus
+}
+
+const precision = 1000
 
+func (n *Nginx) collect() (map[string]int64, error) {
+	mx, err := n.collectStatus()
 	if err != nil {
 		return nil, err
 	}
 
-	return stm.ToMap(status), nil
+	if n.WorkerConnections > 0 {
+		n.collectUtilization(mx)
+	}
+
+	return mx, nil
+}
+
+// collectUtilization calculates the active connections utilization of the maximum number of simultaneous
+// connections (worker_connections * worker_processes).
+func (n *Nginx) collectUtilization(mx map[string]int64) {
+	maxConns := int64(n.WorkerConnections * n.WorkerProcesses)
+	mx["max_connections"] = maxConns
+	mx["connections_utilization"] = mx["active"] * 100 * precision / maxConns
+}
+
+func (n *Nginx) collectStatus() (map[string]int64, error) {
+	if n.plusAPI != nil {
+		return n.collectPlusAPI()
+	}
+
+	if len(n.endpoints) == 1 {
+		// the mode is chosen until stub_status responds: the NGINX Plus API is preferred if available
+		if !n.stubStatusOK {
+			if api, err := n.detectPlusAPI(); err != nil {
+				n.Debugf("NGINX Plus API not detected: %v", err)
+			} else {
+				n.Infof("using NGINX Plus API %s", api.request.URL)
+				n.plusAPI = api
+				n.addPlusCharts()
+				return n.collectPlusAPI()
+			}
+		}
+
+		status, err := n.endpoints[0].apiClient.getStubStatus()
+		if err != nil {
+			return nil, err
+		}
+		n.stubStatusOK = true
+		return stm.ToMap(status), nil
+	}
+
+	return n.collectEndpoints()
+}
+
+func (n *Nginx) collectEndpoints() (map[string]int64, error) {
+	mx := make(map[string]int64)
+	var total stubStatus
+	var up int
+
+	for _, e := range n.endpoints {
+		status, err := e.apiClient.getStubStatus()
+		if err != nil {
+			n.Warning(err)
+			mx[endpointUpKey(e.url)] = 0
+			if e.last == nil {
+				continue
+			}
+			// the current connections are unknown, only the counters are reused
+			status = e.last.counters()
+		} else {
+			mx[endpointUpKey(e.url)] = 1
+			e.last = status
+			up++
+		}
+		total.add(status)
+	}
+
+	if up == 0 {
+		return nil, fmt.Errorf("all %d stub_status endpoints are unavailable", len(n.endpoints))
+	}
+
+	for k, v := range stm.ToMap(&total) {
+		mx[k] = v
+	}
+
+	return mx, nil
+}
+
+func endpointUpKey(url string) string {
+	return "endpoint_" + url + "_up"
 }
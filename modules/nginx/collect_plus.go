@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package nginx
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var plusUpstreamPeerStates = []string{"up", "draining", "down", "unavail", "checking", "unhealthy"}
+
+// detectPlusAPI checks whether the NGINX Plus API is available and returns the client for the latest API version.
+// The API base URL is the configured URL if its path is '/api', otherwise '/api' on the configured URL host.
+func (n *Nginx) detectPlusAPI() (*apiClient, error) {
+	e := n.endpoints[0]
+
+	u, err := url.Parse(e.url)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(u.Path, "/") != "/api" {
+		u.Path = "/api"
+	}
+	u.RawQuery, u.Fragment = "", ""
+
+	req := e.apiClient.request
+	req.URL = u.String()
+	api := newAPIClient(e.apiClient.httpClient, req)
+
+	versions, err := api.getPlusAPIVersions()
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(versions)
+
+	api.request.URL = strings.TrimSuffix(api.request.URL, "/") + "/" + strconv.Itoa(versions[len(versions)-1])
+
+	return api, nil
+}
+
+func (n *Nginx) collectPlusAPI() (map[string]int64, error) {
+	var conns plusConnections
+	if err := n.plusAPI.getJSON("connections", &conns); err != nil {
+		return nil, err
+	}
+	var reqs plusHTTPRequests
+	if err := n.plusAPI.getJSON("http/requests", &reqs); err != nil {
+		return nil, err
+	}
+	var ssl plusSSL
+	if err := n.plusAPI.getJSON("ssl", &ssl); err != nil {
+		return nil, err
+	}
+	var zones map[string]plusServerZone
+	if err := n.plusAPI.getJSON("http/server_zones", &zones); err != nil {
+		return nil, err
+	}
+	var upstreams map[string]plusUpstream
+	if err := n.plusAPI.getJSON("http/upstreams", &upstreams); err != nil {
+		return nil, err
+	}
+
+	// stub_status compatible metrics, 'active' includes the idle connections
+	mx := map[string]int64{
+		"active":   conns.Active + conns.Idle,
+		"waiting":  conns.Idle,
+		"accepts":  conns.Accepted,
+		"handled":  conns.Accepted - conns.Dropped,
+		"requests": reqs.Total,
+
+		"ssl_handshakes":        ssl.Handshakes,
+		"ssl_handshakes_failed": ssl.HandshakesFailed,
+		"ssl_session_reuses":    ssl.SessionReuses,
+	}
+
+	n.collectPlusServerZones(mx, zones)
+	n.collectPlusUpstreams(mx, upstreams)
+
+	return mx, nil
+}
+
+func (n *Nginx) collectPlusServerZones(mx map[string]int64, zones map[string]plusServerZone) {
+	seen := make(map[string]bool)
+	for name, zone := range zones {
+		seen[name] = true
+		if !n.serverZones[name] {
+			n.serverZones[name] = true
+			n.addServerZoneCharts(name)
+		}
+
+		px := "server_zone_" + name + "_"
+		mx[px+"processing"] = zone.Processing
+		mx[px+"requests"] = zone.Requests
+		mx[px+"discarded"] = zone.Discarded
+		mx[px+"received"] = zone.Received
+		mx[px+"sent"] = zone.Sent
+		writeResponses(mx, px, zone.Responses)
+	}
+
+	for name := range n.serverZones {
+		if !seen[name] {
+			delete(n.serverZones, name)
+			n.removeCharts(serverZoneChartsTmpl, name)
+		}
+	}
+}
+
+func (n *Nginx) collectPlusUpstreams(mx map[string]int64, upstreams map[string]plusUpstream) {
+	seen := make(map[string]bool)
+	for name, upstream := range upstreams {
+		seen[name] = true
+		if !n.upstreams[name] {
+			n.upstreams[name] = true
+			n.addUpstreamCharts(name)
+		}
+
+		px := "upstream_" + name + "_"
+		for _, state := range plusUpstreamPeerStates {
+			mx[px+"peers_"+state] = 0
+		}
+
+		var active, requests, fails, hcFails int64
+		var responses plusResponses
+		for _, peer := range upstream.Peers {
+			mx[px+"peers_"+peer.State]++
+			active += peer.Active
+			requests += peer.Requests
+			fails += peer.Fails
+			hcFails += peer.HealthChecks.Fails
+			responses.Resp1xx += peer.Responses.Resp1xx
+			responses.Resp2xx += peer.Responses.Resp2xx
+			responses.Resp3xx += peer.Responses.Resp3xx
+			responses.Resp4xx += peer.Responses.Resp4xx
+			responses.Resp5xx += peer.Responses.Resp5xx
+		}
+
+		mx[px+"active"] = active
+		mx[px+"requests"] = requests
+		mx[px+"fails"] = fails
+		mx[px+"health_checks_fails"] = hcFails
+		writeResponses(mx, px, responses)
+	}
+
+	for name := range n.upstreams {
+		if !seen[name] {
+			delete(n.upstreams, name)
+			n.removeCharts(upstreamChartsTmpl, name)
+		}
+	}
+}
+
+func writeResponses(mx map[string]int64, prefix string, r plusResponses) {
+	mx[prefix+"responses_1xx"] = r.Resp1xx
+	mx[prefix+"responses_2xx"] = r.Resp2xx
+	mx[prefix+"responses_3xx"] = r.Resp3xx
+	mx[prefix+"responses_4xx"] = r.Resp4xx
+	mx[prefix+"responses_5xx"] = r.Resp5xx
+}
+
+func (n *Nginx) addPlusCharts() {
+	// reading and writing connections are not available in the API
+	if chart := n.charts.Get(connectionsStatusesChart.ID); chart != nil {
+		chart.MarkRemove()
+		chart.MarkNotCreated()
+	}
+	if err := n.charts.Add(*plusCharts.Copy()...); err != nil {
+		n.Warning(err)
+	}
+}
+
+func (n *Nginx) addServerZoneCharts(name string) {
+	charts := newServerZoneCharts(name)
+	if err := n.charts.Add(*charts...); err != nil {
+		n.Warningf("failed to add server zone '%s' charts: %v", name, err)
+	}
+}
+
+func (n *Nginx) addUpstreamCharts(name string) {
+	charts := newUpstreamCharts(name)
+	if err := n.charts.Add(*charts...); err != nil {
+		n.Warningf("failed to add upstream '%s' charts: %v", name, err)
+	}
+}
+
+func (n *Nginx) removeCharts(tmpl Charts, name string) {
+	for _, chart := range tmpl {
+		if c := n.charts.Get(fmt.Sprintf(chart.ID, name)); c != nil {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
@@ -32,3 +32,78 @@ type stubStatus struct {
 		Time *int64 `stm:"request_time"`
 	} `stm:""`
 }
+
+func (s *stubStatus) add(other *stubStatus) {
+	s.Connections.Active += other.Connections.Active
+	s.Connections.Accepts += other.Connections.Accepts
+	s.Connections.Handled += other.Connections.Handled
+	s.Connections.Reading += other.Connections.Reading
+	s.Connections.Writing += other.Connections.Writing
+	s.Connections.Waiting += other.Connections.Waiting
+	s.Requests.Total += other.Requests.Total
+	if other.Requests.Time != nil {
+		v := *other.Requests.Time
+		if s.Requests.Time != nil {
+			v += *s.Requests.Time
+		}
+		s.Requests.Time = &v
+	}
+}
+
+// counters returns a copy of the status with only the cumulative counters set.
+func (s *stubStatus) counters() *stubStatus {
+	var c stubStatus
+	c.Connections.Accepts = s.Connections.Accepts
+	c.Connections.Handled = s.Connections.Handled
+	c.Requests.Total = s.Requests.Total
+	c.Requests.Time = s.Requests.Time
+	return &c
+}
+
+// NGINX Plus API (https://nginx.org/en/docs/http/ngx_http_api_module.html).
+type (
+	plusConnections struct {
+		Accepted int64 `json:"accepted"`
+		Dropped  int64 `json:"dropped"`
+		Active   int64 `json:"active"`
+		Idle     int64 `json:"idle"`
+	}
+	plusHTTPRequests struct {
+		Total   int64 `json:"total"`
+		Current int64 `json:"current"`
+	}
+	plusSSL struct {
+		Handshakes       int64 `json:"handshakes"`
+		HandshakesFailed int64 `json:"handshakes_failed"`
+		SessionReuses    int64 `json:"session_reuses"`
+	}
+	plusResponses struct {
+		Resp1xx int64 `json:"1xx"`
+		Resp2xx int64 `json:"2xx"`
+		Resp3xx int64 `json:"3xx"`
+		Resp4xx int64 `json:"4xx"`
+		Resp5xx int64 `json:"5xx"`
+	}
+	plusServerZone struct {
+		Processing int64         `json:"processing"`
+		Requests   int64         `json:"requests"`
+		Responses  plusResponses `json:"responses"`
+		Discarded  int64         `json:"discarded"`
+		Received   int64         `json:"received"`
+		Sent       int64         `json:"sent"`
+	}
+	plusUpstream struct {
+		Peers []plusUpstreamPeer `json:"peers"`
+	}
+	plusUpstreamPeer struct {
+		Server       string        `json:"server"`
+		State        string        `json:"state"`
+		Active       int64         `json:"active"`
+		Requests     int64         `json:"requests"`
+		Responses    plusResponses `json:"responses"`
+		Fails        int64         `json:"fails"`
+		HealthChecks struct {
+			Fails int64 `json:"fails"`
+		} `json:"health_checks"`
+	}
+)
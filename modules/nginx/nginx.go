@@ -34,14 +34,23 @@ func New() *Nginx {
 				Timeout: web.Duration{Duration: defaultHTTPTimeout},
 			},
 		},
+		WorkerProcesses: 1,
 	}
 
-	return &Nginx{Config: config}
+	return &Nginx{
+		Config:      config,
+		charts:      charts.Copy(),
+		serverZones: make(map[string]bool),
+		upstreams:   make(map[string]bool),
+	}
 }
 
 // Config is the Nginx module configuration.
 type Config struct {
-	web.HTTP `yaml:",inline"`
+	web.HTTP          `yaml:",inline"`
+	URLs              []string `yaml:"urls"`
+	WorkerConnections int      `yaml:"worker_connections"`
+	WorkerProcesses   int      `yaml:"worker_processes"`
 }
 
 // Nginx nginx module.
@@ -49,7 +58,12 @@ type Nginx struct {
 	module.Base
 	Config `yaml:",inline"`
 
-	apiClient *apiClient
+	charts       *Charts
+	endpoints    []*endpoint
+	stubStatusOK bool
+	plusAPI      *apiClient
+	serverZones  map[string]bool
+	upstreams    map[string]bool
 }
 
 // Cleanup makes cleanup.
@@ -57,22 +71,52 @@ func (Nginx) Cleanup() {}
 
 // Init makes initialization.
 func (n *Nginx) Init() bool {
-	if n.URL == "" {
+	urls := n.URLs
+	if len(urls) == 0 && n.URL != "" {
+		urls = []string{n.URL}
+	}
+	if len(urls) == 0 {
 		n.Error("URL not set")
 		return false
 	}
 
+	if n.WorkerConnections < 0 || n.WorkerProcesses <= 0 {
+		n.Errorf("invalid worker_connections (%d) or worker_processes (%d)", n.WorkerConnections, n.WorkerProcesses)
+		return false
+	}
+
 	client, err := web.NewHTTPClient(n.Client)
 	if err != nil {
 		n.Error(err)
 		return false
 	}
 
-	n.apiClient = newAPIClient(client, n.Request)
-
-	n.Debugf("using URL %s", n.URL)
+	n.endpoints = n.endpoints[:0]
+	for _, u := range urls {
+		req := n.Request
+		req.URL = u
+		n.endpoints = append(n.endpoints, &endpoint{url: u, apiClient: newAPIClient(client, req)})
+		n.Debugf("using URL %s", u)
+	}
+	if n.UnixSocket != "" {
+		n.Debugf("using unix socket %s", n.UnixSocket)
+	}
 	n.Debugf("using timeout: %s", n.Timeout.Duration)
 
+	if len(n.endpoints) > 1 {
+		if err := n.charts.Add(newEndpointsStatusChart(n.endpoints)); err != nil {
+			n.Error(err)
+			return false
+		}
+	}
+
+	if n.WorkerConnections > 0 {
+		if err := n.addUtilizationCharts(); err != nil {
+			n.Error(err)
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -80,7 +124,7 @@ func (n *Nginx) Init() bool {
 func (n *Nginx) Check() bool { return len(n.Collect()) > 0 }
 
 // Charts creates Charts.
-func (Nginx) Charts() *Charts { return charts.Copy() }
+func (n Nginx) Charts() *Charts { return n.charts }
 
 // Collect collects metrics.
 func (n *Nginx) Collect() map[string]int64 {
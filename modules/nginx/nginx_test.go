@@ -16,6 +16,13 @@ import (
 var (
 	testStatusData, _        = os.ReadFile("testdata/status.txt")
 	testTengineStatusData, _ = os.ReadFile("testdata/tengine-status.txt")
+
+	testPlusAPIVersions, _     = os.ReadFile("testdata/plus/api_versions.json")
+	testPlusConnections, _     = os.ReadFile("testdata/plus/connections.json")
+	testPlusHTTPRequests, _    = os.ReadFile("testdata/plus/http_requests.json")
+	testPlusSSL, _             = os.ReadFile("testdata/plus/ssl.json")
+	testPlusHTTPServerZones, _ = os.ReadFile("testdata/plus/http_server_zones.json")
+	testPlusHTTPUpstreams, _   = os.ReadFile("testdata/plus/http_upstreams.json")
 )
 
 func TestNginx_Cleanup(t *testing.T) { New().Cleanup() }
@@ -32,7 +39,23 @@ func TestNginx_Init(t *testing.T) {
 	job := New()
 
 	require.True(t, job.Init())
-	assert.NotNil(t, job.apiClient)
+	assert.Len(t, job.endpoints, 1)
+}
+
+func TestNginx_Init_URLs(t *testing.T) {
+	job := New()
+	job.URLs = []string{"http://127.0.0.1:8081/stub_status", "http://127.0.0.1:8082/stub_status"}
+
+	require.True(t, job.Init())
+	assert.Len(t, job.endpoints, 2)
+	assert.NotNil(t, job.Charts().Get("endpoints_status"))
+}
+
+func TestNginx_Init_URLNotSet(t *testing.T) {
+	job := New()
+	job.URL = ""
+
+	assert.False(t, job.Init())
 }
 
 func TestNginx_Check(t *testing.T) {
@@ -112,6 +135,185 @@ func TestNginx_CollectTengine(t *testing.T) {
 	assert.Equal(t, expected, job.Collect())
 }
 
+func TestNginx_CollectURLs(t *testing.T) {
+	ts1 := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(testStatusData)
+			}))
+	defer ts1.Close()
+	ts2 := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(testStatusData)
+			}))
+
+	job := New()
+	job.URLs = []string{ts1.URL, ts2.URL}
+	require.True(t, job.Init())
+	require.True(t, job.Check())
+
+	expected := map[string]int64{
+		"accepts":                     72,
+		"active":                      2,
+		"handled":                     72,
+		"reading":                     0,
+		"requests":                    252,
+		"waiting":                     0,
+		"writing":                     2,
+		"endpoint_" + ts1.URL + "_up": 1,
+		"endpoint_" + ts2.URL + "_up": 1,
+	}
+	assert.Equal(t, expected, job.Collect())
+
+	// the counters of the failed endpoint are kept, the current connections are not
+	ts2.Close()
+	expected["active"] = 1
+	expected["writing"] = 1
+	expected["endpoint_"+ts2.URL+"_up"] = 0
+	assert.Equal(t, expected, job.Collect())
+
+	ts1.Close()
+	assert.Nil(t, job.Collect())
+}
+
+func TestNginx_CollectPlusAPI(t *testing.T) {
+	tests := map[string]struct {
+		url func(srvURL string) string
+	}{
+		"stub_status URL":  {url: func(srvURL string) string { return srvURL + "/stub_status" }},
+		"API base URL":     {url: func(srvURL string) string { return srvURL + "/api" }},
+		"API base URL (/)": {url: func(srvURL string) string { return srvURL + "/api/" }},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := newPlusAPIServer()
+			defer ts.Close()
+
+			job := New()
+			job.URL = test.url(ts.URL)
+			require.True(t, job.Init())
+			require.True(t, job.Check())
+
+			expected := map[string]int64{
+				"accepts":                                   4968119,
+				"active":                                    122,
+				"handled":                                   4968117,
+				"requests":                                  10624511,
+				"waiting":                                   117,
+				"ssl_handshakes":                            79572,
+				"ssl_handshakes_failed":                     21025,
+				"ssl_session_reuses":                        15762,
+				"server_zone_hg.nginx.org_discarded":        3,
+				"server_zone_hg.nginx.org_processing":       1,
+				"server_zone_hg.nginx.org_received":         47634185,
+				"server_zone_hg.nginx.org_requests":         175276,
+				"server_zone_hg.nginx.org_responses_1xx":    0,
+				"server_zone_hg.nginx.org_responses_2xx":    162948,
+				"server_zone_hg.nginx.org_responses_3xx":    10134,
+				"server_zone_hg.nginx.org_responses_4xx":    2152,
+				"server_zone_hg.nginx.org_responses_5xx":    39,
+				"server_zone_hg.nginx.org_sent":             7255626929,
+				"server_zone_trac.nginx.org_discarded":      0,
+				"server_zone_trac.nginx.org_processing":     0,
+				"server_zone_trac.nginx.org_received":       9018562,
+				"server_zone_trac.nginx.org_requests":       25311,
+				"server_zone_trac.nginx.org_responses_1xx":  0,
+				"server_zone_trac.nginx.org_responses_2xx":  20283,
+				"server_zone_trac.nginx.org_responses_3xx":  4836,
+				"server_zone_trac.nginx.org_responses_4xx":  188,
+				"server_zone_trac.nginx.org_responses_5xx":  4,
+				"server_zone_trac.nginx.org_sent":           612530722,
+				"upstream_trac-backend_active":              2,
+				"upstream_trac-backend_fails":               10,
+				"upstream_trac-backend_health_checks_fails": 1253,
+				"upstream_trac-backend_peers_checking":      0,
+				"upstream_trac-backend_peers_down":          0,
+				"upstream_trac-backend_peers_draining":      0,
+				"upstream_trac-backend_peers_unavail":       0,
+				"upstream_trac-backend_peers_unhealthy":     1,
+				"upstream_trac-backend_peers_up":            1,
+				"upstream_trac-backend_requests":            10040,
+				"upstream_trac-backend_responses_1xx":       0,
+				"upstream_trac-backend_responses_2xx":       9825,
+				"upstream_trac-backend_responses_3xx":       180,
+				"upstream_trac-backend_responses_4xx":       26,
+				"upstream_trac-backend_responses_5xx":       9,
+			}
+
+			mx := job.Collect()
+			assert.Equal(t, expected, mx)
+			assert.NotNil(t, job.plusAPI)
+			assert.True(t, job.Charts().Get("connections_statuses").Obsolete)
+			ensureCollectedHasAllChartsDimsVarsIDs(t, job, mx)
+		})
+	}
+}
+
+func TestNginx_CollectPlusAPI_RemovesZones(t *testing.T) {
+	zones := testPlusHTTPServerZones
+	ts := newPlusAPIServer(func(mux *http.ServeMux) {
+		mux.HandleFunc("/api/8/http/server_zones", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(zones)
+		})
+	})
+	defer ts.Close()
+
+	job := New()
+	job.URL = ts.URL + "/api"
+	require.True(t, job.Init())
+	require.NotNil(t, job.Collect())
+	require.NotNil(t, job.Charts().Get("server_zone_trac.nginx.org_requests"))
+
+	zones = []byte("{}")
+	require.NotNil(t, job.Collect())
+
+	assert.True(t, job.Charts().Get("server_zone_trac.nginx.org_requests").Obsolete)
+	assert.Empty(t, job.serverZones)
+}
+
+func TestNginx_CollectUtilization(t *testing.T) {
+	ts := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(testStatusData)
+			}))
+	defer ts.Close()
+
+	job := New()
+	job.URL = ts.URL
+	job.WorkerConnections = 512
+	job.WorkerProcesses = 2
+	require.True(t, job.Init())
+	require.True(t, job.Check())
+
+	expected := map[string]int64{
+		"accepts":                 36,
+		"active":                  1,
+		"handled":                 36,
+		"reading":                 0,
+		"requests":                126,
+		"waiting":                 0,
+		"writing":                 1,
+		"max_connections":         1024,
+		"connections_utilization": 97,
+	}
+
+	mx := job.Collect()
+	assert.Equal(t, expected, mx)
+	assert.NotNil(t, job.Charts().Get("connections_utilization"))
+	ensureCollectedHasAllChartsDimsVarsIDs(t, job, mx)
+}
+
+func TestNginx_Init_InvalidWorkers(t *testing.T) {
+	job := New()
+	job.WorkerConnections = 512
+	job.WorkerProcesses = 0
+
+	assert.False(t, job.Init())
+}
+
 func TestNginx_InvalidData(t *testing.T) {
 	ts := httptest.NewServer(
 		http.HandlerFunc(
@@ -139,3 +341,39 @@ func TestNginx_404(t *testing.T) {
 	require.True(t, job.Init())
 	assert.False(t, job.Check())
 }
+
+func newPlusAPIServer(overrides ...func(mux *http.ServeMux)) *httptest.Server {
+	mux := http.NewServeMux()
+	handle := func(path string, data []byte) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(data) })
+	}
+	handle("/api", testPlusAPIVersions)
+	handle("/api/", testPlusAPIVersions)
+	handle("/api/8/connections", testPlusConnections)
+	handle("/api/8/http/requests", testPlusHTTPRequests)
+	handle("/api/8/ssl", testPlusSSL)
+	handle("/api/8/http/upstreams", testPlusHTTPUpstreams)
+	for _, override := range overrides {
+		override(mux)
+	}
+	if len(overrides) == 0 {
+		handle("/api/8/http/server_zones", testPlusHTTPServerZones)
+	}
+	return httptest.NewServer(mux)
+}
+
+func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, n *Nginx, mx map[string]int64) {
+	for _, chart := range *n.Charts() {
+		if chart.Obsolete {
+			continue
+		}
+		for _, dim := range chart.Dims {
+			_, ok := mx[dim.ID]
+			assert.Truef(t, ok, "chart '%s' dim '%s': no dim in collected", dim.ID, chart.ID)
+		}
+		for _, v := range chart.Vars {
+			_, ok := mx[v.ID]
+			assert.Truef(t, ok, "chart '%s' dim '%s': no dim in collected", v.ID, chart.ID)
+		}
+	}
+}
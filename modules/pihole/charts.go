@@ -17,6 +17,10 @@ const (
 
 	prioDNSQueriesTypes
 	prioDNSQueriesForwardedDestination
+
+	prioTopClients
+	prioTopPermittedDomains
+	prioTopBlockedDomains
 )
 
 var baseCharts = module.Charts{
@@ -151,6 +155,36 @@ var (
 	}
 )
 
+var (
+	chartTopClients = module.Chart{
+		ID:       "top_clients",
+		Title:    "Top Clients",
+		Units:    "queries",
+		Fam:      "top clients",
+		Ctx:      "pihole.top_clients",
+		Type:     module.Stacked,
+		Priority: prioTopClients,
+	}
+	chartTopPermittedDomains = module.Chart{
+		ID:       "top_permitted_domains",
+		Title:    "Top Permitted Domains",
+		Units:    "queries",
+		Fam:      "top domains",
+		Ctx:      "pihole.top_permitted_domains",
+		Type:     module.Stacked,
+		Priority: prioTopPermittedDomains,
+	}
+	chartTopBlockedDomains = module.Chart{
+		ID:       "top_blocked_domains",
+		Title:    "Top Blocked Domains",
+		Units:    "queries",
+		Fam:      "top domains",
+		Ctx:      "pihole.top_blocked_domains",
+		Type:     module.Stacked,
+		Priority: prioTopBlockedDomains,
+	}
+)
+
 func (p *Pihole) addChartDNSQueriesType() {
 	chart := chartDNSQueriesTypes.Copy()
 	if err := p.Charts().Add(chart); err != nil {
@@ -164,3 +198,20 @@ func (p *Pihole) addChartDNSQueriesForwardedDestinations() {
 		p.Warning(err)
 	}
 }
+
+func (p *Pihole) addChartTopClients() {
+	chart := chartTopClients.Copy()
+	if err := p.Charts().Add(chart); err != nil {
+		p.Warning(err)
+	}
+}
+
+func (p *Pihole) addChartsTopDomains() {
+	charts := module.Charts{
+		chartTopPermittedDomains.Copy(),
+		chartTopBlockedDomains.Copy(),
+	}
+	if err := p.Charts().Add(charts...); err != nil {
+		p.Warning(err)
+	}
+}
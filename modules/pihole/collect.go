@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -34,18 +35,20 @@ const (
 
 func (p *Pihole) collect() (map[string]int64, error) {
 	if p.checkVersion {
-		ver, err := p.queryAPIVersion()
-		if err != nil {
+		if err := p.detectAPIVersion(); err != nil {
 			return nil, err
 		}
-		if ver != wantAPIVersion {
-			return nil, fmt.Errorf("API version: %d, supported version: %d", ver, wantAPIVersion)
-		}
 		p.checkVersion = false
 	}
 
+	if p.apiV6 {
+		if err := p.ensureSession(); err != nil {
+			return nil, err
+		}
+	}
+
 	pmx := new(piholeMetrics)
-	p.queryMetrics(pmx, true)
+	p.queryMetrics(pmx, !p.apiV6)
 
 	if pmx.hasQueryTypes() {
 		p.addQueriesTypesOnce.Do(p.addChartDNSQueriesType)
@@ -53,9 +56,16 @@ func (p *Pihole) collect() (map[string]int64, error) {
 	if pmx.hasForwarders() {
 		p.addFwsDestinationsOnce.Do(p.addChartDNSQueriesForwardedDestinations)
 	}
+	if pmx.hasTopClients() {
+		p.addTopClientsOnce.Do(p.addChartTopClients)
+	}
+	if pmx.hasTopItems() {
+		p.addTopDomainsOnce.Do(p.addChartsTopDomains)
+	}
 
 	mx := make(map[string]int64)
 	p.collectMetrics(mx, pmx)
+	p.collectTopMetrics(mx, pmx)
 
 	return mx, nil
 }
@@ -93,9 +103,20 @@ func (p *Pihole) collectMetrics(mx map[string]int64, pmx *piholeMetrics) {
 	}
 
 	if pmx.hasForwarders() {
+		upstreams := make(map[string]string)
 		for k, v := range pmx.forwarders.Destinations {
-			name := strings.Split(k, "|")[0]
-			mx["destination_"+name] = int64(v * 100)
+			name := destinationName(k)
+			id := "destination_" + name
+			mx[id] += int64(v * 100)
+			switch name {
+			case destinationBlocked, destinationCached, destinationOther:
+			default:
+				upstreams[id] = name
+			}
+		}
+		if chart := p.Charts().Get(chartDNSQueriesForwardedDestination.ID); chart != nil {
+			// the percentages are multiplied by 100, same as the static dimensions
+			p.updateDynamicDims(chart, upstreams, 100)
 		}
 	}
 }
@@ -105,12 +126,29 @@ func (p *Pihole) queryMetrics(pmx *piholeMetrics, doConcurrently bool) {
 
 	var tasks = []task{p.querySummary}
 
-	if p.Password != "" {
+	if p.apiV6 {
+		tasks = []task{
+			p.querySummaryV6,
+			p.queryUpstreamsV6,
+		}
+		if p.TopClientsEntries > 0 {
+			tasks = append(tasks, p.queryTopClientsV6)
+		}
+		if p.TopItemsEntries > 0 {
+			tasks = append(tasks, p.queryTopItemsV6)
+		}
+	} else if p.Password != "" {
 		tasks = []task{
 			p.querySummary,
 			p.queryQueryTypes,
 			p.queryForwardedDestinations,
 		}
+		if p.TopClientsEntries > 0 {
+			tasks = append(tasks, p.queryTopClients)
+		}
+		if p.TopItemsEntries > 0 {
+			tasks = append(tasks, p.queryTopItems)
+		}
 	}
 
 	wg := &sync.WaitGroup{}
@@ -202,6 +240,52 @@ func (p *Pihole) queryForwardedDestinations(pmx *piholeMetrics) {
 	pmx.forwarders = &v
 }
 
+func (p *Pihole) queryTopClients(pmx *piholeMetrics) {
+	req, err := web.NewHTTPRequest(p.Request)
+	if err != nil {
+		p.Error(err)
+		return
+	}
+
+	req.URL.Path = urlPathAPI
+	req.URL.RawQuery = url.Values{
+		urlQueryKeyAuth:       []string{p.Password},
+		urlQueryKeyTopClients: []string{strconv.Itoa(p.TopClientsEntries)},
+	}.Encode()
+
+	var v topClients
+	err = p.doWithDecode(&v, req)
+	if err != nil {
+		p.Error(err)
+		return
+	}
+
+	pmx.topClients = &v
+}
+
+func (p *Pihole) queryTopItems(pmx *piholeMetrics) {
+	req, err := web.NewHTTPRequest(p.Request)
+	if err != nil {
+		p.Error(err)
+		return
+	}
+
+	req.URL.Path = urlPathAPI
+	req.URL.RawQuery = url.Values{
+		urlQueryKeyAuth:     []string{p.Password},
+		urlQueryKeyTopItems: []string{strconv.Itoa(p.TopItemsEntries)},
+	}.Encode()
+
+	var v topItems
+	err = p.doWithDecode(&v, req)
+	if err != nil {
+		p.Error(err)
+		return
+	}
+
+	pmx.topItems = &v
+}
+
 func (p *Pihole) queryAPIVersion() (int, error) {
 	req, err := web.NewHTTPRequest(p.Request)
 	if err != nil {
@@ -229,6 +313,9 @@ func (p *Pihole) doWithDecode(dst interface{}, req *http.Request) error {
 	}
 	defer closeBody(resp)
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("%s: %w", req.URL, errUnauthorized)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("%s returned %d status code", req.URL, resp.StatusCode)
 	}
@@ -250,6 +337,27 @@ func (p *Pihole) doWithDecode(dst interface{}, req *http.Request) error {
 	return nil
 }
 
+const (
+	destinationBlocked = "blocked"
+	destinationCached  = "cached"
+	destinationOther   = "other"
+)
+
+// destinationName returns the forward destination name ('name|ip' is the destination key).
+// The blocklist and cache pseudo-destinations are 'blocklist' and 'cache' since FTL v5.
+func destinationName(key string) string {
+	name, ip, _ := strings.Cut(key, "|")
+	switch name {
+	case "blocklist":
+		return destinationBlocked
+	case "cache":
+		return destinationCached
+	case "":
+		return ip
+	}
+	return name
+}
+
 func isEmptyArray(data []byte) bool {
 	empty := "[]"
 	return len(data) == len(empty) && string(data) == empty
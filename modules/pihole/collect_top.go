@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pihole
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+const (
+	// FTL privacy levels: https://docs.pi-hole.net/ftldns/privacylevels/
+	privacyLevelHideDomains        = 1
+	privacyLevelHideDomainsClients = 2
+
+	// hiddenName is the name FTL returns instead of the client or domain name that is hidden by the privacy level.
+	hiddenName = "hidden"
+)
+
+type topEntry struct {
+	id    string
+	name  string
+	value int64
+}
+
+func (p *Pihole) collectTopMetrics(mx map[string]int64, pmx *piholeMetrics) {
+	if pmx.hasSummary() && !p.privacyWarned {
+		level := pmx.summary.PrivacyLevel
+		if (p.TopClientsEntries > 0 && level >= privacyLevelHideDomainsClients) ||
+			(p.TopItemsEntries > 0 && level >= privacyLevelHideDomains) {
+			p.Warningf("Pi-hole privacy level is %d, the top clients/domains are hidden", level)
+			p.privacyWarned = true
+		}
+	}
+
+	if pmx.hasTopClients() {
+		var entries []topEntry
+		for k, v := range pmx.topClients.Sources {
+			// 'hostname|ip' or 'ip' if the hostname is unknown
+			name, ip, ok := strings.Cut(k, "|")
+			if !ok {
+				ip = name
+			}
+			if name == "" {
+				name = ip
+			}
+			entries = append(entries, topEntry{id: ip, name: name, value: v})
+		}
+		p.collectTopEntries(mx, chartTopClients.ID, "top_client_", entries, p.TopClientsEntries)
+	}
+
+	if pmx.hasTopItems() {
+		p.collectTopEntries(mx, chartTopPermittedDomains.ID, "top_perm_domain_",
+			domainTopEntries(pmx.topItems.TopQueries), p.TopItemsEntries)
+		p.collectTopEntries(mx, chartTopBlockedDomains.ID, "top_blocked_domain_",
+			domainTopEntries(pmx.topItems.TopAds), p.TopItemsEntries)
+	}
+}
+
+// collectTopEntries writes the top entries values and updates the chart dimensions: the dimensions of the entries
+// that are not in the top anymore are removed, at most 'limit' dimensions (the entries with the highest values)
+// are charted.
+func (p *Pihole) collectTopEntries(mx map[string]int64, chartID, prefix string, entries []topEntry, limit int) {
+	chart := p.Charts().Get(chartID)
+	if chart == nil {
+		return
+	}
+
+	var visible []topEntry
+	for _, e := range entries {
+		if e.id != "" && e.id != hiddenName && e.name != hiddenName {
+			visible = append(visible, e)
+		}
+	}
+	sort.Slice(visible, func(i, j int) bool {
+		if visible[i].value == visible[j].value {
+			return visible[i].id < visible[j].id
+		}
+		return visible[i].value > visible[j].value
+	})
+	if len(visible) > limit {
+		visible = visible[:limit]
+	}
+
+	current := make(map[string]string)
+	for _, e := range visible {
+		id := prefix + e.id
+		mx[id] = e.value
+		current[id] = e.name
+	}
+
+	p.updateDynamicDims(chart, current, 0)
+}
+
+// updateDynamicDims adds the dimensions (ID to name) that are not on the chart yet and removes the previously added
+// dimensions that are not in the current set. The added dimensions have the div (0 means no division).
+func (p *Pihole) updateDynamicDims(chart *module.Chart, current map[string]string, div int) {
+	dims, ok := p.dynamicDims[chart.ID]
+	if !ok {
+		dims = make(map[string]bool)
+		p.dynamicDims[chart.ID] = dims
+	}
+
+	for id, name := range current {
+		if dims[id] {
+			continue
+		}
+		if err := chart.AddDim(&module.Dim{ID: id, Name: name, Div: div}); err != nil {
+			p.Warning(err)
+			continue
+		}
+		dims[id] = true
+		chart.MarkNotCreated()
+	}
+
+	for id := range dims {
+		if _, ok := current[id]; ok {
+			continue
+		}
+		delete(dims, id)
+		if err := chart.MarkDimRemove(id, true); err != nil {
+			p.Warning(err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
+
+func domainTopEntries(items topItem) []topEntry {
+	var entries []topEntry
+	for k, v := range items {
+		entries = append(entries, topEntry{id: k, name: k, value: v})
+	}
+	return entries
+}
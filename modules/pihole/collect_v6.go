@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package pihole
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+// Pi-hole v6 REST API: https://docs.pi-hole.net/api/
+const (
+	urlPathV6Auth       = "/api/auth"
+	urlPathV6InfoLogin  = "/api/info/login" // no auth
+	urlPathV6Summary    = "/api/stats/summary"
+	urlPathV6Blocking   = "/api/dns/blocking"
+	urlPathV6Upstreams  = "/api/stats/upstreams"
+	urlPathV6TopClients = "/api/stats/top_clients"
+	urlPathV6TopDomains = "/api/stats/top_domains"
+
+	headerSessionID = "X-FTL-SID"
+
+	// defaultSessionValidity is used if the session validity is unknown (no password, no session).
+	defaultSessionValidity = time.Minute * 5
+	// sessionRenewMargin is the time before the session expiration when a new session is created.
+	sessionRenewMargin = time.Second * 30
+)
+
+var errUnauthorized = errors.New("unauthorized")
+
+// detectAPIVersion checks whether the v6 REST API is available, falls back to the legacy PHP API otherwise.
+func (p *Pihole) detectAPIVersion() error {
+	if p.isAPIV6() {
+		p.Info("using the Pi-hole v6 REST API")
+		p.apiV6 = true
+		return nil
+	}
+
+	ver, err := p.queryAPIVersion()
+	if err != nil {
+		return err
+	}
+	if ver != wantAPIVersion {
+		return fmt.Errorf("API version: %d, supported version: %d", ver, wantAPIVersion)
+	}
+	return nil
+}
+
+func (p *Pihole) isAPIV6() bool {
+	req, err := web.NewHTTPRequest(p.Request)
+	if err != nil {
+		return false
+	}
+
+	req.URL.Path = urlPathV6InfoLogin
+
+	var v map[string]interface{}
+	if err := p.doWithDecode(&v, req); err != nil {
+		p.Debugf("v6 REST API detection: %v", err)
+		return false
+	}
+	return true
+}
+
+// ensureSession logs in if there is no session or the session is about to expire.
+// The session validity is extended by Pi-hole on every authenticated request.
+func (p *Pihole) ensureSession() error {
+	if p.sessionExpires.After(time.Now().Add(sessionRenewMargin)) {
+		return nil
+	}
+
+	sid, validity, err := p.login()
+	if err != nil {
+		p.sid, p.sessionExpires = "", time.Time{}
+		return fmt.Errorf("login: %v", err)
+	}
+
+	p.Debug("logged in, new session")
+	p.sid, p.sessionValidity = sid, validity
+	p.sessionExpires = time.Now().Add(validity)
+	return nil
+}
+
+func (p *Pihole) login() (string, time.Duration, error) {
+	body, err := json.Marshal(map[string]string{"password": p.Password})
+	if err != nil {
+		return "", 0, err
+	}
+
+	r := p.Request.Copy()
+	r.Method = http.MethodPost
+	r.Body = string(body)
+	r.Headers["Content-Type"] = "application/json"
+
+	req, err := web.NewHTTPRequest(r)
+	if err != nil {
+		return "", 0, err
+	}
+	req.URL.Path = urlPathV6Auth
+
+	var v authResponseV6
+	if err := p.doWithDecode(&v, req); err != nil {
+		if errors.Is(err, errUnauthorized) {
+			return "", 0, errors.New("wrong password or app password")
+		}
+		return "", 0, err
+	}
+	if !v.Session.Valid {
+		msg := "invalid session"
+		if v.Session.Message != nil {
+			msg = *v.Session.Message
+		}
+		return "", 0, errors.New(msg)
+	}
+
+	validity := time.Duration(v.Session.Validity) * time.Second
+	if validity <= 0 {
+		validity = defaultSessionValidity
+	}
+	// no session id if no password is set
+	if v.Session.SID == nil {
+		return "", validity, nil
+	}
+	return *v.Session.SID, validity, nil
+}
+
+func (p *Pihole) logout() {
+	if p.sid == "" {
+		return
+	}
+
+	r := p.Request.Copy()
+	r.Method = http.MethodDelete
+	r.Headers[headerSessionID] = p.sid
+
+	req, err := web.NewHTTPRequest(r)
+	if err != nil {
+		return
+	}
+	req.URL.Path = urlPathV6Auth
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		p.Debugf("logout: %v", err)
+		return
+	}
+	closeBody(resp)
+	p.sid = ""
+}
+
+// queryV6 queries the v6 REST API. The session is invalidated (a new one is created on the next data collection)
+// if the request is unauthorized. The v6 queries are not done concurrently.
+func (p *Pihole) queryV6(dst interface{}, path string, query url.Values) error {
+	r := p.Request.Copy()
+	if p.sid != "" {
+		r.Headers[headerSessionID] = p.sid
+	}
+
+	req, err := web.NewHTTPRequest(r)
+	if err != nil {
+		return err
+	}
+	req.URL.Path = path
+	req.URL.RawQuery = query.Encode()
+
+	if err := p.doWithDecode(dst, req); err != nil {
+		if errors.Is(err, errUnauthorized) {
+			p.sid, p.sessionExpires = "", time.Time{}
+		}
+		return err
+	}
+
+	p.sessionExpires = time.Now().Add(p.sessionValidity)
+	return nil
+}
+
+func (p *Pihole) querySummaryV6(pmx *piholeMetrics) {
+	var v summaryV6
+	if err := p.queryV6(&v, urlPathV6Summary, nil); err != nil {
+		p.Error(err)
+		return
+	}
+
+	var blocking blockingV6
+	if err := p.queryV6(&blocking, urlPathV6Blocking, nil); err != nil {
+		p.Error(err)
+		return
+	}
+
+	summary := summaryRawMetrics{
+		DomainsBeingBlocked: v.Gravity.DomainsBeingBlocked,
+		DNSQueriesToday:     v.Queries.Total,
+		AdsBlockedToday:     v.Queries.Blocked,
+		AdsPercentageToday:  v.Queries.PercentBlocked,
+		UniqueDomains:       v.Queries.UniqueDomains,
+		QueriesForwarded:    v.Queries.Forwarded,
+		QueriesCached:       v.Queries.Cached,
+		ClientsEverSeen:     v.Clients.Total,
+		UniqueClients:       v.Clients.Active,
+		DNSQueriesAllTypes:  v.Queries.Total,
+		Status:              blocking.Blocking,
+	}
+	if v.Gravity.LastUpdate > 0 {
+		lastUpdate := v.Gravity.LastUpdate
+		summary.GravityLastUpdated.FileExists = true
+		summary.GravityLastUpdated.Absolute = &lastUpdate
+	}
+	pmx.summary = &summary
+
+	// the legacy API query types are percentages
+	var total int64
+	for _, n := range v.Queries.Types {
+		total += n
+	}
+	if total == 0 {
+		return
+	}
+	perc := func(typ string) float64 { return float64(v.Queries.Types[typ]) * 100 / float64(total) }
+
+	var qt queryTypesMetrics
+	qt.Types.A = perc("A")
+	qt.Types.AAAA = perc("AAAA")
+	qt.Types.ANY = perc("ANY")
+	qt.Types.SRV = perc("SRV")
+	qt.Types.SOA = perc("SOA")
+	qt.Types.PTR = perc("PTR")
+	qt.Types.TXT = perc("TXT")
+	pmx.queryTypes = &qt
+}
+
+func (p *Pihole) queryUpstreamsV6(pmx *piholeMetrics) {
+	var v upstreamsV6
+	if err := p.queryV6(&v, urlPathV6Upstreams, nil); err != nil {
+		p.Error(err)
+		return
+	}
+	if v.TotalQueries == 0 {
+		return
+	}
+
+	// the legacy API destinations are 'name|ip' percentages, the blocklist and cache are 'blocked' and 'cached'
+	dsts := make(map[string]float64)
+	other := v.TotalQueries
+	for _, u := range v.Upstreams {
+		var key string
+		switch u.IP {
+		case "blocklist":
+			key = "blocked|blocked"
+		case "cache":
+			key = "cached|cached"
+		case "other":
+			key = "other|other"
+		default:
+			name := u.Name
+			if name == "" {
+				name = u.IP
+			}
+			key = name + "|" + u.IP
+		}
+		dsts[key] += float64(u.Count) * 100 / float64(v.TotalQueries)
+		other -= u.Count
+	}
+	if _, ok := dsts["other|other"]; !ok {
+		if other < 0 {
+			other = 0
+		}
+		dsts["other|other"] = float64(other) * 100 / float64(v.TotalQueries)
+	}
+
+	pmx.forwarders = &forwardDestinations{Destinations: dsts}
+}
+
+func (p *Pihole) queryTopClientsV6(pmx *piholeMetrics) {
+	var v topClientsV6
+	query := url.Values{"count": []string{strconv.Itoa(p.TopClientsEntries)}}
+	if err := p.queryV6(&v, urlPathV6TopClients, query); err != nil {
+		p.Error(err)
+		return
+	}
+
+	clients := topClients{Sources: make(topItem)}
+	for _, c := range v.Clients {
+		clients.Sources[c.Name+"|"+c.IP] = c.Count
+	}
+	pmx.topClients = &clients
+}
+
+func (p *Pihole) queryTopItemsV6(pmx *piholeMetrics) {
+	var permitted, blocked topDomainsV6
+	query := url.Values{"count": []string{strconv.Itoa(p.TopItemsEntries)}}
+	if err := p.queryV6(&permitted, urlPathV6TopDomains, query); err != nil {
+		p.Error(err)
+		return
+	}
+	query.Set("blocked", "true")
+	if err := p.queryV6(&blocked, urlPathV6TopDomains, query); err != nil {
+		p.Error(err)
+		return
+	}
+
+	items := topItems{TopQueries: make(topItem), TopAds: make(topItem)}
+	for _, d := range permitted.Domains {
+		items.TopQueries[d.Domain] = d.Count
+	}
+	for _, d := range blocked.Domains {
+		items.TopAds[d.Domain] = d.Count
+	}
+	pmx.topItems = &items
+}
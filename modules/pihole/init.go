@@ -66,6 +66,18 @@ func getWebPassword(path string) (string, error) {
 	return password, nil
 }
 
+func readPasswordFile(path string) (string, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	pass := strings.TrimSpace(string(bs))
+	if pass == "" {
+		return "", fmt.Errorf("'%s' is empty", path)
+	}
+	return pass, nil
+}
+
 func isLocalHost(u string) bool {
 	if strings.Contains(u, "127.0.0.1") {
 		return true
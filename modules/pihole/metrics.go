@@ -2,10 +2,14 @@
 
 package pihole
 
+import "encoding/json"
+
 type piholeMetrics struct {
 	summary    *summaryRawMetrics   // ?summary
 	queryTypes *queryTypesMetrics   // ?getQueryTypes
 	forwarders *forwardDestinations // ?getForwardedDestinations
+	topClients *topClients          // ?topClients
+	topItems   *topItems            // ?topItems
 }
 
 func (p piholeMetrics) hasSummary() bool {
@@ -17,6 +21,12 @@ func (p piholeMetrics) hasQueryTypes() bool {
 func (p piholeMetrics) hasForwarders() bool {
 	return p.forwarders != nil && len(p.forwarders.Destinations) > 0
 }
+func (p piholeMetrics) hasTopClients() bool {
+	return p.topClients != nil
+}
+func (p piholeMetrics) hasTopItems() bool {
+	return p.topItems != nil
+}
 
 type piholeAPIVersion struct {
 	Version int
@@ -63,22 +73,91 @@ type forwardDestinations struct {
 	Destinations map[string]float64 `json:"forward_destinations"`
 }
 
-//type (
-//	item map[string]int64
-//
-//	topClients struct {
-//		Sources item `json:"top_sources"`
-//	}
-//	topItems struct {
-//		TopQueries item `json:"top_queries"`
-//		TopAds     item `json:"top_ads"`
-//	}
-//)
-//
-//func (i *item) UnmarshalJSON(data []byte) error {
-//	if isEmptyArray(data) {
-//		return nil
-//	}
-//	type plain *item
-//	return json.Unmarshal(data, (plain)(i))
-//}
+type (
+	topItem map[string]int64
+
+	// ?topClients
+	topClients struct {
+		Sources topItem `json:"top_sources"`
+	}
+	// ?topItems
+	topItems struct {
+		TopQueries topItem `json:"top_queries"`
+		TopAds     topItem `json:"top_ads"`
+	}
+)
+
+// UnmarshalJSON handles the empty array, it is returned instead of the empty object if there are no items.
+func (i *topItem) UnmarshalJSON(data []byte) error {
+	if isEmptyArray(data) {
+		return nil
+	}
+	type plain topItem
+	return json.Unmarshal(data, (*plain)(i))
+}
+
+// Pi-hole v6 REST API: https://docs.pi-hole.net/api/
+
+// POST /api/auth
+type authResponseV6 struct {
+	Session struct {
+		Valid    bool    `json:"valid"`
+		SID      *string `json:"sid"`
+		Validity int64   `json:"validity"`
+		Message  *string `json:"message"`
+	} `json:"session"`
+}
+
+// GET /api/stats/summary
+type summaryV6 struct {
+	Queries struct {
+		Total          int64            `json:"total"`
+		Blocked        int64            `json:"blocked"`
+		PercentBlocked float64          `json:"percent_blocked"`
+		UniqueDomains  int64            `json:"unique_domains"`
+		Forwarded      int64            `json:"forwarded"`
+		Cached         int64            `json:"cached"`
+		Types          map[string]int64 `json:"types"`
+	} `json:"queries"`
+	Clients struct {
+		Active int64 `json:"active"`
+		Total  int64 `json:"total"`
+	} `json:"clients"`
+	Gravity struct {
+		DomainsBeingBlocked int64 `json:"domains_being_blocked"`
+		LastUpdate          int64 `json:"last_update"`
+	} `json:"gravity"`
+}
+
+// GET /api/dns/blocking
+type blockingV6 struct {
+	Blocking string `json:"blocking"`
+}
+
+// GET /api/stats/upstreams
+type upstreamsV6 struct {
+	Upstreams []struct {
+		IP    string `json:"ip"`
+		Name  string `json:"name"`
+		Port  int    `json:"port"`
+		Count int64  `json:"count"`
+	} `json:"upstreams"`
+	TotalQueries int64 `json:"total_queries"`
+}
+
+// GET /api/stats/top_clients
+type topClientsV6 struct {
+	Clients []struct {
+		IP    string `json:"ip"`
+		Name  string `json:"name"`
+		Count int64  `json:"count"`
+	} `json:"clients"`
+}
+
+// GET /api/stats/top_domains
+type topDomainsV6 struct {
+	Domains []struct {
+		Domain string `json:"domain"`
+		Count  int64  `json:"count"`
+	} `json:"domains"`
+}
@@ -37,12 +37,21 @@ func New() *Pihole {
 		charts:                 baseCharts.Copy(),
 		addQueriesTypesOnce:    &sync.Once{},
 		addFwsDestinationsOnce: &sync.Once{},
+		addTopClientsOnce:      &sync.Once{},
+		addTopDomainsOnce:      &sync.Once{},
+		dynamicDims:            make(map[string]map[string]bool),
 	}
 }
 
 type Config struct {
 	web.HTTP      `yaml:",inline"`
 	SetupVarsPath string `yaml:"setup_vars_path"`
+	// PasswordFile is the file to read the web password (or the v6 app password) from.
+	PasswordFile string `yaml:"password_file"`
+	// TopClientsEntries is the number of the top clients (by queries) to collect, disabled if not set.
+	TopClientsEntries int `yaml:"top_clients_entries"`
+	// TopItemsEntries is the number of the top permitted and blocked domains to collect, disabled if not set.
+	TopItemsEntries int `yaml:"top_items_entries"`
 }
 
 type Pihole struct {
@@ -52,9 +61,22 @@ type Pihole struct {
 	charts                 *module.Charts
 	addQueriesTypesOnce    *sync.Once
 	addFwsDestinationsOnce *sync.Once
+	addTopClientsOnce      *sync.Once
+	addTopDomainsOnce      *sync.Once
 
 	httpClient   *http.Client
 	checkVersion bool
+	apiV6        bool
+
+	// v6 REST API session
+	sid             string
+	sessionValidity time.Duration
+	sessionExpires  time.Time
+
+	// dynamicDims is the current dynamic (top clients/domains, upstreams) dimensions, the key is the chart ID.
+	dynamicDims map[string]map[string]bool
+	// privacyWarned is set when the privacy level hiding the top clients/domains is reported.
+	privacyWarned bool
 }
 
 func (p *Pihole) Init() bool {
@@ -70,6 +92,15 @@ func (p *Pihole) Init() bool {
 	}
 	p.httpClient = httpClient
 
+	if p.PasswordFile != "" {
+		pass, err := readPasswordFile(p.PasswordFile)
+		if err != nil {
+			p.Errorf("read password file: %v", err)
+			return false
+		}
+		p.Password = pass
+	}
+
 	p.Password = p.getWebPassword()
 	if p.Password == "" {
 		p.Warning("no web password, not all metrics available")
@@ -103,6 +134,7 @@ func (p *Pihole) Collect() map[string]int64 {
 
 func (p *Pihole) Cleanup() {
 	if p.httpClient != nil {
+		p.logout()
 		p.httpClient.CloseIdleConnections()
 	}
 }
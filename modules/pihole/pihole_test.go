@@ -3,10 +3,12 @@
 package pihole
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/netdata/go.d.plugin/pkg/web"
@@ -27,6 +29,16 @@ var (
 	dataGetForwardDestinationsResp, _ = os.ReadFile("testdata/getForwardDestinations.json")
 	dataTopClientsResp, _             = os.ReadFile("testdata/topClients.json")
 	dataTopItemsResp, _               = os.ReadFile("testdata/topItems.json")
+	dataTopClientsChangedResp, _      = os.ReadFile("testdata/topClientsChanged.json")
+	dataTopItemsWithAdsResp, _        = os.ReadFile("testdata/topItemsWithAds.json")
+
+	dataV6InfoLoginResp, _         = os.ReadFile("testdata/v6/info_login.json")
+	dataV6SummaryResp, _           = os.ReadFile("testdata/v6/summary.json")
+	dataV6BlockingResp, _          = os.ReadFile("testdata/v6/blocking.json")
+	dataV6UpstreamsResp, _         = os.ReadFile("testdata/v6/upstreams.json")
+	dataV6TopClientsResp, _        = os.ReadFile("testdata/v6/top_clients.json")
+	dataV6TopDomainsResp, _        = os.ReadFile("testdata/v6/top_domains.json")
+	dataV6TopDomainsBlockedResp, _ = os.ReadFile("testdata/v6/top_domains_blocked.json")
 )
 
 func TestPihole_Init(t *testing.T) {
@@ -62,6 +74,21 @@ func TestPihole_Init(t *testing.T) {
 	}
 }
 
+func TestPihole_Init_PasswordFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(path, []byte("secret\n"), 0600))
+
+	p := New()
+	p.PasswordFile = path
+	require.True(t, p.Init())
+	assert.Equal(t, "secret", p.Password)
+
+	p = New()
+	p.PasswordFile = filepath.Join(dir, "not_exists")
+	assert.False(t, p.Init())
+}
+
 func TestPihole_Check(t *testing.T) {
 	tests := map[string]struct {
 		wantFail bool
@@ -175,6 +202,176 @@ func TestPihole_Collect(t *testing.T) {
 	}
 }
 
+func TestPihole_Collect_TopEntries(t *testing.T) {
+	p, srv := New(), mockPiholeServer{topItemsResp: dataTopItemsWithAdsResp}.newHTTPServer()
+	defer srv.Close()
+
+	p.SetupVarsPath = pathSetupVarsOK
+	p.URL = srv.URL
+	p.TopClientsEntries = 5
+	p.TopItemsEntries = 2
+	require.True(t, p.Init())
+
+	mx := p.Collect()
+
+	assert.Len(t, *p.Charts(), len(baseCharts)+5)
+	assert.Equal(t, int64(36), mx["top_client_127.0.0.1"])
+	assert.Equal(t, int64(12), mx["top_perm_domain_222.222.67.208.in-addr.arpa"])
+	assert.Equal(t, int64(11), mx["top_perm_domain_220.220.67.208.in-addr.arpa"])
+	assert.NotContains(t, mx, "top_perm_domain_api.github.com", "limited to the top entries")
+	assert.Equal(t, int64(5), mx["top_blocked_domain_ads.example.com"])
+	assert.NotContains(t, mx, "top_blocked_domain_hidden", "hidden by the privacy level")
+
+	clients := p.Charts().Get(chartTopClients.ID)
+	require.NotNil(t, clients)
+	require.True(t, clients.GetDim("top_client_127.0.0.1") != nil)
+	assert.Equal(t, "localhost", clients.GetDim("top_client_127.0.0.1").Name)
+	assert.Len(t, p.Charts().Get(chartTopPermittedDomains.ID).Dims, 2)
+	assert.Len(t, p.Charts().Get(chartTopBlockedDomains.ID).Dims, 1)
+
+	srvChanged := mockPiholeServer{
+		topClientsResp: dataTopClientsChangedResp,
+		topItemsResp:   dataTopItemsWithAdsResp,
+	}.newHTTPServer()
+	defer srvChanged.Close()
+	p.URL = srvChanged.URL
+
+	mx = p.Collect()
+
+	assert.Equal(t, int64(20), mx["top_client_192.168.0.2"])
+	assert.NotContains(t, mx, "top_client_127.0.0.1")
+	assert.NotContains(t, mx, "top_client_0.0.0.0", "hidden by the privacy level")
+	assert.Equal(t, "192.168.0.2", clients.GetDim("top_client_192.168.0.2").Name)
+	assert.True(t, clients.GetDim("top_client_127.0.0.1").Obsolete, "not in the top anymore")
+}
+
+func TestPihole_Collect_APIV6(t *testing.T) {
+	mock := &mockPiholeV6Server{password: "secret", sid: "session1"}
+	srv := mock.newHTTPServer()
+	defer srv.Close()
+
+	p := New()
+	p.URL = srv.URL
+	p.Password = "secret"
+	p.TopClientsEntries = 5
+	p.TopItemsEntries = 5
+	require.True(t, p.Init())
+
+	expected := map[string]int64{
+		"A":                                  5000,
+		"AAAA":                               2500,
+		"ANY":                                0,
+		"PTR":                                1000,
+		"SOA":                                500,
+		"SRV":                                0,
+		"TXT":                                0,
+		"ads_blocked_today":                  100,
+		"ads_blocked_today_perc":             10000,
+		"ads_percentage_today":               1000,
+		"blocking_status_disabled":           0,
+		"blocking_status_enabled":            1,
+		"blocklist_last_update":              106273651,
+		"destination_1.1.1.1":                1500,
+		"destination_blocked":                1000,
+		"destination_cached":                 3000,
+		"destination_dns.google":             4000,
+		"destination_other":                  500,
+		"dns_queries_today":                  1000,
+		"domains_being_blocked":              120000,
+		"queries_cached":                     300,
+		"queries_cached_perc":                30000,
+		"queries_forwarded":                  600,
+		"queries_forwarded_perc":             60000,
+		"top_blocked_domain_ads.example.com": 80,
+		"top_client_192.168.0.10":            600,
+		"top_client_192.168.0.11":            400,
+		"top_perm_domain_api.github.com":     200,
+		"top_perm_domain_example.com":        100,
+		"unique_clients":                     5,
+	}
+
+	mx := p.Collect()
+	copyBlockListLastUpdate(mx, expected)
+	require.Equal(t, expected, mx)
+	assert.True(t, p.apiV6)
+	assert.Len(t, *p.Charts(), len(baseCharts)+5)
+	fwd := p.Charts().Get(chartDNSQueriesForwardedDestination.ID)
+	require.NotNil(t, fwd)
+	assert.Len(t, fwd.Dims, 5, "cached, blocked, other and an upstream per dimension")
+	if dim := fwd.GetDim("destination_dns.google"); assert.NotNil(t, dim) {
+		assert.Equal(t, 100, dim.Div)
+	}
+	assert.NotNil(t, fwd.GetDim("destination_1.1.1.1"))
+	assert.Equal(t, 1, mock.logins)
+
+	mx = p.Collect()
+	require.NotNil(t, mx)
+	assert.Equal(t, 1, mock.logins, "the session is reused")
+
+	// the session is expired on the Pi-hole side
+	mock.sid = "session2"
+	assert.Nil(t, p.Collect())
+	mx = p.Collect()
+	require.NotNil(t, mx)
+	assert.Equal(t, 2, mock.logins, "a new session")
+
+	p.Cleanup()
+	assert.Equal(t, 1, mock.logouts)
+}
+
+func TestPihole_Collect_APIV6WrongPassword(t *testing.T) {
+	mock := &mockPiholeV6Server{password: "secret", sid: "session1"}
+	srv := mock.newHTTPServer()
+	defer srv.Close()
+
+	p := New()
+	p.URL = srv.URL
+	p.Password = "wrong"
+	require.True(t, p.Init())
+
+	assert.Nil(t, p.Collect())
+	assert.False(t, p.Check())
+}
+
+func TestPihole_Collect_ForwardedUpstreams(t *testing.T) {
+	p := New()
+	p.addChartDNSQueriesForwardedDestinations()
+
+	mx := make(map[string]int64)
+	p.collectMetrics(mx, &piholeMetrics{forwarders: &forwardDestinations{Destinations: map[string]float64{
+		"blocklist|blocklist":     10,
+		"cache|cache":             40,
+		"other|other":             5,
+		"dns.google|8.8.8.8":      30,
+		"one.one.one.one|1.1.1.1": 15,
+	}}})
+
+	assert.Equal(t, map[string]int64{
+		"destination_blocked":         1000,
+		"destination_cached":          4000,
+		"destination_other":           500,
+		"destination_dns.google":      3000,
+		"destination_one.one.one.one": 1500,
+	}, mx)
+
+	chart := p.Charts().Get(chartDNSQueriesForwardedDestination.ID)
+	require.NotNil(t, chart)
+	assert.Len(t, chart.Dims, 5)
+
+	// one.one.one.one is not used anymore
+	mx = make(map[string]int64)
+	p.collectMetrics(mx, &piholeMetrics{forwarders: &forwardDestinations{Destinations: map[string]float64{
+		"blocklist|blocklist": 10,
+		"cache|cache":         40,
+		"other|other":         5,
+		"dns.google|8.8.8.8":  45,
+	}}})
+
+	assert.Equal(t, int64(4500), mx["destination_dns.google"])
+	assert.NotContains(t, mx, "destination_one.one.one.one")
+	assert.True(t, chart.GetDim("destination_one.one.one.one").Obsolete)
+}
+
 func caseSuccessWithWebPassword(t *testing.T) (*Pihole, func()) {
 	p, srv := New(), mockPiholeServer{}.newHTTPServer()
 
@@ -218,6 +415,8 @@ type mockPiholeServer struct {
 	errOnGetForwardDst bool
 	errOnTopClients    bool
 	errOnTopItems      bool
+	topClientsResp     []byte
+	topItemsResp       []byte
 }
 
 func (m mockPiholeServer) newHTTPServer() *httptest.Server {
@@ -260,8 +459,14 @@ func (m mockPiholeServer) newHTTPServer() *httptest.Server {
 			data, isErr = dataGetForwardDestinationsResp, m.errOnGetForwardDst
 		case r.URL.Query().Has(urlQueryKeyTopClients):
 			data, isErr = dataTopClientsResp, m.errOnTopClients
+			if m.topClientsResp != nil {
+				data = m.topClientsResp
+			}
 		case r.URL.Query().Has(urlQueryKeyTopItems):
 			data, isErr = dataTopItemsResp, m.errOnTopItems
+			if m.topItemsResp != nil {
+				data = m.topItemsResp
+			}
 		}
 
 		if isErr {
@@ -272,6 +477,63 @@ func (m mockPiholeServer) newHTTPServer() *httptest.Server {
 	}))
 }
 
+type mockPiholeV6Server struct {
+	password string
+	sid      string
+	logins   int
+	logouts  int
+}
+
+func (m *mockPiholeV6Server) newHTTPServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case urlPathV6InfoLogin:
+			_, _ = w.Write(dataV6InfoLoginResp)
+			return
+		case urlPathV6Auth:
+			switch r.Method {
+			case http.MethodPost:
+				var v struct{ Password string }
+				if err := json.NewDecoder(r.Body).Decode(&v); err != nil || v.Password != m.password {
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"session":{"valid":false,"sid":null,"validity":-1,"message":"password incorrect"}}`))
+					return
+				}
+				m.logins++
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"session":{"valid":true,"sid":"%s","validity":1800,"message":"password correct"}}`, m.sid)))
+			case http.MethodDelete:
+				m.logouts++
+				w.WriteHeader(http.StatusNoContent)
+			}
+			return
+		}
+
+		if r.Header.Get(headerSessionID) != m.sid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case urlPathV6Summary:
+			_, _ = w.Write(dataV6SummaryResp)
+		case urlPathV6Blocking:
+			_, _ = w.Write(dataV6BlockingResp)
+		case urlPathV6Upstreams:
+			_, _ = w.Write(dataV6UpstreamsResp)
+		case urlPathV6TopClients:
+			_, _ = w.Write(dataV6TopClientsResp)
+		case urlPathV6TopDomains:
+			if r.URL.Query().Get("blocked") == "true" {
+				_, _ = w.Write(dataV6TopDomainsBlockedResp)
+			} else {
+				_, _ = w.Write(dataV6TopDomainsResp)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
 func copyBlockListLastUpdate(dst, src map[string]int64) {
 	k := "blocklist_last_update"
 	if v, ok := src[k]; ok {
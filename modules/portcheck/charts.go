@@ -7,18 +7,29 @@ import (
 	"strconv"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 )
 
 const (
 	prioCheckStatus = module.Priority + iota
 	prioCheckInStatusDuration
 	prioCheckLatency
+	prioCheckLatencyHistogram
+	prioCheckStreak
+	prioCheckAddresses
+	prioAddressCheckStatus
+	prioAddressCheckInStatusDuration
+	prioAddressCheckLatency
+	prioUDPCheckStatus
+	prioUDPCheckInStatusDuration
+	prioUDPCheckLatency
 )
 
 var chartsTmpl = module.Charts{
 	checkStatusChartTmpl.Copy(),
 	checkInStateDurationChartTmpl.Copy(),
 	checkConnectionLatencyChartTmpl.Copy(),
+	checkStreakChartTmpl.Copy(),
 }
 
 var checkStatusChartTmpl = module.Chart{
@@ -59,6 +70,132 @@ var checkConnectionLatencyChartTmpl = module.Chart{
 	},
 }
 
+var checkConnectionLatencyHistogramChartTmpl = module.Chart{
+	ID:       "port_%d_connection_latency_histogram",
+	Title:    "TCP Connection Latency Histogram",
+	Units:    "connections/s",
+	Fam:      "latency",
+	Ctx:      "portcheck.latency_histogram",
+	Type:     module.Stacked,
+	Priority: prioCheckLatencyHistogram,
+}
+
+var checkStreakChartTmpl = module.Chart{
+	ID:       "port_%d_streak",
+	Title:    "Consecutive Checks",
+	Units:    "checks",
+	Fam:      "streak",
+	Ctx:      "portcheck.streak",
+	Priority: prioCheckStreak,
+	Dims: module.Dims{
+		{ID: "port_%d_success_streak", Name: "success"},
+		{ID: "port_%d_failure_streak", Name: "failure"},
+	},
+}
+
+var checkAddressesChartTmpl = module.Chart{
+	ID:       "port_%d_addresses",
+	Title:    "Resolved Addresses Reachability",
+	Units:    "addresses",
+	Fam:      "addresses",
+	Ctx:      "portcheck.addresses",
+	Type:     module.Stacked,
+	Priority: prioCheckAddresses,
+	Dims: module.Dims{
+		{ID: "port_%d_addresses_reachable", Name: "reachable"},
+		{ID: "port_%d_addresses_unreachable", Name: "unreachable"},
+	},
+}
+
+var addressChartsTmpl = module.Charts{
+	addressCheckStatusChartTmpl.Copy(),
+	addressCheckInStateDurationChartTmpl.Copy(),
+	addressCheckConnectionLatencyChartTmpl.Copy(),
+}
+
+var addressCheckStatusChartTmpl = module.Chart{
+	ID:       "port_%d_addr_%s_status",
+	Title:    "TCP Check Status Per Address",
+	Units:    "boolean",
+	Fam:      "addresses",
+	Ctx:      "portcheck.address_status",
+	Priority: prioAddressCheckStatus,
+	Dims: module.Dims{
+		{ID: "port_%d_addr_%s_success", Name: "success"},
+		{ID: "port_%d_addr_%s_failed", Name: "failed"},
+		{ID: "port_%d_addr_%s_timeout", Name: "timeout"},
+	},
+}
+
+var addressCheckInStateDurationChartTmpl = module.Chart{
+	ID:       "port_%d_addr_%s_current_state_duration",
+	Title:    "Current State Duration Per Address",
+	Units:    "seconds",
+	Fam:      "addresses",
+	Ctx:      "portcheck.address_state_duration",
+	Priority: prioAddressCheckInStatusDuration,
+	Dims: module.Dims{
+		{ID: "port_%d_addr_%s_current_state_duration", Name: "time"},
+	},
+}
+
+var addressCheckConnectionLatencyChartTmpl = module.Chart{
+	ID:       "port_%d_addr_%s_connection_latency",
+	Title:    "TCP Connection Latency Per Address",
+	Units:    "ms",
+	Fam:      "addresses",
+	Ctx:      "portcheck.address_latency",
+	Priority: prioAddressCheckLatency,
+	Dims: module.Dims{
+		{ID: "port_%d_addr_%s_latency", Name: "time"},
+	},
+}
+
+var udpChartsTmpl = module.Charts{
+	udpCheckStatusChartTmpl.Copy(),
+	udpCheckInStateDurationChartTmpl.Copy(),
+	udpCheckResponseLatencyChartTmpl.Copy(),
+}
+
+var udpCheckStatusChartTmpl = module.Chart{
+	ID:       "udp_port_%d_status",
+	Title:    "UDP Check Status",
+	Units:    "boolean",
+	Fam:      "status",
+	Ctx:      "portcheck.udp_status",
+	Priority: prioUDPCheckStatus,
+	Dims: module.Dims{
+		{ID: "udp_port_%d_open", Name: "open"},
+		{ID: "udp_port_%d_open_filtered", Name: "open_filtered"},
+		{ID: "udp_port_%d_closed", Name: "closed"},
+		{ID: "udp_port_%d_failed", Name: "failed"},
+	},
+}
+
+var udpCheckInStateDurationChartTmpl = module.Chart{
+	ID:       "udp_port_%d_current_state_duration",
+	Title:    "UDP Current State Duration",
+	Units:    "seconds",
+	Fam:      "status duration",
+	Ctx:      "portcheck.udp_state_duration",
+	Priority: prioUDPCheckInStatusDuration,
+	Dims: module.Dims{
+		{ID: "udp_port_%d_current_state_duration", Name: "time"},
+	},
+}
+
+var udpCheckResponseLatencyChartTmpl = module.Chart{
+	ID:       "udp_port_%d_response_latency",
+	Title:    "UDP Response Latency",
+	Units:    "ms",
+	Fam:      "latency",
+	Ctx:      "portcheck.udp_latency",
+	Priority: prioUDPCheckLatency,
+	Dims: module.Dims{
+		{ID: "udp_port_%d_latency", Name: "time"},
+	},
+}
+
 func newPortCharts(host string, port int) *module.Charts {
 	charts := chartsTmpl.Copy()
 	for _, chart := range *charts {
@@ -73,3 +210,59 @@ func newPortCharts(host string, port int) *module.Charts {
 	}
 	return charts
 }
+
+func newLatencyHistogramChart(host string, port int, buckets []float64) *module.Chart {
+	chart := checkConnectionLatencyHistogramChartTmpl.Copy()
+	chart.ID = fmt.Sprintf(chart.ID, port)
+	chart.Labels = []module.Label{
+		{Key: "host", Value: host},
+		{Key: "port", Value: strconv.Itoa(port)},
+	}
+	chart.Dims = metrics.HistogramBucketDims(fmt.Sprintf("port_%d_latency_hist", port), buckets)
+	return chart
+}
+
+func newAddressesChart(host string, port int) *module.Chart {
+	chart := checkAddressesChartTmpl.Copy()
+	chart.ID = fmt.Sprintf(chart.ID, port)
+	chart.Labels = []module.Label{
+		{Key: "host", Value: host},
+		{Key: "port", Value: strconv.Itoa(port)},
+	}
+	for _, dim := range chart.Dims {
+		dim.ID = fmt.Sprintf(dim.ID, port)
+	}
+	return chart
+}
+
+func newAddressPortCharts(host string, port int, addr string) *module.Charts {
+	charts := addressChartsTmpl.Copy()
+	for _, chart := range *charts {
+		chart.Labels = []module.Label{
+			{Key: "host", Value: host},
+			{Key: "port", Value: strconv.Itoa(port)},
+			{Key: "ip", Value: addr},
+		}
+		chart.ID = fmt.Sprintf(chart.ID, port, addressID(addr))
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, port, addressID(addr))
+		}
+	}
+	return charts
+}
+
+func newUDPPortCharts(host string, port int) *module.Charts {
+	charts := udpChartsTmpl.Copy()
+	for _, chart := range *charts {
+		chart.Labels = []module.Label{
+			{Key: "host", Value: host},
+			{Key: "port", Value: strconv.Itoa(port)},
+			{Key: "protocol", Value: "udp"},
+		}
+		chart.ID = fmt.Sprintf(chart.ID, port)
+		for _, dim := range chart.Dims {
+			dim.ID = fmt.Sprintf(dim.ID, port)
+		}
+	}
+	return charts
+}
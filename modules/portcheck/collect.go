@@ -4,6 +4,8 @@ package portcheck
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -17,14 +19,35 @@ const (
 )
 
 func (pc *PortCheck) collect() (map[string]int64, error) {
+	if pc.CheckAllAddresses {
+		pc.resolveAddresses()
+	}
+
 	wg := &sync.WaitGroup{}
 
-	for _, p := range pc.ports {
+	if pc.CheckAllAddresses {
+		for _, ports := range pc.addrPorts {
+			for _, p := range ports {
+				wg.Add(1)
+				go func(p *port) { pc.checkPort(p); wg.Done() }(p)
+			}
+		}
+	} else {
+		for _, p := range pc.ports {
+			wg.Add(1)
+			go func(p *port) { pc.checkPort(p); wg.Done() }(p)
+		}
+	}
+	for _, p := range pc.udpPorts {
 		wg.Add(1)
-		go func(p *port) { pc.checkPort(p); wg.Done() }(p)
+		go func(p *port) { pc.checkUDPPort(p); wg.Done() }(p)
 	}
 	wg.Wait()
 
+	if pc.CheckAllAddresses {
+		pc.aggregateAddressPorts()
+	}
+
 	mx := make(map[string]int64)
 
 	for _, p := range pc.ports {
@@ -34,14 +57,29 @@ func (pc *PortCheck) collect() (map[string]int64, error) {
 		mx[fmt.Sprintf("port_%d_%s", p.number, checkStateTimeout)] = 0
 		mx[fmt.Sprintf("port_%d_%s", p.number, checkStateFailed)] = 0
 		mx[fmt.Sprintf("port_%d_%s", p.number, p.state)] = 1
+		mx[fmt.Sprintf("port_%d_success_streak", p.number)] = int64(p.successes)
+		mx[fmt.Sprintf("port_%d_failure_streak", p.number)] = int64(p.failures)
+		if p.latencyHist != nil {
+			p.latencyHist.WriteTo(mx, fmt.Sprintf("port_%d_latency_hist", p.number), 1, 1)
+		}
 	}
 
+	if pc.CheckAllAddresses {
+		pc.collectAddressPorts(mx)
+	}
+	pc.collectUDPPorts(mx)
+
 	return mx, nil
 }
 
 func (pc *PortCheck) checkPort(p *port) {
+	host := pc.Host
+	if p.addr != "" {
+		host = p.addr
+	}
+
 	start := time.Now()
-	conn, err := pc.dial("tcp", fmt.Sprintf("%s:%d", pc.Host, p.number), pc.Timeout.Duration)
+	conn, err := pc.dial("tcp", net.JoinHostPort(host, strconv.Itoa(p.number)), pc.Timeout.Duration)
 	dur := time.Since(start)
 
 	defer func() {
@@ -53,14 +91,27 @@ func (pc *PortCheck) checkPort(p *port) {
 	if err != nil {
 		v, ok := err.(interface{ Timeout() bool })
 		if ok && v.Timeout() {
-			pc.setPortState(p, checkStateTimeout)
+			pc.updatePort(p, checkStateTimeout, 0)
 		} else {
-			pc.setPortState(p, checkStateFailed)
+			pc.updatePort(p, checkStateFailed, 0)
 		}
 		return
 	}
-	pc.setPortState(p, checkStateSuccess)
-	p.latency = durationToMs(dur)
+	pc.updatePort(p, checkStateSuccess, dur)
+}
+
+// updatePort sets the port check state, updates the streaks and, if the check is successful, the latency.
+func (pc *PortCheck) updatePort(p *port, s checkState, latency time.Duration) {
+	pc.setPortState(p, s)
+	if s != checkStateSuccess {
+		p.successes, p.failures = 0, p.failures+1
+		return
+	}
+	p.successes, p.failures = p.successes+1, 0
+	p.latency = durationToMs(latency)
+	if p.latencyHist != nil {
+		p.latencyHist.Observe(float64(latency) / float64(time.Millisecond))
+	}
 }
 
 func (pc *PortCheck) setPortState(p *port, s checkState) {
@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package portcheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+type resolveFunc func(host string, timeout time.Duration) ([]string, error)
+
+func resolveHost(host string, timeout time.Duration) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// resolveAddresses resolves the host every 'resolve_every', adds the checks (and charts) for the new addresses and
+// removes the checks of the addresses that are gone. The previous addresses are kept if the resolution fails.
+func (pc *PortCheck) resolveAddresses() {
+	now := time.Now()
+	if !pc.lastResolve.IsZero() && now.Sub(pc.lastResolve) < pc.ResolveEvery.Duration {
+		return
+	}
+	pc.lastResolve = now
+
+	addrs, err := pc.resolve(pc.Host, pc.Timeout.Duration)
+	if err != nil {
+		pc.Warningf("failed to resolve '%s': %v", pc.Host, err)
+		return
+	}
+	if len(addrs) == 0 {
+		pc.Warningf("'%s' resolved to no addresses", pc.Host)
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		seen[addr] = true
+		if _, ok := pc.addrPorts[addr]; ok {
+			continue
+		}
+		pc.Debugf("checking new '%s' address: %s", pc.Host, addr)
+		var ports []*port
+		for _, p := range pc.ports {
+			ports = append(ports, &port{number: p.number, addr: addr})
+		}
+		pc.addrPorts[addr] = ports
+		pc.addAddressCharts(addr)
+	}
+
+	for addr := range pc.addrPorts {
+		if !seen[addr] {
+			pc.Debugf("'%s' address is gone: %s", pc.Host, addr)
+			delete(pc.addrPorts, addr)
+			pc.removeAddressCharts(addr)
+		}
+	}
+}
+
+// aggregateAddressPorts sets the ports state based on the per address checks. The port check is successful if any
+// address is reachable, the latency is the lowest one of the reachable addresses.
+func (pc *PortCheck) aggregateAddressPorts() {
+	for i, p := range pc.ports {
+		state, latency := checkStateFailed, -1
+		for _, ports := range pc.addrPorts {
+			ap := ports[i]
+			switch ap.state {
+			case checkStateSuccess:
+				state = checkStateSuccess
+				if latency == -1 || ap.latency < latency {
+					latency = ap.latency
+				}
+			case checkStateTimeout:
+				if state == checkStateFailed {
+					state = checkStateTimeout
+				}
+			}
+		}
+		pc.updatePort(p, state, time.Duration(latency)*time.Millisecond)
+	}
+}
+
+func (pc *PortCheck) collectAddressPorts(mx map[string]int64) {
+	for i, p := range pc.ports {
+		var reachable, unreachable int64
+		for addr, ports := range pc.addrPorts {
+			ap := ports[i]
+			px := fmt.Sprintf("port_%d_addr_%s_", p.number, addressID(addr))
+			mx[px+"current_state_duration"] = int64(ap.inState)
+			mx[px+"latency"] = int64(ap.latency)
+			mx[px+string(checkStateSuccess)] = 0
+			mx[px+string(checkStateTimeout)] = 0
+			mx[px+string(checkStateFailed)] = 0
+			mx[px+string(ap.state)] = 1
+			if ap.state == checkStateSuccess {
+				reachable++
+			} else {
+				unreachable++
+			}
+		}
+		mx[fmt.Sprintf("port_%d_addresses_reachable", p.number)] = reachable
+		mx[fmt.Sprintf("port_%d_addresses_unreachable", p.number)] = unreachable
+	}
+}
+
+func (pc *PortCheck) addAddressCharts(addr string) {
+	for _, p := range pc.ports {
+		charts := newAddressPortCharts(pc.Host, p.number, addr)
+		if err := pc.charts.Add(*charts...); err != nil {
+			pc.Warning(err)
+		}
+	}
+}
+
+func (pc *PortCheck) removeAddressCharts(addr string) {
+	for _, p := range pc.ports {
+		for _, tmpl := range addressChartsTmpl {
+			if chart := pc.charts.Get(fmt.Sprintf(tmpl.ID, p.number, addressID(addr))); chart != nil {
+				chart.MarkRemove()
+				chart.MarkNotCreated()
+			}
+		}
+	}
+}
+
+// addressID converts the IPv4/IPv6 address to the ID used in the chart and dimension IDs.
+func addressID(addr string) string {
+	return strings.NewReplacer(".", "_", ":", "_", "%", "_").Replace(addr)
+}
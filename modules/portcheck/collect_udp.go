@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package portcheck
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	udpStateOpen         checkState = "open"
+	udpStateOpenFiltered checkState = "open_filtered"
+	udpStateClosed       checkState = "closed"
+	udpStateFailed       checkState = "failed"
+)
+
+var udpStates = []checkState{udpStateOpen, udpStateOpenFiltered, udpStateClosed, udpStateFailed}
+
+func (pc *PortCheck) collectUDPPorts(mx map[string]int64) {
+	for _, p := range pc.udpPorts {
+		mx[fmt.Sprintf("udp_port_%d_current_state_duration", p.number)] = int64(p.inState)
+		mx[fmt.Sprintf("udp_port_%d_latency", p.number)] = int64(p.latency)
+		for _, s := range udpStates {
+			mx[fmt.Sprintf("udp_port_%d_%s", p.number, s)] = 0
+		}
+		mx[fmt.Sprintf("udp_port_%d_%s", p.number, p.state)] = 1
+	}
+}
+
+// checkUDPPort sends the payload and waits for a response. The ICMP port unreachable in reply to the datagram
+// is reported as the connection refused error on the connected UDP socket read, so no raw ICMP socket (privileges)
+// is needed. No response within the timeout means the port is either open or filtered.
+func (pc *PortCheck) checkUDPPort(p *port) {
+	start := time.Now()
+	conn, err := pc.dial("udp", net.JoinHostPort(pc.Host, strconv.Itoa(p.number)), pc.Timeout.Duration)
+	if err != nil {
+		pc.Debugf("udp port %d dial error: %v", p.number, err)
+		pc.setPortState(p, udpStateFailed)
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	if err := conn.SetDeadline(start.Add(pc.Timeout.Duration)); err != nil {
+		pc.setPortState(p, udpStateFailed)
+		return
+	}
+
+	if _, err := conn.Write(pc.udpPayload); err != nil {
+		pc.setPortState(p, udpStateFromErr(err))
+		return
+	}
+
+	buf := make([]byte, 1)
+	_, err = conn.Read(buf)
+	dur := time.Since(start)
+
+	if err != nil {
+		state := udpStateFromErr(err)
+		if state == udpStateFailed {
+			pc.Debugf("udp port %d read error: %v", p.number, err)
+		}
+		pc.setPortState(p, state)
+		return
+	}
+
+	pc.setPortState(p, udpStateOpen)
+	p.latency = durationToMs(dur)
+}
+
+func udpStateFromErr(err error) checkState {
+	var v interface{ Timeout() bool }
+	switch {
+	case errors.As(err, &v) && v.Timeout():
+		return udpStateOpenFiltered
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return udpStateClosed
+	default:
+		return udpStateFailed
+	}
+}
@@ -3,17 +3,24 @@
 package portcheck
 
 import (
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 )
 
 func (pc *PortCheck) validateConfig() error {
 	if pc.Host == "" {
 		return errors.New("'host' parameter not set")
 	}
-	if len(pc.Ports) == 0 {
-		return errors.New("'ports' parameter not set")
+	if len(pc.Ports) == 0 && len(pc.UDPPorts) == 0 {
+		return errors.New("neither 'ports' nor 'udp_ports' parameter set")
+	}
+	if err := metrics.ValidateBuckets(pc.LatencyHistogram); err != nil {
+		return fmt.Errorf("latency histogram: %v", err)
 	}
 	return nil
 }
@@ -25,7 +32,36 @@ func (pc *PortCheck) initCharts() (*module.Charts, error) {
 		if err := charts.Add(*newPortCharts(pc.Host, port)...); err != nil {
 			return nil, err
 		}
+		if pc.CheckAllAddresses {
+			if err := charts.Add(newAddressesChart(pc.Host, port)); err != nil {
+				return nil, err
+			}
+		}
+		if len(pc.LatencyHistogram) == 0 {
+			continue
+		}
+		if err := charts.Add(newLatencyHistogramChart(pc.Host, port, pc.LatencyHistogram)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, port := range pc.UDPPorts {
+		if err := charts.Add(*newUDPPortCharts(pc.Host, port)...); err != nil {
+			return nil, err
+		}
 	}
 
 	return &charts, nil
 }
+
+// initUDPPayload decodes the hex encoded UDP payload, an empty datagram is sent by default.
+func (pc *PortCheck) initUDPPayload() ([]byte, error) {
+	return hex.DecodeString(strings.ReplaceAll(pc.UDPPayload, " ", ""))
+}
+
+func (pc *PortCheck) newLatencyHistogram() metrics.Histogram {
+	if len(pc.LatencyHistogram) == 0 {
+		return nil
+	}
+	return metrics.NewHistogramWithRangeBuckets(pc.LatencyHistogram)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
@@ -22,25 +23,40 @@ func init() {
 func New() *PortCheck {
 	return &PortCheck{
 		Config: Config{
-			Timeout: web.Duration{Duration: time.Second * 2},
+			Timeout:      web.Duration{Duration: time.Second * 2},
+			ResolveEvery: web.Duration{Duration: time.Minute},
 		},
-		dial: net.DialTimeout,
+		dial:      net.DialTimeout,
+		resolve:   resolveHost,
+		addrPorts: make(map[string][]*port),
 	}
 }
 
 type Config struct {
-	Host    string       `yaml:"host"`
-	Ports   []int        `yaml:"ports"`
-	Timeout web.Duration `yaml:"timeout"`
+	Host       string       `yaml:"host"`
+	Ports      []int        `yaml:"ports"`
+	UDPPorts   []int        `yaml:"udp_ports"`
+	UDPPayload string       `yaml:"udp_payload"`
+	Timeout    web.Duration `yaml:"timeout"`
+	// LatencyHistogram is the TCP connection latency histogram buckets in milliseconds, the histogram is disabled if not set.
+	LatencyHistogram []float64 `yaml:"latency_histogram"`
+	// CheckAllAddresses enables one check per resolved host address (A and AAAA records).
+	CheckAllAddresses bool         `yaml:"check_all_addresses"`
+	ResolveEvery      web.Duration `yaml:"resolve_every"`
 }
 
 type dialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
 
 type port struct {
 	number  int
+	addr    string // resolved host address, the host is checked if not set
 	state   checkState
 	inState int
 	latency int
+	// successes and failures are the number of consecutive successful and failed (failed or timeout) checks.
+	successes   int
+	failures    int
+	latencyHist metrics.Histogram
 }
 
 type PortCheck struct {
@@ -48,9 +64,17 @@ type PortCheck struct {
 	Config      `yaml:",inline"`
 	UpdateEvery int `yaml:"update_every"`
 
-	charts *module.Charts
-	dial   dialFunc
-	ports  []*port
+	charts     *module.Charts
+	dial       dialFunc
+	resolve    resolveFunc
+	ports      []*port
+	udpPorts   []*port
+	udpPayload []byte
+
+	// addrPorts is the per address checks, the key is the resolved address,
+	// the checks are in the same order as the ports.
+	addrPorts   map[string][]*port
+	lastResolve time.Time
 }
 
 func (pc *PortCheck) Init() bool {
@@ -66,13 +90,29 @@ func (pc *PortCheck) Init() bool {
 	}
 	pc.charts = charts
 
+	payload, err := pc.initUDPPayload()
+	if err != nil {
+		pc.Errorf("init UDP payload: %v", err)
+		return false
+	}
+	pc.udpPayload = payload
+
 	for _, p := range pc.Ports {
-		pc.ports = append(pc.ports, &port{number: p})
+		pc.ports = append(pc.ports, &port{number: p, latencyHist: pc.newLatencyHistogram()})
+	}
+	for _, p := range pc.UDPPorts {
+		pc.udpPorts = append(pc.udpPorts, &port{number: p})
 	}
 
 	pc.Debugf("using host: %s", pc.Host)
 	pc.Debugf("using ports: %v", pc.Ports)
+	if len(pc.UDPPorts) > 0 {
+		pc.Debugf("using UDP ports: %v", pc.UDPPorts)
+	}
 	pc.Debugf("using TCP connection timeout: %s", pc.Timeout)
+	if pc.CheckAllAddresses {
+		pc.Debugf("checking all the host addresses, resolving every %s", pc.ResolveEvery)
+	}
 
 	return true
 }
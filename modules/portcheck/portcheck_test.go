@@ -4,6 +4,7 @@ package portcheck
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"strings"
 	"testing"
@@ -74,13 +75,17 @@ func TestPortCheck_Collect(t *testing.T) {
 
 	expected := map[string]int64{
 		"port_39001_current_state_duration": int64(job.UpdateEvery),
+		"port_39001_failure_streak":         0,
 		"port_39001_failed":                 0,
 		"port_39001_latency":                0,
+		"port_39001_success_streak":         1,
 		"port_39001_success":                1,
 		"port_39001_timeout":                0,
 		"port_39002_current_state_duration": int64(job.UpdateEvery),
+		"port_39002_failure_streak":         0,
 		"port_39002_failed":                 0,
 		"port_39002_latency":                0,
+		"port_39002_success_streak":         1,
 		"port_39002_success":                1,
 		"port_39002_timeout":                0,
 	}
@@ -91,13 +96,17 @@ func TestPortCheck_Collect(t *testing.T) {
 
 	expected = map[string]int64{
 		"port_39001_current_state_duration": int64(job.UpdateEvery) * 2,
+		"port_39001_failure_streak":         0,
 		"port_39001_failed":                 0,
 		"port_39001_latency":                0,
+		"port_39001_success_streak":         2,
 		"port_39001_success":                1,
 		"port_39001_timeout":                0,
 		"port_39002_current_state_duration": int64(job.UpdateEvery) * 2,
+		"port_39002_failure_streak":         0,
 		"port_39002_failed":                 0,
 		"port_39002_latency":                0,
+		"port_39002_success_streak":         2,
 		"port_39002_success":                1,
 		"port_39002_timeout":                0,
 	}
@@ -110,13 +119,17 @@ func TestPortCheck_Collect(t *testing.T) {
 
 	expected = map[string]int64{
 		"port_39001_current_state_duration": int64(job.UpdateEvery),
+		"port_39001_failure_streak":         1,
 		"port_39001_failed":                 1,
 		"port_39001_latency":                0,
+		"port_39001_success_streak":         0,
 		"port_39001_success":                0,
 		"port_39001_timeout":                0,
 		"port_39002_current_state_duration": int64(job.UpdateEvery),
+		"port_39002_failure_streak":         1,
 		"port_39002_failed":                 1,
 		"port_39002_latency":                0,
+		"port_39002_success_streak":         0,
 		"port_39002_success":                0,
 		"port_39002_timeout":                0,
 	}
@@ -129,13 +142,17 @@ func TestPortCheck_Collect(t *testing.T) {
 
 	expected = map[string]int64{
 		"port_39001_current_state_duration": int64(job.UpdateEvery),
+		"port_39001_failure_streak":         2,
 		"port_39001_failed":                 0,
 		"port_39001_latency":                0,
+		"port_39001_success_streak":         0,
 		"port_39001_success":                0,
 		"port_39001_timeout":                1,
 		"port_39002_current_state_duration": int64(job.UpdateEvery),
+		"port_39002_failure_streak":         2,
 		"port_39002_failed":                 0,
 		"port_39002_latency":                0,
+		"port_39002_success_streak":         0,
 		"port_39002_success":                0,
 		"port_39002_timeout":                1,
 	}
@@ -145,6 +162,183 @@ func TestPortCheck_Collect(t *testing.T) {
 	assert.Equal(t, expected, collected)
 }
 
+func TestPortCheck_Collect_LatencyHistogram(t *testing.T) {
+	job := New()
+	job.Host = "127.0.0.1"
+	job.Ports = []int{39001}
+	job.LatencyHistogram = []float64{100, 10}
+	require.True(t, job.Init())
+
+	chart := job.Charts().Get("port_39001_connection_latency_histogram")
+	require.NotNil(t, chart)
+	require.Len(t, chart.Dims, 3)
+	assert.Equal(t, "10", chart.Dims[0].Name)
+
+	// the fake dial returns immediately, so all the successful connections are in the first bucket
+	job.dial = testDial(nil)
+	job.Collect()
+	job.Collect()
+	job.dial = testDial(errors.New("checkStateFailed"))
+	mx := job.Collect()
+
+	assert.Equal(t, int64(2), mx["port_39001_latency_hist_bucket_1"])
+	assert.Equal(t, int64(0), mx["port_39001_latency_hist_bucket_2"])
+	assert.Equal(t, int64(0), mx["port_39001_latency_hist_bucket_inf"])
+	assert.Equal(t, int64(2), mx["port_39001_latency_hist_count"])
+}
+
+func TestPortCheck_Init_LatencyHistogramNG(t *testing.T) {
+	job := New()
+	job.Host = "127.0.0.1"
+	job.Ports = []int{39001}
+	job.LatencyHistogram = []float64{10, 10}
+
+	assert.False(t, job.Init())
+}
+
+func TestPortCheck_Collect_CheckAllAddresses(t *testing.T) {
+	job := New()
+	job.Host = "example.com"
+	job.Ports = []int{39001}
+	job.UpdateEvery = 5
+	job.CheckAllAddresses = true
+	job.ResolveEvery.Duration = 0
+	addrs := []string{"192.0.2.1", "2001:db8::1"}
+	job.resolve = func(string, time.Duration) ([]string, error) { return addrs, nil }
+	// only the IPv6 address is reachable
+	job.dial = func(_, address string, _ time.Duration) (net.Conn, error) {
+		if address == "[2001:db8::1]:39001" {
+			return &net.TCPConn{}, nil
+		}
+		return nil, errors.New("checkStateFailed")
+	}
+	require.True(t, job.Init())
+	require.NotNil(t, job.Charts().Get("port_39001_addresses"))
+
+	mx := job.Collect()
+
+	assert.Equal(t, int64(1), mx["port_39001_success"])
+	assert.Equal(t, int64(1), mx["port_39001_addresses_reachable"])
+	assert.Equal(t, int64(1), mx["port_39001_addresses_unreachable"])
+	assert.Equal(t, int64(1), mx["port_39001_addr_192_0_2_1_failed"])
+	assert.Equal(t, int64(1), mx["port_39001_addr_2001_db8__1_success"])
+	chart := job.Charts().Get("port_39001_addr_2001_db8__1_status")
+	require.NotNil(t, chart)
+	assert.Contains(t, chart.Labels, module.Label{Key: "ip", Value: "2001:db8::1"})
+
+	// DNS failover to the unreachable address
+	addrs = []string{"192.0.2.1"}
+	mx = job.Collect()
+
+	assert.Equal(t, int64(1), mx["port_39001_failed"])
+	assert.Equal(t, int64(0), mx["port_39001_addresses_reachable"])
+	assert.Equal(t, int64(1), mx["port_39001_addresses_unreachable"])
+	assert.NotContains(t, mx, "port_39001_addr_2001_db8__1_success")
+	assert.True(t, job.Charts().Get("port_39001_addr_2001_db8__1_status").Obsolete)
+
+	// the previous addresses are kept if the resolution fails
+	job.resolve = func(string, time.Duration) ([]string, error) { return nil, errors.New("resolve error") }
+	mx = job.Collect()
+
+	assert.Equal(t, int64(1), mx["port_39001_addr_192_0_2_1_failed"])
+}
+
+func TestPortCheck_Collect_IPv6Host(t *testing.T) {
+	job := New()
+	job.Host = "::1"
+	job.Ports = []int{39001}
+	var dialed string
+	job.dial = func(_, address string, _ time.Duration) (net.Conn, error) {
+		dialed = address
+		return &net.TCPConn{}, nil
+	}
+	require.True(t, job.Init())
+
+	mx := job.Collect()
+
+	assert.Equal(t, "[::1]:39001", dialed)
+	assert.Equal(t, int64(1), mx["port_39001_success"])
+}
+
+func TestPortCheck_Collect_UDP(t *testing.T) {
+	// responds to the 'ping' payload only
+	echo, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = echo.Close() }()
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, addr, err := echo.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if string(buf[:n]) == "ping" {
+				_, _ = echo.WriteTo([]byte("pong"), addr)
+			}
+		}
+	}()
+
+	silent, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = silent.Close() }()
+
+	// nobody listens on the port after it's closed
+	closed, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_ = closed.Close()
+
+	port := func(c net.PacketConn) int { return c.LocalAddr().(*net.UDPAddr).Port }
+
+	tests := map[string]struct {
+		port      int
+		payload   string
+		wantState checkState
+	}{
+		"open":          {port: port(echo), payload: "70696e67", wantState: udpStateOpen},
+		"open filtered": {port: port(silent), payload: "70696e67", wantState: udpStateOpenFiltered},
+		"no response":   {port: port(echo), wantState: udpStateOpenFiltered},
+		"closed":        {port: port(closed), wantState: udpStateClosed},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := New()
+			job.Host = "127.0.0.1"
+			job.UDPPorts = []int{test.port}
+			job.UDPPayload = test.payload
+			job.Timeout.Duration = time.Millisecond * 200
+			job.UpdateEvery = 5
+			require.True(t, job.Init())
+			require.Len(t, *job.Charts(), len(udpChartsTmpl))
+
+			mx := job.Collect()
+
+			for _, s := range udpStates {
+				key := fmt.Sprintf("udp_port_%d_%s", test.port, s)
+				require.Containsf(t, mx, key, "key '%s'", key)
+				assert.Equalf(t, boolToInt(s == test.wantState), mx[key], "key '%s'", key)
+			}
+			assert.Equal(t, int64(5), mx[fmt.Sprintf("udp_port_%d_current_state_duration", test.port)])
+		})
+	}
+}
+
+func TestPortCheck_Init_UDPPayloadNG(t *testing.T) {
+	job := New()
+	job.Host = "127.0.0.1"
+	job.UDPPorts = []int{53}
+	job.UDPPayload = "not hex"
+
+	assert.False(t, job.Init())
+}
+
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
 func testDial(err error) dialFunc {
 	return func(_, _ string, _ time.Duration) (net.Conn, error) { return &net.TCPConn{}, err }
 }
@@ -16,12 +16,16 @@ const (
 	prioConnectionsStateCount
 	prioDBConnectionsUtilization
 	prioDBConnectionsCount
+	prioDBBackendsStateCount
 
 	prioTransactionsDuration
 	prioDBTransactionsRatio
 	prioDBTransactionsRate
 
 	prioQueriesDuration
+	prioStatementsCallsRate
+	prioStatementsMeanTime
+	prioStatementsRowsRate
 
 	prioDBOpsFetchedRowsRatio
 	prioDBOpsReadRowsRate
@@ -57,6 +61,7 @@ const (
 	prioLocksUtilization
 	prioDBLocksHeldCount
 	prioDBLocksAwaitedCount
+	prioDBWaitEventsCount
 	prioDBDeadlocksRate
 
 	prioAutovacuumWorkersCount
@@ -67,6 +72,7 @@ const (
 
 	prioCheckpointsRate
 	prioCheckpointsTime
+	prioCheckpointsTimeAvg
 	prioBGWriterHaltsRate
 	prioBuffersIORate
 	prioBuffersBackendFsyncRate
@@ -82,12 +88,16 @@ const (
 	prioReplicationAppWALLagSize
 	prioReplicationAppWALLagTime
 	prioReplicationSlotFilesCount
+	prioReplicationSlotLagSize
+	prioReplicationSlotActive
 	prioDBConflictsRate
 	prioDBConflictsReasonRate
 
 	prioWALIORate
 	prioWALFilesCount
 	prioWALArchivingFilesCount
+	prioWALArchivingRate
+	prioWALArchivingSinceLastTime
 
 	prioDatabasesCount
 	prioCatalogRelationsCount
@@ -103,6 +113,7 @@ var baseCharts = module.Charts{
 	locksUtilization.Copy(),
 	checkpointsChart.Copy(),
 	checkpointWriteChart.Copy(),
+	checkpointAvgTimeChart.Copy(),
 	buffersIORateChart.Copy(),
 	buffersAllocRateChart.Copy(),
 	bgWriterHaltsRateChart.Copy(),
@@ -110,6 +121,8 @@ var baseCharts = module.Charts{
 	walIORateChart.Copy(),
 	walFilesCountChart.Copy(),
 	walArchivingFilesCountChart.Copy(),
+	walArchivingRateChart.Copy(),
+	walArchivingSinceLastTimeChart.Copy(),
 	autovacuumWorkersCountChart.Copy(),
 	txidExhaustionTowardsAutovacuumPercChart.Copy(),
 	txidExhaustionPercChart.Copy(),
@@ -203,6 +216,19 @@ var (
 			{ID: "checkpoint_sync_time", Name: "sync", Algo: module.Incremental},
 		},
 	}
+	checkpointAvgTimeChart = module.Chart{
+		ID:       "checkpoints_time_avg",
+		Title:    "Checkpoint average time",
+		Units:    "milliseconds",
+		Fam:      "maintenance",
+		Ctx:      "postgres.checkpoints_time_avg",
+		Priority: prioCheckpointsTimeAvg,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "checkpoint_write_time_avg", Name: "write", Div: 1000},
+			{ID: "checkpoint_sync_time_avg", Name: "sync", Div: 1000},
+		},
+	}
 	bgWriterHaltsRateChart = module.Chart{
 		ID:       "bgwriter_halts_rate",
 		Title:    "Background writer scan halts",
@@ -290,6 +316,29 @@ var (
 			{ID: "wal_archive_files_done_count", Name: "done"},
 		},
 	}
+	walArchivingRateChart = module.Chart{
+		ID:       "wal_archiving_rate",
+		Title:    "Write-Ahead Log archiving",
+		Units:    "files/s",
+		Fam:      "wal",
+		Ctx:      "postgres.wal_archiving_rate",
+		Priority: prioWALArchivingRate,
+		Dims: module.Dims{
+			{ID: "wal_archiver_archived", Name: "archived", Algo: module.Incremental},
+			{ID: "wal_archiver_failed", Name: "failed", Algo: module.Incremental},
+		},
+	}
+	walArchivingSinceLastTimeChart = module.Chart{
+		ID:       "wal_archiving_since_last_time",
+		Title:    "Time since last successful Write-Ahead Log archiving",
+		Units:    "seconds",
+		Fam:      "wal",
+		Ctx:      "postgres.wal_archiving_since_last_time",
+		Priority: prioWALArchivingSinceLastTime,
+		Dims: module.Dims{
+			{ID: "wal_archiver_last_archived_ago", Name: "time"},
+		},
+	}
 
 	autovacuumWorkersCountChart = module.Chart{
 		ID:       "autovacuum_workers_count",
@@ -484,6 +533,79 @@ func (p *Postgres) addQueriesRunTimeHistogramChart() {
 	}
 }
 
+var (
+	statementsCharts = module.Charts{
+		statementsCallsRateChart.Copy(),
+		statementsMeanTimeChart.Copy(),
+		statementsRowsRateChart.Copy(),
+	}
+	statementsCallsRateChart = module.Chart{
+		ID:       "statements_calls_rate",
+		Title:    "Top statements calls",
+		Units:    "calls/s",
+		Fam:      "statements",
+		Ctx:      "postgres.statements_calls_rate",
+		Priority: prioStatementsCallsRate,
+	}
+	statementsMeanTimeChart = module.Chart{
+		ID:       "statements_mean_time",
+		Title:    "Top statements mean execution time",
+		Units:    "milliseconds",
+		Fam:      "statements",
+		Ctx:      "postgres.statements_mean_time",
+		Priority: prioStatementsMeanTime,
+	}
+	statementsRowsRateChart = module.Chart{
+		ID:       "statements_rows_rate",
+		Title:    "Top statements rows",
+		Units:    "rows/s",
+		Fam:      "statements",
+		Ctx:      "postgres.statements_rows_rate",
+		Priority: prioStatementsRowsRate,
+	}
+)
+
+func (p *Postgres) addStatementsCharts() {
+	if err := p.Charts().Add(*statementsCharts.Copy()...); err != nil {
+		p.Warning(err)
+	}
+}
+
+func (p *Postgres) addStatementDims(queryID, name string) {
+	dims := map[string]*module.Dim{
+		statementsCallsRateChart.ID: {ID: "statement_" + queryID + "_calls", Name: name, Algo: module.Incremental},
+		statementsMeanTimeChart.ID:  {ID: "statement_" + queryID + "_mean_time", Name: name, Div: 1000},
+		statementsRowsRateChart.ID:  {ID: "statement_" + queryID + "_rows", Name: name, Algo: module.Incremental},
+	}
+	for chartID, dim := range dims {
+		chart := p.Charts().Get(chartID)
+		if chart == nil {
+			continue
+		}
+		if err := chart.AddDim(dim); err != nil {
+			p.Warning(err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
+
+func (p *Postgres) removeStatementDims(queryID string) {
+	dimIDs := map[string]string{
+		statementsCallsRateChart.ID: "statement_" + queryID + "_calls",
+		statementsMeanTimeChart.ID:  "statement_" + queryID + "_mean_time",
+		statementsRowsRateChart.ID:  "statement_" + queryID + "_rows",
+	}
+	for chartID, dimID := range dimIDs {
+		chart := p.Charts().Get(chartID)
+		if chart == nil || !chart.HasDim(dimID) {
+			continue
+		}
+		_ = chart.MarkDimRemove(dimID, true)
+		chart.MarkNotCreated()
+	}
+}
+
 var (
 	replicationStandbyAppCharts = module.Charts{
 		replicationAppWALLagSizeChartTmpl.Copy(),
@@ -598,6 +720,63 @@ func (p *Postgres) removeReplicationSlotCharts(slot string) {
 	}
 }
 
+var (
+	replicationSlotLagCharts = module.Charts{
+		replicationSlotLagSizeChartTmpl.Copy(),
+		replicationSlotActiveChartTmpl.Copy(),
+	}
+	replicationSlotLagSizeChartTmpl = module.Chart{
+		ID:       "replication_slot_%s_lag_size",
+		Title:    "Replication slot WAL lag size",
+		Units:    "B",
+		Fam:      "replication",
+		Ctx:      "postgres.replication_slot_lag_size",
+		Priority: prioReplicationSlotLagSize,
+		Dims: module.Dims{
+			{ID: "repl_slot_%s_restart_lsn_lag", Name: "restart_lsn"},
+			{ID: "repl_slot_%s_confirmed_flush_lsn_lag", Name: "confirmed_flush_lsn"},
+		},
+	}
+	replicationSlotActiveChartTmpl = module.Chart{
+		ID:       "replication_slot_%s_active",
+		Title:    "Replication slot active status",
+		Units:    "status",
+		Fam:      "replication",
+		Ctx:      "postgres.replication_slot_active",
+		Priority: prioReplicationSlotActive,
+		Dims: module.Dims{
+			{ID: "repl_slot_%s_active", Name: "active"},
+		},
+	}
+)
+
+func newReplicationSlotLagCharts(slot, slotType string) *module.Charts {
+	charts := replicationSlotLagCharts.Copy()
+	for _, c := range *charts {
+		c.ID = fmt.Sprintf(c.ID, slot)
+		c.Labels = []module.Label{
+			{Key: "slot", Value: slot},
+			{Key: "slot_type", Value: slotType},
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, slot)
+		}
+	}
+	if slotType != "logical" {
+		// confirmed_flush_lsn is not set for physical slots
+		_ = charts.Get(fmt.Sprintf(replicationSlotLagSizeChartTmpl.ID, slot)).
+			RemoveDim(fmt.Sprintf("repl_slot_%s_confirmed_flush_lsn_lag", slot))
+	}
+	return charts
+}
+
+func (p *Postgres) addNewReplicationSlotLagCharts(slot, slotType string) {
+	charts := newReplicationSlotLagCharts(slot, slotType)
+	if err := p.Charts().Add(*charts...); err != nil {
+		p.Warning(err)
+	}
+}
+
 var (
 	dbChartsTmpl = module.Charts{
 		dbTransactionsRatioChartTmpl.Copy(),
@@ -798,6 +977,34 @@ var (
 			{ID: "db_%s_lock_mode_AccessExclusiveLock_awaited", Name: "access_exclusive"},
 		},
 	}
+	dbWaitEventsCountChartTmpl = module.Chart{
+		ID:       "db_%s_wait_events_count",
+		Title:    "Database backends waiting by wait event type",
+		Units:    "backends",
+		Fam:      "locks",
+		Ctx:      "postgres.db_wait_events_count",
+		Priority: prioDBWaitEventsCount,
+		Type:     module.Stacked,
+		Dims: module.Dims{
+			{ID: "db_%s_wait_event_lock", Name: "lock"},
+			{ID: "db_%s_wait_event_lwlock", Name: "lwlock"},
+			{ID: "db_%s_wait_event_io", Name: "io"},
+			{ID: "db_%s_wait_event_client", Name: "client"},
+			{ID: "db_%s_wait_event_activity", Name: "activity"},
+		},
+	}
+	dbBackendsStateCountChartTmpl = module.Chart{
+		ID:       "db_%s_backends_state_count",
+		Title:    "Database backends by state",
+		Units:    "backends",
+		Fam:      "connections",
+		Ctx:      "postgres.db_backends_state_count",
+		Priority: prioDBBackendsStateCount,
+		Dims: module.Dims{
+			{ID: "db_%s_backends_state_active", Name: "active"},
+			{ID: "db_%s_backends_state_idle_in_transaction", Name: "idle_in_transaction"},
+		},
+	}
 	dbTempFilesCreatedRateChartTmpl = module.Chart{
 		ID:       "db_%s_temp_files_files_created_rate",
 		Title:    "Database created temporary files",
@@ -845,6 +1052,18 @@ func (p *Postgres) addDBConflictsCharts(db *dbMetrics) {
 	}
 }
 
+func (p *Postgres) addDBWaitEventsCharts(db *dbMetrics) {
+	tmpl := module.Charts{
+		dbWaitEventsCountChartTmpl.Copy(),
+		dbBackendsStateCountChartTmpl.Copy(),
+	}
+	charts := newDatabaseCharts(tmpl.Copy(), db)
+
+	if err := p.Charts().Add(*charts...); err != nil {
+		p.Warning(err)
+	}
+}
+
 func newDatabaseCharts(tmpl *module.Charts, db *dbMetrics) *module.Charts {
 	charts := tmpl.Copy()
 	for _, c := range *charts {
@@ -892,8 +1111,6 @@ var (
 		tableScansRowsRateChartTmpl.Copy(),
 		tableNullColumnsCountChartTmpl.Copy(),
 		tableTotalSizeChartTmpl.Copy(),
-		tableBloatSizePercChartTmpl.Copy(),
-		tableBloatSizeChartTmpl.Copy(),
 	}
 
 	tableDeadRowsDeadRatioChartTmpl = module.Chart{
@@ -1165,11 +1382,6 @@ var (
 func newTableCharts(tbl *tableMetrics) *module.Charts {
 	charts := tableChartsTmpl.Copy()
 
-	if tbl.bloatSize == nil {
-		_ = charts.Remove(tableBloatSizeChartTmpl.ID)
-		_ = charts.Remove(tableBloatSizePercChartTmpl.ID)
-	}
-
 	for i, chart := range *charts {
 		(*charts)[i] = newTableChart(chart, tbl)
 	}
@@ -1274,6 +1486,27 @@ func (p *Postgres) addTableTOASTIndexIOCharts(tbl *tableMetrics) {
 	}
 }
 
+func (p *Postgres) addTableBloatCharts(tbl *tableMetrics) {
+	charts := module.Charts{
+		newTableChart(tableBloatSizePercChartTmpl.Copy(), tbl),
+		newTableChart(tableBloatSizeChartTmpl.Copy(), tbl),
+	}
+
+	if err := p.Charts().Add(charts...); err != nil {
+		p.Warning(err)
+	}
+}
+
+func (p *Postgres) removeTableBloatCharts(tbl *tableMetrics) {
+	for _, id := range []string{tableBloatSizePercChartTmpl.ID, tableBloatSizeChartTmpl.ID} {
+		id = fmt.Sprintf(id, tbl.name, tbl.db, tbl.schema)
+		if c := p.Charts().Get(id); c != nil {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
+
 func (p *Postgres) removeTableCharts(tbl *tableMetrics) {
 	prefix := fmt.Sprintf("table_%s_db_%s_schema_%s", tbl.name, tbl.db, tbl.schema)
 	for _, c := range *p.Charts() {
@@ -1287,8 +1520,6 @@ func (p *Postgres) removeTableCharts(tbl *tableMetrics) {
 var (
 	indexChartsTmpl = module.Charts{
 		indexSizeChartTmpl.Copy(),
-		indexBloatSizePercChartTmpl.Copy(),
-		indexBloatSizeChartTmpl.Copy(),
 		indexUsageStatusChartTmpl.Copy(),
 	}
 	indexSizeChartTmpl = module.Chart{
@@ -1338,13 +1569,8 @@ var (
 	}
 )
 
-func (p *Postgres) addNewIndexCharts(idx *indexMetrics) {
-	charts := indexChartsTmpl.Copy()
-
-	if idx.bloatSize == nil {
-		_ = charts.Remove(indexBloatSizeChartTmpl.ID)
-		_ = charts.Remove(indexBloatSizePercChartTmpl.ID)
-	}
+func newIndexCharts(tmpl *module.Charts, idx *indexMetrics) *module.Charts {
+	charts := tmpl.Copy()
 
 	for _, chart := range *charts {
 		chart.ID = fmt.Sprintf(chart.ID, idx.name, idx.table, idx.db, idx.schema)
@@ -1358,12 +1584,39 @@ func (p *Postgres) addNewIndexCharts(idx *indexMetrics) {
 			d.ID = fmt.Sprintf(d.ID, idx.name, idx.table, idx.db, idx.schema)
 		}
 	}
+	return charts
+}
+
+func (p *Postgres) addNewIndexCharts(idx *indexMetrics) {
+	charts := newIndexCharts(indexChartsTmpl.Copy(), idx)
 
 	if err := p.Charts().Add(*charts...); err != nil {
 		p.Warning(err)
 	}
 }
 
+func (p *Postgres) addIndexBloatCharts(idx *indexMetrics) {
+	tmpl := module.Charts{
+		indexBloatSizePercChartTmpl.Copy(),
+		indexBloatSizeChartTmpl.Copy(),
+	}
+	charts := newIndexCharts(tmpl.Copy(), idx)
+
+	if err := p.Charts().Add(*charts...); err != nil {
+		p.Warning(err)
+	}
+}
+
+func (p *Postgres) removeIndexBloatCharts(idx *indexMetrics) {
+	for _, id := range []string{indexBloatSizePercChartTmpl.ID, indexBloatSizeChartTmpl.ID} {
+		id = fmt.Sprintf(id, idx.name, idx.table, idx.db, idx.schema)
+		if c := p.Charts().Get(id); c != nil {
+			c.MarkRemove()
+			c.MarkNotCreated()
+		}
+	}
+}
+
 func (p *Postgres) removeIndexCharts(idx *indexMetrics) {
 	prefix := fmt.Sprintf("index_%s_table_%s_db_%s_schema_%s", idx.name, idx.table, idx.db, idx.schema)
 	for _, c := range *p.Charts() {
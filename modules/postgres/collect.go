@@ -17,6 +17,8 @@ const (
 	pgVersion94 = 9_04_00
 	pgVersion10 = 10_00_00
 	pgVersion11 = 11_00_00
+	pgVersion13 = 13_00_00
+	pgVersion17 = 17_00_00
 )
 
 func (p *Postgres) collect() (map[string]int64, error) {
@@ -102,12 +104,21 @@ func (p *Postgres) collect() (map[string]int64, error) {
 	if err := p.doQueryIndexesMetrics(); err != nil {
 		return nil, err
 	}
+	if p.CollectStatements && !p.statementsUnavailable {
+		if err := p.doQueryStatementsMetrics(); err != nil {
+			return nil, err
+		}
+	}
 
-	if now.Sub(p.doSlowTime) > p.doSlowEvery {
-		p.doSlowTime = now
+	if p.CollectBloat && now.Sub(p.bloatTime) > p.BloatEvery.Duration {
+		p.bloatTime = now
 		if err := p.doQueryBloat(); err != nil {
 			return nil, err
 		}
+	}
+
+	if now.Sub(p.doSlowTime) > p.doSlowEvery {
+		p.doSlowTime = now
 		if err := p.doQueryColumns(); err != nil {
 			return nil, err
 		}
@@ -244,6 +255,13 @@ func parseFloat(s string) int64 {
 	return int64(v)
 }
 
+func boolToInt(v bool) int64 {
+	if v {
+		return 1
+	}
+	return 0
+}
+
 func newInt(v int64) *int64 {
 	return &v
 }
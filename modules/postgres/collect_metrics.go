@@ -23,12 +23,22 @@ func (p *Postgres) collectMetrics(mx map[string]int64) {
 	mx["checkpoints_req"] = p.mx.checkpointsReq
 	mx["checkpoint_write_time"] = p.mx.checkpointWriteTime
 	mx["checkpoint_sync_time"] = p.mx.checkpointSyncTime
+	mx["checkpoint_write_time_avg"], mx["checkpoint_sync_time_avg"] = 0, 0
+	if n := p.mx.checkpointsTimed + p.mx.checkpointsReq - p.mx.checkpointsPrev; n > 0 {
+		mx["checkpoint_write_time_avg"] = (p.mx.checkpointWriteTime - p.mx.checkpointWriteTimePrev) * 1000 / n
+		mx["checkpoint_sync_time_avg"] = (p.mx.checkpointSyncTime - p.mx.checkpointSyncTimePrev) * 1000 / n
+	}
 	mx["buffers_checkpoint"] = p.mx.buffersCheckpoint
 	mx["buffers_clean"] = p.mx.buffersClean
 	mx["maxwritten_clean"] = p.mx.maxwrittenClean
 	mx["buffers_backend"] = p.mx.buffersBackend
 	mx["buffers_backend_fsync"] = p.mx.buffersBackendFsync
 	mx["buffers_alloc"] = p.mx.buffersAlloc
+	mx["wal_archiver_archived"] = p.mx.archiverArchivedCount
+	mx["wal_archiver_failed"] = p.mx.archiverFailedCount
+	if p.mx.archiverLastArchivedAgo != nil {
+		mx["wal_archiver_last_archived_ago"] = *p.mx.archiverLastArchivedAgo
+	}
 	mx["oldest_current_xid"] = p.mx.oldestXID
 	mx["percent_towards_wraparound"] = p.mx.percentTowardsWraparound
 	mx["percent_towards_emergency_autovacuum"] = p.mx.percentTowardsEmergencyAutovacuum
@@ -125,6 +135,19 @@ func (p *Postgres) collectMetrics(mx map[string]int64) {
 		mx[px+"lock_mode_ShareRowExclusiveLock_awaited"] = m.shareRowExclusiveLockAwaited
 		mx[px+"lock_mode_ExclusiveLock_awaited"] = m.exclusiveLockAwaited
 		mx[px+"lock_mode_AccessExclusiveLock_awaited"] = m.accessExclusiveLockAwaited
+		if m.hasWaitEvents {
+			if !m.hasWaitEventsCharts {
+				m.hasWaitEventsCharts = true
+				p.addDBWaitEventsCharts(m)
+			}
+			mx[px+"wait_event_lock"] = m.waitEventLock
+			mx[px+"wait_event_lwlock"] = m.waitEventLWLock
+			mx[px+"wait_event_io"] = m.waitEventIO
+			mx[px+"wait_event_client"] = m.waitEventClient
+			mx[px+"wait_event_activity"] = m.waitEventActivity
+			mx[px+"backends_state_active"] = m.backendsStateActive
+			mx[px+"backends_state_idle_in_transaction"] = m.backendsStateIdleInTrans
+		}
 		locksHeld += m.accessShareLockHeld + m.rowShareLockHeld +
 			m.rowExclusiveLockHeld + m.shareUpdateExclusiveLockHeld +
 			m.shareLockHeld + m.shareRowExclusiveLockHeld +
@@ -203,8 +226,15 @@ func (p *Postgres) collectMetrics(mx map[string]int64) {
 		}
 		mx[px+"total_size"] = m.totalSize
 		if m.bloatSize != nil && m.bloatSizePerc != nil {
+			if !m.hasBloatCharts {
+				m.hasBloatCharts = true
+				p.addTableBloatCharts(m)
+			}
 			mx[px+"bloat_size"] = *m.bloatSize
 			mx[px+"bloat_size_perc"] = *m.bloatSizePerc
+		} else if m.hasBloatCharts {
+			m.hasBloatCharts = false
+			p.removeTableBloatCharts(m)
 		}
 		if m.nullColumns != nil {
 			mx[px+"null_columns"] = *m.nullColumns
@@ -248,8 +278,15 @@ func (p *Postgres) collectMetrics(mx map[string]int64) {
 		px := fmt.Sprintf("index_%s_table_%s_db_%s_schema_%s_", m.name, m.table, m.db, m.schema)
 		mx[px+"size"] = m.size
 		if m.bloatSize != nil && m.bloatSizePerc != nil {
+			if !m.hasBloatCharts {
+				m.hasBloatCharts = true
+				p.addIndexBloatCharts(m)
+			}
 			mx[px+"bloat_size"] = *m.bloatSize
 			mx[px+"bloat_size_perc"] = *m.bloatSizePerc
+		} else if m.hasBloatCharts {
+			m.hasBloatCharts = false
+			p.removeIndexBloatCharts(m)
 		}
 		if m.idxScan+m.idxTupRead+m.idxTupFetch > 0 {
 			mx[px+"usage_status_used"], mx[px+"usage_status_unused"] = 1, 0
@@ -284,14 +321,69 @@ func (p *Postgres) collectMetrics(mx map[string]int64) {
 			p.removeReplicationSlotCharts(name)
 			continue
 		}
-		if !m.hasCharts {
-			m.hasCharts = true
-			p.addNewReplicationSlotCharts(name)
-		}
 		px := "repl_slot_" + m.name + "_"
-		mx[px+"replslot_wal_keep"] = m.walKeep
-		mx[px+"replslot_files"] = m.files
+		if p.isSuperUser() {
+			if !m.hasCharts {
+				m.hasCharts = true
+				p.addNewReplicationSlotCharts(name)
+			}
+			mx[px+"replslot_wal_keep"] = m.walKeep
+			mx[px+"replslot_files"] = m.files
+		}
+		if m.hasLag {
+			if !m.hasLagCharts {
+				m.hasLagCharts = true
+				p.addNewReplicationSlotLagCharts(name, m.slotType)
+			}
+			mx[px+"active"] = boolToInt(m.active)
+			mx[px+"restart_lsn_lag"] = m.restartLSNLag
+			if m.confirmedFlushLSNLag != nil {
+				mx[px+"confirmed_flush_lsn_lag"] = *m.confirmedFlushLSNLag
+			}
+		}
 	}
+
+	for id, m := range p.mx.statements {
+		if !m.updated {
+			delete(p.mx.statements, id)
+			if m.hasDims {
+				p.removeStatementDims(id)
+			}
+			continue
+		}
+		if !m.hasDims {
+			m.hasDims = true
+			p.addStatementsChartsOnce.Do(p.addStatementsCharts)
+			p.addStatementDims(id, statementDimName(m.query, id))
+		}
+		calcStatementMetrics(m)
+		px := "statement_" + id + "_"
+		mx[px+"calls"] = m.callsTotal
+		mx[px+"rows"] = m.rowsTotal
+		mx[px+"mean_time"] = m.meanTime
+	}
+}
+
+func calcStatementMetrics(m *statementMetrics) {
+	var calls, rows, totalTime int64
+	switch {
+	case !m.hasPrev:
+		m.hasPrev = true
+		calls, rows, totalTime = m.calls, m.rows, m.totalTime
+	case m.calls < m.prevCalls:
+		// pg_stat_statements_reset() or the entry was evicted and added again
+		calls, rows, totalTime = m.calls, m.rows, m.totalTime
+	default:
+		calls, rows, totalTime = m.calls-m.prevCalls, m.rows-m.prevRows, m.totalTime-m.prevTotalTime
+	}
+
+	m.callsTotal += calls
+	m.rowsTotal += rows
+	m.meanTime = 0
+	if calls > 0 {
+		m.meanTime = totalTime / calls
+	}
+	m.prevCalls, m.prevRows, m.prevTotalTime = m.calls, m.rows, m.totalTime
 }
 
 func (p *Postgres) resetMetrics() {
@@ -300,15 +392,20 @@ func (p *Postgres) resetMetrics() {
 		queryTimeHist:  p.mx.queryTimeHist,
 		maxConnections: p.mx.maxConnections,
 		maxLocksHeld:   p.mx.maxLocksHeld,
+
+		checkpointsPrev:         p.mx.checkpointsTimed + p.mx.checkpointsReq,
+		checkpointWriteTimePrev: p.mx.checkpointWriteTime,
+		checkpointSyncTimePrev:  p.mx.checkpointSyncTime,
 	}
 	for name, m := range p.mx.dbs {
 		p.mx.dbs[name] = &dbMetrics{
-			name:        m.name,
-			hasCharts:   m.hasCharts,
-			blksRead:    incDelta{prev: m.blksRead.prev},
-			blksHit:     incDelta{prev: m.blksHit.prev},
-			tupReturned: incDelta{prev: m.tupReturned.prev},
-			tupFetched:  incDelta{prev: m.tupFetched.prev},
+			name:                m.name,
+			hasCharts:           m.hasCharts,
+			hasWaitEventsCharts: m.hasWaitEventsCharts,
+			blksRead:            incDelta{prev: m.blksRead.prev},
+			blksHit:             incDelta{prev: m.blksHit.prev},
+			tupReturned:         incDelta{prev: m.tupReturned.prev},
+			tupFetched:          incDelta{prev: m.tupFetched.prev},
 		}
 	}
 	for name, m := range p.mx.tables {
@@ -325,6 +422,7 @@ func (p *Postgres) resetMetrics() {
 			hasTableIdxIOCharts:      m.hasTableIdxIOCharts,
 			hasTableTOASTIOCharts:    m.hasTableTOASTIOCharts,
 			hasTableTOASTIdxIOCharts: m.hasTableTOASTIdxIOCharts,
+			hasBloatCharts:           m.hasBloatCharts,
 			nTupUpd:                  incDelta{prev: m.nTupUpd.prev},
 			nTupHotUpd:               incDelta{prev: m.nTupHotUpd.prev},
 			heapBlksRead:             incDelta{prev: m.heapBlksRead.prev},
@@ -342,14 +440,15 @@ func (p *Postgres) resetMetrics() {
 	}
 	for name, m := range p.mx.indexes {
 		p.mx.indexes[name] = &indexMetrics{
-			name:          m.name,
-			db:            m.db,
-			schema:        m.schema,
-			table:         m.table,
-			updated:       m.updated,
-			hasCharts:     m.hasCharts,
-			bloatSize:     m.bloatSize,
-			bloatSizePerc: m.bloatSizePerc,
+			name:           m.name,
+			db:             m.db,
+			schema:         m.schema,
+			table:          m.table,
+			updated:        m.updated,
+			hasCharts:      m.hasCharts,
+			hasBloatCharts: m.hasBloatCharts,
+			bloatSize:      m.bloatSize,
+			bloatSizePerc:  m.bloatSizePerc,
 		}
 	}
 	for name, m := range p.mx.replApps {
@@ -360,8 +459,9 @@ func (p *Postgres) resetMetrics() {
 	}
 	for name, m := range p.mx.replSlots {
 		p.mx.replSlots[name] = &replSlotMetrics{
-			name:      m.name,
-			hasCharts: m.hasCharts,
+			name:         m.name,
+			hasCharts:    m.hasCharts,
+			hasLagCharts: m.hasLagCharts,
 		}
 	}
 }
@@ -2,29 +2,63 @@
 
 package postgres
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type bloatEstimate struct {
+	table  *tableMetrics
+	index  *indexMetrics
+	wasted int64
+}
 
 func (p *Postgres) doQueryBloat() error {
-	if err := p.doDBQueryBloat(p.db); err != nil {
+	var tables, indexes []bloatEstimate
+
+	if err := p.doDBQueryBloat(p.db, &tables, &indexes); err != nil {
 		p.Warning(err)
 	}
 	for _, conn := range p.dbConns {
 		if conn.db == nil {
 			continue
 		}
-		if err := p.doDBQueryBloat(conn.db); err != nil {
+		if err := p.doDBQueryBloat(conn.db, &tables, &indexes); err != nil {
 			p.Warning(err)
 		}
 	}
+
+	for _, m := range p.mx.tables {
+		m.bloatSize, m.bloatSizePerc = nil, nil
+	}
+	for _, m := range p.mx.indexes {
+		m.bloatSize, m.bloatSizePerc = nil, nil
+	}
+
+	for _, v := range topBloatEstimates(tables, p.BloatTopN) {
+		v.table.bloatSize = newInt(v.wasted)
+		v.table.bloatSizePerc = newInt(calcPercentage(v.wasted, v.table.totalSize))
+	}
+	for _, v := range topBloatEstimates(indexes, p.BloatTopN) {
+		v.index.bloatSize = newInt(v.wasted)
+		v.index.bloatSizePerc = newInt(calcPercentage(v.wasted, v.index.size))
+	}
+
 	return nil
 }
 
-func (p *Postgres) doDBQueryBloat(db *sql.DB) error {
+func (p *Postgres) doDBQueryBloat(db *sql.DB, tables, indexes *[]bloatEstimate) error {
 	q := queryBloat()
 
+	// the query returns a row per table index
+	seen := make(map[*tableMetrics]bool)
+
 	var dbname, schema, table, iname string
 	var tableWasted, idxWasted int64
-	return p.doDBQuery(db, q, func(column, value string, rowEnd bool) {
+	return p.doDBQueryWithStatementTimeout(db, q, func(column, value string, rowEnd bool) {
 		switch column {
 		case "db":
 			dbname = value
@@ -42,15 +76,63 @@ func (p *Postgres) doDBQueryBloat(db *sql.DB) error {
 		if !rowEnd {
 			return
 		}
+		// only the tables and indexes the module collects metrics for
 		if p.hasTableMetrics(table, dbname, schema) {
 			v := p.getTableMetrics(table, dbname, schema)
-			v.bloatSize = newInt(tableWasted)
-			v.bloatSizePerc = newInt(calcPercentage(tableWasted, v.totalSize))
+			if !seen[v] {
+				seen[v] = true
+				*tables = append(*tables, bloatEstimate{table: v, wasted: tableWasted})
+			}
 		}
 		if iname != "?" && p.hasIndexMetrics(iname, table, dbname, schema) {
 			v := p.getIndexMetrics(iname, table, dbname, schema)
-			v.bloatSize = newInt(idxWasted)
-			v.bloatSizePerc = newInt(calcPercentage(idxWasted, v.size))
+			*indexes = append(*indexes, bloatEstimate{index: v, wasted: idxWasted})
 		}
 	})
 }
+
+// doDBQueryWithStatementTimeout executes the query in a read only transaction with 'statement_timeout' set,
+// so the server cancels the query before the module timeout is exceeded.
+func (p *Postgres) doDBQueryWithStatementTimeout(db *sql.DB, query string, assign func(column, value string, rowEnd bool)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout.Duration)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, queryStatementTimeout(statementTimeout(p.Timeout.Duration))); err != nil {
+		return fmt.Errorf("setting statement timeout: %v", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	if err := readRows(rows, assign); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// statementTimeout returns 90% of the module timeout in milliseconds (at least 1, 0 disables the timeout).
+// The server cancels the query before the client context is done, so the query doesn't outlive the collection.
+func statementTimeout(timeout time.Duration) int64 {
+	if ms := (timeout - timeout/10).Milliseconds(); ms > 0 {
+		return ms
+	}
+	return 1
+}
+
+func topBloatEstimates(estimates []bloatEstimate, n int) []bloatEstimate {
+	sort.SliceStable(estimates, func(i, j int) bool { return estimates[i].wasted > estimates[j].wasted })
+	if len(estimates) > n {
+		estimates = estimates[:n]
+	}
+	return estimates
+}
@@ -21,6 +21,11 @@ func (p *Postgres) doQueryDatabasesMetrics() error {
 	if err := p.doQueryDatabaseLocks(); err != nil {
 		return fmt.Errorf("querying database locks error: %v", err)
 	}
+	if p.pgVersion >= pgVersion10 {
+		if err := p.doQueryDatabaseWaitEvents(); err != nil {
+			return fmt.Errorf("querying database wait events error: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -158,3 +163,39 @@ func (p *Postgres) doQueryDatabaseLocks() error {
 		}
 	})
 }
+
+func (p *Postgres) doQueryDatabaseWaitEvents() error {
+	q := queryDatabaseWaitEvents()
+
+	var db *dbMetrics
+	return p.doQuery(q, func(column, value string, _ bool) {
+		if column == "datname" {
+			db = nil
+			// only databases known from pg_stat_database and allowed by the selector
+			if m, ok := p.mx.dbs[value]; ok && (p.dbSr == nil || p.dbSr.MatchString(value)) {
+				db = m
+				db.hasWaitEvents = true
+			}
+			return
+		}
+		if db == nil {
+			return
+		}
+		switch column {
+		case "wait_event_lock":
+			db.waitEventLock = parseInt(value)
+		case "wait_event_lwlock":
+			db.waitEventLWLock = parseInt(value)
+		case "wait_event_io":
+			db.waitEventIO = parseInt(value)
+		case "wait_event_client":
+			db.waitEventClient = parseInt(value)
+		case "wait_event_activity":
+			db.waitEventActivity = parseInt(value)
+		case "state_active":
+			db.backendsStateActive = parseInt(value)
+		case "state_idle_in_transaction":
+			db.backendsStateIdleInTrans = parseInt(value)
+		}
+	})
+}
@@ -15,7 +15,12 @@ func (p *Postgres) doQueryGlobalMetrics() error {
 		return fmt.Errorf("querying server connections state error: %v", err)
 	}
 	if err := p.doQueryCheckpoints(); err != nil {
-		return fmt.Errorf("querying database conflicts error: %v", err)
+		return fmt.Errorf("querying checkpoints error: %v", err)
+	}
+	if p.pgVersion >= pgVersion94 {
+		if err := p.doQueryArchiver(); err != nil {
+			return fmt.Errorf("querying archiver error: %v", err)
+		}
 	}
 	if err := p.doQueryUptime(); err != nil {
 		return fmt.Errorf("querying server uptime error: %v", err)
@@ -97,7 +102,7 @@ func (p *Postgres) doQueryConnectionsState() error {
 }
 
 func (p *Postgres) doQueryCheckpoints() error {
-	q := queryCheckpoints()
+	q := queryCheckpoints(p.pgVersion)
 
 	return p.doQuery(q, func(column, value string, _ bool) {
 		switch column {
@@ -125,6 +130,23 @@ func (p *Postgres) doQueryCheckpoints() error {
 	})
 }
 
+func (p *Postgres) doQueryArchiver() error {
+	q := queryArchiver()
+
+	return p.doQuery(q, func(column, value string, _ bool) {
+		switch column {
+		case "archived_count":
+			p.mx.archiverArchivedCount = parseInt(value)
+		case "failed_count":
+			p.mx.archiverFailedCount = parseInt(value)
+		case "last_archived_ago":
+			if value != "" {
+				p.mx.archiverLastArchivedAgo = newInt(parseInt(value))
+			}
+		}
+	})
+}
+
 func (p *Postgres) doQueryUptime() error {
 	q := queryServerUptime()
 
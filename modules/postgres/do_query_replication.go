@@ -3,7 +3,10 @@
 package postgres
 
 import (
+	"errors"
 	"fmt"
+
+	"github.com/jackc/pgconn"
 )
 
 func (p *Postgres) doQueryReplicationMetrics() error {
@@ -17,6 +20,16 @@ func (p *Postgres) doQueryReplicationMetrics() error {
 		}
 	}
 
+	if p.pgVersion >= pgVersion10 && !p.replSlotsAccessDenied {
+		if err := p.doQueryReplSlotLag(); err != nil {
+			if !isInsufficientPrivilegeError(err) {
+				return fmt.Errorf("querying replication slot lag error: %v", err)
+			}
+			p.Warningf("querying replication slot lag: %v, skipping it", err)
+			p.replSlotsAccessDenied = true
+		}
+	}
+
 	if p.pgVersion >= pgVersion10 && p.isSuperUser() {
 		if err := p.doQueryReplSlotFiles(); err != nil {
 			return fmt.Errorf("querying replication slot files error: %v", err)
@@ -82,3 +95,35 @@ func (p *Postgres) doQueryReplSlotFiles() error {
 		}
 	})
 }
+
+func (p *Postgres) doQueryReplSlotLag() error {
+	q := queryReplicationSlotLag()
+
+	var slot string
+	return p.doQuery(q, func(column, value string, _ bool) {
+		switch column {
+		case "slot_name":
+			slot = value
+			p.getReplSlotMetrics(slot).updated = true
+		case "slot_type":
+			p.getReplSlotMetrics(slot).slotType = value
+		case "active":
+			m := p.getReplSlotMetrics(slot)
+			m.hasLag = true
+			m.active = value == "true" || value == "t"
+		case "restart_lsn_lag":
+			p.getReplSlotMetrics(slot).restartLSNLag = parseInt(value)
+		case "confirmed_flush_lsn_lag":
+			// NULL for physical slots
+			if value != "" {
+				p.getReplSlotMetrics(slot).confirmedFlushLSNLag = newInt(parseInt(value))
+			}
+		}
+	})
+}
+
+func isInsufficientPrivilegeError(err error) bool {
+	// https://www.postgresql.org/docs/current/errcodes-appendix.html
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42501"
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgconn"
+)
+
+func (p *Postgres) doQueryStatementsMetrics() error {
+	for _, m := range p.mx.statements {
+		m.updated = false
+	}
+
+	if err := p.doQueryStatements(); err != nil {
+		if !isStatementsUnavailableError(err) {
+			return fmt.Errorf("querying pg_stat_statements error: %v", err)
+		}
+		p.Warningf("querying pg_stat_statements: %v, disabling statements collection", err)
+		p.statementsUnavailable = true
+	}
+
+	return nil
+}
+
+func (p *Postgres) doQueryStatements() error {
+	q := queryStatements(p.pgVersion, p.StatementsTopN)
+
+	var id string
+	return p.doQuery(q, func(column, value string, _ bool) {
+		switch column {
+		case "queryid":
+			id = value
+			p.getStatementMetrics(id).updated = true
+		case "query":
+			p.getStatementMetrics(id).query = value
+		case "calls":
+			p.getStatementMetrics(id).calls = parseInt(value)
+		case "total_time":
+			// milliseconds
+			v, _ := strconv.ParseFloat(value, 64)
+			p.getStatementMetrics(id).totalTime = int64(v * 1000)
+		case "rows":
+			p.getStatementMetrics(id).rows = parseInt(value)
+		}
+	})
+}
+
+func (p *Postgres) getStatementMetrics(queryID string) *statementMetrics {
+	m, ok := p.mx.statements[queryID]
+	if !ok {
+		m = &statementMetrics{queryID: queryID}
+		p.mx.statements[queryID] = m
+	}
+	return m
+}
+
+func isStatementsUnavailableError(err error) bool {
+	// https://www.postgresql.org/docs/current/errcodes-appendix.html
+	// 42P01: the extension is not created in the database ('relation "pg_stat_statements" does not exist')
+	// 55000: the library is not loaded ('pg_stat_statements must be loaded via shared_preload_libraries')
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "42P01" || pgErr.Code == "55000")
+}
+
+// statementDimName returns the first 40 characters of the normalized query with the queryid appended.
+func statementDimName(query, queryID string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if r := []rune(query); len(r) > 40 {
+		query = string(r[:40])
+	}
+	// the name is single-quoted in the plugins.d protocol
+	query = strings.ReplaceAll(query, "'", "\"")
+	return fmt.Sprintf("%s (%s)", query, queryID)
+}
@@ -12,6 +12,12 @@ func (p *Postgres) validateConfig() error {
 	if p.DSN == "" {
 		return errors.New("DSN not set")
 	}
+	if p.CollectStatements && p.StatementsTopN <= 0 {
+		return errors.New("'statements_top_n' must be positive")
+	}
+	if p.CollectBloat && p.BloatTopN <= 0 {
+		return errors.New("'bloat_top_n' must be positive")
+	}
 	return nil
 }
 
@@ -6,11 +6,12 @@ import "github.com/netdata/go.d.plugin/pkg/metrics"
 
 type pgMetrics struct {
 	srvMetrics
-	dbs       map[string]*dbMetrics
-	tables    map[string]*tableMetrics
-	indexes   map[string]*indexMetrics
-	replApps  map[string]*replStandbyAppMetrics
-	replSlots map[string]*replSlotMetrics
+	dbs        map[string]*dbMetrics
+	tables     map[string]*tableMetrics
+	indexes    map[string]*indexMetrics
+	replApps   map[string]*replStandbyAppMetrics
+	replSlots  map[string]*replSlotMetrics
+	statements map[string]*statementMetrics
 }
 
 type srvMetrics struct {
@@ -62,6 +63,15 @@ type srvMetrics struct {
 	buffersBackendFsync int64
 	buffersAlloc        int64
 
+	// the previous collection values, needed to calculate the average checkpoint time
+	checkpointsPrev         int64
+	checkpointWriteTimePrev int64
+	checkpointSyncTimePrev  int64
+
+	archiverArchivedCount   int64
+	archiverFailedCount     int64
+	archiverLastArchivedAgo *int64 // NULL if no WAL file has been archived since the stats reset
+
 	oldestXID                         int64
 	percentTowardsWraparound          int64
 	percentTowardsEmergencyAutovacuum int64
@@ -82,8 +92,10 @@ type srvMetrics struct {
 type dbMetrics struct {
 	name string
 
-	updated   bool
-	hasCharts bool
+	updated             bool
+	hasCharts           bool
+	hasWaitEvents       bool
+	hasWaitEventsCharts bool
 
 	numBackends  int64
 	datConnLimit int64
@@ -125,6 +137,14 @@ type dbMetrics struct {
 	shareRowExclusiveLockAwaited    int64
 	exclusiveLockAwaited            int64
 	accessExclusiveLockAwaited      int64
+
+	waitEventLock            int64
+	waitEventLWLock          int64
+	waitEventIO              int64
+	waitEventClient          int64
+	waitEventActivity        int64
+	backendsStateActive      int64
+	backendsStateIdleInTrans int64
 }
 
 type replStandbyAppMetrics struct {
@@ -144,13 +164,45 @@ type replStandbyAppMetrics struct {
 }
 
 type replSlotMetrics struct {
-	name string
+	name     string
+	slotType string
 
-	updated   bool
-	hasCharts bool
+	updated      bool
+	hasCharts    bool
+	hasLag       bool
+	hasLagCharts bool
 
 	walKeep int64
 	files   int64
+
+	active               bool
+	restartLSNLag        int64
+	confirmedFlushLSNLag *int64
+}
+
+// statementMetrics is not recreated on every collection (see resetMetrics),
+// the previous pg_stat_statements values are needed to calculate per-interval values.
+type statementMetrics struct {
+	queryID string
+	query   string
+
+	updated bool
+	hasDims bool
+	hasPrev bool
+
+	// pg_stat_statements values (total time is in microseconds)
+	calls     int64
+	rows      int64
+	totalTime int64
+
+	prevCalls     int64
+	prevRows      int64
+	prevTotalTime int64
+
+	// not decreasing on stats reset
+	callsTotal int64
+	rowsTotal  int64
+	meanTime   int64
 }
 
 type tableMetrics struct {
@@ -168,6 +220,7 @@ type tableMetrics struct {
 	hasTableIdxIOCharts      bool
 	hasTableTOASTIOCharts    bool
 	hasTableTOASTIdxIOCharts bool
+	hasBloatCharts           bool
 
 	// pg_stat_user_tables
 	seqScan            int64
@@ -212,8 +265,9 @@ type indexMetrics struct {
 	schema string
 	table  string
 
-	updated   bool
-	hasCharts bool
+	updated        bool
+	hasCharts      bool
+	hasBloatCharts bool
 
 	idxScan     int64
 	idxTupRead  int64
@@ -11,10 +11,12 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,6 +33,7 @@ var (
 	dataV140004ServerCurrentConnections, _ = os.ReadFile("testdata/v14.4/server_current_connections.txt")
 	dataV140004ServerConnectionsState, _   = os.ReadFile("testdata/v14.4/server_connections_state.txt")
 	dataV140004Checkpoints, _              = os.ReadFile("testdata/v14.4/checkpoints.txt")
+	dataV140004Archiver, _                 = os.ReadFile("testdata/v14.4/archiver.txt")
 	dataV140004ServerUptime, _             = os.ReadFile("testdata/v14.4/uptime.txt")
 	dataV140004TXIDWraparound, _           = os.ReadFile("testdata/v14.4/txid_wraparound.txt")
 	dataV140004WALWrites, _                = os.ReadFile("testdata/v14.4/wal_writes.txt")
@@ -43,12 +46,14 @@ var (
 	dataV140004ReplStandbyAppDelta, _ = os.ReadFile("testdata/v14.4/replication_standby_app_wal_delta.txt")
 	dataV140004ReplStandbyAppLag, _   = os.ReadFile("testdata/v14.4/replication_standby_app_wal_lag.txt")
 
+	dataV140004ReplSlotLag, _   = os.ReadFile("testdata/v14.4/replication_slot_lag.txt")
 	dataV140004ReplSlotFiles, _ = os.ReadFile("testdata/v14.4/replication_slot_files.txt")
 
-	dataV140004DatabaseStats, _     = os.ReadFile("testdata/v14.4/database_stats.txt")
-	dataV140004DatabaseSize, _      = os.ReadFile("testdata/v14.4/database_size.txt")
-	dataV140004DatabaseConflicts, _ = os.ReadFile("testdata/v14.4/database_conflicts.txt")
-	dataV140004DatabaseLocks, _     = os.ReadFile("testdata/v14.4/database_locks.txt")
+	dataV140004DatabaseStats, _      = os.ReadFile("testdata/v14.4/database_stats.txt")
+	dataV140004DatabaseSize, _       = os.ReadFile("testdata/v14.4/database_size.txt")
+	dataV140004DatabaseConflicts, _  = os.ReadFile("testdata/v14.4/database_conflicts.txt")
+	dataV140004DatabaseLocks, _      = os.ReadFile("testdata/v14.4/database_locks.txt")
+	dataV140004DatabaseWaitEvents, _ = os.ReadFile("testdata/v14.4/database_wait_events.txt")
 
 	dataV140004QueryableDatabaseList, _ = os.ReadFile("testdata/v14.4/queryable_database_list.txt")
 
@@ -59,6 +64,9 @@ var (
 
 	dataV140004Bloat, _        = os.ReadFile("testdata/v14.4/bloat_tables.txt")
 	dataV140004ColumnsStats, _ = os.ReadFile("testdata/v14.4/table_columns_stats.txt")
+
+	dataV140004Statements, _      = os.ReadFile("testdata/v14.4/pg_stat_statements.txt")
+	dataV140004StatementsReset, _ = os.ReadFile("testdata/v14.4/pg_stat_statements-reset.txt")
 )
 
 func Test_testDataIsValid(t *testing.T) {
@@ -74,6 +82,7 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataV140004ServerCurrentConnections": dataV140004ServerCurrentConnections,
 		"dataV140004ServerConnectionsState":   dataV140004ServerConnectionsState,
 		"dataV140004Checkpoints":              dataV140004Checkpoints,
+		"dataV140004Archiver":                 dataV140004Archiver,
 		"dataV140004ServerUptime":             dataV140004ServerUptime,
 		"dataV140004TXIDWraparound":           dataV140004TXIDWraparound,
 		"dataV140004WALWrites":                dataV140004WALWrites,
@@ -86,12 +95,14 @@ func Test_testDataIsValid(t *testing.T) {
 		"dataV14004ReplStandbyAppDelta": dataV140004ReplStandbyAppDelta,
 		"dataV14004ReplStandbyAppLag":   dataV140004ReplStandbyAppLag,
 
+		"dataV140004ReplSlotLag":   dataV140004ReplSlotLag,
 		"dataV140004ReplSlotFiles": dataV140004ReplSlotFiles,
 
-		"dataV140004DatabaseStats":     dataV140004DatabaseStats,
-		"dataV140004DatabaseSize":      dataV140004DatabaseSize,
-		"dataV140004DatabaseConflicts": dataV140004DatabaseConflicts,
-		"dataV140004DatabaseLocks":     dataV140004DatabaseLocks,
+		"dataV140004DatabaseStats":      dataV140004DatabaseStats,
+		"dataV140004DatabaseSize":       dataV140004DatabaseSize,
+		"dataV140004DatabaseConflicts":  dataV140004DatabaseConflicts,
+		"dataV140004DatabaseLocks":      dataV140004DatabaseLocks,
+		"dataV140004DatabaseWaitEvents": dataV140004DatabaseWaitEvents,
 
 		"dataV140004QueryableDatabaseList": dataV140004QueryableDatabaseList,
 
@@ -102,6 +113,9 @@ func Test_testDataIsValid(t *testing.T) {
 
 		"dataV140004Bloat":        dataV140004Bloat,
 		"dataV140004ColumnsStats": dataV140004ColumnsStats,
+
+		"dataV140004Statements":      dataV140004Statements,
+		"dataV140004StatementsReset": dataV140004StatementsReset,
 	} {
 		require.NotNilf(t, data, name)
 	}
@@ -153,6 +167,7 @@ func TestPostgres_Check(t *testing.T) {
 			wantFail: false,
 			prepareMock: func(t *testing.T, pg *Postgres, m sqlmock.Sqlmock) {
 				pg.dbSr = matcher.TRUE()
+				pg.CollectBloat = true
 
 				mockExpect(t, m, queryServerVersion(), dataV140004ServerVersionNum)
 				mockExpect(t, m, queryIsSuperUser(), dataV140004IsSuperUserTrue)
@@ -163,7 +178,8 @@ func TestPostgres_Check(t *testing.T) {
 
 				mockExpect(t, m, queryServerCurrentConnectionsUsed(), dataV140004ServerCurrentConnections)
 				mockExpect(t, m, queryServerConnectionsState(), dataV140004ServerConnectionsState)
-				mockExpect(t, m, queryCheckpoints(), dataV140004Checkpoints)
+				mockExpect(t, m, queryCheckpoints(140004), dataV140004Checkpoints)
+				mockExpect(t, m, queryArchiver(), dataV140004Archiver)
 				mockExpect(t, m, queryServerUptime(), dataV140004ServerUptime)
 				mockExpect(t, m, queryTXIDWraparound(), dataV140004TXIDWraparound)
 				mockExpect(t, m, queryWALWrites(140004), dataV140004WALWrites)
@@ -176,18 +192,20 @@ func TestPostgres_Check(t *testing.T) {
 
 				mockExpect(t, m, queryReplicationStandbyAppDelta(140004), dataV140004ReplStandbyAppDelta)
 				mockExpect(t, m, queryReplicationStandbyAppLag(), dataV140004ReplStandbyAppLag)
+				mockExpect(t, m, queryReplicationSlotLag(), dataV140004ReplSlotLag)
 				mockExpect(t, m, queryReplicationSlotFiles(140004), dataV140004ReplSlotFiles)
 
 				mockExpect(t, m, queryDatabaseStats(), dataV140004DatabaseStats)
 				mockExpect(t, m, queryDatabaseSize(), dataV140004DatabaseSize)
 				mockExpect(t, m, queryDatabaseConflicts(), dataV140004DatabaseConflicts)
 				mockExpect(t, m, queryDatabaseLocks(), dataV140004DatabaseLocks)
+				mockExpect(t, m, queryDatabaseWaitEvents(), dataV140004DatabaseWaitEvents)
 
 				mockExpect(t, m, queryQueryableDatabaseList(), dataV140004QueryableDatabaseList)
 				mockExpect(t, m, queryStatUserTables(), dataV140004StatUserTablesDBPostgres)
 				mockExpect(t, m, queryStatIOUserTables(), dataV140004StatIOUserTablesDBPostgres)
 				mockExpect(t, m, queryStatUserIndexes(), dataV140004StatUserIndexesDBPostgres)
-				mockExpect(t, m, queryBloat(), dataV140004Bloat)
+				mockExpectBloat(t, m, dataV140004Bloat)
 				mockExpect(t, m, queryColumnsStats(), dataV140004ColumnsStats)
 			},
 		},
@@ -257,6 +275,7 @@ func TestPostgres_Collect(t *testing.T) {
 			{
 				prepareMock: func(t *testing.T, pg *Postgres, m sqlmock.Sqlmock) {
 					pg.dbSr = matcher.TRUE()
+					pg.CollectBloat = true
 					mockExpect(t, m, queryServerVersion(), dataV140004ServerVersionNum)
 					mockExpect(t, m, queryIsSuperUser(), dataV140004IsSuperUserTrue)
 					mockExpect(t, m, queryPGIsInRecovery(), dataV140004PGIsInRecoveryTrue)
@@ -266,7 +285,8 @@ func TestPostgres_Collect(t *testing.T) {
 
 					mockExpect(t, m, queryServerCurrentConnectionsUsed(), dataV140004ServerCurrentConnections)
 					mockExpect(t, m, queryServerConnectionsState(), dataV140004ServerConnectionsState)
-					mockExpect(t, m, queryCheckpoints(), dataV140004Checkpoints)
+					mockExpect(t, m, queryCheckpoints(140004), dataV140004Checkpoints)
+					mockExpect(t, m, queryArchiver(), dataV140004Archiver)
 					mockExpect(t, m, queryServerUptime(), dataV140004ServerUptime)
 					mockExpect(t, m, queryTXIDWraparound(), dataV140004TXIDWraparound)
 					mockExpect(t, m, queryWALWrites(140004), dataV140004WALWrites)
@@ -279,18 +299,20 @@ func TestPostgres_Collect(t *testing.T) {
 
 					mockExpect(t, m, queryReplicationStandbyAppDelta(140004), dataV140004ReplStandbyAppDelta)
 					mockExpect(t, m, queryReplicationStandbyAppLag(), dataV140004ReplStandbyAppLag)
+					mockExpect(t, m, queryReplicationSlotLag(), dataV140004ReplSlotLag)
 					mockExpect(t, m, queryReplicationSlotFiles(140004), dataV140004ReplSlotFiles)
 
 					mockExpect(t, m, queryDatabaseStats(), dataV140004DatabaseStats)
 					mockExpect(t, m, queryDatabaseSize(), dataV140004DatabaseSize)
 					mockExpect(t, m, queryDatabaseConflicts(), dataV140004DatabaseConflicts)
 					mockExpect(t, m, queryDatabaseLocks(), dataV140004DatabaseLocks)
+					mockExpect(t, m, queryDatabaseWaitEvents(), dataV140004DatabaseWaitEvents)
 
 					mockExpect(t, m, queryQueryableDatabaseList(), dataV140004QueryableDatabaseList)
 					mockExpect(t, m, queryStatUserTables(), dataV140004StatUserTablesDBPostgres)
 					mockExpect(t, m, queryStatIOUserTables(), dataV140004StatIOUserTablesDBPostgres)
 					mockExpect(t, m, queryStatUserIndexes(), dataV140004StatUserIndexesDBPostgres)
-					mockExpect(t, m, queryBloat(), dataV140004Bloat)
+					mockExpectBloat(t, m, dataV140004Bloat)
 					mockExpect(t, m, queryColumnsStats(), dataV140004ColumnsStats)
 				},
 				check: func(t *testing.T, pg *Postgres) {
@@ -328,10 +350,14 @@ func TestPostgres_Collect(t *testing.T) {
 						"catalog_relkind_v_count":                                  137,
 						"catalog_relkind_v_size":                                   0,
 						"checkpoint_sync_time":                                     47,
+						"checkpoint_sync_time_avg":                                 25,
 						"checkpoint_write_time":                                    167,
+						"checkpoint_write_time_avg":                                91,
 						"checkpoints_req":                                          16,
 						"checkpoints_timed":                                        1814,
 						"databases_count":                                          2,
+						"db_postgres_backends_state_active":                        1,
+						"db_postgres_backends_state_idle_in_transaction":           1,
 						"db_postgres_blks_hit":                                     1221125,
 						"db_postgres_blks_read":                                    3252,
 						"db_postgres_blks_read_perc":                               0,
@@ -369,8 +395,15 @@ func TestPostgres_Collect(t *testing.T) {
 						"db_postgres_tup_inserted":                                 0,
 						"db_postgres_tup_returned":                                 13207245,
 						"db_postgres_tup_updated":                                  0,
+						"db_postgres_wait_event_activity":                          0,
+						"db_postgres_wait_event_client":                            2,
+						"db_postgres_wait_event_io":                                0,
+						"db_postgres_wait_event_lock":                              1,
+						"db_postgres_wait_event_lwlock":                            0,
 						"db_postgres_xact_commit":                                  1438660,
 						"db_postgres_xact_rollback":                                70,
+						"db_production_backends_state_active":                      6,
+						"db_production_backends_state_idle_in_transaction":         0,
 						"db_production_blks_hit":                                   0,
 						"db_production_blks_read":                                  0,
 						"db_production_blks_read_perc":                             0,
@@ -408,6 +441,11 @@ func TestPostgres_Collect(t *testing.T) {
 						"db_production_tup_inserted":                               0,
 						"db_production_tup_returned":                               0,
 						"db_production_tup_updated":                                0,
+						"db_production_wait_event_activity":                        0,
+						"db_production_wait_event_client":                          0,
+						"db_production_wait_event_io":                              2,
+						"db_production_wait_event_lock":                            3,
+						"db_production_wait_event_lwlock":                          1,
 						"db_production_xact_commit":                                0,
 						"db_production_xact_rollback":                              0,
 						"index_myaccounts_email_key_table_myaccounts_db_postgres_schema_myschema_size":                     8192,
@@ -453,8 +491,15 @@ func TestPostgres_Collect(t *testing.T) {
 						"query_running_time_hist_bucket_inf":                                    0,
 						"query_running_time_hist_count":                                         1,
 						"query_running_time_hist_sum":                                           0,
+						"repl_slot_debezium_active":                                             0,
+						"repl_slot_debezium_confirmed_flush_lsn_lag":                            42949670912,
+						"repl_slot_debezium_replslot_files":                                     0,
+						"repl_slot_debezium_replslot_wal_keep":                                  0,
+						"repl_slot_debezium_restart_lsn_lag":                                    42949672960,
+						"repl_slot_ocean_active":                                                1,
 						"repl_slot_ocean_replslot_files":                                        0,
 						"repl_slot_ocean_replslot_wal_keep":                                     0,
+						"repl_slot_ocean_restart_lsn_lag":                                       16400,
 						"repl_standby_app_phys-standby2_wal_flush_lag_size":                     0,
 						"repl_standby_app_phys-standby2_wal_flush_lag_time":                     0,
 						"repl_standby_app_phys-standby2_wal_replay_lag_size":                    0,
@@ -601,6 +646,9 @@ func TestPostgres_Collect(t *testing.T) {
 						"transaction_running_time_hist_sum":                                     4022,
 						"wal_archive_files_done_count":                                          1,
 						"wal_archive_files_ready_count":                                         1,
+						"wal_archiver_archived":                                                 232,
+						"wal_archiver_failed":                                                   3,
+						"wal_archiver_last_archived_ago":                                        42,
 						"wal_recycled_files":                                                    0,
 						"wal_writes":                                                            24103144,
 						"wal_written_files":                                                     1,
@@ -682,10 +730,262 @@ func TestPostgres_Collect(t *testing.T) {
 	}
 }
 
+func TestPostgres_doQueryReplicationMetrics_ReplSlots(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pg := New()
+	pg.db = db
+	pg.pgVersion = 140004
+	superUser := false
+	pg.superUser = &superUser
+	require.True(t, pg.Init())
+
+	mockExpect(t, mock, queryReplicationStandbyAppDelta(140004), dataV140004ReplStandbyAppDelta)
+	mockExpect(t, mock, queryReplicationStandbyAppLag(), dataV140004ReplStandbyAppLag)
+	mockExpect(t, mock, queryReplicationSlotLag(), dataV140004ReplSlotLag)
+	pg.resetMetrics()
+	require.NoError(t, pg.doQueryReplicationMetrics())
+
+	mx := make(map[string]int64)
+	pg.collectMetrics(mx)
+
+	assert.EqualValues(t, 42949670912, mx["repl_slot_debezium_confirmed_flush_lsn_lag"])
+	assert.NotContains(t, mx, "repl_slot_ocean_confirmed_flush_lsn_lag")
+	assert.NotContains(t, mx, "repl_slot_ocean_replslot_files")
+	require.NotNil(t, pg.Charts().Get("replication_slot_ocean_lag_size"))
+	assert.False(t, pg.Charts().Get("replication_slot_ocean_lag_size").HasDim("repl_slot_ocean_confirmed_flush_lsn_lag"))
+	assert.True(t, pg.Charts().Get("replication_slot_debezium_lag_size").HasDim("repl_slot_debezium_confirmed_flush_lsn_lag"))
+	assert.Nil(t, pg.Charts().Get("replication_slot_ocean_files_count"))
+
+	// no access to pg_replication_slots: the query is skipped after the first failure
+	mockExpect(t, mock, queryReplicationStandbyAppDelta(140004), dataV140004ReplStandbyAppDelta)
+	mockExpect(t, mock, queryReplicationStandbyAppLag(), dataV140004ReplStandbyAppLag)
+	mock.ExpectQuery(queryReplicationSlotLag()).WillReturnError(&pgconn.PgError{
+		Code:    "42501",
+		Message: "permission denied for view pg_replication_slots",
+	})
+	pg.resetMetrics()
+	require.NoError(t, pg.doQueryReplicationMetrics())
+	assert.True(t, pg.replSlotsAccessDenied)
+
+	mockExpect(t, mock, queryReplicationStandbyAppDelta(140004), dataV140004ReplStandbyAppDelta)
+	mockExpect(t, mock, queryReplicationStandbyAppLag(), dataV140004ReplStandbyAppLag)
+	pg.resetMetrics()
+	require.NoError(t, pg.doQueryReplicationMetrics())
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_doQueryDatabaseWaitEvents_DBSelector(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pg := New()
+	pg.db = db
+	pg.pgVersion = 140004
+	require.True(t, pg.Init())
+	pg.dbSr = matcher.Must(matcher.NewSimplePatternsMatcher("postgres"))
+
+	mockExpect(t, mock, queryDatabaseStats(), dataV140004DatabaseStats)
+	mockExpect(t, mock, queryDatabaseWaitEvents(), dataV140004DatabaseWaitEvents)
+	pg.resetMetrics()
+	require.NoError(t, pg.doQueryDatabaseStats())
+	require.NoError(t, pg.doQueryDatabaseWaitEvents())
+
+	mx := make(map[string]int64)
+	pg.collectMetrics(mx)
+
+	assert.EqualValues(t, 1, mx["db_postgres_wait_event_lock"])
+	assert.NotContains(t, mx, "db_production_wait_event_lock")
+	assert.NotNil(t, pg.Charts().Get("db_postgres_wait_events_count"))
+	assert.Nil(t, pg.Charts().Get("db_production_wait_events_count"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_doQueryDatabaseWaitEvents_IdleDatabase(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pg := New()
+	pg.db = db
+	pg.pgVersion = 140004
+	require.True(t, pg.Init())
+
+	waitEvents := []byte(`
+  datname   | wait_event_lock | wait_event_lwlock | wait_event_io | wait_event_client | wait_event_activity | state_active | state_idle_in_transaction
+------------+-----------------+-------------------+---------------+-------------------+---------------------+--------------+---------------------------
+ postgres   |               1 |                 0 |             0 |                 2 |                   0 |            1 |                         1
+ production |               0 |                 0 |             0 |                 0 |                   0 |            0 |                         0
+`)
+	mockExpect(t, mock, queryDatabaseStats(), dataV140004DatabaseStats)
+	mockExpect(t, mock, queryDatabaseWaitEvents(), waitEvents)
+	pg.resetMetrics()
+	require.NoError(t, pg.doQueryDatabaseStats())
+	require.NoError(t, pg.doQueryDatabaseWaitEvents())
+
+	mx := make(map[string]int64)
+	pg.collectMetrics(mx)
+
+	assert.EqualValues(t, 1, mx["db_postgres_wait_event_lock"])
+	assert.Contains(t, mx, "db_production_wait_event_lock")
+	assert.EqualValues(t, 0, mx["db_production_wait_event_lock"])
+	assert.EqualValues(t, 0, mx["db_production_backends_state_active"])
+	assert.NotNil(t, pg.Charts().Get("db_production_wait_events_count"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_doQueryBloat_TopN(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pg := New()
+	pg.db = db
+	pg.pgVersion = 140004
+	pg.CollectBloat = true
+	pg.BloatTopN = 1
+	require.True(t, pg.Init())
+
+	mockExpect(t, mock, queryStatUserTables(), dataV140004StatUserTablesDBPostgres)
+	mockExpect(t, mock, queryStatUserIndexes(), dataV140004StatUserIndexesDBPostgres)
+	mockExpectBloat(t, mock, dataV140004Bloat)
+	pg.resetMetrics()
+	require.NoError(t, pg.doQueryStatUserTable())
+	require.NoError(t, pg.doQueryStatUserIndexes())
+	require.NoError(t, pg.doQueryBloat())
+
+	mx := make(map[string]int64)
+	pg.collectMetrics(mx)
+
+	var tables, indexes int
+	for k := range mx {
+		switch {
+		case strings.HasPrefix(k, "table_") && strings.HasSuffix(k, "_bloat_size"):
+			tables++
+		case strings.HasPrefix(k, "index_") && strings.HasSuffix(k, "_bloat_size"):
+			indexes++
+		}
+	}
+	assert.Equal(t, 1, tables)
+	assert.Equal(t, 1, indexes)
+	assert.EqualValues(t, 9863168, mx["table_pgbench_accounts_db_postgres_schema_public_bloat_size"])
+	chart := pg.Charts().Get("table_pgbench_accounts_db_postgres_schema_public_bloat_size")
+	require.NotNil(t, chart)
+
+	// the estimation failed: the previous values are not valid anymore
+	mockExpect(t, mock, queryStatUserTables(), dataV140004StatUserTablesDBPostgres)
+	mockExpect(t, mock, queryStatUserIndexes(), dataV140004StatUserIndexesDBPostgres)
+	mock.ExpectBegin()
+	mock.ExpectExec(queryStatementTimeout(1800)).WillReturnError(errors.New("mock error"))
+	mock.ExpectRollback()
+	pg.resetMetrics()
+	require.NoError(t, pg.doQueryStatUserTable())
+	require.NoError(t, pg.doQueryStatUserIndexes())
+	require.NoError(t, pg.doQueryBloat())
+
+	mx = make(map[string]int64)
+	pg.collectMetrics(mx)
+
+	assert.NotContains(t, mx, "table_pgbench_accounts_db_postgres_schema_public_bloat_size")
+	assert.True(t, chart.Obsolete)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_statementTimeout(t *testing.T) {
+	assert.Equal(t, int64(1800), statementTimeout(time.Second*2))
+	assert.Equal(t, int64(9), statementTimeout(time.Millisecond*10))
+	assert.Equal(t, int64(1), statementTimeout(time.Microsecond))
+}
+
+func TestPostgres_doQueryStatementsMetrics(t *testing.T) {
+	db, mock, err := sqlmock.New(
+		sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual),
+	)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	pg := New()
+	pg.db = db
+	pg.pgVersion = 140004
+	pg.CollectStatements = true
+	require.True(t, pg.Init())
+
+	collect := func() map[string]int64 {
+		require.NoError(t, pg.doQueryStatementsMetrics())
+		mx := make(map[string]int64)
+		pg.collectMetrics(mx)
+		return mx
+	}
+	q := queryStatements(140004, 10)
+
+	mockExpect(t, mock, q, dataV140004Statements)
+	mx := collect()
+
+	assert.EqualValues(t, 12000, mx["statement_-2793465187239184640_calls"])
+	assert.EqualValues(t, 12000, mx["statement_-2793465187239184640_rows"])
+	assert.EqualValues(t, 4978, mx["statement_-2793465187239184640_mean_time"])
+	assert.EqualValues(t, 100, mx["statement_4387281536262345120_mean_time"])
+	chart := pg.Charts().Get(statementsCallsRateChart.ID)
+	require.NotNil(t, chart)
+	require.Len(t, chart.Dims, 3)
+	require.NotNil(t, chart.GetDim("statement_-2793465187239184640_calls"))
+	assert.Equal(t, "UPDATE pgbench_branches SET bbalance = b (-2793465187239184640)",
+		chart.GetDim("statement_-2793465187239184640_calls").Name)
+
+	// no new calls
+	mockExpect(t, mock, q, dataV140004Statements)
+	mx = collect()
+
+	assert.EqualValues(t, 12000, mx["statement_-2793465187239184640_calls"])
+	assert.EqualValues(t, 0, mx["statement_-2793465187239184640_mean_time"])
+
+	// pg_stat_statements_reset(), one of the statements is gone
+	mockExpect(t, mock, q, dataV140004StatementsReset)
+	mx = collect()
+
+	assert.EqualValues(t, 12100, mx["statement_-2793465187239184640_calls"])
+	assert.EqualValues(t, 12100, mx["statement_-2793465187239184640_rows"])
+	assert.EqualValues(t, 6005, mx["statement_-2793465187239184640_mean_time"])
+	assert.EqualValues(t, 12050, mx["statement_7470290815457470938_calls"])
+	assert.NotContains(t, mx, "statement_4387281536262345120_calls")
+	assert.True(t, chart.GetDim("statement_4387281536262345120_calls").Obsolete)
+
+	// the extension is not available: the query is skipped after the first failure
+	mock.ExpectQuery(q).WillReturnError(&pgconn.PgError{
+		Code:    "55000",
+		Message: "pg_stat_statements must be loaded via shared_preload_libraries",
+	})
+	mx = collect()
+	assert.True(t, pg.statementsUnavailable)
+	assert.NotContains(t, mx, "statement_-2793465187239184640_calls")
+	assert.Empty(t, pg.mx.statements)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func mockExpect(t *testing.T, mock sqlmock.Sqlmock, query string, rows []byte) {
 	mock.ExpectQuery(query).WillReturnRows(mustMockRows(t, rows)).RowsWillBeClosed()
 }
 
+func mockExpectBloat(t *testing.T, mock sqlmock.Sqlmock, rows []byte) {
+	mock.ExpectBegin()
+	mock.ExpectExec(queryStatementTimeout(1800)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mockExpect(t, mock, queryBloat(), rows)
+	mock.ExpectCommit()
+}
+
 func mockExpectErr(mock sqlmock.Sqlmock, query string) {
 	mock.ExpectQuery(query).WillReturnError(errors.New("mock error"))
 }
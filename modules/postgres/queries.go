@@ -2,6 +2,8 @@
 
 package postgres
 
+import "fmt"
+
 func queryServerVersion() string {
 	return "SHOW server_version_num;"
 }
@@ -51,11 +53,27 @@ GROUP BY state;
 `
 }
 
-func queryCheckpoints() string {
+func queryCheckpoints(version int) string {
 	// definition by version: https://pgpedia.info/p/pg_stat_bgwriter.html
 	// docs: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-BGWRITER-VIEW
 	// code: https://github.com/postgres/postgres/blob/366283961ac0ed6d89014444c6090f3fd02fce0a/src/backend/catalog/system_views.sql#L1104
 
+	if version >= pgVersion17 {
+		// the checkpoint columns were moved to pg_stat_checkpointer, buffers_backend* were removed (see pg_stat_io)
+		// docs: https://www.postgresql.org/docs/17/monitoring-stats.html#MONITORING-PG-STAT-CHECKPOINTER-VIEW
+		return `
+SELECT c.num_timed                                                  AS checkpoints_timed,
+       c.num_requested                                              AS checkpoints_req,
+       c.write_time                                                 AS checkpoint_write_time,
+       c.sync_time                                                  AS checkpoint_sync_time,
+       c.buffers_written * current_setting('block_size')::numeric AS buffers_checkpoint_bytes,
+       b.buffers_clean * current_setting('block_size')::numeric   AS buffers_clean_bytes,
+       b.maxwritten_clean,
+       b.buffers_alloc * current_setting('block_size')::numeric   AS buffers_alloc_bytes
+FROM pg_stat_checkpointer c,
+     pg_stat_bgwriter b;
+`
+	}
 	return `
 SELECT checkpoints_timed,
        checkpoints_req,
@@ -71,6 +89,17 @@ FROM pg_stat_bgwriter;
 `
 }
 
+func queryArchiver() string {
+	// docs: https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-ARCHIVER-VIEW
+
+	return `
+SELECT archived_count,
+       failed_count,
+       EXTRACT(epoch FROM now() - last_archived_time)::bigint AS last_archived_ago
+FROM pg_stat_archiver;
+`
+}
+
 func queryServerUptime() string {
 	return `SELECT EXTRACT(epoch FROM CURRENT_TIMESTAMP - pg_postmaster_start_time());`
 }
@@ -321,6 +350,27 @@ WHERE application_name IS NOT NULL;
 `
 }
 
+func queryReplicationSlotLag() string {
+	return `
+SELECT slot_name,
+       slot_type,
+       active,
+       COALESCE(pg_wal_lsn_diff(
+               CASE pg_is_in_recovery()
+                   WHEN true THEN pg_last_wal_receive_lsn()
+                   ELSE pg_current_wal_lsn()
+                   END,
+               restart_lsn), 0)::bigint AS restart_lsn_lag,
+       pg_wal_lsn_diff(
+               CASE pg_is_in_recovery()
+                   WHEN true THEN pg_last_wal_receive_lsn()
+                   ELSE pg_current_wal_lsn()
+                   END,
+               confirmed_flush_lsn)::bigint AS confirmed_flush_lsn_lag
+FROM pg_replication_slots;
+`
+}
+
 func queryReplicationSlotFiles(version int) string {
 	if version < pgVersion11 {
 		return `
@@ -522,6 +572,29 @@ ORDER BY datname,
 `
 }
 
+func queryDatabaseWaitEvents() string {
+	// docs: https://www.postgresql.org/docs/current/monitoring-stats.html#WAIT-EVENT-TABLE
+	// driven by pg_database, databases without backends have zero counts
+
+	return `
+SELECT pg_database.datname,
+       count(pid) FILTER (WHERE wait_event_type = 'Lock')      AS wait_event_lock,
+       count(pid) FILTER (WHERE wait_event_type = 'LWLock')    AS wait_event_lwlock,
+       count(pid) FILTER (WHERE wait_event_type = 'IO')        AS wait_event_io,
+       count(pid) FILTER (WHERE wait_event_type = 'Client')    AS wait_event_client,
+       count(pid) FILTER (WHERE wait_event_type = 'Activity')  AS wait_event_activity,
+       count(pid) FILTER (WHERE state = 'active')              AS state_active,
+       count(pid) FILTER (WHERE state = 'idle in transaction') AS state_idle_in_transaction
+FROM pg_database
+         LEFT JOIN
+     pg_stat_activity
+     ON pg_stat_activity.datid = pg_database.oid
+         AND pid <> pg_backend_pid()
+WHERE pg_database.datistemplate = false
+GROUP BY pg_database.datname;
+`
+}
+
 func queryUserTablesCount() string {
 	return "SELECT count(*) from  pg_stat_user_tables;"
 }
@@ -598,6 +671,10 @@ WHERE has_schema_privilege(schemaname, 'USAGE');
 // Copyright (c) 2007-2017 Greg Sabino Mullane
 //------------------------------------------------------------------------------
 
+func queryStatementTimeout(ms int64) string {
+	return fmt.Sprintf("SET LOCAL statement_timeout = %d;", ms)
+}
+
 func queryBloat() string {
 	return `
 SELECT
@@ -715,3 +792,22 @@ ORDER BY nspname,
          st.attname;
 `
 }
+
+func queryStatements(version, topN int) string {
+	totalTime := "total_exec_time"
+	if version < pgVersion13 {
+		totalTime = "total_time"
+	}
+	return fmt.Sprintf(`
+SELECT queryid,
+       left(min(query), 256) AS query,
+       sum(calls)            AS calls,
+       sum(%s)               AS total_time,
+       sum(rows)             AS rows
+FROM pg_stat_statements
+WHERE queryid IS NOT NULL
+GROUP BY queryid
+ORDER BY total_time DESC
+LIMIT %d;
+`, totalTime, topN)
+}
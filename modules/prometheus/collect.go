@@ -49,7 +49,7 @@ func (p *Prometheus) collect() (map[string]int64, error) {
 	defer p.removeStaleCharts()
 
 	for _, mf := range mfs {
-		if strings.HasSuffix(mf.Name(), "_info") {
+		if isInfo(mf) {
 			continue
 		}
 		if p.MaxTSPerMetric > 0 && len(mf.Metrics()) > p.MaxTSPerMetric {
@@ -59,7 +59,7 @@ func (p *Prometheus) collect() (map[string]int64, error) {
 		}
 
 		switch mf.Type() {
-		case textparse.MetricTypeGauge:
+		case textparse.MetricTypeGauge, textparse.MetricTypeStateset:
 			p.collectGauge(mx, mf)
 		case textparse.MetricTypeCounter:
 			p.collectCounter(mx, mf)
@@ -230,6 +230,19 @@ var (
 	backslashReplacer = strings.NewReplacer(`\`, "_")
 )
 
+// isInfo reports whether the metric family is an info metric. The declared type is used if there is one,
+// otherwise it is guessed by the name.
+func isInfo(mf *prometheus.MetricFamily) bool {
+	switch mf.Type() {
+	case textparse.MetricTypeInfo:
+		return true
+	case textparse.MetricTypeUnknown:
+		return strings.HasSuffix(mf.Name(), "_info")
+	default:
+		return false
+	}
+}
+
 func hasPrefix(mf map[string]*prometheus.MetricFamily, prefix string) bool {
 	for name := range mf {
 		if strings.HasPrefix(name, prefix) {
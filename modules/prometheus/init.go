@@ -8,6 +8,7 @@ import (
 	"os"
 
 	"github.com/netdata/go.d.plugin/pkg/prometheus"
+	"github.com/netdata/go.d.plugin/pkg/prometheus/relabel"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
 
@@ -38,8 +39,23 @@ func (p *Prometheus) initPrometheusClient() (prometheus.Prometheus, error) {
 		return nil, fmt.Errorf("parsing selector: %v", err)
 	}
 
+	var opts []prometheus.Option
+	if p.CacheTTL.Duration > 0 {
+		opts = append(opts, prometheus.WithCacheTTL(p.CacheTTL.Duration))
+	}
+	if len(p.Relabel) > 0 {
+		rl, err := relabel.New(p.Relabel)
+		if err != nil {
+			return nil, fmt.Errorf("relabel: %v", err)
+		}
+		opts = append(opts, prometheus.WithRelabeler(rl))
+	}
+
+	if len(p.FallbackURLs) > 0 {
+		return prometheus.NewWithFallback(httpClient, prometheus.FallbackRequests(req, p.FallbackURLs), sr, opts...), nil
+	}
 	if sr != nil {
-		return prometheus.NewWithSelector(httpClient, req, sr), nil
+		return prometheus.NewWithSelector(httpClient, req, sr, opts...), nil
 	}
-	return prometheus.New(httpClient, req), nil
+	return prometheus.New(httpClient, req, opts...), nil
 }
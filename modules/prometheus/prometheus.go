@@ -7,6 +7,7 @@ import (
 
 	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/netdata/go.d.plugin/pkg/prometheus"
+	"github.com/netdata/go.d.plugin/pkg/prometheus/relabel"
 	"github.com/netdata/go.d.plugin/pkg/prometheus/selector"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
@@ -38,11 +39,14 @@ func New() *Prometheus {
 
 type Config struct {
 	web.HTTP        `yaml:",inline"`
-	Name            string `yaml:"name"`
-	Application     string `yaml:"app"`
-	BearerTokenFile string `yaml:"bearer_token_file"`
-
-	Selector selector.Expr `yaml:"selector"`
+	Name            string       `yaml:"name"`
+	Application     string       `yaml:"app"`
+	BearerTokenFile string       `yaml:"bearer_token_file"`
+	FallbackURLs    []string     `yaml:"fallback_urls"`
+	CacheTTL        web.Duration `yaml:"cache_ttl"`
+
+	Selector selector.Expr    `yaml:"selector"`
+	Relabel  []relabel.Config `yaml:"relabel"`
 
 	ExpectedPrefix string `yaml:"expected_prefix"`
 	MaxTS          int    `yaml:"max_time_series"`
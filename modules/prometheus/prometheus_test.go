@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/prometheus/relabel"
 	"github.com/netdata/go.d.plugin/pkg/prometheus/selector"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
@@ -32,6 +33,20 @@ func TestPrometheus_Init(t *testing.T) {
 				Selector: selector.Expr{Allow: []string{`name{label=#"value"}`}},
 			},
 		},
+		"valid relabel rules": {
+			wantFail: false,
+			config: Config{
+				HTTP:    web.HTTP{Request: web.Request{URL: "http://127.0.0.1:9090/metric"}},
+				Relabel: []relabel.Config{{SourceLabels: []string{"instance"}, Regex: "(.+):.*", TargetLabel: "host", Replacement: "$1"}},
+			},
+		},
+		"invalid relabel rules": {
+			wantFail: true,
+			config: Config{
+				HTTP:    web.HTTP{Request: web.Request{URL: "http://127.0.0.1:9090/metric"}},
+				Relabel: []relabel.Config{{SourceLabels: []string{"instance"}, Action: "unknown"}},
+			},
+		},
 		"default": {
 			wantFail: true,
 			config:   New().Config,
@@ -238,6 +253,24 @@ test_gauge_metric_1{label1="value2"} 12
 				},
 			},
 		},
+		"Info": {
+			prepare: New,
+			steps: []testCaseStep{
+				{
+					desc: "Declared gauge collected, untyped and info ignored",
+					input: `
+# HELP test_gauge_metric_info Test Gauge Metric
+# TYPE test_gauge_metric_info gauge
+test_gauge_metric_info{label1="value1"} 11
+test_untyped_metric_info{version="1.0"} 1
+`,
+					wantCollected: map[string]int64{
+						"test_gauge_metric_info-label1=value1": 11000,
+					},
+					wantCharts: 1,
+				},
+			},
+		},
 		"Counter": {
 			prepare: New,
 			steps: []testCaseStep{
@@ -2,7 +2,12 @@
 
 package redis
 
-import "github.com/netdata/go.d.plugin/agent/module"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
 
 const (
 	prioConnections = module.Priority + iota
@@ -22,6 +27,7 @@ const (
 	prioMasterLinkStatus
 	prioMasterLastIOSinceTime
 	prioMasterLinkDownSinceTime
+	prioRoleChanges
 
 	prioPersistenceRDBChanges
 	prioPersistenceRDBBgSaveNow
@@ -32,11 +38,21 @@ const (
 	prioCommandsCalls
 	prioCommandsUsec
 	prioCommandsUsecPerSec
+	prioCommandCalls
+	prioCommandUsecPerCall
+	prioCommandLatency
 
 	prioKeyExpiration
 	prioKeys
 	prioExpiresKeys
 
+	prioClusterState
+	prioClusterSlots
+	prioClusterKnownNodes
+	prioClusterKeys
+	prioClusterNodeRole
+	prioClusterNodeKeys
+
 	prioUptime
 )
 
@@ -55,6 +71,7 @@ var redisCharts = module.Charts{
 	chartNet.Copy(),
 
 	chartConnectedReplicas.Copy(),
+	chartRoleChanges.Copy(),
 
 	chartPersistenceRDBChanges.Copy(),
 	chartPersistenceRDBBgSaveNow.Copy(),
@@ -288,6 +305,63 @@ var (
 	}
 )
 
+var (
+	commandChartsTmpl = module.Charts{
+		chartCommandCallsTmpl.Copy(),
+		chartCommandUsecPerCallTmpl.Copy(),
+	}
+
+	chartCommandCallsTmpl = module.Chart{
+		ID:       "command_%s_calls",
+		Title:    "Command calls",
+		Units:    "calls/s",
+		Fam:      "commands",
+		Ctx:      "redis.command_calls",
+		Priority: prioCommandCalls,
+		Dims: module.Dims{
+			{ID: "cmd_%s_calls", Name: "calls", Algo: module.Incremental},
+		},
+	}
+	chartCommandUsecPerCallTmpl = module.Chart{
+		ID:       "command_%s_usec_per_call",
+		Title:    "Command average CPU time per call",
+		Units:    "microseconds",
+		Fam:      "commands",
+		Ctx:      "redis.command_usec_per_call",
+		Priority: prioCommandUsecPerCall,
+		Dims: module.Dims{
+			{ID: "cmd_%s_usec_per_call", Name: "usec_per_call", Div: precision},
+		},
+	}
+	chartCommandLatencyTmpl = module.Chart{
+		ID:       "command_%s_latency_percentiles",
+		Title:    "Command latency percentiles",
+		Units:    "microseconds",
+		Fam:      "commands",
+		Ctx:      "redis.command_latency_percentiles",
+		Priority: prioCommandLatency,
+	}
+)
+
+func newCommandCharts(cmd string) *module.Charts {
+	charts := commandChartsTmpl.Copy()
+	for _, c := range *charts {
+		c.ID = commandChartID(c.ID, cmd)
+		c.Labels = []module.Label{
+			{Key: "command", Value: strings.ToUpper(cmd)},
+		}
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, cmd)
+		}
+	}
+	return charts
+}
+
+// commandChartID returns the chart id, subcommands ('client|list') are not allowed in the id.
+func commandChartID(tmpl, cmd string) string {
+	return fmt.Sprintf(tmpl, strings.ReplaceAll(cmd, "|", "_"))
+}
+
 var (
 	chartKeyExpiration = module.Chart{
 		ID:       "key_expiration_events",
@@ -332,6 +406,17 @@ var (
 			{ID: "connected_slaves", Name: "connected"},
 		},
 	}
+	chartRoleChanges = module.Chart{
+		ID:       "role_changes",
+		Title:    "Role changes (master address changes in the Sentinel mode)",
+		Units:    "changes/s",
+		Fam:      "replication",
+		Ctx:      "redis.role_changes",
+		Priority: prioRoleChanges,
+		Dims: module.Dims{
+			{ID: "role_changes", Name: "changes", Algo: module.Incremental},
+		},
+	}
 	masterLinkStatusChart = module.Chart{
 		ID:       "master_last_status",
 		Title:    "Master link status",
@@ -381,3 +466,142 @@ var (
 		},
 	}
 )
+
+var (
+	clusterCharts = module.Charts{
+		chartClusterState.Copy(),
+		chartClusterSlots.Copy(),
+		chartClusterKnownNodes.Copy(),
+	}
+
+	chartClusterState = module.Chart{
+		ID:       "cluster_state",
+		Title:    "Cluster state",
+		Units:    "state",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_state",
+		Priority: prioClusterState,
+		Dims: module.Dims{
+			{ID: "cluster_state_ok", Name: "ok"},
+			{ID: "cluster_state_fail", Name: "fail"},
+		},
+	}
+	chartClusterSlots = module.Chart{
+		ID:       "cluster_slots",
+		Title:    "Cluster slots",
+		Units:    "slots",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_slots",
+		Priority: prioClusterSlots,
+		Dims: module.Dims{
+			{ID: "cluster_slots_assigned", Name: "assigned"},
+			{ID: "cluster_slots_ok", Name: "ok"},
+			{ID: "cluster_slots_pfail", Name: "pfail"},
+			{ID: "cluster_slots_fail", Name: "fail"},
+		},
+	}
+	chartClusterKnownNodes = module.Chart{
+		ID:       "cluster_known_nodes",
+		Title:    "Cluster known nodes and masters serving slots",
+		Units:    "nodes",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_known_nodes",
+		Priority: prioClusterKnownNodes,
+		Dims: module.Dims{
+			{ID: "cluster_known_nodes", Name: "known"},
+			{ID: "cluster_size", Name: "serving_slots"},
+		},
+	}
+	chartClusterKeys = module.Chart{
+		ID:       "cluster_keys",
+		Title:    "Cluster keys (sum of the master nodes keys)",
+		Units:    "keys",
+		Fam:      "cluster",
+		Ctx:      "redis.cluster_keys",
+		Priority: prioClusterKeys,
+		Dims: module.Dims{
+			{ID: "cluster_keys", Name: "keys"},
+			{ID: "cluster_expires_keys", Name: "expires_keys"},
+		},
+	}
+)
+
+var (
+	chartClusterNodeRoleTmpl = module.Chart{
+		ID:       "cluster_node_%s_role",
+		Title:    "Cluster node role",
+		Units:    "role",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_role",
+		Priority: prioClusterNodeRole,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_role_master", Name: "master"},
+			{ID: "cluster_node_%s_role_replica", Name: "replica"},
+		},
+	}
+	chartClusterNodeKeysTmpl = module.Chart{
+		ID:       "cluster_node_%s_keys",
+		Title:    "Cluster node keys",
+		Units:    "keys",
+		Fam:      "cluster nodes",
+		Ctx:      "redis.cluster_node_keys",
+		Priority: prioClusterNodeKeys,
+		Dims: module.Dims{
+			{ID: "cluster_node_%s_keys", Name: "keys"},
+			{ID: "cluster_node_%s_expires_keys", Name: "expires_keys"},
+		},
+	}
+)
+
+func newClusterNodeChart(tmpl module.Chart, node *clusterNode) *module.Chart {
+	c := tmpl.Copy()
+	c.ID = fmt.Sprintf(c.ID, node.id)
+	c.Labels = []module.Label{
+		{Key: "node_id", Value: node.id},
+		{Key: "node_address", Value: node.addr},
+	}
+	for _, d := range c.Dims {
+		d.ID = fmt.Sprintf(d.ID, node.id)
+	}
+	return c
+}
+
+func (r *Redis) addClusterCharts() {
+	charts := clusterCharts.Copy()
+	if r.ClusterMastersDiscovery {
+		_ = charts.Add(chartClusterKeys.Copy())
+	}
+	if err := r.Charts().Add(*charts...); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *Redis) addClusterNodeCharts(node *clusterNode) {
+	if err := r.Charts().Add(newClusterNodeChart(chartClusterNodeRoleTmpl, node)); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *Redis) removeClusterNodeCharts(node *clusterNode) {
+	r.removeChart(fmt.Sprintf(chartClusterNodeRoleTmpl.ID, node.id))
+	if node.hasKeysCharts {
+		r.removeClusterNodeKeysCharts(node)
+	}
+}
+
+func (r *Redis) addClusterNodeKeysCharts(node *clusterNode) {
+	if err := r.Charts().Add(newClusterNodeChart(chartClusterNodeKeysTmpl, node)); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *Redis) removeClusterNodeKeysCharts(node *clusterNode) {
+	r.removeChart(fmt.Sprintf(chartClusterNodeKeysTmpl.ID, node.id))
+}
+
+func (r *Redis) removeChart(id string) {
+	if chart := r.Charts().Get(id); chart != nil {
+		chart.MarkRemove()
+		chart.MarkNotCreated()
+	}
+}
@@ -15,8 +15,15 @@ import (
 const precision = 1000 // float values multiplier and dimensions divisor
 
 func (r *Redis) collect() (map[string]int64, error) {
+	if r.isSentinelMode() && !r.sentinelConnected {
+		if err := r.connectSentinelMaster(); err != nil {
+			return nil, err
+		}
+	}
+
 	info, err := r.rdb.Info(context.Background(), "all").Result()
 	if err != nil {
+		r.resetSentinelMaster()
 		return nil, err
 	}
 
@@ -33,8 +40,26 @@ func (r *Redis) collect() (map[string]int64, error) {
 		return nil, fmt.Errorf("unsupported server app, want=redis, got=%s", r.server)
 	}
 
+	role := infoRole(info)
+	if r.isSentinelMode() && role != "master" {
+		r.resetSentinelMaster()
+		return nil, fmt.Errorf("sentinel: '%s' is not a master (role '%s'), will re-resolve the master address", r.sentinelMaster, role)
+	}
+	// in the Sentinel mode the master address changes are counted
+	if !r.isSentinelMode() && r.role != "" && role != r.role {
+		r.Infof("role changed: '%s' => '%s'", r.role, role)
+		r.roleChanges++
+	}
+	r.role = role
+
 	mx := make(map[string]int64)
+	mx["role_changes"] = r.roleChanges
 	r.collectInfo(mx, info)
+	if mx["cluster_enabled"] == 1 {
+		if err := r.collectCluster(mx); err != nil {
+			r.Warningf("collect cluster metrics: %v", err)
+		}
+	}
 	r.collectPingLatency(mx)
 
 	return mx, nil
@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type clusterNode struct {
+	id      string
+	addr    string
+	master  bool
+	myself  bool
+	failing bool
+
+	rdb           redisClient
+	hasKeysCharts bool
+}
+
+func (r *Redis) collectCluster(mx map[string]int64) error {
+	r.addClusterChartsOnce.Do(r.addClusterCharts)
+
+	info, err := r.rdb.ClusterInfo(context.Background()).Result()
+	if err != nil {
+		return fmt.Errorf("CLUSTER INFO: %v", err)
+	}
+	r.collectClusterInfo(mx, info)
+
+	nodes, err := r.rdb.ClusterNodes(context.Background()).Result()
+	if err != nil {
+		return fmt.Errorf("CLUSTER NODES: %v", err)
+	}
+	r.collectClusterNodes(mx, parseClusterNodes(nodes))
+
+	if r.ClusterMastersDiscovery {
+		r.collectClusterKeyspace(mx)
+	}
+
+	return nil
+}
+
+func (r *Redis) collectClusterInfo(mx map[string]int64, info string) {
+	// https://redis.io/commands/cluster-info/
+	for sc := bufio.NewScanner(strings.NewReader(info)); sc.Scan(); {
+		field, value, ok := parseProperty(strings.TrimSpace(sc.Text()))
+		if !ok {
+			continue
+		}
+		switch field {
+		case "cluster_state":
+			mx["cluster_state_ok"] = boolToInt(value == "ok")
+			mx["cluster_state_fail"] = boolToInt(value == "fail")
+		case "cluster_slots_assigned",
+			"cluster_slots_ok",
+			"cluster_slots_pfail",
+			"cluster_slots_fail",
+			"cluster_known_nodes",
+			"cluster_size":
+			collectNumericValue(mx, field, value)
+		}
+	}
+}
+
+func (r *Redis) collectClusterNodes(mx map[string]int64, nodes []*clusterNode) {
+	seen := make(map[string]bool)
+
+	for _, node := range nodes {
+		seen[node.id] = true
+
+		cur, ok := r.clusterNodes[node.id]
+		if !ok {
+			r.clusterNodes[node.id] = node
+			r.addClusterNodeCharts(node)
+			cur = node
+		} else if cur.addr != node.addr || cur.master != node.master {
+			// failover or the node is restarted with another address
+			r.Infof("cluster node '%s' topology changed: address '%s' => '%s', master %v => %v",
+				node.id, cur.addr, node.addr, cur.master, node.master)
+			r.closeClusterNodeClient(cur)
+			cur.addr, cur.master = node.addr, node.master
+		}
+		cur.myself, cur.failing = node.myself, node.failing
+
+		px := "cluster_node_" + cur.id + "_"
+		mx[px+"role_master"] = boolToInt(cur.master)
+		mx[px+"role_replica"] = boolToInt(!cur.master)
+	}
+
+	for id, node := range r.clusterNodes {
+		if !seen[id] {
+			delete(r.clusterNodes, id)
+			r.closeClusterNodeClient(node)
+			r.removeClusterNodeCharts(node)
+		}
+	}
+}
+
+func (r *Redis) collectClusterKeyspace(mx map[string]int64) {
+	mx["cluster_keys"] = 0
+	mx["cluster_expires_keys"] = 0
+
+	for _, node := range r.clusterNodes {
+		if !node.master {
+			if node.hasKeysCharts {
+				node.hasKeysCharts = false
+				r.removeClusterNodeKeysCharts(node)
+			}
+			continue
+		}
+		if node.failing {
+			continue
+		}
+
+		keys, expires, err := r.queryClusterNodeKeyspace(node)
+		if err != nil {
+			r.Warningf("cluster node '%s' (%s): %v", node.id, node.addr, err)
+			r.closeClusterNodeClient(node)
+			continue
+		}
+
+		if !node.hasKeysCharts {
+			node.hasKeysCharts = true
+			r.addClusterNodeKeysCharts(node)
+		}
+
+		px := "cluster_node_" + node.id + "_"
+		mx[px+"keys"] = keys
+		mx[px+"expires_keys"] = expires
+		mx["cluster_keys"] += keys
+		mx["cluster_expires_keys"] += expires
+	}
+}
+
+func (r *Redis) queryClusterNodeKeyspace(node *clusterNode) (keys, expires int64, err error) {
+	if node.rdb == nil {
+		if node.myself {
+			node.rdb = r.rdb
+		} else if node.rdb, err = r.newNodeClient(node.addr); err != nil {
+			return 0, 0, fmt.Errorf("create client: %v", err)
+		}
+	}
+
+	info, err := node.rdb.Info(context.Background(), "keyspace").Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for sc := bufio.NewScanner(strings.NewReader(info)); sc.Scan(); {
+		_, value, ok := parseProperty(strings.TrimSpace(sc.Text()))
+		if !ok {
+			continue
+		}
+		// db0:keys=1,expires=0,avg_ttl=0
+		match := reKeyspaceValue.FindStringSubmatch(value)
+		if match == nil {
+			continue
+		}
+		k, _ := strconv.ParseInt(match[1], 10, 64)
+		e, _ := strconv.ParseInt(match[2], 10, 64)
+		keys += k
+		expires += e
+	}
+
+	return keys, expires, nil
+}
+
+func (r *Redis) closeClusterNodeClient(node *clusterNode) {
+	if node.rdb == nil {
+		return
+	}
+	if !node.myself {
+		if err := node.rdb.Close(); err != nil {
+			r.Warningf("error on closing cluster node '%s' client: %v", node.addr, err)
+		}
+	}
+	node.rdb = nil
+}
+
+func (r *Redis) cleanupClusterNodes() {
+	for _, node := range r.clusterNodes {
+		r.closeClusterNodeClient(node)
+	}
+}
+
+// parseClusterNodes parses CLUSTER NODES output (https://redis.io/commands/cluster-nodes/):
+// <id> <ip:port@cport[,hostname]> <flags> <master> <ping-sent> <pong-recv> <config-epoch> <link-state> <slot> ...
+func parseClusterNodes(resp string) []*clusterNode {
+	var nodes []*clusterNode
+
+	for sc := bufio.NewScanner(strings.NewReader(resp)); sc.Scan(); {
+		parts := strings.Fields(sc.Text())
+		if len(parts) < 8 {
+			continue
+		}
+
+		flags := make(map[string]bool)
+		for _, f := range strings.Split(parts[2], ",") {
+			flags[f] = true
+		}
+		if flags["noaddr"] || flags["handshake"] {
+			continue
+		}
+
+		addr := parts[1]
+		if i := strings.IndexAny(addr, "@,"); i != -1 {
+			addr = addr[:i]
+		}
+
+		nodes = append(nodes, &clusterNode{
+			id:      parts[0],
+			addr:    addr,
+			master:  flags["master"],
+			myself:  flags["myself"],
+			failing: flags["fail"] || flags["fail?"],
+		})
+	}
+
+	return nodes
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+type (
+	// commandStats is a parsed Commandstats section property:
+	// cmdstat_get:calls=2,usec=29,usec_per_call=14.50,rejected_calls=0,failed_calls=0
+	// 'rejected_calls' and 'failed_calls' are reported since v6.2.
+	commandStats struct {
+		name   string
+		calls  int64
+		values map[string]string
+	}
+	// commandPercentile is a parsed Latencystats section (v7.0+) property value:
+	// latency_percentiles_usec_get:p50=1.003,p99=4.015,p99.9=8.031
+	commandPercentile struct {
+		name  string // 'p99.9' => 'p99_9'
+		value string
+	}
+)
+
+func parseCommandstatsProperty(field, value string) (commandStats, bool) {
+	if !strings.HasPrefix(field, "cmdstat_") {
+		return commandStats{}, false
+	}
+	cs := commandStats{
+		name:   field[len("cmdstat_"):],
+		values: parseKeyValues(value),
+	}
+	calls, err := strconv.ParseInt(cs.values["calls"], 10, 64)
+	if err != nil {
+		return commandStats{}, false
+	}
+	cs.calls = calls
+	return cs, cs.name != ""
+}
+
+func parseLatencystatsProperty(field, value string) (string, []commandPercentile, bool) {
+	if !strings.HasPrefix(field, "latency_percentiles_usec_") {
+		return "", nil, false
+	}
+	cmd := field[len("latency_percentiles_usec_"):]
+
+	var ps []commandPercentile
+	for _, kv := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" || v == "" {
+			continue
+		}
+		ps = append(ps, commandPercentile{name: strings.ReplaceAll(k, ".", "_"), value: v})
+	}
+	return cmd, ps, cmd != "" && len(ps) > 0
+}
+
+func parseKeyValues(value string) map[string]string {
+	kvs := make(map[string]string)
+	for _, kv := range strings.Split(value, ",") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			kvs[k] = v
+		}
+	}
+	return kvs
+}
+
+func (r *Redis) collectCommands(mx map[string]int64, cmds []commandStats, latency map[string][]commandPercentile) {
+	for _, cs := range cmds {
+		collectNumericValue(mx, "cmd_"+cs.name+"_calls", cs.values["calls"])
+		collectNumericValue(mx, "cmd_"+cs.name+"_usec", cs.values["usec"])
+		collectNumericValue(mx, "cmd_"+cs.name+"_usec_per_call", cs.values["usec_per_call"])
+
+		if !r.collectedCommands[cs.name] {
+			r.collectedCommands[cs.name] = true
+			r.addCmdToCommandsCharts(cs.name)
+		}
+	}
+
+	if r.cmdMatcher == nil && r.CommandsTopN == 0 {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for _, cs := range r.selectCommands(cmds) {
+		seen[cs.name] = true
+
+		for _, v := range []string{"rejected_calls", "failed_calls"} {
+			if s, ok := cs.values[v]; ok {
+				collectNumericValue(mx, "cmd_"+cs.name+"_"+v, s)
+			}
+		}
+		ps := latency[cs.name]
+		for _, p := range ps {
+			collectNumericValue(mx, "cmd_"+cs.name+"_latency_"+p.name, p.value)
+		}
+
+		if _, ok := r.selectedCommands[cs.name]; !ok {
+			r.selectedCommands[cs.name] = false
+			r.addCommandCharts(cs)
+		}
+		if len(ps) > 0 && !r.selectedCommands[cs.name] {
+			r.selectedCommands[cs.name] = true
+			r.addCommandLatencyChart(cs.name, ps)
+		}
+	}
+
+	for cmd := range r.selectedCommands {
+		if !seen[cmd] {
+			delete(r.selectedCommands, cmd)
+			r.removeCommandCharts(cmd)
+		}
+	}
+}
+
+// selectCommands returns the commands that match 'commands' (if set) limited to the top 'commands_top_n' by calls (if set).
+func (r *Redis) selectCommands(cmds []commandStats) []commandStats {
+	var selected []commandStats
+	for _, cs := range cmds {
+		if r.cmdMatcher == nil || r.cmdMatcher.MatchString(cs.name) {
+			selected = append(selected, cs)
+		}
+	}
+	if r.CommandsTopN == 0 || len(selected) <= r.CommandsTopN {
+		return selected
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].calls > selected[j].calls })
+	return selected[:r.CommandsTopN]
+}
+
+func (r *Redis) addCommandCharts(cs commandStats) {
+	charts := newCommandCharts(cs.name)
+
+	chart := charts.Get(commandChartID(chartCommandCallsTmpl.ID, cs.name))
+	for _, v := range []struct{ key, name string }{
+		{key: "rejected_calls", name: "rejected"},
+		{key: "failed_calls", name: "failed"},
+	} {
+		if _, ok := cs.values[v.key]; ok {
+			_ = chart.AddDim(&module.Dim{ID: "cmd_" + cs.name + "_" + v.key, Name: v.name, Algo: module.Incremental})
+		}
+	}
+
+	if err := r.Charts().Add(*charts...); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *Redis) addCommandLatencyChart(cmd string, ps []commandPercentile) {
+	chart := chartCommandLatencyTmpl.Copy()
+	chart.ID = commandChartID(chart.ID, cmd)
+	chart.Labels = []module.Label{
+		{Key: "command", Value: strings.ToUpper(cmd)},
+	}
+	for _, p := range ps {
+		_ = chart.AddDim(&module.Dim{ID: "cmd_" + cmd + "_latency_" + p.name, Name: p.name, Div: precision})
+	}
+
+	if err := r.Charts().Add(chart); err != nil {
+		r.Warning(err)
+	}
+}
+
+func (r *Redis) removeCommandCharts(cmd string) {
+	for _, tmpl := range []string{chartCommandCallsTmpl.ID, chartCommandUsecPerCallTmpl.ID, chartCommandLatencyTmpl.ID} {
+		r.removeChart(commandChartID(tmpl, cmd))
+	}
+}
+
+func (r *Redis) addCmdToCommandsCharts(cmd string) {
+	r.addDimToChart(chartCommandsCalls.ID, &module.Dim{
+		ID:   "cmd_" + cmd + "_calls",
+		Name: strings.ToUpper(cmd),
+		Algo: module.Incremental,
+	})
+	r.addDimToChart(chartCommandsUsec.ID, &module.Dim{
+		ID:   "cmd_" + cmd + "_usec",
+		Name: strings.ToUpper(cmd),
+		Algo: module.Incremental,
+	})
+	r.addDimToChart(chartCommandsUsecPerSec.ID, &module.Dim{
+		ID:   "cmd_" + cmd + "_usec_per_call",
+		Name: strings.ToUpper(cmd),
+		Div:  precision,
+	})
+}
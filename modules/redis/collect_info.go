@@ -18,6 +18,7 @@ const (
 	infoSectionClients      = "# Clients"
 	infoSectionStats        = "# Stats"
 	infoSectionCommandstats = "# Commandstats"
+	infoSectionLatencystats = "# Latencystats"
 	infoSectionCPU          = "# CPU"
 	infoSectionRepl         = "# Replication"
 	infoSectionKeyspace     = "# Keyspace"
@@ -29,6 +30,7 @@ var infoSections = map[string]struct{}{
 	infoSectionClients:      {},
 	infoSectionStats:        {},
 	infoSectionCommandstats: {},
+	infoSectionLatencystats: {},
 	infoSectionCPU:          {},
 	infoSectionRepl:         {},
 	infoSectionKeyspace:     {},
@@ -42,6 +44,9 @@ func (r *Redis) collectInfo(mx map[string]int64, info string) {
 	// All the properties are in the form of field:value terminated by \r\n.
 
 	var curSection string
+	var cmds []commandStats
+	latency := make(map[string][]commandPercentile)
+
 	sc := bufio.NewScanner(strings.NewReader(info))
 	for sc.Scan() {
 		line := strings.TrimSpace(sc.Text())
@@ -63,7 +68,13 @@ func (r *Redis) collectInfo(mx map[string]int64, info string) {
 
 		switch {
 		case curSection == infoSectionCommandstats:
-			r.collectInfoCommandstatsProperty(mx, field, value)
+			if cs, ok := parseCommandstatsProperty(field, value); ok {
+				cmds = append(cmds, cs)
+			}
+		case curSection == infoSectionLatencystats:
+			if cmd, ps, ok := parseLatencystatsProperty(field, value); ok {
+				latency[cmd] = ps
+			}
 		case curSection == infoSectionKeyspace:
 			r.collectInfoKeyspaceProperty(mx, field, value)
 		case field == "rdb_last_bgsave_status":
@@ -88,6 +99,8 @@ func (r *Redis) collectInfo(mx map[string]int64, info string) {
 		}
 	}
 
+	r.collectCommands(mx, cmds, latency)
+
 	if has(mx, "keyspace_hits", "keyspace_misses") {
 		mx["keyspace_hit_rate"] = int64(calcKeyspaceHitRate(mx) * precision)
 	}
@@ -117,30 +130,6 @@ func (r *Redis) collectInfoKeyspaceProperty(ms map[string]int64, field, value st
 	}
 }
 
-var reCommandstatsValue = regexp.MustCompile(`^calls=(\d+),usec=(\d+),usec_per_call=([\d.]+)`)
-
-func (r *Redis) collectInfoCommandstatsProperty(ms map[string]int64, field, value string) {
-	if !strings.HasPrefix(field, "cmdstat_") {
-		return
-	}
-	cmd := field[len("cmdstat_"):]
-
-	match := reCommandstatsValue.FindStringSubmatch(value)
-	if match == nil {
-		return
-	}
-
-	calls, usec, usecPerCall := match[1], match[2], match[3]
-	collectNumericValue(ms, "cmd_"+cmd+"_calls", calls)
-	collectNumericValue(ms, "cmd_"+cmd+"_usec", usec)
-	collectNumericValue(ms, "cmd_"+cmd+"_usec_per_call", usecPerCall)
-
-	if !r.collectedCommands[cmd] {
-		r.collectedCommands[cmd] = true
-		r.addCmdToCommandsCharts(cmd)
-	}
-}
-
 func collectNumericValue(ms map[string]int64, field, value string) {
 	v, err := strconv.ParseFloat(value, 64)
 	if err != nil {
@@ -180,24 +169,6 @@ func calcKeyspaceHitRate(ms map[string]int64) float64 {
 	return float64(hits) * 100 / float64(hits+misses)
 }
 
-func (r *Redis) addCmdToCommandsCharts(cmd string) {
-	r.addDimToChart(chartCommandsCalls.ID, &module.Dim{
-		ID:   "cmd_" + cmd + "_calls",
-		Name: strings.ToUpper(cmd),
-		Algo: module.Incremental,
-	})
-	r.addDimToChart(chartCommandsUsec.ID, &module.Dim{
-		ID:   "cmd_" + cmd + "_usec",
-		Name: strings.ToUpper(cmd),
-		Algo: module.Incremental,
-	})
-	r.addDimToChart(chartCommandsUsecPerSec.ID, &module.Dim{
-		ID:   "cmd_" + cmd + "_usec_per_call",
-		Name: strings.ToUpper(cmd),
-		Div:  precision,
-	})
-}
-
 func (r *Redis) addDbToKeyspaceCharts(db string) {
 	r.addDimToChart(chartKeys.ID, &module.Dim{
 		ID:   db + "_keys",
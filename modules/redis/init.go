@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 
 	"github.com/go-redis/redis/v8"
@@ -15,7 +16,10 @@ func (r *Redis) validateConfig() error {
 	if r.Address == "" {
 		return errors.New("'address' not set")
 	}
-	return nil
+	if r.CommandsTopN < 0 {
+		return errors.New("'commands_top_n' can not be negative")
+	}
+	return validateSentinelConfig(r.Sentinel)
 }
 
 func (r *Redis) initRedisClient() (*redis.Client, error) {
@@ -42,6 +46,31 @@ func (r *Redis) initRedisClient() (*redis.Client, error) {
 	return redis.NewClient(opts), nil
 }
 
+// initNodeClient creates a client for a discovered node (cluster node, Sentinel master), it uses the connection options of 'address'.
+func (r *Redis) initNodeClient(addr string) (redisClient, error) {
+	rdb, err := r.initRedisClient()
+	if err != nil {
+		return nil, err
+	}
+	opts := rdb.Options()
+	opts.Network = "tcp"
+	opts.Addr = addr
+	_ = rdb.Close()
+
+	return redis.NewClient(opts), nil
+}
+
+func (r *Redis) initCommandsMatcher() (matcher.Matcher, error) {
+	if r.Commands.Empty() {
+		return nil, nil
+	}
+	m, err := r.Commands.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return matcher.WithCache(m), nil
+}
+
 func (r *Redis) initCharts() (*module.Charts, error) {
 	return redisCharts.Copy(), nil
 }
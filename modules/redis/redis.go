@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/metrics"
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 	"github.com/netdata/go.d.plugin/pkg/web"
@@ -32,17 +33,24 @@ func New() *Redis {
 
 		addAOFChartsOnce:       &sync.Once{},
 		addReplSlaveChartsOnce: &sync.Once{},
+		addClusterChartsOnce:   &sync.Once{},
 		pingSummary:            metrics.NewSummary(),
 		collectedCommands:      make(map[string]bool),
+		selectedCommands:       make(map[string]bool),
 		collectedDbs:           make(map[string]bool),
+		clusterNodes:           make(map[string]*clusterNode),
 	}
 }
 
 type Config struct {
-	Address          string       `yaml:"address"`
-	Timeout          web.Duration `yaml:"timeout"`
-	PingSamples      int          `yaml:"ping_samples"`
-	tlscfg.TLSConfig `yaml:",inline"`
+	Address                 string             `yaml:"address"`
+	Timeout                 web.Duration       `yaml:"timeout"`
+	PingSamples             int                `yaml:"ping_samples"`
+	Commands                matcher.SimpleExpr `yaml:"commands"`
+	CommandsTopN            int                `yaml:"commands_top_n"`
+	ClusterMastersDiscovery bool               `yaml:"cluster_masters_discovery"`
+	Sentinel                SentinelConfig     `yaml:"sentinel"`
+	tlscfg.TLSConfig        `yaml:",inline"`
 }
 
 type (
@@ -59,15 +67,31 @@ type (
 
 		addAOFChartsOnce       *sync.Once
 		addReplSlaveChartsOnce *sync.Once
+		addClusterChartsOnce   *sync.Once
 
 		pingSummary metrics.Summary
 
+		cmdMatcher matcher.Matcher
+
 		collectedCommands map[string]bool
+		selectedCommands  map[string]bool // the value is whether the latency percentiles chart is added
 		collectedDbs      map[string]bool
+
+		clusterNodes  map[string]*clusterNode
+		newNodeClient func(addr string) (redisClient, error)
+
+		sentinelMaster    string
+		sentinelConnected bool
+		newSentinelClient func(addr string) sentinelClient
+
+		role        string
+		roleChanges int64
 	}
 	redisClient interface {
 		Info(ctx context.Context, section ...string) *redis.StringCmd
 		Ping(context.Context) *redis.StatusCmd
+		ClusterInfo(context.Context) *redis.StringCmd
+		ClusterNodes(context.Context) *redis.StringCmd
 		Close() error
 	}
 )
@@ -85,6 +109,15 @@ func (r *Redis) Init() bool {
 		return false
 	}
 	r.rdb = rdb
+	r.newNodeClient = r.initNodeClient
+	r.newSentinelClient = r.initSentinelClient
+
+	m, err := r.initCommandsMatcher()
+	if err != nil {
+		r.Errorf("init commands matcher: %v", err)
+		return false
+	}
+	r.cmdMatcher = m
 
 	charts, err := r.initCharts()
 	if err != nil {
@@ -117,6 +150,7 @@ func (r *Redis) Collect() map[string]int64 {
 }
 
 func (r *Redis) Cleanup() {
+	r.cleanupClusterNodes()
 	if r.rdb == nil {
 		return
 	}
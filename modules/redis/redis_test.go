@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 
 	"github.com/go-redis/redis/v8"
@@ -17,14 +18,20 @@ import (
 )
 
 var (
-	pikaInfoAll, _ = os.ReadFile("testdata/pika/info_all.txt")
-	v609InfoAll, _ = os.ReadFile("testdata/v6.0.9/info_all.txt")
+	pikaInfoAll, _      = os.ReadFile("testdata/pika/info_all.txt")
+	v609InfoAll, _      = os.ReadFile("testdata/v6.0.9/info_all.txt")
+	v704InfoCmds, _     = os.ReadFile("testdata/v7.0.4/info_commands.txt")
+	v704ClusterInfo, _  = os.ReadFile("testdata/v7.0.4/cluster_info.txt")
+	v704ClusterNodes, _ = os.ReadFile("testdata/v7.0.4/cluster_nodes.txt")
 )
 
 func Test_Testdata(t *testing.T) {
 	for name, data := range map[string][]byte{
-		"pikaInfoAll": pikaInfoAll,
-		"v609InfoAll": v609InfoAll,
+		"pikaInfoAll":      pikaInfoAll,
+		"v609InfoAll":      v609InfoAll,
+		"v704InfoCmds":     v704InfoCmds,
+		"v704ClusterInfo":  v704ClusterInfo,
+		"v704ClusterNodes": v704ClusterNodes,
 	} {
 		require.NotNilf(t, data, name)
 	}
@@ -50,6 +57,13 @@ func TestRedis_Init(t *testing.T) {
 			wantFail: true,
 			config:   Config{Address: "127.0.0.1:6379"},
 		},
+		"fails on 'sentinel.addresses' set and unset 'sentinel.master_name'": {
+			wantFail: true,
+			config: Config{
+				Address:  "redis://127.0.0.1:6379",
+				Sentinel: SentinelConfig{Addresses: []string{"127.0.0.1:26379"}},
+			},
+		},
 		"fails on invalid TLSCA": {
 			wantFail: true,
 			config: Config{
@@ -244,6 +258,7 @@ func TestRedis_Collect(t *testing.T) {
 				"repl_backlog_first_byte_offset":  0,
 				"repl_backlog_histlen":            0,
 				"repl_backlog_size":               1048576,
+				"role_changes":                    0,
 				"rss_overhead_bytes":              266240,
 				"rss_overhead_ratio":              1070,
 				"second_repl_offset":              -1,
@@ -306,6 +321,224 @@ func TestRedis_Collect(t *testing.T) {
 	}
 }
 
+func TestRedis_Collect_Commands(t *testing.T) {
+	rdb := New()
+	rdb.Commands = matcher.SimpleExpr{Excludes: []string{"= info"}}
+	rdb.CommandsTopN = 2
+	require.True(t, rdb.Init())
+	mock := &mockRedisClient{result: v704InfoCmds}
+	rdb.rdb = mock
+
+	mx := rdb.Collect()
+
+	expected := map[string]int64{
+		"cmd_get_calls":                 20,
+		"cmd_get_failed_calls":          0,
+		"cmd_get_latency_p50":           1002,
+		"cmd_get_latency_p99":           4014,
+		"cmd_get_latency_p99_9":         8031,
+		"cmd_get_rejected_calls":        2,
+		"cmd_get_usec":                  40,
+		"cmd_get_usec_per_call":         2000,
+		"cmd_set_calls":                 30,
+		"cmd_set_failed_calls":          1,
+		"cmd_set_latency_p50":           6015,
+		"cmd_set_latency_p99":           22015,
+		"cmd_set_latency_p99_9":         30079,
+		"cmd_set_rejected_calls":        0,
+		"cmd_set_usec":                  210,
+		"cmd_set_usec_per_call":         7000,
+		"cmd_client|list_calls":         1,
+		"cmd_client|list_usec":          20,
+		"cmd_client|list_usec_per_call": 20000,
+		"cmd_info_calls":                132,
+		"cmd_info_usec":                 5280,
+		"cmd_info_usec_per_call":        40000,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	assert.NotContains(t, mx, "cmd_info_latency_p50")
+	assert.NotContains(t, mx, "cmd_client|list_latency_p50")
+
+	ensureCollectedCommandChartsDimsIDs(t, rdb, mx)
+	ensureCollectedCommandsAddedToCharts(t, rdb)
+	for _, cmd := range []string{"get", "set"} {
+		for _, id := range []string{
+			"command_" + cmd + "_calls",
+			"command_" + cmd + "_usec_per_call",
+			"command_" + cmd + "_latency_percentiles",
+		} {
+			assert.Truef(t, rdb.Charts().Has(id), "chart '%s' is not in charts", id)
+		}
+		assert.Len(t, rdb.Charts().Get("command_"+cmd+"_calls").Dims, 3)
+		assert.Len(t, rdb.Charts().Get("command_"+cmd+"_latency_percentiles").Dims, 3)
+	}
+	assert.False(t, rdb.Charts().Has("command_info_calls"))
+
+	mock.result = []byte(strings.Replace(string(v704InfoCmds), "client|list:calls=1", "client|list:calls=100", 1))
+	mx = rdb.Collect()
+
+	assert.True(t, rdb.Charts().Has("command_client_list_calls"))
+	assert.True(t, rdb.Charts().Get("command_get_calls").Obsolete)
+	assert.True(t, rdb.Charts().Get("command_get_latency_percentiles").Obsolete)
+	assert.False(t, rdb.Charts().Get("command_set_calls").Obsolete)
+	assert.NotContains(t, mx, "cmd_get_latency_p50")
+	assert.EqualValues(t, 20095, mx["cmd_client|list_latency_p50"])
+	ensureCollectedCommandChartsDimsIDs(t, rdb, mx)
+}
+
+func TestRedis_Collect_Cluster(t *testing.T) {
+	const (
+		nodeMyself = "e7d1eecce10fd6bb5eb35b9f99a514335d9ba9ca"
+		nodeMaster = "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1"
+		nodeRepl1  = "292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f"
+		nodeRepl2  = "6ec23923021cf3ffec47632106199cb7f496ce01"
+	)
+
+	rdb := New()
+	rdb.ClusterMastersDiscovery = true
+	require.True(t, rdb.Init())
+	rdb.rdb = &mockRedisClient{
+		result:       []byte(strings.Replace(string(v609InfoAll), "cluster_enabled:0", "cluster_enabled:1", 1)),
+		clusterInfo:  v704ClusterInfo,
+		clusterNodes: v704ClusterNodes,
+	}
+	nodes := map[string]*mockRedisClient{
+		"127.0.0.1:30002": {result: []byte("# Keyspace\r\ndb0:keys=10,expires=2,avg_ttl=0\r\n")},
+		"127.0.0.1:30004": {result: []byte("# Keyspace\r\ndb0:keys=11,expires=3,avg_ttl=0\r\n")},
+	}
+	rdb.newNodeClient = func(addr string) (redisClient, error) {
+		if m, ok := nodes[addr]; ok {
+			return m, nil
+		}
+		return nil, errors.New("unknown node")
+	}
+
+	mx := rdb.Collect()
+
+	expected := map[string]int64{
+		"cluster_expires_keys":                         2,
+		"cluster_keys":                                 14,
+		"cluster_known_nodes":                          4,
+		"cluster_size":                                 2,
+		"cluster_slots_assigned":                       16384,
+		"cluster_slots_fail":                           0,
+		"cluster_slots_ok":                             16384,
+		"cluster_slots_pfail":                          0,
+		"cluster_state_fail":                           0,
+		"cluster_state_ok":                             1,
+		"cluster_node_" + nodeMyself + "_role_master":  1,
+		"cluster_node_" + nodeMyself + "_role_replica": 0,
+		"cluster_node_" + nodeMyself + "_keys":         4,
+		"cluster_node_" + nodeMyself + "_expires_keys": 0,
+		"cluster_node_" + nodeMaster + "_role_master":  1,
+		"cluster_node_" + nodeMaster + "_role_replica": 0,
+		"cluster_node_" + nodeMaster + "_keys":         10,
+		"cluster_node_" + nodeMaster + "_expires_keys": 2,
+		"cluster_node_" + nodeRepl1 + "_role_master":   0,
+		"cluster_node_" + nodeRepl1 + "_role_replica":  1,
+		"cluster_node_" + nodeRepl2 + "_role_master":   0,
+		"cluster_node_" + nodeRepl2 + "_role_replica":  1,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	assert.NotContains(t, mx, "cluster_node_"+nodeRepl1+"_keys")
+	ensureCollectedHasAllChartsDimsVarsIDs(t, rdb, mx)
+	for _, id := range []string{"cluster_state", "cluster_slots", "cluster_known_nodes", "cluster_keys"} {
+		assert.Truef(t, rdb.Charts().Has(id), "chart '%s' is not in charts", id)
+	}
+	assert.Len(t, *rdb.Charts(), len(redisCharts)+4+4+2)
+
+	// failover: the replica is promoted, the old master is a replica now; one of the replicas is gone
+	resp := string(v704ClusterNodes)
+	resp = strings.Replace(resp, "31002 master -", "31002 slave "+nodeRepl2, 1)
+	resp = strings.Replace(resp, "31004 slave "+nodeMaster, "31004 master -", 1)
+	resp = strings.Replace(resp, nodeRepl1+" 127.0.0.1:30003@31003 slave "+nodeMyself+" 0 1426238317239 1 connected\n", "", 1)
+	rdb.rdb.(*mockRedisClient).clusterNodes = []byte(resp)
+
+	mx = rdb.Collect()
+
+	assert.EqualValues(t, 0, mx["cluster_node_"+nodeMaster+"_role_master"])
+	assert.EqualValues(t, 1, mx["cluster_node_"+nodeRepl2+"_role_master"])
+	assert.EqualValues(t, 15, mx["cluster_keys"])
+	assert.EqualValues(t, 3, mx["cluster_expires_keys"])
+	assert.NotContains(t, mx, "cluster_node_"+nodeMaster+"_keys")
+	assert.True(t, nodes["127.0.0.1:30002"].calledClose)
+	assert.True(t, rdb.Charts().Get("cluster_node_"+nodeMaster+"_keys").Obsolete)
+	assert.True(t, rdb.Charts().Get("cluster_node_"+nodeRepl1+"_role").Obsolete)
+	assert.False(t, rdb.Charts().Get("cluster_node_"+nodeRepl2+"_keys").Obsolete)
+	ensureCollectedHasAllChartsDimsVarsIDs(t, rdb, mx)
+}
+
+func TestRedis_Collect_RoleChanges(t *testing.T) {
+	rdb := prepareRedisV609(t)
+	mock := rdb.rdb.(*mockRedisClient)
+
+	assert.EqualValues(t, 0, rdb.Collect()["role_changes"])
+
+	mock.result = []byte(strings.Replace(string(v609InfoAll), "role:master", "role:slave", 1))
+	assert.EqualValues(t, 1, rdb.Collect()["role_changes"])
+	assert.EqualValues(t, 1, rdb.Collect()["role_changes"])
+}
+
+func TestRedis_Collect_Sentinel(t *testing.T) {
+	rdb := New()
+	rdb.Sentinel = SentinelConfig{
+		Addresses:  []string{"127.0.0.1:26379", "127.0.0.1:26380"},
+		MasterName: "mymaster",
+	}
+	require.True(t, rdb.Init())
+
+	sentinels := map[string]*mockSentinelClient{
+		"127.0.0.1:26379": {errOnGet: true},
+		"127.0.0.1:26380": {masterAddr: []string{"10.0.0.1", "6379"}},
+	}
+	rdb.newSentinelClient = func(addr string) sentinelClient { return sentinels[addr] }
+	nodes := map[string]*mockRedisClient{
+		"10.0.0.1:6379": {result: v609InfoAll},
+		"10.0.0.2:6379": {result: v609InfoAll},
+	}
+	rdb.newNodeClient = func(addr string) (redisClient, error) {
+		if m, ok := nodes[addr]; ok {
+			return m, nil
+		}
+		return nil, errors.New("unknown node")
+	}
+
+	mx := rdb.Collect()
+	require.NotNil(t, mx)
+	assert.EqualValues(t, 0, mx["role_changes"])
+	assert.Equal(t, "10.0.0.1:6379", rdb.sentinelMaster)
+	assert.True(t, sentinels["127.0.0.1:26379"].calledClose)
+
+	// failover: the old master is a replica now
+	nodes["10.0.0.1:6379"].result = []byte(strings.Replace(string(v609InfoAll), "role:master", "role:slave", 1))
+	sentinels["127.0.0.1:26380"].masterAddr = []string{"10.0.0.2", "6379"}
+
+	assert.Nil(t, rdb.Collect())
+	mx = rdb.Collect()
+	require.NotNil(t, mx)
+	assert.EqualValues(t, 1, mx["role_changes"])
+	assert.Equal(t, "10.0.0.2:6379", rdb.sentinelMaster)
+	assert.True(t, nodes["10.0.0.1:6379"].calledClose)
+
+	// connection error: the master address is re-resolved, it is not changed
+	nodes["10.0.0.2:6379"].errOnInfo = true
+	assert.Nil(t, rdb.Collect())
+	nodes["10.0.0.2:6379"].errOnInfo = false
+	mx = rdb.Collect()
+	require.NotNil(t, mx)
+	assert.EqualValues(t, 1, mx["role_changes"])
+
+	// no sentinel is available
+	sentinels["127.0.0.1:26380"].errOnGet = true
+	nodes["10.0.0.2:6379"].errOnInfo = true
+	assert.Nil(t, rdb.Collect())
+	assert.Nil(t, rdb.Collect())
+}
+
 func prepareRedisV609(t *testing.T) *Redis {
 	rdb := New()
 	require.True(t, rdb.Init())
@@ -349,6 +582,18 @@ func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, rdb *Redis, ms map[str
 	}
 }
 
+func ensureCollectedCommandChartsDimsIDs(t *testing.T, rdb *Redis, ms map[string]int64) {
+	for _, chart := range *rdb.Charts() {
+		if chart.Obsolete || chart.Fam != "commands" {
+			continue
+		}
+		for _, dim := range chart.Dims {
+			_, ok := ms[dim.ID]
+			assert.Truef(t, ok, "chart '%s' dim '%s': no dim in collected", chart.ID, dim.ID)
+		}
+	}
+}
+
 func ensureCollectedCommandsAddedToCharts(t *testing.T, rdb *Redis) {
 	for _, id := range []string{
 		chartCommandsCalls.ID,
@@ -388,9 +633,11 @@ func copyTimeRelatedMetrics(dst, src map[string]int64) {
 }
 
 type mockRedisClient struct {
-	errOnInfo   bool
-	result      []byte
-	calledClose bool
+	errOnInfo    bool
+	result       []byte
+	clusterInfo  []byte
+	clusterNodes []byte
+	calledClose  bool
 }
 
 func (m *mockRedisClient) Info(_ context.Context, _ ...string) (cmd *redis.StringCmd) {
@@ -406,7 +653,33 @@ func (m *mockRedisClient) Ping(_ context.Context) (cmd *redis.StatusCmd) {
 	return redis.NewStatusResult("PONG", nil)
 }
 
+func (m *mockRedisClient) ClusterInfo(_ context.Context) *redis.StringCmd {
+	return redis.NewStringResult(string(m.clusterInfo), nil)
+}
+
+func (m *mockRedisClient) ClusterNodes(_ context.Context) *redis.StringCmd {
+	return redis.NewStringResult(string(m.clusterNodes), nil)
+}
+
 func (m *mockRedisClient) Close() error {
 	m.calledClose = true
 	return nil
 }
+
+type mockSentinelClient struct {
+	errOnGet    bool
+	masterAddr  []string
+	calledClose bool
+}
+
+func (m *mockSentinelClient) GetMasterAddrByName(_ context.Context, _ string) *redis.StringSliceCmd {
+	if m.errOnGet {
+		return redis.NewStringSliceResult(nil, errors.New("error on GetMasterAddrByName"))
+	}
+	return redis.NewStringSliceResult(m.masterAddr, nil)
+}
+
+func (m *mockSentinelClient) Close() error {
+	m.calledClose = true
+	return nil
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type (
+	SentinelConfig struct {
+		Addresses  []string `yaml:"addresses"`
+		MasterName string   `yaml:"master_name"`
+		Username   string   `yaml:"username"`
+		Password   string   `yaml:"password"`
+	}
+	sentinelClient interface {
+		GetMasterAddrByName(ctx context.Context, name string) *redis.StringSliceCmd
+		Close() error
+	}
+)
+
+func (r *Redis) isSentinelMode() bool { return len(r.Sentinel.Addresses) > 0 }
+
+// connectSentinelMaster asks Sentinel for the current master address and (re)creates the client if the address is changed.
+func (r *Redis) connectSentinelMaster() error {
+	addr, err := r.resolveSentinelMaster()
+	if err != nil {
+		return err
+	}
+
+	if r.sentinelMaster != "" && r.sentinelMaster != addr {
+		r.Infof("sentinel: master '%s' address changed: '%s' => '%s'", r.Sentinel.MasterName, r.sentinelMaster, addr)
+		r.roleChanges++
+	}
+
+	rdb, err := r.newNodeClient(addr)
+	if err != nil {
+		return fmt.Errorf("sentinel: create master '%s' client: %v", addr, err)
+	}
+	if r.rdb != nil {
+		_ = r.rdb.Close()
+	}
+	r.rdb, r.sentinelMaster, r.sentinelConnected = rdb, addr, true
+
+	return nil
+}
+
+func (r *Redis) resolveSentinelMaster() (string, error) {
+	for _, sentinelAddr := range r.Sentinel.Addresses {
+		sc := r.newSentinelClient(sentinelAddr)
+		resp, err := sc.GetMasterAddrByName(context.Background(), r.Sentinel.MasterName).Result()
+		_ = sc.Close()
+
+		if err != nil {
+			r.Warningf("sentinel '%s': get master '%s' address: %v", sentinelAddr, r.Sentinel.MasterName, err)
+			continue
+		}
+		if len(resp) != 2 {
+			r.Warningf("sentinel '%s': unexpected master '%s' address response: %v", sentinelAddr, r.Sentinel.MasterName, resp)
+			continue
+		}
+
+		return net.JoinHostPort(resp[0], resp[1]), nil
+	}
+
+	return "", fmt.Errorf("sentinel: can not resolve master '%s' address using any of %v", r.Sentinel.MasterName, r.Sentinel.Addresses)
+}
+
+// resetSentinelMaster makes the module re-resolve the master address on the next data collection.
+func (r *Redis) resetSentinelMaster() {
+	if r.isSentinelMode() {
+		r.sentinelConnected = false
+	}
+}
+
+func (r *Redis) initSentinelClient(addr string) sentinelClient {
+	// the errors are checked on Init
+	tlsConfig, _ := tlscfg.NewTLSConfig(r.TLSConfig)
+
+	return redis.NewSentinelClient(&redis.Options{
+		Addr:         addr,
+		Username:     r.Sentinel.Username,
+		Password:     r.Sentinel.Password,
+		TLSConfig:    tlsConfig,
+		PoolSize:     1,
+		DialTimeout:  r.Timeout.Duration,
+		ReadTimeout:  r.Timeout.Duration,
+		WriteTimeout: r.Timeout.Duration,
+	})
+}
+
+func validateSentinelConfig(cfg SentinelConfig) error {
+	if len(cfg.Addresses) == 0 && cfg.MasterName == "" {
+		return nil
+	}
+	if len(cfg.Addresses) == 0 {
+		return errors.New("'sentinel.addresses' not set")
+	}
+	if strings.TrimSpace(cfg.MasterName) == "" {
+		return errors.New("'sentinel.master_name' not set")
+	}
+	return nil
+}
+
+func infoRole(info string) string {
+	// role:master
+	i := strings.Index(info, "\nrole:")
+	if i == -1 {
+		return ""
+	}
+	role := info[i+len("\nrole:"):]
+	if j := strings.IndexAny(role, "\r\n"); j != -1 {
+		role = role[:j]
+	}
+	return strings.TrimSpace(role)
+}
@@ -114,3 +114,47 @@ func newChart(cfg ChartConfig) (*module.Chart, error) {
 
 	return chart, nil
 }
+
+func newTableRowChart(cfg TableConfig, index, label string) (*module.Chart, error) {
+	entry := tableEntryOID(cfg)
+	chartCfg := ChartConfig{
+		ID:       cfg.ID,
+		Title:    cfg.Title,
+		Units:    cfg.Units,
+		Family:   cfg.Family,
+		Type:     cfg.Type,
+		Priority: cfg.Priority,
+	}
+	for _, d := range cfg.Dimensions {
+		chartCfg.Dimensions = append(chartCfg.Dimensions, DimensionConfig{
+			OID:        fmt.Sprintf("%s.%d.%s", entry, d.Column, index),
+			Name:       d.Name,
+			Algorithm:  d.Algorithm,
+			Multiplier: d.Multiplier,
+			Divisor:    d.Divisor,
+		})
+	}
+
+	chart, err := newChart(chartCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	chart.ID = tableRowChartID(cfg, index)
+	chart.Title = fmt.Sprintf("%s %s", chart.Title, label)
+	chart.Labels = []module.Label{
+		{Key: "row_index", Value: index},
+		{Key: "row_label", Value: label},
+	}
+
+	return chart, nil
+}
+
+func tableRowChartID(cfg TableConfig, index string) string {
+	return fmt.Sprintf("%s_%s", cfg.ID, strings.ReplaceAll(index, ".", "_"))
+}
+
+// tableEntryOID returns the conceptual row OID (the table OID + '.1', e.g. ifXEntry for ifXTable).
+func tableEntryOID(cfg TableConfig) string {
+	return strings.TrimPrefix(cfg.OID, ".") + ".1"
+}
@@ -3,29 +3,77 @@
 package snmp
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/gosnmp/gosnmp"
 )
 
 func (s *SNMP) collect() (map[string]int64, error) {
 	collected := make(map[string]int64)
 
-	if err := s.collectOIDs(collected); err != nil {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	// the hosts are collected concurrently, a dead host doesn't block the others
+	for _, h := range s.hosts {
+		wg.Add(1)
+		go func(h *snmpHost) {
+			defer wg.Done()
+
+			mx, err := s.collectHost(h)
+			if err != nil {
+				s.Errorf("cannot get SNMP data from '%s': %v", h.name, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for k, v := range mx {
+				collected[k] = v
+			}
+		}(h)
+	}
+	wg.Wait()
+
+	return collected, nil
+}
+
+func (s *SNMP) collectHost(h *snmpHost) (map[string]int64, error) {
+	if !h.connected {
+		if err := h.client.Connect(); err != nil {
+			return nil, err
+		}
+		h.connected = true
+	}
+
+	collected := make(map[string]int64)
+
+	if err := s.collectOIDs(h, collected); err != nil {
 		return nil, err
 	}
 
+	for i := range s.Tables {
+		if err := s.collectTable(h, i, collected); err != nil {
+			s.Errorf("cannot walk SNMP table '%s' (%s): %v", s.Tables[i].ID, h.name, err)
+		}
+	}
+
 	return collected, nil
 }
 
-func (s *SNMP) collectOIDs(collected map[string]int64) error {
+// collectOIDs gets the dimensions OIDs in batches of 'max_request_size'. GETBULK (and 'max_repetitions') is not used,
+// it returns the OIDs that follow the requested ones.
+func (s *SNMP) collectOIDs(h *snmpHost, collected map[string]int64) error {
 	for i, end := 0, 0; i < len(s.oids); i += s.Options.MaxOIDs {
 		if end = i + s.Options.MaxOIDs; end > len(s.oids) {
 			end = len(s.oids)
 		}
 
 		oids := s.oids[i:end]
-		resp, err := s.snmpClient.Get(oids)
+		resp, err := h.client.Get(oids)
 		if err != nil {
-			s.Errorf("cannot get SNMP data: %v", err)
 			return err
 		}
 
@@ -34,22 +82,130 @@ func (s *SNMP) collectOIDs(collected map[string]int64) error {
 				continue
 			}
 
-			switch v := resp.Variables[i]; v.Type {
-			case gosnmp.Boolean,
-				gosnmp.Counter32,
-				gosnmp.Counter64,
-				gosnmp.Gauge32,
-				gosnmp.TimeTicks,
-				gosnmp.Uinteger32,
-				gosnmp.OpaqueFloat,
-				gosnmp.OpaqueDouble,
-				gosnmp.Integer:
-				collected[oid] = gosnmp.ToBigInt(v.Value).Int64()
-			default:
-				s.Debugf("skipping OID '%s' (unsupported type '%s')", oid, v.Type)
+			if v, ok := s.pduValue(h, oid, s.oidValues[oid], resp.Variables[i]); ok {
+				collected[h.prefix+oid] = v
+			} else {
+				s.Debugf("skipping OID '%s' (unsupported type '%s')", oid, resp.Variables[i].Type)
 			}
 		}
 	}
 
 	return nil
 }
+
+func (s *SNMP) collectTable(h *snmpHost, idx int, collected map[string]int64) error {
+	cfg := s.Tables[idx]
+	entry := tableEntryOID(cfg)
+
+	labels := make(map[string]string)
+	if cfg.LabelColumn > 0 {
+		pdus, err := s.walk(h, fmt.Sprintf("%s.%d", entry, cfg.LabelColumn))
+		if err != nil {
+			return err
+		}
+		for _, pdu := range pdus {
+			labels[tableRowIndex(pdu.Name, entry, cfg.LabelColumn)] = pduToString(pdu)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, d := range cfg.Dimensions {
+		pdus, err := s.walk(h, fmt.Sprintf("%s.%d", entry, d.Column))
+		if err != nil {
+			return err
+		}
+		for _, pdu := range pdus {
+			oid := strings.TrimPrefix(pdu.Name, ".")
+			v, ok := s.pduValue(h, oid, d.Value, pdu)
+			if !ok {
+				continue
+			}
+			collected[h.prefix+oid] = v
+			seen[tableRowIndex(pdu.Name, entry, d.Column)] = true
+		}
+	}
+
+	s.chartsMu.Lock()
+	defer s.chartsMu.Unlock()
+
+	rows := h.tableRows[idx]
+	for index := range seen {
+		if rows[index] {
+			continue
+		}
+		label, ok := labels[index]
+		if !ok || label == "" {
+			label = index
+		}
+		chart, err := newTableRowChart(cfg, index, label)
+		if err != nil {
+			s.Warningf("table '%s' row '%s': %v", cfg.ID, index, err)
+			continue
+		}
+		h.applyTo(chart)
+		if err := s.charts.Add(chart); err != nil {
+			s.Warning(err)
+			continue
+		}
+		rows[index] = true
+	}
+	for index := range rows {
+		if seen[index] {
+			continue
+		}
+		delete(rows, index)
+		for _, d := range cfg.Dimensions {
+			delete(h.counters, fmt.Sprintf("%s.%d.%s", entry, d.Column, index))
+		}
+		if chart := s.charts.Get(h.prefix + tableRowChartID(cfg, index)); chart != nil {
+			chart.MarkRemove()
+			chart.MarkNotCreated()
+		}
+	}
+
+	return nil
+}
+
+func (s *SNMP) walk(h *snmpHost, oid string) ([]gosnmp.SnmpPDU, error) {
+	// GETBULK is not supported by SNMPv1
+	if ver, _ := parseSNMPVersion(s.Options.Version); ver == gosnmp.Version1 {
+		return h.client.WalkAll(oid)
+	}
+	return h.client.BulkWalkAll(oid)
+}
+
+// tableRowIndex returns the row index part of the table cell OID ('<entry>.<column>.<index>').
+func tableRowIndex(name, entry string, column int) string {
+	return strings.TrimPrefix(strings.TrimPrefix(name, "."), fmt.Sprintf("%s.%d.", entry, column))
+}
+
+func pduToInt64(pdu gosnmp.SnmpPDU) (int64, bool) {
+	switch pdu.Type {
+	case gosnmp.Boolean,
+		gosnmp.Counter32,
+		gosnmp.Counter64,
+		gosnmp.Gauge32,
+		gosnmp.TimeTicks,
+		gosnmp.Uinteger32,
+		gosnmp.OpaqueFloat,
+		gosnmp.OpaqueDouble,
+		gosnmp.Integer:
+		return gosnmp.ToBigInt(pdu.Value).Int64(), true
+	default:
+		return 0, false
+	}
+}
+
+func pduToString(pdu gosnmp.SnmpPDU) string {
+	switch v := pdu.Value.(type) {
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	default:
+		if n, ok := pduToInt64(pdu); ok {
+			return strconv.FormatInt(n, 10)
+		}
+		return ""
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"regexp"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+func newSNMPHost(name string, client gosnmp.Handler, tables int) *snmpHost {
+	h := &snmpHost{
+		name:      name,
+		client:    client,
+		counters:  make(map[string]*counter),
+		tableRows: make([]map[string]bool, tables),
+	}
+	for i := range h.tableRows {
+		h.tableRows[i] = make(map[string]bool)
+	}
+	return h
+}
+
+// snmpHost is the SNMP agent the data is collected from, the job has one host unless 'hosts' is set.
+type snmpHost struct {
+	name string
+	// prefix is the charts and dimensions IDs prefix, it is empty if 'hosts' is not set.
+	prefix    string
+	client    gosnmp.Handler
+	connected bool
+	counters  map[string]*counter
+	// tableRows are the discovered rows per table, the key is the row index.
+	tableRows []map[string]bool
+}
+
+func (h *snmpHost) newCharts(charts *module.Charts) *module.Charts {
+	cs := charts.Copy()
+	for _, c := range *cs {
+		h.applyTo(c)
+	}
+	return cs
+}
+
+// applyTo makes the chart the host chart: the IDs are prefixed with the host, the host is the chart family.
+func (h *snmpHost) applyTo(chart *module.Chart) {
+	if h.prefix == "" {
+		return
+	}
+	chart.ID = h.prefix + chart.ID
+	if chart.Fam == "" {
+		chart.Fam = h.name
+	} else {
+		chart.Fam = h.name + " " + chart.Fam
+	}
+	chart.Labels = append(chart.Labels, module.Label{Key: "host", Value: h.name})
+	for _, d := range chart.Dims {
+		d.ID = h.prefix + d.ID
+	}
+}
+
+var reHostPrefix = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+func hostPrefix(name string) string {
+	return reHostPrefix.ReplaceAllString(name, "_") + "_"
+}
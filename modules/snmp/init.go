@@ -5,40 +5,137 @@ package snmp
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/netdata/go.d.plugin/agent/module"
+
 	"github.com/gosnmp/gosnmp"
 )
 
 var newSNMPClient = gosnmp.NewHandler
 
 func (s SNMP) validateConfig() error {
-	if len(s.ChartsInput) == 0 {
-		return errors.New("'charts' are required but not set")
+	if len(s.ChartsInput) == 0 && len(s.Tables) == 0 {
+		return errors.New("'charts' or 'tables' are required but not set")
+	}
+
+	for i, t := range s.Tables {
+		if t.ID == "" {
+			return fmt.Errorf("'tables[%d].id' is required but not set", i)
+		}
+		if t.OID == "" {
+			return fmt.Errorf("'tables[%d].oid' is required but not set", i)
+		}
+		if len(t.Dimensions) == 0 {
+			return fmt.Errorf("'tables[%d].dimensions' are required but not set", i)
+		}
+		for j, d := range t.Dimensions {
+			if d.Column <= 0 {
+				return fmt.Errorf("'tables[%d].dimensions[%d].column' is required to be a positive number", i, j)
+			}
+			if err := validateValue(d.Value); err != nil {
+				return fmt.Errorf("'tables[%d].dimensions[%d]': %v", i, j, err)
+			}
+		}
+	}
+
+	for i, c := range s.ChartsInput {
+		for j, d := range c.Dimensions {
+			if err := validateValue(d.Value); err != nil {
+				return fmt.Errorf("'charts[%d].dimensions[%d]': %v", i, j, err)
+			}
+		}
 	}
 
 	if s.Options.Version == gosnmp.Version3.String() {
-		if s.User.Name == "" {
-			return errors.New("'user.name' is required when using SNMPv3 but not set")
+		return s.validateSNMPv3User()
+	}
+
+	return nil
+}
+
+// minSNMPv3KeyLength is the minimum pass phrase length (RFC 3414, section 11.2).
+const minSNMPv3KeyLength = 8
+
+func (s SNMP) validateSNMPv3User() error {
+	if s.User.Name == "" {
+		return errors.New("'user.name' is required when using SNMPv3 but not set")
+	}
+	level, err := parseSNMPv3SecurityLevel(s.User.SecurityLevel)
+	if err != nil {
+		return err
+	}
+	authProto, err := parseSNMPv3AuthProtocol(s.User.AuthProto)
+	if err != nil {
+		return err
+	}
+	privProto, err := parseSNMPv3PrivProtocol(s.User.PrivProto)
+	if err != nil {
+		return err
+	}
+
+	if level == gosnmp.AuthNoPriv || level == gosnmp.AuthPriv {
+		if authProto == gosnmp.NoAuth {
+			return fmt.Errorf("'user.auth_proto' is required when 'user.level' is '%s' but not set", s.User.SecurityLevel)
 		}
-		if _, err := parseSNMPv3SecurityLevel(s.User.SecurityLevel); err != nil {
-			return err
+		if len(s.User.AuthKey) < minSNMPv3KeyLength {
+			return fmt.Errorf("'user.auth_key' is required to be at least %d characters long when 'user.level' is '%s'",
+				minSNMPv3KeyLength, s.User.SecurityLevel)
 		}
-		if _, err := parseSNMPv3AuthProtocol(s.User.AuthProto); err != nil {
-			return err
+	}
+	if level == gosnmp.AuthPriv {
+		if privProto == gosnmp.NoPriv {
+			return fmt.Errorf("'user.priv_proto' is required when 'user.level' is '%s' but not set", s.User.SecurityLevel)
 		}
-		if _, err := parseSNMPv3PrivProtocol(s.User.PrivProto); err != nil {
-			return err
+		if len(s.User.PrivKey) < minSNMPv3KeyLength {
+			return fmt.Errorf("'user.priv_key' is required to be at least %d characters long when 'user.level' is '%s'",
+				minSNMPv3KeyLength, s.User.SecurityLevel)
 		}
 	}
 
 	return nil
 }
 
-func (s SNMP) initSNMPClient() (gosnmp.Handler, error) {
+func (s *SNMP) initHosts() ([]*snmpHost, error) {
+	names := s.Hosts
+	if len(names) == 0 {
+		names = []string{s.Hostname}
+	}
+
+	var hosts []*snmpHost
+	var connected int
+	for _, name := range names {
+		client, err := s.initSNMPClient(name)
+		if err != nil {
+			return nil, err
+		}
+		s.Info(snmpClientConnInfo(client))
+
+		h := newSNMPHost(name, client, len(s.Tables))
+		if len(s.Hosts) > 0 {
+			h.prefix = hostPrefix(name)
+		}
+		hosts = append(hosts, h)
+
+		if err := client.Connect(); err != nil {
+			s.Warningf("SNMP client connect (%s): %v", name, err)
+			continue
+		}
+		h.connected = true
+		connected++
+	}
+
+	if connected == 0 {
+		return nil, errors.New("no host is connected")
+	}
+	return hosts, nil
+}
+
+func (s SNMP) initSNMPClient(hostname string) (gosnmp.Handler, error) {
 	client := newSNMPClient()
 
-	if client.SetTarget(s.Hostname); client.Target() == "" {
+	if client.SetTarget(hostname); client.Target() == "" {
 		s.Warningf("'hostname' not set, using the default value: '%s'", defaultHostname)
 		client.SetTarget(defaultHostname)
 	}
@@ -58,6 +155,12 @@ func (s SNMP) initSNMPClient() (gosnmp.Handler, error) {
 		s.Warningf("'options.max_request_size' is invalid, changing to the default value: '%d' => '%d'", s.Options.MaxOIDs, defaultMaxOIDs)
 		client.SetMaxOids(defaultMaxOIDs)
 	}
+	if s.Options.MaxReps < 1 {
+		s.Warningf("'options.max_repetitions' is invalid, changing to the default value: '%d' => '%d'", s.Options.MaxReps, defaultMaxReps)
+		client.SetMaxRepetitions(defaultMaxReps)
+	} else {
+		client.SetMaxRepetitions(uint32(s.Options.MaxReps))
+	}
 
 	ver, err := parseSNMPVersion(s.Options.Version)
 	if err != nil {
@@ -82,6 +185,7 @@ func (s SNMP) initSNMPClient() (gosnmp.Handler, error) {
 		client.SetVersion(gosnmp.Version3)
 		client.SetSecurityModel(gosnmp.UserSecurityModel)
 		client.SetMsgFlags(safeParseSNMPv3SecurityLevel(s.User.SecurityLevel))
+		client.SetContextName(s.User.ContextName)
 		client.SetSecurityParameters(&gosnmp.UsmSecurityParameters{
 			UserName:                 s.User.Name,
 			AuthenticationProtocol:   safeParseSNMPv3AuthProtocol(s.User.AuthProto),
@@ -96,8 +200,8 @@ func (s SNMP) initSNMPClient() (gosnmp.Handler, error) {
 	return client, nil
 }
 
-func (s SNMP) initOIDs() (oids []string) {
-	for _, c := range *s.charts {
+func (s SNMP) initOIDs(charts *module.Charts) (oids []string) {
+	for _, c := range *charts {
 		for _, d := range c.Dims {
 			oids = append(oids, d.ID)
 		}
@@ -105,6 +209,23 @@ func (s SNMP) initOIDs() (oids []string) {
 	return oids
 }
 
+func (s SNMP) initOIDValues() map[string]Value {
+	values := make(map[string]Value)
+	for _, c := range s.ChartsInput {
+		for _, d := range c.Dimensions {
+			oid := strings.TrimPrefix(d.OID, ".")
+			if len(c.IndexRange) == 2 {
+				for i := c.IndexRange[0]; i <= c.IndexRange[1]; i++ {
+					values[fmt.Sprintf("%s.%d", oid, i)] = d.Value
+				}
+			} else {
+				values[oid] = d.Value
+			}
+		}
+	}
+	return values
+}
+
 func parseSNMPVersion(version string) (gosnmp.SnmpVersion, error) {
 	switch version {
 	case "0", "1":
@@ -124,12 +245,12 @@ func safeParseSNMPv3SecurityLevel(level string) gosnmp.SnmpV3MsgFlags {
 }
 
 func parseSNMPv3SecurityLevel(level string) (gosnmp.SnmpV3MsgFlags, error) {
-	switch level {
-	case "1", "none", "noAuthNoPriv", "":
+	switch strings.ToLower(level) {
+	case "1", "none", "noauthnopriv", "":
 		return gosnmp.NoAuthNoPriv, nil
-	case "2", "authNoPriv":
+	case "2", "authnopriv":
 		return gosnmp.AuthNoPriv, nil
-	case "3", "authPriv":
+	case "3", "authpriv":
 		return gosnmp.AuthPriv, nil
 	default:
 		return gosnmp.NoAuthNoPriv, fmt.Errorf("invalid snmpv3 user security level value (%s)", level)
@@ -142,8 +263,8 @@ func safeParseSNMPv3AuthProtocol(protocol string) gosnmp.SnmpV3AuthProtocol {
 }
 
 func parseSNMPv3AuthProtocol(protocol string) (gosnmp.SnmpV3AuthProtocol, error) {
-	switch protocol {
-	case "1", "none", "noAuth", "":
+	switch normalizeSNMPv3Protocol(protocol) {
+	case "1", "none", "noauth", "":
 		return gosnmp.NoAuth, nil
 	case "2", "md5":
 		return gosnmp.MD5, nil
@@ -168,8 +289,8 @@ func safeParseSNMPv3PrivProtocol(protocol string) gosnmp.SnmpV3PrivProtocol {
 }
 
 func parseSNMPv3PrivProtocol(protocol string) (gosnmp.SnmpV3PrivProtocol, error) {
-	switch protocol {
-	case "1", "none", "noPriv", "":
+	switch normalizeSNMPv3Protocol(protocol) {
+	case "1", "none", "nopriv", "":
 		return gosnmp.NoPriv, nil
 	case "2", "des":
 		return gosnmp.DES, nil
@@ -187,3 +308,8 @@ func parseSNMPv3PrivProtocol(protocol string) (gosnmp.SnmpV3PrivProtocol, error)
 		return gosnmp.NoPriv, fmt.Errorf("invalid snmpv3 user priv protocol value (%s)", protocol)
 	}
 }
+
+// normalizeSNMPv3Protocol makes the protocol names case-insensitive and allows the 'SHA-256', 'AES-256' notation.
+func normalizeSNMPv3Protocol(protocol string) string {
+	return strings.ReplaceAll(strings.ToLower(protocol), "-", "")
+}
@@ -5,6 +5,7 @@ package snmp
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 
@@ -20,6 +21,7 @@ const (
 	defaultRetries     = 1
 	defaultTimeout     = defaultUpdateEvery
 	defaultMaxOIDs     = 60
+	defaultMaxReps     = 25
 )
 
 func init() {
@@ -35,6 +37,7 @@ func init() {
 
 func New() *SNMP {
 	return &SNMP{
+		chartsMu: &sync.Mutex{},
 		Config: Config{
 			Hostname:  defaultHostname,
 			Community: defaultCommunity,
@@ -44,6 +47,7 @@ func New() *SNMP {
 				Timeout: defaultUpdateEvery,
 				Version: defaultVersion.String(),
 				MaxOIDs: defaultMaxOIDs,
+				MaxReps: defaultMaxReps,
 			},
 		},
 	}
@@ -53,10 +57,12 @@ type (
 	Config struct {
 		UpdateEvery int           `yaml:"update_every"`
 		Hostname    string        `yaml:"hostname"`
+		Hosts       []string      `yaml:"hosts"`
 		Community   string        `yaml:"community"`
 		User        User          `yaml:"user"`
 		Options     Options       `yaml:"options"`
 		ChartsInput []ChartConfig `yaml:"charts"`
+		Tables      []TableConfig `yaml:"tables"`
 	}
 	User struct {
 		Name          string `yaml:"name"`
@@ -65,6 +71,7 @@ type (
 		AuthKey       string `yaml:"auth_key"`
 		PrivProto     string `yaml:"priv_proto"`
 		PrivKey       string `yaml:"priv_key"`
+		ContextName   string `yaml:"context_name"`
 	}
 	Options struct {
 		Port    int    `yaml:"port"`
@@ -72,6 +79,7 @@ type (
 		Timeout int    `yaml:"timeout"`
 		Version string `yaml:"version"`
 		MaxOIDs int    `yaml:"max_request_size"`
+		MaxReps int    `yaml:"max_repetitions"`
 	}
 	ChartConfig struct {
 		ID         string            `yaml:"id"`
@@ -89,6 +97,41 @@ type (
 		Algorithm  string `yaml:"algorithm"`
 		Multiplier int    `yaml:"multiplier"`
 		Divisor    int    `yaml:"divisor"`
+		Value      `yaml:",inline"`
+	}
+	// TableConfig is a conceptual table (e.g. ifXTable) that is walked every data collection,
+	// a chart is created for every discovered table row.
+	TableConfig struct {
+		ID       string `yaml:"id"`
+		Title    string `yaml:"title"`
+		Units    string `yaml:"units"`
+		Family   string `yaml:"family"`
+		Type     string `yaml:"type"`
+		Priority int    `yaml:"priority"`
+		// OID is the table OID, the columns OIDs are '<OID>.1.<column>'.
+		OID string `yaml:"oid"`
+		// LabelColumn is the column used as the row label (e.g. ifName), the row index is used if not set.
+		LabelColumn int                    `yaml:"label_column"`
+		Dimensions  []TableDimensionConfig `yaml:"dimensions"`
+	}
+	TableDimensionConfig struct {
+		Column     int    `yaml:"column"`
+		Name       string `yaml:"name"`
+		Algorithm  string `yaml:"algorithm"`
+		Multiplier int    `yaml:"multiplier"`
+		Divisor    int    `yaml:"divisor"`
+		Value      `yaml:",inline"`
+	}
+	// Value is the collected value processing, it is applied before the value is stored.
+	Value struct {
+		// Type is the value type hint (counter32, counter64, gauge), the PDU type is used if not set.
+		Type     string          `yaml:"type"`
+		Mapping  map[int64]int64 `yaml:"mapping"`
+		Multiply float64         `yaml:"multiply"`
+		Divide   float64         `yaml:"divide"`
+		Offset   float64         `yaml:"offset"`
+		// NoSuchInstance is the noSuchInstance/noSuchObject handling: 'skip' (default) or 'zero'.
+		NoSuchInstance string `yaml:"no_such_instance"`
 	}
 )
 
@@ -96,9 +139,13 @@ type SNMP struct {
 	module.Base
 	Config `yaml:",inline"`
 
-	charts     *module.Charts
-	snmpClient gosnmp.Handler
-	oids       []string
+	charts *module.Charts
+	// chartsMu protects the charts, the hosts are collected concurrently.
+	chartsMu *sync.Mutex
+	hosts    []*snmpHost
+	oids     []string
+	// oidValues are the configured value processing of the charts OIDs.
+	oidValues map[string]Value
 }
 
 func (s *SNMP) Init() bool {
@@ -108,29 +155,28 @@ func (s *SNMP) Init() bool {
 		return false
 	}
 
-	snmpClient, err := s.initSNMPClient()
+	hosts, err := s.initHosts()
 	if err != nil {
-		s.Errorf("SNMP client initialization: %v", err)
+		s.Errorf("SNMP hosts initialization: %v", err)
 		return false
 	}
-
-	s.Info(snmpClientConnInfo(snmpClient))
-
-	err = snmpClient.Connect()
-	if err != nil {
-		s.Errorf("SNMP client connect: %v", err)
-		return false
-	}
-	s.snmpClient = snmpClient
+	s.hosts = hosts
 
 	charts, err := newCharts(s.ChartsInput)
 	if err != nil {
 		s.Errorf("Population of charts failed: %v", err)
 		return false
 	}
-	s.charts = charts
+	s.charts = &module.Charts{}
+	for _, h := range s.hosts {
+		if err := s.charts.Add(*h.newCharts(charts)...); err != nil {
+			s.Errorf("Population of charts failed: %v", err)
+			return false
+		}
+	}
 
-	s.oids = s.initOIDs()
+	s.oids = s.initOIDs(charts)
+	s.oidValues = s.initOIDValues()
 
 	return true
 }
@@ -156,8 +202,10 @@ func (s *SNMP) Collect() map[string]int64 {
 }
 
 func (s *SNMP) Cleanup() {
-	if s.snmpClient != nil {
-		_ = s.snmpClient.Close()
+	for _, h := range s.hosts {
+		if h.client != nil {
+			_ = h.client.Close()
+		}
 	}
 }
 
@@ -169,6 +217,9 @@ func snmpClientConnInfo(c gosnmp.Handler) string {
 		info.WriteString(fmt.Sprintf(",community=%s", c.Community()))
 	case gosnmp.Version3:
 		info.WriteString(fmt.Sprintf(",security_level=%d,%s", c.MsgFlags(), c.SecurityParameters().Description()))
+		if c.ContextName() != "" {
+			info.WriteString(fmt.Sprintf(",context_name=%s", c.ContextName()))
+		}
 	}
 	return info.String()
 }
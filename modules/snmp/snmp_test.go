@@ -5,6 +5,7 @@ package snmp
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 
@@ -76,6 +77,111 @@ func TestSNMP_Init(t *testing.T) {
 				return snmp
 			},
 		},
+		"fail when using SNMPv3 'authNoPriv' but 'user.auth_proto' not set": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV3Config()
+				snmp.User.SecurityLevel = "authNoPriv"
+				snmp.User.AuthProto = ""
+				return snmp
+			},
+		},
+		"fail when using SNMPv3 'authNoPriv' but 'user.auth_key' is too short": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV3Config()
+				snmp.User.SecurityLevel = "authNoPriv"
+				snmp.User.AuthKey = "short"
+				return snmp
+			},
+		},
+		"fail when using SNMPv3 'authPriv' but 'user.priv_proto' not set": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV3Config()
+				snmp.User.PrivProto = ""
+				return snmp
+			},
+		},
+		"fail when using SNMPv3 'authPriv' but 'user.priv_key' not set": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV3Config()
+				snmp.User.PrivKey = ""
+				return snmp
+			},
+		},
+		"success when using SNMPv3 'noAuthNoPriv' with only 'user.name' set": {
+			wantFail: false,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV3Config()
+				snmp.User = User{Name: "name", SecurityLevel: "noAuthNoPriv"}
+				return snmp
+			},
+		},
+		"success when using SNMPv3 with 'SHA-256' and 'AES-256' protocols": {
+			wantFail: false,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV3Config()
+				snmp.User.AuthProto = "SHA-256"
+				snmp.User.PrivProto = "AES-256"
+				return snmp
+			},
+		},
+		"fail when 'tables.oid' not set": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV2Config()
+				snmp.Tables = prepareTablesConfig()
+				snmp.Tables[0].OID = ""
+				return snmp
+			},
+		},
+		"fail when 'charts.dimensions.type' is invalid": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV2Config()
+				snmp.ChartsInput[0].Dimensions[0].Type = "invalid"
+				return snmp
+			},
+		},
+		"fail when 'charts.dimensions.no_such_instance' is invalid": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV2Config()
+				snmp.ChartsInput[0].Dimensions[0].NoSuchInstance = "invalid"
+				return snmp
+			},
+		},
+		"fail when 'tables.dimensions.column' not set": {
+			wantFail: true,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV2Config()
+				snmp.Tables = prepareTablesConfig()
+				snmp.Tables[0].Dimensions[0].Column = 0
+				return snmp
+			},
+		},
+		"success when only 'tables' set": {
+			wantFail: false,
+			prepareSNMP: func() *SNMP {
+				snmp := New()
+				snmp.Config = prepareV2Config()
+				snmp.ChartsInput = nil
+				snmp.Tables = prepareTablesConfig()
+				return snmp
+			},
+		},
 		"success when using SNMPv1 with valid config": {
 			wantFail: false,
 			prepareSNMP: func() *SNMP {
@@ -115,6 +221,37 @@ func TestSNMP_Init(t *testing.T) {
 	}
 }
 
+func TestSNMP_Init_SNMPv3Client(t *testing.T) {
+	mockSNMP, cleanup := mockInit(t)
+	defer cleanup()
+
+	newSNMPClient = func() gosnmp.Handler { return mockSNMP }
+
+	snmp := New()
+	snmp.Config = prepareV3Config()
+	snmp.User.AuthProto = "SHA-256"
+	snmp.User.PrivProto = "AES-256"
+	snmp.User.ContextName = "context"
+
+	mockSNMP.EXPECT().SetVersion(gosnmp.Version3).Times(1)
+	mockSNMP.EXPECT().SetSecurityModel(gosnmp.UserSecurityModel).Times(1)
+	mockSNMP.EXPECT().SetMsgFlags(gosnmp.AuthPriv).Times(1)
+	mockSNMP.EXPECT().SetContextName("context").Times(1)
+	mockSNMP.EXPECT().SetSecurityParameters(&gosnmp.UsmSecurityParameters{
+		UserName:                 "name",
+		AuthenticationProtocol:   gosnmp.SHA256,
+		AuthenticationPassphrase: "auth_key",
+		PrivacyProtocol:          gosnmp.AES256,
+		PrivacyPassphrase:        "priv_key",
+	}).Times(1)
+	mockSNMP.EXPECT().MsgFlags().AnyTimes()
+	mockSNMP.EXPECT().SecurityParameters().Return(&gosnmp.UsmSecurityParameters{}).AnyTimes()
+	mockSNMP.EXPECT().ContextName().AnyTimes()
+	defaultMockExpects(mockSNMP)
+
+	assert.True(t, snmp.Init())
+}
+
 func TestSNMP_Check(t *testing.T) {
 	tests := map[string]struct {
 		prepareSNMP func(m *snmpmock.MockHandler) *SNMP
@@ -320,11 +457,232 @@ func TestSNMP_Collect(t *testing.T) {
 	}
 }
 
+func TestSNMP_Collect_MultipleHosts(t *testing.T) {
+	mockSNMP1, cleanup1 := mockInit(t)
+	defer cleanup1()
+	mockSNMP2, cleanup2 := mockInit(t)
+	defer cleanup2()
+
+	mocks := []*snmpmock.MockHandler{mockSNMP1, mockSNMP2}
+	newSNMPClient = func() gosnmp.Handler { m := mocks[0]; mocks = mocks[1:]; return m }
+	defaultMockExpects(mockSNMP1)
+	defaultMockExpects(mockSNMP2)
+
+	snmp := New()
+	snmp.Config = prepareV2Config()
+	snmp.Hosts = []string{"192.0.2.1", "192.0.2.2"}
+	require.True(t, snmp.Init())
+
+	require.Len(t, *snmp.Charts(), 2)
+	chart := snmp.Charts().Get("192_0_2_1_test_chart1")
+	require.NotNil(t, chart)
+	assert.Equal(t, "192.0.2.1 family", chart.Fam)
+	assert.True(t, chart.HasDim("192_0_2_1_1.3.6.1.2.1.2.2.1.10"))
+	require.NotNil(t, snmp.Charts().Get("192_0_2_2_test_chart1"))
+
+	mockSNMP1.EXPECT().Get(gomock.Any()).Return(&gosnmp.SnmpPacket{
+		Variables: []gosnmp.SnmpPDU{
+			{Value: 10, Type: gosnmp.Gauge32},
+			{Value: 20, Type: gosnmp.Gauge32},
+		},
+	}, nil).Times(1)
+	// the dead host doesn't fail the job
+	mockSNMP2.EXPECT().Get(gomock.Any()).Return(nil, errors.New("mock Get() error")).Times(1)
+
+	expected := map[string]int64{
+		"192_0_2_1_1.3.6.1.2.1.2.2.1.10": 10,
+		"192_0_2_1_1.3.6.1.2.1.2.2.1.16": 20,
+	}
+	assert.Equal(t, expected, snmp.Collect())
+}
+
+func TestSNMP_Collect_CountersWrap(t *testing.T) {
+	tests := map[string]struct {
+		typ     string
+		pduType gosnmp.Asn1BER
+		values  []uint64
+		want    []int64
+	}{
+		"Counter32 wrap": {
+			pduType: gosnmp.Counter32,
+			values:  []uint64{math.MaxUint32 - 100, 200, 1000},
+			want:    []int64{math.MaxUint32 - 100, math.MaxUint32 + 201, math.MaxUint32 + 1001},
+		},
+		"Counter64 reset": {
+			pduType: gosnmp.Counter64,
+			values:  []uint64{5000, 100, 300},
+			want:    []int64{5000, 5100, 5300},
+		},
+		"Gauge32 with 'counter32' type": {
+			typ:     valueTypeCounter32,
+			pduType: gosnmp.Gauge32,
+			values:  []uint64{math.MaxUint32, 9},
+			want:    []int64{math.MaxUint32, math.MaxUint32 + 10},
+		},
+		"Counter32 with 'gauge' type": {
+			typ:     valueTypeGauge,
+			pduType: gosnmp.Counter32,
+			values:  []uint64{math.MaxUint32, 9},
+			want:    []int64{math.MaxUint32, 9},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockSNMP, cleanup := mockInit(t)
+			defer cleanup()
+
+			newSNMPClient = func() gosnmp.Handler { return mockSNMP }
+			defaultMockExpects(mockSNMP)
+
+			snmp := New()
+			snmp.Config = prepareV2Config()
+			snmp.ChartsInput[0].Dimensions = snmp.ChartsInput[0].Dimensions[:1]
+			snmp.ChartsInput[0].Dimensions[0].Type = test.typ
+			require.True(t, snmp.Init())
+
+			oid := snmp.ChartsInput[0].Dimensions[0].OID
+			for i, v := range test.values {
+				mockSNMP.EXPECT().Get(gomock.Any()).Return(&gosnmp.SnmpPacket{
+					Variables: []gosnmp.SnmpPDU{{Value: v, Type: test.pduType}},
+				}, nil).Times(1)
+
+				assert.Equalf(t, map[string]int64{oid: test.want[i]}, snmp.Collect(), "collection %d", i+1)
+			}
+		})
+	}
+}
+
+func TestSNMP_Collect_ValueProcessing(t *testing.T) {
+	tests := map[string]struct {
+		value Value
+		pdu   gosnmp.SnmpPDU
+		want  map[string]int64
+	}{
+		"mapping": {
+			value: Value{Mapping: map[int64]int64{1: 1, 2: 0}},
+			pdu:   gosnmp.SnmpPDU{Value: 2, Type: gosnmp.Integer},
+			want:  map[string]int64{"1.3.6.1.2.1.2.2.1.10": 0},
+		},
+		"not mapped value": {
+			value: Value{Mapping: map[int64]int64{1: 1, 2: 0}},
+			pdu:   gosnmp.SnmpPDU{Value: 3, Type: gosnmp.Integer},
+			want:  map[string]int64{"1.3.6.1.2.1.2.2.1.10": 3},
+		},
+		"divide": {
+			value: Value{Divide: 10},
+			pdu:   gosnmp.SnmpPDU{Value: 235, Type: gosnmp.Gauge32},
+			want:  map[string]int64{"1.3.6.1.2.1.2.2.1.10": 24},
+		},
+		"multiply and offset (inversion)": {
+			value: Value{Multiply: -1, Offset: 100},
+			pdu:   gosnmp.SnmpPDU{Value: 30, Type: gosnmp.Gauge32},
+			want:  map[string]int64{"1.3.6.1.2.1.2.2.1.10": 70},
+		},
+		"noSuchInstance skip": {
+			value: Value{},
+			pdu:   gosnmp.SnmpPDU{Type: gosnmp.NoSuchInstance},
+			want:  nil,
+		},
+		"noSuchInstance zero": {
+			value: Value{NoSuchInstance: noSuchInstanceZero},
+			pdu:   gosnmp.SnmpPDU{Type: gosnmp.NoSuchInstance},
+			want:  map[string]int64{"1.3.6.1.2.1.2.2.1.10": 0},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockSNMP, cleanup := mockInit(t)
+			defer cleanup()
+
+			newSNMPClient = func() gosnmp.Handler { return mockSNMP }
+			defaultMockExpects(mockSNMP)
+
+			snmp := New()
+			snmp.Config = prepareV2Config()
+			snmp.ChartsInput[0].Dimensions = snmp.ChartsInput[0].Dimensions[:1]
+			snmp.ChartsInput[0].Dimensions[0].Value = test.value
+			require.True(t, snmp.Init())
+
+			mockSNMP.EXPECT().Get(gomock.Any()).Return(&gosnmp.SnmpPacket{
+				Variables: []gosnmp.SnmpPDU{test.pdu},
+			}, nil).Times(1)
+
+			assert.Equal(t, test.want, snmp.Collect())
+		})
+	}
+}
+
+func TestSNMP_Collect_Tables(t *testing.T) {
+	mockSNMP, cleanup := mockInit(t)
+	defer cleanup()
+
+	newSNMPClient = func() gosnmp.Handler { return mockSNMP }
+	defaultMockExpects(mockSNMP)
+
+	snmp := New()
+	snmp.Config = prepareV2Config()
+	snmp.ChartsInput = nil
+	snmp.Tables = prepareTablesConfig()
+	require.True(t, snmp.Init())
+
+	ifName := func(idx int, name string) gosnmp.SnmpPDU {
+		return gosnmp.SnmpPDU{Name: fmt.Sprintf(".1.3.6.1.2.1.31.1.1.1.1.%d", idx), Type: gosnmp.OctetString, Value: []byte(name)}
+	}
+	ifHCInOctets := func(idx int, v uint64) gosnmp.SnmpPDU {
+		return gosnmp.SnmpPDU{Name: fmt.Sprintf(".1.3.6.1.2.1.31.1.1.1.6.%d", idx), Type: gosnmp.Counter64, Value: v}
+	}
+	ifHCOutOctets := func(idx int, v uint64) gosnmp.SnmpPDU {
+		return gosnmp.SnmpPDU{Name: fmt.Sprintf(".1.3.6.1.2.1.31.1.1.1.10.%d", idx), Type: gosnmp.Counter64, Value: v}
+	}
+
+	// both rows
+	mockSNMP.EXPECT().BulkWalkAll("1.3.6.1.2.1.31.1.1.1.1").
+		Return([]gosnmp.SnmpPDU{ifName(1, "eth0"), ifName(2, "eth1")}, nil).Times(1)
+	mockSNMP.EXPECT().BulkWalkAll("1.3.6.1.2.1.31.1.1.1.6").
+		Return([]gosnmp.SnmpPDU{ifHCInOctets(1, 10), ifHCInOctets(2, 20)}, nil).Times(1)
+	mockSNMP.EXPECT().BulkWalkAll("1.3.6.1.2.1.31.1.1.1.10").
+		Return([]gosnmp.SnmpPDU{ifHCOutOctets(1, 30), ifHCOutOctets(2, 40)}, nil).Times(1)
+
+	expected := map[string]int64{
+		"1.3.6.1.2.1.31.1.1.1.6.1":  10,
+		"1.3.6.1.2.1.31.1.1.1.6.2":  20,
+		"1.3.6.1.2.1.31.1.1.1.10.1": 30,
+		"1.3.6.1.2.1.31.1.1.1.10.2": 40,
+	}
+	assert.Equal(t, expected, snmp.Collect())
+	require.Len(t, *snmp.Charts(), 2)
+
+	chart := snmp.Charts().Get("if_traffic_2")
+	require.NotNil(t, chart)
+	assert.Equal(t, "Interface traffic eth1", chart.Title)
+	assert.Equal(t, "snmp.if_traffic", chart.Ctx)
+	assert.True(t, chart.HasDim("1.3.6.1.2.1.31.1.1.1.6.2"))
+	assert.True(t, chart.HasDim("1.3.6.1.2.1.31.1.1.1.10.2"))
+
+	// the second row is gone
+	mockSNMP.EXPECT().BulkWalkAll("1.3.6.1.2.1.31.1.1.1.1").
+		Return([]gosnmp.SnmpPDU{ifName(1, "eth0")}, nil).Times(1)
+	mockSNMP.EXPECT().BulkWalkAll("1.3.6.1.2.1.31.1.1.1.6").
+		Return([]gosnmp.SnmpPDU{ifHCInOctets(1, 11)}, nil).Times(1)
+	mockSNMP.EXPECT().BulkWalkAll("1.3.6.1.2.1.31.1.1.1.10").
+		Return([]gosnmp.SnmpPDU{ifHCOutOctets(1, 31)}, nil).Times(1)
+
+	expected = map[string]int64{
+		"1.3.6.1.2.1.31.1.1.1.6.1":  11,
+		"1.3.6.1.2.1.31.1.1.1.10.1": 31,
+	}
+	assert.Equal(t, expected, snmp.Collect())
+	assert.False(t, snmp.Charts().Get("if_traffic_1").Obsolete)
+	assert.True(t, snmp.Charts().Get("if_traffic_2").Obsolete)
+}
+
 func TestSNMP_Cleanup(t *testing.T) {
 	tests := map[string]struct {
 		prepareSNMP func(t *testing.T, m *snmpmock.MockHandler) *SNMP
 	}{
-		"cleanup call if snmpClient initialized": {
+		"cleanup call if snmp client initialized": {
 			prepareSNMP: func(t *testing.T, m *snmpmock.MockHandler) *SNMP {
 				snmp := New()
 				snmp.Config = prepareV2Config()
@@ -335,12 +693,12 @@ func TestSNMP_Cleanup(t *testing.T) {
 				return snmp
 			},
 		},
-		"cleanup call does not panic if snmpClient not initialized": {
+		"cleanup call does not panic if snmp client not initialized": {
 			prepareSNMP: func(t *testing.T, m *snmpmock.MockHandler) *SNMP {
 				snmp := New()
 				snmp.Config = prepareV2Config()
 				require.True(t, snmp.Init())
-				snmp.snmpClient = nil
+				snmp.hosts[0].client = nil
 
 				return snmp
 			},
@@ -429,6 +787,8 @@ func defaultMockExpects(m *snmpmock.MockHandler) {
 	m.EXPECT().SetSecurityModel(gomock.Any()).AnyTimes()
 	m.EXPECT().SetMsgFlags(gomock.Any()).AnyTimes()
 	m.EXPECT().SetSecurityParameters(gomock.Any()).AnyTimes()
+	m.EXPECT().SetContextName(gomock.Any()).AnyTimes()
+	m.EXPECT().SetMaxRepetitions(gomock.Any()).AnyTimes()
 	m.EXPECT().Connect().Return(nil).AnyTimes()
 }
 
@@ -457,6 +817,23 @@ func prepareV3Config() Config {
 	return cfg
 }
 
+func prepareTablesConfig() []TableConfig {
+	return []TableConfig{
+		{
+			ID:          "if_traffic",
+			Title:       "Interface traffic",
+			Units:       "kilobits/s",
+			Type:        module.Area.String(),
+			OID:         "1.3.6.1.2.1.31.1.1",
+			LabelColumn: 1,
+			Dimensions: []TableDimensionConfig{
+				{Column: 6, Name: "in", Algorithm: module.Incremental.String(), Multiplier: 8, Divisor: 1000},
+				{Column: 10, Name: "out", Algorithm: module.Incremental.String(), Multiplier: -8, Divisor: 1000},
+			},
+		},
+	}
+}
+
 func prepareV2Config() Config {
 	cfg := prepareV1Config()
 	cfg.Options.Version = gosnmp.Version2c.String()
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package snmp
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+const (
+	valueTypeCounter32 = "counter32"
+	valueTypeCounter64 = "counter64"
+	valueTypeGauge     = "gauge"
+)
+
+const (
+	noSuchInstanceSkip = "skip"
+	noSuchInstanceZero = "zero"
+)
+
+func validateValue(v Value) error {
+	switch v.Type {
+	case "", valueTypeCounter32, valueTypeCounter64, valueTypeGauge:
+	default:
+		return fmt.Errorf("invalid 'type' value (%s)", v.Type)
+	}
+	switch v.NoSuchInstance {
+	case "", noSuchInstanceSkip, noSuchInstanceZero:
+	default:
+		return fmt.Errorf("invalid 'no_such_instance' value (%s)", v.NoSuchInstance)
+	}
+	return nil
+}
+
+// counter is a monotonic counter built from the Counter32/Counter64 values, it keeps growing after the SNMP counter wraps.
+type counter struct {
+	prev  uint64
+	value int64
+}
+
+// pduValue converts the PDU value and applies the value processing (mapping, multiply, divide, offset).
+// The counters wrap is handled according to the value type hint (the PDU type if the hint is not set).
+func (s *SNMP) pduValue(h *snmpHost, oid string, val Value, pdu gosnmp.SnmpPDU) (int64, bool) {
+	v, ok := s.rawPDUValue(h, oid, val.Type, pdu)
+	if !ok {
+		if val.NoSuchInstance == noSuchInstanceZero && (pdu.Type == gosnmp.NoSuchInstance || pdu.Type == gosnmp.NoSuchObject) {
+			return 0, true
+		}
+		return 0, false
+	}
+	return val.apply(v), true
+}
+
+func (v Value) apply(value int64) int64 {
+	if m, ok := v.Mapping[value]; ok {
+		value = m
+	}
+	if v.Multiply == 0 && v.Divide == 0 && v.Offset == 0 {
+		return value
+	}
+
+	f := float64(value)
+	if v.Multiply != 0 {
+		f *= v.Multiply
+	}
+	if v.Divide != 0 {
+		f /= v.Divide
+	}
+	return int64(math.Round(f + v.Offset))
+}
+
+func (s *SNMP) rawPDUValue(h *snmpHost, oid, typ string, pdu gosnmp.SnmpPDU) (int64, bool) {
+	if _, ok := pduToInt64(pdu); !ok {
+		return 0, false
+	}
+
+	if typ == "" {
+		switch pdu.Type {
+		case gosnmp.Counter32:
+			typ = valueTypeCounter32
+		case gosnmp.Counter64:
+			typ = valueTypeCounter64
+		}
+	}
+
+	switch typ {
+	case valueTypeCounter32:
+		return h.counterValue(oid, uint64(uint32(gosnmp.ToBigInt(pdu.Value).Uint64())), true), true
+	case valueTypeCounter64:
+		return h.counterValue(oid, gosnmp.ToBigInt(pdu.Value).Uint64(), false), true
+	default:
+		return pduToInt64(pdu)
+	}
+}
+
+func (h *snmpHost) counterValue(oid string, v uint64, is32 bool) int64 {
+	c, ok := h.counters[oid]
+	if !ok {
+		c = &counter{prev: v, value: int64(v & math.MaxInt64)}
+		h.counters[oid] = c
+		return c.value
+	}
+
+	var delta uint64
+	switch {
+	case is32:
+		// Counter32 wraps within minutes on 10G interfaces, the delta is calculated modulo 2^32
+		delta = uint64(uint32(v) - uint32(c.prev))
+	case v >= c.prev:
+		delta = v - c.prev
+	default:
+		// Counter64 doesn't wrap in practice, the decrease is a counter reset (e.g. the device reboot)
+		delta = v
+	}
+	c.prev = v
+	c.value = int64(uint64(c.value)+delta) & math.MaxInt64
+
+	return c.value
+}
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/netdata/go.d.plugin/pkg/web"
+
 	"github.com/mattn/go-xmlrpc"
 )
 
@@ -26,7 +28,7 @@ func newSupervisorRPCClient(serverURL *url.URL, httpClient *http.Client) (superv
 		return &supervisorRPCClient{client: c}, nil
 	case "unix":
 		c := xmlrpc.NewClient("http://unix/RPC2")
-		t, ok := httpClient.Transport.(*http.Transport)
+		t, ok := web.BaseTransport(httpClient)
 		if !ok {
 			return nil, errors.New("unexpected HTTP client transport")
 		}
@@ -21,10 +21,58 @@ type (
 )
 
 const (
-	hostPrio = module.Priority
-	vmPrio   = hostPrio + 200
+	hostPrio    = module.Priority
+	vmPrio      = hostPrio + 200
+	summaryPrio = vmPrio + 200
+	clusterPrio = summaryPrio + 100
+	poolPrio    = clusterPrio + 100
 )
 
+var summaryCharts = Charts{
+	{
+		ID:       "hosts_overall_status",
+		Title:    "Hosts By Overall Status",
+		Units:    "hosts",
+		Fam:      "overall status",
+		Ctx:      "vsphere.hosts_overall_status",
+		Type:     module.Stacked,
+		Priority: summaryPrio,
+		Dims: Dims{
+			{ID: "hosts_overall_status_green", Name: "green"},
+			{ID: "hosts_overall_status_yellow", Name: "yellow"},
+			{ID: "hosts_overall_status_red", Name: "red"},
+			{ID: "hosts_overall_status_gray", Name: "gray"},
+		},
+	},
+	{
+		ID:       "vms_overall_status",
+		Title:    "VMs By Overall Status",
+		Units:    "vms",
+		Fam:      "overall status",
+		Ctx:      "vsphere.vms_overall_status",
+		Type:     module.Stacked,
+		Priority: summaryPrio + 1,
+		Dims: Dims{
+			{ID: "vms_overall_status_green", Name: "green"},
+			{ID: "vms_overall_status_yellow", Name: "yellow"},
+			{ID: "vms_overall_status_red", Name: "red"},
+			{ID: "vms_overall_status_gray", Name: "gray"},
+		},
+	},
+	{
+		ID:       "vms_snapshots",
+		Title:    "VMs With Snapshots",
+		Units:    "vms",
+		Fam:      "snapshots",
+		Ctx:      "vsphere.vms_snapshots",
+		Priority: summaryPrio + 2,
+		Dims: Dims{
+			{ID: "vms_with_snapshots", Name: "with_snapshots"},
+			{ID: "vms_with_snapshots_older_than_3d", Name: "older_than_3d"},
+		},
+	},
+}
+
 var (
 	vmCharts = func() Charts {
 		cs := Charts{}
@@ -33,6 +81,7 @@ var (
 		panicIf(cs.Add(vmNetCharts...))
 		panicIf(cs.Add(vmDiskCharts...))
 		panicIf(cs.Add(vmSystemCharts...))
+		panicIf(cs.Add(vmSnapshotCharts...))
 		return cs
 	}()
 
@@ -170,6 +219,18 @@ var (
 				{ID: "%s_overall.status", Name: "status"},
 			},
 		},
+		{
+			ID:    "%s_triggered_alarms",
+			Title: "Triggered Alarms",
+			Units: "alarms",
+			Fam:   "vm %s (%s)",
+			Ctx:   "vsphere.vm_triggered_alarms",
+			Type:  module.Stacked,
+			Dims: Dims{
+				{ID: "%s_triggered_alarms.yellow", Name: "yellow"},
+				{ID: "%s_triggered_alarms.red", Name: "red"},
+			},
+		},
 		{
 			ID:    "%s_system_uptime",
 			Title: "System Uptime",
@@ -181,6 +242,28 @@ var (
 			},
 		},
 	}
+	vmSnapshotCharts = Charts{
+		{
+			ID:    "%s_snapshots",
+			Title: "Snapshots",
+			Units: "snapshots",
+			Fam:   "vm %s (%s)",
+			Ctx:   "vsphere.vm_snapshots",
+			Dims: Dims{
+				{ID: "%s_snapshots.count", Name: "snapshots"},
+			},
+		},
+		{
+			ID:    "%s_snapshots_oldest_age",
+			Title: "Oldest Snapshot Age",
+			Units: "hours",
+			Fam:   "vm %s (%s)",
+			Ctx:   "vsphere.vm_snapshots_oldest_age",
+			Dims: Dims{
+				{ID: "%s_snapshots.oldest_age", Name: "age"},
+			},
+		},
+	}
 )
 
 var (
@@ -328,6 +411,18 @@ var (
 				{ID: "%s_overall.status", Name: "status"},
 			},
 		},
+		{
+			ID:    "%s_triggered_alarms",
+			Title: "Triggered Alarms",
+			Units: "alarms",
+			Fam:   "host %s",
+			Ctx:   "vsphere.host_triggered_alarms",
+			Type:  module.Stacked,
+			Dims: Dims{
+				{ID: "%s_triggered_alarms.yellow", Name: "yellow"},
+				{ID: "%s_triggered_alarms.red", Name: "red"},
+			},
+		},
 		{
 			ID:    "%s_system_uptime",
 			Title: "System Uptime",
@@ -341,6 +436,134 @@ var (
 	}
 )
 
+var (
+	clusterCharts = Charts{
+		{
+			ID:    "%s_cpu_capacity",
+			Title: "CPU Capacity",
+			Units: "MHz",
+			Fam:   "cluster %s",
+			Ctx:   "vsphere.cluster_cpu_capacity",
+			Dims: Dims{
+				{ID: "%s_cpu.total", Name: "total"},
+				{ID: "%s_cpu.effective", Name: "effective"},
+				{ID: "%s_cpu.usagemhz.average", Name: "consumed"},
+			},
+		},
+		{
+			ID:    "%s_mem_capacity",
+			Title: "Memory Capacity",
+			Units: "KiB",
+			Fam:   "cluster %s",
+			Ctx:   "vsphere.cluster_mem_capacity",
+			Dims: Dims{
+				{ID: "%s_mem.total", Name: "total"},
+				{ID: "%s_mem.effective", Name: "effective"},
+				{ID: "%s_mem.consumed.average", Name: "consumed"},
+			},
+		},
+		{
+			ID:    "%s_failover_level",
+			Title: "HA Failover Level",
+			Units: "hosts",
+			Fam:   "cluster %s",
+			Ctx:   "vsphere.cluster_failover_level",
+			Dims: Dims{
+				{ID: "%s_failover_level.current", Name: "current"},
+				{ID: "%s_failover_level.configured", Name: "configured"},
+			},
+		},
+	}
+	resourcePoolCharts = Charts{
+		{
+			ID:    "%s_cpu_usage",
+			Title: "CPU Usage",
+			Units: "MHz",
+			Fam:   "resource pool %s (%s)",
+			Ctx:   "vsphere.resource_pool_cpu_usage",
+			Dims: Dims{
+				{ID: "%s_cpu.usagemhz.average", Name: "used"},
+			},
+		},
+		{
+			ID:    "%s_mem_usage",
+			Title: "Memory Usage",
+			Units: "KiB",
+			Fam:   "resource pool %s (%s)",
+			Ctx:   "vsphere.resource_pool_mem_usage",
+			Dims: Dims{
+				{ID: "%s_mem.consumed.average", Name: "consumed"},
+			},
+		},
+	}
+)
+
+func (vs *VSphere) updateClustersCharts() {
+	for _, c := range vs.resources.Clusters {
+		if c.Summary == nil || vs.chartedClusters[c.ID] {
+			continue
+		}
+		vs.chartedClusters[c.ID] = true
+
+		cs := newClusterCharts(c)
+		if err := vs.charts.Add(*cs...); err != nil {
+			vs.Error(err)
+		}
+	}
+	for id := range vs.chartedClusters {
+		if c := vs.resources.Clusters.Get(id); c == nil || c.Summary == nil {
+			delete(vs.chartedClusters, id)
+			vs.removeFromCharts(id + "_")
+		}
+	}
+}
+
+func newClusterCharts(cluster *rs.Cluster) *Charts {
+	cs := clusterCharts.Copy()
+	for i, c := range *cs {
+		c.Priority = clusterPrio + i
+		c.ID = fmt.Sprintf(c.ID, cluster.ID)
+		c.Fam = fmt.Sprintf(c.Fam, cluster.Name)
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, cluster.ID)
+		}
+	}
+	return cs
+}
+
+func (vs *VSphere) updateResourcePoolsCharts() {
+	for _, p := range vs.resources.ResourcePools {
+		if vs.chartedPools[p.ID] {
+			continue
+		}
+		vs.chartedPools[p.ID] = true
+
+		cs := newResourcePoolCharts(p)
+		if err := vs.charts.Add(*cs...); err != nil {
+			vs.Error(err)
+		}
+	}
+	for id := range vs.chartedPools {
+		if vs.resources.ResourcePools.Get(id) == nil {
+			delete(vs.chartedPools, id)
+			vs.removeFromCharts(id + "_")
+		}
+	}
+}
+
+func newResourcePoolCharts(pool *rs.ResourcePool) *Charts {
+	cs := resourcePoolCharts.Copy()
+	for i, c := range *cs {
+		c.Priority = poolPrio + i
+		c.ID = fmt.Sprintf(c.ID, pool.ID)
+		c.Fam = fmt.Sprintf(c.Fam, pool.Name, pool.Hier.Cluster.Name)
+		for _, d := range c.Dims {
+			d.ID = fmt.Sprintf(d.ID, pool.ID)
+		}
+	}
+	return cs
+}
+
 func (vs *VSphere) updateHostsCharts(collected map[string]string) {
 	for id, userID := range collected {
 		if vs.charted[userID] {
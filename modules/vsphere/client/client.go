@@ -26,6 +26,7 @@ const (
 	computeResource = "ComputeResource"
 	hostSystem      = "HostSystem"
 	virtualMachine  = "VirtualMachine"
+	resourcePool    = "ResourcePool"
 
 	maxIdleConnections = 32
 )
@@ -175,6 +176,11 @@ func (c *Client) VirtualMachines(pathSet ...string) (vms []mo.VirtualMachine, er
 	return
 }
 
+func (c *Client) ResourcePools(pathSet ...string) (pools []mo.ResourcePool, err error) {
+	err = c.root.Retrieve(context.Background(), []string{resourcePool}, pathSet, &pools)
+	return
+}
+
 func (c *Client) CounterInfoByName() (map[string]*types.PerfCounterInfo, error) {
 	return c.perf.CounterInfoByName(context.Background())
 }
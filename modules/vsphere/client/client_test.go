@@ -122,6 +122,15 @@ func TestClient_VirtualMachines(t *testing.T) {
 	assert.NotEmpty(t, vms)
 }
 
+func TestClient_ResourcePools(t *testing.T) {
+	client, teardown := prepareClient(t)
+	defer teardown()
+
+	pools, err := client.ResourcePools()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, pools)
+}
+
 func TestClient_PerformanceMetrics(t *testing.T) {
 	client, teardown := prepareClient(t)
 	defer teardown()
@@ -22,6 +22,10 @@ func (vs *VSphere) collect() (map[string]int64, error) {
 	t := time.Now()
 	mx := make(map[string]int64)
 
+	vs.collectOverallStatus(mx)
+	vs.collectClusters(mx)
+	vs.collectResourcePools(mx)
+
 	err := vs.collectHosts(mx)
 	if err != nil {
 		return mx, err
@@ -60,6 +64,9 @@ func (vs *VSphere) collectHostsMetrics(mx map[string]int64, metrics []performanc
 			continue
 		}
 		writeHostMetrics(mx, host, m.Value)
+		if cluster := vs.resources.Clusters.Get(host.ParentID); cluster != nil && cluster.Summary != nil {
+			sumAggregatedMetrics(mx, cluster.ID, m.Value)
+		}
 		hosts[host.ID] = vs.hostID(host)
 	}
 	return hosts
@@ -75,6 +82,7 @@ func writeHostMetrics(mx map[string]int64, host *rs.Host, metrics []performance.
 	}
 	key := fmt.Sprintf("%s_overall.status", host.ID)
 	mx[key] = overallStatusToInt(host.OverallStatus)
+	writeTriggeredAlarms(mx, host.ID, host.Alarms)
 }
 
 func (vs *VSphere) updateDiscoveredHosts(collected map[string]string) {
@@ -103,6 +111,8 @@ func (vs VSphere) hostID(host *rs.Host) (id string) {
 }
 
 func (vs *VSphere) collectVMs(mx map[string]int64) error {
+	collectVMsSnapshots(mx, vs.resources.VMs)
+
 	if len(vs.resources.VMs) == 0 {
 		return nil
 	}
@@ -113,6 +123,9 @@ func (vs *VSphere) collectVMs(mx map[string]int64) error {
 	}
 
 	vms := vs.collectVMsMetrics(mx, ems)
+	if !vs.VMCharts {
+		return nil
+	}
 	vs.updateDiscoveredVMs(vms)
 	vs.updateVMsCharts(vms)
 	return nil
@@ -125,6 +138,14 @@ func (vs *VSphere) collectVMsMetrics(mx map[string]int64, ems []performance.Enti
 		if vm == nil {
 			continue
 		}
+		// nested pools usage includes child pools usage
+		pool := vs.resources.ResourcePools.Get(vm.ResourcePoolID)
+		for ; pool != nil; pool = vs.resources.ResourcePools.Get(pool.ParentID) {
+			sumAggregatedMetrics(mx, pool.ID, em.Value)
+		}
+		if !vs.VMCharts {
+			continue
+		}
 		writeVMMetrics(mx, vm, em.Value)
 		vms[vm.ID] = vs.vmID(vm)
 	}
@@ -141,6 +162,38 @@ func writeVMMetrics(mx map[string]int64, vm *rs.VM, metrics []performance.Metric
 	}
 	key := fmt.Sprintf("%s_overall.status", vm.ID)
 	mx[key] = overallStatusToInt(vm.OverallStatus)
+	writeTriggeredAlarms(mx, vm.ID, vm.Alarms)
+	key = fmt.Sprintf("%s_snapshots.count", vm.ID)
+	mx[key] = int64(vm.Snapshots)
+	key = fmt.Sprintf("%s_snapshots.oldest_age", vm.ID)
+	mx[key] = vmOldestSnapshotAge(vm)
+}
+
+// snapshotAgeThreshold is the age after which a snapshot is considered forgotten.
+const snapshotAgeThreshold = time.Hour * 24 * 3
+
+// collectVMsSnapshots writes the snapshots summary. Snapshot trees are fetched at discovery,
+// the age is calculated on every collection.
+func collectVMsSnapshots(mx map[string]int64, vms rs.VMs) {
+	var withSnapshots, olderThanThreshold int64
+	for _, vm := range vms {
+		if vm.Snapshots == 0 {
+			continue
+		}
+		withSnapshots++
+		if time.Since(vm.OldestSnapshot) > snapshotAgeThreshold {
+			olderThanThreshold++
+		}
+	}
+	mx["vms_with_snapshots"] = withSnapshots
+	mx["vms_with_snapshots_older_than_3d"] = olderThanThreshold
+}
+
+func vmOldestSnapshotAge(vm *rs.VM) int64 {
+	if vm.Snapshots == 0 || vm.OldestSnapshot.IsZero() {
+		return 0
+	}
+	return int64(time.Since(vm.OldestSnapshot) / time.Hour)
 }
 
 func (vs *VSphere) updateDiscoveredVMs(collected map[string]string) {
@@ -205,6 +258,79 @@ func cleanID(id string) string {
 
 var r = strings.NewReplacer(" ", "_", ".", "_")
 
+var aggregatedMetrics = []string{
+	"cpu.usagemhz.average",
+	"mem.consumed.average",
+}
+
+func (vs *VSphere) collectClusters(mx map[string]int64) {
+	for _, c := range vs.resources.Clusters {
+		if c.Summary == nil {
+			continue
+		}
+		mx[c.ID+"_cpu.total"] = c.Summary.TotalCPU
+		mx[c.ID+"_cpu.effective"] = c.Summary.EffectiveCPU
+		mx[c.ID+"_mem.total"] = c.Summary.TotalMem / 1024
+		mx[c.ID+"_mem.effective"] = c.Summary.EffectiveMem / 1024
+		mx[c.ID+"_failover_level.current"] = c.Summary.CurrentFailoverLevel
+		mx[c.ID+"_failover_level.configured"] = c.Summary.ConfiguredFailoverLevel
+		for _, name := range aggregatedMetrics {
+			mx[c.ID+"_"+name] = 0
+		}
+	}
+	vs.updateClustersCharts()
+}
+
+func (vs *VSphere) collectResourcePools(mx map[string]int64) {
+	for _, p := range vs.resources.ResourcePools {
+		for _, name := range aggregatedMetrics {
+			mx[p.ID+"_"+name] = 0
+		}
+	}
+	vs.updateResourcePoolsCharts()
+}
+
+func sumAggregatedMetrics(mx map[string]int64, id string, metrics []performance.MetricSeries) {
+	for _, m := range metrics {
+		if len(m.Value) == 0 || m.Value[0] == -1 {
+			continue
+		}
+		for _, name := range aggregatedMetrics {
+			if m.Name == name {
+				mx[id+"_"+name] += m.Value[0]
+			}
+		}
+	}
+}
+
+func writeTriggeredAlarms(mx map[string]int64, id string, alarms rs.TriggeredAlarms) {
+	mx[id+"_triggered_alarms.yellow"] = int64(alarms.Yellow)
+	mx[id+"_triggered_alarms.red"] = int64(alarms.Red)
+}
+
+// collectOverallStatus writes the number of hosts and vms by overall status, the status is calculated by vCenter.
+func (vs *VSphere) collectOverallStatus(mx map[string]int64) {
+	for _, s := range []string{"green", "yellow", "red", "gray"} {
+		mx["hosts_overall_status_"+s] = 0
+		mx["vms_overall_status_"+s] = 0
+	}
+	for _, h := range vs.resources.Hosts {
+		mx["hosts_overall_status_"+overallStatusName(h.OverallStatus)]++
+	}
+	for _, vm := range vs.resources.VMs {
+		mx["vms_overall_status_"+overallStatusName(vm.OverallStatus)]++
+	}
+}
+
+func overallStatusName(status string) string {
+	switch status {
+	case "green", "yellow", "red":
+		return status
+	default:
+		return "gray"
+	}
+}
+
 func overallStatusToInt(status string) int64 {
 	// ManagedEntityStatus
 	switch status {
@@ -8,6 +8,7 @@ import (
 	rs "github.com/netdata/go.d.plugin/modules/vsphere/resources"
 
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 func (d Discoverer) build(raw *resources) *rs.Resources {
@@ -19,16 +20,19 @@ func (d Discoverer) build(raw *resources) *rs.Resources {
 	res.Folders = d.buildFolders(raw.folders)
 	res.Clusters = d.buildClusters(raw.clusters)
 	fixClustersParentID(&res)
+	res.ResourcePools = d.buildResourcePools(raw.pools)
 	res.Hosts = d.buildHosts(raw.hosts)
 	res.VMs = d.buildVMs(raw.vms)
 
-	d.Infof("discovering : building : built %d/%d dcs, %d/%d folders, %d/%d clusters, %d/%d hosts, %d/%d vms, process took %s",
+	d.Infof("discovering : building : built %d/%d dcs, %d/%d folders, %d/%d clusters, %d/%d resource pools, %d/%d hosts, %d/%d vms, process took %s",
 		len(res.DataCenters),
 		len(raw.dcs),
 		len(res.Folders),
 		len(raw.folders),
 		len(res.Clusters),
 		len(raw.clusters),
+		len(res.ResourcePools),
+		len(raw.pools),
 		len(res.Hosts),
 		len(raw.hosts),
 		len(res.VMs),
@@ -106,9 +110,64 @@ func newCluster(raw mo.ComputeResource) *rs.Cluster {
 		Name:     raw.Name,
 		ID:       raw.Reference().Value,
 		ParentID: raw.Parent.Value,
+		Summary:  newClusterSummary(raw),
 	}
 }
 
+func newClusterSummary(raw mo.ComputeResource) *rs.ClusterSummary {
+	s, ok := raw.Summary.(*types.ClusterComputeResourceSummary)
+	if !ok {
+		return nil
+	}
+	summary := &rs.ClusterSummary{
+		TotalCPU:     int64(s.TotalCpu),
+		EffectiveCPU: int64(s.EffectiveCpu),
+		TotalMem:     s.TotalMemory,
+		// effectiveMemory is in MB
+		EffectiveMem:         s.EffectiveMemory * 1024 * 1024,
+		CurrentFailoverLevel: int64(s.CurrentFailoverLevel),
+	}
+	if cfg, ok := raw.ConfigurationEx.(*types.ClusterConfigInfoEx); ok {
+		summary.ConfiguredFailoverLevel = int64(cfg.DasConfig.FailoverLevel)
+		if p, ok := cfg.DasConfig.AdmissionControlPolicy.(*types.ClusterFailoverLevelAdmissionControlPolicy); ok {
+			summary.ConfiguredFailoverLevel = int64(p.FailoverLevel)
+		}
+	}
+	return summary
+}
+
+func (Discoverer) buildResourcePools(raw []mo.ResourcePool) rs.ResourcePools {
+	pools := make(rs.ResourcePools)
+	for _, p := range raw {
+		// cluster (or standalone host) root pool "Resources" parent is the compute resource
+		if p.Parent == nil || !isResourcePool(*p.Parent) {
+			continue
+		}
+		pools.Put(newResourcePool(p))
+	}
+	// root pool children have no parent pool
+	for _, p := range pools {
+		if pools.Get(p.ParentID) == nil {
+			p.ParentID = ""
+		}
+	}
+	return pools
+}
+
+func newResourcePool(raw mo.ResourcePool) *rs.ResourcePool {
+	// RP1 resgroup-71 resgroup-8 domain-c7
+	return &rs.ResourcePool{
+		Name:     raw.Name,
+		ID:       raw.Reference().Value,
+		ParentID: raw.Parent.Value,
+		OwnerID:  raw.Owner.Value,
+	}
+}
+
+func isResourcePool(ref types.ManagedObjectReference) bool {
+	return ref.Type == "ResourcePool" || ref.Type == "VirtualApp"
+}
+
 const (
 	poweredOn = "poweredOn"
 )
@@ -143,6 +202,7 @@ func newHost(raw mo.HostSystem) *rs.Host {
 		ID:            raw.Reference().Value,
 		ParentID:      raw.Parent.Value,
 		OverallStatus: string(raw.Summary.OverallStatus),
+		Alarms:        countTriggeredAlarms(raw.TriggeredAlarmState),
 		Ref:           raw.Reference(),
 	}
 }
@@ -170,11 +230,48 @@ func (d Discoverer) buildVMs(raw []mo.VirtualMachine) rs.VMs {
 
 func newVM(raw mo.VirtualMachine) *rs.VM {
 	// deb91 vm-25 group-v3 host-22
-	return &rs.VM{
+	vm := &rs.VM{
 		Name:          raw.Name,
 		ID:            raw.Reference().Value,
 		ParentID:      raw.Runtime.Host.Value,
 		OverallStatus: string(raw.Summary.OverallStatus),
+		Alarms:        countTriggeredAlarms(raw.TriggeredAlarmState),
 		Ref:           raw.Reference(),
 	}
+	if raw.ResourcePool != nil {
+		vm.ResourcePoolID = raw.ResourcePool.Value
+	}
+	if raw.Snapshot != nil {
+		vm.Snapshots, vm.OldestSnapshot = walkSnapshotTree(raw.Snapshot.RootSnapshotList)
+	}
+	return vm
+}
+
+// countTriggeredAlarms counts triggered alarms by status, acknowledged alarms are counted too.
+func countTriggeredAlarms(states []types.AlarmState) (alarms rs.TriggeredAlarms) {
+	for _, s := range states {
+		switch s.OverallStatus {
+		case types.ManagedEntityStatusYellow:
+			alarms.Yellow++
+		case types.ManagedEntityStatusRed:
+			alarms.Red++
+		}
+	}
+	return alarms
+}
+
+// walkSnapshotTree returns the number of snapshots in the tree and the creation time of the oldest one.
+func walkSnapshotTree(tree []types.VirtualMachineSnapshotTree) (num int, oldest time.Time) {
+	for _, s := range tree {
+		num++
+		if oldest.IsZero() || s.CreateTime.Before(oldest) {
+			oldest = s.CreateTime
+		}
+		n, o := walkSnapshotTree(s.ChildSnapshotList)
+		num += n
+		if !o.IsZero() && o.Before(oldest) {
+			oldest = o
+		}
+	}
+	return num, oldest
 }
@@ -21,6 +21,7 @@ type Client interface {
 	ComputeResources(pathSet ...string) ([]mo.ComputeResource, error)
 	Hosts(pathSet ...string) ([]mo.HostSystem, error)
 	VirtualMachines(pathSet ...string) ([]mo.VirtualMachine, error)
+	ResourcePools(pathSet ...string) ([]mo.ResourcePool, error)
 
 	CounterInfoByName() (map[string]*types.PerfCounterInfo, error)
 }
@@ -42,6 +43,7 @@ type resources struct {
 	dcs      []mo.Datacenter
 	folders  []mo.Folder
 	clusters []mo.ComputeResource
+	pools    []mo.ResourcePool
 	hosts    []mo.HostSystem
 	vms      []mo.VirtualMachine
 }
@@ -87,9 +89,10 @@ var (
 	// properties to set
 	datacenterPathSet = []string{"name", "parent"}
 	folderPathSet     = []string{"name", "parent"}
-	clusterPathSet    = []string{"name", "parent"}
-	hostPathSet       = []string{"name", "parent", "runtime.powerState", "summary.overallStatus"}
-	vmPathSet         = []string{"name", "runtime.host", "runtime.powerState", "summary.overallStatus"}
+	clusterPathSet    = []string{"name", "parent", "summary", "configurationEx"}
+	poolPathSet       = []string{"name", "parent", "owner"}
+	hostPathSet       = []string{"name", "parent", "runtime.powerState", "summary.overallStatus", "triggeredAlarmState"}
+	vmPathSet         = []string{"name", "runtime.host", "runtime.powerState", "summary.overallStatus", "triggeredAlarmState", "snapshot", "resourcePool"}
 )
 
 func (d Discoverer) discover() (*resources, error) {
@@ -117,6 +120,13 @@ func (d Discoverer) discover() (*resources, error) {
 	}
 	d.Debugf("discovering : found %d clusters, process took %s", len(clusters), time.Since(t))
 
+	t = time.Now()
+	pools, err := d.ResourcePools(poolPathSet...)
+	if err != nil {
+		return nil, err
+	}
+	d.Debugf("discovering : found %d resource pools, process took %s", len(pools), time.Since(t))
+
 	t = time.Now()
 	hosts, err := d.Hosts(hostPathSet...)
 	if err != nil {
@@ -135,15 +145,17 @@ func (d Discoverer) discover() (*resources, error) {
 		dcs:      datacenters,
 		folders:  folders,
 		clusters: clusters,
+		pools:    pools,
 		hosts:    hosts,
 		vms:      vms,
 	}
 
-	d.Infof("discovering : found %d dcs, %d folders, %d clusters (%d dummy), %d hosts, %d vms, process took %s",
+	d.Infof("discovering : found %d dcs, %d folders, %d clusters (%d dummy), %d resource pools, %d hosts, %d vms, process took %s",
 		len(raw.dcs),
 		len(raw.folders),
 		len(clusters),
 		numOfDummyClusters(clusters),
+		len(raw.pools),
 		len(raw.hosts),
 		len(raw.vms),
 		time.Since(start),
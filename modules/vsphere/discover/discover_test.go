@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 func TestDiscoverer_Discover(t *testing.T) {
@@ -65,6 +66,40 @@ func TestDiscoverer_build(t *testing.T) {
 	assert.Lenf(t, res.VMs, len(raw.vms), "hosts")
 }
 
+func TestDiscoverer_build_ResourcePools(t *testing.T) {
+	model := simulator.VPX()
+	model.Pool = 2
+	require.NoError(t, model.Create())
+	model.Service.TLS = new(tls.Config)
+	srv := model.Service.NewServer()
+	defer func() { model.Remove(); srv.Close() }()
+	d := New(newClient(t, srv.URL))
+
+	raw, err := d.discover()
+	require.NoError(t, err)
+
+	res := d.build(raw)
+	require.NoError(t, d.setHierarchy(res))
+
+	// root pools are not included
+	assert.Len(t, res.ResourcePools, model.Pool*model.Cluster)
+	for _, p := range res.ResourcePools {
+		assert.Empty(t, p.ParentID)
+		assert.True(t, p.Hier.IsSet())
+	}
+	for _, v := range res.VMs {
+		assert.NotEmpty(t, v.ResourcePoolID)
+	}
+	var clusters int
+	for _, c := range res.Clusters {
+		if c.Summary != nil {
+			clusters++
+			assert.NotZero(t, c.Summary.TotalCPU)
+		}
+	}
+	assert.Equal(t, model.Cluster, clusters)
+}
+
 func TestDiscoverer_setHierarchy(t *testing.T) {
 	d, _, teardown := prepareDiscovererSim(t)
 	defer teardown()
@@ -109,6 +144,59 @@ func TestDiscoverer_collectMetricLists(t *testing.T) {
 	assert.True(t, isMetricListsCollected(res))
 }
 
+func Test_countTriggeredAlarms(t *testing.T) {
+	acked := true
+	states := []types.AlarmState{
+		{OverallStatus: types.ManagedEntityStatusYellow},
+		{OverallStatus: types.ManagedEntityStatusRed},
+		{OverallStatus: types.ManagedEntityStatusRed, Acknowledged: &acked},
+		{OverallStatus: types.ManagedEntityStatusGreen},
+		{OverallStatus: types.ManagedEntityStatusGray},
+	}
+
+	assert.Equal(t, rs.TriggeredAlarms{Yellow: 1, Red: 2}, countTriggeredAlarms(states))
+	assert.Equal(t, rs.TriggeredAlarms{}, countTriggeredAlarms(nil))
+}
+
+func Test_walkSnapshotTree(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		tree       []types.VirtualMachineSnapshotTree
+		wantNum    int
+		wantOldest time.Time
+	}{
+		"no snapshots": {},
+		"single root snapshot": {
+			tree:       []types.VirtualMachineSnapshotTree{{CreateTime: now}},
+			wantNum:    1,
+			wantOldest: now,
+		},
+		"nested snapshots": {
+			tree: []types.VirtualMachineSnapshotTree{
+				{
+					CreateTime: now.Add(-time.Hour),
+					ChildSnapshotList: []types.VirtualMachineSnapshotTree{
+						{CreateTime: now},
+						{CreateTime: now.Add(-time.Minute)},
+					},
+				},
+				{CreateTime: now.Add(-time.Hour * 2)},
+			},
+			wantNum:    4,
+			wantOldest: now.Add(-time.Hour * 2),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			num, oldest := walkSnapshotTree(test.tree)
+
+			assert.Equal(t, test.wantNum, num)
+			assert.Equal(t, test.wantOldest, oldest)
+		})
+	}
+}
+
 func prepareDiscovererSim(t *testing.T) (d *Discoverer, model *simulator.Model, teardown func()) {
 	model, srv := createSim(t)
 	teardown = func() { model.Remove(); srv.Close() }
@@ -143,6 +231,11 @@ func isHierarchySet(res *rs.Resources) bool {
 			return false
 		}
 	}
+	for _, p := range res.ResourcePools {
+		if !p.Hier.IsSet() {
+			return false
+		}
+	}
 	for _, h := range res.Hosts {
 		if !h.Hier.IsSet() {
 			return false
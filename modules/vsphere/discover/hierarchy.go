@@ -13,12 +13,14 @@ func (d Discoverer) setHierarchy(res *rs.Resources) error {
 	t := time.Now()
 
 	c := d.setClustersHierarchy(res)
+	p := d.setResourcePoolsHierarchy(res)
 	h := d.setHostsHierarchy(res)
 	v := d.setVMsHierarchy(res)
 
 	// notSet := len(res.Clusters) + len(res.Hosts) + len(res.VMs) - (c + h + v)
-	d.Infof("discovering : hierarchy : set %d/%d clusters, %d/%d hosts, %d/%d vms, process took %s",
+	d.Infof("discovering : hierarchy : set %d/%d clusters, %d/%d resource pools, %d/%d hosts, %d/%d vms, process took %s",
 		c, len(res.Clusters),
+		p, len(res.ResourcePools),
 		h, len(res.Hosts),
 		v, len(res.VMs),
 		time.Since(t),
@@ -36,6 +38,15 @@ func (d Discoverer) setClustersHierarchy(res *rs.Resources) (set int) {
 	return set
 }
 
+func (d Discoverer) setResourcePoolsHierarchy(res *rs.Resources) (set int) {
+	for _, pool := range res.ResourcePools {
+		if setResourcePoolHierarchy(pool, res) {
+			set++
+		}
+	}
+	return set
+}
+
 func (d Discoverer) setHostsHierarchy(res *rs.Resources) (set int) {
 	for _, host := range res.Hosts {
 		if setHostHierarchy(host, res) {
@@ -63,6 +74,21 @@ func setClusterHierarchy(cluster *rs.Cluster, res *rs.Resources) bool {
 	return cluster.Hier.IsSet()
 }
 
+func setResourcePoolHierarchy(pool *rs.ResourcePool, res *rs.Resources) bool {
+	cr := res.Clusters.Get(pool.OwnerID)
+	if cr == nil {
+		return false
+	}
+	pool.Hier.Cluster.Set(cr.ID, cr.Name)
+
+	dc := res.DataCenters.Get(cr.ParentID)
+	if dc == nil {
+		return false
+	}
+	pool.Hier.DC.Set(dc.ID, dc.Name)
+	return pool.Hier.IsSet()
+}
+
 func setHostHierarchy(host *rs.Host, res *rs.Resources) bool {
 	cr := res.Clusters.Get(host.ParentID)
 	if cr == nil {
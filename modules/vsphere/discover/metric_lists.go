@@ -77,6 +77,7 @@ func simpleMetricList(metrics []string, pci map[string]*types.PerfCounterInfo) p
 var (
 	vmMetrics = []string{
 		"cpu.usage.average",
+		"cpu.usagemhz.average",
 
 		"mem.usage.average",
 		"mem.granted.average",
@@ -105,6 +106,7 @@ var (
 
 	hostMetrics = []string{
 		"cpu.usage.average",
+		"cpu.usagemhz.average",
 
 		"mem.usage.average",
 		"mem.granted.average",
@@ -3,6 +3,8 @@
 package resources
 
 import (
+	"time"
+
 	"github.com/vmware/govmomi/performance"
 	"github.com/vmware/govmomi/vim25/types"
 )
@@ -44,14 +46,21 @@ Virtual Datacenter Architecture Representation (partial).
 */
 
 type Resources struct {
-	DataCenters DataCenters
-	Folders     Folders
-	Clusters    Clusters
-	Hosts       Hosts
-	VMs         VMs
+	DataCenters   DataCenters
+	Folders       Folders
+	Clusters      Clusters
+	ResourcePools ResourcePools
+	Hosts         Hosts
+	VMs           VMs
 }
 
 type (
+	// TriggeredAlarms is the number of active triggered alarms by status.
+	TriggeredAlarms struct {
+		Yellow int
+		Red    int
+	}
+
 	Datacenter struct {
 		Name string
 		ID   string
@@ -70,11 +79,37 @@ type (
 	ClusterHierarchy struct {
 		DC HierarchyValue
 	}
+	// ClusterSummary is the cluster capacity and HA state, it is not set for standalone hosts compute resources.
+	ClusterSummary struct {
+		TotalCPU     int64 // MHz
+		EffectiveCPU int64 // MHz
+		TotalMem     int64 // bytes
+		EffectiveMem int64 // bytes
+		// CurrentFailoverLevel is the number of host failures the cluster can tolerate.
+		CurrentFailoverLevel int64
+		// ConfiguredFailoverLevel is the HA admission control failover level.
+		ConfiguredFailoverLevel int64
+	}
 	Cluster struct {
 		Name     string
 		ID       string
 		ParentID string
 		Hier     ClusterHierarchy
+		Summary  *ClusterSummary
+	}
+
+	ResourcePoolHierarchy struct {
+		DC      HierarchyValue
+		Cluster HierarchyValue
+	}
+	// ResourcePool is a user created resource pool, clusters root pools are not included.
+	ResourcePool struct {
+		Name string
+		ID   string
+		// ParentID is the parent resource pool id, it is not set for the root pool children.
+		ParentID string
+		OwnerID  string
+		Hier     ResourcePoolHierarchy
 	}
 
 	HostHierarchy struct {
@@ -87,6 +122,7 @@ type (
 		ParentID      string
 		Hier          HostHierarchy
 		OverallStatus string
+		Alarms        TriggeredAlarms
 		MetricList    performance.MetricList
 		Ref           types.ManagedObjectReference
 	}
@@ -103,8 +139,15 @@ type (
 		ParentID      string
 		Hier          VMHierarchy
 		OverallStatus string
+		Alarms        TriggeredAlarms
 		MetricList    performance.MetricList
 		Ref           types.ManagedObjectReference
+		// ResourcePoolID is the resource pool the VM belongs to.
+		ResourcePoolID string
+		// Snapshots is the number of snapshots in the VM snapshot tree.
+		Snapshots int
+		// OldestSnapshot is the creation time of the oldest snapshot, zero if there are no snapshots.
+		OldestSnapshot time.Time
 	}
 )
 
@@ -115,23 +158,28 @@ func (h ClusterHierarchy) IsSet() bool { return h.DC.IsSet() }
 func (h HostHierarchy) IsSet() bool    { return h.DC.IsSet() && h.Cluster.IsSet() }
 func (h VMHierarchy) IsSet() bool      { return h.DC.IsSet() && h.Cluster.IsSet() && h.Host.IsSet() }
 
+func (h ResourcePoolHierarchy) IsSet() bool { return h.DC.IsSet() && h.Cluster.IsSet() }
+
 type (
-	DataCenters map[string]*Datacenter
-	Folders     map[string]*Folder
-	Clusters    map[string]*Cluster
-	Hosts       map[string]*Host
-	VMs         map[string]*VM
+	DataCenters   map[string]*Datacenter
+	Folders       map[string]*Folder
+	Clusters      map[string]*Cluster
+	ResourcePools map[string]*ResourcePool
+	Hosts         map[string]*Host
+	VMs           map[string]*VM
 )
 
-func (dcs DataCenters) Put(dc *Datacenter)        { dcs[dc.ID] = dc }
-func (dcs DataCenters) Get(id string) *Datacenter { return dcs[id] }
-func (fs Folders) Put(folder *Folder)             { fs[folder.ID] = folder }
-func (fs Folders) Get(id string) *Folder          { return fs[id] }
-func (cs Clusters) Put(cluster *Cluster)          { cs[cluster.ID] = cluster }
-func (cs Clusters) Get(id string) *Cluster        { return cs[id] }
-func (hs Hosts) Put(host *Host)                   { hs[host.ID] = host }
-func (hs Hosts) Remove(id string)                 { delete(hs, id) }
-func (hs Hosts) Get(id string) *Host              { return hs[id] }
-func (vs VMs) Put(vm *VM)                         { vs[vm.ID] = vm }
-func (vs VMs) Remove(id string)                   { delete(vs, id) }
-func (vs VMs) Get(id string) *VM                  { return vs[id] }
+func (dcs DataCenters) Put(dc *Datacenter)           { dcs[dc.ID] = dc }
+func (dcs DataCenters) Get(id string) *Datacenter    { return dcs[id] }
+func (fs Folders) Put(folder *Folder)                { fs[folder.ID] = folder }
+func (fs Folders) Get(id string) *Folder             { return fs[id] }
+func (cs Clusters) Put(cluster *Cluster)             { cs[cluster.ID] = cluster }
+func (cs Clusters) Get(id string) *Cluster           { return cs[id] }
+func (ps ResourcePools) Put(pool *ResourcePool)      { ps[pool.ID] = pool }
+func (ps ResourcePools) Get(id string) *ResourcePool { return ps[id] }
+func (hs Hosts) Put(host *Host)                      { hs[host.ID] = host }
+func (hs Hosts) Remove(id string)                    { delete(hs, id) }
+func (hs Hosts) Get(id string) *Host                 { return hs[id] }
+func (vs VMs) Put(vm *VM)                            { vs[vm.ID] = vm }
+func (vs VMs) Remove(id string)                      { delete(vs, id) }
+func (vs VMs) Get(id string) *VM                     { return vs[id] }
@@ -39,15 +39,18 @@ func New() *VSphere {
 		DiscoveryInterval: web.Duration{Duration: time.Minute * 5},
 		HostsInclude:      []string{"/*"},
 		VMsInclude:        []string{"/*"},
+		VMCharts:          true,
 	}
 
 	return &VSphere{
 		collectionLock:  new(sync.RWMutex),
 		Config:          config,
-		charts:          &Charts{},
+		charts:          summaryCharts.Copy(),
 		discoveredHosts: make(map[string]int),
 		discoveredVMs:   make(map[string]int),
 		charted:         make(map[string]bool),
+		chartedClusters: make(map[string]bool),
+		chartedPools:    make(map[string]bool),
 	}
 }
 
@@ -67,6 +70,8 @@ type Config struct {
 	VMsInclude        match.VMIncludes                               `yaml:"vm_include"`
 	HostMetrics       struct{ Name, Cluster, DataCenter bool }       `yaml:"host_metrics"`
 	VMMetrics         struct{ Name, Host, Cluster, DataCenter bool } `yaml:"vm_metrics"`
+	// VMCharts enables per VM charts, VMs metrics are collected anyway for resource pools aggregation.
+	VMCharts bool `yaml:"vm_charts"`
 }
 
 type VSphere struct {
@@ -83,6 +88,8 @@ type VSphere struct {
 	discoveredHosts map[string]int
 	discoveredVMs   map[string]int
 	charted         map[string]bool
+	chartedClusters map[string]bool
+	chartedPools    map[string]bool
 	charts          *Charts
 }
 
@@ -124,174 +124,225 @@ func TestVSphere_Collect(t *testing.T) {
 	vSphere.scraper = mockScraper{vSphere.scraper}
 
 	expected := map[string]int64{
-		"host-20_cpu.usage.average":           100,
-		"host-20_disk.maxTotalLatency.latest": 100,
-		"host-20_disk.read.average":           100,
-		"host-20_disk.write.average":          100,
-		"host-20_mem.active.average":          100,
-		"host-20_mem.consumed.average":        100,
-		"host-20_mem.granted.average":         100,
-		"host-20_mem.shared.average":          100,
-		"host-20_mem.sharedcommon.average":    100,
-		"host-20_mem.swapinRate.average":      100,
-		"host-20_mem.swapoutRate.average":     100,
-		"host-20_mem.usage.average":           100,
-		"host-20_net.bytesRx.average":         100,
-		"host-20_net.bytesTx.average":         100,
-		"host-20_net.droppedRx.summation":     100,
-		"host-20_net.droppedTx.summation":     100,
-		"host-20_net.errorsRx.summation":      100,
-		"host-20_net.errorsTx.summation":      100,
-		"host-20_net.packetsRx.summation":     100,
-		"host-20_net.packetsTx.summation":     100,
-		"host-20_overall.status":              0,
-		"host-20_sys.uptime.latest":           100,
-		"host-32_cpu.usage.average":           100,
-		"host-32_disk.maxTotalLatency.latest": 100,
-		"host-32_disk.read.average":           100,
-		"host-32_disk.write.average":          100,
-		"host-32_mem.active.average":          100,
-		"host-32_mem.consumed.average":        100,
-		"host-32_mem.granted.average":         100,
-		"host-32_mem.shared.average":          100,
-		"host-32_mem.sharedcommon.average":    100,
-		"host-32_mem.swapinRate.average":      100,
-		"host-32_mem.swapoutRate.average":     100,
-		"host-32_mem.usage.average":           100,
-		"host-32_net.bytesRx.average":         100,
-		"host-32_net.bytesTx.average":         100,
-		"host-32_net.droppedRx.summation":     100,
-		"host-32_net.droppedTx.summation":     100,
-		"host-32_net.errorsRx.summation":      100,
-		"host-32_net.errorsTx.summation":      100,
-		"host-32_net.packetsRx.summation":     100,
-		"host-32_net.packetsTx.summation":     100,
-		"host-32_overall.status":              0,
-		"host-32_sys.uptime.latest":           100,
-		"host-39_cpu.usage.average":           100,
-		"host-39_disk.maxTotalLatency.latest": 100,
-		"host-39_disk.read.average":           100,
-		"host-39_disk.write.average":          100,
-		"host-39_mem.active.average":          100,
-		"host-39_mem.consumed.average":        100,
-		"host-39_mem.granted.average":         100,
-		"host-39_mem.shared.average":          100,
-		"host-39_mem.sharedcommon.average":    100,
-		"host-39_mem.swapinRate.average":      100,
-		"host-39_mem.swapoutRate.average":     100,
-		"host-39_mem.usage.average":           100,
-		"host-39_net.bytesRx.average":         100,
-		"host-39_net.bytesTx.average":         100,
-		"host-39_net.droppedRx.summation":     100,
-		"host-39_net.droppedTx.summation":     100,
-		"host-39_net.errorsRx.summation":      100,
-		"host-39_net.errorsTx.summation":      100,
-		"host-39_net.packetsRx.summation":     100,
-		"host-39_net.packetsTx.summation":     100,
-		"host-39_overall.status":              0,
-		"host-39_sys.uptime.latest":           100,
-		"host-46_cpu.usage.average":           100,
-		"host-46_disk.maxTotalLatency.latest": 100,
-		"host-46_disk.read.average":           100,
-		"host-46_disk.write.average":          100,
-		"host-46_mem.active.average":          100,
-		"host-46_mem.consumed.average":        100,
-		"host-46_mem.granted.average":         100,
-		"host-46_mem.shared.average":          100,
-		"host-46_mem.sharedcommon.average":    100,
-		"host-46_mem.swapinRate.average":      100,
-		"host-46_mem.swapoutRate.average":     100,
-		"host-46_mem.usage.average":           100,
-		"host-46_net.bytesRx.average":         100,
-		"host-46_net.bytesTx.average":         100,
-		"host-46_net.droppedRx.summation":     100,
-		"host-46_net.droppedTx.summation":     100,
-		"host-46_net.errorsRx.summation":      100,
-		"host-46_net.errorsTx.summation":      100,
-		"host-46_net.packetsRx.summation":     100,
-		"host-46_net.packetsTx.summation":     100,
-		"host-46_overall.status":              0,
-		"host-46_sys.uptime.latest":           100,
-		"vm-53_cpu.usage.average":             200,
-		"vm-53_disk.maxTotalLatency.latest":   200,
-		"vm-53_disk.read.average":             200,
-		"vm-53_disk.write.average":            200,
-		"vm-53_mem.active.average":            200,
-		"vm-53_mem.consumed.average":          200,
-		"vm-53_mem.granted.average":           200,
-		"vm-53_mem.shared.average":            200,
-		"vm-53_mem.swapinRate.average":        200,
-		"vm-53_mem.swapoutRate.average":       200,
-		"vm-53_mem.swapped.average":           200,
-		"vm-53_mem.usage.average":             200,
-		"vm-53_net.bytesRx.average":           200,
-		"vm-53_net.bytesTx.average":           200,
-		"vm-53_net.droppedRx.summation":       200,
-		"vm-53_net.droppedTx.summation":       200,
-		"vm-53_net.packetsRx.summation":       200,
-		"vm-53_net.packetsTx.summation":       200,
-		"vm-53_overall.status":                1,
-		"vm-53_sys.uptime.latest":             200,
-		"vm-56_cpu.usage.average":             200,
-		"vm-56_disk.maxTotalLatency.latest":   200,
-		"vm-56_disk.read.average":             200,
-		"vm-56_disk.write.average":            200,
-		"vm-56_mem.active.average":            200,
-		"vm-56_mem.consumed.average":          200,
-		"vm-56_mem.granted.average":           200,
-		"vm-56_mem.shared.average":            200,
-		"vm-56_mem.swapinRate.average":        200,
-		"vm-56_mem.swapoutRate.average":       200,
-		"vm-56_mem.swapped.average":           200,
-		"vm-56_mem.usage.average":             200,
-		"vm-56_net.bytesRx.average":           200,
-		"vm-56_net.bytesTx.average":           200,
-		"vm-56_net.droppedRx.summation":       200,
-		"vm-56_net.droppedTx.summation":       200,
-		"vm-56_net.packetsRx.summation":       200,
-		"vm-56_net.packetsTx.summation":       200,
-		"vm-56_overall.status":                1,
-		"vm-56_sys.uptime.latest":             200,
-		"vm-59_cpu.usage.average":             200,
-		"vm-59_disk.maxTotalLatency.latest":   200,
-		"vm-59_disk.read.average":             200,
-		"vm-59_disk.write.average":            200,
-		"vm-59_mem.active.average":            200,
-		"vm-59_mem.consumed.average":          200,
-		"vm-59_mem.granted.average":           200,
-		"vm-59_mem.shared.average":            200,
-		"vm-59_mem.swapinRate.average":        200,
-		"vm-59_mem.swapoutRate.average":       200,
-		"vm-59_mem.swapped.average":           200,
-		"vm-59_mem.usage.average":             200,
-		"vm-59_net.bytesRx.average":           200,
-		"vm-59_net.bytesTx.average":           200,
-		"vm-59_net.droppedRx.summation":       200,
-		"vm-59_net.droppedTx.summation":       200,
-		"vm-59_net.packetsRx.summation":       200,
-		"vm-59_net.packetsTx.summation":       200,
-		"vm-59_overall.status":                1,
-		"vm-59_sys.uptime.latest":             200,
-		"vm-62_cpu.usage.average":             200,
-		"vm-62_disk.maxTotalLatency.latest":   200,
-		"vm-62_disk.read.average":             200,
-		"vm-62_disk.write.average":            200,
-		"vm-62_mem.active.average":            200,
-		"vm-62_mem.consumed.average":          200,
-		"vm-62_mem.granted.average":           200,
-		"vm-62_mem.shared.average":            200,
-		"vm-62_mem.swapinRate.average":        200,
-		"vm-62_mem.swapoutRate.average":       200,
-		"vm-62_mem.swapped.average":           200,
-		"vm-62_mem.usage.average":             200,
-		"vm-62_net.bytesRx.average":           200,
-		"vm-62_net.bytesTx.average":           200,
-		"vm-62_net.droppedRx.summation":       200,
-		"vm-62_net.droppedTx.summation":       200,
-		"vm-62_net.packetsRx.summation":       200,
-		"vm-62_net.packetsTx.summation":       200,
-		"vm-62_overall.status":                1,
-		"vm-62_sys.uptime.latest":             200,
+		"clustercomputeresource-26_cpu.effective":             6882,
+		"clustercomputeresource-26_cpu.total":                 6882,
+		"clustercomputeresource-26_cpu.usagemhz.average":      300,
+		"clustercomputeresource-26_failover_level.configured": 0,
+		"clustercomputeresource-26_failover_level.current":    0,
+		"clustercomputeresource-26_mem.consumed.average":      300,
+		// the simulator reports effectiveMemory in bytes instead of MB
+		"clustercomputeresource-26_mem.effective": 13192491171840,
+		"clustercomputeresource-26_mem.total":     12581340,
+		"host-20_cpu.usage.average":               100,
+		"host-20_cpu.usagemhz.average":            100,
+		"host-20_disk.maxTotalLatency.latest":     100,
+		"host-20_disk.read.average":               100,
+		"host-20_disk.write.average":              100,
+		"host-20_mem.active.average":              100,
+		"host-20_mem.consumed.average":            100,
+		"host-20_mem.granted.average":             100,
+		"host-20_mem.shared.average":              100,
+		"host-20_mem.sharedcommon.average":        100,
+		"host-20_mem.swapinRate.average":          100,
+		"host-20_mem.swapoutRate.average":         100,
+		"host-20_mem.usage.average":               100,
+		"host-20_net.bytesRx.average":             100,
+		"host-20_net.bytesTx.average":             100,
+		"host-20_net.droppedRx.summation":         100,
+		"host-20_net.droppedTx.summation":         100,
+		"host-20_net.errorsRx.summation":          100,
+		"host-20_net.errorsTx.summation":          100,
+		"host-20_net.packetsRx.summation":         100,
+		"host-20_net.packetsTx.summation":         100,
+		"host-20_overall.status":                  0,
+		"host-20_sys.uptime.latest":               100,
+		"host-20_triggered_alarms.red":            0,
+		"host-20_triggered_alarms.yellow":         0,
+		"host-32_cpu.usage.average":               100,
+		"host-32_cpu.usagemhz.average":            100,
+		"host-32_disk.maxTotalLatency.latest":     100,
+		"host-32_disk.read.average":               100,
+		"host-32_disk.write.average":              100,
+		"host-32_mem.active.average":              100,
+		"host-32_mem.consumed.average":            100,
+		"host-32_mem.granted.average":             100,
+		"host-32_mem.shared.average":              100,
+		"host-32_mem.sharedcommon.average":        100,
+		"host-32_mem.swapinRate.average":          100,
+		"host-32_mem.swapoutRate.average":         100,
+		"host-32_mem.usage.average":               100,
+		"host-32_net.bytesRx.average":             100,
+		"host-32_net.bytesTx.average":             100,
+		"host-32_net.droppedRx.summation":         100,
+		"host-32_net.droppedTx.summation":         100,
+		"host-32_net.errorsRx.summation":          100,
+		"host-32_net.errorsTx.summation":          100,
+		"host-32_net.packetsRx.summation":         100,
+		"host-32_net.packetsTx.summation":         100,
+		"host-32_overall.status":                  0,
+		"host-32_sys.uptime.latest":               100,
+		"host-32_triggered_alarms.red":            0,
+		"host-32_triggered_alarms.yellow":         0,
+		"host-39_cpu.usage.average":               100,
+		"host-39_cpu.usagemhz.average":            100,
+		"host-39_disk.maxTotalLatency.latest":     100,
+		"host-39_disk.read.average":               100,
+		"host-39_disk.write.average":              100,
+		"host-39_mem.active.average":              100,
+		"host-39_mem.consumed.average":            100,
+		"host-39_mem.granted.average":             100,
+		"host-39_mem.shared.average":              100,
+		"host-39_mem.sharedcommon.average":        100,
+		"host-39_mem.swapinRate.average":          100,
+		"host-39_mem.swapoutRate.average":         100,
+		"host-39_mem.usage.average":               100,
+		"host-39_net.bytesRx.average":             100,
+		"host-39_net.bytesTx.average":             100,
+		"host-39_net.droppedRx.summation":         100,
+		"host-39_net.droppedTx.summation":         100,
+		"host-39_net.errorsRx.summation":          100,
+		"host-39_net.errorsTx.summation":          100,
+		"host-39_net.packetsRx.summation":         100,
+		"host-39_net.packetsTx.summation":         100,
+		"host-39_overall.status":                  0,
+		"host-39_sys.uptime.latest":               100,
+		"host-39_triggered_alarms.red":            0,
+		"host-39_triggered_alarms.yellow":         0,
+		"host-46_cpu.usage.average":               100,
+		"host-46_cpu.usagemhz.average":            100,
+		"host-46_disk.maxTotalLatency.latest":     100,
+		"host-46_disk.read.average":               100,
+		"host-46_disk.write.average":              100,
+		"host-46_mem.active.average":              100,
+		"host-46_mem.consumed.average":            100,
+		"host-46_mem.granted.average":             100,
+		"host-46_mem.shared.average":              100,
+		"host-46_mem.sharedcommon.average":        100,
+		"host-46_mem.swapinRate.average":          100,
+		"host-46_mem.swapoutRate.average":         100,
+		"host-46_mem.usage.average":               100,
+		"host-46_net.bytesRx.average":             100,
+		"host-46_net.bytesTx.average":             100,
+		"host-46_net.droppedRx.summation":         100,
+		"host-46_net.droppedTx.summation":         100,
+		"host-46_net.errorsRx.summation":          100,
+		"host-46_net.errorsTx.summation":          100,
+		"host-46_net.packetsRx.summation":         100,
+		"host-46_net.packetsTx.summation":         100,
+		"host-46_overall.status":                  0,
+		"host-46_sys.uptime.latest":               100,
+		"host-46_triggered_alarms.red":            0,
+		"host-46_triggered_alarms.yellow":         0,
+		"vm-53_cpu.usage.average":                 200,
+		"vm-53_cpu.usagemhz.average":              200,
+		"vm-53_disk.maxTotalLatency.latest":       200,
+		"vm-53_disk.read.average":                 200,
+		"vm-53_disk.write.average":                200,
+		"vm-53_mem.active.average":                200,
+		"vm-53_mem.consumed.average":              200,
+		"vm-53_mem.granted.average":               200,
+		"vm-53_mem.shared.average":                200,
+		"vm-53_mem.swapinRate.average":            200,
+		"vm-53_mem.swapoutRate.average":           200,
+		"vm-53_mem.swapped.average":               200,
+		"vm-53_mem.usage.average":                 200,
+		"vm-53_net.bytesRx.average":               200,
+		"vm-53_net.bytesTx.average":               200,
+		"vm-53_net.droppedRx.summation":           200,
+		"vm-53_net.droppedTx.summation":           200,
+		"vm-53_net.packetsRx.summation":           200,
+		"vm-53_net.packetsTx.summation":           200,
+		"vm-53_overall.status":                    1,
+		"vm-53_snapshots.count":                   0,
+		"vm-53_snapshots.oldest_age":              0,
+		"vm-53_sys.uptime.latest":                 200,
+		"vm-53_triggered_alarms.red":              0,
+		"vm-53_triggered_alarms.yellow":           0,
+		"vm-56_cpu.usage.average":                 200,
+		"vm-56_cpu.usagemhz.average":              200,
+		"vm-56_disk.maxTotalLatency.latest":       200,
+		"vm-56_disk.read.average":                 200,
+		"vm-56_disk.write.average":                200,
+		"vm-56_mem.active.average":                200,
+		"vm-56_mem.consumed.average":              200,
+		"vm-56_mem.granted.average":               200,
+		"vm-56_mem.shared.average":                200,
+		"vm-56_mem.swapinRate.average":            200,
+		"vm-56_mem.swapoutRate.average":           200,
+		"vm-56_mem.swapped.average":               200,
+		"vm-56_mem.usage.average":                 200,
+		"vm-56_net.bytesRx.average":               200,
+		"vm-56_net.bytesTx.average":               200,
+		"vm-56_net.droppedRx.summation":           200,
+		"vm-56_net.droppedTx.summation":           200,
+		"vm-56_net.packetsRx.summation":           200,
+		"vm-56_net.packetsTx.summation":           200,
+		"vm-56_overall.status":                    1,
+		"vm-56_snapshots.count":                   0,
+		"vm-56_snapshots.oldest_age":              0,
+		"vm-56_sys.uptime.latest":                 200,
+		"vm-56_triggered_alarms.red":              0,
+		"vm-56_triggered_alarms.yellow":           0,
+		"vm-59_cpu.usage.average":                 200,
+		"vm-59_cpu.usagemhz.average":              200,
+		"vm-59_disk.maxTotalLatency.latest":       200,
+		"vm-59_disk.read.average":                 200,
+		"vm-59_disk.write.average":                200,
+		"vm-59_mem.active.average":                200,
+		"vm-59_mem.consumed.average":              200,
+		"vm-59_mem.granted.average":               200,
+		"vm-59_mem.shared.average":                200,
+		"vm-59_mem.swapinRate.average":            200,
+		"vm-59_mem.swapoutRate.average":           200,
+		"vm-59_mem.swapped.average":               200,
+		"vm-59_mem.usage.average":                 200,
+		"vm-59_net.bytesRx.average":               200,
+		"vm-59_net.bytesTx.average":               200,
+		"vm-59_net.droppedRx.summation":           200,
+		"vm-59_net.droppedTx.summation":           200,
+		"vm-59_net.packetsRx.summation":           200,
+		"vm-59_net.packetsTx.summation":           200,
+		"vm-59_overall.status":                    1,
+		"vm-59_snapshots.count":                   0,
+		"vm-59_snapshots.oldest_age":              0,
+		"vm-59_sys.uptime.latest":                 200,
+		"vm-59_triggered_alarms.red":              0,
+		"vm-59_triggered_alarms.yellow":           0,
+		"vm-62_cpu.usage.average":                 200,
+		"vm-62_cpu.usagemhz.average":              200,
+		"vm-62_disk.maxTotalLatency.latest":       200,
+		"vm-62_disk.read.average":                 200,
+		"vm-62_disk.write.average":                200,
+		"vm-62_mem.active.average":                200,
+		"vm-62_mem.consumed.average":              200,
+		"vm-62_mem.granted.average":               200,
+		"vm-62_mem.shared.average":                200,
+		"vm-62_mem.swapinRate.average":            200,
+		"vm-62_mem.swapoutRate.average":           200,
+		"vm-62_mem.swapped.average":               200,
+		"vm-62_mem.usage.average":                 200,
+		"vm-62_net.bytesRx.average":               200,
+		"vm-62_net.bytesTx.average":               200,
+		"vm-62_net.droppedRx.summation":           200,
+		"vm-62_net.droppedTx.summation":           200,
+		"vm-62_net.packetsRx.summation":           200,
+		"vm-62_net.packetsTx.summation":           200,
+		"vm-62_overall.status":                    1,
+		"vm-62_snapshots.count":                   0,
+		"vm-62_snapshots.oldest_age":              0,
+		"vm-62_sys.uptime.latest":                 200,
+		"vm-62_triggered_alarms.red":              0,
+		"vm-62_triggered_alarms.yellow":           0,
+		"hosts_overall_status_gray":               4,
+		"hosts_overall_status_green":              0,
+		"hosts_overall_status_red":                0,
+		"hosts_overall_status_yellow":             0,
+		"vms_overall_status_gray":                 0,
+		"vms_overall_status_green":                4,
+		"vms_overall_status_red":                  0,
+		"vms_overall_status_yellow":               0,
+		"vms_with_snapshots":                      0,
+		"vms_with_snapshots_older_than_3d":        0,
 	}
 
 	collected := vSphere.Collect()
@@ -300,10 +351,146 @@ func TestVSphere_Collect(t *testing.T) {
 	assert.Len(t, vSphere.discoveredHosts, count.Host)
 	assert.Len(t, vSphere.discoveredVMs, count.Machine)
 	assert.Len(t, vSphere.charted, count.Host+count.Machine)
-	assert.Len(t, *vSphere.charts, count.Host*len(hostCharts)+count.Machine*len(vmCharts))
+	assert.Len(t, *vSphere.charts, len(summaryCharts)+count.Cluster*len(clusterCharts)+count.Host*len(hostCharts)+count.Machine*len(vmCharts))
 	ensureCollectedHasAllChartsDimsVarsIDs(t, vSphere, collected)
 }
 
+func TestVSphere_Collect_VMsSnapshots(t *testing.T) {
+	vSphere, _, teardown := prepareVSphereSim(t)
+	defer teardown()
+
+	require.True(t, vSphere.Init())
+	require.True(t, vSphere.Check())
+
+	vSphere.scraper = mockScraper{vSphere.scraper}
+
+	old := vSphere.resources.VMs.Get("vm-53")
+	old.Snapshots, old.OldestSnapshot = 3, time.Now().Add(-time.Hour*24*5)
+	recent := vSphere.resources.VMs.Get("vm-56")
+	recent.Snapshots, recent.OldestSnapshot = 1, time.Now().Add(-time.Hour*2)
+
+	collected := vSphere.Collect()
+
+	expected := map[string]int64{
+		"vm-53_snapshots.count":            3,
+		"vm-53_snapshots.oldest_age":       120,
+		"vm-56_snapshots.count":            1,
+		"vm-56_snapshots.oldest_age":       2,
+		"vm-59_snapshots.count":            0,
+		"vm-59_snapshots.oldest_age":       0,
+		"vms_with_snapshots":               2,
+		"vms_with_snapshots_older_than_3d": 1,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, collected[k], "metric '%s'", k)
+	}
+}
+
+func TestVSphere_Collect_OverallStatusAndTriggeredAlarms(t *testing.T) {
+	vSphere, _, teardown := prepareVSphereSim(t)
+	defer teardown()
+
+	require.True(t, vSphere.Init())
+	require.True(t, vSphere.Check())
+
+	vSphere.scraper = mockScraper{vSphere.scraper}
+
+	host := vSphere.resources.Hosts.Get("host-20")
+	host.OverallStatus, host.Alarms = "red", rs.TriggeredAlarms{Yellow: 1, Red: 2}
+	vm := vSphere.resources.VMs.Get("vm-53")
+	vm.OverallStatus, vm.Alarms = "yellow", rs.TriggeredAlarms{Yellow: 3}
+
+	collected := vSphere.Collect()
+
+	expected := map[string]int64{
+		"host-20_overall.status":          3,
+		"host-20_triggered_alarms.yellow": 1,
+		"host-20_triggered_alarms.red":    2,
+		"vm-53_overall.status":            2,
+		"vm-53_triggered_alarms.yellow":   3,
+		"vm-53_triggered_alarms.red":      0,
+		"hosts_overall_status_green":      0,
+		"hosts_overall_status_yellow":     0,
+		"hosts_overall_status_red":        1,
+		"hosts_overall_status_gray":       3,
+		"vms_overall_status_green":        3,
+		"vms_overall_status_yellow":       1,
+		"vms_overall_status_red":          0,
+		"vms_overall_status_gray":         0,
+	}
+	for k, v := range expected {
+		assert.Equalf(t, v, collected[k], "metric '%s'", k)
+	}
+}
+
+func TestVSphere_Collect_ResourcePools(t *testing.T) {
+	tests := map[string]struct {
+		vmCharts bool
+	}{
+		"vm charts enabled":  {vmCharts: true},
+		"vm charts disabled": {vmCharts: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			vSphere, _, teardown := prepareVSphereSim(t)
+			defer teardown()
+
+			vSphere.VMCharts = test.vmCharts
+			require.True(t, vSphere.Init())
+			require.True(t, vSphere.Check())
+
+			vSphere.scraper = mockScraper{vSphere.scraper}
+
+			pools := vSphere.resources.ResourcePools
+			pools.Put(&rs.ResourcePool{ID: "resgroup-100", Name: "parent"})
+			pools.Put(&rs.ResourcePool{ID: "resgroup-101", Name: "child", ParentID: "resgroup-100"})
+			pools.Put(&rs.ResourcePool{ID: "resgroup-102", Name: "empty"})
+			vSphere.resources.VMs.Get("vm-53").ResourcePoolID = "resgroup-100"
+			vSphere.resources.VMs.Get("vm-56").ResourcePoolID = "resgroup-101"
+			vSphere.resources.VMs.Get("vm-59").ResourcePoolID = "resgroup-101"
+
+			collected := vSphere.Collect()
+
+			expected := map[string]int64{
+				"resgroup-100_cpu.usagemhz.average": 600,
+				"resgroup-100_mem.consumed.average": 600,
+				"resgroup-101_cpu.usagemhz.average": 400,
+				"resgroup-101_mem.consumed.average": 400,
+				"resgroup-102_cpu.usagemhz.average": 0,
+				"resgroup-102_mem.consumed.average": 0,
+			}
+			for k, v := range expected {
+				assert.Equalf(t, v, collected[k], "metric '%s'", k)
+			}
+			for _, id := range []string{"resgroup-100", "resgroup-101", "resgroup-102"} {
+				assert.Truef(t, vSphere.Charts().Has(id+"_cpu_usage"), "chart '%s_cpu_usage'", id)
+			}
+
+			var vmCharts int
+			for _, c := range *vSphere.Charts() {
+				if strings.HasPrefix(c.ID, "vm-") {
+					vmCharts++
+				}
+			}
+			if test.vmCharts {
+				assert.NotZero(t, vmCharts)
+				assert.Contains(t, collected, "vm-53_cpu.usage.average")
+			} else {
+				assert.Zero(t, vmCharts)
+				assert.NotContains(t, collected, "vm-53_cpu.usage.average")
+			}
+			ensureCollectedHasAllChartsDimsVarsIDs(t, vSphere, collected)
+
+			vSphere.resources.ResourcePools = rs.ResourcePools{}
+			vSphere.Collect()
+			for _, id := range []string{"resgroup-100", "resgroup-101", "resgroup-102"} {
+				assert.Truef(t, vSphere.Charts().Get(id+"_cpu_usage").Obsolete, "chart '%s_cpu_usage'", id)
+			}
+		})
+	}
+}
+
 func TestVSphere_Collect_RemoveHostsVMsInRuntime(t *testing.T) {
 	vSphere, _, teardown := prepareVSphereSim(t)
 	defer teardown()
@@ -351,7 +538,8 @@ func TestVSphere_Collect_RemoveHostsVMsInRuntime(t *testing.T) {
 	assert.Len(t, vSphere.charted, 2)
 
 	for _, c := range *vSphere.Charts() {
-		if strings.HasPrefix(c.ID, okHostID) || strings.HasPrefix(c.ID, okVMID) {
+		if summaryCharts.Has(c.ID) || strings.HasPrefix(c.ID, "clustercomputeresource") ||
+			strings.HasPrefix(c.ID, okHostID) || strings.HasPrefix(c.ID, okVMID) {
 			assert.False(t, c.Obsolete)
 		} else {
 			assert.True(t, c.Obsolete)
@@ -379,7 +567,7 @@ func TestVSphere_Collect_Run(t *testing.T) {
 	assert.Len(t, vSphere.discoveredHosts, count.Host)
 	assert.Len(t, vSphere.discoveredVMs, count.Machine)
 	assert.Len(t, vSphere.charted, count.Host+count.Machine)
-	assert.Len(t, *vSphere.charts, count.Host*len(hostCharts)+count.Machine*len(vmCharts))
+	assert.Len(t, *vSphere.charts, len(summaryCharts)+count.Cluster*len(clusterCharts)+count.Host*len(hostCharts)+count.Machine*len(vmCharts))
 }
 
 func TestVSphere_chartIDsHasAllHierarchyData(t *testing.T) {
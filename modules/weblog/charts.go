@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/netdata/go.d.plugin/agent/module"
+	"github.com/netdata/go.d.plugin/pkg/metrics"
 )
 
 type (
@@ -19,6 +20,9 @@ type (
 const (
 	prioReqTotal = module.Priority + iota
 	prioReqExcluded
+	prioReqUnmatched
+	prioLogFileRotations
+	prioLogFileSkippedLines
 	prioReqType
 
 	prioRespCodesClass
@@ -32,13 +36,17 @@ const (
 	prioBandwidth
 
 	prioReqProcTime
+	prioReqProcTimeQuantiles
 	prioRespTimeHist
 	prioUpsRespTime
 	prioUpsRespTimeHist
+	prioReqTimestampLag
 
 	prioUniqIP
 
 	prioReqVhost
+	prioVhostResp4xx
+	prioVhostResp5xx
 	prioReqPort
 	prioReqScheme
 	prioReqMethod
@@ -51,7 +59,7 @@ const (
 	prioReqCustomTimeField     // chart per custom time field, alphabetical order
 	prioReqCustomTimeFieldHist // histogram chart per custom time field
 	prioReqURLPattern
-	prioURLPatternStats // 3 charts per url pattern, alphabetical order
+	prioURLPatternStats // 5 charts per url pattern, alphabetical order
 )
 
 // NOTE: inconsistency with python web_log
@@ -82,6 +90,42 @@ var (
 			{ID: "req_unmatched", Name: "unmatched", Algo: module.Incremental},
 		},
 	}
+	reqUnmatched = Chart{
+		ID:       "unmatched_requests",
+		Title:    "Unmatched Requests By Reason",
+		Units:    "requests/s",
+		Fam:      "requests",
+		Ctx:      "web_log.unmatched_requests",
+		Type:     module.Stacked,
+		Priority: prioReqUnmatched,
+		Dims: Dims{
+			{ID: "req_unmatched_parse_error", Name: "parse_error", Algo: module.Incremental},
+			{ID: "req_unmatched_bad_field", Name: "bad_field", Algo: module.Incremental},
+			{ID: "req_unmatched_missing_fields", Name: "missing_fields", Algo: module.Incremental},
+		},
+	}
+	logFileRotations = Chart{
+		ID:       "log_file_rotations",
+		Title:    "Log File Rotations",
+		Units:    "rotations/s",
+		Fam:      "log file",
+		Ctx:      "web_log.log_file_rotations",
+		Priority: prioLogFileRotations,
+		Dims: Dims{
+			{ID: "log_file_rotations", Name: "rotations", Algo: module.Incremental},
+		},
+	}
+	logFileSkippedLines = Chart{
+		ID:       "log_file_skipped_lines",
+		Title:    "Log File Lines Skipped After Rotation",
+		Units:    "lines/s",
+		Fam:      "log file",
+		Ctx:      "web_log.log_file_skipped_lines",
+		Priority: prioLogFileSkippedLines,
+		Dims: Dims{
+			{ID: "log_file_skipped_lines", Name: "skipped", Algo: module.Incremental},
+		},
+	}
 	// netdata specific grouping
 	reqTypes = Chart{
 		ID:       "requests_by_type",
@@ -206,6 +250,14 @@ var (
 			{ID: "req_proc_time_avg", Name: "avg", Div: 1000},
 		},
 	}
+	reqProcTimeQuantiles = Chart{
+		ID:       "request_processing_time_quantiles",
+		Title:    "Request Processing Time Quantiles",
+		Units:    "milliseconds",
+		Fam:      "timings",
+		Ctx:      "web_log.request_processing_time_quantiles",
+		Priority: prioReqProcTimeQuantiles,
+	}
 	reqProcTimeHist = Chart{
 		ID:       "requests_processing_time_histogram",
 		Title:    "Requests Processing Time Histogram",
@@ -231,6 +283,19 @@ var (
 			{ID: "upstream_resp_time_avg", Name: "avg", Div: 1000},
 		},
 	}
+	reqTimestampLag = Chart{
+		ID:       "request_timestamp_lag",
+		Title:    "Request Timestamp Lag",
+		Units:    "seconds",
+		Fam:      "timings",
+		Ctx:      "web_log.request_timestamp_lag",
+		Priority: prioReqTimestampLag,
+		Dims: Dims{
+			{ID: "req_timestamp_lag_min", Name: "min", Div: 1000},
+			{ID: "req_timestamp_lag_max", Name: "max", Div: 1000},
+			{ID: "req_timestamp_lag_avg", Name: "avg", Div: 1000},
+		},
+	}
 	upsRespTimeHist = Chart{
 		ID:       "upstream_responses_time_histogram",
 		Title:    "Upstream Responses Time Histogram",
@@ -269,6 +334,24 @@ var (
 		Type:     module.Stacked,
 		Priority: prioReqVhost,
 	}
+	vhostResp4xx = Chart{
+		ID:       "vhost_4xx_responses",
+		Title:    "4xx Responses By Vhost",
+		Units:    "responses/s",
+		Fam:      "vhost",
+		Ctx:      "web_log.vhost_4xx_responses",
+		Type:     module.Stacked,
+		Priority: prioVhostResp4xx,
+	}
+	vhostResp5xx = Chart{
+		ID:       "vhost_5xx_responses",
+		Title:    "5xx Responses By Vhost",
+		Units:    "responses/s",
+		Fam:      "vhost",
+		Ctx:      "web_log.vhost_5xx_responses",
+		Type:     module.Stacked,
+		Priority: prioVhostResp5xx,
+	}
 	reqByPort = Chart{
 		ID:       "requests_by_port",
 		Title:    "Requests By Port",
@@ -435,6 +518,14 @@ var (
 			{ID: "url_ptn_%s_req_proc_time_avg", Name: "avg", Div: 1000},
 		},
 	}
+	urlPatternReqProcTimeQuantiles = Chart{
+		ID:       "url_pattern_%s_request_processing_time_quantiles",
+		Title:    "Request Processing Time Quantiles",
+		Units:    "milliseconds",
+		Fam:      "url ptn %s",
+		Ctx:      "web_log.url_pattern_request_processing_time_quantiles",
+		Priority: prioURLPatternStats + 4,
+	}
 )
 
 func newReqProcTimeHistChart(histogram []float64) (*Chart, error) {
@@ -459,6 +550,22 @@ func newReqProcTimeHistChart(histogram []float64) (*Chart, error) {
 	return chart, nil
 }
 
+func newReqProcTimeQuantilesChart(quantiles []float64) (*Chart, error) {
+	chart := reqProcTimeQuantiles.Copy()
+	for _, q := range quantiles {
+		suffix := metrics.QuantileSuffix(q)
+		dim := &Dim{
+			ID:   "req_proc_time_" + suffix,
+			Name: suffix,
+			Div:  1000,
+		}
+		if err := chart.AddDim(dim); err != nil {
+			return nil, err
+		}
+	}
+	return chart, nil
+}
+
 func newUpsRespTimeHistChart(histogram []float64) (*Chart, error) {
 	chart := upsRespTimeHist.Copy()
 	for i, v := range histogram {
@@ -530,6 +637,24 @@ func newURLPatternReqProcTimeChart(name string) *Chart {
 	return chart
 }
 
+func newURLPatternReqProcTimeQuantilesChart(name string, quantiles []float64) (*Chart, error) {
+	chart := urlPatternReqProcTimeQuantiles.Copy()
+	chart.ID = fmt.Sprintf(chart.ID, name)
+	chart.Fam = fmt.Sprintf(chart.Fam, name)
+	for _, q := range quantiles {
+		suffix := metrics.QuantileSuffix(q)
+		dim := &Dim{
+			ID:   fmt.Sprintf("url_ptn_%s_req_proc_time_%s", name, suffix),
+			Name: suffix,
+			Div:  1000,
+		}
+		if err := chart.AddDim(dim); err != nil {
+			return nil, err
+		}
+	}
+	return chart, nil
+}
+
 func newCustomFieldCharts(fields []customField) (Charts, error) {
 	charts := Charts{}
 	for _, f := range fields {
@@ -633,9 +758,12 @@ func (w *WebLog) createCharts(line *logLine) error {
 	charts := &Charts{
 		reqTotal.Copy(),
 		reqExcluded.Copy(),
+		reqUnmatched.Copy(),
+		logFileRotations.Copy(),
+		logFileSkippedLines.Copy(),
 	}
 	if line.hasVhost() {
-		if err := addVhostCharts(charts); err != nil {
+		if err := addVhostCharts(charts, line.hasRespCode()); err != nil {
 			return err
 		}
 	}
@@ -680,7 +808,7 @@ func (w *WebLog) createCharts(line *logLine) error {
 		}
 	}
 	if line.hasReqProcTime() {
-		if err := addReqProcTimeCharts(charts, w.Histogram, w.URLPatterns); err != nil {
+		if err := addReqProcTimeCharts(charts, w.Histogram, w.Quantiles, w.URLPatternQuantiles, w.URLPatterns); err != nil {
 			return err
 		}
 	}
@@ -689,6 +817,11 @@ func (w *WebLog) createCharts(line *logLine) error {
 			return err
 		}
 	}
+	if line.hasTimestamp() {
+		if err := charts.Add(reqTimestampLag.Copy()); err != nil {
+			return err
+		}
+	}
 	if line.hasCustomFields() {
 
 		if len(w.CustomFields) > 0 {
@@ -708,8 +841,14 @@ func (w *WebLog) createCharts(line *logLine) error {
 	return nil
 }
 
-func addVhostCharts(charts *Charts) error {
-	return charts.Add(reqByVhost.Copy())
+func addVhostCharts(charts *Charts, respCode bool) error {
+	if err := charts.Add(reqByVhost.Copy()); err != nil {
+		return err
+	}
+	if !respCode {
+		return nil
+	}
+	return charts.Add(vhostResp4xx.Copy(), vhostResp5xx.Copy())
 }
 
 func addPortCharts(charts *Charts) error {
@@ -798,15 +937,34 @@ func addBandwidthCharts(charts *Charts, patterns []userPattern) error {
 	return nil
 }
 
-func addReqProcTimeCharts(charts *Charts, histogram []float64, patterns []userPattern) error {
+func addReqProcTimeCharts(charts *Charts, histogram, quantiles, ptnQuantiles []float64, patterns []userPattern) error {
 	if err := charts.Add(reqProcTime.Copy()); err != nil {
 		return err
 	}
+	if len(quantiles) > 0 {
+		chart, err := newReqProcTimeQuantilesChart(quantiles)
+		if err != nil {
+			return err
+		}
+		if err := charts.Add(chart); err != nil {
+			return err
+		}
+	}
 	for _, p := range patterns {
 		chart := newURLPatternReqProcTimeChart(p.Name)
 		if err := charts.Add(chart); err != nil {
 			return err
 		}
+		if len(ptnQuantiles) == 0 {
+			continue
+		}
+		chart, err := newURLPatternReqProcTimeQuantilesChart(p.Name, ptnQuantiles)
+		if err != nil {
+			return err
+		}
+		if err := charts.Add(chart); err != nil {
+			return err
+		}
 	}
 	if len(histogram) == 0 {
 		return nil
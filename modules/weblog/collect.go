@@ -3,11 +3,13 @@
 package weblog
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/logs"
 	"github.com/netdata/go.d.plugin/pkg/stm"
@@ -34,10 +36,12 @@ func (w WebLog) logPanicStackIfAny() {
 func (w *WebLog) collect() (map[string]int64, error) {
 	defer w.logPanicStackIfAny()
 	w.mx.reset()
+	w.now = time.Now()
 
 	var mx map[string]int64
 
 	n, err := w.collectLogLines()
+	w.collectLogFile()
 
 	if n > 0 || err == nil {
 		mx = stm.ToMap(w.mx)
@@ -63,18 +67,26 @@ func (w *WebLog) collectLogLines() (int, error) {
 				w.Infof("unmatched line: %v (parser: %s)", err, w.parser.Info())
 				logOnce = false
 			}
-			w.collectUnmatched()
+			w.collectUnmatched(err)
 			continue
 		}
 		n++
 		if w.line.empty() {
-			w.collectUnmatched()
+			w.collectUnmatched(errEmptyLine)
 		} else {
 			w.collectLogLine()
 		}
 	}
 }
 
+func (w *WebLog) collectLogFile() {
+	if w.file == nil {
+		return
+	}
+	w.mx.LogFileRotations.Set(float64(w.file.Rotations()))
+	w.mx.LogFileSkippedLines.Set(float64(w.file.SkippedLines()))
+}
+
 func (w *WebLog) collectLogLine() {
 	w.mx.Requests.Inc()
 	w.collectVhost()
@@ -89,25 +101,60 @@ func (w *WebLog) collectLogLine() {
 	w.collectRespSize()
 	w.collectReqProcTime()
 	w.collectUpsRespTime()
+	w.collectReqTimestamp()
 	w.collectSSLProto()
 	w.collectSSLCipherSuite()
 	w.collectCustomFields()
 }
 
-func (w *WebLog) collectUnmatched() {
+func (w *WebLog) collectUnmatched(err error) {
 	w.mx.Requests.Inc()
 	w.mx.ReqUnmatched.Inc()
+	switch {
+	case errors.Is(err, errEmptyLine):
+		// the line is parsed, but has no known fields (e.g. JSON mapping doesn't match)
+		w.mx.ReqUnmatchedMissingFields.Inc()
+	case isBadFieldError(err):
+		w.mx.ReqUnmatchedBadField.Inc()
+	default:
+		w.mx.ReqUnmatchedParseError.Inc()
+	}
 }
 
 func (w *WebLog) collectVhost() {
 	if !w.line.hasVhost() {
 		return
 	}
-	c, ok := w.mx.ReqVhost.GetP(w.line.vhost)
+	vhost := w.line.vhost
+	if w.vhostFilter != nil && !w.vhostFilter.MatchString(vhost) {
+		return
+	}
+	c, ok := w.mx.ReqVhost[vhost]
 	if !ok {
-		w.addDimToVhostChart(w.line.vhost)
+		if w.MaxVhosts > 0 && len(w.mx.ReqVhost) >= w.MaxVhosts {
+			if !w.vhostsLimitHit {
+				w.vhostsLimitHit = true
+				w.Warningf("vhosts limit (%d) is reached, new vhosts are not collected", w.MaxVhosts)
+			}
+			return
+		}
+		c = w.mx.ReqVhost.Get(vhost)
+		w.addDimToVhostChart(vhost)
 	}
 	c.Inc()
+
+	if !w.line.hasRespCode() {
+		return
+	}
+	if _, ok := w.mx.VhostResp4xx[vhost]; !ok {
+		w.addDimToVhostRespCodesCharts(vhost)
+	}
+	switch code := w.line.respCode; {
+	case code >= 400 && code < 500:
+		w.mx.VhostResp4xx.Get(vhost).Inc()
+	case code >= 500 && code < 600:
+		w.mx.VhostResp5xx.Get(vhost).Inc()
+	}
 }
 
 func (w *WebLog) collectPort() {
@@ -258,6 +305,18 @@ func (w *WebLog) collectUpsRespTime() {
 	w.mx.UpsRespTimeHist.Observe(w.line.upsRespTime)
 }
 
+func (w *WebLog) collectReqTimestamp() {
+	if !w.line.hasTimestamp() {
+		return
+	}
+	// the lag is in milliseconds, it is negative if the log timestamps are ahead of the local clock
+	lag := w.now.Sub(w.line.timestamp)
+	if lag < 0 {
+		lag = 0
+	}
+	w.mx.ReqTimestampLag.Observe(float64(lag.Milliseconds()))
+}
+
 func (w *WebLog) collectSSLProto() {
 	if !w.line.hasSSLProto() {
 		return
@@ -370,6 +429,32 @@ func (w *WebLog) addDimToVhostChart(vhost string) {
 	chart.MarkNotCreated()
 }
 
+func (w *WebLog) addDimToVhostRespCodesCharts(vhost string) {
+	_, _ = w.mx.VhostResp4xx.GetP(vhost)
+	_, _ = w.mx.VhostResp5xx.GetP(vhost)
+
+	for _, v := range []struct{ chartID, dimID string }{
+		{chartID: vhostResp4xx.ID, dimID: "vhost_resp_4xx_" + vhost},
+		{chartID: vhostResp5xx.ID, dimID: "vhost_resp_5xx_" + vhost},
+	} {
+		chart := w.Charts().Get(v.chartID)
+		if chart == nil {
+			w.Warningf("add dimension: no '%s' chart", v.chartID)
+			continue
+		}
+		dim := &Dim{
+			ID:   v.dimID,
+			Name: vhost,
+			Algo: module.Incremental,
+		}
+		if err := chart.AddDim(dim); err != nil {
+			w.Warning(err)
+			continue
+		}
+		chart.MarkNotCreated()
+	}
+}
+
 func (w *WebLog) addDimToPortChart(port string) {
 	chart := w.Charts().Get(reqByPort.ID)
 	if chart == nil {
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/netdata/go.d.plugin/pkg/logs"
 	"github.com/netdata/go.d.plugin/pkg/matcher"
@@ -90,8 +91,46 @@ func (w *WebLog) createCustomTimeFields() error {
 	return nil
 }
 
+func (w *WebLog) createVhostFilter() error {
+	if w.VhostFilter.Empty() {
+		return nil
+	}
+	m, err := w.VhostFilter.Parse()
+	if err != nil {
+		return fmt.Errorf("create vhost filter: %v", err)
+	}
+	w.vhostFilter = m
+	return nil
+}
+
+func (w *WebLog) validateTimeLayouts() error {
+	for i, layout := range w.TimeLayouts {
+		if strings.TrimSpace(layout) == "" {
+			return fmt.Errorf("time layout %d is empty", i+1)
+		}
+	}
+	return nil
+}
+
+func (w *WebLog) validateQuantiles() error {
+	for _, q := range w.Quantiles {
+		if !(q > 0 && q <= 1) {
+			return fmt.Errorf("invalid request processing time quantile '%v': must be in the (0, 1] range", q)
+		}
+	}
+	for _, q := range w.URLPatternQuantiles {
+		if !(q > 0 && q <= 1) {
+			return fmt.Errorf("invalid url pattern request processing time quantile '%v': must be in the (0, 1] range", q)
+		}
+	}
+	return nil
+}
+
 func (w *WebLog) createLogLine() {
 	w.line = newEmptyLogLine()
+	if len(w.TimeLayouts) > 0 {
+		w.line.timeLayouts = w.TimeLayouts
+	}
 	for v := range w.customFields {
 		w.line.custom.fields[v] = struct{}{}
 	}
@@ -108,6 +147,7 @@ func (w *WebLog) createLogReader() error {
 		return fmt.Errorf("creating log reader: %v", err)
 	}
 	w.Debugf("created log reader, current file '%s'", reader.CurrentFilename())
+	reader.SetReplayRotated(w.ReplayRotated)
 	w.file = reader
 	return nil
 }
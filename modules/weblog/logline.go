@@ -5,9 +5,11 @@ package weblog
 import (
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // TODO: it is not clear how to handle "-", current handling is not good
@@ -65,10 +67,40 @@ var (
 	errBadUpsRespTime    = errors.New("bad upstream resp time")
 	errBadSSLProto       = errors.New("bad ssl protocol")
 	errBadSSLCipherSuite = errors.New("bad ssl cipher suite")
+
+	badFieldErrors = []error{
+		errBadVhost,
+		errBadVhostPort,
+		errBadPort,
+		errBadReqScheme,
+		errBadReqClient,
+		errBadRequest,
+		errBadReqMethod,
+		errBadReqURL,
+		errBadReqProto,
+		errBadReqSize,
+		errBadRespCode,
+		errBadRespSize,
+		errBadReqProcTime,
+		errBadUpsRespTime,
+		errBadSSLProto,
+		errBadSSLCipherSuite,
+	}
 )
 
+// isBadFieldError reports whether the error is caused by a known field invalid value.
+func isBadFieldError(err error) bool {
+	for _, e := range badFieldErrors {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
 func newEmptyLogLine() *logLine {
 	var l logLine
+	l.timeLayouts = defaultTimeLayouts
 	l.custom.fields = make(map[string]struct{})
 	l.custom.values = make([]customValue, 0, 20)
 	l.reset()
@@ -79,6 +111,8 @@ type (
 	logLine struct {
 		web
 		custom custom
+		// timeLayouts are used to parse the request timestamp
+		timeLayouts []string
 	}
 	web struct {
 		vhost          string
@@ -95,6 +129,7 @@ type (
 		upsRespTime    float64
 		sslProto       string
 		sslCipherSuite string
+		timestamp      time.Time
 	}
 	custom struct {
 		fields map[string]struct{}
@@ -112,7 +147,7 @@ func (l *logLine) Assign(field string, value string) (err error) {
 	}
 
 	switch field {
-	case "host", "http_host", "v":
+	case "host", "http_host", "vhost", "v":
 		err = l.assignVhost(value)
 	case "server_port", "p":
 		err = l.assignPort(value)
@@ -144,6 +179,13 @@ func (l *logLine) Assign(field string, value string) (err error) {
 		err = l.assignSSLProto(value)
 	case "ssl_cipher":
 		err = l.assignSSLCipherSuite(value)
+	case "time_local", "time_iso8601", "msec", "time", "t":
+		// a custom field with the same name takes precedence
+		if _, ok := l.custom.fields[field]; ok {
+			err = l.assignCustom(field, value)
+		} else {
+			l.assignTimestamp(value)
+		}
 	default:
 		err = l.assignCustom(field, value)
 	}
@@ -326,6 +368,18 @@ func (l *logLine) assignReqProcTime(time string) error {
 	return nil
 }
 
+// assignTimestamp doesn't fail the line, the timestamp is not assigned if it doesn't match the time layouts.
+func (l *logLine) assignTimestamp(ts string) {
+	if ts == hyphen {
+		return
+	}
+	// apache %t and nginx CLF $time_local are in square brackets
+	ts = strings.TrimSuffix(strings.TrimPrefix(ts, "["), "]")
+	if v, ok := parseTimestamp(ts, l.timeLayouts); ok {
+		l.timestamp = v
+	}
+}
+
 func isUpstreamTimeSeparator(r rune) bool { return r == ',' || r == ':' }
 
 func (l *logLine) assignUpsRespTime(time string) error {
@@ -451,6 +505,7 @@ func (l logLine) hasReqProcTime() bool        { return !isEmptyNumber(int(l.reqP
 func (l logLine) hasUpsRespTime() bool        { return !isEmptyNumber(int(l.upsRespTime)) }
 func (l logLine) hasSSLProto() bool           { return !isEmptyString(l.sslProto) }
 func (l logLine) hasSSLCipherSuite() bool     { return !isEmptyString(l.sslCipherSuite) }
+func (l logLine) hasTimestamp() bool          { return !l.timestamp.IsZero() }
 func (l logLine) isVhostValid() bool          { return reVhost.MatchString(l.vhost) }
 func (l logLine) isPortValid() bool           { return isPortValid(l.port) }
 func (l logLine) isSchemeValid() bool         { return isSchemeValid(l.reqScheme) }
@@ -607,6 +662,71 @@ func isSSLProtoValid(proto string) bool {
 	return false
 }
 
+const (
+	timeLayoutUnix   = "unix"
+	timeLayoutUnixMs = "unix_ms"
+	timeLayoutUnixUs = "unix_us"
+)
+
+// defaultTimeLayouts are nginx $time_local (apache %t), nginx $time_iso8601 and nginx $msec.
+var defaultTimeLayouts = []string{
+	"02/Jan/2006:15:04:05 -0700",
+	time.RFC3339Nano,
+	timeLayoutUnix,
+}
+
+// parseTimestamp parses the timestamp using the first matching layout.
+// A layout is either a Go time layout or one of the "unix", "unix_ms", "unix_us" keywords,
+// the epoch values can have a fractional part.
+func parseTimestamp(ts string, layouts []string) (time.Time, bool) {
+	for _, layout := range layouts {
+		var v time.Time
+		var ok bool
+		switch layout {
+		case timeLayoutUnix:
+			v, ok = parseEpoch(ts, 1e9)
+		case timeLayoutUnixMs:
+			v, ok = parseEpoch(ts, 1e6)
+		case timeLayoutUnixUs:
+			v, ok = parseEpoch(ts, 1e3)
+		default:
+			var err error
+			v, err = time.Parse(layout, ts)
+			ok = err == nil
+		}
+		if ok {
+			return v, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseEpoch parses the epoch time, unit is the number of nanoseconds in the time unit.
+// The integer and the fractional parts are parsed separately to avoid float rounding errors.
+func parseEpoch(ts string, unit int64) (time.Time, bool) {
+	intPart, fracPart, _ := strings.Cut(ts, ".")
+	v, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || v <= 0 || v > math.MaxInt64/unit {
+		return time.Time{}, false
+	}
+	nsec := v * unit
+	if fracPart == "" {
+		return time.Unix(0, nsec), true
+	}
+	if len(fracPart) > 9 {
+		fracPart = fracPart[:9]
+	}
+	frac, err := strconv.ParseUint(fracPart, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	div := int64(1)
+	for range fracPart {
+		div *= 10
+	}
+	return time.Unix(0, nsec+int64(frac)*unit/div), true
+}
+
 func timeMultiplier(time string) float64 {
 	// TODO: Change code to detect and modify properly IIS time (in milliseconds)
 	// Convert to microseconds:
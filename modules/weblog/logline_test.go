@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,6 +35,7 @@ func TestLogLine_Assign(t *testing.T) {
 			fields: []string{
 				"host",
 				"http_host",
+				"vhost",
 				"v",
 			},
 			cases: []subTest{
@@ -329,6 +331,26 @@ func TestLogLine_Assign(t *testing.T) {
 				{input: "invalid", wantLine: emptyLogLine, wantErr: errBadSSLCipherSuite},
 			},
 		},
+		{
+			name: "Timestamp",
+			fields: []string{
+				"time_local",
+				"time_iso8601",
+				"msec",
+				"time",
+				"t",
+			},
+			cases: []subTest{
+				{input: "[10/Oct/2000:13:55:36 -0700]", wantLine: logLine{web: web{timestamp: mustParseTime(defaultTimeLayouts[0], "10/Oct/2000:13:55:36 -0700")}}},
+				{input: "10/Oct/2000:13:55:36 -0700", wantLine: logLine{web: web{timestamp: mustParseTime(defaultTimeLayouts[0], "10/Oct/2000:13:55:36 -0700")}}},
+				{input: "2022-06-21T09:14:25.123456789Z", wantLine: logLine{web: web{timestamp: mustParseTime(time.RFC3339Nano, "2022-06-21T09:14:25.123456789Z")}}},
+				{input: "1655802865.123", wantLine: logLine{web: web{timestamp: time.UnixMilli(1655802865123)}}},
+				{input: emptyStr, wantLine: emptyLogLine},
+				{input: hyphen, wantLine: emptyLogLine},
+				{input: "0", wantLine: emptyLogLine},
+				{input: "invalid", wantLine: emptyLogLine},
+			},
+		},
 		{
 			name: "Custom Fields",
 			fields: []string{
@@ -387,6 +409,123 @@ func TestLogLine_Assign(t *testing.T) {
 	}
 }
 
+func TestLogLine_Assign_TimestampCustomField(t *testing.T) {
+	for _, field := range []string{"time", "t"} {
+		t.Run(field, func(t *testing.T) {
+			line := newEmptyLogLine()
+			line.custom.fields = map[string]struct{}{field: {}}
+
+			require.NoError(t, line.Assign(field, "1655802865.123"))
+
+			assert.False(t, line.hasTimestamp())
+			assert.Equal(t, []customValue{{name: field, value: "1655802865.123"}}, line.custom.values)
+		})
+	}
+}
+
+func Test_parseTimestamp(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		layouts []string
+		want    time.Time
+		wantOK  bool
+	}{
+		"CLF with negative offset": {
+			input:   "10/Oct/2000:13:55:36 -0700",
+			layouts: defaultTimeLayouts,
+			want:    time.Date(2000, 10, 10, 20, 55, 36, 0, time.UTC),
+			wantOK:  true,
+		},
+		"CLF with positive offset": {
+			input:   "10/Oct/2000:13:55:36 +0300",
+			layouts: defaultTimeLayouts,
+			want:    time.Date(2000, 10, 10, 10, 55, 36, 0, time.UTC),
+			wantOK:  true,
+		},
+		"ISO8601 UTC with nanoseconds": {
+			input:   "2022-06-21T09:14:25.123456789Z",
+			layouts: defaultTimeLayouts,
+			want:    time.Date(2022, 6, 21, 9, 14, 25, 123456789, time.UTC),
+			wantOK:  true,
+		},
+		"ISO8601 with offset": {
+			input:   "2022-06-21T11:14:25.5+02:00",
+			layouts: defaultTimeLayouts,
+			want:    time.Date(2022, 6, 21, 9, 14, 25, 5e8, time.UTC),
+			wantOK:  true,
+		},
+		"ISO8601 without fraction": {
+			input:   "2022-06-21T09:14:25Z",
+			layouts: defaultTimeLayouts,
+			want:    time.Date(2022, 6, 21, 9, 14, 25, 0, time.UTC),
+			wantOK:  true,
+		},
+		"unix with milliseconds (nginx $msec)": {
+			input:   "1655802865.123",
+			layouts: defaultTimeLayouts,
+			want:    time.UnixMilli(1655802865123),
+			wantOK:  true,
+		},
+		"unix_ms": {
+			input:   "1655802865123",
+			layouts: []string{"unix_ms"},
+			want:    time.UnixMilli(1655802865123),
+			wantOK:  true,
+		},
+		"unix_us": {
+			input:   "1655802865123456",
+			layouts: []string{"unix_us"},
+			want:    time.UnixMicro(1655802865123456),
+			wantOK:  true,
+		},
+		"unix_ms is not unix": {
+			input:   "1655802865123",
+			layouts: defaultTimeLayouts,
+		},
+		"first matching layout": {
+			input:   "1655802865123",
+			layouts: []string{"unix_ms", "unix"},
+			want:    time.UnixMilli(1655802865123),
+			wantOK:  true,
+		},
+		"HAProxy with milliseconds": {
+			input:   "21/Jun/2022:09:14:25.123",
+			layouts: []string{"02/Jan/2006:15:04:05.000"},
+			want:    time.Date(2022, 6, 21, 9, 14, 25, 123e6, time.UTC),
+			wantOK:  true,
+		},
+		"Go layout with offset": {
+			input:   "2022-06-21 12:14:25.123 +03:00",
+			layouts: []string{"2006-01-02 15:04:05.000 -07:00"},
+			want:    time.Date(2022, 6, 21, 9, 14, 25, 123e6, time.UTC),
+			wantOK:  true,
+		},
+		"not matching Go layout": {
+			input:   "1655802865",
+			layouts: []string{time.RFC3339},
+		},
+		"negative epoch": {
+			input:   "-1655802865",
+			layouts: []string{"unix"},
+		},
+		"garbage": {
+			input:   "invalid",
+			layouts: defaultTimeLayouts,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, ok := parseTimestamp(test.input, test.layouts)
+
+			require.Equal(t, test.wantOK, ok)
+			if ok {
+				assert.Truef(t, test.want.Equal(v), "want '%s', got '%s'", test.want, v)
+			}
+		})
+	}
+}
+
 func TestLogLine_verify(t *testing.T) {
 	type subTest struct {
 		line    logLine
@@ -618,7 +757,7 @@ func prepareLogLine(field string, template logLine) logLine {
 	line.reset()
 
 	switch field {
-	case "host", "http_host", "v":
+	case "host", "http_host", "vhost", "v":
 		line.vhost = template.vhost
 	case "server_port", "p":
 		line.port = template.port
@@ -653,12 +792,22 @@ func prepareLogLine(field string, template logLine) logLine {
 		line.sslProto = template.sslProto
 	case "ssl_cipher":
 		line.sslCipherSuite = template.sslCipherSuite
+	case "time_local", "time_iso8601", "msec", "time", "t":
+		line.timestamp = template.timestamp
 	default:
 		line.custom.values = template.custom.values
 	}
 	return *line
 }
 
+func mustParseTime(layout, value string) time.Time {
+	v, err := time.Parse(layout, value)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 func newEmptyLogLineWithFields() *logLine {
 	l := newEmptyLogLine()
 	l.custom.fields = map[string]struct{}{"custom": {}}
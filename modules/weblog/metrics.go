@@ -7,11 +7,23 @@ import (
 )
 
 func newWebLogSummary() metrics.Summary {
-	return &weblogSummary{metrics.NewSummary()}
+	return &weblogSummary{Summary: metrics.NewSummary()}
+}
+
+// urlPatternReservoirSize is the number of observations a URL pattern quantiles summary keeps.
+// It is smaller than the default one, because there is a summary per pattern.
+const urlPatternReservoirSize = 256
+
+func newWebLogSummaryWithQuantiles(quantiles []float64) metrics.Summary {
+	if len(quantiles) == 0 {
+		return newWebLogSummary()
+	}
+	return &weblogSummary{Summary: metrics.NewSummaryWithQuantiles(quantiles), quantiles: quantiles}
 }
 
 type weblogSummary struct {
 	metrics.Summary
+	quantiles []float64
 }
 
 // WriteTo redefines metrics.Summary.WriteTo
@@ -22,6 +34,9 @@ func (s weblogSummary) WriteTo(rv map[string]int64, key string, mul, div int) {
 		rv[key+"_min"] = 0
 		rv[key+"_max"] = 0
 		rv[key+"_avg"] = 0
+		for _, q := range s.quantiles {
+			rv[key+"_"+metrics.QuantileSuffix(q)] = 0
+		}
 	}
 }
 
@@ -29,6 +44,13 @@ type (
 	metricsData struct {
 		Requests     metrics.Counter `stm:"requests"`
 		ReqUnmatched metrics.Counter `stm:"req_unmatched"`
+		// unmatched by reason
+		ReqUnmatchedParseError    metrics.Counter `stm:"req_unmatched_parse_error"`
+		ReqUnmatchedBadField      metrics.Counter `stm:"req_unmatched_bad_field"`
+		ReqUnmatchedMissingFields metrics.Counter `stm:"req_unmatched_missing_fields"`
+
+		LogFileRotations    metrics.Gauge `stm:"log_file_rotations"`
+		LogFileSkippedLines metrics.Gauge `stm:"log_file_skipped_lines"`
 
 		RespCode metrics.CounterVec `stm:"resp_code"`
 		Resp1xx  metrics.Counter    `stm:"resp_1xx"`
@@ -50,8 +72,11 @@ type (
 		ReqProcTimeHist metrics.Histogram     `stm:"req_proc_time_hist"`
 		UpsRespTime     metrics.Summary       `stm:"upstream_resp_time"`
 		UpsRespTimeHist metrics.Histogram     `stm:"upstream_resp_time_hist"`
+		ReqTimestampLag metrics.Summary       `stm:"req_timestamp_lag"`
 
 		ReqVhost          metrics.CounterVec `stm:"req_vhost"`
+		VhostResp4xx      metrics.CounterVec `stm:"vhost_resp_4xx"`
+		VhostResp5xx      metrics.CounterVec `stm:"vhost_resp_5xx"`
 		ReqPort           metrics.CounterVec `stm:"req_port"`
 		ReqMethod         metrics.CounterVec `stm:"req_method"`
 		ReqURLPattern     metrics.CounterVec `stm:"req_url_ptn"`
@@ -84,21 +109,24 @@ type (
 func newMetricsData(config Config) *metricsData {
 	return &metricsData{
 		ReqVhost:           metrics.NewCounterVec(),
+		VhostResp4xx:       metrics.NewCounterVec(),
+		VhostResp5xx:       metrics.NewCounterVec(),
 		ReqPort:            metrics.NewCounterVec(),
 		ReqMethod:          metrics.NewCounterVec(),
 		ReqVersion:         metrics.NewCounterVec(),
 		RespCode:           metrics.NewCounterVec(),
 		ReqSSLProto:        metrics.NewCounterVec(),
 		ReqSSLCipherSuite:  metrics.NewCounterVec(),
-		ReqProcTime:        newWebLogSummary(),
+		ReqProcTime:        newWebLogSummaryWithQuantiles(config.Quantiles),
 		ReqProcTimeHist:    metrics.NewHistogram(convHistOptionsToMicroseconds(config.Histogram)),
 		UpsRespTime:        newWebLogSummary(),
+		ReqTimestampLag:    newWebLogSummary(),
 		UpsRespTimeHist:    metrics.NewHistogram(convHistOptionsToMicroseconds(config.Histogram)),
 		UniqueIPv4:         metrics.NewUniqueCounter(true),
 		UniqueIPv6:         metrics.NewUniqueCounter(true),
 		ReqURLPattern:      newCounterVecFromPatterns(config.URLPatterns),
 		ReqCustomField:     newReqCustomField(config.CustomFields),
-		URLPatternStats:    newURLPatternStats(config.URLPatterns),
+		URLPatternStats:    newURLPatternStats(config.URLPatterns, config.URLPatternQuantiles),
 		ReqCustomTimeField: newReqCustomTimeField(config.CustomTimeFields),
 	}
 }
@@ -108,6 +136,7 @@ func (m *metricsData) reset() {
 	m.UniqueIPv6.Reset()
 	m.ReqProcTime.Reset()
 	m.UpsRespTime.Reset()
+	m.ReqTimestampLag.Reset()
 	for _, v := range m.URLPatternStats {
 		v.ReqProcTime.Reset()
 	}
@@ -124,18 +153,27 @@ func newCounterVecFromPatterns(patterns []userPattern) metrics.CounterVec {
 	return c
 }
 
-func newURLPatternStats(patterns []userPattern) map[string]*patternMetrics {
+func newURLPatternStats(patterns []userPattern, quantiles []float64) map[string]*patternMetrics {
 	stats := make(map[string]*patternMetrics)
 	for _, p := range patterns {
 		stats[p.Name] = &patternMetrics{
 			RespCode:    metrics.NewCounterVec(),
 			ReqMethod:   metrics.NewCounterVec(),
-			ReqProcTime: newWebLogSummary(),
+			ReqProcTime: newURLPatternSummary(quantiles),
 		}
 	}
 	return stats
 }
 
+// newURLPatternSummary doesn't zero the quantiles if there are no requests,
+// a pattern without traffic has no quantiles values (gaps on the chart).
+func newURLPatternSummary(quantiles []float64) metrics.Summary {
+	if len(quantiles) == 0 {
+		return newWebLogSummary()
+	}
+	return &weblogSummary{Summary: metrics.NewSummaryWithQuantilesSize(quantiles, urlPatternReservoirSize)}
+}
+
 func newReqCustomField(fields []customField) map[string]metrics.CounterVec {
 	cf := make(map[string]metrics.CounterVec)
 	for _, f := range fields {
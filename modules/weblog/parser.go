@@ -92,6 +92,9 @@ func (w *WebLog) newParser(record []byte) (logs.Parser, error) {
 		w.Debugf("config: %+v", w.Parser.RegExp)
 	case logs.TypeJSON:
 		w.Debugf("config: %+v", w.Parser.JSON)
+	case logs.TypeLogfmt:
+		w.Parser.Logfmt.Mapping = logfmtMapping(w.Parser.Logfmt.Mapping)
+		w.Debugf("config: %+v", w.Parser.Logfmt)
 	}
 	return logs.NewParser(w.Parser, w.file)
 }
@@ -139,9 +142,34 @@ func (w *WebLog) guessCSVParser(record []byte) (logs.Parser, error) {
 	return nil, errors.New("cannot auto-detect log format, use custom log format")
 }
 
+// defaultLogfmtMapping maps the Heroku router (https://devcenter.heroku.com/articles/http-routing#heroku-router-log-format)
+// keys to the web_log field names. The keys that are the same ("host", "status") are not listed.
+var defaultLogfmtMapping = map[string]string{
+	"method":   "request_method",
+	"path":     "request_uri",
+	"fwd":      "remote_addr",
+	"protocol": "scheme",
+	"bytes":    "bytes_sent",
+	"service":  "request_time",
+}
+
+// logfmtMapping returns the default logfmt mapping merged with the user mapping (the user mapping takes precedence).
+func logfmtMapping(mapping map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaultLogfmtMapping)+len(mapping))
+	for k, v := range defaultLogfmtMapping {
+		merged[k] = v
+	}
+	for k, v := range mapping {
+		merged[k] = v
+	}
+	return merged
+}
+
 func checkCSVFormatField(field string) (newName string, offset int, valid bool) {
+	// CLF time is 2 tokens after csv parse ("[22/Mar/2009:09:30:31" and "+0100]"),
+	// the parser joins them back into one field
 	if isTimeField(field) {
-		return "", 1, false
+		return "time_local", 1, true
 	}
 	if !isFieldValid(field) {
 		return "", 0, false
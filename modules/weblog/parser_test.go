@@ -202,6 +202,74 @@ func TestWebLog_guessCSVParser(t *testing.T) {
 	}
 }
 
+func TestWebLog_newParser_Logfmt(t *testing.T) {
+	tests := map[string]struct {
+		mapping  map[string]string
+		input    string
+		wantLine web
+	}{
+		"Heroku router with default mapping": {
+			input: `at=info method=GET path="/users?page=2" host=myapp.herokuapp.com fwd="204.204.204.204" dyno=web.1 connect=1ms service=18ms status=200 bytes=13 protocol=https`,
+			wantLine: web{
+				vhost:          "myapp.herokuapp.com",
+				port:           emptyString,
+				reqScheme:      "https",
+				reqClient:      "204.204.204.204",
+				reqMethod:      "GET",
+				reqURL:         "/users?page=2",
+				reqProto:       emptyString,
+				reqSize:        emptyNumber,
+				respCode:       200,
+				respSize:       13,
+				reqProcTime:    18000,
+				upsRespTime:    emptyNumber,
+				sslProto:       emptyString,
+				sslCipherSuite: emptyString,
+			},
+		},
+		"user mapping takes precedence": {
+			mapping: map[string]string{
+				"path": "path",
+				"uri":  "request_uri",
+			},
+			input: `method=POST path=/ignored uri=/upload status=201 service=1.5s`,
+			wantLine: web{
+				vhost:          emptyString,
+				port:           emptyString,
+				reqScheme:      emptyString,
+				reqClient:      emptyString,
+				reqMethod:      "POST",
+				reqURL:         "/upload",
+				reqProto:       emptyString,
+				reqSize:        emptyNumber,
+				respCode:       201,
+				respSize:       emptyNumber,
+				reqProcTime:    1500000,
+				upsRespTime:    emptyNumber,
+				sslProto:       emptyString,
+				sslCipherSuite: emptyString,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			weblog := prepareWebLog()
+			weblog.Parser.LogType = logs.TypeLogfmt
+			weblog.Parser.Logfmt.Mapping = test.mapping
+
+			p, err := weblog.newParser([]byte(test.input))
+			require.NoError(t, err)
+			require.IsType(t, (*logs.LogfmtParser)(nil), p)
+
+			line := newEmptyLogLine()
+			require.NoError(t, p.Parse([]byte(test.input), line))
+			require.NoError(t, line.verify())
+			assert.Equal(t, test.wantLine, line.web)
+		})
+	}
+}
+
 func prepareWebLog() *WebLog {
 	cfg := logs.ParserConfig{
 		LogType: typeAuto,
@@ -3,7 +3,10 @@
 package weblog
 
 import (
+	"time"
+
 	"github.com/netdata/go.d.plugin/pkg/logs"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 )
@@ -31,12 +34,15 @@ func New() *WebLog {
 		},
 		RegExp: logs.RegExpConfig{},
 		JSON:   logs.JSONConfig{},
+		Logfmt: logs.LogfmtConfig{},
 	}
 	return &WebLog{
 		Config: Config{
-			ExcludePath:    "*.gz",
-			GroupRespCodes: true,
-			Parser:         cfg,
+			ExcludePath:         "*.gz",
+			GroupRespCodes:      true,
+			URLPatternQuantiles: []float64{0.95, 0.99},
+			ReplayRotated:       true,
+			Parser:              cfg,
 		},
 	}
 }
@@ -56,14 +62,20 @@ type (
 	}
 
 	Config struct {
-		Parser           logs.ParserConfig `yaml:",inline"`
-		Path             string            `yaml:"path"`
-		ExcludePath      string            `yaml:"exclude_path"`
-		URLPatterns      []userPattern     `yaml:"url_patterns"`
-		CustomFields     []customField     `yaml:"custom_fields"`
-		CustomTimeFields []customTimeField `yaml:"custom_time_fields"`
-		Histogram        []float64         `yaml:"histogram"`
-		GroupRespCodes   bool              `yaml:"group_response_codes"`
+		Parser              logs.ParserConfig  `yaml:",inline"`
+		Path                string             `yaml:"path"`
+		ExcludePath         string             `yaml:"exclude_path"`
+		URLPatterns         []userPattern      `yaml:"url_patterns"`
+		CustomFields        []customField      `yaml:"custom_fields"`
+		CustomTimeFields    []customTimeField  `yaml:"custom_time_fields"`
+		Histogram           []float64          `yaml:"histogram"`
+		Quantiles           []float64          `yaml:"req_proc_time_quantiles"`
+		URLPatternQuantiles []float64          `yaml:"url_pattern_req_proc_time_quantiles"`
+		GroupRespCodes      bool               `yaml:"group_response_codes"`
+		VhostFilter         matcher.SimpleExpr `yaml:"vhost_filter"`
+		MaxVhosts           int                `yaml:"max_vhosts"`
+		ReplayRotated       bool               `yaml:"replay_rotated"`
+		TimeLayouts         []string           `yaml:"time_layouts"`
 	}
 
 	WebLog struct {
@@ -76,6 +88,9 @@ type (
 		urlPatterns      []*pattern
 		customFields     map[string][]*pattern
 		customTimeFields map[string][]float64
+		vhostFilter      matcher.Matcher
+		vhostsLimitHit   bool
+		now              time.Time
 
 		mx     *metricsData
 		charts *module.Charts
@@ -98,6 +113,21 @@ func (w *WebLog) Init() bool {
 		return false
 	}
 
+	if err := w.createVhostFilter(); err != nil {
+		w.Error("init failed: ", err)
+		return false
+	}
+
+	if err := w.validateTimeLayouts(); err != nil {
+		w.Error("init failed: ", err)
+		return false
+	}
+
+	if err := w.validateQuantiles(); err != nil {
+		w.Error("init failed: ", err)
+		return false
+	}
+
 	w.createLogLine()
 	w.mx = newMetricsData(w.Config)
 	return true
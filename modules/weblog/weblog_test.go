@@ -10,8 +10,10 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/logs"
+	"github.com/netdata/go.d.plugin/pkg/matcher"
 	"github.com/netdata/go.d.plugin/pkg/metrics"
 
 	"github.com/netdata/go.d.plugin/agent/module"
@@ -25,6 +27,7 @@ var (
 	testCustomLog, _          = os.ReadFile("testdata/custom.log")
 	testCustomTimeFieldLog, _ = os.ReadFile("testdata/custom_time_fields.log")
 	testIISLog, _             = os.ReadFile("testdata/u_ex221107.log")
+	testJSONNestedLog, _      = os.ReadFile("testdata/envoy_nested.log")
 )
 
 func Test_readTestData(t *testing.T) {
@@ -33,6 +36,7 @@ func Test_readTestData(t *testing.T) {
 	assert.NotNil(t, testCustomLog)
 	assert.NotNil(t, testCustomTimeFieldLog)
 	assert.NotNil(t, testIISLog)
+	assert.NotNil(t, testJSONNestedLog)
 }
 
 func TestNew(t *testing.T) {
@@ -59,6 +63,34 @@ func TestWebLog_Init_ErrorOnCreatingCustomFields(t *testing.T) {
 	assert.False(t, weblog.Init())
 }
 
+func TestWebLog_Init_ErrorOnInvalidQuantiles(t *testing.T) {
+	weblog := New()
+	weblog.Quantiles = []float64{0.5, 95}
+
+	assert.False(t, weblog.Init())
+}
+
+func TestWebLog_Init_ErrorOnInvalidURLPatternQuantiles(t *testing.T) {
+	weblog := New()
+	weblog.URLPatternQuantiles = []float64{0, 0.99}
+
+	assert.False(t, weblog.Init())
+}
+
+func TestWebLog_Init_ErrorOnCreatingVhostFilter(t *testing.T) {
+	weblog := New()
+	weblog.VhostFilter = matcher.SimpleExpr{Includes: []string{"~ [invalid"}}
+
+	assert.False(t, weblog.Init())
+}
+
+func TestWebLog_Init_ErrorOnEmptyTimeLayout(t *testing.T) {
+	weblog := New()
+	weblog.TimeLayouts = []string{"unix_ms", " "}
+
+	assert.False(t, weblog.Init())
+}
+
 func TestWebLog_Check(t *testing.T) {
 	weblog := New()
 	defer weblog.Cleanup()
@@ -131,6 +163,8 @@ func TestWebLog_Collect(t *testing.T) {
 		"custom_field_drink_wine":                                 231,
 		"custom_field_side_dark":                                  231,
 		"custom_field_side_light":                                 221,
+		"log_file_rotations":                                      0,
+		"log_file_skipped_lines":                                  0,
 		"req_http_scheme":                                         218,
 		"req_https_scheme":                                        234,
 		"req_ipv4":                                                275,
@@ -171,11 +205,19 @@ func TestWebLog_Collect(t *testing.T) {
 		"req_ssl_proto_TLSv1.1":                                   87,
 		"req_ssl_proto_TLSv1.2":                                   73,
 		"req_ssl_proto_TLSv1.3":                                   85,
+		"req_timestamp_lag_avg":                                   0,
+		"req_timestamp_lag_count":                                 452,
+		"req_timestamp_lag_max":                                   0,
+		"req_timestamp_lag_min":                                   0,
+		"req_timestamp_lag_sum":                                   0,
 		"req_type_bad":                                            49,
 		"req_type_error":                                          0,
 		"req_type_redirect":                                       119,
 		"req_type_success":                                        284,
 		"req_unmatched":                                           48,
+		"req_unmatched_bad_field":                                 0,
+		"req_unmatched_missing_fields":                            0,
+		"req_unmatched_parse_error":                               48,
 		"req_url_ptn_com":                                         120,
 		"req_url_ptn_net":                                         116,
 		"req_url_ptn_not_match":                                   0,
@@ -301,19 +343,138 @@ func TestWebLog_Collect(t *testing.T) {
 		"custom_time_field_random_time_field_time_max":            230,
 		"custom_time_field_random_time_field_time_min":            230,
 		"custom_time_field_random_time_field_time_sum":            103960,
+		"vhost_resp_4xx_198.51.100.1":                             20,
+		"vhost_resp_4xx_2001:db8:1ce::1":                          23,
+		"vhost_resp_4xx_localhost":                                18,
+		"vhost_resp_4xx_test.example.com":                         20,
+		"vhost_resp_4xx_test.example.org":                         14,
+		"vhost_resp_5xx_198.51.100.1":                             0,
+		"vhost_resp_5xx_2001:db8:1ce::1":                          0,
+		"vhost_resp_5xx_localhost":                                0,
+		"vhost_resp_5xx_test.example.com":                         0,
+		"vhost_resp_5xx_test.example.org":                         0,
 	}
 
 	mx := weblog.Collect()
+	copyReqTimestampLag(t, expected, mx)
 	assert.Equal(t, expected, mx)
 	testCharts(t, weblog, mx)
 }
 
+func TestWebLog_Collect_ReqProcTimeQuantiles(t *testing.T) {
+	weblog := prepareWebLogCollectFull(t)
+	weblog.Quantiles = []float64{0.5, 0.95, 0.99}
+	weblog.mx = newMetricsData(weblog.Config)
+	require.NoError(t, weblog.createCharts(weblog.line))
+
+	mx := weblog.Collect()
+
+	for _, key := range []string{"req_proc_time_p50", "req_proc_time_p95", "req_proc_time_p99"} {
+		require.Containsf(t, mx, key, "metric '%s' is not collected", key)
+	}
+	assert.LessOrEqual(t, mx["req_proc_time_min"], mx["req_proc_time_p50"])
+	assert.LessOrEqual(t, mx["req_proc_time_p50"], mx["req_proc_time_p95"])
+	assert.LessOrEqual(t, mx["req_proc_time_p95"], mx["req_proc_time_p99"])
+	assert.LessOrEqual(t, mx["req_proc_time_p99"], mx["req_proc_time_max"])
+	testCharts(t, weblog, mx)
+
+	// no new lines, the quantiles are zeroed
+	mx = weblog.Collect()
+	assert.EqualValues(t, 0, mx["req_proc_time_p99"])
+}
+
+func TestWebLog_Collect_URLPatternReqProcTimeQuantiles(t *testing.T) {
+	weblog := prepareWebLogCollectFull(t)
+	weblog.URLPatternQuantiles = []float64{0.95, 0.99}
+	weblog.mx = newMetricsData(weblog.Config)
+	require.NoError(t, weblog.createCharts(weblog.line))
+
+	mx := weblog.Collect()
+
+	for _, name := range []string{"com", "org", "net"} {
+		prefix := "url_ptn_" + name + "_req_proc_time_"
+		for _, key := range []string{prefix + "p95", prefix + "p99"} {
+			require.Containsf(t, mx, key, "metric '%s' is not collected", key)
+		}
+		assert.LessOrEqual(t, mx[prefix+"avg"], mx[prefix+"max"])
+		assert.LessOrEqual(t, mx[prefix+"p95"], mx[prefix+"p99"])
+		assert.LessOrEqual(t, mx[prefix+"p99"], mx[prefix+"max"])
+		assert.Truef(t, weblog.Charts().Has(fmt.Sprintf(urlPatternReqProcTimeQuantiles.ID, name)),
+			"chart for '%s' url pattern quantiles is not created", name)
+	}
+	// a pattern without traffic has no quantiles
+	assert.NotContains(t, mx, "url_ptn_not_match_req_proc_time_p95")
+
+	// no new lines, the quantiles are empty
+	mx = weblog.Collect()
+	assert.NotContains(t, mx, "url_ptn_com_req_proc_time_p95")
+	assert.NotContains(t, mx, "url_ptn_com_req_proc_time_p99")
+}
+
+func TestWebLog_Collect_VhostFilter(t *testing.T) {
+	weblog := prepareWebLogCollectFull(t)
+	weblog.VhostFilter = matcher.SimpleExpr{Excludes: []string{"* *.org", "* 198.*"}}
+	require.NoError(t, weblog.createVhostFilter())
+
+	mx := weblog.Collect()
+
+	assert.Contains(t, mx, "req_vhost_test.example.com")
+	assert.Contains(t, mx, "vhost_resp_4xx_test.example.com")
+	assert.NotContains(t, mx, "req_vhost_test.example.org")
+	assert.NotContains(t, mx, "vhost_resp_4xx_test.example.org")
+	assert.NotContains(t, mx, "req_vhost_198.51.100.1")
+	testCharts(t, weblog, mx)
+}
+
+func TestWebLog_Collect_MaxVhosts(t *testing.T) {
+	weblog := prepareWebLogCollectFull(t)
+	weblog.MaxVhosts = 2
+
+	mx := weblog.Collect()
+
+	assert.Len(t, weblog.mx.ReqVhost, 2)
+	assert.Len(t, weblog.mx.VhostResp4xx, 2)
+	assert.Len(t, weblog.mx.VhostResp5xx, 2)
+	assert.Len(t, weblog.Charts().Get(reqByVhost.ID).Dims, 2)
+	assert.Len(t, weblog.Charts().Get(vhostResp4xx.ID).Dims, 2)
+	testCharts(t, weblog, mx)
+}
+
+func TestWebLog_Collect_ReqTimestampLag(t *testing.T) {
+	weblog := New()
+	weblog.Parser.LogType = logs.TypeJSON
+	weblog.TimeLayouts = []string{"unix_ms"}
+	require.True(t, weblog.Init())
+
+	ts := time.Now().Add(-time.Minute).UnixMilli()
+	data := fmt.Sprintf("{\"ts\":%d,\"code\":200}\n{\"ts\":%d,\"code\":404}\n{\"ts\":\"bad\",\"code\":200}\n", ts, ts+10000)
+	cfg := logs.JSONConfig{Mapping: map[string]string{"ts": "time", "code": "status"}}
+	p, err := logs.NewJSONParser(cfg, strings.NewReader(data))
+	require.NoError(t, err)
+	weblog.parser = p
+	require.NoError(t, weblog.line.Assign("time", strconv.FormatInt(ts, 10)))
+	require.NoError(t, weblog.line.Assign("status", "200"))
+	require.NoError(t, weblog.createCharts(weblog.line))
+
+	mx := weblog.Collect()
+
+	assert.EqualValues(t, 2, mx["req_timestamp_lag_count"])
+	assert.EqualValues(t, 3, mx["requests"])
+	assert.EqualValues(t, 0, mx["req_unmatched"])
+	assert.InDelta(t, 60000, mx["req_timestamp_lag_max"], 5000)
+	assert.InDelta(t, 50000, mx["req_timestamp_lag_min"], 5000)
+	assert.True(t, weblog.Charts().Has(reqTimestampLag.ID))
+	testCharts(t, weblog, mx)
+}
+
 func TestWebLog_Collect_CommonLogFormat(t *testing.T) {
 	weblog := prepareWebLogCollectCommon(t)
 
 	expected := map[string]int64{
 		"bytes_received":                    0,
 		"bytes_sent":                        1388056,
+		"log_file_rotations":                0,
+		"log_file_skipped_lines":            0,
 		"req_http_scheme":                   0,
 		"req_https_scheme":                  0,
 		"req_ipv4":                          283,
@@ -339,11 +500,19 @@ func TestWebLog_Collect_CommonLogFormat(t *testing.T) {
 		"req_proc_time_max":                 0,
 		"req_proc_time_min":                 0,
 		"req_proc_time_sum":                 0,
+		"req_timestamp_lag_avg":             0,
+		"req_timestamp_lag_count":           456,
+		"req_timestamp_lag_max":             0,
+		"req_timestamp_lag_min":             0,
+		"req_timestamp_lag_sum":             0,
 		"req_type_bad":                      54,
 		"req_type_error":                    0,
 		"req_type_redirect":                 122,
 		"req_type_success":                  280,
 		"req_unmatched":                     44,
+		"req_unmatched_bad_field":           44,
+		"req_unmatched_missing_fields":      0,
+		"req_unmatched_parse_error":         0,
 		"req_version_1.1":                   155,
 		"req_version_2":                     147,
 		"req_version_2.0":                   154,
@@ -384,6 +553,7 @@ func TestWebLog_Collect_CommonLogFormat(t *testing.T) {
 	}
 
 	mx := weblog.Collect()
+	copyReqTimestampLag(t, expected, mx)
 	assert.Equal(t, expected, mx)
 	testCharts(t, weblog, mx)
 }
@@ -398,6 +568,8 @@ func TestWebLog_Collect_CustomLogs(t *testing.T) {
 		"custom_field_drink_wine":           40,
 		"custom_field_side_dark":            46,
 		"custom_field_side_light":           46,
+		"log_file_rotations":                0,
+		"log_file_skipped_lines":            0,
 		"req_http_scheme":                   0,
 		"req_https_scheme":                  0,
 		"req_ipv4":                          0,
@@ -420,11 +592,19 @@ func TestWebLog_Collect_CustomLogs(t *testing.T) {
 		"req_proc_time_max":                 0,
 		"req_proc_time_min":                 0,
 		"req_proc_time_sum":                 0,
+		"req_timestamp_lag_avg":             0,
+		"req_timestamp_lag_count":           0,
+		"req_timestamp_lag_max":             0,
+		"req_timestamp_lag_min":             0,
+		"req_timestamp_lag_sum":             0,
 		"req_type_bad":                      0,
 		"req_type_error":                    0,
 		"req_type_redirect":                 0,
 		"req_type_success":                  0,
 		"req_unmatched":                     8,
+		"req_unmatched_bad_field":           0,
+		"req_unmatched_missing_fields":      0,
+		"req_unmatched_parse_error":         8,
 		"requests":                          100,
 		"resp_1xx":                          0,
 		"resp_2xx":                          0,
@@ -500,6 +680,8 @@ func TestWebLog_Collect_CustomTimeFieldsLogs(t *testing.T) {
 		"custom_time_field_time2_time_max":            321,
 		"custom_time_field_time2_time_min":            123,
 		"custom_time_field_time2_time_sum":            18360,
+		"log_file_rotations":                          0,
+		"log_file_skipped_lines":                      0,
 		"req_http_scheme":                             0,
 		"req_https_scheme":                            0,
 		"req_ipv4":                                    0,
@@ -522,11 +704,19 @@ func TestWebLog_Collect_CustomTimeFieldsLogs(t *testing.T) {
 		"req_proc_time_max":                           0,
 		"req_proc_time_min":                           0,
 		"req_proc_time_sum":                           0,
+		"req_timestamp_lag_avg":                       0,
+		"req_timestamp_lag_count":                     0,
+		"req_timestamp_lag_max":                       0,
+		"req_timestamp_lag_min":                       0,
+		"req_timestamp_lag_sum":                       0,
 		"req_type_bad":                                0,
 		"req_type_error":                              0,
 		"req_type_redirect":                           0,
 		"req_type_success":                            0,
 		"req_unmatched":                               0,
+		"req_unmatched_bad_field":                     0,
+		"req_unmatched_missing_fields":                0,
+		"req_unmatched_parse_error":                   0,
 		"requests":                                    72,
 		"resp_1xx":                                    0,
 		"resp_2xx":                                    0,
@@ -560,12 +750,41 @@ func TestWebLog_Collect_CustomTimeFieldsLogs(t *testing.T) {
 	testCharts(t, weblog, mx)
 }
 
+func TestWebLog_Collect_JSONNestedLogs(t *testing.T) {
+	weblog := prepareWebLogCollectJSONNested(t)
+
+	expected := map[string]int64{
+		"bytes_received":               1024,
+		"bytes_sent":                   712,
+		"req_method_GET":               2,
+		"req_method_POST":              1,
+		"req_unmatched":                3,
+		"req_unmatched_bad_field":      1,
+		"req_unmatched_missing_fields": 1,
+		"req_unmatched_parse_error":    1,
+		"req_version_1.1":              2,
+		"req_version_2":                1,
+		"requests":                     6,
+		"resp_code_200":                1,
+		"resp_code_404":                1,
+		"resp_code_503":                1,
+	}
+
+	mx := weblog.Collect()
+	for k, v := range expected {
+		assert.Equalf(t, v, mx[k], "metric '%s'", k)
+	}
+	testCharts(t, weblog, mx)
+}
+
 func TestWebLog_IISLogs(t *testing.T) {
 	weblog := prepareWebLogCollectIISFields(t)
 
 	expected := map[string]int64{
 		"bytes_received":                    0,
 		"bytes_sent":                        0,
+		"log_file_rotations":                0,
+		"log_file_skipped_lines":            0,
 		"req_http_scheme":                   0,
 		"req_https_scheme":                  0,
 		"req_ipv4":                          38,
@@ -590,11 +809,19 @@ func TestWebLog_IISLogs(t *testing.T) {
 		"req_proc_time_max":                 256,
 		"req_proc_time_min":                 0,
 		"req_proc_time_sum":                 799,
+		"req_timestamp_lag_avg":             0,
+		"req_timestamp_lag_count":           0,
+		"req_timestamp_lag_max":             0,
+		"req_timestamp_lag_min":             0,
+		"req_timestamp_lag_sum":             0,
 		"req_type_bad":                      42,
 		"req_type_error":                    0,
 		"req_type_redirect":                 0,
 		"req_type_success":                  110,
 		"req_unmatched":                     16,
+		"req_unmatched_bad_field":           4,
+		"req_unmatched_missing_fields":      0,
+		"req_unmatched_parse_error":         12,
 		"req_vhost_127.0.0.1":               38,
 		"req_vhost_::1":                     114,
 		"requests":                          168,
@@ -626,6 +853,10 @@ func TestWebLog_IISLogs(t *testing.T) {
 		"upstream_resp_time_max":            0,
 		"upstream_resp_time_min":            0,
 		"upstream_resp_time_sum":            0,
+		"vhost_resp_4xx_127.0.0.1":          38,
+		"vhost_resp_4xx_::1":                4,
+		"vhost_resp_5xx_127.0.0.1":          0,
+		"vhost_resp_5xx_::1":                0,
 	}
 
 	mx := weblog.Collect()
@@ -653,6 +884,15 @@ func testCharts(t *testing.T, w *WebLog, mx map[string]int64) {
 	testChartsDimIDs(t, w, mx)
 }
 
+// copyReqTimestampLag copies the request timestamp lag values, they depend on the current time.
+func copyReqTimestampLag(t *testing.T, expected, mx map[string]int64) {
+	for _, key := range []string{"req_timestamp_lag_avg", "req_timestamp_lag_max", "req_timestamp_lag_min", "req_timestamp_lag_sum"} {
+		require.Containsf(t, mx, key, "collected metrics has no '%s'", key)
+		assert.Positivef(t, mx[key], "'%s' is not positive", key)
+		expected[key] = mx[key]
+	}
+}
+
 func testChartsDimIDs(t *testing.T, w *WebLog, mx map[string]int64) {
 	for _, chart := range *w.Charts() {
 		for _, dim := range chart.Dims {
@@ -677,6 +917,28 @@ func testVhostChart(t *testing.T, w *WebLog) {
 		id := "req_vhost_" + v
 		assert.Truef(t, chart.HasDim(id), "chart '%s' has no dim for '%s' vhost, expected '%s'", chart.ID, v, id)
 	}
+
+	for _, v := range []struct {
+		chart  Chart
+		prefix string
+		vec    metrics.CounterVec
+	}{
+		{chart: vhostResp4xx, prefix: "vhost_resp_4xx_", vec: w.mx.VhostResp4xx},
+		{chart: vhostResp5xx, prefix: "vhost_resp_5xx_", vec: w.mx.VhostResp5xx},
+	} {
+		if len(v.vec) == 0 {
+			continue
+		}
+		chart := w.Charts().Get(v.chart.ID)
+		assert.NotNilf(t, chart, "chart '%s' is not created", v.chart.ID)
+		if chart == nil {
+			continue
+		}
+		for vhost := range v.vec {
+			id := v.prefix + vhost
+			assert.Truef(t, chart.HasDim(id), "chart '%s' has no dim for '%s' vhost, expected '%s'", chart.ID, vhost, id)
+		}
+	}
 }
 
 func testPortChart(t *testing.T, w *WebLog) {
@@ -846,6 +1108,12 @@ func testReqProcTimeCharts(t *testing.T, w *WebLog) {
 		assert.Truef(t, w.Charts().Has(reqProcTime.ID), "chart '%s' is not created", reqProcTime.ID)
 	}
 
+	if isEmptySummary(w.mx.ReqProcTime) || len(w.Quantiles) == 0 {
+		assert.Falsef(t, w.Charts().Has(reqProcTimeQuantiles.ID), "chart '%s' is created", reqProcTimeQuantiles.ID)
+	} else {
+		assert.Truef(t, w.Charts().Has(reqProcTimeQuantiles.ID), "chart '%s' is not created", reqProcTimeQuantiles.ID)
+	}
+
 	if isEmptyHistogram(w.mx.ReqProcTimeHist) {
 		assert.Falsef(t, w.Charts().Has(reqProcTimeHist.ID), "chart '%s' is created", reqProcTimeHist.ID)
 	} else {
@@ -1291,6 +1559,39 @@ func prepareWebLogCollectIISFields(t *testing.T) *WebLog {
 	return weblog
 }
 
+func prepareWebLogCollectJSONNested(t *testing.T) *WebLog {
+	t.Helper()
+	cfg := Config{
+		Parser: logs.ParserConfig{
+			LogType: logs.TypeJSON,
+			JSON: logs.JSONConfig{
+				Mapping: map[string]string{
+					"request.authority":   "host",
+					"request.method":      "request_method",
+					"request.path":        "request_uri",
+					"request.protocol":    "server_protocol",
+					"request.bytes":       "request_length",
+					"response.code":       "status",
+					"response.bytes_sent": "bytes_sent",
+				},
+			},
+		},
+		Path:           "testdata/envoy_nested.log",
+		GroupRespCodes: false,
+	}
+
+	weblog := New()
+	weblog.Config = cfg
+	require.True(t, weblog.Init())
+	require.True(t, weblog.Check())
+	defer weblog.Cleanup()
+
+	p, err := logs.NewJSONParser(weblog.Parser.JSON, bytes.NewReader(testJSONNestedLog))
+	require.NoError(t, err)
+	weblog.parser = p
+	return weblog
+}
+
 // generateLogs is used to populate 'testdata/full.log'
 //func generateLogs(w io.Writer, num int) error {
 //	var (
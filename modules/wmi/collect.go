@@ -3,6 +3,8 @@
 package wmi
 
 import (
+	"strings"
+
 	"github.com/netdata/go.d.plugin/pkg/prometheus"
 )
 
@@ -28,7 +30,7 @@ const (
 )
 
 func (w *WMI) collect() (map[string]int64, error) {
-	pms, err := w.prom.ScrapeSeries()
+	pms, err := w.scrapeSeries()
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +117,24 @@ func (w *WMI) collectMetrics(mx map[string]int64, pms prometheus.Series) {
 	}
 }
 
+// scrapeSeries keeps only the windows_exporter series, the series buffer is reused between scrapes.
+func (w *WMI) scrapeSeries() (prometheus.Series, error) {
+	w.pms.Reset()
+
+	err := w.prom.ScrapeSeriesFunc(func(s prometheus.SeriesSample) {
+		if strings.HasPrefix(s.Name(), "windows_") {
+			w.pms.Add(s.Copy())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	w.pms.Sort()
+
+	return w.pms, nil
+}
+
 func hasKey(mx map[string]int64, key string, keys ...string) bool {
 	_, ok := mx[key]
 	switch len(keys) {
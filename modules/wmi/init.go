@@ -22,5 +22,8 @@ func (w *WMI) initHTTPClient() (*http.Client, error) {
 }
 
 func (w *WMI) initPrometheusClient(client *http.Client) (prometheus.Prometheus, error) {
-	return prometheus.New(client, w.Request), nil
+	if len(w.FallbackURLs) == 0 {
+		return prometheus.New(client, w.Request), nil
+	}
+	return prometheus.NewWithFallback(client, prometheus.FallbackRequests(w.Request, w.FallbackURLs), nil), nil
 }
@@ -48,7 +48,8 @@ func New() *WMI {
 }
 
 type Config struct {
-	web.HTTP `yaml:",inline"`
+	web.HTTP     `yaml:",inline"`
+	FallbackURLs []string `yaml:"fallback_urls"`
 }
 
 type (
@@ -62,6 +63,7 @@ type (
 
 		httpClient *http.Client
 		prom       prometheus.Prometheus
+		pms        prometheus.Series
 
 		cache cache
 	}
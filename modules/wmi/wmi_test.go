@@ -3,6 +3,7 @@
 package wmi
 
 import (
+	"compress/gzip"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -509,6 +510,21 @@ func TestWMI_Collect(t *testing.T) {
 	}
 }
 
+func TestWMI_Collect_GzipEncodedResponse(t *testing.T) {
+	plain, cleanupPlain := prepareWMIv0200()
+	defer cleanupPlain()
+	gzipped, cleanupGzipped := prepareWMIv0200GzipEncoded()
+	defer cleanupGzipped()
+
+	require.True(t, plain.Init())
+	require.True(t, gzipped.Init())
+
+	mx := gzipped.Collect()
+
+	require.NotEmpty(t, mx)
+	assert.Equal(t, plain.Collect(), mx)
+}
+
 func testCharts(t *testing.T, wmi *WMI, mx map[string]int64) {
 	ensureChartsDimsCreated(t, wmi)
 	ensureCollectedHasAllChartsDimsVarsIDs(t, wmi, mx)
@@ -677,6 +693,25 @@ func prepareWMIv0200() (wmi *WMI, cleanup func()) {
 	return wmi, ts.Close
 }
 
+func prepareWMIv0200GzipEncoded() (wmi *WMI, cleanup func()) {
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+				_, _ = w.Write(v0200Metrics)
+				return
+			}
+			w.Header().Set("Content-Encoding", "gzip")
+			gw := gzip.NewWriter(w)
+			_, _ = gw.Write(v0200Metrics)
+			_ = gw.Close()
+		}))
+
+	wmi = New()
+	wmi.URL = ts.URL
+	wmi.AcceptEncoding = "gzip"
+	return wmi, ts.Close
+}
+
 func prepareWMIReturnsInvalidData() (wmi *WMI, cleanup func()) {
 	ts := httptest.NewServer(http.HandlerFunc(
 		func(w http.ResponseWriter, r *http.Request) {
@@ -7,9 +7,13 @@ import "github.com/netdata/go.d.plugin/agent/module"
 var (
 	baseCharts = module.Charts{
 		timeUntilExpirationChart.Copy(),
+		chainTimeUntilExpirationChart.Copy(),
+		subjectAltNamesChart.Copy(),
 	}
 	withRevocationCharts = module.Charts{
 		timeUntilExpirationChart.Copy(),
+		chainTimeUntilExpirationChart.Copy(),
+		subjectAltNamesChart.Copy(),
 		revocationStatusChart.Copy(),
 	}
 
@@ -28,6 +32,37 @@ var (
 			{ID: "days_until_expiration_critical"},
 		},
 	}
+	// dimensions are added during runtime, a dimension per certificate in the chain
+	chainTimeUntilExpirationChart = module.Chart{
+		ID:    "chain_time_until_expiration",
+		Title: "Time Until Certificate Expiration By Chain Position",
+		Units: "seconds",
+		Fam:   "expiration time",
+		Ctx:   "x509check.chain_time_until_expiration",
+		Opts:  module.Opts{StoreFirst: true},
+	}
+	hostnameVerificationChart = module.Chart{
+		ID:    "hostname_verification",
+		Title: "Hostname Verification Status",
+		Units: "boolean",
+		Fam:   "hostname",
+		Ctx:   "x509check.hostname_verification",
+		Opts:  module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "hostname_valid"},
+		},
+	}
+	subjectAltNamesChart = module.Chart{
+		ID:    "subject_alternative_names",
+		Title: "Subject Alternative Names",
+		Units: "names",
+		Fam:   "hostname",
+		Ctx:   "x509check.subject_alternative_names",
+		Opts:  module.Opts{StoreFirst: true},
+		Dims: module.Dims{
+			{ID: "san_count", Name: "names"},
+		},
+	}
 	revocationStatusChart = module.Chart{
 		ID:    "revocation_status",
 		Title: "Revocation Status",
@@ -40,3 +75,33 @@ var (
 		},
 	}
 )
+
+func (x *X509Check) addChainPositionDim(pos string) {
+	if x.charts == nil {
+		return
+	}
+	chart := x.charts.Get(chainTimeUntilExpirationChart.ID)
+	if chart == nil {
+		return
+	}
+	if err := chart.AddDim(&module.Dim{ID: "expiry_" + pos, Name: pos}); err != nil {
+		x.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
+
+func (x *X509Check) removeChainPositionDim(pos string) {
+	if x.charts == nil {
+		return
+	}
+	chart := x.charts.Get(chainTimeUntilExpirationChart.ID)
+	if chart == nil {
+		return
+	}
+	if err := chart.MarkDimRemove("expiry_"+pos, true); err != nil {
+		x.Warning(err)
+		return
+	}
+	chart.MarkNotCreated()
+}
@@ -3,6 +3,7 @@
 package x509check
 
 import (
+	"bytes"
 	"crypto/x509"
 	"fmt"
 	"time"
@@ -23,6 +24,8 @@ func (x *X509Check) collect() (map[string]int64, error) {
 	mx := make(map[string]int64)
 
 	x.collectExpiration(mx, certs)
+	x.collectHostname(mx, certs[0])
+	x.collectSANs(mx, certs[0])
 	if x.CheckRevocation {
 		x.collectRevocation(mx, certs)
 	}
@@ -31,11 +34,81 @@ func (x *X509Check) collect() (map[string]int64, error) {
 }
 
 func (x *X509Check) collectExpiration(mx map[string]int64, certs []*x509.Certificate) {
-	expiry := time.Until(certs[0].NotAfter).Seconds()
-	mx["expiry"] = int64(expiry)
+	// the chain is as good as its certificate that expires first
+	var minExpiry int64
+	seen := make(map[string]bool)
+	for i, cert := range certs {
+		expiry := int64(time.Until(cert.NotAfter).Seconds())
+		if i == 0 || expiry < minExpiry {
+			minExpiry = expiry
+		}
+
+		pos := chainPosition(certs, i)
+		seen[pos] = true
+		if !x.chainPositions[pos] {
+			x.chainPositions[pos] = true
+			x.addChainPositionDim(pos)
+		}
+		mx["expiry_"+pos] = expiry
+	}
+	for pos := range x.chainPositions {
+		if !seen[pos] {
+			delete(x.chainPositions, pos)
+			x.removeChainPositionDim(pos)
+		}
+	}
+
+	mx["expiry"] = minExpiry
 	mx["days_until_expiration_warning"] = x.DaysUntilWarn
 	mx["days_until_expiration_critical"] = x.DaysUntilCritical
+}
 
+// chainPosition returns the position name of the i-th certificate in the chain: "leaf", "root" (a self-signed
+// certificate that is not the leaf) or "intermediate" ("intermediate_N" if there are several intermediates).
+func chainPosition(certs []*x509.Certificate, i int) string {
+	if i == 0 {
+		return "leaf"
+	}
+	if isSelfSigned(certs[i]) {
+		return "root"
+	}
+	var num int
+	for j := 1; j < len(certs); j++ {
+		if !isSelfSigned(certs[j]) {
+			num++
+		}
+	}
+	if num == 1 {
+		return "intermediate"
+	}
+	return fmt.Sprintf("intermediate_%d", i)
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer)
+}
+
+// collectHostname verifies the leaf certificate against the server name regardless of tls_skip_verify.
+// x509.Certificate.VerifyHostname follows RFC 6125: only DNS and IP SANs are checked (the Common Name is ignored),
+// a wildcard is allowed only as the complete left-most label and matches exactly one label.
+func (x *X509Check) collectHostname(mx map[string]int64, cert *x509.Certificate) {
+	if x.serverName == "" {
+		return
+	}
+	mx["hostname_valid"] = 1
+	if err := cert.VerifyHostname(x.serverName); err != nil {
+		x.Debug(err)
+		mx["hostname_valid"] = 0
+	}
+}
+
+func (x *X509Check) collectSANs(mx map[string]int64, cert *x509.Certificate) {
+	num := len(cert.DNSNames) + len(cert.IPAddresses) + len(cert.EmailAddresses) + len(cert.URIs)
+	if x.sanCount != -1 && x.sanCount != num {
+		x.Warningf("the number of subject alternative names changed from %d to %d", x.sanCount, num)
+	}
+	x.sanCount = num
+	mx["san_count"] = int64(num)
 }
 
 func (x *X509Check) collectRevocation(mx map[string]int64, certs []*x509.Certificate) {
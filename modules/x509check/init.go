@@ -4,6 +4,8 @@ package x509check
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 
 	"github.com/netdata/go.d.plugin/agent/module"
 )
@@ -12,6 +14,11 @@ func (x *X509Check) validateConfig() error {
 	if x.Source == "" {
 		return errors.New("source is not set")
 	}
+	switch x.StartTLS {
+	case "", startTLSNone, startTLSSMTP, startTLSIMAP, startTLSPOP3, startTLSLDAP:
+	default:
+		return fmt.Errorf("unknown starttls protocol '%s'", x.StartTLS)
+	}
 	return nil
 }
 
@@ -19,6 +26,19 @@ func (x *X509Check) initProvider() (provider, error) {
 	return newProvider(x.Config)
 }
 
+// initServerName returns the name the leaf certificate is verified against,
+// it is the source hostname unless it is set explicitly.
+func (x *X509Check) initServerName() string {
+	if x.ServerName != "" {
+		return x.ServerName
+	}
+	u, err := url.Parse(x.Source)
+	if err != nil || u.Scheme == "file" {
+		return ""
+	}
+	return u.Hostname()
+}
+
 func (x *X509Check) initCharts() *module.Charts {
 	var charts *module.Charts
 	if x.CheckRevocation {
@@ -26,6 +46,9 @@ func (x *X509Check) initCharts() *module.Charts {
 	} else {
 		charts = baseCharts.Copy()
 	}
+	if x.serverName != "" {
+		_ = charts.Add(hostnameVerificationChart.Copy())
+	}
 
 	for _, chart := range *charts {
 		chart.Labels = []module.Label{
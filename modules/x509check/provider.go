@@ -11,6 +11,7 @@ import (
 	"net/smtp"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
@@ -51,6 +52,9 @@ func newProvider(config Config) (provider, error) {
 		tlsCfg = &tls.Config{}
 	}
 	tlsCfg.ServerName = sourceURL.Hostname()
+	if config.ServerName != "" {
+		tlsCfg.ServerName = config.ServerName
+	}
 
 	switch sourceURL.Scheme {
 	case "file":
@@ -59,7 +63,17 @@ func newProvider(config Config) (provider, error) {
 		if sourceURL.Scheme == "https" {
 			sourceURL.Scheme = "tcp"
 		}
-		return &fromNet{url: sourceURL, tlsConfig: tlsCfg, timeout: config.Timeout.Duration}, nil
+		switch config.StartTLS {
+		case "", startTLSNone:
+			return &fromNet{url: sourceURL, tlsConfig: tlsCfg, timeout: config.Timeout.Duration}, nil
+		}
+		if !strings.HasPrefix(sourceURL.Scheme, "tcp") {
+			return nil, fmt.Errorf("starttls requires a tcp source, got '%s'", sourceURL.Scheme)
+		}
+		if config.StartTLS == startTLSSMTP {
+			return &fromSMTP{url: sourceURL, tlsConfig: tlsCfg, timeout: config.Timeout.Duration}, nil
+		}
+		return &fromStartTLS{url: sourceURL, tlsConfig: tlsCfg, timeout: config.Timeout.Duration, proto: config.StartTLS}, nil
 	case "smtp":
 		sourceURL.Scheme = "tcp"
 		return &fromSMTP{url: sourceURL, tlsConfig: tlsCfg, timeout: config.Timeout.Duration}, nil
@@ -74,17 +88,23 @@ func (f fromFile) certificates() ([]*x509.Certificate, error) {
 		return nil, fmt.Errorf("error on reading '%s': %v", f.path, err)
 	}
 
-	block, _ := pem.Decode(content)
-	if block == nil {
-		return nil, fmt.Errorf("error on decoding '%s': %v", f.path, err)
+	// the file may contain the full chain (e.g. fullchain.pem), the leaf certificate goes first
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error on parsing certificate '%s': %v", f.path, err)
+		}
+		certs = append(certs, cert)
 	}
-
-	cert, err := x509.ParseCertificate(block.Bytes)
-	if err != nil {
-		return nil, fmt.Errorf("error on parsing certificate '%s': %v", f.path, err)
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("error on decoding '%s': no certificate found", f.path)
 	}
 
-	return []*x509.Certificate{cert}, nil
+	return certs, nil
 }
 
 func (f fromNet) certificates() ([]*x509.Certificate, error) {
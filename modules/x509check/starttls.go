@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package x509check
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	startTLSNone = "none"
+	startTLSSMTP = "smtp"
+	startTLSIMAP = "imap"
+	startTLSPOP3 = "pop3"
+	startTLSLDAP = "ldap"
+)
+
+// fromStartTLS gets the certificates after upgrading a plaintext connection to TLS (IMAP, POP3, LDAP).
+// SMTP is handled by fromSMTP.
+type fromStartTLS struct {
+	url       *url.URL
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	proto     string
+}
+
+func (f fromStartTLS) certificates() ([]*x509.Certificate, error) {
+	ipConn, err := net.DialTimeout(f.url.Scheme, f.url.Host, f.timeout)
+	if err != nil {
+		return nil, fmt.Errorf("error on dial to '%s': %v", f.url, err)
+	}
+	defer func() { _ = ipConn.Close() }()
+
+	if f.timeout > 0 {
+		_ = ipConn.SetDeadline(time.Now().Add(f.timeout))
+	}
+
+	switch f.proto {
+	case startTLSIMAP:
+		err = startTLSIMAPDialog(ipConn)
+	case startTLSPOP3:
+		err = startTLSPOP3Dialog(ipConn)
+	case startTLSLDAP:
+		err = startTLSLDAPDialog(ipConn)
+	default:
+		err = fmt.Errorf("unknown protocol '%s'", f.proto)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error on %s startTLS with '%s': %v", f.proto, f.url, err)
+	}
+
+	conn := tls.Client(ipConn, f.tlsConfig.Clone())
+	defer func() { _ = conn.Close() }()
+	if err := conn.Handshake(); err != nil {
+		return nil, fmt.Errorf("error on SSL handshake with '%s': %v", f.url, err)
+	}
+
+	return conn.ConnectionState().PeerCertificates, nil
+}
+
+// https://www.rfc-editor.org/rfc/rfc3501#section-6.2.1
+func startTLSIMAPDialog(conn net.Conn) error {
+	// the reader must not read past the server response, the rest of the data is the TLS handshake
+	r := bufio.NewReaderSize(conn, 1)
+
+	greeting, err := readLine(r)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(greeting, "* OK") {
+		return fmt.Errorf("unexpected greeting '%s'", greeting)
+	}
+
+	const tag = "x509check"
+	if _, err := fmt.Fprintf(conn, "%s STARTTLS\r\n", tag); err != nil {
+		return err
+	}
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return err
+		}
+		// skip untagged responses
+		if !strings.HasPrefix(line, tag+" ") {
+			continue
+		}
+		if !strings.HasPrefix(line, tag+" OK") {
+			return fmt.Errorf("STARTTLS failed: '%s'", line)
+		}
+		return nil
+	}
+}
+
+// https://www.rfc-editor.org/rfc/rfc2595#section-4
+func startTLSPOP3Dialog(conn net.Conn) error {
+	r := bufio.NewReaderSize(conn, 1)
+
+	greeting, err := readLine(r)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(greeting, "+OK") {
+		return fmt.Errorf("unexpected greeting '%s'", greeting)
+	}
+
+	if _, err := io.WriteString(conn, "STLS\r\n"); err != nil {
+		return err
+	}
+	line, err := readLine(r)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return fmt.Errorf("STLS failed: '%s'", line)
+	}
+	return nil
+}
+
+// ldapStartTLSRequest is the BER encoded LDAP ExtendedRequest (messageID 1) with the StartTLS OID.
+// https://www.rfc-editor.org/rfc/rfc4511#section-4.14.1
+var ldapStartTLSRequest = append([]byte{
+	0x30, 0x1d, // LDAPMessage SEQUENCE
+	0x02, 0x01, 0x01, // messageID INTEGER 1
+	0x77, 0x18, // ExtendedRequest [APPLICATION 23]
+	0x80, 0x16, // requestName [0]
+}, "1.3.6.1.4.1.1466.20037"...)
+
+func startTLSLDAPDialog(conn net.Conn) error {
+	if _, err := conn.Write(ldapStartTLSRequest); err != nil {
+		return err
+	}
+
+	tag, msg, err := readBER(conn)
+	if err != nil {
+		return err
+	}
+	if tag != 0x30 {
+		return fmt.Errorf("unexpected LDAP message tag 0x%x", tag)
+	}
+
+	r := bytes.NewReader(msg)
+	// messageID
+	if tag, _, err = readBER(r); err != nil || tag != 0x02 {
+		return fmt.Errorf("bad LDAP messageID (tag 0x%x): %v", tag, err)
+	}
+	// ExtendedResponse [APPLICATION 24]
+	tag, resp, err := readBER(r)
+	if err != nil || tag != 0x78 {
+		return fmt.Errorf("bad LDAP ExtendedResponse (tag 0x%x): %v", tag, err)
+	}
+	// resultCode ENUMERATED
+	tag, code, err := readBER(bytes.NewReader(resp))
+	if err != nil || tag != 0x0a || len(code) != 1 {
+		return fmt.Errorf("bad LDAP resultCode (tag 0x%x): %v", tag, err)
+	}
+	if code[0] != 0 {
+		return fmt.Errorf("StartTLS failed: LDAP resultCode %d", code[0])
+	}
+	return nil
+}
+
+// readBER reads a BER encoded element (definite length form only).
+func readBER(r io.Reader) (tag byte, value []byte, err error) {
+	var hdr [2]byte
+	if _, err = io.ReadFull(r, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+	tag, length := hdr[0], int(hdr[1])
+	if length&0x80 != 0 {
+		num := length & 0x7f
+		if num == 0 || num > 4 {
+			return 0, nil, errors.New("unsupported BER length")
+		}
+		var buf [4]byte
+		if _, err = io.ReadFull(r, buf[:num]); err != nil {
+			return 0, nil, err
+		}
+		length = 0
+		for _, b := range buf[:num] {
+			length = length<<8 | int(b)
+		}
+	}
+	if length > 1<<16 {
+		return 0, nil, fmt.Errorf("BER element is too big (%d bytes)", length)
+	}
+	value = make([]byte, length)
+	_, err = io.ReadFull(r, value)
+	return tag, value, err
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
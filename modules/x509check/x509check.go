@@ -29,6 +29,8 @@ func New() *X509Check {
 			DaysUntilWarn:     14,
 			DaysUntilCritical: 7,
 		},
+		chainPositions: make(map[string]bool),
+		sanCount:       -1,
 	}
 }
 
@@ -36,9 +38,11 @@ type Config struct {
 	Source            string
 	Timeout           web.Duration
 	tlscfg.TLSConfig  `yaml:",inline"`
-	DaysUntilWarn     int64 `yaml:"days_until_expiration_warning"`
-	DaysUntilCritical int64 `yaml:"days_until_expiration_critical"`
-	CheckRevocation   bool  `yaml:"check_revocation_status"`
+	DaysUntilWarn     int64  `yaml:"days_until_expiration_warning"`
+	DaysUntilCritical int64  `yaml:"days_until_expiration_critical"`
+	CheckRevocation   bool   `yaml:"check_revocation_status"`
+	StartTLS          string `yaml:"starttls"`
+	ServerName        string `yaml:"server_name"`
 }
 
 type X509Check struct {
@@ -46,6 +50,10 @@ type X509Check struct {
 	Config `yaml:",inline"`
 	charts *module.Charts
 	prov   provider
+
+	serverName     string
+	chainPositions map[string]bool
+	sanCount       int
 }
 
 func (x *X509Check) Init() bool {
@@ -61,6 +69,8 @@ func (x *X509Check) Init() bool {
 	}
 	x.prov = prov
 
+	x.serverName = x.initServerName()
+
 	x.charts = x.initCharts()
 
 	return true
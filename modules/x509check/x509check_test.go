@@ -3,9 +3,24 @@
 package x509check
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/tlscfg"
 
@@ -29,6 +44,7 @@ func TestX509Check_Init(t *testing.T) {
 		file = iota
 		net
 		smtp
+		startTLS
 	)
 	tests := map[string]struct {
 		config       Config
@@ -51,6 +67,26 @@ func TestX509Check_Init(t *testing.T) {
 			config:       Config{Source: "smtp://smtp.my_mail.org:587"},
 			providerType: smtp,
 		},
+		"ok from tcp with smtp starttls": {
+			config:       Config{Source: "tcp://smtp.my_mail.org:587", StartTLS: "smtp"},
+			providerType: smtp,
+		},
+		"ok from tcp with imap starttls": {
+			config:       Config{Source: "tcp://imap.my_mail.org:143", StartTLS: "imap"},
+			providerType: startTLS,
+		},
+		"ok from tcp with none starttls": {
+			config:       Config{Source: "tcp://example.org:443", StartTLS: "none"},
+			providerType: net,
+		},
+		"unknown starttls protocol": {
+			config: Config{Source: "tcp://example.org:21", StartTLS: "ftp"},
+			err:    true,
+		},
+		"starttls with udp source": {
+			config: Config{Source: "udp://example.org:143", StartTLS: "imap"},
+			err:    true,
+		},
 		"empty source": {
 			config: Config{Source: ""},
 			err:    true},
@@ -82,6 +118,8 @@ func TestX509Check_Init(t *testing.T) {
 					_, typeOK = x509Check.prov.(*fromNet)
 				case smtp:
 					_, typeOK = x509Check.prov.(*fromSMTP)
+				case startTLS:
+					_, typeOK = x509Check.prov.(*fromStartTLS)
 				}
 
 				assert.True(t, typeOK)
@@ -116,6 +154,357 @@ func TestX509Check_Collect(t *testing.T) {
 	ensureCollectedHasAllChartsDimsVarsIDs(t, x509Check, collected)
 }
 
+func TestX509Check_Collect_CertificateChain(t *testing.T) {
+	root := newTestCert(t, "root", nil, time.Hour*24*100)
+	interm1 := newTestCert(t, "intermediate 1", root, time.Hour*24*5)
+	interm2 := newTestCert(t, "intermediate 2", interm1, time.Hour*24*50)
+	leaf := newTestCert(t, "leaf", interm2, time.Hour*24*30)
+
+	tests := map[string]struct {
+		chain      []*x509.Certificate
+		wantExpiry map[string]int64 // days
+	}{
+		"self-signed leaf only": {
+			chain:      []*x509.Certificate{root.cert},
+			wantExpiry: map[string]int64{"expiry": 100, "expiry_leaf": 100},
+		},
+		"leaf, intermediate and root": {
+			chain: []*x509.Certificate{interm2.cert, interm1.cert, root.cert},
+			wantExpiry: map[string]int64{
+				"expiry":              5,
+				"expiry_leaf":         50,
+				"expiry_intermediate": 5,
+				"expiry_root":         100,
+			},
+		},
+		"leaf and several intermediates without root": {
+			chain: []*x509.Certificate{leaf.cert, interm2.cert, interm1.cert},
+			wantExpiry: map[string]int64{
+				"expiry":                5,
+				"expiry_leaf":           30,
+				"expiry_intermediate_1": 50,
+				"expiry_intermediate_2": 5,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			x509Check := New()
+			x509Check.Source = "https://example.com"
+			require.True(t, x509Check.Init())
+			x509Check.prov = &mockProvider{certs: test.chain}
+
+			mx := x509Check.Collect()
+
+			var keys []string
+			for k := range mx {
+				if strings.HasPrefix(k, "expiry") {
+					keys = append(keys, k)
+				}
+			}
+			assert.Len(t, keys, len(test.wantExpiry))
+			for k, days := range test.wantExpiry {
+				require.Contains(t, mx, k)
+				assert.InDeltaf(t, days*86400, mx[k], 60, "metric '%s'", k)
+			}
+			ensureCollectedHasAllChartsDimsVarsIDs(t, x509Check, mx)
+		})
+	}
+}
+
+func TestX509Check_Collect_CertificateChainChanged(t *testing.T) {
+	root := newTestCert(t, "root", nil, time.Hour*24*100)
+	leaf := newTestCert(t, "leaf", root, time.Hour*24*30)
+
+	x509Check := New()
+	x509Check.Source = "https://example.com"
+	require.True(t, x509Check.Init())
+
+	x509Check.prov = &mockProvider{certs: []*x509.Certificate{leaf.cert, root.cert}}
+	_ = x509Check.Collect()
+	x509Check.prov = &mockProvider{certs: []*x509.Certificate{leaf.cert}}
+	mx := x509Check.Collect()
+
+	assert.NotContains(t, mx, "expiry_root")
+	chart := x509Check.Charts().Get(chainTimeUntilExpirationChart.ID)
+	require.NotNil(t, chart)
+	assert.True(t, chart.GetDim("expiry_root").Obsolete)
+	assert.False(t, chart.GetDim("expiry_leaf").Obsolete)
+}
+
+func TestX509Check_Collect_HostnameValid(t *testing.T) {
+	tests := map[string]struct {
+		source     string
+		serverName string
+		dnsNames   []string
+		wantValid  int64
+	}{
+		"exact match": {
+			source:    "https://example.com",
+			dnsNames:  []string{"example.com"},
+			wantValid: 1,
+		},
+		"case insensitive match": {
+			source:    "https://Example.COM",
+			dnsNames:  []string{"example.com"},
+			wantValid: 1,
+		},
+		"no match": {
+			source:    "https://example.org",
+			dnsNames:  []string{"example.com", "www.example.com"},
+			wantValid: 0,
+		},
+		"common name is ignored": {
+			source:    "https://leaf",
+			wantValid: 0,
+		},
+		"wildcard matches one label": {
+			source:    "https://www.example.com",
+			dnsNames:  []string{"*.example.com"},
+			wantValid: 1,
+		},
+		"wildcard does not match the parent domain": {
+			source:    "https://example.com",
+			dnsNames:  []string{"*.example.com"},
+			wantValid: 0,
+		},
+		"wildcard does not match several labels": {
+			source:    "https://a.b.example.com",
+			dnsNames:  []string{"*.example.com"},
+			wantValid: 0,
+		},
+		"partial label wildcard does not match": {
+			source:    "https://www.example.com",
+			dnsNames:  []string{"w*.example.com"},
+			wantValid: 0,
+		},
+		"wildcard not in the left-most label does not match": {
+			source:    "https://www.example.com",
+			dnsNames:  []string{"www.*.com"},
+			wantValid: 0,
+		},
+		"server name overrides the source hostname": {
+			source:     "https://127.0.0.1:443",
+			serverName: "example.com",
+			dnsNames:   []string{"example.com"},
+			wantValid:  1,
+		},
+		"server name for a file source": {
+			source:     "file:///home/me/cert.pem",
+			serverName: "example.com",
+			dnsNames:   []string{"example.com"},
+			wantValid:  1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			leaf := newTestCert(t, "leaf", nil, time.Hour*24*30, test.dnsNames...)
+
+			x509Check := New()
+			x509Check.Source = test.source
+			x509Check.ServerName = test.serverName
+			// hostname verification does not depend on the chain verification
+			x509Check.InsecureSkipVerify = true
+			require.True(t, x509Check.Init())
+			x509Check.prov = &mockProvider{certs: []*x509.Certificate{leaf.cert}}
+
+			mx := x509Check.Collect()
+
+			require.NotNil(t, mx)
+			assert.Equal(t, test.wantValid, mx["hostname_valid"])
+			assert.Equal(t, int64(len(test.dnsNames)), mx["san_count"])
+			assert.NotNil(t, x509Check.Charts().Get(hostnameVerificationChart.ID))
+		})
+	}
+}
+
+func TestX509Check_Collect_NoHostnameForFileSource(t *testing.T) {
+	x509Check := New()
+	x509Check.Source = "file:///home/me/cert.pem"
+	require.True(t, x509Check.Init())
+	x509Check.prov = &mockProvider{certs: []*x509.Certificate{{}}}
+
+	mx := x509Check.Collect()
+
+	assert.NotContains(t, mx, "hostname_valid")
+	assert.Nil(t, x509Check.Charts().Get(hostnameVerificationChart.ID))
+}
+
+func TestX509Check_Collect_SANCountChanged(t *testing.T) {
+	x509Check := New()
+	x509Check.Source = "https://example.com"
+	require.True(t, x509Check.Init())
+
+	x509Check.prov = &mockProvider{
+		certs: []*x509.Certificate{newTestCert(t, "leaf", nil, time.Hour*24*30, "example.com", "www.example.com").cert},
+	}
+	mx := x509Check.Collect()
+	assert.Equal(t, int64(2), mx["san_count"])
+	assert.Equal(t, int64(1), mx["hostname_valid"])
+
+	// reissued without the checked name
+	x509Check.prov = &mockProvider{
+		certs: []*x509.Certificate{newTestCert(t, "leaf", nil, time.Hour*24*30, "www.example.com").cert},
+	}
+	mx = x509Check.Collect()
+	assert.Equal(t, int64(1), mx["san_count"])
+	assert.Equal(t, int64(0), mx["hostname_valid"])
+	assert.Equal(t, 1, x509Check.sanCount)
+}
+
+func TestFromFile_Certificates(t *testing.T) {
+	root := newTestCert(t, "root", nil, time.Hour*24*100)
+	leaf := newTestCert(t, "leaf", root, time.Hour*24*30)
+
+	var buf bytes.Buffer
+	require.NoError(t, pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: leaf.cert.Raw}))
+	require.NoError(t, pem.Encode(&buf, &pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))
+	require.NoError(t, pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: root.cert.Raw}))
+	path := filepath.Join(t.TempDir(), "fullchain.pem")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+	certs, err := fromFile{path: path}.certificates()
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+	assert.Equal(t, "leaf", certs[0].Subject.CommonName)
+	assert.Equal(t, "root", certs[1].Subject.CommonName)
+
+	require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0644))
+	_, err = fromFile{path: path}.certificates()
+	assert.Error(t, err)
+}
+
+func TestX509Check_Collect_StartTLS(t *testing.T) {
+	tests := map[string]struct {
+		proto   string
+		dialog  func(conn net.Conn) error
+		wantErr bool
+	}{
+		"smtp": {
+			proto: "smtp",
+			dialog: func(conn net.Conn) error {
+				r := bufio.NewReader(conn)
+				_, _ = io.WriteString(conn, "220 localhost ESMTP\r\n")
+				if _, err := r.ReadString('\n'); err != nil { // EHLO
+					return err
+				}
+				_, _ = io.WriteString(conn, "250-localhost\r\n250 STARTTLS\r\n")
+				if _, err := r.ReadString('\n'); err != nil { // STARTTLS
+					return err
+				}
+				_, err := io.WriteString(conn, "220 Ready to start TLS\r\n")
+				return err
+			},
+		},
+		"imap": {
+			proto: "imap",
+			dialog: func(conn net.Conn) error {
+				r := bufio.NewReader(conn)
+				_, _ = io.WriteString(conn, "* OK IMAP4rev1 ready\r\n")
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return err
+				}
+				tag, _, _ := strings.Cut(line, " ")
+				_, err = io.WriteString(conn, "* CAPABILITY IMAP4rev1\r\n"+tag+" OK Begin TLS negotiation now\r\n")
+				return err
+			},
+		},
+		"imap rejected": {
+			proto: "imap",
+			dialog: func(conn net.Conn) error {
+				r := bufio.NewReader(conn)
+				_, _ = io.WriteString(conn, "* OK IMAP4rev1 ready\r\n")
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return err
+				}
+				tag, _, _ := strings.Cut(line, " ")
+				_, _ = io.WriteString(conn, tag+" BAD STARTTLS not supported\r\n")
+				return errors.New("rejected")
+			},
+			wantErr: true,
+		},
+		"pop3": {
+			proto: "pop3",
+			dialog: func(conn net.Conn) error {
+				r := bufio.NewReader(conn)
+				_, _ = io.WriteString(conn, "+OK POP3 ready\r\n")
+				if _, err := r.ReadString('\n'); err != nil { // STLS
+					return err
+				}
+				_, err := io.WriteString(conn, "+OK Begin TLS negotiation\r\n")
+				return err
+			},
+		},
+		"pop3 rejected": {
+			proto: "pop3",
+			dialog: func(conn net.Conn) error {
+				r := bufio.NewReader(conn)
+				_, _ = io.WriteString(conn, "+OK POP3 ready\r\n")
+				if _, err := r.ReadString('\n'); err != nil {
+					return err
+				}
+				_, _ = io.WriteString(conn, "-ERR command not supported\r\n")
+				return errors.New("rejected")
+			},
+			wantErr: true,
+		},
+		"ldap": {
+			proto: "ldap",
+			dialog: func(conn net.Conn) error {
+				req := make([]byte, len(ldapStartTLSRequest))
+				if _, err := io.ReadFull(conn, req); err != nil {
+					return err
+				}
+				if !bytes.Equal(req, ldapStartTLSRequest) {
+					return errors.New("unexpected LDAP request")
+				}
+				// ExtendedResponse, resultCode success
+				_, err := conn.Write([]byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x00, 0x04, 0x00, 0x04, 0x00})
+				return err
+			},
+		},
+		"ldap rejected": {
+			proto: "ldap",
+			dialog: func(conn net.Conn) error {
+				req := make([]byte, len(ldapStartTLSRequest))
+				if _, err := io.ReadFull(conn, req); err != nil {
+					return err
+				}
+				// ExtendedResponse, resultCode protocolError
+				_, _ = conn.Write([]byte{0x30, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x02, 0x04, 0x00, 0x04, 0x00})
+				return errors.New("rejected")
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cert := newTestCert(t, "localhost", nil, time.Hour*24*10)
+			addr := startTestStartTLSServer(t, cert, test.dialog)
+
+			x509Check := New()
+			x509Check.Source = "tcp://" + addr
+			x509Check.StartTLS = test.proto
+			x509Check.InsecureSkipVerify = true
+			require.True(t, x509Check.Init())
+
+			mx := x509Check.Collect()
+
+			if test.wantErr {
+				assert.Nil(t, mx)
+				return
+			}
+			require.NotNil(t, mx)
+			assert.InDelta(t, time.Until(cert.cert.NotAfter).Seconds(), float64(mx["expiry"]), 5)
+		})
+	}
+}
+
 func TestX509Check_Collect_ReturnsNilOnProviderError(t *testing.T) {
 	x509Check := New()
 	x509Check.prov = &mockProvider{err: true}
@@ -144,6 +533,78 @@ func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, x509Check *X509Check,
 	}
 }
 
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert creates a certificate signed by the parent, it is self-signed if the parent is nil.
+func newTestCert(t *testing.T, name string, parent *testCert, validFor time.Duration, dnsNames ...string) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validFor),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		DNSNames:              dnsNames,
+	}
+	issuer, signer := tmpl, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, issuer, &key.PublicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key}
+}
+
+// startTestStartTLSServer accepts a single connection, runs the plaintext dialog and upgrades the connection to TLS.
+func startTestStartTLSServer(t *testing.T, cert *testCert, dialog func(conn net.Conn) error) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{cert.cert.Raw}, PrivateKey: cert.key}},
+	}
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_ = conn.SetDeadline(time.Now().Add(time.Second * 5))
+
+		if err := dialog(conn); err != nil {
+			return
+		}
+		tlsConn := tls.Server(conn, tlsCfg)
+		if err := tlsConn.Handshake(); err != nil {
+			return
+		}
+		// the SMTP client sends EHLO and QUIT after the handshake
+		r := bufio.NewReader(tlsConn)
+		for {
+			if _, err := r.ReadString('\n'); err != nil {
+				return
+			}
+			_, _ = io.WriteString(tlsConn, "250 OK\r\n")
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
 type mockProvider struct {
 	certs []*x509.Certificate
 	err   bool
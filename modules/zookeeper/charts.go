@@ -109,3 +109,162 @@ var charts = Charts{
 		},
 	},
 }
+
+// extendedCharts are added when the server reports their metrics (3.6+).
+var extendedCharts = Charts{
+	{
+		ID:    "read_latency",
+		Title: "Read Requests Latency",
+		Units: "ms",
+		Fam:   "requests",
+		Ctx:   "zookeeper.read_latency",
+		Dims: Dims{
+			{ID: "min_readlatency", Name: "min", Div: 1000},
+			{ID: "avg_readlatency", Name: "avg", Div: 1000},
+			{ID: "max_readlatency", Name: "max", Div: 1000},
+		},
+	},
+	{
+		ID:    "update_latency",
+		Title: "Update Requests Latency",
+		Units: "ms",
+		Fam:   "requests",
+		Ctx:   "zookeeper.update_latency",
+		Dims: Dims{
+			{ID: "min_updatelatency", Name: "min", Div: 1000},
+			{ID: "avg_updatelatency", Name: "avg", Div: 1000},
+			{ID: "max_updatelatency", Name: "max", Div: 1000},
+		},
+	},
+	{
+		ID:    "throttled_requests",
+		Title: "Throttled Requests",
+		Units: "requests/s",
+		Fam:   "requests",
+		Ctx:   "zookeeper.throttled_requests",
+		Dims: Dims{
+			{ID: "request_throttle_wait_count", Name: "throttled", Algo: module.Incremental},
+			{ID: "large_requests_rejected", Name: "large_rejected", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "stale_requests",
+		Title: "Stale Requests",
+		Units: "requests/s",
+		Fam:   "requests",
+		Ctx:   "zookeeper.stale_requests",
+		Dims: Dims{
+			{ID: "stale_requests", Name: "stale", Algo: module.Incremental},
+			{ID: "stale_requests_dropped", Name: "dropped", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "stale_sessions",
+		Title: "Expired Stale Sessions",
+		Units: "sessions/s",
+		Fam:   "sessions",
+		Ctx:   "zookeeper.stale_sessions",
+		Dims: Dims{
+			{ID: "stale_sessions_expired", Name: "expired", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "proposals_commits",
+		Title: "Proposals and Commits",
+		Units: "events/s",
+		Fam:   "quorum",
+		Ctx:   "zookeeper.proposals_commits",
+		Dims: Dims{
+			{ID: "proposal_count", Name: "proposals", Algo: module.Incremental},
+			{ID: "commit_count", Name: "commits", Algo: module.Incremental},
+			{ID: "learner_proposal_received_count", Name: "learner_proposals", Algo: module.Incremental},
+			{ID: "learner_commit_received_count", Name: "learner_commits", Algo: module.Incremental},
+		},
+	},
+	{
+		ID:    "follower_sync_time",
+		Title: "Follower Sync Time",
+		Units: "ms",
+		Fam:   "quorum",
+		Ctx:   "zookeeper.follower_sync_time",
+		Dims: Dims{
+			{ID: "min_follower_sync_time", Name: "min", Div: 1000},
+			{ID: "avg_follower_sync_time", Name: "avg", Div: 1000},
+			{ID: "max_follower_sync_time", Name: "max", Div: 1000},
+		},
+	},
+	{
+		ID:    "snapshot_time",
+		Title: "Snapshot Write Time",
+		Units: "ms",
+		Fam:   "disk",
+		Ctx:   "zookeeper.snapshot_time",
+		Dims: Dims{
+			{ID: "min_snapshottime", Name: "min", Div: 1000},
+			{ID: "avg_snapshottime", Name: "avg", Div: 1000},
+			{ID: "max_snapshottime", Name: "max", Div: 1000},
+		},
+	},
+	{
+		ID:    "txn_log_fsync_time",
+		Title: "Transaction Log Fsync Time",
+		Units: "ms",
+		Fam:   "disk",
+		Ctx:   "zookeeper.txn_log_fsync_time",
+		Dims: Dims{
+			{ID: "min_fsynctime", Name: "min", Div: 1000},
+			{ID: "avg_fsynctime", Name: "avg", Div: 1000},
+			{ID: "max_fsynctime", Name: "max", Div: 1000},
+		},
+	},
+}
+
+var ensembleMembersStateChart = module.Chart{
+	ID:    "ensemble_members_state",
+	Title: "Ensemble Members State",
+	Units: "state",
+	Fam:   "ensemble",
+	Ctx:   "zookeeper.ensemble_members_state",
+}
+
+// ensembleCharts are used instead of the server charts if 'address' is a list.
+// The members state chart dimensions are added on initialization.
+var ensembleCharts = Charts{
+	ensembleMembersStateChart.Copy(),
+	{
+		ID:    "ensemble_members",
+		Title: "Ensemble Members",
+		Units: "members",
+		Fam:   "ensemble",
+		Ctx:   "zookeeper.ensemble_members",
+		Type:  module.Stacked,
+		Dims: Dims{
+			{ID: "ensemble_members_up", Name: "up"},
+			{ID: "ensemble_members_down", Name: "down"},
+		},
+	},
+	{
+		ID:    "ensemble_nodes",
+		Title: "Ensemble Number of Nodes",
+		Units: "nodes",
+		Fam:   "ensemble",
+		Ctx:   "zookeeper.ensemble_nodes",
+		Dims: Dims{
+			{ID: "ensemble_znode_count", Name: "znode"},
+		},
+	},
+}
+
+// extendedEnsembleCharts are added when at least one member reports their metrics (3.6+).
+var extendedEnsembleCharts = Charts{
+	{
+		ID:    "ensemble_follower_sync_time",
+		Title: "Ensemble Max Follower Sync Time",
+		Units: "ms",
+		Fam:   "ensemble",
+		Ctx:   "zookeeper.ensemble_follower_sync_time",
+		Dims: Dims{
+			{ID: "ensemble_max_follower_sync_time", Name: "max", Div: 1000},
+		},
+	},
+}
@@ -3,18 +3,98 @@
 package zookeeper
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/netdata/go.d.plugin/agent/module"
 )
 
+var errMntrNotInWhitelist = errors.New("'mntr' command is not in the whitelist")
+
 func (z *Zookeeper) collect() (map[string]int64, error) {
-	return z.collectMntr()
+	if len(z.members) == 1 {
+		m := z.members[0]
+		mx, err := z.collectMember(m)
+		if err != nil {
+			return nil, err
+		}
+		z.addExtendedCharts(m, mx)
+		return mx, nil
+	}
+	return z.collectEnsemble()
+}
+
+// serverStateDown is the server state of an ensemble member that failed to respond.
+const serverStateDown = 5
+
+func (z *Zookeeper) collectEnsemble() (map[string]int64, error) {
+	mxs := make([]map[string]int64, len(z.members))
+
+	var wg sync.WaitGroup
+	// the members are collected concurrently, a dead member doesn't block the others
+	for i, m := range z.members {
+		wg.Add(1)
+		go func(i int, m *zkMember) {
+			defer wg.Done()
+
+			mx, err := z.collectMember(m)
+			if err != nil {
+				z.Errorf("member '%s': %v", m.address, err)
+				return
+			}
+			mxs[i] = mx
+		}(i, m)
+	}
+	wg.Wait()
+
+	mx := make(map[string]int64)
+	var up, down int64
+	for i, m := range z.members {
+		mmx := mxs[i]
+		if mmx == nil {
+			down++
+			mx[m.prefix+"server_state"] = serverStateDown
+			continue
+		}
+		up++
+		for k, v := range mmx {
+			mx[m.prefix+k] = v
+		}
+		setMax(mx, "ensemble_znode_count", mmx, "znode_count")
+		setMax(mx, "ensemble_max_follower_sync_time", mmx, "max_follower_sync_time")
+		if z.MemberCharts {
+			z.addExtendedCharts(m, mmx)
+		}
+	}
+	if up == 0 {
+		return nil, errors.New("all ensemble members are down")
+	}
+	mx["ensemble_members_up"] = up
+	mx["ensemble_members_down"] = down
+
+	z.addCharts(extendedEnsembleCharts, mx)
+
+	return mx, nil
 }
 
-func (z *Zookeeper) collectMntr() (map[string]int64, error) {
+// collectMember collects the member metrics, it switches the member to the admin server
+// if the mntr command is not in the whitelist.
+func (z *Zookeeper) collectMember(m *zkMember) (map[string]int64, error) {
+	mx, err := z.collectMntr(m.fetcher)
+	if errors.Is(err, errMntrNotInWhitelist) && m.fetcher != m.adminFetcher {
+		z.Infof("%v (%s), switching to the admin server (%s)", err, m.address, m.adminURL)
+		m.fetcher = m.adminFetcher
+		mx, err = z.collectMntr(m.fetcher)
+	}
+	return mx, err
+}
+
+func (z *Zookeeper) collectMntr(f fetcher) (map[string]int64, error) {
 	const command = "mntr"
-	lines, err := z.fetch("mntr")
+	lines, err := f.fetch(command)
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +102,9 @@ func (z *Zookeeper) collectMntr() (map[string]int64, error) {
 	case 0:
 		return nil, fmt.Errorf("'%s' command returned empty response", command)
 	case 1:
-		// mntr is not executed because it is not in the whitelist.
+		if isNotInWhitelistLine(lines[0]) {
+			return nil, errMntrNotInWhitelist
+		}
 		return nil, fmt.Errorf("'%s' command returned bad response: %s", command, lines[0])
 	}
 
@@ -34,31 +116,82 @@ func (z *Zookeeper) collectMntr() (map[string]int64, error) {
 		}
 
 		key, value := strings.TrimPrefix(parts[0], "zk_"), parts[1]
-		switch key {
-		case "version":
-		case "server_state":
+		if key == "server_state" {
 			mx[key] = convertServerState(value)
-		case "min_latency", "avg_latency", "max_latency":
-			v, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				continue
-			}
-			mx[key] = int64(v * 1000)
-		default:
-			v, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				continue
-			}
-			mx[key] = int64(v)
+			continue
+		}
+		// the keys that are not charted (and the keys of future versions) are ignored
+		mul, ok := mntrKeys[key]
+		if !ok {
+			continue
 		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		mx[key] = int64(v * float64(mul))
 	}
 
 	if len(mx) == 0 {
 		return nil, fmt.Errorf("'%s' command: failed to parse response", command)
 	}
+
 	return mx, nil
 }
 
+// addExtendedCharts adds the member charts of the metrics that are not reported by all ZooKeeper versions (3.6+).
+func (z *Zookeeper) addExtendedCharts(m *zkMember, mx map[string]int64) {
+	z.addCharts(*m.newCharts(&extendedCharts), addPrefix(mx, m.prefix))
+}
+
+func (z *Zookeeper) addCharts(charts Charts, mx map[string]int64) {
+	for _, chart := range charts {
+		if z.addedCharts[chart.ID] || !hasAllDims(mx, chart) {
+			continue
+		}
+		z.addedCharts[chart.ID] = true
+		if err := z.charts.Add(chart.Copy()); err != nil {
+			z.Warning(err)
+		}
+	}
+}
+
+func setMax(mx map[string]int64, key string, mmx map[string]int64, mkey string) {
+	v, ok := mmx[mkey]
+	if !ok {
+		return
+	}
+	if cur, ok := mx[key]; !ok || v > cur {
+		mx[key] = v
+	}
+}
+
+func addPrefix(mx map[string]int64, prefix string) map[string]int64 {
+	if prefix == "" {
+		return mx
+	}
+	pmx := make(map[string]int64, len(mx))
+	for k, v := range mx {
+		pmx[prefix+k] = v
+	}
+	return pmx
+}
+
+func hasAllDims(mx map[string]int64, chart *module.Chart) bool {
+	for _, dim := range chart.Dims {
+		if _, ok := mx[dim.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isNotInWhitelistLine reports whether the line is the response of a four letter word command
+// that is disabled on the server (the "4lw.commands.whitelist" option).
+func isNotInWhitelistLine(line string) bool {
+	return strings.HasSuffix(strings.TrimSpace(line), "is not in the whitelist.")
+}
+
 func convertServerState(state string) int64 {
 	switch state {
 	default:
@@ -48,26 +48,57 @@ func isZKLine(line []byte) bool {
 
 func isMntrLineOK(line []byte) bool {
 	idx := bytes.LastIndexByte(line, '\t')
-	return idx > 0 && collectedZKKeys[unsafeString(line)[:idx]]
+	if idx <= 0 {
+		return false
+	}
+	_, ok := mntrKeys[unsafeString(line)[len("zk_"):idx]]
+	return ok
 }
 
 func unsafeString(b []byte) string {
 	return *((*string)(unsafe.Pointer(&b)))
 }
 
-var collectedZKKeys = map[string]bool{
-	"zk_num_alive_connections":      true,
-	"zk_outstanding_requests":       true,
-	"zk_min_latency":                true,
-	"zk_avg_latency":                true,
-	"zk_max_latency":                true,
-	"zk_packets_received":           true,
-	"zk_packets_sent":               true,
-	"zk_open_file_descriptor_count": true,
-	"zk_max_file_descriptor_count":  true,
-	"zk_znode_count":                true,
-	"zk_ephemerals_count":           true,
-	"zk_watch_count":                true,
-	"zk_approximate_data_size":      true,
-	"zk_server_state":               true,
+// mntrKeys is the allowlist of the charted mntr keys (without the "zk_" prefix).
+// The value is the multiplier that keeps the precision of fractional values.
+var mntrKeys = map[string]int64{
+	"num_alive_connections":      1,
+	"outstanding_requests":       1,
+	"min_latency":                1000,
+	"avg_latency":                1000,
+	"max_latency":                1000,
+	"packets_received":           1,
+	"packets_sent":               1,
+	"open_file_descriptor_count": 1,
+	"max_file_descriptor_count":  1,
+	"znode_count":                1,
+	"ephemerals_count":           1,
+	"watch_count":                1,
+	"approximate_data_size":      1,
+	"server_state":               1,
+	// 3.6+
+	"min_readlatency":                 1000,
+	"avg_readlatency":                 1000,
+	"max_readlatency":                 1000,
+	"min_updatelatency":               1000,
+	"avg_updatelatency":               1000,
+	"max_updatelatency":               1000,
+	"proposal_count":                  1,
+	"commit_count":                    1,
+	"learner_proposal_received_count": 1,
+	"learner_commit_received_count":   1,
+	"min_follower_sync_time":          1000,
+	"avg_follower_sync_time":          1000,
+	"max_follower_sync_time":          1000,
+	"min_snapshottime":                1000,
+	"avg_snapshottime":                1000,
+	"max_snapshottime":                1000,
+	"min_fsynctime":                   1000,
+	"avg_fsynctime":                   1000,
+	"max_fsynctime":                   1000,
+	"stale_sessions_expired":          1,
+	"stale_requests":                  1,
+	"stale_requests_dropped":          1,
+	"request_throttle_wait_count":     1,
+	"large_requests_rejected":         1,
 }
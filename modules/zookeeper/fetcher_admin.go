@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package zookeeper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+// adminServerFetcher fetches the monitor command output from the AdminServer (ZooKeeper 3.5+)
+// and converts it into the mntr lines, so the same parsing and charts are used for both transports.
+type adminServerFetcher struct {
+	httpClient *http.Client
+	request    web.Request
+}
+
+func (f *adminServerFetcher) fetch(_ string) ([]string, error) {
+	req, err := web.NewHTTPRequest(f.request)
+	if err != nil {
+		return nil, fmt.Errorf("error on creating HTTP request to '%s': %v", f.request.URL, err)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error on HTTP request '%s': %v", req.URL, err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("'%s' returned HTTP status code: %d", req.URL, resp.StatusCode)
+	}
+
+	var monitor map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&monitor); err != nil {
+		return nil, fmt.Errorf("error on decoding response from '%s': %v", req.URL, err)
+	}
+	if v, ok := monitor["error"]; ok && v != nil {
+		return nil, fmt.Errorf("'%s' returned error: %v", req.URL, v)
+	}
+
+	var rows []string
+	for key, value := range monitor {
+		if _, ok := mntrKeys[key]; !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			rows = append(rows, "zk_"+key+"\t"+v)
+		case float64:
+			rows = append(rows, "zk_"+key+"\t"+strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	}
+	return rows, nil
+}
+
+func closeBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+}
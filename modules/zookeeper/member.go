@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package zookeeper
+
+import (
+	"regexp"
+
+	"github.com/netdata/go.d.plugin/agent/module"
+)
+
+// Addresses is the list of the ensemble members addresses. It can be set as a single address:
+//
+//	address: 127.0.0.1:2181
+//	address:
+//	  - 203.0.113.10:2181
+//	  - 203.0.113.11:2181
+type Addresses []string
+
+// UnmarshalYAML implements yaml.Unmarshaler, it allows the addresses to be set as a single address.
+func (a *Addresses) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var address string
+	if err := unmarshal(&address); err == nil {
+		*a = Addresses{address}
+		return nil
+	}
+	var addresses []string
+	if err := unmarshal(&addresses); err != nil {
+		return err
+	}
+	*a = addresses
+	return nil
+}
+
+// zkMember is the server the data is collected from, the job has one member unless 'address' is a list.
+type zkMember struct {
+	fetcher
+	address string
+	// prefix is the charts and dimensions IDs prefix, it is empty if the job has one member.
+	prefix string
+	// adminFetcher is used instead of the four letter words fetcher
+	// if the mntr command is not in the whitelist.
+	adminFetcher fetcher
+	adminURL     string
+}
+
+func (m *zkMember) newCharts(charts *module.Charts) *module.Charts {
+	cs := charts.Copy()
+	for _, c := range *cs {
+		m.applyTo(c)
+	}
+	return cs
+}
+
+// applyTo makes the chart the member chart: the IDs are prefixed with the member, the member address is the chart family.
+func (m *zkMember) applyTo(chart *module.Chart) {
+	if m.prefix == "" {
+		return
+	}
+	chart.ID = m.prefix + chart.ID
+	chart.Fam = m.address + " " + chart.Fam
+	chart.Labels = append(chart.Labels, module.Label{Key: "member", Value: m.address})
+	for _, d := range chart.Dims {
+		d.ID = m.prefix + d.ID
+	}
+	for _, v := range chart.Vars {
+		v.ID = m.prefix + v.ID
+	}
+}
+
+var reMemberPrefix = regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+
+func memberPrefix(address string) string {
+	return reMemberPrefix.ReplaceAllString(address, "_") + "_"
+}
@@ -4,7 +4,10 @@ package zookeeper
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"time"
 
 	"github.com/netdata/go.d.plugin/pkg/socket"
@@ -24,20 +27,35 @@ func init() {
 
 // Config is the Zookeeper module configuration.
 type Config struct {
-	Address          string
+	Address          Addresses
 	Timeout          web.Duration `yaml:"timeout"`
 	UseTLS           bool         `yaml:"use_tls"`
 	tlscfg.TLSConfig `yaml:",inline"`
+	UseAdminServer   bool     `yaml:"use_admin_server"`
+	AdminServer      web.HTTP `yaml:"admin_server"`
+	// MemberCharts enables per member charts if 'address' is a list, members metrics are collected anyway
+	// for the ensemble aggregation.
+	MemberCharts bool `yaml:"member_charts"`
 }
 
 // New creates Zookeeper with default values.
 func New() *Zookeeper {
 	config := Config{
-		Address: "127.0.0.1:2181",
+		Address: Addresses{"127.0.0.1:2181"},
 		Timeout: web.Duration{Duration: time.Second},
 		UseTLS:  false,
+		AdminServer: web.HTTP{
+			Client: web.Client{
+				Timeout: web.Duration{Duration: time.Second},
+			},
+		},
+		MemberCharts: true,
+	}
+	return &Zookeeper{
+		Config:      config,
+		charts:      charts.Copy(),
+		addedCharts: make(map[string]bool),
 	}
-	return &Zookeeper{Config: config}
 }
 
 type fetcher interface {
@@ -47,40 +65,143 @@ type fetcher interface {
 // Zookeeper Zookeeper module.
 type Zookeeper struct {
 	module.Base
-	fetcher
 	Config `yaml:",inline"`
+
+	members []*zkMember
+
+	charts      *Charts
+	addedCharts map[string]bool
 }
 
 // Cleanup makes cleanup.
 func (Zookeeper) Cleanup() {}
 
-func (z *Zookeeper) createZookeeperFetcher() (err error) {
+func (z *Zookeeper) createZookeeperFetcher(address string) (fetcher, error) {
 	var tlsConf *tls.Config
 	if z.UseTLS {
+		var err error
 		tlsConf, err = tlscfg.NewTLSConfig(z.TLSConfig)
 		if err != nil {
-			return fmt.Errorf("error on creating tls config : %v", err)
+			return nil, fmt.Errorf("error on creating tls config : %v", err)
+		}
+		// NewTLSConfig returns nil if no TLS options are set, but the secure client port still needs TLS
+		if tlsConf == nil {
+			tlsConf = &tls.Config{}
 		}
 	}
 
 	sock := socket.New(socket.Config{
-		Address:        z.Address,
+		Address:        address,
 		ConnectTimeout: z.Timeout.Duration,
 		ReadTimeout:    z.Timeout.Duration,
 		WriteTimeout:   z.Timeout.Duration,
 		TLSConf:        tlsConf,
 	})
-	z.fetcher = &zookeeperFetcher{Client: sock}
-	return nil
+	return &zookeeperFetcher{Client: sock}, nil
+}
+
+// adminServerURL returns the admin server monitor command URL of the member.
+// The 'admin_server' URL is used as is if the job has one member, otherwise its host is replaced with the member host.
+func (z *Zookeeper) adminServerURL(address string) (string, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", fmt.Errorf("error on parsing address '%s': %v", address, err)
+	}
+	if z.AdminServer.URL == "" {
+		return fmt.Sprintf("http://%s/commands/monitor", net.JoinHostPort(host, "8080")), nil
+	}
+	if len(z.Address) == 1 {
+		return z.AdminServer.URL, nil
+	}
+
+	u, err := url.Parse(z.AdminServer.URL)
+	if err != nil {
+		return "", fmt.Errorf("error on parsing admin server url '%s': %v", z.AdminServer.URL, err)
+	}
+	if port := u.Port(); port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else {
+		u.Host = host
+	}
+	return u.String(), nil
+}
+
+func (z *Zookeeper) initMembers() ([]*zkMember, error) {
+	if len(z.Address) == 0 {
+		return nil, errors.New("address not set")
+	}
+
+	httpClient, err := web.NewHTTPClient(z.AdminServer.Client)
+	if err != nil {
+		return nil, fmt.Errorf("error on creating admin server http client : %v", err)
+	}
+
+	var members []*zkMember
+	seen := make(map[string]bool)
+	for _, address := range z.Address {
+		if seen[address] {
+			return nil, fmt.Errorf("duplicate address '%s'", address)
+		}
+		seen[address] = true
+
+		m := &zkMember{address: address}
+		if len(z.Address) > 1 {
+			m.prefix = memberPrefix(address)
+		}
+
+		if m.adminURL, err = z.adminServerURL(address); err != nil {
+			return nil, err
+		}
+		req := z.AdminServer.Request
+		req.URL = m.adminURL
+		m.adminFetcher = &adminServerFetcher{httpClient: httpClient, request: req}
+
+		if z.UseAdminServer {
+			m.fetcher = m.adminFetcher
+		} else if m.fetcher, err = z.createZookeeperFetcher(address); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+func (z *Zookeeper) initCharts() (*Charts, error) {
+	if len(z.members) == 1 {
+		return charts.Copy(), nil
+	}
+
+	cs := ensembleCharts.Copy()
+	state := cs.Get(ensembleMembersStateChart.ID)
+	for _, m := range z.members {
+		if err := state.AddDim(&module.Dim{ID: m.prefix + "server_state", Name: m.address}); err != nil {
+			return nil, err
+		}
+		if !z.MemberCharts {
+			continue
+		}
+		if err := cs.Add(*m.newCharts(&charts)...); err != nil {
+			return nil, err
+		}
+	}
+	return cs, nil
 }
 
 // Init makes initialization.
 func (z *Zookeeper) Init() bool {
-	err := z.createZookeeperFetcher()
+	members, err := z.initMembers()
+	if err != nil {
+		z.Error(err)
+		return false
+	}
+	z.members = members
+
+	cs, err := z.initCharts()
 	if err != nil {
 		z.Error(err)
 		return false
 	}
+	z.charts = cs
 
 	return true
 }
@@ -91,8 +212,8 @@ func (z *Zookeeper) Check() bool {
 }
 
 // Charts creates Charts.
-func (Zookeeper) Charts() *Charts {
-	return charts.Copy()
+func (z *Zookeeper) Charts() *Charts {
+	return z.charts
 }
 
 // Collect collects metrics.
@@ -6,21 +6,29 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/netdata/go.d.plugin/pkg/socket"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 var (
 	testMntrData, _               = os.ReadFile("testdata/mntr.txt")
 	testMntrNotInWhiteListData, _ = os.ReadFile("testdata/mntr_notinwhitelist.txt")
+	testMonitorData, _            = os.ReadFile("testdata/monitor.json")
 )
 
 func Test_testDataLoad(t *testing.T) {
 	assert.NotNil(t, testMntrData)
 	assert.NotNil(t, testMntrNotInWhiteListData)
+	assert.NotNil(t, testMonitorData)
 }
 
 func TestNew(t *testing.T) {
@@ -33,7 +41,61 @@ func TestZookeeper_Init(t *testing.T) {
 	job := New()
 
 	assert.True(t, job.Init())
-	assert.NotNil(t, job.fetcher)
+	assert.NotNil(t, job.members[0].fetcher)
+}
+
+func TestZookeeper_InitUseTLSWithoutTLSOptions(t *testing.T) {
+	job := New()
+	job.UseTLS = true
+
+	require.True(t, job.Init())
+	require.IsType(t, (*zookeeperFetcher)(nil), job.members[0].fetcher)
+	sock, ok := job.members[0].fetcher.(*zookeeperFetcher).Client.(*socket.Socket)
+	require.True(t, ok)
+	assert.NotNil(t, sock.TLSConf)
+}
+
+func TestZookeeper_InitUseAdminServer(t *testing.T) {
+	job := New()
+	job.Address = Addresses{"203.0.113.10:2181"}
+	job.UseAdminServer = true
+
+	require.True(t, job.Init())
+	assert.IsType(t, (*adminServerFetcher)(nil), job.members[0].fetcher)
+	assert.Equal(t, "http://203.0.113.10:8080/commands/monitor", job.members[0].adminURL)
+}
+
+func TestZookeeper_InitEnsemble(t *testing.T) {
+	job := New()
+	job.Address = Addresses{"203.0.113.10:2181", "203.0.113.11:2181"}
+	job.AdminServer.URL = "https://127.0.0.1:8443/commands/monitor"
+
+	require.True(t, job.Init())
+	require.Len(t, job.members, 2)
+	assert.Equal(t, "203_0_113_11_2181_", job.members[1].prefix)
+	assert.Equal(t, "https://203.0.113.11:8443/commands/monitor", job.members[1].adminURL)
+	assert.Len(t, job.Charts().Get("ensemble_members_state").Dims, 2)
+	assert.True(t, job.Charts().Has("203_0_113_10_2181_requests"))
+	assert.False(t, job.Charts().Has("requests"))
+}
+
+func TestZookeeper_InitEnsembleDuplicateAddress(t *testing.T) {
+	job := New()
+	job.Address = Addresses{"203.0.113.10:2181", "203.0.113.10:2181"}
+
+	assert.False(t, job.Init())
+}
+
+func TestAddresses_UnmarshalYAML(t *testing.T) {
+	var cfg struct {
+		Address Addresses
+	}
+
+	require.NoError(t, yaml.Unmarshal([]byte("address: 127.0.0.1:2181"), &cfg))
+	assert.Equal(t, Addresses{"127.0.0.1:2181"}, cfg.Address)
+
+	require.NoError(t, yaml.Unmarshal([]byte("address: [127.0.0.1:2181, 127.0.0.1:2182]"), &cfg))
+	assert.Equal(t, Addresses{"127.0.0.1:2181", "127.0.0.1:2182"}, cfg.Address)
 }
 
 func TestZookeeper_InitErrorOnCreatingTLSConfig(t *testing.T) {
@@ -47,7 +109,7 @@ func TestZookeeper_InitErrorOnCreatingTLSConfig(t *testing.T) {
 func TestZookeeper_Check(t *testing.T) {
 	job := New()
 	require.True(t, job.Init())
-	job.fetcher = &mockZookeeperFetcher{data: testMntrData}
+	job.members[0].fetcher = &mockZookeeperFetcher{data: testMntrData}
 
 	assert.True(t, job.Check())
 }
@@ -55,7 +117,7 @@ func TestZookeeper_Check(t *testing.T) {
 func TestZookeeper_CheckErrorOnFetch(t *testing.T) {
 	job := New()
 	require.True(t, job.Init())
-	job.fetcher = &mockZookeeperFetcher{err: true}
+	job.members[0].fetcher = &mockZookeeperFetcher{err: true}
 
 	assert.False(t, job.Check())
 }
@@ -71,35 +133,217 @@ func TestZookeeper_Cleanup(t *testing.T) {
 func TestZookeeper_Collect(t *testing.T) {
 	job := New()
 	require.True(t, job.Init())
-	job.fetcher = &mockZookeeperFetcher{data: testMntrData}
+	job.members[0].fetcher = &mockZookeeperFetcher{data: testMntrData}
+
+	expected := map[string]int64{
+		"approximate_data_size":           44,
+		"avg_follower_sync_time":          0,
+		"avg_fsynctime":                   0,
+		"avg_latency":                     100,
+		"avg_readlatency":                 0,
+		"avg_snapshottime":                0,
+		"avg_updatelatency":               0,
+		"commit_count":                    0,
+		"ephemerals_count":                0,
+		"large_requests_rejected":         0,
+		"learner_commit_received_count":   0,
+		"learner_proposal_received_count": 0,
+		"max_file_descriptor_count":       1048576,
+		"max_follower_sync_time":          0,
+		"max_fsynctime":                   0,
+		"max_latency":                     100,
+		"max_readlatency":                 0,
+		"max_snapshottime":                0,
+		"max_updatelatency":               0,
+		"min_follower_sync_time":          0,
+		"min_fsynctime":                   0,
+		"min_latency":                     100,
+		"min_readlatency":                 0,
+		"min_snapshottime":                0,
+		"min_updatelatency":               0,
+		"num_alive_connections":           1,
+		"open_file_descriptor_count":      63,
+		"outstanding_requests":            0,
+		"packets_received":                92,
+		"packets_sent":                    182,
+		"proposal_count":                  0,
+		"request_throttle_wait_count":     0,
+		"server_state":                    4,
+		"stale_requests":                  0,
+		"stale_requests_dropped":          0,
+		"stale_sessions_expired":          0,
+		"watch_count":                     0,
+		"znode_count":                     5,
+	}
+
+	collected := job.Collect()
+
+	assert.Equal(t, expected, collected)
+	ensureCollectedHasAllChartsDimsVarsIDs(t, job, collected)
+	for _, chart := range extendedCharts {
+		assert.Truef(t, job.Charts().Has(chart.ID), "chart '%s' is not added", chart.ID)
+	}
+}
+
+func TestZookeeper_Collect_ExtendedMetrics(t *testing.T) {
+	job := New()
+	require.True(t, job.Init())
+	job.members[0].fetcher = &mockZookeeperFetcher{data: []byte(`zk_version	3.7.1-a2fb57c55f8e59cdd76c34b357ad5181df1258d5, built on 2022-05-07 06:45 UTC
+zk_server_state	leader
+zk_avg_latency	0.4781
+zk_min_latency	0
+zk_max_latency	23
+zk_avg_readlatency	0.25
+zk_min_readlatency	0
+zk_max_readlatency	11
+zk_avg_updatelatency	2.5
+zk_min_updatelatency	1
+zk_max_updatelatency	23
+zk_proposal_count	120
+zk_commit_count	118
+zk_avg_fsynctime	1.5
+zk_min_fsynctime	1
+zk_max_fsynctime	7
+zk_stale_sessions_expired	3
+zk_some_future_metric	42
+zk_another_future_metric	not a number
+`)}
+
+	collected := job.Collect()
 
 	expected := map[string]int64{
-		"approximate_data_size":      44,
-		"avg_latency":                100,
-		"ephemerals_count":           0,
-		"max_file_descriptor_count":  1048576,
-		"max_latency":                100,
-		"min_latency":                100,
-		"num_alive_connections":      1,
-		"open_file_descriptor_count": 63,
-		"outstanding_requests":       0,
-		"packets_received":           92,
-		"packets_sent":               182,
-		"server_state":               4,
-		"watch_count":                0,
-		"znode_count":                5,
+		"avg_latency":            478,
+		"min_latency":            0,
+		"max_latency":            23000,
+		"avg_readlatency":        250,
+		"min_readlatency":        0,
+		"max_readlatency":        11000,
+		"avg_updatelatency":      2500,
+		"min_updatelatency":      1000,
+		"max_updatelatency":      23000,
+		"proposal_count":         120,
+		"commit_count":           118,
+		"avg_fsynctime":          1500,
+		"min_fsynctime":          1000,
+		"max_fsynctime":          7000,
+		"server_state":           1,
+		"stale_sessions_expired": 3,
+	}
+	assert.Equal(t, expected, collected)
+
+	for _, id := range []string{"read_latency", "update_latency", "txn_log_fsync_time", "stale_sessions"} {
+		assert.Truef(t, job.Charts().Has(id), "chart '%s' is not added", id)
 	}
+	// not all metrics of these charts are reported
+	for _, id := range []string{"proposals_commits", "snapshot_time", "stale_requests", "throttled_requests"} {
+		assert.Falsef(t, job.Charts().Has(id), "chart '%s' is added", id)
+	}
+}
+
+func TestZookeeper_CollectEnsemble(t *testing.T) {
+	job := New()
+	job.Address = Addresses{"127.0.0.1:2181", "127.0.0.1:2182", "127.0.0.1:2183"}
+	require.True(t, job.Init())
+	job.members[0].fetcher = &mockZookeeperFetcher{data: []byte("zk_server_state	leader\nzk_znode_count	10\nzk_max_follower_sync_time	7\n")}
+	job.members[1].fetcher = &mockZookeeperFetcher{data: []byte("zk_server_state	follower\nzk_znode_count	9\nzk_max_follower_sync_time	12\n")}
+	job.members[2].fetcher = &mockZookeeperFetcher{err: true}
 
 	collected := job.Collect()
 
+	expected := map[string]int64{
+		"127_0_0_1_2181_server_state":           1,
+		"127_0_0_1_2181_znode_count":            10,
+		"127_0_0_1_2181_max_follower_sync_time": 7000,
+		"127_0_0_1_2182_server_state":           2,
+		"127_0_0_1_2182_znode_count":            9,
+		"127_0_0_1_2182_max_follower_sync_time": 12000,
+		"127_0_0_1_2183_server_state":           serverStateDown,
+		"ensemble_members_up":                   2,
+		"ensemble_members_down":                 1,
+		"ensemble_znode_count":                  10,
+		"ensemble_max_follower_sync_time":       12000,
+	}
 	assert.Equal(t, expected, collected)
+	assert.True(t, job.Charts().Has("ensemble_follower_sync_time"))
+}
+
+func TestZookeeper_CollectEnsembleWithoutMemberCharts(t *testing.T) {
+	job := New()
+	job.Address = Addresses{"127.0.0.1:2181", "127.0.0.1:2182"}
+	job.MemberCharts = false
+	require.True(t, job.Init())
+	job.members[0].fetcher = &mockZookeeperFetcher{data: testMntrData}
+	job.members[1].fetcher = &mockZookeeperFetcher{data: testMntrData}
+
+	collected := job.Collect()
+
+	require.NotNil(t, collected)
+	assert.Equal(t, int64(5), collected["ensemble_znode_count"])
 	ensureCollectedHasAllChartsDimsVarsIDs(t, job, collected)
+	for _, chart := range *job.Charts() {
+		assert.Truef(t, strings.HasPrefix(chart.ID, "ensemble_"), "chart '%s' is not an ensemble chart", chart.ID)
+	}
+}
+
+func TestZookeeper_CollectEnsembleAllMembersDown(t *testing.T) {
+	job := New()
+	job.Address = Addresses{"127.0.0.1:2181", "127.0.0.1:2182"}
+	require.True(t, job.Init())
+	job.members[0].fetcher = &mockZookeeperFetcher{err: true}
+	job.members[1].fetcher = &mockZookeeperFetcher{err: true}
+
+	assert.Nil(t, job.Collect())
 }
 
 func TestZookeeper_CollectMntrNotInWhiteList(t *testing.T) {
 	job := New()
 	require.True(t, job.Init())
-	job.fetcher = &mockZookeeperFetcher{data: testMntrNotInWhiteListData}
+	job.members[0].fetcher = &mockZookeeperFetcher{data: testMntrNotInWhiteListData}
+	job.members[0].adminFetcher = &mockZookeeperFetcher{err: true}
+
+	assert.Nil(t, job.Collect())
+}
+
+func TestZookeeper_CollectAdminServer(t *testing.T) {
+	srv := newAdminServer(testMonitorData)
+	defer srv.Close()
+
+	job := New()
+	job.UseAdminServer = true
+	job.AdminServer.URL = srv.URL + "/commands/monitor"
+	require.True(t, job.Init())
+
+	mntr := New()
+	require.True(t, mntr.Init())
+	mntr.members[0].fetcher = &mockZookeeperFetcher{data: testMntrData}
+
+	assert.Equal(t, mntr.Collect(), job.Collect())
+}
+
+func TestZookeeper_CollectMntrNotInWhiteListFallbackToAdminServer(t *testing.T) {
+	srv := newAdminServer(testMonitorData)
+	defer srv.Close()
+
+	job := New()
+	job.AdminServer.URL = srv.URL + "/commands/monitor"
+	require.True(t, job.Init())
+	job.members[0].fetcher = &mockZookeeperFetcher{data: testMntrNotInWhiteListData}
+
+	collected := job.Collect()
+
+	require.NotNil(t, collected)
+	assert.Equal(t, int64(4), collected["server_state"])
+	assert.IsType(t, (*adminServerFetcher)(nil), job.members[0].fetcher)
+}
+
+func TestZookeeper_CollectAdminServerInvalidData(t *testing.T) {
+	srv := newAdminServer([]byte("hello and goodbye"))
+	defer srv.Close()
+
+	job := New()
+	job.UseAdminServer = true
+	job.AdminServer.URL = srv.URL + "/commands/monitor"
+	require.True(t, job.Init())
 
 	assert.Nil(t, job.Collect())
 }
@@ -107,7 +351,7 @@ func TestZookeeper_CollectMntrNotInWhiteList(t *testing.T) {
 func TestZookeeper_CollectMntrEmptyResponse(t *testing.T) {
 	job := New()
 	require.True(t, job.Init())
-	job.fetcher = &mockZookeeperFetcher{}
+	job.members[0].fetcher = &mockZookeeperFetcher{}
 
 	assert.Nil(t, job.Collect())
 }
@@ -115,7 +359,7 @@ func TestZookeeper_CollectMntrEmptyResponse(t *testing.T) {
 func TestZookeeper_CollectMntrInvalidData(t *testing.T) {
 	job := New()
 	require.True(t, job.Init())
-	job.fetcher = &mockZookeeperFetcher{data: []byte("hello \nand good buy\n")}
+	job.members[0].fetcher = &mockZookeeperFetcher{data: []byte("hello \nand good buy\n")}
 
 	assert.Nil(t, job.Collect())
 }
@@ -123,7 +367,7 @@ func TestZookeeper_CollectMntrInvalidData(t *testing.T) {
 func TestZookeeper_CollectMntrReceiveError(t *testing.T) {
 	job := New()
 	require.True(t, job.Init())
-	job.fetcher = &mockZookeeperFetcher{err: true}
+	job.members[0].fetcher = &mockZookeeperFetcher{err: true}
 
 	assert.Nil(t, job.Collect())
 }
@@ -144,6 +388,17 @@ func ensureCollectedHasAllChartsDimsVarsIDs(t *testing.T, zk *Zookeeper, collect
 	}
 }
 
+func newAdminServer(data []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/commands/monitor" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}))
+}
+
 type mockZookeeperFetcher struct {
 	data []byte
 	err  bool
@@ -49,8 +49,15 @@ var (
 // IPv4 CIDR ("192.0.2.0/24"), IPv4 subnet mask ("192.0.2.0/255.255.255.0"),
 // IPv6 address ("2001:db8::1"), IPv6 range ("2001:db8::-2001:db8::10"),
 // or IPv6 CIDR ("2001:db8::/64") form.
+// IPv6 addresses can have a zone identifier ("fe80::1%eth0", "fe80::%eth0/64"), the range zone is the same for all addresses.
 // IPv4 CIDR, IPv4 subnet mask and IPv6 CIDR ranges don't include network and broadcast addresses.
 func ParseRange(s string) (Range, error) {
+	in := s
+	s, zone, ok := cutZone(s)
+	if !ok {
+		return nil, fmt.Errorf("ip range (%s) invalid zone", in)
+	}
+
 	s = strings.ToLower(s)
 	if s == "" {
 		return nil, nil
@@ -69,9 +76,50 @@ func ParseRange(s string) (Range, error) {
 	if r == nil {
 		return nil, fmt.Errorf("ip range (%s) invalid syntax", s)
 	}
+
+	if zone != "" {
+		v6, ok := r.(v6Range)
+		if !ok {
+			return nil, fmt.Errorf("ip range (%s) zone is only allowed for IPv6 addresses", in)
+		}
+		v6.zone = zone
+		r = v6
+	}
+
 	return r, nil
 }
 
+// cutZone removes the IPv6 zone identifiers from s, returning the result and the zone.
+// It returns false if the zone is empty or the addresses have different zones.
+func cutZone(s string) (string, string, bool) {
+	if strings.IndexByte(s, '%') == -1 {
+		return s, "", true
+	}
+
+	var b strings.Builder
+	var zone string
+	for {
+		idx := strings.IndexByte(s, '%')
+		if idx == -1 {
+			b.WriteString(s)
+			return b.String(), zone, true
+		}
+		b.WriteString(s[:idx])
+		s = s[idx+1:]
+
+		end := strings.IndexAny(s, "-/")
+		if end == -1 {
+			end = len(s)
+		}
+		z := s[:end]
+		if z == "" || (zone != "" && z != zone) {
+			return "", "", false
+		}
+		zone = z
+		s = s[end:]
+	}
+}
+
 func parseRange(s string) Range {
 	var start, end net.IP
 	if idx := strings.IndexByte(s, '-'); idx != -1 {
@@ -235,6 +235,38 @@ func TestParseRange(t *testing.T) {
 			input:   "2001:db8::/999",
 			wantErr: true,
 		},
+		"v6 IP: zone": {
+			input:     "fe80::1%eth0",
+			wantRange: prepareZoneRange("fe80::1", "fe80::1", "eth0"),
+		},
+		"v6 IP: zone is case sensitive": {
+			input:     "FE80::1%Ethernet0",
+			wantRange: prepareZoneRange("fe80::1", "fe80::1", "Ethernet0"),
+		},
+		"v6 Range: zone": {
+			input:     "fe80::1%eth0-fe80::10%eth0",
+			wantRange: prepareZoneRange("fe80::1", "fe80::10", "eth0"),
+		},
+		"v6 Range: zone of the end address": {
+			input:     "fe80::1-fe80::10%eth0",
+			wantRange: prepareZoneRange("fe80::1", "fe80::10", "eth0"),
+		},
+		"v6 Range: different zones": {
+			input:   "fe80::1%eth0-fe80::10%eth1",
+			wantErr: true,
+		},
+		"v6 CIDR: zone": {
+			input:     "fe80::%eth0/126",
+			wantRange: prepareZoneRange("fe80::1", "fe80::2", "eth0"),
+		},
+		"v6 IP: empty zone": {
+			input:   "fe80::1%",
+			wantErr: true,
+		},
+		"v4 IP: zone": {
+			input:   "192.0.2.1%eth0",
+			wantErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -256,3 +288,9 @@ func TestParseRange(t *testing.T) {
 func prepareRange(start, end string) Range {
 	return New(net.ParseIP(start), net.ParseIP(end))
 }
+
+func prepareZoneRange(start, end, zone string) Range {
+	r := New(net.ParseIP(start), net.ParseIP(end)).(v6Range)
+	r.zone = zone
+	return r
+}
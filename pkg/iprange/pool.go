@@ -3,8 +3,10 @@
 package iprange
 
 import (
+	"bytes"
 	"math/big"
 	"net"
+	"sort"
 	"strings"
 )
 
@@ -38,3 +40,223 @@ func (p Pool) Contains(ip net.IP) bool {
 	}
 	return false
 }
+
+// Iterator returns an iterator over the pool IP addresses.
+func (p Pool) Iterator() *Iterator {
+	return FilteredPool{Include: p}.Iterator()
+}
+
+// FilteredPool is a collection of IP Ranges with exclusions.
+type FilteredPool struct {
+	// Include is the list of the pool IP Ranges.
+	Include Pool
+	// Exclude is the list of the IP Ranges excluded from the pool.
+	Exclude Pool
+}
+
+// ParseFilteredPool parses include and exclude as space separated lists of IP Ranges (see ParseRanges),
+// returning the pool and an error if any.
+func ParseFilteredPool(include, exclude string) (FilteredPool, error) {
+	in, err := ParseRanges(include)
+	if err != nil {
+		return FilteredPool{}, err
+	}
+	ex, err := ParseRanges(exclude)
+	if err != nil {
+		return FilteredPool{}, err
+	}
+	return FilteredPool{Include: in, Exclude: ex}, nil
+}
+
+// String returns the string form of the pool.
+func (p FilteredPool) String() string {
+	if len(p.Exclude) == 0 {
+		return p.Include.String()
+	}
+	return p.Include.String() + " except " + p.Exclude.String()
+}
+
+// Size reports the number of IP addresses in the pool (excluded addresses are not counted).
+func (p FilteredPool) Size() *big.Int {
+	excluded := mergeRanges(p.Exclude)
+
+	size := big.NewInt(0)
+	for _, r := range p.Include {
+		size.Add(size, r.Size())
+
+		start, end := bounds(r)
+		for _, ex := range excluded {
+			if ex.family != r.Family() {
+				continue
+			}
+			s, e := maxIP(start, ex.start), minIP(end, ex.end)
+			if bytes.Compare(s, e) <= 0 {
+				size.Sub(size, ipsBetween(s, e))
+			}
+		}
+	}
+	return size
+}
+
+// Contains reports whether the pool includes IP and doesn't exclude it.
+func (p FilteredPool) Contains(ip net.IP) bool {
+	return !p.Exclude.Contains(ip) && p.Include.Contains(ip)
+}
+
+// Iterator returns an iterator over the pool IP addresses (excluded addresses are skipped).
+func (p FilteredPool) Iterator() *Iterator {
+	return &Iterator{include: p.Include, excluded: mergeRanges(p.Exclude)}
+}
+
+// Iterator lazily iterates over the pool IP addresses, it doesn't materialize the ranges.
+//
+//	it := pool.Iterator()
+//	for it.Next() {
+//		ip := it.IP()
+//	}
+type Iterator struct {
+	include  Pool
+	excluded []bound
+
+	idx  int
+	curr net.IP
+	end  net.IP
+	zone string
+	fam  Family
+}
+
+// Next advances the iterator to the next IP address, it returns false when there are no more addresses.
+func (it *Iterator) Next() bool {
+	for {
+		switch {
+		case it.curr == nil:
+			if it.idx >= len(it.include) {
+				return false
+			}
+			r := it.include[it.idx]
+			it.idx++
+			it.curr, it.end = bounds(r)
+			it.fam, it.zone = r.Family(), zoneOf(r)
+		case bytes.Equal(it.curr, it.end):
+			it.curr = nil
+			continue
+		default:
+			it.curr = incIP(it.curr)
+		}
+
+		// skip the excluded addresses at once
+		if end, ok := it.excludedUntil(); ok {
+			if bytes.Compare(end, it.end) >= 0 {
+				it.curr = nil
+			} else {
+				it.curr = end
+			}
+			continue
+		}
+
+		return true
+	}
+}
+
+// IP returns the current IP address.
+func (it *Iterator) IP() net.IP {
+	return it.curr
+}
+
+// Zone returns the IPv6 zone of the current IP address range, it is empty if there is no zone.
+func (it *Iterator) Zone() string {
+	return it.zone
+}
+
+func (it *Iterator) excludedUntil() (net.IP, bool) {
+	for _, ex := range it.excluded {
+		if ex.family == it.fam && bytes.Compare(it.curr, ex.start) >= 0 && bytes.Compare(it.curr, ex.end) <= 0 {
+			return ex.end, true
+		}
+	}
+	return nil, false
+}
+
+// bound is a normalized (IPv4 addresses are 4 bytes long) range start and end.
+type bound struct {
+	family     Family
+	start, end net.IP
+}
+
+// mergeRanges returns the ranges bounds sorted and merged (overlapping and adjacent ranges are joined).
+func mergeRanges(rs []Range) []bound {
+	bs := make([]bound, 0, len(rs))
+	for _, r := range rs {
+		start, end := bounds(r)
+		bs = append(bs, bound{family: r.Family(), start: start, end: end})
+	}
+
+	sort.Slice(bs, func(i, j int) bool {
+		if bs[i].family != bs[j].family {
+			return bs[i].family < bs[j].family
+		}
+		return bytes.Compare(bs[i].start, bs[j].start) < 0
+	})
+
+	var merged []bound
+	for _, b := range bs {
+		if n := len(merged); n > 0 && merged[n-1].family == b.family {
+			last := &merged[n-1]
+			if next := incIP(last.end); next == nil || bytes.Compare(b.start, next) <= 0 {
+				last.end = maxIP(last.end, b.end)
+				continue
+			}
+		}
+		merged = append(merged, b)
+	}
+	return merged
+}
+
+func bounds(r Range) (net.IP, net.IP) {
+	switch r := r.(type) {
+	case v4Range:
+		return r.start.To4(), r.end.To4()
+	case v6Range:
+		return r.start.To16(), r.end.To16()
+	}
+	return nil, nil
+}
+
+func zoneOf(r Range) string {
+	if r, ok := r.(v6Range); ok {
+		return r.zone
+	}
+	return ""
+}
+
+// incIP returns the next IP address, it returns nil if the IP address is the last one.
+func incIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		if next[i]++; next[i] != 0 {
+			return next
+		}
+	}
+	return nil
+}
+
+func ipsBetween(start, end net.IP) *big.Int {
+	n := big.NewInt(0).SetBytes(end)
+	n.Sub(n, big.NewInt(0).SetBytes(start))
+	return n.Add(n, big.NewInt(1))
+}
+
+func maxIP(a, b net.IP) net.IP {
+	if bytes.Compare(a, b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func minIP(a, b net.IP) net.IP {
+	if bytes.Compare(a, b) <= 0 {
+		return a
+	}
+	return b
+}
@@ -102,3 +102,136 @@ func TestPool_Contains(t *testing.T) {
 		})
 	}
 }
+
+func TestFilteredPool(t *testing.T) {
+	tests := map[string]struct {
+		include    string
+		exclude    string
+		wantString string
+		wantSize   *big.Int
+		contains   []string
+		excluded   []string
+	}{
+		"no exclusions": {
+			include:    "10.0.0.0/24",
+			wantString: "10.0.0.1-10.0.0.254",
+			wantSize:   big.NewInt(254),
+			contains:   []string{"10.0.0.1", "10.0.0.254"},
+			excluded:   []string{"10.0.0.0", "10.0.1.1"},
+		},
+		"exclude range": {
+			include:    "10.0.0.0/24",
+			exclude:    "10.0.0.1-10.0.0.10",
+			wantString: "10.0.0.1-10.0.0.254 except 10.0.0.1-10.0.0.10",
+			wantSize:   big.NewInt(244),
+			contains:   []string{"10.0.0.11", "10.0.0.254"},
+			excluded:   []string{"10.0.0.1", "10.0.0.10"},
+		},
+		"overlapping exclusions": {
+			include:  "10.0.0.0/24",
+			exclude:  "10.0.0.1-10.0.0.10 10.0.0.5-10.0.0.20 10.0.0.21",
+			wantSize: big.NewInt(233),
+			contains: []string{"10.0.0.22"},
+			excluded: []string{"10.0.0.1", "10.0.0.15", "10.0.0.21"},
+		},
+		"exclusion outside of the pool": {
+			include:  "10.0.0.0/24",
+			exclude:  "10.0.1.0/24 2001:db8::1",
+			wantSize: big.NewInt(254),
+			contains: []string{"10.0.0.1"},
+		},
+		"exclude IPv6": {
+			include:  "10.0.0.1-10.0.0.2 2001:db8::/64",
+			exclude:  "2001:db8::-2001:db8::ff",
+			wantSize: big.NewInt(0).Sub(big.NewInt(0).Lsh(big.NewInt(1), 64), big.NewInt(255)),
+			contains: []string{"10.0.0.1", "2001:db8::100"},
+			excluded: []string{"2001:db8::1", "2001:db8::ff"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := ParseFilteredPool(test.include, test.exclude)
+			require.NoError(t, err)
+
+			if test.wantString != "" {
+				assert.Equal(t, test.wantString, p.String())
+			}
+			assert.Equal(t, test.wantSize, p.Size())
+			for _, ip := range test.contains {
+				assert.Truef(t, p.Contains(net.ParseIP(ip)), "contains %s", ip)
+			}
+			for _, ip := range test.excluded {
+				assert.Falsef(t, p.Contains(net.ParseIP(ip)), "contains %s", ip)
+			}
+		})
+	}
+}
+
+func TestParseFilteredPool_Error(t *testing.T) {
+	_, err := ParseFilteredPool("10.0.0.0/24", "10.0.0.")
+	assert.Error(t, err)
+	_, err = ParseFilteredPool("10.0.0.", "")
+	assert.Error(t, err)
+}
+
+func TestFilteredPool_Iterator(t *testing.T) {
+	tests := map[string]struct {
+		include  string
+		exclude  string
+		wantIPs  []string
+		wantZone string
+	}{
+		"empty pool": {},
+		"single address": {
+			include: "192.0.2.1",
+			wantIPs: []string{"192.0.2.1"},
+		},
+		"ranges with exclusions": {
+			include: "192.0.2.0-192.0.2.5 192.0.2.10-192.0.2.11 2001:db8::-2001:db8::2",
+			exclude: "192.0.2.1-192.0.2.2 192.0.2.4 192.0.2.5 192.0.2.11 2001:db8::1",
+			wantIPs: []string{"192.0.2.0", "192.0.2.3", "192.0.2.10", "2001:db8::", "2001:db8::2"},
+		},
+		"everything excluded": {
+			include: "192.0.2.0/24",
+			exclude: "192.0.2.0-192.0.2.255",
+		},
+		"last address": {
+			include: "255.255.255.254-255.255.255.255",
+			wantIPs: []string{"255.255.255.254", "255.255.255.255"},
+		},
+		"zone": {
+			include:  "fe80::1%eth0-fe80::2%eth0",
+			wantIPs:  []string{"fe80::1", "fe80::2"},
+			wantZone: "eth0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := ParseFilteredPool(test.include, test.exclude)
+			require.NoError(t, err)
+
+			var ips []string
+			it := p.Iterator()
+			for it.Next() {
+				ips = append(ips, it.IP().String())
+				assert.Equal(t, test.wantZone, it.Zone())
+			}
+
+			assert.Equal(t, test.wantIPs, ips)
+			assert.False(t, it.Next())
+		})
+	}
+}
+
+func TestFilteredPool_Iterator_HugeRange(t *testing.T) {
+	p, err := ParseFilteredPool("2001:db8::/32", "2001:db8::-2001:db8:7fff:ffff:ffff:ffff:ffff:ffff")
+	require.NoError(t, err)
+
+	it := p.Iterator()
+	require.True(t, it.Next())
+	assert.Equal(t, "2001:db8:8000::", it.IP().String())
+	require.True(t, it.Next())
+	assert.Equal(t, "2001:db8:8000::1", it.IP().String())
+}
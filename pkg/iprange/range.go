@@ -57,7 +57,10 @@ func (r v4Range) Family() Family {
 
 // Contains reports whether the range includes IP.
 func (r v4Range) Contains(ip net.IP) bool {
-	return bytes.Compare(ip, r.start) >= 0 && bytes.Compare(ip, r.end) <= 0
+	if ip = ip.To4(); ip == nil {
+		return false
+	}
+	return bytes.Compare(ip, r.start.To4()) >= 0 && bytes.Compare(ip, r.end.To4()) <= 0
 }
 
 // Size reports the number of IP addresses in the range.
@@ -68,10 +71,15 @@ func (r v4Range) Size() *big.Int {
 type v6Range struct {
 	start net.IP
 	end   net.IP
+	// zone is the IPv6 scoped addressing zone ("fe80::1%eth0"), it is not taken into account by Contains.
+	zone string
 }
 
 // String returns the string form of the range.
 func (r v6Range) String() string {
+	if r.zone != "" {
+		return fmt.Sprintf("%s%%%s-%s%%%s", r.start, r.zone, r.end, r.zone)
+	}
 	return fmt.Sprintf("%s-%s", r.start, r.end)
 }
 
@@ -82,6 +90,12 @@ func (r v6Range) Family() Family {
 
 // Contains reports whether the range includes IP.
 func (r v6Range) Contains(ip net.IP) bool {
+	if isV4IP(ip) {
+		return false
+	}
+	if ip = ip.To16(); ip == nil {
+		return false
+	}
 	return bytes.Compare(ip, r.start) >= 0 && bytes.Compare(ip, r.end) <= 0
 }
 
@@ -36,6 +36,8 @@ type (
 	csvField struct {
 		name string
 		idx  int
+		// span is the number of the following record tokens the field value continues into.
+		span int
 	}
 )
 
@@ -84,7 +86,11 @@ func (f *csvFormat) parse(record []string, line LogLine) error {
 	}
 
 	for _, v := range f.fields {
-		if err := line.Assign(v.name, record[v.idx]); err != nil {
+		value := record[v.idx]
+		if v.span > 0 {
+			value = strings.Join(record[v.idx:v.idx+v.span+1], " ")
+		}
+		if err := line.Assign(v.name, value); err != nil {
 			return &ParseError{msg: fmt.Sprintf("csv parse: %v", err), err: err}
 		}
 	}
@@ -129,7 +135,7 @@ func newCSVFormat(config CSVConfig) (*csvFormat, error) {
 
 	format := &csvFormat{
 		raw:      config.Format,
-		maxIndex: fields[len(fields)-1].idx,
+		maxIndex: fields[len(fields)-1].idx + fields[len(fields)-1].span,
 		fields:   fields,
 	}
 	return format, nil
@@ -146,7 +152,10 @@ func createCSVFields(format []string, check func(string) (string, int, bool)) ([
 	for i, name := range format {
 		name = strings.Trim(name, `"`)
 
+		// a valid field with an offset takes the next offset tokens of the record as well,
+		// an invalid one just shifts the following fields.
 		name, addOffset, valid := check(name)
+		idx := i + offset
 		offset += addOffset
 		if !valid {
 			continue
@@ -156,8 +165,7 @@ func createCSVFields(format []string, check func(string) (string, int, bool)) ([
 		}
 		seen[name] = true
 
-		idx := i + offset
-		fields = append(fields, csvField{name, idx})
+		fields = append(fields, csvField{name, idx, addOffset})
 	}
 	return fields, nil
 }
@@ -50,13 +50,15 @@ func TestNewCSVFormat(t *testing.T) {
 		wantFormat csvFormat
 		wantErr    bool
 	}{
-		{format: "$A $B", wantFormat: csvFormat{maxIndex: 1, fields: []csvField{{"$A", 0}, {"$B", 1}}}},
-		{format: "$A $B !C $E", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$A", 0}, {"$B", 1}, {"$E", 3}}}},
-		{format: "!A !B !C $E", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$E", 3}}}},
-		{format: "$A $OFFSET $B", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$A", 0}, {"$B", 3}}}},
-		{format: "$A $OFFSET $B $OFFSET !A", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$A", 0}, {"$B", 3}}}},
-		{format: "$A $OFFSET $OFFSET $B", wantFormat: csvFormat{maxIndex: 5, fields: []csvField{{"$A", 0}, {"$B", 5}}}},
-		{format: "$OFFSET $A $OFFSET $B", wantFormat: csvFormat{maxIndex: 5, fields: []csvField{{"$A", 2}, {"$B", 5}}}},
+		{format: "$A $B", wantFormat: csvFormat{maxIndex: 1, fields: []csvField{{"$A", 0, 0}, {"$B", 1, 0}}}},
+		{format: "$A $B !C $E", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$A", 0, 0}, {"$B", 1, 0}, {"$E", 3, 0}}}},
+		{format: "!A !B !C $E", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$E", 3, 0}}}},
+		{format: "$A $OFFSET $B", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$A", 0, 0}, {"$B", 3, 0}}}},
+		{format: "$A $OFFSET $B $OFFSET !A", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$A", 0, 0}, {"$B", 3, 0}}}},
+		{format: "$A $OFFSET $OFFSET $B", wantFormat: csvFormat{maxIndex: 5, fields: []csvField{{"$A", 0, 0}, {"$B", 5, 0}}}},
+		{format: "$OFFSET $A $OFFSET $B", wantFormat: csvFormat{maxIndex: 5, fields: []csvField{{"$A", 2, 0}, {"$B", 5, 0}}}},
+		{format: "$A $SPAN $B", wantFormat: csvFormat{maxIndex: 3, fields: []csvField{{"$A", 0, 0}, {"$SPAN", 1, 1}, {"$B", 3, 0}}}},
+		{format: "$A $SPAN", wantFormat: csvFormat{maxIndex: 2, fields: []csvField{{"$A", 0, 0}, {"$SPAN", 1, 1}}}},
 		{format: "$A \"$A", wantErr: true},
 		{format: "$A $A", wantErr: true},
 		{format: "!A !A", wantErr: true},
@@ -158,6 +160,22 @@ func TestCSVParser_Parse(t *testing.T) {
 
 }
 
+func TestCSVParser_Parse_SpanField(t *testing.T) {
+	c := testCSVConfig
+	c.Format = `$A $SPAN $B`
+	c.CheckField = testCheckCSVFormatField
+	p, err := NewCSVParser(c, nil)
+	require.NoError(t, err)
+
+	line := newLogLine()
+	require.NoError(t, p.Parse([]byte(`1 [10/Oct/2000:13:55:36 -0700] 3`), line))
+
+	expected := map[string]string{"$A": "1", "$SPAN": "[10/Oct/2000:13:55:36 -0700]", "$B": "3"}
+	assert.Equal(t, expected, line.assigned)
+
+	assert.True(t, IsParseError(p.Parse([]byte(`1 [10/Oct/2000:13:55:36`), newLogLine())))
+}
+
 func TestCSVParser_Info(t *testing.T) {
 	p, err := NewCSVParser(testCSVConfig, nil)
 	require.NoError(t, err)
@@ -171,5 +189,8 @@ func testCheckCSVFormatField(name string) (newName string, offset int, valid boo
 	if name == "$OFFSET" {
 		return "", 1, false
 	}
+	if name == "$SPAN" {
+		return name, 1, true
+	}
 	return name, 0, true
 }
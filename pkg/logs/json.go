@@ -6,26 +6,41 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"math"
+	"strconv"
 
 	"github.com/valyala/fastjson"
 )
 
+// defaultJSONSeparator joins the keys of nested objects and the indexes of arrays ("request.headers.0").
+const defaultJSONSeparator = "."
+
 type JSONConfig struct {
+	// Mapping maps the flattened field names (e.g. "request.method") to the log line field names.
 	Mapping map[string]string `yaml:"mapping"`
+	// Separator is used to flatten nested objects and arrays. An empty string means ".".
+	Separator string `yaml:"separator"`
 }
 
 type JSONParser struct {
-	reader  *bufio.Reader
-	parser  fastjson.Parser
-	buf     []byte
-	mapping map[string]string
+	reader    *bufio.Reader
+	parser    fastjson.Parser
+	buf       []byte
+	key       []byte
+	mapping   map[string]string
+	separator string
 }
 
 func NewJSONParser(config JSONConfig, in io.Reader) (*JSONParser, error) {
 	parser := &JSONParser{
-		reader:  bufio.NewReader(in),
-		mapping: config.Mapping,
-		buf:     make([]byte, 0, 100),
+		reader:    bufio.NewReader(in),
+		mapping:   config.Mapping,
+		separator: config.Separator,
+		buf:       make([]byte, 0, 100),
+		key:       make([]byte, 0, 100),
+	}
+	if parser.separator == "" {
+		parser.separator = defaultJSONSeparator
 	}
 	return parser, nil
 }
@@ -41,50 +56,97 @@ func (p *JSONParser) ReadLine(line LogLine) error {
 	return p.Parse(row, line)
 }
 
+// Parse assigns the string and number values of the JSON object. Nested objects and arrays are flattened:
+// the value of {"request": {"headers": ["a"]}} is assigned to "request.headers.0" (using the default separator).
 func (p *JSONParser) Parse(row []byte, line LogLine) error {
 	val, err := p.parser.ParseBytes(row)
 	if err != nil {
-		return err
+		return &ParseError{msg: fmt.Sprintf("json parse: %v", err), err: err}
 	}
 	obj, err := val.Object()
 	if err != nil {
-		return err
+		return &ParseError{msg: fmt.Sprintf("json parse: %v", err), err: err}
 	}
 
+	p.key = p.key[:0]
+	if err := p.parseObject(obj, line); err != nil {
+		return &ParseError{msg: fmt.Sprintf("json parse: %v", err), err: err}
+	}
+	return nil
+}
+
+func (p *JSONParser) parseObject(obj *fastjson.Object, line LogLine) error {
+	var err error
+	n := len(p.key)
+
 	obj.Visit(func(key []byte, v *fastjson.Value) {
 		if err != nil {
 			return
 		}
-		switch v.Type() {
-		case fastjson.TypeString, fastjson.TypeNumber:
-		default:
-			return
-		}
+		p.key = append(p.key[:n], key...)
+		err = p.parseValue(v, line)
+	})
 
-		name := string(key)
-		if mapped, ok := p.mapping[name]; ok {
-			name = mapped
-		}
+	p.key = p.key[:n]
+	return err
+}
 
-		p.buf = p.buf[:0]
-		if p.buf = v.MarshalTo(p.buf); len(p.buf) == 0 {
-			return
+func (p *JSONParser) parseValue(v *fastjson.Value, line LogLine) error {
+	switch v.Type() {
+	case fastjson.TypeObject:
+		obj, _ := v.Object()
+		p.key = append(p.key, p.separator...)
+		return p.parseObject(obj, line)
+	case fastjson.TypeArray:
+		arr, _ := v.Array()
+		n := len(p.key)
+		for i, elem := range arr {
+			p.key = append(p.key[:n], p.separator...)
+			p.key = strconv.AppendInt(p.key, int64(i), 10)
+			if err := p.parseValue(elem, line); err != nil {
+				return err
+			}
 		}
+		p.key = p.key[:n]
+		return nil
+	case fastjson.TypeString:
+		b, _ := v.StringBytes()
+		return line.Assign(p.fieldName(), string(b))
+	case fastjson.TypeNumber:
+		p.buf = appendNumber(p.buf[:0], v)
+		return line.Assign(p.fieldName(), string(p.buf))
+	default:
+		return nil
+	}
+}
+
+// maxExactFloatInt is the max integer that float64 represents exactly.
+const maxExactFloatInt = 1 << 53
 
-		switch v.Type() {
-		case fastjson.TypeString:
-			// trim "
-			err = line.Assign(name, string(p.buf[1:len(p.buf)-1]))
-		default:
-			err = line.Assign(name, string(p.buf))
+// appendNumber appends the number in the form that is parsable by strconv.Atoi/ParseFloat:
+// integral values are appended without the fractional part ("200.0" => "200"),
+// the exponent notation is expanded ("1.5e-3" => "0.0015").
+func appendNumber(dst []byte, v *fastjson.Value) []byte {
+	f, err := v.Float64()
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return v.MarshalTo(dst)
+	}
+	if f == math.Trunc(f) {
+		if math.Abs(f) > maxExactFloatInt {
+			return v.MarshalTo(dst)
 		}
-	})
-	if err != nil {
-		return &ParseError{msg: fmt.Sprintf("json parse: %v", err), err: err}
+		return strconv.AppendInt(dst, int64(f), 10)
 	}
-	return nil
+	return strconv.AppendFloat(dst, f, 'f', -1, 64)
+}
+
+func (p *JSONParser) fieldName() string {
+	if mapped, ok := p.mapping[string(p.key)]; ok {
+		return mapped
+	}
+	return string(p.key)
 }
 
 func (p *JSONParser) Info() string {
-	return fmt.Sprintf("json: %q", p.mapping)
+	return fmt.Sprintf("json: %q (separator: %q)", p.mapping, p.separator)
 }
@@ -23,6 +23,10 @@ func TestNewJSONParser(t *testing.T) {
 			config:  JSONConfig{Mapping: map[string]string{"from_field_1": "to_field_1"}},
 			wantErr: false,
 		},
+		"with separator": {
+			config:  JSONConfig{Separator: "_"},
+			wantErr: false,
+		},
 	}
 
 	for name, test := range tests {
@@ -171,10 +175,200 @@ func TestJSONParser_Parse(t *testing.T) {
 				"FLOAT":  "1.1",
 			},
 		},
+		"nested objects": {
+			input:   `{ "request": { "method": "GET", "size": 10, "tls": { "version": "TLSv1.3" } } }`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"request.method":      "GET",
+				"request.size":        "10",
+				"request.tls.version": "TLSv1.3",
+			},
+		},
+		"arrays": {
+			input:   `{ "hosts": [ "a", "b" ], "upstreams": [ { "addr": "10.0.0.1" }, { "addr": "10.0.0.2" } ] }`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"hosts.0":          "a",
+				"hosts.1":          "b",
+				"upstreams.0.addr": "10.0.0.1",
+				"upstreams.1.addr": "10.0.0.2",
+			},
+		},
+		"nested objects with separator": {
+			config:  JSONConfig{Separator: "_"},
+			input:   `{ "request": { "method": "GET", "headers": [ "a" ] } }`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"request_method":    "GET",
+				"request_headers_0": "a",
+			},
+		},
+		"nested objects with mappings": {
+			config: JSONConfig{Mapping: map[string]string{
+				"request.method":    "METHOD",
+				"upstreams.1.addr":  "UPSTREAM",
+				"request.headers.0": "HEADER",
+			}},
+			input:   `{ "request": { "method": "GET", "headers": [ "a" ] }, "upstreams": [ { "addr": "10.0.0.1" }, { "addr": "10.0.0.2" } ] }`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"METHOD":           "GET",
+				"HEADER":           "a",
+				"upstreams.0.addr": "10.0.0.1",
+				"UPSTREAM":         "10.0.0.2",
+			},
+		},
+		"escaped string value": {
+			input:   `{ "agent": "curl \"7.68\"\t" }`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"agent": "curl \"7.68\"\t",
+			},
+		},
+		"null, bool and empty values are skipped": {
+			input:        `{ "null": null, "bool": true, "object": {}, "array": [] }`,
+			wantErr:      false,
+			wantAssigned: map[string]string{},
+		},
+		"Envoy access log": {
+			config: JSONConfig{Mapping: map[string]string{
+				"authority":             "host",
+				"method":                "request_method",
+				"path":                  "request_uri",
+				"protocol":              "server_protocol",
+				"response_code":         "status",
+				"bytes_received":        "request_length",
+				"bytes_sent":            "bytes_sent",
+				"duration":              "request_time",
+				"upstream_service_time": "upstream_response_time",
+				"user_agent":            "http_user_agent",
+			}},
+			input:   `{"authority":"example.com","bytes_received":0,"bytes_sent":612,"downstream_local_address":"10.1.2.3:8080","downstream_remote_address":"10.4.5.6:51234","duration":3,"method":"GET","path":"/api/v1/status?full","protocol":"HTTP/1.1","request_id":"5f1c2d1e-3a6b-4c8d-9e0f-1a2b3c4d5e6f","requested_server_name":null,"response_code":200,"response_flags":"-","route_name":"default","start_time":"2022-06-21T09:14:25.123Z","upstream_cluster":"outbound|8080||api.default.svc.cluster.local","upstream_host":"10.7.8.9:8080","upstream_local_address":"10.1.2.3:40312","upstream_service_time":"2","upstream_transport_failure_reason":null,"user_agent":"curl/7.79.1","x_forwarded_for":null}`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"host":                      "example.com",
+				"request_length":            "0",
+				"bytes_sent":                "612",
+				"downstream_local_address":  "10.1.2.3:8080",
+				"downstream_remote_address": "10.4.5.6:51234",
+				"request_time":              "3",
+				"request_method":            "GET",
+				"request_uri":               "/api/v1/status?full",
+				"server_protocol":           "HTTP/1.1",
+				"request_id":                "5f1c2d1e-3a6b-4c8d-9e0f-1a2b3c4d5e6f",
+				"status":                    "200",
+				"response_flags":            "-",
+				"route_name":                "default",
+				"start_time":                "2022-06-21T09:14:25.123Z",
+				"upstream_cluster":          "outbound|8080||api.default.svc.cluster.local",
+				"upstream_host":             "10.7.8.9:8080",
+				"upstream_local_address":    "10.1.2.3:40312",
+				"upstream_response_time":    "2",
+				"http_user_agent":           "curl/7.79.1",
+			},
+		},
+		"Envoy access log with nested fields": {
+			config: JSONConfig{Mapping: map[string]string{
+				"request.authority":            "host",
+				"request.method":               "request_method",
+				"request.path":                 "request_uri",
+				"response.code":                "status",
+				"response.bytes_sent":          "bytes_sent",
+				"duration_ms":                  "request_time",
+				"upstream.hosts.0":             "upstream_addr",
+				"request.headers.x-request-id": "request_id",
+			}},
+			input:   `{"start_time":"2022-06-21T09:14:25.123Z","duration_ms":12,"request":{"authority":"example.com","method":"POST","path":"/upload","headers":{"x-request-id":"e4b6a6c6-0c4b-4f0e-9c69-5e2d4b3f1a7d"}},"response":{"code":503,"flags":"UF,URX","bytes_sent":91},"upstream":{"cluster":"backend","hosts":["10.7.8.9:8080","10.7.8.10:8080"]}}`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"start_time":       "2022-06-21T09:14:25.123Z",
+				"request_time":     "12",
+				"host":             "example.com",
+				"request_method":   "POST",
+				"request_uri":      "/upload",
+				"request_id":       "e4b6a6c6-0c4b-4f0e-9c69-5e2d4b3f1a7d",
+				"status":           "503",
+				"response.flags":   "UF,URX",
+				"bytes_sent":       "91",
+				"upstream.cluster": "backend",
+				"upstream_addr":    "10.7.8.9:8080",
+				"upstream.hosts.1": "10.7.8.10:8080",
+			},
+		},
+		"numbers coercion": {
+			input:   `{ "status": 200.0, "size": 1e3, "time": 1.5e-3, "neg": -2.50, "big": 12345678901234567890 }`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"status": "200",
+				"size":   "1000",
+				"time":   "0.0015",
+				"neg":    "-2.5",
+				"big":    "12345678901234567890",
+			},
+		},
+		"Traefik access log": {
+			config: JSONConfig{Mapping: map[string]string{
+				"ClientHost":            "remote_addr",
+				"RequestHost":           "host",
+				"RequestMethod":         "request_method",
+				"RequestPath":           "request_uri",
+				"RequestProtocol":       "server_protocol",
+				"RequestScheme":         "scheme",
+				"DownstreamStatus":      "status",
+				"DownstreamContentSize": "bytes_sent",
+				"RequestContentSize":    "request_length",
+			}},
+			input:   `{"ClientAddr":"192.168.1.10:52838","ClientHost":"192.168.1.10","ClientPort":"52838","ClientUsername":"-","DownstreamContentSize":19,"DownstreamStatus":200,"Duration":1254667,"OriginContentSize":19,"OriginDuration":1087792,"OriginStatus":200,"Overhead":166875,"RequestAddr":"whoami.example.com","RequestContentSize":0,"RequestCount":1,"RequestHost":"whoami.example.com","RequestMethod":"GET","RequestPath":"/","RequestPort":"-","RequestProtocol":"HTTP/1.1","RequestScheme":"http","RetryAttempts":0,"RouterName":"whoami@docker","ServiceAddr":"172.18.0.3:80","ServiceName":"whoami@docker","ServiceURL":{"Scheme":"http","Opaque":"","User":null,"Host":"172.18.0.3:80","Path":"","ForceQuery":false},"StartLocal":"2023-02-10T10:26:55.270940013Z","StartUTC":"2023-02-10T10:26:55.270940013Z","entryPointName":"web","level":"info","msg":"","time":"2023-02-10T10:26:55Z"}`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"ClientAddr":        "192.168.1.10:52838",
+				"remote_addr":       "192.168.1.10",
+				"ClientPort":        "52838",
+				"ClientUsername":    "-",
+				"bytes_sent":        "19",
+				"status":            "200",
+				"Duration":          "1254667",
+				"OriginContentSize": "19",
+				"OriginDuration":    "1087792",
+				"OriginStatus":      "200",
+				"Overhead":          "166875",
+				"RequestAddr":       "whoami.example.com",
+				"request_length":    "0",
+				"RequestCount":      "1",
+				"host":              "whoami.example.com",
+				"request_method":    "GET",
+				"request_uri":       "/",
+				"RequestPort":       "-",
+				"server_protocol":   "HTTP/1.1",
+				"scheme":            "http",
+				"RetryAttempts":     "0",
+				"RouterName":        "whoami@docker",
+				"ServiceAddr":       "172.18.0.3:80",
+				"ServiceName":       "whoami@docker",
+				"ServiceURL.Scheme": "http",
+				"ServiceURL.Host":   "172.18.0.3:80",
+				"ServiceURL.Opaque": "",
+				"ServiceURL.Path":   "",
+				"StartLocal":        "2023-02-10T10:26:55.270940013Z",
+				"StartUTC":          "2023-02-10T10:26:55.270940013Z",
+				"entryPointName":    "web",
+				"level":             "info",
+				"msg":               "",
+				"time":              "2023-02-10T10:26:55Z",
+			},
+		},
 		"error on malformed JSON": {
 			input:   `{ "host"": unquoted_string}`,
 			wantErr: true,
 		},
+		"error on truncated JSON": {
+			input:   `{"authority":"example.com","bytes_received":0,"bytes_se`,
+			wantErr: true,
+		},
+		"error on not an object": {
+			input:   `[ "example.com" ]`,
+			wantErr: true,
+		},
 		"error on empty input": {
 			wantErr: true,
 		},
@@ -191,6 +385,7 @@ func TestJSONParser_Parse(t *testing.T) {
 
 			if test.wantErr {
 				assert.Error(t, err)
+				assert.True(t, IsParseError(err))
 			} else {
 				require.NoError(t, err)
 				assert.Equal(t, test.wantAssigned, line.assigned)
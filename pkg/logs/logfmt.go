@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	LogfmtConfig struct {
+		Mapping map[string]string `yaml:"mapping"`
+	}
+
+	// LogfmtParser parses 'key=value key2="quoted value"' lines (https://brandur.org/logfmt).
+	//
+	// Quoted values support the Go escape sequences (\", \\, \n, \t, \uXXXX, etc.).
+	// A key without a value ('key' or 'key=') is assigned an empty string.
+	// Unquoted numeric values with a duration unit ('12ms', '1.5s') are converted to seconds ('0.012', '1.5').
+	LogfmtParser struct {
+		r       *bufio.Reader
+		mapping map[string]string
+		buf     []byte
+	}
+)
+
+func NewLogfmtParser(config LogfmtConfig, in io.Reader) (*LogfmtParser, error) {
+	parser := &LogfmtParser{
+		r:       bufio.NewReader(in),
+		mapping: config.Mapping,
+		buf:     make([]byte, 0, 100),
+	}
+	return parser, nil
+}
+
+func (p *LogfmtParser) ReadLine(line LogLine) error {
+	row, err := p.r.ReadSlice('\n')
+	if err != nil && len(row) == 0 {
+		return err
+	}
+	if len(row) > 0 && row[len(row)-1] == '\n' {
+		row = row[:len(row)-1]
+	}
+	return p.Parse(row, line)
+}
+
+func (p *LogfmtParser) Parse(row []byte, line LogLine) error {
+	if len(bytes.TrimSpace(row)) == 0 {
+		return &ParseError{msg: "logfmt parse: empty line"}
+	}
+	if err := p.parse(row, line); err != nil {
+		return &ParseError{msg: fmt.Sprintf("logfmt parse: %v", err), err: err}
+	}
+	return nil
+}
+
+func (p *LogfmtParser) Info() string {
+	return fmt.Sprintf("logfmt: %q", p.mapping)
+}
+
+var (
+	errLogfmtUnexpectedQuote = errors.New("unexpected '\"'")
+	errLogfmtUnexpectedEqual = errors.New("unexpected '='")
+	errLogfmtUnterminated    = errors.New("unterminated quoted value")
+	errLogfmtInvalidEscape   = errors.New("invalid escape sequence")
+)
+
+func (p *LogfmtParser) parse(row []byte, line LogLine) error {
+	for i := 0; i < len(row); {
+		if isLogfmtSpace(row[i]) {
+			i++
+			continue
+		}
+
+		// key
+		start := i
+		for i < len(row) && !isLogfmtSpace(row[i]) && row[i] != '=' {
+			if row[i] == '"' {
+				return fmt.Errorf("col %d: %w", i+1, errLogfmtUnexpectedQuote)
+			}
+			i++
+		}
+		if i == start {
+			return fmt.Errorf("col %d: %w", i+1, errLogfmtUnexpectedEqual)
+		}
+		key := row[start:i]
+
+		// value
+		var value string
+		var err error
+		if i < len(row) && row[i] == '=' {
+			i++
+			if value, i, err = p.parseValue(row, i); err != nil {
+				return err
+			}
+		}
+
+		name := string(key)
+		if v, ok := p.mapping[name]; ok {
+			name = v
+		}
+		if err := line.Assign(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseValue parses the value that starts at i, it returns the value and the index of the next byte after it.
+func (p *LogfmtParser) parseValue(row []byte, i int) (string, int, error) {
+	if i >= len(row) || isLogfmtSpace(row[i]) {
+		return "", i, nil
+	}
+
+	if row[i] == '"' {
+		return p.parseQuotedValue(row, i)
+	}
+
+	// '=' is allowed in unquoted values ('path=/?a=b')
+	start := i
+	for i < len(row) && !isLogfmtSpace(row[i]) {
+		if row[i] == '"' {
+			return "", i, fmt.Errorf("col %d: %w", i+1, errLogfmtUnexpectedQuote)
+		}
+		i++
+	}
+	return coerceLogfmtValue(string(row[start:i])), i, nil
+}
+
+func (p *LogfmtParser) parseQuotedValue(row []byte, i int) (string, int, error) {
+	start := i
+	i++ // opening quote
+
+	// fast path: no escape sequences
+	if end := bytes.IndexByte(row[i:], '"'); end >= 0 && bytes.IndexByte(row[i:i+end], '\\') < 0 {
+		i += end + 1
+		if i < len(row) && !isLogfmtSpace(row[i]) {
+			return "", i, fmt.Errorf("col %d: %w", i+1, errLogfmtUnexpectedQuote)
+		}
+		return string(row[start+1 : i-1]), i, nil
+	}
+
+	p.buf = p.buf[:0]
+	for {
+		if i >= len(row) {
+			return "", i, fmt.Errorf("col %d: %w", start+1, errLogfmtUnterminated)
+		}
+		switch c := row[i]; {
+		case c == '"':
+			i++
+			if i < len(row) && !isLogfmtSpace(row[i]) {
+				return "", i, fmt.Errorf("col %d: %w", i+1, errLogfmtUnexpectedQuote)
+			}
+			return string(p.buf), i, nil
+		case c == '\\':
+			// the longest escape sequence is '\UXXXXXXXX'
+			s := string(row[i:minInt(i+10, len(row))])
+			r, multibyte, tail, err := strconv.UnquoteChar(s, '"')
+			if err != nil {
+				return "", i, fmt.Errorf("col %d: %w", i+1, errLogfmtInvalidEscape)
+			}
+			if multibyte {
+				p.buf = utf8.AppendRune(p.buf, r)
+			} else {
+				p.buf = append(p.buf, byte(r))
+			}
+			i += len(s) - len(tail)
+		default:
+			p.buf = append(p.buf, c)
+			i++
+		}
+	}
+}
+
+// coerceLogfmtValue converts the numeric values with a duration unit ('12ms', '1.5s', '2m') to seconds.
+func coerceLogfmtValue(value string) string {
+	if len(value) < 2 || !isLogfmtDigit(value[0]) || isLogfmtDigit(value[len(value)-1]) {
+		return value
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return value
+	}
+	s := strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+	if d%time.Second == 0 {
+		// keep the fractional part, it tells that the value is in seconds
+		s += ".000"
+	}
+	return s
+}
+
+func isLogfmtSpace(c byte) bool { return c == ' ' || c == '\t' || c == '\r' }
+
+func isLogfmtDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package logs
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogfmtParser(t *testing.T) {
+	tests := map[string]struct {
+		config  LogfmtConfig
+		wantErr bool
+	}{
+		"empty config": {
+			config:  LogfmtConfig{},
+			wantErr: false,
+		},
+		"with mappings": {
+			config:  LogfmtConfig{Mapping: map[string]string{"from_field_1": "to_field_1"}},
+			wantErr: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := NewLogfmtParser(test.config, nil)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, p)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, p)
+			}
+		})
+	}
+}
+
+func TestLogfmtParser_ReadLine(t *testing.T) {
+	tests := map[string]struct {
+		config       LogfmtConfig
+		input        string
+		wantAssigned map[string]string
+		wantErr      bool
+	}{
+		"one line": {
+			input:   "method=GET status=200\n",
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"method": "GET",
+				"status": "200",
+			},
+		},
+		"without newline": {
+			input:   `method=GET path="/a b"`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"method": "GET",
+				"path":   "/a b",
+			},
+		},
+		"error on malformed line": {
+			input:   "method=\"GET\n",
+			wantErr: true,
+		},
+		"error on empty input": {
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			line := newLogLine()
+			in := strings.NewReader(test.input)
+			p, err := NewLogfmtParser(test.config, in)
+			require.NoError(t, err)
+			require.NotNil(t, p)
+
+			err = p.ReadLine(line)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.wantAssigned, line.assigned)
+			}
+		})
+	}
+}
+
+func TestLogfmtParser_Parse(t *testing.T) {
+	tests := map[string]struct {
+		config       LogfmtConfig
+		input        string
+		wantAssigned map[string]string
+		wantErr      bool
+	}{
+		"unquoted values": {
+			input:   `method=GET path=/index.html status=200`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"method": "GET",
+				"path":   "/index.html",
+				"status": "200",
+			},
+		},
+		"quoted values": {
+			input:   `msg="request served" agent="Go-http-client/1.1" empty=""`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"msg":   "request served",
+				"agent": "Go-http-client/1.1",
+				"empty": "",
+			},
+		},
+		"escaped values": {
+			input:   `a="say \"hi\"" b="back\\slash" c="tab\tnew\nline" d="\u00e9\U0001F600" e="\x41" f="="`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"a": `say "hi"`,
+				"b": `back\slash`,
+				"c": "tab\tnew\nline",
+				"d": "é😀",
+				"e": "A",
+				"f": "=",
+			},
+		},
+		"escaped quote at the end": {
+			input:   `a="\"" b=1`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"a": `"`,
+				"b": "1",
+			},
+		},
+		"unicode values": {
+			input:   `path=/привет msg="日本語 テキスト"`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"path": "/привет",
+				"msg":  "日本語 テキスト",
+			},
+		},
+		"keys without values": {
+			input:   `debug a= b=1 c`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"debug": "",
+				"a":     "",
+				"b":     "1",
+				"c":     "",
+			},
+		},
+		"'=' in unquoted value": {
+			input:   `path=/search?q=go&page=2`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"path": "/search?q=go&page=2",
+			},
+		},
+		"extra whitespaces": {
+			input:   " \ta=1  \t b=2 \r",
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"a": "1",
+				"b": "2",
+			},
+		},
+		"duration values": {
+			input:   `connect=0ms service=12ms total=1.5s wait=2s tiny=250us quoted="12ms" version=1.2.3s`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"connect": "0.000",
+				"service": "0.012",
+				"total":   "1.5",
+				"wait":    "2.000",
+				"tiny":    "0.00025",
+				"quoted":  "12ms",
+				"version": "1.2.3s",
+			},
+		},
+		"numeric values": {
+			input:   `bytes=1234 ratio=0.75 neg=-1 exp=1e3 id=7f`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"bytes": "1234",
+				"ratio": "0.75",
+				"neg":   "-1",
+				"exp":   "1e3",
+				"id":    "7f",
+			},
+		},
+		"with mappings": {
+			config: LogfmtConfig{Mapping: map[string]string{
+				"method": "request_method",
+				"path":   "request_uri",
+				"bytes":  "bytes_sent",
+			}},
+			input:   `method=GET path="/" bytes=10 status=200`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"request_method": "GET",
+				"request_uri":    "/",
+				"bytes_sent":     "10",
+				"status":         "200",
+			},
+		},
+		"Heroku router": {
+			input:   `at=info method=GET path="/users?page=2" host=myapp.herokuapp.com request_id=8601b555-6a83-4c12-8269-97c8e32cdb22 fwd="204.204.204.204" dyno=web.1 connect=1ms service=18ms status=200 bytes=13 protocol=https`,
+			wantErr: false,
+			wantAssigned: map[string]string{
+				"at":         "info",
+				"method":     "GET",
+				"path":       "/users?page=2",
+				"host":       "myapp.herokuapp.com",
+				"request_id": "8601b555-6a83-4c12-8269-97c8e32cdb22",
+				"fwd":        "204.204.204.204",
+				"dyno":       "web.1",
+				"connect":    "0.001",
+				"service":    "0.018",
+				"status":     "200",
+				"bytes":      "13",
+				"protocol":   "https",
+			},
+		},
+		"error on unterminated quoted value": {
+			input:   `a="unterminated b=1`,
+			wantErr: true,
+		},
+		"error on escaped closing quote": {
+			input:   `a="value\"`,
+			wantErr: true,
+		},
+		"error on invalid escape sequence": {
+			input:   `a="\q"`,
+			wantErr: true,
+		},
+		"error on truncated escape sequence": {
+			input:   `a="\u12`,
+			wantErr: true,
+		},
+		"error on trailing backslash": {
+			input:   `a="\`,
+			wantErr: true,
+		},
+		"error on quote in key": {
+			input:   `a"b=1`,
+			wantErr: true,
+		},
+		"error on quote in unquoted value": {
+			input:   `a=b"c`,
+			wantErr: true,
+		},
+		"error on garbage after quoted value": {
+			input:   `a="b"c d=1`,
+			wantErr: true,
+		},
+		"error on missing key": {
+			input:   `=1`,
+			wantErr: true,
+		},
+		"error on assign": {
+			input:   `ERR=1`,
+			wantErr: true,
+		},
+		"error on whitespaces only": {
+			input:   " \t ",
+			wantErr: true,
+		},
+		"error on empty input": {
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			line := newLogLine()
+			p, err := NewLogfmtParser(test.config, nil)
+			require.NoError(t, err)
+			require.NotNil(t, p)
+
+			err = p.Parse([]byte(test.input), line)
+
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.True(t, IsParseError(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.wantAssigned, line.assigned)
+			}
+		})
+	}
+}
+
+// FuzzLogfmtParser_Parse checks that a quoted value survives the parsing unchanged.
+func FuzzLogfmtParser_Parse(f *testing.F) {
+	for _, v := range []string{
+		"",
+		" ",
+		`"`,
+		`\`,
+		`\"`,
+		`""`,
+		"=",
+		"a=b c=d",
+		"tab\there",
+		"new\nline",
+		"\x00\x7f",
+		"é😀",
+		`C:\Program Files\`,
+	} {
+		f.Add(v)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		if !utf8.ValidString(value) {
+			t.Skip()
+		}
+		line := newLogLine()
+		p, err := NewLogfmtParser(LogfmtConfig{}, nil)
+		require.NoError(t, err)
+
+		row := "key=" + strconv.Quote(value) + " next=1"
+		require.NoError(t, p.Parse([]byte(row), line))
+		assert.Equal(t, map[string]string{"key": value, "next": "1"}, line.assigned)
+	})
+}
@@ -37,6 +37,7 @@ const (
 	TypeLTSV   = "ltsv"
 	TypeRegExp = "regexp"
 	TypeJSON   = "json"
+	TypeLogfmt = "logfmt"
 )
 
 type ParserConfig struct {
@@ -45,6 +46,7 @@ type ParserConfig struct {
 	LTSV    LTSVConfig   `yaml:"ltsv_config"`
 	RegExp  RegExpConfig `yaml:"regexp_config"`
 	JSON    JSONConfig   `yaml:"json_config"`
+	Logfmt  LogfmtConfig `yaml:"logfmt_config"`
 }
 
 func NewParser(config ParserConfig, in io.Reader) (Parser, error) {
@@ -57,6 +59,8 @@ func NewParser(config ParserConfig, in io.Reader) (Parser, error) {
 		return NewRegExpParser(config.RegExp, in)
 	case TypeJSON:
 		return NewJSONParser(config.JSON, in)
+	case TypeLogfmt:
+		return NewLogfmtParser(config.Logfmt, in)
 	default:
 		return nil, fmt.Errorf("invalid type: %q", config.LogType)
 	}
@@ -3,6 +3,7 @@
 package logs
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -21,9 +22,10 @@ var (
 	ErrNoMatchedFile = errors.New("no matched files")
 )
 
-// Reader is a log rotate aware Reader
-// TODO: better reopen algorithm
-// TODO: handle truncate
+// Reader is a log rotate aware Reader.
+// On every EOF it checks whether the file was rotated (the path points to another file, rename/create rotation)
+// or truncated (the file size is less than the read offset, copytruncate rotation).
+// The rotated files are not read, and a rotation that happened before Open is not detected.
 type Reader struct {
 	file          *os.File
 	path          string
@@ -31,6 +33,13 @@ type Reader struct {
 	eofCounter    int
 	continuousEOF int
 	log           *logger.Logger
+
+	// offset is the read position in the current file.
+	offset int64
+	// replayRotated makes the reader read a rotated file from the beginning.
+	replayRotated bool
+	rotations     int64
+	skippedLines  int64
 }
 
 // Open a file and seek to end of the file.
@@ -64,6 +73,22 @@ func (r *Reader) CurrentFilename() string {
 	return r.file.Name()
 }
 
+// SetReplayRotated sets whether a newly created (after rename) or truncated file is read from the beginning.
+// Otherwise, the reader skips to the end of the file and counts the skipped lines.
+func (r *Reader) SetReplayRotated(v bool) {
+	r.replayRotated = v
+}
+
+// Rotations returns the number of detected file rotations (rename or truncate).
+func (r *Reader) Rotations() int64 {
+	return r.rotations
+}
+
+// SkippedLines returns the number of lines skipped after file rotations.
+func (r *Reader) SkippedLines() int64 {
+	return r.skippedLines
+}
+
 func (r *Reader) open() error {
 	path := r.findFile()
 	if path == "" {
@@ -77,17 +102,21 @@ func (r *Reader) open() error {
 	}
 	stat, err := file.Stat()
 	if err != nil {
+		_ = file.Close()
 		return err
 	}
 	if _, err = file.Seek(stat.Size(), io.SeekStart); err != nil {
+		_ = file.Close()
 		return err
 	}
 	r.file = file
+	r.offset = stat.Size()
 	return nil
 }
 
 func (r *Reader) Read(p []byte) (n int, err error) {
 	n, err = r.file.Read(p)
+	r.offset += int64(n)
 	if err != nil {
 		switch err {
 		case io.EOF:
@@ -105,6 +134,12 @@ func (r *Reader) handleEOFErr() (err error) {
 	err = io.EOF
 	r.eofCounter++
 	r.continuousEOF++
+	if rotated, err2 := r.handleRotation(); rotated || err2 != nil {
+		if err2 != nil {
+			err = err2
+		}
+		return err
+	}
 	if r.eofCounter < maxEOF || r.continuousEOF < 2 {
 		return err
 	}
@@ -139,6 +174,77 @@ func (r *Reader) reopen() error {
 	return r.open()
 }
 
+// handleRotation switches to the new file if the current one was rotated.
+// It does nothing if the path doesn't point to a file (it's probably not created yet).
+func (r *Reader) handleRotation() (bool, error) {
+	curr, err := r.file.Stat()
+	if err != nil {
+		return false, nil
+	}
+
+	if curr.Size() < r.offset {
+		r.log.Infof("log file '%s' is truncated (size %d, offset %d)", r.file.Name(), curr.Size(), r.offset)
+		r.rotations++
+		return true, r.seekRotated(r.file)
+	}
+
+	path := r.findFile()
+	if path == "" {
+		return false, nil
+	}
+	next, err := os.Stat(path)
+	if err != nil || os.SameFile(curr, next) {
+		return false, nil
+	}
+
+	r.log.Infof("log file '%s' is rotated, new file '%s'", r.file.Name(), path)
+	r.rotations++
+	file, err := os.Open(path)
+	if err != nil {
+		return true, err
+	}
+	_ = r.Close()
+	r.file = file
+	return true, r.seekRotated(file)
+}
+
+func (r *Reader) seekRotated(file *os.File) (err error) {
+	if r.replayRotated {
+		r.offset, err = file.Seek(0, io.SeekStart)
+		return err
+	}
+	n, err := countLines(file)
+	if err != nil {
+		return err
+	}
+	if r.offset, err = file.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+	if n > 0 {
+		r.skippedLines += n
+		r.log.Warningf("skipped %d lines (%d bytes) of '%s' after rotation", n, r.offset, file.Name())
+	}
+	return nil
+}
+
+func countLines(file *os.File) (int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	var n int64
+	buf := make([]byte, 32*1024)
+	for {
+		c, err := file.Read(buf)
+		n += int64(bytes.Count(buf[:c], []byte{'\n'}))
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+	}
+}
+
 func (r *Reader) findFile() string {
 	return find(r.path, r.excludePath)
 }
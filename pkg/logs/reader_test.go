@@ -52,6 +52,66 @@ func TestReader_Read_HandleFileRotation(t *testing.T) {
 	n, err = r.readUntilEOF()
 	assert.Equal(t, io.EOF, err)
 	assert.Equal(t, numLogs, n)
+	assert.EqualValues(t, 1, reader.Rotations())
+	assert.EqualValues(t, numLogs, reader.SkippedLines())
+}
+
+func TestReader_Read_HandleFileRotationReplay(t *testing.T) {
+	reader, teardown := prepareTestReader(t)
+	defer teardown()
+	reader.SetReplayRotated(true)
+
+	r := testReader{bufio.NewReader(reader)}
+	filename := reader.CurrentFilename()
+	numLogs := 5
+	appendLogs(t, filename, 0, numLogs)
+	n, err := r.readUntilEOF()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, numLogs, n)
+
+	rotateFile(t, filename)
+	appendLogs(t, filename, 0, numLogs)
+
+	n, err = r.readUntilEOFTimes(2)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, numLogs, n)
+	assert.EqualValues(t, 1, reader.Rotations())
+	assert.EqualValues(t, 0, reader.SkippedLines())
+}
+
+func TestReader_Read_HandleFileTruncate(t *testing.T) {
+	tests := map[string]struct {
+		replay      bool
+		wantLines   int
+		wantSkipped int64
+	}{
+		"replay":    {replay: true, wantLines: 3, wantSkipped: 0},
+		"no replay": {replay: false, wantLines: 0, wantSkipped: 3},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reader, teardown := prepareTestReader(t)
+			defer teardown()
+			reader.SetReplayRotated(test.replay)
+
+			r := testReader{bufio.NewReader(reader)}
+			filename := reader.CurrentFilename()
+			appendLogs(t, filename, 0, 5)
+			n, err := r.readUntilEOF()
+			assert.Equal(t, io.EOF, err)
+			assert.Equal(t, 5, n)
+
+			require.NoError(t, os.Truncate(filename, 0))
+			appendLogs(t, filename, 0, 3)
+
+			n, err = r.readUntilEOFTimes(2)
+			assert.Equal(t, io.EOF, err)
+			assert.Equal(t, test.wantLines, n)
+			assert.EqualValues(t, 1, reader.Rotations())
+			assert.Equal(t, test.wantSkipped, reader.SkippedLines())
+		})
+	}
 }
 
 func TestReader_Read_HandleFileRotationWithDelay(t *testing.T) {
@@ -3,15 +3,19 @@
 package matcher
 
 import (
+	"errors"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
 	"unicode/utf8"
-
-	"errors"
 )
 
-// globMatcher implements Matcher, it uses filepath.MatchString to match.
-type globMatcher string
+// globMatcher implements Matcher, it uses filepath.Match semantics to match.
+type globMatcher struct {
+	pattern string
+	glob    *compiledGlob
+}
 
 var (
 	errBadGlobPattern = errors.New("bad glob pattern")
@@ -55,7 +59,7 @@ func NewGlobMatcher(expr string) (Matcher, error) {
 			unescapedExpr = append(unescapedExpr, nextCh)
 			i++
 		} else if isGlobMeta(ch) {
-			return globMatcher(expr), nil
+			return newGlobMatcher(expr), nil
 		} else {
 			unescapedExpr = append(unescapedExpr, ch)
 		}
@@ -73,6 +77,11 @@ func isGlobMeta(ch rune) bool {
 	}
 }
 
+// newGlobMatcher creates a new globMatcher, the compiled pattern is shared by the matchers with the same pattern.
+func newGlobMatcher(pattern string) globMatcher {
+	return globMatcher{pattern: pattern, glob: loadGlob(pattern)}
+}
+
 // Match matches.
 func (m globMatcher) Match(b []byte) bool {
 	return m.MatchString(string(b))
@@ -80,165 +89,285 @@ func (m globMatcher) Match(b []byte) bool {
 
 // MatchString matches.
 func (m globMatcher) MatchString(line string) bool {
-	rs, _ := m.globMatch(line)
-	return rs
+	return m.glob.match(line)
+}
+
+// globCache holds the compiled glob patterns, the key is the pattern.
+var globCache sync.Map
+
+func loadGlob(pattern string) *compiledGlob {
+	if g, ok := globCache.Load(pattern); ok {
+		return g.(*compiledGlob)
+	}
+	g, _ := globCache.LoadOrStore(pattern, compileGlob(pattern))
+	return g.(*compiledGlob)
 }
 
-func (m globMatcher) globMatch(name string) (matched bool, err error) {
-	pattern := string(m)
-Pattern:
+type (
+	// compiledGlob is a glob pattern split into chunks, every chunk is a non-star sequence of tokens
+	// possibly preceded by a star. The chunks are matched the same way filepath.Match does, but the pattern
+	// is parsed once.
+	compiledGlob struct {
+		chunks []globChunk
+		// bad is set if the pattern has a malformed character class, such a pattern never matches.
+		bad bool
+	}
+	globChunk struct {
+		star   bool
+		tokens []globToken
+		// literal is set if the chunk has no '?' and character classes.
+		literal bool
+	}
+	globToken struct {
+		kind    globTokenKind
+		lit     string
+		negated bool
+		ranges  []globRange
+	}
+	globTokenKind uint8
+	globRange     struct{ lo, hi rune }
+)
+
+const (
+	globLiteral globTokenKind = iota
+	globAnyRune
+	globClass
+)
+
+func compileGlob(pattern string) *compiledGlob {
+	g := &compiledGlob{}
 	for len(pattern) > 0 {
 		var star bool
 		var chunk string
 		star, chunk, pattern = scanChunk(pattern)
-		if star && chunk == "" {
-			// Trailing * matches rest of string unless it has a /.
-			// return !strings.Contains(name, string(Separator)), nil
-
-			return true, nil
-		}
-		// Look for match at current position.
-		t, ok, err := matchChunk(chunk, name)
-		// if we're the last chunk, make sure we've exhausted the name
-		// otherwise we'll give a false result even if we could still match
-		// using the star
-		if ok && (len(t) == 0 || len(pattern) > 0) {
-			name = t
-			continue
-		}
+		c, err := compileChunk(chunk)
 		if err != nil {
-			return false, err
+			return &compiledGlob{bad: true}
 		}
-		if star {
-			// Look for match skipping i+1 bytes.
-			// Cannot skip /.
-			for i := 0; i < len(name); i++ {
-				//for i := 0; i < len(name) && name[i] != Separator; i++ {
-				t, ok, err := matchChunk(chunk, name[i+1:])
-				if ok {
-					// if we're the last chunk, make sure we exhausted the name
-					if len(pattern) == 0 && len(t) > 0 {
-						continue
-					}
-					name = t
-					continue Pattern
-				}
-				if err != nil {
-					return false, err
-				}
-			}
-		}
-		return false, nil
+		c.star = star
+		g.chunks = append(g.chunks, c)
 	}
-	return len(name) == 0, nil
+	return g
 }
 
-// scanChunk gets the next segment of pattern, which is a non-star string
-// possibly preceded by a star.
-func scanChunk(pattern string) (star bool, chunk, rest string) {
-	for len(pattern) > 0 && pattern[0] == '*' {
-		pattern = pattern[1:]
-		star = true
-	}
-	inrange := false
-	var i int
-Scan:
-	for i = 0; i < len(pattern); i++ {
-		switch pattern[i] {
-		case '\\':
-			if i+1 < len(pattern) {
-				i++
-			}
-		case '[':
-			inrange = true
-		case ']':
-			inrange = false
-		case '*':
-			if !inrange {
-				break Scan
-			}
+// compileChunk parses the chunk, it returns an error if the chunk is malformed.
+// Chunk is all single-character operators: literals, char classes, and ?.
+func compileChunk(chunk string) (c globChunk, err error) {
+	var lit []byte
+	flush := func() {
+		if len(lit) > 0 {
+			c.tokens = append(c.tokens, globToken{kind: globLiteral, lit: string(lit)})
+			lit = lit[:0]
 		}
 	}
-	return star, pattern[0:i], pattern[i:]
-}
+	c.literal = true
 
-// matchChunk checks whether chunk matches the beginning of s.
-// If so, it returns the remainder of s (after the match).
-// Chunk is all single-character operators: literals, char classes, and ?.
-func matchChunk(chunk, s string) (rest string, ok bool, err error) {
 	for len(chunk) > 0 {
-		if len(s) == 0 {
-			return
-		}
 		switch chunk[0] {
 		case '[':
-			// character class
-			r, n := utf8.DecodeRuneInString(s)
-			s = s[n:]
+			flush()
+			c.literal = false
 			chunk = chunk[1:]
 			// We can't end right after '[', we're expecting at least
 			// a closing bracket and possibly a caret.
 			if len(chunk) == 0 {
-				err = filepath.ErrBadPattern
-				return
+				return c, filepath.ErrBadPattern
 			}
+			tok := globToken{kind: globClass}
 			// possibly negated
-			negated := chunk[0] == '^'
-			if negated {
+			if tok.negated = chunk[0] == '^'; tok.negated {
 				chunk = chunk[1:]
 			}
 			// parse all ranges
-			match := false
-			nrange := 0
 			for {
-				if len(chunk) > 0 && chunk[0] == ']' && nrange > 0 {
+				if len(chunk) > 0 && chunk[0] == ']' && len(tok.ranges) > 0 {
 					chunk = chunk[1:]
 					break
 				}
 				var lo, hi rune
 				if lo, chunk, err = getEsc(chunk); err != nil {
-					return
+					return c, err
 				}
 				hi = lo
 				if chunk[0] == '-' {
 					if hi, chunk, err = getEsc(chunk[1:]); err != nil {
-						return
+						return c, err
 					}
 				}
-				if lo <= r && r <= hi {
-					match = true
-				}
-				nrange++
+				tok.ranges = append(tok.ranges, globRange{lo: lo, hi: hi})
 			}
-			if match == negated {
-				return
-			}
-
+			c.tokens = append(c.tokens, tok)
 		case '?':
-			//if s[0] == Separator {
-			//	return
-			//}
-			_, n := utf8.DecodeRuneInString(s)
-			s = s[n:]
+			flush()
+			c.literal = false
+			c.tokens = append(c.tokens, globToken{kind: globAnyRune})
 			chunk = chunk[1:]
-
 		case '\\':
 			chunk = chunk[1:]
 			if len(chunk) == 0 {
-				err = filepath.ErrBadPattern
-				return
+				return c, filepath.ErrBadPattern
 			}
 			fallthrough
-
 		default:
-			if chunk[0] != s[0] {
-				return
-			}
-			s = s[1:]
+			lit = append(lit, chunk[0])
 			chunk = chunk[1:]
 		}
 	}
-	return s, true, nil
+	flush()
+
+	return c, nil
+}
+
+func (g *compiledGlob) match(name string) bool {
+	if g.bad {
+		return false
+	}
+	for i := range g.chunks {
+		c := &g.chunks[i]
+		last := i == len(g.chunks)-1
+		if c.star && len(c.tokens) == 0 {
+			// Trailing * matches rest of string.
+			return true
+		}
+		if c.literal {
+			var ok bool
+			if name, ok = c.matchLiteral(name, last); !ok {
+				return false
+			}
+			continue
+		}
+		// Look for match at current position.
+		// if we're the last chunk, make sure we've exhausted the name
+		// otherwise we'll give a false result even if we could still match
+		// using the star
+		if t, ok := c.match(name); ok && (len(t) == 0 || !last) {
+			name = t
+			continue
+		}
+		if !c.star {
+			return false
+		}
+		// Look for match skipping i+1 bytes.
+		// The positions the chunk leading literal is not at are skipped, they can't match.
+		var found bool
+		prefix := c.prefix()
+		for j := 1; j < len(name) && !found; j++ {
+			if prefix != "" {
+				idx := strings.Index(name[j:], prefix)
+				if idx < 0 {
+					break
+				}
+				j += idx
+			}
+			t, ok := c.match(name[j:])
+			// if we're the last chunk, make sure we exhausted the name
+			if found = ok && (len(t) == 0 || !last); found {
+				name = t
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return len(name) == 0
+}
+
+// prefix returns the chunk leading literal.
+func (c *globChunk) prefix() string {
+	if len(c.tokens) > 0 && c.tokens[0].kind == globLiteral {
+		return c.tokens[0].lit
+	}
+	return ""
+}
+
+// matchLiteral is a fast path of the literal only chunk matching.
+func (c *globChunk) matchLiteral(name string, last bool) (string, bool) {
+	var lit string
+	if len(c.tokens) > 0 {
+		lit = c.tokens[0].lit
+	}
+	switch {
+	case !c.star:
+		if !strings.HasPrefix(name, lit) || (last && len(name) != len(lit)) {
+			return name, false
+		}
+		return name[len(lit):], true
+	case last:
+		if !strings.HasSuffix(name, lit) {
+			return name, false
+		}
+		return "", true
+	default:
+		idx := strings.Index(name, lit)
+		if idx < 0 {
+			return name, false
+		}
+		return name[idx+len(lit):], true
+	}
+}
+
+// match checks whether the chunk matches the beginning of s.
+// If so, it returns the remainder of s (after the match).
+func (c *globChunk) match(s string) (rest string, ok bool) {
+	for i := range c.tokens {
+		tok := &c.tokens[i]
+		if len(s) == 0 {
+			return s, false
+		}
+		switch tok.kind {
+		case globLiteral:
+			if !strings.HasPrefix(s, tok.lit) {
+				return s, false
+			}
+			s = s[len(tok.lit):]
+		case globAnyRune:
+			_, n := utf8.DecodeRuneInString(s)
+			s = s[n:]
+		case globClass:
+			r, n := utf8.DecodeRuneInString(s)
+			s = s[n:]
+			var match bool
+			for _, rng := range tok.ranges {
+				if rng.lo <= r && r <= rng.hi {
+					match = true
+					break
+				}
+			}
+			if match == tok.negated {
+				return s, false
+			}
+		}
+	}
+	return s, true
+}
+
+// scanChunk gets the next segment of pattern, which is a non-star string
+// possibly preceded by a star.
+func scanChunk(pattern string) (star bool, chunk, rest string) {
+	for len(pattern) > 0 && pattern[0] == '*' {
+		pattern = pattern[1:]
+		star = true
+	}
+	inrange := false
+	var i int
+Scan:
+	for i = 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+		case '[':
+			inrange = true
+		case ']':
+			inrange = false
+		case '*':
+			if !inrange {
+				break Scan
+			}
+		}
+	}
+	return star, pattern[0:i], pattern[i:]
 }
 
 // getEsc gets a possibly-escaped character from chunk, for a character class.
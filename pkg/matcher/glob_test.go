@@ -3,7 +3,9 @@
 package matcher
 
 import (
+	"path/filepath"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,8 +17,8 @@ func TestNewGlobMatcher(t *testing.T) {
 	}{
 		{"", stringFullMatcher("")},
 		{"a", stringFullMatcher("a")},
-		{"a*b", globMatcher("a*b")},
-		{`a*\b`, globMatcher(`a*\b`)},
+		{"a*b", newGlobMatcher("a*b")},
+		{`a*\b`, newGlobMatcher(`a*\b`)},
 		{`a\[`, stringFullMatcher(`a[`)},
 		{`ab\`, nil},
 		{`ab[`, nil},
@@ -59,13 +61,55 @@ func TestGlobMatcher_MatchString(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.line, func(t *testing.T) {
-			m := globMatcher(c.expr)
+			m := newGlobMatcher(c.expr)
 			assert.Equal(t, c.expected, m.Match([]byte(c.line)))
 			assert.Equal(t, c.expected, m.MatchString(c.line))
 		})
 	}
 }
 
+func TestGlobMatcher_MatchString_Compatibility(t *testing.T) {
+	exprs := []string{
+		"a*", "*a", "*a*", "a*b", "a*b*c", "*a*b*", "**a", "a**", "a?", "?a?", "*?", "?*",
+		"[a-c]*", "*[^a-c]", "a[b-d]*[x-z]", "*[ab]?[cd]*", "[\\]]*", "[\\-a]*", `a\*b*`, `*\?`,
+		"é*", "*[é-ü]", "?*?", "*.[jp][pn]g", "/a/*/d", "[]-a]*", "*[a-]]", "a*[]-b]",
+	}
+	lines := []string{
+		"", "a", "b", "ab", "ba", "abc", "aab", "abab", "acbc", "a*b", "a?", "]", "\\", "-", "é", "éü", "ü",
+		"\xff", "a\xffb", "photo.png", "photo.jpg", "photo.gif", "/a/b/c/d", "a/b/c/d", "xaybzc",
+	}
+
+	for _, expr := range exprs {
+		for _, line := range lines {
+			want, _ := legacyGlobMatch(expr, line)
+			assert.Equalf(t, want, newGlobMatcher(expr).MatchString(line), "expr '%s', line '%s'", expr, line)
+		}
+	}
+}
+
+func TestNewGlobMatcher_SharedCompiledPattern(t *testing.T) {
+	m1, err := NewGlobMatcher("*/shared/*.js")
+	assert.NoError(t, err)
+	m2, err := NewGlobMatcher("*/shared/*.js")
+	assert.NoError(t, err)
+
+	assert.Same(t, m1.(globMatcher).glob, m2.(globMatcher).glob)
+}
+
+func FuzzGlobMatcher_MatchString(f *testing.F) {
+	f.Add("*a*b", "xaxb")
+	f.Add("a?[b-d]*", "aébcd")
+	f.Add("[^a]*[a-]]", "ba]")
+
+	f.Fuzz(func(t *testing.T, expr, line string) {
+		if !utf8.ValidString(expr) {
+			t.Skip()
+		}
+		want, _ := legacyGlobMatch(expr, line)
+		assert.Equal(t, want, newGlobMatcher(expr).MatchString(line))
+	})
+}
+
 func BenchmarkGlob_MatchString(b *testing.B) {
 	benchmarks := []struct {
 		expr string
@@ -77,15 +121,24 @@ func BenchmarkGlob_MatchString(b *testing.B) {
 		{"abc*", "abcd"},
 		{"*abc*", "abcd"},
 		{"[a-z]", "abcd"},
+		{"*/api/v?/*", "/index.php/api/v2/users/1234/profile?full=true"},
+		{"/static/*.js", "/static/js/vendor/jquery-3.6.0.min.js"},
+		{"*.[jp][pn]g", "/images/2023/01/photos/avatar-123456.png"},
+		{"/users/*/[0-9]*/edit", "/users/john/12345/edit"},
 	}
 	for _, bm := range benchmarks {
 		b.Run(bm.expr+"_raw", func(b *testing.B) {
-			m := globMatcher(bm.expr)
+			m := newGlobMatcher(bm.expr)
 			b.ResetTimer()
 			for i := 0; i < b.N; i++ {
 				m.MatchString(bm.test)
 			}
 		})
+		b.Run(bm.expr+"_legacy", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = legacyGlobMatch(bm.expr, bm.test)
+			}
+		})
 		b.Run(bm.expr+"_optimized", func(b *testing.B) {
 			m, _ := NewGlobMatcher(bm.expr)
 			b.ResetTimer()
@@ -95,3 +148,125 @@ func BenchmarkGlob_MatchString(b *testing.B) {
 		})
 	}
 }
+
+// legacyGlobMatch is the not compiled glob matching, the compiled globMatcher must give the same results.
+func legacyGlobMatch(pattern, name string) (matched bool, err error) {
+Pattern:
+	for len(pattern) > 0 {
+		var star bool
+		var chunk string
+		star, chunk, pattern = scanChunk(pattern)
+		if star && chunk == "" {
+			// Trailing * matches rest of string.
+			return true, nil
+		}
+		// Look for match at current position.
+		t, ok, err := legacyMatchChunk(chunk, name)
+		// if we're the last chunk, make sure we've exhausted the name
+		// otherwise we'll give a false result even if we could still match
+		// using the star
+		if ok && (len(t) == 0 || len(pattern) > 0) {
+			name = t
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if star {
+			// Look for match skipping i+1 bytes.
+			// Cannot skip /.
+			for i := 0; i < len(name); i++ {
+				t, ok, err := legacyMatchChunk(chunk, name[i+1:])
+				if ok {
+					// if we're the last chunk, make sure we exhausted the name
+					if len(pattern) == 0 && len(t) > 0 {
+						continue
+					}
+					name = t
+					continue Pattern
+				}
+				if err != nil {
+					return false, err
+				}
+			}
+		}
+		return false, nil
+	}
+	return len(name) == 0, nil
+}
+
+// legacyMatchChunk checks whether chunk matches the beginning of s.
+// If so, it returns the remainder of s (after the match).
+// Chunk is all single-character operators: literals, char classes, and ?.
+func legacyMatchChunk(chunk, s string) (rest string, ok bool, err error) {
+	for len(chunk) > 0 {
+		if len(s) == 0 {
+			return
+		}
+		switch chunk[0] {
+		case '[':
+			// character class
+			r, n := utf8.DecodeRuneInString(s)
+			s = s[n:]
+			chunk = chunk[1:]
+			// We can't end right after '[', we're expecting at least
+			// a closing bracket and possibly a caret.
+			if len(chunk) == 0 {
+				err = filepath.ErrBadPattern
+				return
+			}
+			// possibly negated
+			negated := chunk[0] == '^'
+			if negated {
+				chunk = chunk[1:]
+			}
+			// parse all ranges
+			match := false
+			nrange := 0
+			for {
+				if len(chunk) > 0 && chunk[0] == ']' && nrange > 0 {
+					chunk = chunk[1:]
+					break
+				}
+				var lo, hi rune
+				if lo, chunk, err = getEsc(chunk); err != nil {
+					return
+				}
+				hi = lo
+				if chunk[0] == '-' {
+					if hi, chunk, err = getEsc(chunk[1:]); err != nil {
+						return
+					}
+				}
+				if lo <= r && r <= hi {
+					match = true
+				}
+				nrange++
+			}
+			if match == negated {
+				return
+			}
+
+		case '?':
+			_, n := utf8.DecodeRuneInString(s)
+			s = s[n:]
+			chunk = chunk[1:]
+
+		case '\\':
+			chunk = chunk[1:]
+			if len(chunk) == 0 {
+				err = filepath.ErrBadPattern
+				return
+			}
+			fallthrough
+
+		default:
+			if chunk[0] != s[0] {
+				return
+			}
+			s = s[1:]
+			chunk = chunk[1:]
+		}
+	}
+	return s, true, nil
+}
@@ -69,21 +69,21 @@ func TestParse(t *testing.T) {
 		{true, `* foo*`, stringPrefixMatcher("foo")},
 		{true, `* *foo`, stringSuffixMatcher("foo")},
 		{true, `* *foo*`, stringPartialMatcher("foo")},
-		{true, `* foo*bar`, globMatcher("foo*bar")},
-		{true, `* *foo*bar`, globMatcher("*foo*bar")},
-		{true, `* foo?bar`, globMatcher("foo?bar")},
+		{true, `* foo*bar`, newGlobMatcher("foo*bar")},
+		{true, `* *foo*bar`, newGlobMatcher("*foo*bar")},
+		{true, `* foo?bar`, newGlobMatcher("foo?bar")},
 
 		{true, `!*`, Not(stringFullMatcher(""))},
 		{true, `!* foo`, Not(stringFullMatcher("foo"))},
 		{true, `!* foo*`, Not(stringPrefixMatcher("foo"))},
 		{true, `!* *foo`, Not(stringSuffixMatcher("foo"))},
 		{true, `!* *foo*`, Not(stringPartialMatcher("foo"))},
-		{true, `!* foo*bar`, Not(globMatcher("foo*bar"))},
-		{true, `!* *foo*bar`, Not(globMatcher("*foo*bar"))},
-		{true, `!* foo?bar`, Not(globMatcher("foo?bar"))},
+		{true, `!* foo*bar`, Not(newGlobMatcher("foo*bar"))},
+		{true, `!* *foo*bar`, Not(newGlobMatcher("*foo*bar"))},
+		{true, `!* foo?bar`, Not(newGlobMatcher("foo?bar"))},
 
-		{true, "glob:foo*bar", globMatcher("foo*bar")},
-		{true, "!glob:foo*bar", Not(globMatcher("foo*bar"))},
+		{true, "glob:foo*bar", newGlobMatcher("foo*bar")},
+		{true, "!glob:foo*bar", Not(newGlobMatcher("foo*bar"))},
 
 		{true, `simple_patterns:`, FALSE()},
 		{true, `simple_patterns:  `, FALSE()},
@@ -4,8 +4,11 @@ package metrics
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 
+	"github.com/netdata/go.d.plugin/agent/module"
 	"github.com/netdata/go.d.plugin/pkg/stm"
 )
 
@@ -31,6 +34,7 @@ type (
 		sum          float64
 		count        int64
 		rangeBuckets bool
+		cdf          bool
 	}
 )
 
@@ -88,38 +92,79 @@ func ExponentialBuckets(start, factor float64, count int) []float64 {
 	return buckets
 }
 
-// NewHistogram creates a new Histogram.
-func NewHistogram(buckets []float64) Histogram {
-	if len(buckets) == 0 {
-		buckets = DefBuckets
-	} else {
-		sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+// ValidateBuckets checks the user-defined histogram buckets (e.g. from a module configuration).
+// The buckets must be finite and unique, the order doesn't matter.
+func ValidateBuckets(buckets []float64) error {
+	seen := make(map[float64]bool, len(buckets))
+	for _, v := range buckets {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("bucket '%v' is not a finite number", v)
+		}
+		if seen[v] {
+			return fmt.Errorf("duplicate bucket '%v'", v)
+		}
+		seen[v] = true
 	}
+	return nil
+}
 
+// NewHistogram creates a new Histogram.
+func NewHistogram(buckets []float64) Histogram {
+	bounds := sortedBuckets(buckets)
 	return &histogram{
-		buckets:     make([]int64, len(buckets)),
-		upperBounds: buckets,
-		count:       0,
-		sum:         0,
+		buckets:     make([]int64, len(bounds)),
+		upperBounds: bounds,
 	}
 }
 
 func NewHistogramWithRangeBuckets(buckets []float64) Histogram {
-	if len(buckets) == 0 {
-		buckets = DefBuckets
-	} else {
-		sort.Slice(buckets, func(i, j int) bool { return buckets[i] < buckets[j] })
+	bounds := sortedBuckets(buckets)
+	return &histogram{
+		buckets:      make([]int64, len(bounds)),
+		upperBounds:  bounds,
+		rangeBuckets: true,
 	}
+}
 
+// NewHistogramWithCDF creates a new Histogram that writes both the per-bucket
+// and the cumulative (values less than or equal to the bucket upper bound) counts.
+func NewHistogramWithCDF(buckets []float64) Histogram {
+	bounds := sortedBuckets(buckets)
 	return &histogram{
-		buckets:      make([]int64, len(buckets)),
-		upperBounds:  buckets,
-		count:        0,
-		sum:          0,
+		buckets:      make([]int64, len(bounds)),
+		upperBounds:  bounds,
 		rangeBuckets: true,
+		cdf:          true,
 	}
 }
 
+// HistogramBucketDims returns the chart dimensions of the per-bucket counts of a histogram
+// created by NewHistogramWithRangeBuckets or NewHistogramWithCDF, the last dimension is the +Inf bucket.
+// The dimension IDs depend only on the bucket position in the sorted buckets, the names are the upper bounds.
+// Use key+"_cdf" as the key to get the dimensions of the cumulative counts.
+func HistogramBucketDims(key string, buckets []float64) module.Dims {
+	bounds := sortedBuckets(buckets)
+	dims := make(module.Dims, 0, len(bounds)+1)
+	for i, v := range bounds {
+		dims = append(dims, &module.Dim{
+			ID:   fmt.Sprintf("%s_bucket_%d", key, i+1),
+			Name: strconv.FormatFloat(v, 'f', -1, 64),
+			Algo: module.Incremental,
+		})
+	}
+	return append(dims, &module.Dim{ID: key + "_bucket_inf", Name: "+Inf", Algo: module.Incremental})
+}
+
+// sortedBuckets returns the sorted copy of the buckets, DefBuckets if the buckets are empty.
+func sortedBuckets(buckets []float64) []float64 {
+	if len(buckets) == 0 {
+		return DefBuckets
+	}
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	return bounds
+}
+
 // WriteTo writes its values into given map.
 // It adds those key-value pairs:
 //
@@ -129,6 +174,15 @@ func NewHistogramWithRangeBuckets(buckets []float64) Histogram {
 //	${key}_bucket_2   counter, for 2nd bucket count
 //	...
 //	${key}_bucket_N   counter, for Nth bucket count
+//
+// The bucket counts are cumulative, unless the histogram is created with range buckets.
+// Range bucket histograms also add ${key}_bucket_inf (+Inf bucket count).
+// Histograms created by NewHistogramWithCDF also add the cumulative counts:
+//
+//	${key}_cdf_bucket_1     counter, for count of values less than or equal to the 1st bucket upper bound
+//	...
+//	${key}_cdf_bucket_N     counter, for count of values less than or equal to the Nth bucket upper bound
+//	${key}_cdf_bucket_inf   counter, for count of it's observed values
 func (h histogram) WriteTo(rv map[string]int64, key string, mul, div int) {
 	rv[key+"_sum"] = int64(h.sum * float64(mul) / float64(div))
 	rv[key+"_count"] = h.count
@@ -141,11 +195,17 @@ func (h histogram) WriteTo(rv map[string]int64, key string, mul, div int) {
 		} else {
 			rv[name] = conn
 		}
+		if h.cdf {
+			rv[fmt.Sprintf("%s_cdf_bucket_%d", key, i+1)] = conn
+		}
 	}
 	if h.rangeBuckets {
 		name := fmt.Sprintf("%s_bucket_inf", key)
 		rv[name] = h.count - conn
 	}
+	if h.cdf {
+		rv[key+"_cdf_bucket_inf"] = h.count
+	}
 }
 
 // Observe observes a value
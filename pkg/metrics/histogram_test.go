@@ -3,8 +3,11 @@
 package metrics
 
 import (
+	"math"
 	"testing"
 
+	"github.com/netdata/go.d.plugin/agent/module"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,6 +76,88 @@ func TestHistogram_WriteTo(t *testing.T) {
 	assert.EqualValues(t, 2, m["pi_bucket_3"])
 }
 
+func TestNewHistogram_DoesNotModifyBuckets(t *testing.T) {
+	buckets := []float64{10, 1, 5}
+	NewHistogram(buckets)
+	NewHistogramWithCDF(buckets)
+	assert.Equal(t, []float64{10, 1, 5}, buckets)
+}
+
+func TestValidateBuckets(t *testing.T) {
+	tests := map[string]struct {
+		buckets []float64
+		wantErr bool
+	}{
+		"empty":         {buckets: nil},
+		"sorted":        {buckets: []float64{0.0001, 0.001, 0.01}},
+		"not sorted":    {buckets: []float64{60, 1, 3600}},
+		"negative":      {buckets: []float64{-1, 0, 1}},
+		"duplicate":     {buckets: []float64{1, 2, 1}, wantErr: true},
+		"NaN":           {buckets: []float64{1, math.NaN()}, wantErr: true},
+		"positive +Inf": {buckets: []float64{1, math.Inf(1)}, wantErr: true},
+		"negative -Inf": {buckets: []float64{math.Inf(-1), 1}, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.wantErr {
+				assert.Error(t, ValidateBuckets(test.buckets))
+			} else {
+				assert.NoError(t, ValidateBuckets(test.buckets))
+			}
+		})
+	}
+}
+
+func TestHistogramWithCDF_WriteTo(t *testing.T) {
+	h := NewHistogramWithCDF([]float64{3, 1, 2})
+	m := map[string]int64{}
+	h.WriteTo(m, "pi", 100, 1)
+	assert.Len(t, m, 10)
+
+	h.Observe(0)
+	h.Observe(1.5)
+	h.Observe(1.8)
+	h.Observe(3.5)
+	h.WriteTo(m, "pi", 100, 1)
+	expected := map[string]int64{
+		"pi_count":          4,
+		"pi_sum":            680,
+		"pi_bucket_1":       1,
+		"pi_bucket_2":       2,
+		"pi_bucket_3":       0,
+		"pi_bucket_inf":     1,
+		"pi_cdf_bucket_1":   1,
+		"pi_cdf_bucket_2":   3,
+		"pi_cdf_bucket_3":   3,
+		"pi_cdf_bucket_inf": 4,
+	}
+	assert.Equal(t, expected, m)
+}
+
+func TestHistogramBucketDims(t *testing.T) {
+	buckets := []float64{0.01, 0.0005, 0.001}
+
+	var ids, names []string
+	for _, dim := range HistogramBucketDims("time_hist", buckets) {
+		ids, names = append(ids, dim.ID), append(names, dim.Name)
+		assert.Equal(t, module.Incremental, dim.Algo)
+	}
+	assert.Equal(t, []string{"time_hist_bucket_1", "time_hist_bucket_2", "time_hist_bucket_3", "time_hist_bucket_inf"}, ids)
+	assert.Equal(t, []string{"0.0005", "0.001", "0.01", "+Inf"}, names)
+
+	// the dimension IDs match the histogram keys
+	h := NewHistogramWithCDF(buckets)
+	h.Observe(0.0007)
+	m := map[string]int64{}
+	h.WriteTo(m, "time_hist", 1, 1)
+	dims := append(HistogramBucketDims("time_hist", buckets), HistogramBucketDims("time_hist_cdf", buckets)...)
+	assert.Len(t, dims, len(m)-2) // sum and count
+	for _, dim := range dims {
+		assert.Contains(t, m, dim.ID)
+	}
+}
+
 func TestHistogram_searchBucketIndex(t *testing.T) {
 	h := NewHistogram(LinearBuckets(1, 1, 5)).(*histogram) // [1, 2, ..., 5]
 	assert.Equal(t, 0, h.searchBucketIndex(0.1))
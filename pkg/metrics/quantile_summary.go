@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package metrics
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/netdata/go.d.plugin/pkg/stm"
+)
+
+// DefObjectives are the default summary quantile objectives.
+var DefObjectives = []float64{0.5, 0.9, 0.95, 0.99}
+
+// DefReservoirSize is the default number of observations a summary with quantiles keeps.
+const DefReservoirSize = 1024
+
+type (
+	// quantileSummary is a Summary that also estimates quantiles of its observed values from last Reset calls.
+	// It keeps a uniform random sample of the observations (a fixed-size reservoir, Vitter's Algorithm R),
+	// the quantiles are exact if the number of observations doesn't exceed the reservoir size.
+	quantileSummary struct {
+		summary
+		objectives []float64
+		reservoir  []float64
+		sorted     []float64
+		rnd        *rand.Rand
+	}
+)
+
+var (
+	_ stm.Value = (*quantileSummary)(nil)
+)
+
+// NewSummaryWithQuantiles creates a new Summary that estimates the quantiles of its observed values.
+// DefObjectives are used if objectives is empty.
+//
+// The function panics if an objective is not in the (0, 1] range.
+func NewSummaryWithQuantiles(objectives []float64) Summary {
+	return NewSummaryWithQuantilesSize(objectives, DefReservoirSize)
+}
+
+// NewSummaryWithQuantilesSize is like NewSummaryWithQuantiles, but it allows to set the reservoir size.
+//
+// The function panics if an objective is not in the (0, 1] range or if size is 0 or negative.
+func NewSummaryWithQuantilesSize(objectives []float64, size int) Summary {
+	if size < 1 {
+		panic("NewSummaryWithQuantilesSize needs a positive size")
+	}
+	if len(objectives) == 0 {
+		objectives = DefObjectives
+	}
+	for _, q := range objectives {
+		if !(q > 0 && q <= 1) {
+			panic("NewSummaryWithQuantiles needs objectives in the (0, 1] range")
+		}
+	}
+	objectives = append([]float64(nil), objectives...)
+	sort.Float64s(objectives)
+
+	return &quantileSummary{
+		summary: summary{
+			min: math.MaxFloat64,
+			max: -math.MaxFloat64,
+		},
+		objectives: objectives,
+		reservoir:  make([]float64, 0, size),
+		rnd:        rand.New(rand.NewSource(1)),
+	}
+}
+
+// QuantileSuffix returns the key suffix of the quantile ("p50" for 0.5, "p99_9" for 0.999).
+func QuantileSuffix(q float64) string {
+	s := strconv.FormatFloat(q*100, 'f', -1, 64)
+	return "p" + strings.ReplaceAll(s, ".", "_")
+}
+
+// WriteTo writes its values into given map.
+// It adds the Summary key-value pairs and those key-value pairs:
+//
+//	${key}_p50        gauge, for 0.5 quantile of it's observed values from last Reset calls (only exists if count > 0)
+//	...
+//	${key}_p99        gauge, for 0.99 quantile of it's observed values from last Reset calls (only exists if count > 0)
+func (s *quantileSummary) WriteTo(rv map[string]int64, key string, mul, div int) {
+	s.summary.WriteTo(rv, key, mul, div)
+
+	if len(s.reservoir) == 0 {
+		for _, q := range s.objectives {
+			delete(rv, key+"_"+QuantileSuffix(q))
+		}
+		return
+	}
+
+	s.sorted = append(s.sorted[:0], s.reservoir...)
+	sort.Float64s(s.sorted)
+	for _, q := range s.objectives {
+		rv[key+"_"+QuantileSuffix(q)] = int64(quantile(s.sorted, q) * float64(mul) / float64(div))
+	}
+}
+
+// Reset resets all of its counters and drops the observations.
+// Call it before every scrape loop.
+func (s *quantileSummary) Reset() {
+	s.summary.Reset()
+	s.reservoir = s.reservoir[:0]
+}
+
+// Observe observes a value
+func (s *quantileSummary) Observe(v float64) {
+	s.summary.Observe(v)
+
+	if len(s.reservoir) < cap(s.reservoir) {
+		s.reservoir = append(s.reservoir, v)
+		return
+	}
+	if i := s.rnd.Int63n(s.count); i < int64(len(s.reservoir)) {
+		s.reservoir[i] = v
+	}
+}
+
+// quantile returns the q-quantile of the sorted values, it interpolates linearly between the closest ranks.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lo := int(pos)
+	if lo >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + (sorted[lo+1]-sorted[lo])*(pos-float64(lo))
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/netdata/go.d.plugin/pkg/stm"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSummaryWithQuantiles(t *testing.T) {
+	s := NewSummaryWithQuantiles(nil).(*quantileSummary)
+	assert.Equal(t, DefObjectives, s.objectives)
+	assert.Equal(t, DefReservoirSize, cap(s.reservoir))
+
+	s = NewSummaryWithQuantiles([]float64{0.99, 0.5}).(*quantileSummary)
+	assert.Equal(t, []float64{0.5, 0.99}, s.objectives)
+
+	assert.Panics(t, func() {
+		NewSummaryWithQuantiles([]float64{0})
+	})
+	assert.Panics(t, func() {
+		NewSummaryWithQuantiles([]float64{1.5})
+	})
+	assert.Panics(t, func() {
+		NewSummaryWithQuantilesSize(nil, 0)
+	})
+}
+
+func TestQuantileSuffix(t *testing.T) {
+	tests := map[float64]string{
+		0.5:   "p50",
+		0.95:  "p95",
+		0.99:  "p99",
+		0.999: "p99_9",
+		1:     "p100",
+	}
+
+	for q, suffix := range tests {
+		assert.Equal(t, suffix, QuantileSuffix(q))
+	}
+}
+
+func TestQuantileSummary_WriteTo(t *testing.T) {
+	s := NewSummaryWithQuantiles([]float64{0.5, 0.95, 0.99})
+
+	m := map[string]int64{}
+	s.WriteTo(m, "time", 1, 1)
+	assert.Equal(t, map[string]int64{"time_count": 0, "time_sum": 0}, m)
+
+	for i := 1; i <= 100; i++ {
+		s.Observe(float64(i))
+	}
+
+	s.WriteTo(m, "time", 1, 1)
+	expected := map[string]int64{
+		"time_count": 100,
+		"time_sum":   5050,
+		"time_min":   1,
+		"time_max":   100,
+		"time_avg":   50,
+		"time_p50":   50,
+		"time_p95":   95,
+		"time_p99":   99,
+	}
+	assert.Equal(t, expected, m)
+
+	s.Reset()
+	s.WriteTo(m, "time", 1, 1)
+	assert.Equal(t, map[string]int64{"time_count": 0, "time_sum": 0}, m)
+}
+
+func TestQuantileSummary_WriteTo_MulDiv(t *testing.T) {
+	s := NewSummaryWithQuantiles([]float64{0.5})
+	s.Observe(0.25)
+	s.Observe(0.75)
+
+	m := map[string]int64{}
+	s.WriteTo(m, "time", 1000, 1)
+	assert.EqualValues(t, 500, m["time_p50"])
+}
+
+func TestQuantileSummary_Reservoir(t *testing.T) {
+	s := NewSummaryWithQuantilesSize([]float64{0.5, 0.9}, 100).(*quantileSummary)
+
+	for i := 0; i < 100000; i++ {
+		s.Observe(float64(i % 1000))
+	}
+
+	assert.Len(t, s.reservoir, 100)
+	assert.EqualValues(t, 100000, s.count)
+
+	m := map[string]int64{}
+	s.WriteTo(m, "v", 1, 1)
+	assert.InDelta(t, 500, m["v_p50"], 150)
+	assert.InDelta(t, 900, m["v_p90"], 100)
+	assert.EqualValues(t, 0, m["v_min"])
+	assert.EqualValues(t, 999, m["v_max"])
+}
+
+func TestQuantileSummary_Stm(t *testing.T) {
+	v := struct {
+		Time Summary `stm:"time"`
+	}{
+		Time: NewSummaryWithQuantiles([]float64{0.95}),
+	}
+	v.Time.Observe(1)
+	v.Time.Observe(3)
+
+	m := stm.ToMap(v)
+	assert.EqualValues(t, 2, m["time_p95"])
+}
+
+func BenchmarkQuantileSummary_Observe(b *testing.B) {
+	s := NewSummaryWithQuantiles(nil)
+	for i := 0; i < b.N; i++ {
+		s.Observe(float64(i))
+	}
+}
+
+func BenchmarkQuantileSummary_WriteTo(b *testing.B) {
+	s := NewSummaryWithQuantiles(nil)
+	for i := 0; i < DefReservoirSize; i++ {
+		s.Observe(float64(i))
+	}
+	m := map[string]int64{}
+	for i := 0; i < b.N; i++ {
+		s.WriteTo(m, "pi", 100, 1)
+	}
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package prometheus
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netdata/go.d.plugin/pkg/web"
+)
+
+// cacheEntryMaxIdle is the time after which an unused cache entry is removed.
+const cacheEntryMaxIdle = 10 * time.Minute
+
+// defaultCache is the in-process response cache shared by Prometheus instances created WithCacheTTL.
+var defaultCache = newResponseCache()
+
+type (
+	responseCache struct {
+		mu      sync.Mutex
+		entries map[string]*cacheEntry
+		used    map[string]time.Time
+	}
+	cacheEntry struct {
+		mu sync.Mutex
+		validators
+
+		body        []byte
+		contentType string
+		expires     time.Time
+		// version is incremented every time the body changes
+		version uint64
+	}
+	// validators are the response validators of the URL, they are used to make conditional requests.
+	validators struct {
+		url          string
+		etag         string
+		lastModified string
+	}
+)
+
+func newResponseCache() *responseCache {
+	return &responseCache{
+		entries: make(map[string]*cacheEntry),
+		used:    make(map[string]time.Time),
+	}
+}
+
+// get returns the cache entry of the key, it creates a new one if there is no such entry.
+// Entries that are not used for cacheEntryMaxIdle are removed.
+func (c *responseCache) get(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, t := range c.used {
+		if now.Sub(t) > cacheEntryMaxIdle {
+			delete(c.entries, k)
+			delete(c.used, k)
+		}
+	}
+
+	e, ok := c.entries[key]
+	if !ok {
+		e = &cacheEntry{}
+		c.entries[key] = e
+	}
+	c.used[key] = now
+
+	return e
+}
+
+// fetch returns the cached response body if it is not expired, otherwise it refreshes the entry
+// (using a conditional request if possible). Only one instance at a time refreshes the entry.
+// The returned body must not be modified.
+func (e *cacheEntry) fetch(p *prometheus) ([]byte, string, uint64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	if e.version > 0 && now.Before(e.expires) {
+		return e.body, e.contentType, e.version, nil
+	}
+
+	resp, err := p.doWithFallback(e.validators)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotModified {
+		e.expires = now.Add(p.cacheTTL)
+		return e.body, e.contentType, e.version, nil
+	}
+
+	r, err := p.bodyReader(resp)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	e.body = body
+	e.contentType = resp.Header.Get("Content-Type")
+	e.validators = validatorsOf(p.request.URL, resp.Header)
+	e.expires = now.Add(p.cacheTTL)
+	e.version++
+
+	return e.body, e.contentType, e.version, nil
+}
+
+func validatorsOf(url string, h http.Header) validators {
+	return validators{url: url, etag: h.Get("ETag"), lastModified: h.Get("Last-Modified")}
+}
+
+func (v validators) isSet() bool {
+	return v.etag != "" || v.lastModified != ""
+}
+
+func (v validators) setHeaders(h http.Header) {
+	if v.etag != "" {
+		h.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		h.Set("If-Modified-Since", v.lastModified)
+	}
+}
+
+// requestKey returns the cache key of the request: the URL, the method, the body, the credentials and the headers.
+func requestKey(req web.Request) string {
+	var sb strings.Builder
+
+	for _, v := range []string{req.Method, req.URL, req.Body, req.Username, req.Password, req.ProxyUsername, req.ProxyPassword} {
+		sb.WriteString(v)
+		sb.WriteByte(0)
+	}
+
+	names := make([]string, 0, len(req.Headers))
+	for k := range req.Headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		sb.WriteString(k)
+		sb.WriteByte(':')
+		sb.WriteString(req.Headers[k])
+		sb.WriteByte(0)
+	}
+
+	return sb.String()
+}
@@ -6,10 +6,14 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/netdata/go.d.plugin/pkg/prometheus/relabel"
 	"github.com/netdata/go.d.plugin/pkg/prometheus/selector"
 	"github.com/netdata/go.d.plugin/pkg/web"
 )
@@ -19,80 +23,205 @@ type (
 	Prometheus interface {
 		// ScrapeSeries and parse prometheus format metrics
 		ScrapeSeries() (Series, error)
+		// ScrapeSeriesFunc scrapes and parses prometheus format metrics calling fn for every series.
+		// The series are not retained, labels are only valid until fn returns (use SeriesSample.Copy to keep them).
+		ScrapeSeriesFunc(fn func(SeriesSample)) error
 		Scrape() (MetricFamilies, error)
+		// Metadata returns the metric families metadata ('# HELP' and '# TYPE' lines) of the last scrape.
+		// It is only valid until the next scrape.
+		Metadata() Metadata
+		// URL returns the URL of the last successful scrape (the first URL if there were no successful scrapes).
+		URL() string
 	}
 
+	// Option configures a Prometheus instance.
+	Option func(*prometheus)
+
 	prometheus struct {
 		client  *http.Client
 		request web.Request
 
+		// fallback requests, the last successful one is tried first
+		requests []web.Request
+		curr     int
+
 		sr selector.Selector
 
+		cacheTTL time.Duration
+		// last is the last successfully parsed response, it is used to make conditional requests
+		last struct {
+			kind scrapeKind
+			validators
+			entry   *cacheEntry
+			version uint64
+		}
+
 		parser promTextParser
 
-		buf     *bytes.Buffer
+		buf     []byte
 		gzipr   *gzip.Reader
 		bodyBuf *bufio.Reader
 	}
 )
 
+// scrapeKind is the kind of the parsed result the scrape keeps between scrapes.
+type scrapeKind int
+
 const (
-	acceptHeader    = `text/plain;version=0.0.4;q=1,*/*;q=0.1`
+	scrapeNone scrapeKind = iota
+	scrapeSeries
+	scrapeMetricFamilies
+)
+
+// StatusCodeError is returned if the server responds with an unexpected HTTP status code.
+type StatusCodeError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusCodeError) Error() string {
+	return fmt.Sprintf("server '%s' returned HTTP status code %d (%s)", e.URL, e.StatusCode, e.Status)
+}
+
+// errNotModified is returned by fetch if the response is not modified since the last scrape of the same kind.
+var errNotModified = errors.New("not modified")
+
+const (
+	acceptHeader    = `application/openmetrics-text;version=1.0.0;q=0.8,application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3,*/*;q=0.1`
 	userAgentHeader = `netdata/go.d.plugin`
 )
 
+// chunkSize is the initial size of the buffer the response body is read (and parsed) by.
+const chunkSize = 32 * 1024
+
 // New creates a Prometheus instance.
-func New(client *http.Client, request web.Request) Prometheus {
-	return &prometheus{
-		client:  client,
-		request: request,
-		buf:     bytes.NewBuffer(make([]byte, 0, 16000)),
-	}
+func New(client *http.Client, request web.Request, opts ...Option) Prometheus {
+	return newPrometheus(client, request, nil, opts)
 }
 
 // NewWithSelector creates a Prometheus instance with the selector.
-func NewWithSelector(client *http.Client, request web.Request, sr selector.Selector) Prometheus {
-	return &prometheus{
+func NewWithSelector(client *http.Client, request web.Request, sr selector.Selector, opts ...Option) Prometheus {
+	return newPrometheus(client, request, sr, opts)
+}
+
+// NewWithFallback creates a Prometheus instance that scrapes the first responding URL of the requests.
+// The last successful request is tried first on the next scrape. The selector is optional (can be nil).
+func NewWithFallback(client *http.Client, requests []web.Request, sr selector.Selector, opts ...Option) Prometheus {
+	var request web.Request
+	if len(requests) > 0 {
+		request = requests[0]
+	}
+	p := newPrometheus(client, request, sr, opts)
+	p.requests = requests
+	return p
+}
+
+// FallbackRequests returns the request followed by its copies with the fallback URLs (the requests of NewWithFallback).
+func FallbackRequests(request web.Request, urls []string) []web.Request {
+	reqs := []web.Request{request}
+	for _, u := range urls {
+		req := request.Copy()
+		req.URL = u
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+// WithCacheTTL makes Prometheus instances scraping the same URL (with the same headers and credentials)
+// share the response for the ttl. Every instance parses the shared response with its own selector.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(p *prometheus) { p.cacheTTL = ttl }
+}
+
+// WithRelabeler makes Prometheus apply the relabeling rules to the scraped series (after the selector).
+func WithRelabeler(rl *relabel.Relabeler) Option {
+	return func(p *prometheus) { p.parser.rl = rl }
+}
+
+// WithCreatedSeries makes Prometheus keep the OpenMetrics '_created' series (the creation timestamps
+// of counters, summaries and histograms), they are dropped by default. Scrape puts them in "name_created" gauge families.
+func WithCreatedSeries() Option {
+	return func(p *prometheus) { p.parser.keepCreated = true }
+}
+
+func newPrometheus(client *http.Client, request web.Request, sr selector.Selector, opts []Option) *prometheus {
+	p := &prometheus{
 		client:  client,
 		request: request,
 		sr:      sr,
-		buf:     bytes.NewBuffer(make([]byte, 0, 16000)),
+		buf:     make([]byte, chunkSize),
 		parser:  promTextParser{sr: sr},
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
-// ScrapeSeries scrapes metrics, parses and sorts
-func (p *prometheus) ScrapeSeries() (Series, error) {
-	p.buf.Reset()
+func (p *prometheus) Metadata() Metadata {
+	return p.parser.meta
+}
 
-	if err := p.fetch(p.buf); err != nil {
+func (p *prometheus) URL() string {
+	return p.request.URL
+}
+
+// ScrapeSeries scrapes metrics, parses and sorts.
+// It returns the previously parsed series if the response is not modified since the last scrape.
+func (p *prometheus) ScrapeSeries() (Series, error) {
+	err := p.fetch(scrapeSeries, func(chunk []byte) error {
+		return p.parser.parseSeries(chunk, p.parser.addSeries)
+	})
+	if errors.Is(err, errNotModified) {
+		return p.parser.series, nil
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	return p.parser.parseToSeries(p.buf.Bytes())
+	p.parser.series.Sort()
+
+	return p.parser.series, nil
 }
 
-func (p *prometheus) Scrape() (MetricFamilies, error) {
-	p.buf.Reset()
+func (p *prometheus) ScrapeSeriesFunc(fn func(SeriesSample)) error {
+	return p.fetch(scrapeNone, func(chunk []byte) error {
+		return p.parser.parseSeries(chunk, fn)
+	})
+}
 
-	if err := p.fetch(p.buf); err != nil {
+// Scrape scrapes metrics and parses them into metric families.
+// It returns the previously parsed metric families if the response is not modified since the last scrape.
+func (p *prometheus) Scrape() (MetricFamilies, error) {
+	err := p.fetch(scrapeMetricFamilies, p.parser.parseMetricFamilies)
+	if errors.Is(err, errNotModified) {
+		return p.parser.metrics, nil
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	return p.parser.parseToMetricFamilies(p.buf.Bytes())
+	p.parser.removeEmptyMetricFamilies()
+
+	return p.parser.metrics, nil
 }
 
-func (p *prometheus) fetch(w io.Writer) error {
-	req, err := web.NewHTTPRequest(p.request)
-	if err != nil {
-		return err
+// fetch makes the request (or gets the response from the cache) and calls fn for every chunk of the response body.
+// A chunk consists of whole lines, it is only valid until fn returns.
+// It returns errNotModified (without calling fn) if the response is not modified since the last fetch of the same kind.
+func (p *prometheus) fetch(kind scrapeKind, fn func(chunk []byte) error) error {
+	if p.cacheTTL > 0 {
+		return p.fetchCached(kind, fn)
 	}
 
-	req.Header.Add("Accept", acceptHeader)
-	req.Header.Add("Accept-Encoding", "gzip")
-	req.Header.Set("User-Agent", userAgentHeader)
+	var v validators
+	if kind != scrapeNone && p.last.kind == kind && p.last.entry == nil {
+		v = p.last.validators
+	}
+	p.last.kind = scrapeNone
 
-	resp, err := p.client.Do(req)
+	resp, err := p.doWithFallback(v)
 	if err != nil {
 		return err
 	}
@@ -102,28 +231,251 @@ func (p *prometheus) fetch(w io.Writer) error {
 		_ = resp.Body.Close()
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("server '%s' returned HTTP status code %d (%s)", req.URL, resp.StatusCode, resp.Status)
+	if resp.StatusCode == http.StatusNotModified {
+		p.last.kind = kind
+		return errNotModified
 	}
 
-	if resp.Header.Get("Content-Encoding") != "gzip" {
-		_, err = io.Copy(w, resp.Body)
+	r, err := p.bodyReader(resp)
+	if err != nil {
 		return err
 	}
 
+	if err := p.parse(kind, r, resp.Header.Get("Content-Type"), fn); err != nil {
+		return err
+	}
+
+	if kind != scrapeNone {
+		p.last.validators = validatorsOf(p.request.URL, resp.Header)
+		p.last.entry, p.last.version = nil, 0
+		if p.last.validators.isSet() {
+			p.last.kind = kind
+		}
+	}
+
+	return nil
+}
+
+// fetchCached gets the response from the shared cache, the cache entry is refreshed if it is expired.
+func (p *prometheus) fetchCached(kind scrapeKind, fn func(chunk []byte) error) error {
+	entry := defaultCache.get(p.cacheKey())
+
+	body, contentType, version, err := entry.fetch(p)
+	if err != nil {
+		p.last.kind = scrapeNone
+		return err
+	}
+
+	if kind != scrapeNone && p.last.kind == kind && p.last.entry == entry && p.last.version == version {
+		return errNotModified
+	}
+	p.last.kind = scrapeNone
+
+	if err := p.parse(kind, bytes.NewReader(body), contentType, fn); err != nil {
+		return err
+	}
+
+	if kind != scrapeNone {
+		p.last.kind, p.last.entry, p.last.version = kind, entry, version
+	}
+
+	return nil
+}
+
+// parse resets the parser state of the kind and parses the response body.
+func (p *prometheus) parse(kind scrapeKind, r io.Reader, contentType string, fn func(chunk []byte) error) error {
+	switch kind {
+	case scrapeSeries:
+		p.parser.series.Reset()
+		p.parser.resetMetadata()
+	case scrapeMetricFamilies:
+		p.parser.reset()
+	default:
+		p.parser.resetMetadata()
+	}
+
+	p.parser.protobuf = isProtobuf(contentType)
+	p.parser.openMetrics = !p.parser.protobuf && isOpenMetrics(contentType)
+	p.parser.currType.name, p.parser.currType.typ = "", ""
+
+	// the protobuf format is not line based, it is parsed at once
+	if p.parser.protobuf {
+		return p.readAll(r, fn)
+	}
+	return p.readChunks(r, fn)
+}
+
+// bodyReader returns the response body reader, it decompresses gzip encoded body.
+func (p *prometheus) bodyReader(resp *http.Response) (io.Reader, error) {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return resp.Body, nil
+	}
+
 	if p.gzipr == nil {
 		p.bodyBuf = bufio.NewReader(resp.Body)
-		p.gzipr, err = gzip.NewReader(p.bodyBuf)
+		gzipr, err := gzip.NewReader(p.bodyBuf)
 		if err != nil {
-			return err
+			return nil, err
 		}
+		p.gzipr = gzipr
 	} else {
 		p.bodyBuf.Reset(resp.Body)
-		_ = p.gzipr.Reset(p.bodyBuf)
+		if err := p.gzipr.Reset(p.bodyBuf); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.gzipr, nil
+}
+
+func (p *prometheus) cacheKey() string {
+	if len(p.requests) == 0 {
+		return requestKey(p.request)
+	}
+	keys := make([]string, 0, len(p.requests))
+	for _, req := range p.requests {
+		keys = append(keys, requestKey(req))
+	}
+	return strings.Join(keys, "|")
+}
+
+// doWithFallback tries the last successful request first, then the rest of the requests in order.
+// It returns an error only if all the requests fail.
+func (p *prometheus) doWithFallback(v validators) (*http.Response, error) {
+	if len(p.requests) == 0 {
+		return p.do(p.request, v)
+	}
+
+	resp, err := p.do(p.requests[p.curr], v)
+	if err == nil {
+		return resp, nil
+	}
+	errs := []string{err.Error()}
+
+	for i, req := range p.requests {
+		if i == p.curr {
+			continue
+		}
+		resp, err := p.do(req, v)
+		if err == nil {
+			p.curr, p.request = i, req
+			return resp, nil
+		}
+		errs = append(errs, err.Error())
+	}
+
+	return nil, errors.New(strings.Join(errs, "; "))
+}
+
+// do makes the request, it is a conditional request if the validators are of the request URL.
+func (p *prometheus) do(request web.Request, v validators) (*http.Response, error) {
+	req, err := web.NewHTTPRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Accept", acceptHeader)
+	req.Header.Add("Accept-Encoding", "gzip")
+	req.Header.Set("User-Agent", userAgentHeader)
+
+	conditional := v.isSet() && v.url == request.URL
+	if conditional {
+		v.setHeaders(req.Header)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = io.Copy(w, p.gzipr)
-	_ = p.gzipr.Close()
+	if resp.StatusCode != http.StatusOK && !(conditional && resp.StatusCode == http.StatusNotModified) {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return nil, &StatusCodeError{URL: req.URL.String(), StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	return resp, nil
+}
+
+// readChunks reads r into the reusable buffer and calls fn for every chunk that ends with a newline.
+// The buffer grows only if a single line doesn't fit in it.
+func (p *prometheus) readChunks(r io.Reader, fn func(chunk []byte) error) error {
+	if len(p.buf) == 0 {
+		p.buf = make([]byte, chunkSize)
+	}
+
+	var n int
+	for {
+		if n == len(p.buf) {
+			p.buf = append(p.buf, make([]byte, len(p.buf))...)
+		}
+
+		num, err := r.Read(p.buf[n:])
+		n += num
+
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			if n == 0 {
+				return nil
+			}
+			if p.buf[n-1] != '\n' {
+				if n == len(p.buf) {
+					p.buf = append(p.buf, '\n')
+				} else {
+					p.buf[n] = '\n'
+				}
+				n++
+			}
+			return fn(p.buf[:n])
+		}
+
+		i := bytes.LastIndexByte(p.buf[:n], '\n')
+		if i == -1 {
+			continue
+		}
+		if i+1 == n {
+			if err := fn(p.buf[:n]); err != nil {
+				return err
+			}
+			n = 0
+			continue
+		}
+		// the text parser appends a newline to the input, that overwrites the first byte of the remainder
+		next := p.buf[i+1]
+		if err := fn(p.buf[:i+1]); err != nil {
+			return err
+		}
+		p.buf[i+1] = next
+		n = copy(p.buf, p.buf[i+1:n])
+	}
+}
+
+// readAll reads r into the reusable buffer and calls fn once for the whole content.
+func (p *prometheus) readAll(r io.Reader, fn func(chunk []byte) error) error {
+	if len(p.buf) == 0 {
+		p.buf = make([]byte, chunkSize)
+	}
+
+	var n int
+	for {
+		if n == len(p.buf) {
+			p.buf = append(p.buf, make([]byte, len(p.buf))...)
+		}
+
+		num, err := r.Read(p.buf[n:])
+		n += num
+
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			return fn(p.buf[:n])
+		}
+	}
+}
 
-	return err
+func isOpenMetrics(contentType string) bool {
+	return strings.HasPrefix(strings.TrimSpace(contentType), "application/openmetrics-text")
 }
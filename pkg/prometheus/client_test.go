@@ -5,27 +5,39 @@ package prometheus
 import (
 	"bytes"
 	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/netdata/go.d.plugin/pkg/prometheus/relabel"
 	"github.com/netdata/go.d.plugin/pkg/prometheus/selector"
 	"github.com/netdata/go.d.plugin/pkg/web"
 
+	"github.com/prometheus/prometheus/model/textparse"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 var (
-	testData, _       = os.ReadFile("testdata/testdata.txt")
-	testDataNoMeta, _ = os.ReadFile("testdata/testdata.nometa.txt")
+	testData, _             = os.ReadFile("testdata/testdata.txt")
+	testDataNoMeta, _       = os.ReadFile("testdata/testdata.nometa.txt")
+	testDataOpenMetrics, _  = os.ReadFile("testdata/openmetrics.txt")
+	testDataProtobuf, _     = os.ReadFile("testdata/protobuf.bin")
+	testDataProtobufText, _ = os.ReadFile("testdata/protobuf.txt")
 )
 
 func Test_testClientDataIsValid(t *testing.T) {
 	for name, data := range map[string][]byte{
-		"testData": testData,
+		"testData":             testData,
+		"testDataOpenMetrics":  testDataOpenMetrics,
+		"testDataProtobuf":     testDataProtobuf,
+		"testDataProtobufText": testDataProtobufText,
 	} {
 		require.NotNilf(t, data, name)
 	}
@@ -45,6 +57,10 @@ func TestPrometheus404(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Nil(t, res)
+
+	var se *StatusCodeError
+	require.True(t, errors.As(err, &se))
+	assert.Equal(t, http.StatusNotFound, se.StatusCode)
 }
 
 func TestPrometheusPlain(t *testing.T) {
@@ -111,6 +127,554 @@ func TestPrometheusGzip(t *testing.T) {
 	}
 }
 
+func TestPrometheus_ScrapeSeriesFunc(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(testData)
+	}))
+	defer ts.Close()
+
+	prom := New(http.DefaultClient, web.Request{URL: ts.URL})
+
+	var res Series
+	err := prom.ScrapeSeriesFunc(func(s SeriesSample) { res.Add(s.Copy()) })
+	require.NoError(t, err)
+
+	res.Sort()
+	verifyTestData(t, res)
+}
+
+func TestPrometheus_SmallChunks(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(bytes.TrimSuffix(testData, []byte("\n")))
+	}))
+	defer ts.Close()
+
+	want, err := New(http.DefaultClient, web.Request{URL: ts.URL}).Scrape()
+	require.NoError(t, err)
+
+	prom := New(http.DefaultClient, web.Request{URL: ts.URL}).(*prometheus)
+	prom.buf = make([]byte, 7)
+
+	series, err := prom.ScrapeSeries()
+	require.NoError(t, err)
+	verifyTestData(t, series)
+
+	mfs, err := prom.Scrape()
+	require.NoError(t, err)
+	require.Equal(t, want.Len(), mfs.Len())
+	for name, mf := range want {
+		got := mfs.Get(name)
+		require.NotNilf(t, got, name)
+		assert.Equalf(t, mf.Help(), got.Help(), name)
+		assert.Equalf(t, mf.Type(), got.Type(), name)
+		assert.Lenf(t, got.Metrics(), len(mf.Metrics()), name)
+	}
+}
+
+func TestPrometheus_Metadata(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(testData)
+	}))
+	defer ts.Close()
+
+	for name, scrape := range map[string]func(p Prometheus) error{
+		"ScrapeSeries":     func(p Prometheus) error { _, err := p.ScrapeSeries(); return err },
+		"ScrapeSeriesFunc": func(p Prometheus) error { return p.ScrapeSeriesFunc(func(SeriesSample) {}) },
+		"Scrape":           func(p Prometheus) error { _, err := p.Scrape(); return err },
+	} {
+		t.Run(name, func(t *testing.T) {
+			prom := New(http.DefaultClient, web.Request{URL: ts.URL})
+
+			for i := 0; i < 2; i++ {
+				require.NoError(t, scrape(prom))
+
+				meta := prom.Metadata()
+				md, ok := meta.Get("go_gc_duration_seconds")
+				require.True(t, ok)
+				assert.Equal(t, "A summary of the GC invocation durations.", md.Help())
+				assert.Equal(t, textparse.MetricTypeSummary, md.Type())
+
+				assert.Equal(t, textparse.MetricTypeSummary, meta.TypeOf("go_gc_duration_seconds_count"))
+				assert.Equal(t, textparse.MetricTypeCounter, meta.TypeOf("go_memstats_alloc_bytes_total"))
+				assert.Equal(t, textparse.MetricTypeUnknown, meta.TypeOf("not_exist_yet"))
+			}
+		})
+	}
+}
+
+func TestPrometheus_Fallback(t *testing.T) {
+	var primaryUp bool
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !primaryUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(testData)
+	}))
+	defer primary.Close()
+
+	var secondaryHits int
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secondaryHits++
+		_, _ = w.Write(testData)
+	}))
+	defer secondary.Close()
+
+	reqs := []web.Request{{URL: primary.URL}, {URL: secondary.URL}}
+	prom := NewWithFallback(http.DefaultClient, reqs, nil)
+	assert.Equal(t, primary.URL, prom.URL())
+
+	res, err := prom.ScrapeSeries()
+	require.NoError(t, err)
+	verifyTestData(t, res)
+	assert.Equal(t, secondary.URL, prom.URL())
+	assert.Equal(t, 1, secondaryHits)
+
+	primaryUp = true
+	_, err = prom.ScrapeSeries()
+	require.NoError(t, err)
+	assert.Equal(t, secondary.URL, prom.URL(), "last successful URL is tried first")
+	assert.Equal(t, 2, secondaryHits)
+
+	secondary.Close()
+	res, err = prom.ScrapeSeries()
+	require.NoError(t, err)
+	verifyTestData(t, res)
+	assert.Equal(t, primary.URL, prom.URL())
+}
+
+func TestFallbackRequests(t *testing.T) {
+	req := web.Request{URL: "http://127.0.0.1:9090/metrics", Headers: map[string]string{"X-Key": "value"}}
+
+	reqs := FallbackRequests(req, []string{"http://127.0.0.2:9090/metrics", "http://127.0.0.3:9090/metrics"})
+
+	require.Len(t, reqs, 3)
+	for i, u := range []string{req.URL, "http://127.0.0.2:9090/metrics", "http://127.0.0.3:9090/metrics"} {
+		assert.Equal(t, u, reqs[i].URL)
+		assert.Equal(t, req.Headers, reqs[i].Headers)
+	}
+}
+
+func TestPrometheus_Fallback_AllFail(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	reqs := []web.Request{{URL: ts.URL + "/first"}, {URL: ts.URL + "/second"}}
+	prom := NewWithFallback(http.DefaultClient, reqs, nil)
+
+	res, err := prom.ScrapeSeries()
+	require.Error(t, err)
+	assert.Nil(t, res)
+	assert.Contains(t, err.Error(), "/first")
+	assert.Contains(t, err.Error(), "/second")
+	assert.Equal(t, ts.URL+"/first", prom.URL())
+}
+
+func TestPrometheus_ConditionalRequests(t *testing.T) {
+	tests := map[string]struct {
+		validator   string
+		conditional string
+		value       string
+	}{
+		"ETag":          {validator: "ETag", conditional: "If-None-Match", value: `"v1"`},
+		"Last-Modified": {validator: "Last-Modified", conditional: "If-Modified-Since", value: "Wed, 21 Oct 2015 07:28:00 GMT"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests, notModified int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get(test.conditional) == test.value {
+					notModified++
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set(test.validator, test.value)
+				_, _ = w.Write(testData)
+			}))
+			defer ts.Close()
+
+			prom := New(http.DefaultClient, web.Request{URL: ts.URL})
+
+			for i := 0; i < 3; i++ {
+				series, err := prom.ScrapeSeries()
+				require.NoError(t, err)
+				verifyTestData(t, series)
+			}
+			assert.Equal(t, 3, requests)
+			assert.Equal(t, 2, notModified)
+
+			want, err := New(http.DefaultClient, web.Request{URL: ts.URL}).Scrape()
+			require.NoError(t, err)
+			for i := 0; i < 2; i++ {
+				mfs, err := prom.Scrape()
+				require.NoError(t, err)
+				assert.Equal(t, want.Len(), mfs.Len())
+			}
+			assert.Equal(t, 6, requests)
+			assert.Equal(t, 3, notModified)
+
+			require.NoError(t, prom.ScrapeSeriesFunc(func(SeriesSample) {}))
+			assert.Equal(t, 3, notModified, "ScrapeSeriesFunc doesn't make conditional requests")
+		})
+	}
+}
+
+func TestPrometheus_NotModifiedWithoutConditionalRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer ts.Close()
+
+	_, err := New(http.DefaultClient, web.Request{URL: ts.URL}).ScrapeSeries()
+	assert.Error(t, err)
+}
+
+func TestPrometheus_WithCacheTTL(t *testing.T) {
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write(testData)
+	}))
+	defer ts.Close()
+
+	req := web.Request{URL: ts.URL, Headers: map[string]string{"X-Job": "test"}}
+	sr1, err := selector.Parse("go_gc*")
+	require.NoError(t, err)
+	sr2, err := selector.Parse("go_memstats*")
+	require.NoError(t, err)
+
+	ttl := time.Millisecond * 200
+	prom1 := NewWithSelector(http.DefaultClient, req.Copy(), sr1, WithCacheTTL(ttl))
+	prom2 := NewWithSelector(http.DefaultClient, req.Copy(), sr2, WithCacheTTL(ttl))
+
+	series1, err := prom1.ScrapeSeries()
+	require.NoError(t, err)
+	series2, err := prom2.ScrapeSeries()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, requests)
+	assert.NotEmpty(t, series1)
+	assert.NotEmpty(t, series2)
+	for _, s := range series1 {
+		assert.True(t, strings.HasPrefix(s.Name(), "go_gc"))
+	}
+	for _, s := range series2 {
+		assert.True(t, strings.HasPrefix(s.Name(), "go_memstats"))
+	}
+
+	other := web.Request{URL: ts.URL, Headers: map[string]string{"X-Job": "other"}}
+	_, err = New(http.DefaultClient, other, WithCacheTTL(ttl)).ScrapeSeries()
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "different headers, different cache entry")
+
+	time.Sleep(ttl)
+
+	_, err = prom1.ScrapeSeries()
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests)
+}
+
+func TestPrometheus_WithRelabeler(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(testData)
+	}))
+	defer ts.Close()
+
+	rl, err := relabel.New([]relabel.Config{
+		{SourceLabels: []string{"__name__"}, Regex: "go_gc_duration_seconds.*", Action: relabel.ActionKeep},
+		{SourceLabels: []string{"quantile"}, Regex: "0\\.(.*)", TargetLabel: "percentile", Replacement: "p${1}"},
+	})
+	require.NoError(t, err)
+
+	prom := New(http.DefaultClient, web.Request{URL: ts.URL}, WithRelabeler(rl))
+
+	series, err := prom.ScrapeSeries()
+	require.NoError(t, err)
+	require.Len(t, series, 7)
+	for _, s := range series.FindByName("go_gc_duration_seconds") {
+		if s.Labels.Get("quantile") == "0.25" {
+			assert.Equal(t, "p25", s.Labels.Get("percentile"))
+		}
+	}
+
+	mfs, err := prom.Scrape()
+	require.NoError(t, err)
+	require.Equal(t, 1, mfs.Len())
+	mf := mfs.GetSummary("go_gc_duration_seconds")
+	require.NotNil(t, mf)
+	// quantiles "0" and "1" are not relabeled, they are in the metric without labels
+	require.Len(t, mf.Metrics(), 4)
+	assert.Equal(t, "p25", mf.Metrics()[1].Labels().Get("percentile"))
+}
+
+func TestPrometheus_Protobuf_ScrapeSeries(t *testing.T) {
+	var accept string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		if r.URL.Path == "/text" {
+			_, _ = w.Write(testDataProtobufText)
+			return
+		}
+		w.Header().Set("Content-Type", protobufContentType)
+		_, _ = w.Write(testDataProtobuf)
+	}))
+	defer ts.Close()
+
+	want, err := New(http.DefaultClient, web.Request{URL: ts.URL + "/text"}).ScrapeSeries()
+	require.NoError(t, err)
+	require.Len(t, want, 16)
+
+	got, err := New(http.DefaultClient, web.Request{URL: ts.URL + "/protobuf"}).ScrapeSeries()
+	require.NoError(t, err)
+
+	assert.True(t, strings.Contains(accept, protobufMediaType))
+	assert.Equal(t, want, got)
+}
+
+func TestPrometheus_Protobuf_Scrape(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/text" {
+			_, _ = w.Write(testDataProtobufText)
+			return
+		}
+		w.Header().Set("Content-Type", protobufContentType)
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write(testDataProtobuf)
+		_ = gz.Close()
+	}))
+	defer ts.Close()
+
+	want, err := New(http.DefaultClient, web.Request{URL: ts.URL + "/text"}).Scrape()
+	require.NoError(t, err)
+	require.Equal(t, 5, want.Len())
+
+	sr, err := selector.Parse("test_*")
+	require.NoError(t, err)
+	got, err := NewWithSelector(http.DefaultClient, web.Request{URL: ts.URL + "/protobuf"}, sr).Scrape()
+	require.NoError(t, err)
+
+	assert.Equal(t, want, got)
+}
+
+func TestPrometheus_Protobuf_InvalidData(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", protobufContentType)
+		_, _ = w.Write(testDataProtobuf[:len(testDataProtobuf)-10])
+	}))
+	defer ts.Close()
+
+	_, err := New(http.DefaultClient, web.Request{URL: ts.URL}).ScrapeSeries()
+	assert.Error(t, err)
+}
+
+func TestPrometheus_OpenMetrics_ScrapeSeries(t *testing.T) {
+	for name, bufSize := range map[string]int{"default buffer": chunkSize, "small buffer": 7} {
+		t.Run(name, func(t *testing.T) {
+			ts := prepareOpenMetricsServer()
+			defer ts.Close()
+
+			prom := New(http.DefaultClient, web.Request{URL: ts.URL}).(*prometheus)
+			prom.buf = make([]byte, bufSize)
+
+			series, err := prom.ScrapeSeries()
+			require.NoError(t, err)
+
+			assert.Len(t, series, 21)
+			for _, s := range series {
+				assert.Falsef(t, strings.HasSuffix(s.Name(), "_created"), "series %s", s.Name())
+			}
+
+			assert.Len(t, series.FindByName("go_gc_duration_seconds"), 5)
+			assert.Equal(t, float64(12), series.FindByName("go_goroutines").Max())
+
+			requests := series.FindByName("http_requests_total")
+			require.Len(t, requests, 2)
+			for _, s := range requests {
+				switch s.Labels.Get("code") {
+				case "200":
+					assert.Equal(t, float64(1027), s.Value)
+				case "500":
+					assert.Equal(t, float64(3), s.Value)
+				default:
+					t.Errorf("unexpected series %v", s.Labels)
+				}
+			}
+
+			assert.Len(t, series.FindByName("http_request_duration_seconds_bucket"), 4)
+			assert.Equal(t, float64(144320), series.FindByName("http_request_duration_seconds_count").Max())
+
+			info := series.FindByName("app_build_info")
+			require.Len(t, info, 1)
+			assert.Equal(t, "1.2.3", info[0].Labels.Get("version"))
+		})
+	}
+}
+
+func TestPrometheus_OpenMetrics_Scrape(t *testing.T) {
+	ts := prepareOpenMetricsServer()
+	defer ts.Close()
+
+	mfs, err := New(http.DefaultClient, web.Request{URL: ts.URL}).Scrape()
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, mfs.Len())
+
+	if mf := mfs.GetSummary("go_gc_duration_seconds"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 1)
+		assert.Len(t, mf.Metrics()[0].Summary().Quantiles(), 5)
+		assert.Equal(t, float64(23), mf.Metrics()[0].Summary().Count())
+	}
+	if mf := mfs.GetGauge("go_goroutines"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 1)
+		assert.Equal(t, float64(12), mf.Metrics()[0].Gauge().Value())
+	}
+	if mf := mfs.GetCounter("http_requests"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 2)
+		assert.Equal(t, float64(1027), mf.Metrics()[0].Counter().Value())
+		assert.Equal(t, float64(3), mf.Metrics()[1].Counter().Value())
+	}
+	if mf := mfs.GetHistogram("http_request_duration_seconds"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 1)
+		assert.Len(t, mf.Metrics()[0].Histogram().Buckets(), 4)
+		assert.Equal(t, float64(53423), mf.Metrics()[0].Histogram().Sum())
+		assert.Equal(t, float64(144320), mf.Metrics()[0].Histogram().Count())
+	}
+	if mf := mfs.GetSummary("rpc_duration_seconds"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 1)
+		assert.Len(t, mf.Metrics()[0].Summary().Quantiles(), 2)
+	}
+	if mf := mfs.Get("app_build"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 1)
+		assert.Equal(t, float64(1), mf.Metrics()[0].Gauge().Value())
+	}
+}
+
+func TestPrometheus_OpenMetrics_WithCreatedSeries(t *testing.T) {
+	ts := prepareOpenMetricsServer()
+	defer ts.Close()
+
+	prom := New(http.DefaultClient, web.Request{URL: ts.URL}, WithCreatedSeries())
+
+	series, err := prom.ScrapeSeries()
+	require.NoError(t, err)
+
+	assert.Len(t, series, 25)
+	assert.Len(t, series.FindByName("http_requests_created"), 2)
+	assert.Len(t, series.FindByName("http_request_duration_seconds_created"), 1)
+	assert.Len(t, series.FindByName("rpc_duration_seconds_created"), 1)
+
+	mfs, err := prom.Scrape()
+	require.NoError(t, err)
+
+	assert.Equal(t, 9, mfs.Len())
+
+	if mf := mfs.GetCounter("http_requests"); assert.NotNil(t, mf) {
+		assert.Len(t, mf.Metrics(), 2)
+	}
+	if mf := mfs.GetGauge("http_requests_created"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 2)
+		assert.Equal(t, 1.6206978924e+09, mf.Metrics()[0].Gauge().Value())
+	}
+	if mf := mfs.GetHistogram("http_request_duration_seconds"); assert.NotNil(t, mf) {
+		require.Len(t, mf.Metrics(), 1)
+		assert.Len(t, mf.Metrics()[0].Histogram().Buckets(), 4)
+		assert.Equal(t, float64(144320), mf.Metrics()[0].Histogram().Count())
+	}
+	assert.NotNil(t, mfs.GetGauge("http_request_duration_seconds_created"))
+	assert.NotNil(t, mfs.GetGauge("rpc_duration_seconds_created"))
+}
+
+func TestPrometheus_AcceptsOpenMetrics(t *testing.T) {
+	var accept string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		_, _ = w.Write(testData)
+	}))
+	defer ts.Close()
+
+	_, err := New(http.DefaultClient, web.Request{URL: ts.URL}).ScrapeSeries()
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(accept, "application/openmetrics-text;version=1.0.0"), accept)
+}
+
+func prepareOpenMetricsServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		_, _ = w.Write(testDataOpenMetrics)
+	}))
+}
+
+// BenchmarkPrometheus_ScrapeSeries_Buffered is the baseline: the whole response body is buffered and then parsed.
+func BenchmarkPrometheus_ScrapeSeries_Buffered(b *testing.B) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(benchData)
+	}))
+	defer ts.Close()
+
+	var parser promTextParser
+	buf := bytes.NewBuffer(make([]byte, 0, 16000))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := http.Get(ts.URL)
+		if err != nil {
+			b.Fatal(err)
+		}
+		buf.Reset()
+		_, _ = io.Copy(buf, resp.Body)
+		_ = resp.Body.Close()
+		_, _ = parser.parseToSeries(buf.Bytes())
+	}
+}
+
+func BenchmarkPrometheus_ScrapeSeries(b *testing.B) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(benchData)
+	}))
+	defer ts.Close()
+
+	prom := New(http.DefaultClient, web.Request{URL: ts.URL})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = prom.ScrapeSeries()
+	}
+}
+
+func BenchmarkPrometheus_ScrapeSeriesFunc(b *testing.B) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(benchData)
+	}))
+	defer ts.Close()
+
+	prom := New(http.DefaultClient, web.Request{URL: ts.URL})
+
+	var sum float64
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = prom.ScrapeSeriesFunc(func(s SeriesSample) { sum += s.Value })
+	}
+}
+
+var benchData = func() []byte {
+	var buf bytes.Buffer
+	for i := 0; i < 50000; i++ {
+		_, _ = fmt.Fprintf(&buf, "node_test_metric{device=\"dev%d\",mode=\"idle\"} %d\n", i, i)
+	}
+	return buf.Bytes()
+}()
+
+const protobufContentType = `application/vnd.google.protobuf; proto=io.prometheus.client.MetricFamily; encoding=delimited`
+
 func verifyTestData(t *testing.T, ms Series) {
 	assert.Equal(t, 410, len(ms))
 	assert.Equal(t, "go_gc_duration_seconds", ms[0].Labels.Get("__name__"))
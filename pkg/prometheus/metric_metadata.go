@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package prometheus
+
+import (
+	"strings"
+
+	"github.com/prometheus/prometheus/model/textparse"
+)
+
+type (
+	// Metadata is the metric families metadata (from the '# HELP' and '# TYPE' lines) by the family name.
+	Metadata map[string]MetricMetadata
+
+	// MetricMetadata is the metric family help and declared type.
+	MetricMetadata struct {
+		help string
+		typ  textparse.MetricType
+	}
+)
+
+func (m MetricMetadata) Help() string {
+	return m.help
+}
+
+// Type returns the declared type, it is MetricTypeUnknown if there is no '# TYPE' line.
+func (m MetricMetadata) Type() textparse.MetricType {
+	if m.typ == "" {
+		return textparse.MetricTypeUnknown
+	}
+	return m.typ
+}
+
+func (m Metadata) Get(name string) (MetricMetadata, bool) {
+	md, ok := m[name]
+	return md, ok
+}
+
+// TypeOf returns the declared type of the family the series metric name belongs to
+// ("name_bucket", "name_sum", "name_count", "name_total" and "name_info" series belong to the "name" family).
+// It returns MetricTypeUnknown if the type is not declared.
+func (m Metadata) TypeOf(seriesName string) textparse.MetricType {
+	if md, ok := m[seriesName]; ok && md.typ != "" {
+		return md.typ
+	}
+
+	for _, v := range []struct {
+		suffix string
+		types  []textparse.MetricType
+	}{
+		{bucketSuffix, []textparse.MetricType{textparse.MetricTypeHistogram, textparse.MetricTypeGaugeHistogram}},
+		{sumSuffix, []textparse.MetricType{textparse.MetricTypeSummary, textparse.MetricTypeHistogram}},
+		{countSuffix, []textparse.MetricType{textparse.MetricTypeSummary, textparse.MetricTypeHistogram}},
+		{totalSuffix, []textparse.MetricType{textparse.MetricTypeCounter}},
+		{infoSuffix, []textparse.MetricType{textparse.MetricTypeInfo}},
+	} {
+		if !strings.HasSuffix(seriesName, v.suffix) {
+			continue
+		}
+		md, ok := m[strings.TrimSuffix(seriesName, v.suffix)]
+		if !ok {
+			continue
+		}
+		for _, typ := range v.types {
+			if md.typ == typ {
+				return typ
+			}
+		}
+	}
+
+	return textparse.MetricTypeUnknown
+}
+
+func (m Metadata) setHelp(name, help []byte) {
+	md := m[string(name)]
+	md.help = string(help)
+	m[string(name)] = md
+}
+
+func (m Metadata) setType(name []byte, typ textparse.MetricType) {
+	md := m[string(name)]
+	md.typ = typ
+	m[string(name)] = md
+}
+
+func (m Metadata) reset() {
+	for k := range m {
+		delete(m, k)
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/textparse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_TypeOf(t *testing.T) {
+	meta := Metadata{
+		"http_requests":         {typ: textparse.MetricTypeCounter},
+		"http_requests_total":   {typ: textparse.MetricTypeCounter},
+		"request_duration":      {typ: textparse.MetricTypeHistogram},
+		"rpc_duration":          {typ: textparse.MetricTypeSummary},
+		"build":                 {typ: textparse.MetricTypeInfo},
+		"temperature":           {typ: textparse.MetricTypeGauge},
+		"temperature_count":     {typ: textparse.MetricTypeGauge},
+		"no_type":               {help: "help"},
+		"queue_length_sum_sum":  {typ: textparse.MetricTypeGauge},
+		"queue_length_sum":      {typ: textparse.MetricTypeSummary},
+		"queue_length_sum_info": {},
+	}
+
+	tests := map[string]textparse.MetricType{
+		"http_requests":           textparse.MetricTypeCounter,
+		"http_requests_total":     textparse.MetricTypeCounter,
+		"request_duration_bucket": textparse.MetricTypeHistogram,
+		"request_duration_sum":    textparse.MetricTypeHistogram,
+		"request_duration_count":  textparse.MetricTypeHistogram,
+		"request_duration_total":  textparse.MetricTypeUnknown,
+		"rpc_duration":            textparse.MetricTypeSummary,
+		"rpc_duration_sum":        textparse.MetricTypeSummary,
+		"rpc_duration_bucket":     textparse.MetricTypeUnknown,
+		"build_info":              textparse.MetricTypeInfo,
+		"temperature":             textparse.MetricTypeGauge,
+		"temperature_count":       textparse.MetricTypeGauge,
+		"temperature_sum":         textparse.MetricTypeUnknown,
+		"no_type":                 textparse.MetricTypeUnknown,
+		"queue_length_sum_sum":    textparse.MetricTypeGauge,
+		"queue_length_sum_count":  textparse.MetricTypeSummary,
+		"not_exists":              textparse.MetricTypeUnknown,
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, want, meta.TypeOf(name))
+		})
+	}
+}
+
+func TestMetricMetadata_Type(t *testing.T) {
+	assert.Equal(t, textparse.MetricTypeUnknown, MetricMetadata{help: "help"}.Type())
+	assert.Equal(t, textparse.MetricTypeGauge, MetricMetadata{typ: textparse.MetricTypeGauge}.Type())
+}
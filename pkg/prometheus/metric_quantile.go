@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package prometheus
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// Histograms groups the "name_bucket", "name_sum" and "name_count" series into histograms by label set
+// (excluding "le"). The returned metrics keep the order of the series label sets.
+// It expects the series is sorted.
+func (s Series) Histograms(name string) []Metric {
+	g := newSeriesGrouper(bucketLabel)
+
+	for _, ss := range s.FindByName(name + bucketSuffix) {
+		h := g.get(ss.Labels, func(m *Metric) { m.histogram = &Histogram{} }).histogram
+		if v, err := strconv.ParseFloat(ss.Labels.Get(bucketLabel), 64); err == nil {
+			h.buckets = append(h.buckets, Bucket{upperBound: v, cumulativeCount: ss.Value})
+		}
+	}
+	for _, ss := range s.FindByName(name + sumSuffix) {
+		g.get(ss.Labels, func(m *Metric) { m.histogram = &Histogram{} }).histogram.sum = ss.Value
+	}
+	for _, ss := range s.FindByName(name + countSuffix) {
+		g.get(ss.Labels, func(m *Metric) { m.histogram = &Histogram{} }).histogram.count = ss.Value
+	}
+
+	for _, m := range g.metrics {
+		sort.Slice(m.histogram.buckets, func(i, j int) bool {
+			return m.histogram.buckets[i].upperBound < m.histogram.buckets[j].upperBound
+		})
+	}
+
+	return g.metrics
+}
+
+// Summaries groups the "name" (with "quantile" label), "name_sum" and "name_count" series into summaries
+// by label set (excluding "quantile"). The returned metrics keep the order of the series label sets.
+// It expects the series is sorted.
+func (s Series) Summaries(name string) []Metric {
+	g := newSeriesGrouper(quantileLabel)
+
+	for _, ss := range s.FindByName(name) {
+		if !ss.Labels.Has(quantileLabel) {
+			continue
+		}
+		sum := g.get(ss.Labels, func(m *Metric) { m.summary = &Summary{} }).summary
+		if v, err := strconv.ParseFloat(ss.Labels.Get(quantileLabel), 64); err == nil {
+			sum.quantiles = append(sum.quantiles, Quantile{quantile: v, value: ss.Value})
+		}
+	}
+	for _, ss := range s.FindByName(name + sumSuffix) {
+		g.get(ss.Labels, func(m *Metric) { m.summary = &Summary{} }).summary.sum = ss.Value
+	}
+	for _, ss := range s.FindByName(name + countSuffix) {
+		g.get(ss.Labels, func(m *Metric) { m.summary = &Summary{} }).summary.count = ss.Value
+	}
+
+	return g.metrics
+}
+
+// Quantile returns the value of the q quantile (0 <= q <= 1) and true if the summary has it.
+func (s Summary) Quantile(q float64) (float64, bool) {
+	for _, v := range s.quantiles {
+		if v.quantile == q {
+			return v.value, true
+		}
+	}
+	return 0, false
+}
+
+// Average returns the average observed value (sum/count), 0 if there are no observations.
+func (s Summary) Average() float64 {
+	if s.count == 0 {
+		return 0
+	}
+	return s.sum / s.count
+}
+
+// Quantile estimates the value of the q quantile (0 <= q <= 1) from the buckets assuming a linear distribution
+// within a bucket (the same way as the PromQL histogram_quantile function does).
+// It returns NaN if the histogram has less than two buckets or has no +Inf bucket.
+// It expects the buckets are sorted by the upper bound.
+func (h Histogram) Quantile(q float64) float64 {
+	switch {
+	case q < 0:
+		return math.Inf(-1)
+	case q > 1:
+		return math.Inf(+1)
+	}
+
+	bs := h.buckets
+	if len(bs) < 2 || !math.IsInf(bs[len(bs)-1].upperBound, +1) {
+		return math.NaN()
+	}
+
+	observations := bs[len(bs)-1].cumulativeCount
+	if observations == 0 {
+		return math.NaN()
+	}
+
+	rank := q * observations
+	b := sort.Search(len(bs)-1, func(i int) bool { return bs[i].cumulativeCount >= rank })
+
+	if b == len(bs)-1 {
+		return bs[len(bs)-2].upperBound
+	}
+	if b == 0 && bs[0].upperBound <= 0 {
+		return bs[0].upperBound
+	}
+
+	var start, count float64
+	end := bs[b].upperBound
+	count = bs[b].cumulativeCount
+	if b > 0 {
+		start = bs[b-1].upperBound
+		count -= bs[b-1].cumulativeCount
+		rank -= bs[b-1].cumulativeCount
+	}
+	if count == 0 {
+		return end
+	}
+	return start + (end-start)*(rank/count)
+}
+
+// Delta returns the histogram of the observations made since the prev histogram.
+// It returns the histogram itself if the buckets layout is different or the counters were reset.
+func (h Histogram) Delta(prev Histogram) Histogram {
+	if len(h.buckets) != len(prev.buckets) || h.count < prev.count {
+		return h
+	}
+
+	delta := Histogram{
+		sum:     h.sum - prev.sum,
+		count:   h.count - prev.count,
+		buckets: make([]Bucket, len(h.buckets)),
+	}
+	for i, b := range h.buckets {
+		pb := prev.buckets[i]
+		if b.upperBound != pb.upperBound || b.cumulativeCount < pb.cumulativeCount {
+			return h
+		}
+		delta.buckets[i] = Bucket{upperBound: b.upperBound, cumulativeCount: b.cumulativeCount - pb.cumulativeCount}
+	}
+	return delta
+}
+
+// Average returns the average observed value (sum/count), 0 if there are no observations.
+func (h Histogram) Average() float64 {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / h.count
+}
+
+type seriesGrouper struct {
+	exclude string
+	index   map[uint64]int
+	metrics []Metric
+	lbs     labels.Labels
+}
+
+func newSeriesGrouper(exclude string) *seriesGrouper {
+	return &seriesGrouper{exclude: exclude, index: make(map[uint64]int)}
+}
+
+// get returns the metric for the label set (excluding "__name__" and the exclude label),
+// it creates and initializes a new one if there is no such metric.
+func (g *seriesGrouper) get(lbs labels.Labels, init func(m *Metric)) *Metric {
+	g.lbs = g.lbs[:0]
+	for _, lb := range lbs {
+		if lb.Name != labels.MetricName && lb.Name != g.exclude {
+			g.lbs = append(g.lbs, lb)
+		}
+	}
+
+	hash := g.lbs.Hash()
+	if i, ok := g.index[hash]; ok {
+		return &g.metrics[i]
+	}
+
+	g.metrics = append(g.metrics, Metric{labels: copyLabels(g.lbs)})
+	g.index[hash] = len(g.metrics) - 1
+	m := &g.metrics[len(g.metrics)-1]
+	init(m)
+
+	return m
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package prometheus
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeries_Histograms(t *testing.T) {
+	series, err := (&promTextParser{}).parseToSeries([]byte(`
+http_request_duration_seconds_bucket{method="get",le="0.1"} 10
+http_request_duration_seconds_bucket{method="get",le="0.5"} 30
+http_request_duration_seconds_bucket{method="get",le="+Inf"} 40
+http_request_duration_seconds_sum{method="get"} 12
+http_request_duration_seconds_count{method="get"} 40
+http_request_duration_seconds_bucket{method="post",le="0.1"} 0
+http_request_duration_seconds_bucket{method="post",le="0.5"} 0
+http_request_duration_seconds_bucket{method="post",le="+Inf"} 0
+http_request_duration_seconds_sum{method="post"} 0
+http_request_duration_seconds_count{method="post"} 0
+`))
+	require.NoError(t, err)
+
+	hs := series.Histograms("http_request_duration_seconds")
+	require.Len(t, hs, 2)
+
+	for _, m := range hs {
+		h := m.Histogram()
+		require.NotNil(t, h)
+		require.Len(t, h.Buckets(), 3)
+
+		switch m.Labels().Get("method") {
+		case "get":
+			assert.Equal(t, float64(40), h.Count())
+			assert.Equal(t, float64(12), h.Sum())
+			assert.Equal(t, 0.3, h.Average())
+			assert.Equal(t, 0.1, h.Buckets()[0].UpperBound())
+			assert.InDelta(t, 0.05, h.Quantile(0.125), 1e-9)
+			assert.InDelta(t, 0.3, h.Quantile(0.5), 1e-9)
+			assert.Equal(t, 0.5, h.Quantile(0.99))
+		case "post":
+			assert.Equal(t, float64(0), h.Average())
+			assert.True(t, math.IsNaN(h.Quantile(0.5)))
+		default:
+			t.Errorf("unexpected labels %v", m.Labels())
+		}
+	}
+}
+
+func TestHistogram_Quantile(t *testing.T) {
+	tests := map[string]struct {
+		h        Histogram
+		q        float64
+		expected float64
+	}{
+		"no buckets": {
+			q:        0.5,
+			expected: math.NaN(),
+		},
+		"no +Inf bucket": {
+			h:        Histogram{buckets: []Bucket{{upperBound: 1, cumulativeCount: 1}, {upperBound: 2, cumulativeCount: 2}}},
+			q:        0.5,
+			expected: math.NaN(),
+		},
+		"q < 0": {
+			q:        -1,
+			expected: math.Inf(-1),
+		},
+		"q > 1": {
+			q:        2,
+			expected: math.Inf(+1),
+		},
+		"first bucket": {
+			h:        Histogram{buckets: []Bucket{{upperBound: 1, cumulativeCount: 10}, {upperBound: math.Inf(+1), cumulativeCount: 10}}},
+			q:        0.5,
+			expected: 0.5,
+		},
+		"+Inf bucket": {
+			h:        Histogram{buckets: []Bucket{{upperBound: 1, cumulativeCount: 5}, {upperBound: math.Inf(+1), cumulativeCount: 10}}},
+			q:        0.9,
+			expected: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := test.h.Quantile(test.q)
+
+			if math.IsNaN(test.expected) {
+				assert.True(t, math.IsNaN(v))
+			} else {
+				assert.Equal(t, test.expected, v)
+			}
+		})
+	}
+}
+
+func TestHistogram_Delta(t *testing.T) {
+	inf := math.Inf(+1)
+	prev := Histogram{
+		sum:     10,
+		count:   10,
+		buckets: []Bucket{{upperBound: 1, cumulativeCount: 6}, {upperBound: inf, cumulativeCount: 10}},
+	}
+
+	tests := map[string]struct {
+		h        Histogram
+		expected Histogram
+	}{
+		"new observations": {
+			h: Histogram{
+				sum:     25,
+				count:   15,
+				buckets: []Bucket{{upperBound: 1, cumulativeCount: 7}, {upperBound: inf, cumulativeCount: 15}},
+			},
+			expected: Histogram{
+				sum:     15,
+				count:   5,
+				buckets: []Bucket{{upperBound: 1, cumulativeCount: 1}, {upperBound: inf, cumulativeCount: 5}},
+			},
+		},
+		"counters reset": {
+			h: Histogram{
+				sum:     2,
+				count:   2,
+				buckets: []Bucket{{upperBound: 1, cumulativeCount: 2}, {upperBound: inf, cumulativeCount: 2}},
+			},
+			expected: Histogram{
+				sum:     2,
+				count:   2,
+				buckets: []Bucket{{upperBound: 1, cumulativeCount: 2}, {upperBound: inf, cumulativeCount: 2}},
+			},
+		},
+		"different buckets": {
+			h: Histogram{
+				sum:     25,
+				count:   15,
+				buckets: []Bucket{{upperBound: 2, cumulativeCount: 7}, {upperBound: inf, cumulativeCount: 15}},
+			},
+			expected: Histogram{
+				sum:     25,
+				count:   15,
+				buckets: []Bucket{{upperBound: 2, cumulativeCount: 7}, {upperBound: inf, cumulativeCount: 15}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.h.Delta(prev))
+		})
+	}
+}
+
+func TestSeries_Summaries(t *testing.T) {
+	series, err := (&promTextParser{}).parseToSeries([]byte(`
+rpc_duration_seconds{service="a",quantile="0.5"} 0.2
+rpc_duration_seconds{service="a",quantile="0.99"} 0.9
+rpc_duration_seconds_sum{service="a"} 50
+rpc_duration_seconds_count{service="a"} 100
+rpc_duration_seconds{service="b",quantile="0.5"} 0.1
+rpc_duration_seconds_sum{service="b"} 1
+rpc_duration_seconds_count{service="b"} 10
+`))
+	require.NoError(t, err)
+
+	ss := series.Summaries("rpc_duration_seconds")
+	require.Len(t, ss, 2)
+
+	for _, m := range ss {
+		s := m.Summary()
+		require.NotNil(t, s)
+
+		switch m.Labels().Get("service") {
+		case "a":
+			v, ok := s.Quantile(0.99)
+			assert.True(t, ok)
+			assert.Equal(t, 0.9, v)
+			assert.Equal(t, 0.5, s.Average())
+			assert.Equal(t, float64(100), s.Count())
+		case "b":
+			_, ok := s.Quantile(0.99)
+			assert.False(t, ok)
+			assert.Equal(t, 0.1, s.Average())
+		default:
+			t.Errorf("unexpected labels %v", m.Labels())
+		}
+	}
+}
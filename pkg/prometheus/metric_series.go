@@ -24,6 +24,12 @@ func (s SeriesSample) Name() string {
 	return s.Labels[0].Value
 }
 
+// Copy makes a full copy of the SeriesSample.
+func (s SeriesSample) Copy() SeriesSample {
+	s.Labels = copyLabels(s.Labels)
+	return s
+}
+
 // Add appends a metric.
 func (s *Series) Add(kv SeriesSample) {
 	*s = append(*s, kv)
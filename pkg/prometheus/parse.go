@@ -1,11 +1,13 @@
 package prometheus
 
 import (
+	"bytes"
 	"errors"
 	"io"
 	"strconv"
 	"strings"
 
+	"github.com/netdata/go.d.plugin/pkg/prometheus/relabel"
 	"github.com/netdata/go.d.plugin/pkg/prometheus/selector"
 
 	"github.com/prometheus/prometheus/model/labels"
@@ -18,16 +20,39 @@ const (
 )
 
 const (
-	countSuffix  = "_count"
-	sumSuffix    = "_sum"
-	bucketSuffix = "_bucket"
+	countSuffix   = "_count"
+	sumSuffix     = "_sum"
+	bucketSuffix  = "_bucket"
+	totalSuffix   = "_total"
+	infoSuffix    = "_info"
+	createdSuffix = "_created"
 )
 
+// openMetricsEOF ends the OpenMetrics text. It is appended to the chunks of the text, so the parser
+// reports the end of every chunk with io.EOF.
+var openMetricsEOF = []byte("# EOF\n")
+
 type promTextParser struct {
 	metrics MetricFamilies
 	series  Series
+	meta    Metadata
 
 	sr selector.Selector
+	rl *relabel.Relabeler
+
+	// openMetrics is true if the text is in the OpenMetrics format.
+	openMetrics bool
+	// protobuf is true if the text is in the (length-delimited) protobuf format.
+	protobuf bool
+	// keepCreated is true if the OpenMetrics '_created' series are not dropped.
+	keepCreated bool
+	// omBuf is the OpenMetrics chunk with '# EOF' appended.
+	omBuf []byte
+	// currType is the name and the type from the last '# TYPE' line.
+	currType struct {
+		name string
+		typ  textparse.MetricType
+	}
 
 	currMF     *MetricFamily
 	currSeries labels.Labels
@@ -46,63 +71,114 @@ type promTextParser struct {
 
 func (p *promTextParser) parseToSeries(text []byte) (Series, error) {
 	p.series.Reset()
+	p.resetMetadata()
+	p.currType.name, p.currType.typ = "", ""
+
+	if err := p.parseSeries(text, p.addSeries); err != nil {
+		return nil, err
+	}
+
+	p.series.Sort()
+
+	return p.series, nil
+}
 
-	parser := textparse.NewPromParser(text)
+// parseSeries calls fn for every series that matches the selector.
+// The series labels are reused, they are only valid until fn returns.
+func (p *promTextParser) parseSeries(text []byte, fn func(SeriesSample)) error {
+	parser := p.newParser(text)
 	for {
 		entry, err := parser.Next()
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
+			if p.isEndOfText(err) {
+				return nil
 			}
 			if entry == textparse.EntryInvalid && strings.HasPrefix(err.Error(), "invalid metric type") {
 				continue
 			}
-			return nil, err
+			return err
 		}
 
 		switch entry {
+		case textparse.EntryHelp:
+			p.meta.setHelp(parser.Help())
+		case textparse.EntryType:
+			name, typ := parser.Type()
+			p.setCurrType(name, typ)
+			p.meta.setType(name, typ)
 		case textparse.EntrySeries:
 			p.currSeries = p.currSeries[:0]
 
 			parser.Metric(&p.currSeries)
 
+			if !p.keepCreated && p.isCreatedSeries() {
+				continue
+			}
 			if p.sr != nil && !p.sr.Matches(p.currSeries) {
 				continue
 			}
+			if !p.relabel() {
+				continue
+			}
 
 			_, _, val := parser.Series()
-			p.series.Add(SeriesSample{Labels: copyLabels(p.currSeries), Value: val})
+			fn(SeriesSample{Labels: p.currSeries, Value: val})
 		}
 	}
+}
 
-	p.series.Sort()
+// relabel applies the relabeling rules to the current series, it returns false if the series is dropped.
+func (p *promTextParser) relabel() bool {
+	if p.rl == nil {
+		return true
+	}
+	var keep bool
+	p.currSeries, keep = p.rl.Process(p.currSeries)
+	return keep
+}
 
-	return p.series, nil
+func (p *promTextParser) addSeries(s SeriesSample) {
+	p.series.Add(s.Copy())
 }
 
 func (p *promTextParser) parseToMetricFamilies(text []byte) (MetricFamilies, error) {
 	p.reset()
 
-	parser := textparse.NewPromParser(text)
+	if err := p.parseMetricFamilies(text); err != nil {
+		return nil, err
+	}
+
+	p.removeEmptyMetricFamilies()
+
+	return p.metrics, nil
+}
+
+// parseMetricFamilies adds the parsed metrics to the metric families. It can be called multiple times
+// (once per chunk of the exposition) between reset and removeEmptyMetricFamilies.
+func (p *promTextParser) parseMetricFamilies(text []byte) error {
+	parser := p.newParser(text)
 	for {
 		entry, err := parser.Next()
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
+			if p.isEndOfText(err) {
+				return nil
 			}
 			if entry == textparse.EntryInvalid && strings.HasPrefix(err.Error(), "invalid metric type") {
 				continue
 			}
-			return nil, err
+			return err
 		}
 
 		switch entry {
 		case textparse.EntryHelp:
 			name, help := parser.Help()
+			p.meta.setHelp(name, help)
 			p.setMetricFamilyByName(string(name))
 			p.currMF.help = string(help)
 		case textparse.EntryType:
 			name, typ := parser.Type()
+			p.setCurrType(name, typ)
+			p.meta.setType(name, typ)
 			p.setMetricFamilyByName(string(name))
 			p.currMF.typ = typ
 		case textparse.EntrySeries:
@@ -110,16 +186,29 @@ func (p *promTextParser) parseToMetricFamilies(text []byte) (MetricFamilies, err
 
 			parser.Metric(&p.currSeries)
 
+			created := p.isCreatedSeries()
+			if created && !p.keepCreated {
+				continue
+			}
 			if p.sr != nil && !p.sr.Matches(p.currSeries) {
 				continue
 			}
+			if !p.relabel() {
+				continue
+			}
+
+			if created {
+				_, _, value := parser.Series()
+				p.addCreated(value)
+				continue
+			}
 
 			p.setMetricFamilyBySeries()
 
 			_, _, value := parser.Series()
 
 			switch p.currMF.typ {
-			case textparse.MetricTypeGauge:
+			case textparse.MetricTypeGauge, textparse.MetricTypeInfo, textparse.MetricTypeStateset:
 				p.addGauge(value)
 			case textparse.MetricTypeCounter:
 				p.addCounter(value)
@@ -127,19 +216,69 @@ func (p *promTextParser) parseToMetricFamilies(text []byte) (MetricFamilies, err
 				p.addSummary(value)
 			case textparse.MetricTypeHistogram:
 				p.addHistogram(value)
-			case textparse.MetricTypeUnknown:
+			case textparse.MetricTypeUnknown, textparse.MetricTypeGaugeHistogram:
 				p.addUnknown(value)
 			}
 		}
 	}
+}
+
+func (p *promTextParser) newParser(text []byte) textparse.Parser {
+	if p.protobuf {
+		return newProtobufParser(text)
+	}
+	if p.openMetrics {
+		return textparse.NewOpenMetricsParser(p.terminateOpenMetrics(text))
+	}
+	return textparse.NewPromParser(text)
+}
+
+// terminateOpenMetrics appends '# EOF' to the OpenMetrics text if it doesn't end with it
+// (a chunk of the text doesn't).
+func (p *promTextParser) terminateOpenMetrics(text []byte) []byte {
+	if bytes.HasSuffix(bytes.TrimRight(text, "\n"), openMetricsEOF[:len(openMetricsEOF)-1]) {
+		return text
+	}
+	p.omBuf = append(p.omBuf[:0], text...)
+	if len(text) > 0 && text[len(text)-1] != '\n' {
+		p.omBuf = append(p.omBuf, '\n')
+	}
+	p.omBuf = append(p.omBuf, openMetricsEOF...)
+	return p.omBuf
+}
 
+func (p *promTextParser) isEndOfText(err error) bool {
+	return errors.Is(err, io.EOF)
+}
+
+func (p *promTextParser) setCurrType(name []byte, typ textparse.MetricType) {
+	if p.currType.name != string(name) {
+		p.currType.name = string(name)
+	}
+	p.currType.typ = typ
+}
+
+// isCreatedSeries reports whether the current series is an OpenMetrics '_created' series (the creation timestamp)
+// of a counter, a summary or a histogram.
+func (p *promTextParser) isCreatedSeries() bool {
+	if !p.openMetrics {
+		return false
+	}
+	switch p.currType.typ {
+	case textparse.MetricTypeCounter, textparse.MetricTypeSummary, textparse.MetricTypeHistogram:
+	default:
+		return false
+	}
+	name := p.currSeries[0].Value
+	return strings.HasSuffix(name, createdSuffix) && name[:len(name)-len(createdSuffix)] == p.currType.name
+}
+
+func (p *promTextParser) removeEmptyMetricFamilies() {
 	for k, v := range p.metrics {
 		if len(v.Metrics()) == 0 {
 			delete(p.metrics, k)
 		}
 	}
-
-	return p.metrics, nil
 }
 
 func (p *promTextParser) setMetricFamilyByName(name string) {
@@ -164,6 +303,11 @@ func (p *promTextParser) setMetricFamilyBySeries() {
 		return
 	}
 
+	// OpenMetrics counter and info families "name" have "name_total" and "name_info" series
+	if p.currMF != nil && isOpenMetricsSeriesOf(p.currMF, name) {
+		return
+	}
+
 	typ := textparse.MetricTypeUnknown
 
 	switch {
@@ -243,6 +387,16 @@ func (p *promTextParser) addGauge(value float64) {
 	}
 }
 
+// addCreated adds the current '_created' series to its own gauge family ("name_created"),
+// the current metric family is left as is.
+func (p *promTextParser) addCreated(value float64) {
+	mf := p.currMF
+	p.setMetricFamilyByName(p.currSeries[0].Value)
+	p.currMF.typ = textparse.MetricTypeGauge
+	p.addGauge(value)
+	p.currMF = mf
+}
+
 func (p *promTextParser) addCounter(value float64) {
 	p.currSeries = p.currSeries[1:] // remove "__name__"
 
@@ -359,8 +513,18 @@ func (p *promTextParser) addHistogram(value float64) {
 	}
 }
 
+// resetMetadata removes the metadata of the previous scrape.
+func (p *promTextParser) resetMetadata() {
+	if p.meta == nil {
+		p.meta = make(Metadata)
+	}
+	p.meta.reset()
+}
+
 func (p *promTextParser) reset() {
+	p.resetMetadata()
 	p.currMF = nil
+	p.currType.name, p.currType.typ = "", ""
 	p.currSeries = p.currSeries[:0]
 
 	if p.metrics == nil {
@@ -400,6 +564,17 @@ func removeLabel(lbs labels.Labels, name string) (labels.Labels, string, bool) {
 	return lbs, "", false
 }
 
+func isOpenMetricsSeriesOf(mf *MetricFamily, name string) bool {
+	switch mf.typ {
+	case textparse.MetricTypeCounter:
+		return name == mf.name+totalSuffix
+	case textparse.MetricTypeInfo:
+		return name == mf.name+infoSuffix
+	default:
+		return false
+	}
+}
+
 func isSummaryOrHistogram(typ textparse.MetricType) bool {
 	return typ == textparse.MetricTypeSummary || typ == textparse.MetricTypeHistogram
 }
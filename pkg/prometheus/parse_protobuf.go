@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package prometheus
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"mime"
+	"sort"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/textparse"
+)
+
+const (
+	protobufMediaType = "application/vnd.google.protobuf"
+	protobufProto     = "io.prometheus.client.MetricFamily"
+	protobufEncoding  = "delimited"
+)
+
+// isProtobuf reports whether the content type is the (length-delimited) protobuf exposition format.
+func isProtobuf(contentType string) bool {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	return err == nil &&
+		mediaType == protobufMediaType &&
+		params["proto"] == protobufProto &&
+		params["encoding"] == protobufEncoding
+}
+
+type protobufSample struct {
+	name  string
+	label labels.Label // "le" or "quantile", empty if none
+	value float64
+}
+
+// protobufParser is a textparse.Parser for the length-delimited protobuf exposition format.
+// It decodes MetricFamily messages one by one and returns the same entries (help, type and series)
+// the text parser returns for the text representation of the metric family.
+type protobufParser struct {
+	data []byte
+
+	mf      dto.MetricFamily
+	metric  int
+	samples []protobufSample
+	sample  int
+
+	state textparse.Entry
+	names struct{ bucket, sum, count string }
+}
+
+func newProtobufParser(data []byte) *protobufParser {
+	return &protobufParser{data: data, state: textparse.EntryInvalid}
+}
+
+func (p *protobufParser) Next() (textparse.Entry, error) {
+	switch p.state {
+	case textparse.EntryInvalid:
+		if err := p.nextMetricFamily(); err != nil {
+			return textparse.EntryInvalid, err
+		}
+		if p.mf.Help != nil {
+			p.state = textparse.EntryHelp
+			return p.state, nil
+		}
+		p.state = textparse.EntryType
+		return p.state, nil
+	case textparse.EntryHelp:
+		p.state = textparse.EntryType
+		return p.state, nil
+	case textparse.EntryType, textparse.EntrySeries:
+		p.sample++
+		for p.sample >= len(p.samples) {
+			if p.metric >= len(p.mf.Metric) {
+				p.state = textparse.EntryInvalid
+				return p.Next()
+			}
+			p.setSamples(p.mf.Metric[p.metric])
+			p.metric++
+		}
+		p.state = textparse.EntrySeries
+		return p.state, nil
+	}
+	return textparse.EntryInvalid, errors.New("protobuf parser: invalid state")
+}
+
+// Series returns nil instead of the series bytes, there is no text representation of the series.
+func (p *protobufParser) Series() ([]byte, *int64, float64) {
+	return nil, p.mf.Metric[p.metric-1].TimestampMs, p.samples[p.sample].value
+}
+
+func (p *protobufParser) Help() ([]byte, []byte) {
+	return []byte(p.mf.GetName()), []byte(p.mf.GetHelp())
+}
+
+func (p *protobufParser) Type() ([]byte, textparse.MetricType) {
+	name := []byte(p.mf.GetName())
+	switch p.mf.GetType() {
+	case dto.MetricType_COUNTER:
+		return name, textparse.MetricTypeCounter
+	case dto.MetricType_GAUGE:
+		return name, textparse.MetricTypeGauge
+	case dto.MetricType_SUMMARY:
+		return name, textparse.MetricTypeSummary
+	case dto.MetricType_HISTOGRAM:
+		return name, textparse.MetricTypeHistogram
+	case dto.MetricType_GAUGE_HISTOGRAM:
+		return name, textparse.MetricTypeGaugeHistogram
+	default:
+		return name, textparse.MetricTypeUnknown
+	}
+}
+
+func (p *protobufParser) Unit() ([]byte, []byte) { return nil, nil }
+
+func (p *protobufParser) Comment() []byte { return nil }
+
+func (p *protobufParser) Metric(l *labels.Labels) string {
+	s := p.samples[p.sample]
+
+	*l = append(*l, labels.Label{Name: labels.MetricName, Value: s.name})
+	for _, lp := range p.mf.Metric[p.metric-1].Label {
+		*l = append(*l, labels.Label{Name: lp.GetName(), Value: lp.GetValue()})
+	}
+	if s.label.Name != "" {
+		*l = append(*l, s.label)
+	}
+
+	// Sort labels to maintain the sorted labels invariant (the same way the text parser does).
+	sort.Sort(*l)
+
+	return s.name
+}
+
+func (p *protobufParser) Exemplar(*exemplar.Exemplar) bool { return false }
+
+func (p *protobufParser) nextMetricFamily() error {
+	if len(p.data) == 0 {
+		return io.EOF
+	}
+
+	size, n := binary.Uvarint(p.data)
+	if n <= 0 || size > uint64(len(p.data)-n) {
+		return errors.New("protobuf parser: invalid message length")
+	}
+
+	p.mf.Reset()
+	if err := proto.Unmarshal(p.data[n:n+int(size)], &p.mf); err != nil {
+		return err
+	}
+	p.data = p.data[n+int(size):]
+
+	name := p.mf.GetName()
+	p.names.bucket, p.names.sum, p.names.count = name+bucketSuffix, name+sumSuffix, name+countSuffix
+	p.metric, p.samples, p.sample = 0, p.samples[:0], 0
+
+	return nil
+}
+
+// setSamples sets the samples of the metric in the order of the text exposition format.
+func (p *protobufParser) setSamples(m *dto.Metric) {
+	p.samples, p.sample = p.samples[:0], 0
+	name := p.mf.GetName()
+
+	switch p.mf.GetType() {
+	case dto.MetricType_COUNTER:
+		p.add(name, labels.Label{}, m.GetCounter().GetValue())
+	case dto.MetricType_GAUGE:
+		p.add(name, labels.Label{}, m.GetGauge().GetValue())
+	case dto.MetricType_UNTYPED:
+		p.add(name, labels.Label{}, m.GetUntyped().GetValue())
+	case dto.MetricType_SUMMARY:
+		s := m.GetSummary()
+		for _, q := range s.GetQuantile() {
+			p.add(name, labels.Label{Name: quantileLabel, Value: formatFloat(q.GetQuantile())}, q.GetValue())
+		}
+		p.add(p.names.sum, labels.Label{}, s.GetSampleSum())
+		p.add(p.names.count, labels.Label{}, float64(s.GetSampleCount()))
+	case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+		h := m.GetHistogram()
+		count := float64(h.GetSampleCount())
+		if h.SampleCountFloat != nil {
+			count = h.GetSampleCountFloat()
+		}
+		var hasInf bool
+		for _, b := range h.GetBucket() {
+			v := float64(b.GetCumulativeCount())
+			if b.CumulativeCountFloat != nil {
+				v = b.GetCumulativeCountFloat()
+			}
+			hasInf = hasInf || math.IsInf(b.GetUpperBound(), +1)
+			p.add(p.names.bucket, labels.Label{Name: bucketLabel, Value: formatFloat(b.GetUpperBound())}, v)
+		}
+		// the +Inf bucket is implicit in the protobuf format
+		if !hasInf {
+			p.add(p.names.bucket, labels.Label{Name: bucketLabel, Value: formatFloat(math.Inf(+1))}, count)
+		}
+		p.add(p.names.sum, labels.Label{}, h.GetSampleSum())
+		p.add(p.names.count, labels.Label{}, count)
+	}
+}
+
+func (p *protobufParser) add(name string, lb labels.Label, value float64) {
+	p.samples = append(p.samples, protobufSample{name: name, label: lb, value: value})
+}
+
+// formatFloat formats the "le" and "quantile" label values the same way the text exposition format does.
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, +1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
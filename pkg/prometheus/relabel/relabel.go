@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package relabel
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+const (
+	ActionReplace = "replace"
+	ActionKeep    = "keep"
+	ActionDrop    = "drop"
+)
+
+const (
+	defaultSeparator   = ";"
+	defaultRegex       = "(.*)"
+	defaultReplacement = "$1"
+)
+
+// Config is a relabeling rule configuration.
+// Supported configuration file formats: YAML.
+type Config struct {
+	// SourceLabels are the labels whose values are concatenated using the Separator and matched against the Regex.
+	SourceLabels []string `yaml:"source_labels"`
+	// Separator is placed between the source label values. An empty string means ";".
+	Separator string `yaml:"separator"`
+	// Regex is matched against the concatenated source label values (the regex is anchored).
+	// An empty string means "(.*)".
+	Regex string `yaml:"regex"`
+	// TargetLabel is the label the replacement is written to ("replace" action).
+	TargetLabel string `yaml:"target_label"`
+	// Replacement is the value written to the target label, regex capture groups ($1, ${name}) are expanded.
+	// The target label is removed if the replacement is empty. It is "$1" if not set in the configuration file.
+	Replacement string `yaml:"replacement"`
+	// Action is the relabeling action: "replace", "keep" or "drop". An empty string means "replace".
+	Action string `yaml:"action"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, it sets the Replacement default value.
+func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain Config
+	*c = Config{Replacement: defaultReplacement}
+	return unmarshal((*plain)(c))
+}
+
+type rule struct {
+	sourceLabels []string
+	separator    string
+	regex        *regexp.Regexp
+	targetLabel  string
+	replacement  string
+	action       string
+}
+
+// Relabeler applies the relabeling rules to the time series labels.
+// It is not safe for concurrent use.
+type Relabeler struct {
+	rules []rule
+	buf   []byte
+}
+
+// New creates a Relabeler from the configurations, the rules are applied in the order.
+func New(cfgs []Config) (*Relabeler, error) {
+	r := &Relabeler{}
+	for i, cfg := range cfgs {
+		rl, err := newRule(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("relabel rule %d: %v", i+1, err)
+		}
+		r.rules = append(r.rules, rl)
+	}
+	return r, nil
+}
+
+func newRule(cfg Config) (rule, error) {
+	rl := rule{
+		sourceLabels: cfg.SourceLabels,
+		separator:    cfg.Separator,
+		targetLabel:  cfg.TargetLabel,
+		replacement:  cfg.Replacement,
+		action:       strings.ToLower(cfg.Action),
+	}
+	if rl.separator == "" {
+		rl.separator = defaultSeparator
+	}
+	if rl.action == "" {
+		rl.action = ActionReplace
+	}
+
+	expr := cfg.Regex
+	if expr == "" {
+		expr = defaultRegex
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return rl, fmt.Errorf("invalid regex '%s': %v", cfg.Regex, err)
+	}
+	rl.regex = re
+
+	switch rl.action {
+	case ActionReplace:
+		if rl.targetLabel == "" {
+			return rl, errors.New("'target_label' is required for the 'replace' action")
+		}
+		if !isValidLabelName(rl.targetLabel) {
+			return rl, fmt.Errorf("invalid target label name '%s'", rl.targetLabel)
+		}
+	case ActionKeep, ActionDrop:
+	default:
+		return rl, fmt.Errorf("unknown action '%s' (supported: '%s', '%s', '%s')",
+			cfg.Action, ActionReplace, ActionKeep, ActionDrop)
+	}
+
+	return rl, nil
+}
+
+// Process applies the rules to the labels. It returns the resulting labels and false if the series is dropped.
+// The labels are modified in place (the returned labels may share the underlying array with lbs),
+// they are kept sorted. A series without the metric name after relabeling is dropped.
+func (r *Relabeler) Process(lbs labels.Labels) (labels.Labels, bool) {
+	var resort bool
+
+	for _, rl := range r.rules {
+		value := r.sourceValue(rl, lbs)
+
+		switch rl.action {
+		case ActionKeep:
+			if !rl.regex.MatchString(value) {
+				return lbs, false
+			}
+		case ActionDrop:
+			if rl.regex.MatchString(value) {
+				return lbs, false
+			}
+		case ActionReplace:
+			idx := rl.regex.FindStringSubmatchIndex(value)
+			if idx == nil {
+				continue
+			}
+			r.buf = rl.regex.ExpandString(r.buf[:0], rl.replacement, value, idx)
+			var added bool
+			if len(r.buf) == 0 {
+				lbs = deleteLabel(lbs, rl.targetLabel)
+			} else {
+				lbs, added = setLabel(lbs, rl.targetLabel, string(r.buf))
+			}
+			resort = resort || added
+		}
+	}
+
+	if resort {
+		sort.Sort(lbs)
+	}
+
+	return lbs, lbs.Get(labels.MetricName) != ""
+}
+
+func (r *Relabeler) sourceValue(rl rule, lbs labels.Labels) string {
+	if len(rl.sourceLabels) == 1 {
+		return lbs.Get(rl.sourceLabels[0])
+	}
+	r.buf = r.buf[:0]
+	for i, name := range rl.sourceLabels {
+		if i > 0 {
+			r.buf = append(r.buf, rl.separator...)
+		}
+		r.buf = append(r.buf, lbs.Get(name)...)
+	}
+	return string(r.buf)
+}
+
+func setLabel(lbs labels.Labels, name, value string) (labels.Labels, bool) {
+	for i := range lbs {
+		if lbs[i].Name == name {
+			lbs[i].Value = value
+			return lbs, false
+		}
+	}
+	return append(lbs, labels.Label{Name: name, Value: value}), true
+}
+
+func deleteLabel(lbs labels.Labels, name string) labels.Labels {
+	for i := range lbs {
+		if lbs[i].Name == name {
+			return append(lbs[:i], lbs[i+1:]...)
+		}
+	}
+	return lbs
+}
+
+func isValidLabelName(name string) bool {
+	for i, c := range name {
+		if !(c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9' && i > 0)) {
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package relabel
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		cfgs    []Config
+		wantErr bool
+	}{
+		"no rules": {},
+		"valid rules": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, TargetLabel: "b", Replacement: "$1"},
+				{SourceLabels: []string{"a"}, Regex: "x.*", Action: "keep"},
+				{SourceLabels: []string{"a"}, Regex: "y.*", Action: "DROP"},
+			},
+		},
+		"replace without target label": {
+			cfgs:    []Config{{SourceLabels: []string{"a"}}},
+			wantErr: true,
+		},
+		"invalid target label": {
+			cfgs:    []Config{{SourceLabels: []string{"a"}, TargetLabel: "1abc"}},
+			wantErr: true,
+		},
+		"invalid regex": {
+			cfgs:    []Config{{SourceLabels: []string{"a"}, Regex: "(", Action: ActionKeep}},
+			wantErr: true,
+		},
+		"unknown action": {
+			cfgs:    []Config{{SourceLabels: []string{"a"}, Action: "hashmod"}},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rl, err := New(test.cfgs)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, rl)
+			}
+		})
+	}
+}
+
+func TestRelabeler_Process(t *testing.T) {
+	tests := map[string]struct {
+		cfgs     []Config
+		input    labels.Labels
+		expected labels.Labels
+		dropped  bool
+	}{
+		"no rules": {
+			input:    labels.FromStrings("__name__", "metric", "a", "1"),
+			expected: labels.FromStrings("__name__", "metric", "a", "1"),
+		},
+		"replace: new label": {
+			cfgs: []Config{
+				{SourceLabels: []string{"instance"}, Regex: "(.+):.*", TargetLabel: "host", Replacement: "$1"},
+			},
+			input:    labels.FromStrings("__name__", "metric", "instance", "srv1:9100"),
+			expected: labels.FromStrings("__name__", "metric", "host", "srv1", "instance", "srv1:9100"),
+		},
+		"replace: existing label": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, Regex: "x-(.*)", TargetLabel: "a", Replacement: "$1"},
+			},
+			input:    labels.FromStrings("__name__", "metric", "a", "x-1"),
+			expected: labels.FromStrings("__name__", "metric", "a", "1"),
+		},
+		"replace: several source labels and named groups": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a", "b"}, Separator: "/", Regex: "(?P<first>.*)/(?P<second>.*)", TargetLabel: "c", Replacement: "${second}_${first}"},
+			},
+			input:    labels.FromStrings("__name__", "metric", "a", "1", "b", "2"),
+			expected: labels.FromStrings("__name__", "metric", "a", "1", "b", "2", "c", "2_1"),
+		},
+		"replace: default separator": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a", "b"}, TargetLabel: "c", Replacement: "$1"},
+			},
+			input:    labels.FromStrings("__name__", "metric", "a", "1", "b", "2"),
+			expected: labels.FromStrings("__name__", "metric", "a", "1", "b", "2", "c", "1;2"),
+		},
+		"replace: not matched": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, Regex: "y", TargetLabel: "a", Replacement: "z"},
+			},
+			input:    labels.FromStrings("__name__", "metric", "a", "x"),
+			expected: labels.FromStrings("__name__", "metric", "a", "x"),
+		},
+		"replace: empty replacement removes the label": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, TargetLabel: "a", Replacement: ""},
+			},
+			input:    labels.FromStrings("__name__", "metric", "a", "x", "b", "y"),
+			expected: labels.FromStrings("__name__", "metric", "b", "y"),
+		},
+		"replace: metric name": {
+			cfgs: []Config{
+				{SourceLabels: []string{"__name__"}, Regex: "old_(.*)", TargetLabel: "__name__", Replacement: "new_$1"},
+			},
+			input:    labels.FromStrings("__name__", "old_metric"),
+			expected: labels.FromStrings("__name__", "new_metric"),
+		},
+		"replace: removed metric name drops the series": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, TargetLabel: "__name__", Replacement: ""},
+			},
+			input:   labels.FromStrings("__name__", "metric", "a", "x"),
+			dropped: true,
+		},
+		"keep: matched": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, Regex: "x|y", Action: ActionKeep},
+			},
+			input:    labels.FromStrings("__name__", "metric", "a", "x"),
+			expected: labels.FromStrings("__name__", "metric", "a", "x"),
+		},
+		"keep: not matched": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, Regex: "x|y", Action: ActionKeep},
+			},
+			input:   labels.FromStrings("__name__", "metric", "a", "xy"),
+			dropped: true,
+		},
+		"drop: matched": {
+			cfgs: []Config{
+				{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: ActionDrop},
+			},
+			input:   labels.FromStrings("__name__", "go_goroutines"),
+			dropped: true,
+		},
+		"drop: not matched": {
+			cfgs: []Config{
+				{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: ActionDrop},
+			},
+			input:    labels.FromStrings("__name__", "process_cpu_seconds_total"),
+			expected: labels.FromStrings("__name__", "process_cpu_seconds_total"),
+		},
+		"rules are applied in order": {
+			cfgs: []Config{
+				{SourceLabels: []string{"a"}, TargetLabel: "b", Replacement: "$1"},
+				{SourceLabels: []string{"b"}, Regex: "x", Action: ActionDrop},
+			},
+			input:   labels.FromStrings("__name__", "metric", "a", "x"),
+			dropped: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rl, err := New(test.cfgs)
+			require.NoError(t, err)
+
+			lbs, keep := rl.Process(test.input.Copy())
+
+			if test.dropped {
+				assert.False(t, keep)
+			} else {
+				assert.True(t, keep)
+				assert.Equal(t, test.expected, lbs)
+			}
+		})
+	}
+}
+
+func TestConfig_UnmarshalYAML(t *testing.T) {
+	var cfgs []Config
+	data := `
+- source_labels: [instance]
+  regex: '(.+):.*'
+  target_label: host
+- source_labels: [a]
+  target_label: a
+  replacement: ''
+`
+	require.NoError(t, yaml.Unmarshal([]byte(data), &cfgs))
+
+	expected := []Config{
+		{SourceLabels: []string{"instance"}, Regex: "(.+):.*", TargetLabel: "host", Replacement: "$1"},
+		{SourceLabels: []string{"a"}, TargetLabel: "a", Replacement: ""},
+	}
+	assert.Equal(t, expected, cfgs)
+}
@@ -9,6 +9,22 @@ type Expr struct {
 	Deny  []string `yaml:"deny"`
 }
 
+// UnmarshalYAML implements yaml.Unmarshaler.
+// In addition to the allow/deny lists it accepts a single selector expression (an allow list of one item).
+func (e *Expr) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*e = Expr{}
+		if s != "" {
+			e.Allow = []string{s}
+		}
+		return nil
+	}
+
+	type plain Expr
+	return unmarshal((*plain)(e))
+}
+
 func (e Expr) Empty() bool {
 	return len(e.Allow) == 0 && len(e.Deny) == 0
 
@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 )
 
 func TestExpr_Empty(t *testing.T) {
@@ -229,3 +230,30 @@ func TestExprSelector_Matches(t *testing.T) {
 		})
 	}
 }
+
+func TestExpr_UnmarshalYAML(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected Expr
+	}{
+		"single expression": {
+			input:    `selector: 'go_* or node_*{label="value"}'`,
+			expected: Expr{Allow: []string{`go_* or node_*{label="value"}`}},
+		},
+		"allow and deny": {
+			input:    "selector:\n  allow: [go_*]\n  deny: [go_memstats_*]",
+			expected: Expr{Allow: []string{"go_*"}, Deny: []string{"go_memstats_*"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg struct {
+				Selector Expr `yaml:"selector"`
+			}
+
+			require.NoError(t, yaml.Unmarshal([]byte(test.input), &cfg))
+			assert.Equal(t, test.expected, cfg.Selector)
+		})
+	}
+}
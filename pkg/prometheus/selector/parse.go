@@ -14,11 +14,49 @@ var (
 	reLV = regexp.MustCompile(`^(?P<label_name>[a-zA-Z0-9_]+)(?P<op>=~|!~|=\*|!\*|=|!=)"(?P<pattern>.+)"$`)
 )
 
+const (
+	keywordOr  = "or"
+	keywordNot = "not"
+)
+
+// Parse parses the selector expression.
+// The expression is a list of selectors joined by the "or" keyword, every selector may be negated
+// using the "not" keyword.
 func Parse(expr string) (Selector, error) {
 	var srs []Selector
-	lvs := strings.Split(unsugarExpr(expr), ",")
 
-	for _, lv := range lvs {
+	for _, term := range splitOr(expr) {
+		sr, err := parseTerm(term)
+		if err != nil {
+			return nil, err
+		}
+		srs = append(srs, sr)
+	}
+
+	switch len(srs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return srs[0], nil
+	default:
+		return Or(srs[0], srs[1], srs[2:]...), nil
+	}
+}
+
+func parseTerm(term string) (Selector, error) {
+	term = strings.TrimSpace(term)
+
+	if rest, ok := cutKeyword(term, keywordNot); ok {
+		sr, err := parseTerm(rest)
+		if err != nil {
+			return nil, err
+		}
+		return Not(sr), nil
+	}
+
+	var srs []Selector
+
+	for _, lv := range splitUnquoted(unsugarExpr(term), ',') {
 		sr, err := parseSelector(lv)
 		if err != nil {
 			return nil, err
@@ -42,7 +80,7 @@ func parseSelector(line string) (Selector, error) {
 		return nil, fmt.Errorf("invalid selector syntax: '%s'", line)
 	}
 
-	name, op, pattern := sub[1], sub[2], strings.Trim(sub[3], "\"")
+	name, op, pattern := sub[1], sub[2], strings.ReplaceAll(strings.Trim(sub[3], "\""), `\"`, `"`)
 
 	var m matcher.Matcher
 	var err error
@@ -85,13 +123,83 @@ func unsugarExpr(expr string) string {
 			strings.TrimSpace(expr),
 		)
 	case idx == 0:
-		expr = strings.Trim(expr, "{}")
+		expr = trimBraces(expr)
 	default:
 		expr = fmt.Sprintf(`__name__%s"%s",%s`,
 			OpSimplePatterns,
 			strings.TrimSpace(expr[:idx]),
-			strings.Trim(expr[idx:], "{}"),
+			trimBraces(expr[idx:]),
 		)
 	}
 	return expr
 }
+
+func trimBraces(s string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(s, "{"), "}")
+}
+
+// splitOr splits the expression by the "or" keyword, the keyword is ignored inside label matchers.
+func splitOr(expr string) []string {
+	var parts []string
+	var start, depth int
+	var quoted, escaped bool
+
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+		case depth == 0 && isSpace(c):
+			if _, ok := cutKeyword(expr[i+1:], keywordOr); ok {
+				parts = append(parts, expr[start:i])
+				start = i + 1 + len(keywordOr)
+				i = start - 1
+			}
+		}
+	}
+
+	return append(parts, expr[start:])
+}
+
+// splitUnquoted splits s by sep, the separators inside double quotes are ignored.
+func splitUnquoted(s string, sep byte) []string {
+	var parts []string
+	var start int
+	var quoted, escaped bool
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case quoted && c == '\\':
+			escaped = true
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// cutKeyword returns s without the leading keyword (case-insensitive) if s starts with it followed by a space.
+func cutKeyword(s, keyword string) (string, bool) {
+	if len(s) <= len(keyword) || !strings.EqualFold(s[:len(keyword)], keyword) || !isSpace(s[len(keyword)]) {
+		return s, false
+	}
+	return s[len(keyword)+1:], true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
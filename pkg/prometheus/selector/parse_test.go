@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParse(t *testing.T) {
@@ -85,6 +86,69 @@ func TestParse(t *testing.T) {
 				rhs: mustString("label2", "value2"),
 			},
 		},
+		"or: metric names": {
+			input: "windows_cpu_* or windows_memory_*",
+			expectedSr: orSelector{
+				lhs: mustSPName("windows_cpu_*"),
+				rhs: mustSPName("windows_memory_*"),
+			},
+		},
+		"or (upper case): metric names with labels": {
+			input: `windows_cpu_*{core="0,0"} OR windows_memory_* OR {job!="node"}`,
+			expectedSr: orSelector{
+				lhs: orSelector{
+					lhs: andSelector{
+						lhs: mustSPName("windows_cpu_*"),
+						rhs: mustString("core", "0,0"),
+					},
+					rhs: mustSPName("windows_memory_*"),
+				},
+				rhs: Not(mustString("job", "node")),
+			},
+		},
+		"or: keyword inside label value": {
+			input: `windows_cpu_*{mode="a or b"}`,
+			expectedSr: andSelector{
+				lhs: mustSPName("windows_cpu_*"),
+				rhs: mustString("mode", "a or b"),
+			},
+		},
+		"not: metric name": {
+			input:      "not go_memstats_*",
+			expectedSr: Not(mustSPName("go_memstats_*")),
+		},
+		"not: metric name with labels or metric name": {
+			input: `not go_*{label="value"} or node_*`,
+			expectedSr: orSelector{
+				lhs: Not(andSelector{
+					lhs: mustSPName("go_*"),
+					rhs: mustString("label", "value"),
+				}),
+				rhs: mustSPName("node_*"),
+			},
+		},
+		"regexp op: comma in value": {
+			input: `windows_cpu_time_total{core=~"0,.*"}`,
+			expectedSr: andSelector{
+				lhs: mustSPName("windows_cpu_time_total"),
+				rhs: mustRegexp("core", "0,.*"),
+			},
+		},
+		"string op: escaped quote in value": {
+			input: `metric{label="va\"lue"}`,
+			expectedSr: andSelector{
+				lhs: mustSPName("metric"),
+				rhs: mustString("label", `va"lue`),
+			},
+		},
+		"invalid syntax: or without rhs": {
+			input:       "go_* or ",
+			expectedErr: true,
+		},
+		"invalid syntax: unknown op": {
+			input:       `go_*{label=>"value"}`,
+			expectedErr: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -94,12 +158,35 @@ func TestParse(t *testing.T) {
 			if test.expectedErr {
 				assert.Error(t, err)
 			} else {
+				assert.NoError(t, err)
 				assert.Equal(t, test.expectedSr, sr)
 			}
 		})
 	}
 }
 
+func TestParse_Matches(t *testing.T) {
+	cpu0 := labels.Labels{{Name: labels.MetricName, Value: "windows_cpu_time_total"}, {Name: "core", Value: "0,1"}}
+	cpu1 := labels.Labels{{Name: labels.MetricName, Value: "windows_cpu_time_total"}, {Name: "core", Value: "1,1"}}
+	mem := labels.Labels{{Name: labels.MetricName, Value: "windows_memory_available_bytes"}}
+	net := labels.Labels{{Name: labels.MetricName, Value: "windows_net_bytes_total"}}
+
+	sr, err := Parse(`windows_cpu_*{core=~"0,.*"} or windows_memory_*`)
+	require.NoError(t, err)
+
+	assert.True(t, sr.Matches(cpu0))
+	assert.False(t, sr.Matches(cpu1))
+	assert.True(t, sr.Matches(mem))
+	assert.False(t, sr.Matches(net))
+
+	sr, err = Parse(`not windows_cpu_*{core!~"0,.*"}`)
+	require.NoError(t, err)
+
+	assert.True(t, sr.Matches(cpu0))
+	assert.False(t, sr.Matches(cpu1))
+	assert.True(t, sr.Matches(mem))
+}
+
 func mustSPName(pattern string) Selector {
 	return mustSP(labels.MetricName, pattern)
 }
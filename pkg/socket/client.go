@@ -6,6 +6,7 @@ import (
 	"bufio"
 	"crypto/tls"
 	"errors"
+	"io"
 	"net"
 	"time"
 )
@@ -58,7 +59,8 @@ func (s *Socket) Disconnect() (err error) {
 // timeout value from the Socket config and returns read, write and
 // timeout errors if any. If a timeout occurs during the processing
 // of the responses this function will stop processing and return a
-// timeout error.
+// timeout error. The response is complete when the config Terminator
+// condition is met.
 func (s *Socket) Command(command string, process Processor) error {
 	if s.conn == nil {
 		return errors.New("cannot send command on nil connection")
@@ -66,7 +68,7 @@ func (s *Socket) Command(command string, process Processor) error {
 	if err := write(command, s.conn, s.WriteTimeout); err != nil {
 		return err
 	}
-	return read(s.conn, process, s.ReadTimeout)
+	return read(s.conn, process, s.ReadTimeout, s.Terminator)
 }
 
 func write(command string, writer net.Conn, timeout time.Duration) error {
@@ -80,18 +82,63 @@ func write(command string, writer net.Conn, timeout time.Duration) error {
 	return err
 }
 
-func read(reader net.Conn, process Processor, timeout time.Duration) error {
+func read(reader net.Conn, process Processor, timeout time.Duration, term Terminator) error {
 	if process == nil {
 		return errors.New("process func is nil")
 	}
 	if reader == nil {
 		return errors.New("attempt to read on nil connection")
 	}
-	if err := reader.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+	deadline := time.Now().Add(timeout)
+	if err := reader.SetReadDeadline(deadline); err != nil {
 		return err
 	}
-	scanner := bufio.NewScanner(reader)
-	for scanner.Scan() && process(scanner.Bytes()) {
+
+	var r io.Reader = reader
+	if term.IdleTimeout > 0 {
+		r = &idleReader{conn: reader, deadline: deadline, idle: term.IdleTimeout}
+	}
+	if term.Bytes > 0 {
+		r = io.LimitReader(r, int64(term.Bytes))
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lines := 0; scanner.Scan(); {
+		if !process(scanner.Bytes()) {
+			break
+		}
+		if lines++; term.Lines > 0 && lines >= term.Lines {
+			break
+		}
 	}
 	return scanner.Err()
 }
+
+// idleReader returns io.EOF if no data is received for the idle duration.
+// It returns the timeout error if the read deadline is exceeded.
+type idleReader struct {
+	conn     net.Conn
+	deadline time.Time
+	idle     time.Duration
+}
+
+func (r *idleReader) Read(p []byte) (int, error) {
+	deadline, idle := r.deadline, false
+	if d := time.Now().Add(r.idle); d.Before(deadline) {
+		deadline, idle = d, true
+	}
+	if err := r.conn.SetReadDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	n, err := r.conn.Read(p)
+	if err != nil && idle && isTimeout(err) {
+		return n, io.EOF
+	}
+	return n, err
+}
+
+func isTimeout(err error) bool {
+	var e net.Error
+	return errors.As(err, &e) && e.Timeout()
+}
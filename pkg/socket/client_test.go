@@ -161,3 +161,136 @@ func Test_clientEmptyProcessFunc(t *testing.T) {
 	err := sock.Command("ping\n", nil)
 	require.Error(t, err, "nil process func should return an error")
 }
+
+func Test_clientTLSCommand(t *testing.T) {
+	srvTLSConf, err := newTestServerTLSConfig()
+	require.NoError(t, err)
+	srv := &lineServer{tlsConf: srvTLSConf, rowsNumResp: 3}
+	addr, err := srv.Run()
+	require.NoError(t, err)
+	defer func() { _ = srv.Close() }()
+
+	cfg := tcpConfig
+	cfg.Address = addr
+	cfg.TLSConf = &tls.Config{InsecureSkipVerify: true}
+	sock := New(cfg)
+	require.NoError(t, sock.Connect())
+	defer func() { _ = sock.Disconnect() }()
+
+	var lines int
+	err = sock.Command("ping\n", func(bytes []byte) bool {
+		assert.Equal(t, "pong", string(bytes))
+		lines++
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, lines)
+}
+
+func Test_clientTLSCommandUnknownAuthority(t *testing.T) {
+	srvTLSConf, err := newTestServerTLSConfig()
+	require.NoError(t, err)
+	srv := &lineServer{tlsConf: srvTLSConf, rowsNumResp: 1}
+	addr, err := srv.Run()
+	require.NoError(t, err)
+	defer func() { _ = srv.Close() }()
+
+	cfg := tcpConfig
+	cfg.Address = addr
+	cfg.TLSConf = &tls.Config{}
+	sock := New(cfg)
+
+	assert.Error(t, sock.Connect())
+}
+
+func Test_clientCommandTerminator(t *testing.T) {
+	tests := map[string]struct {
+		rowsNumResp int
+		hold        time.Duration
+		terminator  Terminator
+		wantLines   []string
+		wantErr     bool
+	}{
+		"read until EOF": {
+			rowsNumResp: 5,
+			wantLines:   []string{"pong", "pong", "pong", "pong", "pong"},
+		},
+		"line count": {
+			rowsNumResp: 5,
+			hold:        time.Second,
+			terminator:  Terminator{Lines: 2},
+			wantLines:   []string{"pong", "pong"},
+		},
+		"byte limit": {
+			rowsNumResp: 5,
+			hold:        time.Second,
+			terminator:  Terminator{Bytes: 12},
+			wantLines:   []string{"pong", "pong", "po"},
+		},
+		"idle timeout": {
+			rowsNumResp: 3,
+			hold:        time.Second,
+			terminator:  Terminator{IdleTimeout: 20 * time.Millisecond},
+			wantLines:   []string{"pong", "pong", "pong"},
+		},
+		"read timeout before idle timeout": {
+			rowsNumResp: 3,
+			hold:        time.Second,
+			terminator:  Terminator{IdleTimeout: time.Second},
+			wantLines:   []string{"pong", "pong", "pong"},
+			wantErr:     true,
+		},
+		"read timeout without terminator": {
+			rowsNumResp: 3,
+			hold:        time.Second,
+			wantLines:   []string{"pong", "pong", "pong"},
+			wantErr:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := &lineServer{rowsNumResp: test.rowsNumResp, hold: test.hold}
+			addr, err := srv.Run()
+			require.NoError(t, err)
+			defer func() { _ = srv.Close() }()
+
+			cfg := tcpConfig
+			cfg.Address = addr
+			cfg.Terminator = test.terminator
+			sock := New(cfg)
+			require.NoError(t, sock.Connect())
+			defer func() { _ = sock.Disconnect() }()
+
+			var lines []string
+			err = sock.Command("ping\n", func(bytes []byte) bool {
+				lines = append(lines, string(bytes))
+				return true
+			})
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.wantLines, lines)
+		})
+	}
+}
+
+func Test_clientWriteTimeout(t *testing.T) {
+	srv := &lineServer{rowsNumResp: 1}
+	addr, err := srv.Run()
+	require.NoError(t, err)
+	defer func() { _ = srv.Close() }()
+
+	cfg := tcpConfig
+	cfg.Address = addr
+	cfg.WriteTimeout = 0
+	sock := New(cfg)
+	require.NoError(t, sock.Connect())
+	defer func() { _ = sock.Disconnect() }()
+
+	err = sock.Command("ping\n", func([]byte) bool { return true })
+	assert.Error(t, err)
+}
@@ -4,8 +4,15 @@ package socket
 
 import (
 	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
 	"os"
 	"strings"
@@ -137,3 +144,73 @@ func (u *unixServer) handleConnection(conn net.Conn) {
 		_ = rw.Flush()
 	}
 }
+
+// lineServer responds with rowsNumResp "pong" lines to every command line.
+// It keeps the connection open for the hold duration after the response, it uses TLS if tlsConf is set.
+type lineServer struct {
+	tlsConf     *tls.Config
+	rowsNumResp int
+	hold        time.Duration
+	listener    net.Listener
+}
+
+func (l *lineServer) Run() (addr string, err error) {
+	l.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	if l.tlsConf != nil {
+		l.listener = tls.NewListener(l.listener, l.tlsConf)
+	}
+	go l.handleConnections()
+	return l.listener.Addr().String(), nil
+}
+
+func (l *lineServer) Close() error {
+	return l.listener.Close()
+}
+
+func (l *lineServer) handleConnections() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			return
+		}
+		go l.handleConnection(conn)
+	}
+}
+
+func (l *lineServer) handleConnection(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(time.Second + l.hold))
+
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	if _, err := rw.ReadString('\n'); err != nil {
+		return
+	}
+	_, _ = rw.WriteString(strings.Repeat("pong\n", l.rowsNumResp))
+	_ = rw.Flush()
+	time.Sleep(l.hold)
+}
+
+func newTestServerTLSConfig() (*tls.Config, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
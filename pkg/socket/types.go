@@ -35,7 +35,25 @@ type Client interface {
 type Config struct {
 	Address        string
 	ConnectTimeout time.Duration
-	ReadTimeout    time.Duration
-	WriteTimeout   time.Duration
-	TLSConf        *tls.Config
+	// ReadTimeout is the deadline for reading the whole command response.
+	ReadTimeout time.Duration
+	// WriteTimeout is the deadline for writing the command.
+	WriteTimeout time.Duration
+	// TLSConf enables TLS (TCP only) if not nil.
+	TLSConf *tls.Config
+	// Terminator defines when the command response is complete.
+	Terminator Terminator
+}
+
+// Terminator defines when the command response is complete.
+// The response is read until EOF (or until the Processor returns false) if no conditions are set,
+// otherwise the reading stops on the first met condition.
+type Terminator struct {
+	// Lines is the maximum number of response lines.
+	Lines int
+	// Bytes is the maximum number of response bytes (the last line is truncated if the limit is exceeded).
+	Bytes int
+	// IdleTimeout completes the response if no data is received for the duration.
+	// It is for servers that don't close the connection after the response. Reaching it is not an error.
+	IdleTimeout time.Duration
 }
@@ -47,7 +47,7 @@ func toMap(value reflect.Value, rv map[string]int64, key string, mul, div int) {
 	case reflect.Ptr:
 		convertPtr(value, rv, key, mul, div)
 	case reflect.Struct:
-		convertStruct(value, rv, key)
+		convertStruct(value, rv, key, mul, div)
 	case reflect.Array, reflect.Slice:
 		convertArraySlice(value, rv, key, mul, div)
 	case reflect.Map:
@@ -71,7 +71,9 @@ func convertPtr(value reflect.Value, rv map[string]int64, key string, mul, div i
 	}
 }
 
-func convertStruct(value reflect.Value, rv map[string]int64, key string) {
+// convertStruct converts the tagged fields, the fields without multiplier and divisor in the tag
+// inherit the struct ones (the struct field tag or the parent struct inherited ones).
+func convertStruct(value reflect.Value, rv map[string]int64, key string, mul, div int) {
 	t := value.Type()
 	k := value.FieldByName(structKey)
 	if k.Kind() == reflect.String {
@@ -84,8 +86,11 @@ func convertStruct(value reflect.Value, rv map[string]int64, key string) {
 			continue
 		}
 		value := value.Field(i)
-		prefix, mul, div := parseTag(tag)
-		toMap(value, rv, joinPrefix(key, prefix), mul, div)
+		prefix, fmul, fdiv, hasOpts := parseTag(tag)
+		if !hasOpts {
+			fmul, fdiv = mul, div
+		}
+		toMap(value, rv, joinPrefix(key, prefix), fmul, fdiv)
 	}
 }
 
@@ -94,7 +99,26 @@ func convertMap(value reflect.Value, rv map[string]int64, key string, mul, div i
 		logger.Panicf("value is nil key=%s", key)
 	}
 	for _, k := range value.MapKeys() {
-		toMap(value.MapIndex(k), rv, joinPrefix(key, k.String()), mul, div)
+		toMap(value.MapIndex(k), rv, joinPrefix(key, mapKey(k)), mul, div)
+	}
+}
+
+func mapKey(k reflect.Value) string {
+	if k.CanInterface() {
+		if s, ok := k.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+	switch k.Kind() {
+	case reflect.String:
+		return k.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(k.Uint(), 10)
+	default:
+		logger.Panic("unsupported map key type: ", k.Kind())
+		return ""
 	}
 }
 
@@ -146,10 +170,11 @@ func joinPrefix(prefix, key string) string {
 	return prefix + "_" + key
 }
 
-func parseTag(tag string) (prefix string, mul int, div int) {
+func parseTag(tag string) (prefix string, mul int, div int, hasOpts bool) {
 	tokens := strings.Split(tag, ",")
 	mul = 1
 	div = 1
+	hasOpts = len(tokens) > 1
 	var err error
 	switch len(tokens) {
 	case 3:
@@ -195,6 +195,89 @@ func TestToMap_nestMap(t *testing.T) {
 	assert.EqualValuesf(t, expected, stm.ToMap(&s), "ptr test")
 }
 
+func TestToMap_mapStruct(t *testing.T) {
+	type pool struct {
+		name    string
+		Active  int64   `stm:"active"`
+		Latency float64 `stm:"latency,1000"`
+	}
+	s := struct {
+		Pools map[string]*pool `stm:"pool"`
+	}{
+		Pools: map[string]*pool{
+			"read":  {name: "read", Active: 1, Latency: 0.5},
+			"write": {name: "write", Active: 2, Latency: 1.25},
+		},
+	}
+
+	expected := map[string]int64{
+		"pool_read_active":   1,
+		"pool_read_latency":  500,
+		"pool_write_active":  2,
+		"pool_write_latency": 1250,
+	}
+
+	assert.EqualValuesf(t, expected, stm.ToMap(s), "value test")
+	assert.EqualValuesf(t, expected, stm.ToMap(&s), "ptr test")
+}
+
+func TestToMap_mapIntKeys(t *testing.T) {
+	s := struct {
+		Codes map[int]int64   `stm:"code"`
+		Ports map[uint16]bool `stm:"port"`
+	}{
+		Codes: map[int]int64{200: 10, -1: 1},
+		Ports: map[uint16]bool{80: true, 443: false},
+	}
+
+	expected := map[string]int64{
+		"code_200": 10,
+		"code_-1":  1,
+		"port_80":  1,
+		"port_443": 0,
+	}
+
+	assert.EqualValuesf(t, expected, stm.ToMap(s), "value test")
+	assert.EqualValuesf(t, expected, stm.ToMap(&s), "ptr test")
+}
+
+func TestToMap_inheritedPrecision(t *testing.T) {
+	type times struct {
+		Avg float64 `stm:"avg"`
+		Max float64 `stm:"max,1"`
+	}
+	s := struct {
+		Time   times            `stm:"time,1000"`
+		ByPath map[string]times `stm:"path,100"`
+		Count  float64          `stm:"count"`
+	}{
+		Time:   times{Avg: 0.25, Max: 2.5},
+		ByPath: map[string]times{"root": {Avg: 0.5, Max: 3.5}},
+		Count:  1.5,
+	}
+
+	expected := map[string]int64{
+		"time_avg":      250,
+		"time_max":      2,
+		"path_root_avg": 50,
+		"path_root_max": 3,
+		"count":         1,
+	}
+
+	assert.EqualValuesf(t, expected, stm.ToMap(s), "value test")
+	assert.EqualValuesf(t, expected, stm.ToMap(&s), "ptr test")
+}
+
+func TestToMap_unsupportedMapKey(t *testing.T) {
+	s := struct {
+		M map[float64]int64 `stm:"m"`
+	}{
+		M: map[float64]int64{1.5: 1},
+	}
+
+	assert.Panics(t, func() { stm.ToMap(s) })
+}
+
 func TestToMap_ptr(t *testing.T) {
 	two := 2
 	s := struct {
@@ -22,11 +22,25 @@ type TLSConfig struct {
 
 	// InsecureSkipVerify controls whether a client verifies the server's certificate chain and host name.
 	InsecureSkipVerify bool `yaml:"tls_skip_verify"`
+
+	// MinTLSVersion specifies the minimum TLS version that is acceptable ("1.0", "1.1", "1.2" or "1.3").
+	// Default (empty string) is crypto/tls package default.
+	MinTLSVersion string `yaml:"min_tls_version"`
+
+	// MaxTLSVersion specifies the maximum TLS version that is acceptable ("1.0", "1.1", "1.2" or "1.3").
+	// Default (empty string) is crypto/tls package default.
+	MaxTLSVersion string `yaml:"max_tls_version"`
+
+	// CipherSuites specifies the list of enabled TLS 1.0–1.2 cipher suites by IANA name
+	// (e.g. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"). TLS 1.3 cipher suites are not configurable.
+	// Default (empty list) is crypto/tls package default.
+	CipherSuites []string `yaml:"cipher_suites"`
 }
 
 // NewTLSConfig creates a tls.Config, may be nil without an error if TLS is not configured.
 func NewTLSConfig(cfg TLSConfig) (*tls.Config, error) {
-	if cfg.TLSCA == "" && cfg.TLSKey == "" && cfg.TLSCert == "" && !cfg.InsecureSkipVerify {
+	if cfg.TLSCA == "" && cfg.TLSKey == "" && cfg.TLSCert == "" && !cfg.InsecureSkipVerify &&
+		cfg.MinTLSVersion == "" && cfg.MaxTLSVersion == "" && len(cfg.CipherSuites) == 0 {
 		return nil, nil
 	}
 
@@ -35,6 +49,27 @@ func NewTLSConfig(cfg TLSConfig) (*tls.Config, error) {
 		Renegotiation:      tls.RenegotiateNever,
 	}
 
+	minVersion, err := parseTLSVersion(cfg.MinTLSVersion)
+	if err != nil {
+		return nil, fmt.Errorf("min TLS version: %v", err)
+	}
+	maxVersion, err := parseTLSVersion(cfg.MaxTLSVersion)
+	if err != nil {
+		return nil, fmt.Errorf("max TLS version: %v", err)
+	}
+	if minVersion != 0 && maxVersion != 0 && minVersion > maxVersion {
+		return nil, fmt.Errorf("min TLS version '%s' is greater than max TLS version '%s'", cfg.MinTLSVersion, cfg.MaxTLSVersion)
+	}
+	tlsConfig.MinVersion, tlsConfig.MaxVersion = minVersion, maxVersion
+
+	if len(cfg.CipherSuites) > 0 {
+		suites, err := parseCipherSuites(cfg.CipherSuites)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.CipherSuites = suites
+	}
+
 	if cfg.TLSCA != "" {
 		pool, err := loadCertPool([]string{cfg.TLSCA})
 		if err != nil {
@@ -44,16 +79,61 @@ func NewTLSConfig(cfg TLSConfig) (*tls.Config, error) {
 	}
 
 	if cfg.TLSCert != "" && cfg.TLSKey != "" {
-		cert, err := loadCertificate(cfg.TLSCert, cfg.TLSKey)
+		// the client certificate is re-read when the files change (e.g. short-lived certificates rotation),
+		// Certificates is set for the clients that don't use tls.Config to do the handshake.
+		reloader, err := newCertReloader(cfg.TLSCert, cfg.TLSKey)
 		if err != nil {
 			return nil, err
 		}
-		tlsConfig.Certificates = []tls.Certificate{cert}
+		tlsConfig.Certificates = []tls.Certificate{*reloader.cert}
+		tlsConfig.GetClientCertificate = reloader.GetClientCertificate
 	}
 
 	return tlsConfig, nil
 }
 
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func parseTLSVersion(version string) (uint16, error) {
+	if version == "" {
+		return 0, nil
+	}
+	v, ok := tlsVersions[version]
+	if !ok {
+		return 0, fmt.Errorf("unknown TLS version '%s' (supported: 1.0, 1.1, 1.2, 1.3)", version)
+	}
+	return v, nil
+}
+
+func parseCipherSuites(names []string) ([]uint16, error) {
+	known := make(map[string]*tls.CipherSuite)
+	for _, cs := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		known[cs.Name] = cs
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		cs, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite '%s'", name)
+		}
+		if isTLS13Only(cs) {
+			return nil, fmt.Errorf("cipher suite '%s' is TLS 1.3 only, TLS 1.3 cipher suites are not configurable", name)
+		}
+		ids = append(ids, cs.ID)
+	}
+	return ids, nil
+}
+
+func isTLS13Only(cs *tls.CipherSuite) bool {
+	return len(cs.SupportedVersions) == 1 && cs.SupportedVersions[0] == tls.VersionTLS13
+}
+
 func loadCertPool(certFiles []string) (*x509.CertPool, error) {
 	pool := x509.NewCertPool()
 	for _, certFile := range certFiles {
@@ -2,9 +2,217 @@
 
 package tlscfg
 
-import "testing"
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
 // TODO:
 func TestNewClientTLSConfig(t *testing.T) {
 
 }
+
+func TestNewTLSConfig_VersionsAndCipherSuites(t *testing.T) {
+	tests := map[string]struct {
+		cfg     TLSConfig
+		wantErr bool
+	}{
+		"valid versions": {
+			cfg: TLSConfig{MinTLSVersion: "1.0", MaxTLSVersion: "1.3"},
+		},
+		"valid cipher suites": {
+			cfg: TLSConfig{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+		},
+		"unknown min version": {
+			cfg:     TLSConfig{MinTLSVersion: "1.4"},
+			wantErr: true,
+		},
+		"unknown max version": {
+			cfg:     TLSConfig{MaxTLSVersion: "tls12"},
+			wantErr: true,
+		},
+		"min version greater than max version": {
+			cfg:     TLSConfig{MinTLSVersion: "1.3", MaxTLSVersion: "1.2"},
+			wantErr: true,
+		},
+		"unknown cipher suite": {
+			cfg:     TLSConfig{CipherSuites: []string{"TLS_UNKNOWN"}},
+			wantErr: true,
+		},
+		"TLS 1.3 cipher suite": {
+			cfg:     TLSConfig{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tlsConfig, err := NewTLSConfig(test.cfg)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, tlsConfig)
+			}
+		})
+	}
+}
+
+func TestNewTLSConfig_Handshake(t *testing.T) {
+	tests := map[string]struct {
+		serverMaxVersion uint16
+		serverCiphers    []uint16
+		cfg              TLSConfig
+		wantVersion      uint16
+		wantFail         bool
+	}{
+		"server TLS 1.2, client min 1.2": {
+			serverMaxVersion: tls.VersionTLS12,
+			cfg:              TLSConfig{MinTLSVersion: "1.2"},
+			wantVersion:      tls.VersionTLS12,
+		},
+		"server TLS 1.2, client min 1.3": {
+			serverMaxVersion: tls.VersionTLS12,
+			cfg:              TLSConfig{MinTLSVersion: "1.3"},
+			wantFail:         true,
+		},
+		"server TLS 1.3, client max 1.2": {
+			serverMaxVersion: tls.VersionTLS13,
+			cfg:              TLSConfig{MaxTLSVersion: "1.2"},
+			wantVersion:      tls.VersionTLS12,
+		},
+		"matching cipher suite": {
+			serverMaxVersion: tls.VersionTLS12,
+			serverCiphers:    []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			cfg:              TLSConfig{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+			wantVersion:      tls.VersionTLS12,
+		},
+		"not matching cipher suite": {
+			serverMaxVersion: tls.VersionTLS12,
+			serverCiphers:    []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			cfg:              TLSConfig{CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}},
+			wantFail:         true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			srv.TLS = &tls.Config{MaxVersion: test.serverMaxVersion, CipherSuites: test.serverCiphers}
+			srv.StartTLS()
+			defer srv.Close()
+
+			test.cfg.InsecureSkipVerify = true
+			tlsConfig, err := NewTLSConfig(test.cfg)
+			require.NoError(t, err)
+
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+			resp, err := client.Get(srv.URL)
+
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, test.wantVersion, resp.TLS.Version)
+		})
+	}
+}
+
+func TestNewTLSConfig_ClientCertificateReload(t *testing.T) {
+	var presented string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			presented = r.TLS.PeerCertificates[0].Subject.CommonName
+		}
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	modTime := time.Now().Add(-time.Hour)
+	writeSelfSignedPair(t, certFile, keyFile, "first", modTime)
+
+	tlsConfig, err := NewTLSConfig(TLSConfig{TLSCert: certFile, TLSKey: keyFile, InsecureSkipVerify: true})
+	require.NoError(t, err)
+	require.Len(t, tlsConfig.Certificates, 1)
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig, DisableKeepAlives: true}}
+	get := func() string {
+		presented = ""
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return presented
+	}
+
+	assert.Equal(t, "first", get())
+
+	// rotated
+	writeSelfSignedPair(t, certFile, keyFile, "second", modTime.Add(time.Minute))
+	assert.Equal(t, "second", get())
+
+	// partially written, the last good pair is used
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0600))
+	require.NoError(t, os.Chtimes(certFile, modTime.Add(2*time.Minute), modTime.Add(2*time.Minute)))
+	assert.Equal(t, "second", get())
+
+	// removed, the last good pair is used
+	require.NoError(t, os.Remove(keyFile))
+	assert.Equal(t, "second", get())
+
+	// rotated again
+	writeSelfSignedPair(t, certFile, keyFile, "third", modTime.Add(3*time.Minute))
+	assert.Equal(t, "third", get())
+}
+
+func TestNewTLSConfig_ClientCertificateNotExist(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewTLSConfig(TLSConfig{TLSCert: filepath.Join(dir, "client.crt"), TLSKey: filepath.Join(dir, "client.key")})
+	assert.Error(t, err)
+}
+
+func writeSelfSignedPair(t *testing.T, certFile, keyFile, commonName string, modTime time.Time) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package tlscfg
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// certReloader provides the client certificate, it re-reads the cert/key pair when the files modification time changes.
+// The last successfully loaded pair is used if the files can't be loaded (e.g. one of them is being rotated).
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	certMod time.Time
+	keyMod  time.Time
+}
+
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := loadCertificate(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	r.cert, r.certMod, r.keyMod = &cert, certMod, keyMod
+
+	return r, nil
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (r *certReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.certificate(), nil
+}
+
+func (r *certReloader) certificate() *tls.Certificate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil || (certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod)) {
+		return r.cert
+	}
+
+	cert, err := loadCertificate(r.certFile, r.keyFile)
+	if err != nil {
+		// the modification times are not updated, the pair is re-read on the next handshake
+		return r.cert
+	}
+	r.cert, r.certMod, r.keyMod = &cert, certMod, keyMod
+
+	return r.cert
+}
+
+func (r *certReloader) modTimes() (certMod, keyMod time.Time, err error) {
+	fi, err := os.Stat(r.certFile)
+	if err != nil {
+		return certMod, keyMod, err
+	}
+	certMod = fi.ModTime()
+
+	if fi, err = os.Stat(r.keyFile); err != nil {
+		return certMod, keyMod, err
+	}
+	keyMod = fi.ModTime()
+
+	return certMod, keyMod, nil
+}
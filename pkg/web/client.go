@@ -3,6 +3,7 @@
 package web
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -15,6 +16,8 @@ import (
 // ErrRedirectAttempted indicates that a redirect occurred.
 var ErrRedirectAttempted = errors.New("redirect")
 
+type dialContextFunc = func(ctx context.Context, network, addr string) (net.Conn, error)
+
 // Client is the configuration of the HTTP client.
 // This structure is not intended to be used directly as part of a module's configuration.
 // Supported configuration file formats: YAML.
@@ -31,6 +34,31 @@ type Client struct {
 	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY (or the lowercase versions thereof) to get the URL.
 	ProxyURL string `yaml:"proxy_url"`
 
+	// ConnectTo specifies the address (host:port) to connect to instead of the URL host and port.
+	// The URL host is still used for the Host header, TLS SNI and certificate verification.
+	// It applies only to the connections to the request URL host (the first one if redirected), not to the proxy.
+	ConnectTo string `yaml:"connect_to"`
+
+	// UnixSocket specifies the path to the unix domain socket to connect to instead of the URL host and port.
+	// The URL host is still used for the Host header. Can't be used together with ConnectTo.
+	UnixSocket string `yaml:"unix_socket"`
+
+	// KeepAlive specifies the interval between keep-alive probes for an active network connection.
+	// Default (zero value) is std net package default (15 seconds). Negative value disables keep-alive probes.
+	KeepAlive Duration `yaml:"keep_alive"`
+
+	// MaxIdleConnsPerHost specifies the maximum idle (keep-alive) connections to keep per-host.
+	// Default (zero value) is std http package default (2).
+	MaxIdleConnsPerHost int `yaml:"max_idle_conns_per_host"`
+
+	// DisableKeepAlives disables HTTP keep-alives, a connection is used only for a single request.
+	DisableKeepAlives bool `yaml:"disable_keep_alives"`
+
+	// AcceptEncoding specifies the Accept-Encoding header value to be sent by the client ('gzip' or 'identity').
+	// An empty string means use the std http package default behaviour.
+	// Gzip encoded responses are decompressed regardless of this option.
+	AcceptEncoding string `yaml:"accept_encoding"`
+
 	// TLSConfig specifies the TLS configuration.
 	tlscfg.TLSConfig `yaml:",inline"`
 }
@@ -48,20 +76,102 @@ func NewHTTPClient(cfg Client) (*http.Client, error) {
 		}
 	}
 
-	transport := &http.Transport{
+	if cfg.ConnectTo != "" {
+		if _, _, err := net.SplitHostPort(cfg.ConnectTo); err != nil {
+			return nil, fmt.Errorf("error on parsing connect to address '%s': %v", cfg.ConnectTo, err)
+		}
+	}
+
+	if cfg.UnixSocket != "" && cfg.ConnectTo != "" {
+		return nil, errors.New("'connect_to' and 'unix_socket' are mutually exclusive")
+	}
+
+	if err := validateAcceptEncoding(cfg.AcceptEncoding); err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxIdleConnsPerHost < 0 {
+		return nil, fmt.Errorf("invalid max idle connections per host: %d", cfg.MaxIdleConnsPerHost)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   cfg.Timeout.Duration,
+		KeepAlive: cfg.KeepAlive.Duration,
+	}
+
+	t := &transport{acceptEncoding: cfg.AcceptEncoding, connectTo: cfg.ConnectTo != ""}
+	t.base = &http.Transport{
 		Proxy:               proxyFunc(cfg.ProxyURL),
 		TLSClientConfig:     tlsConfig,
-		DialContext:         (&net.Dialer{Timeout: cfg.Timeout.Duration}).DialContext,
+		DialContext:         t.countingDialContext(dialContext(dialer.DialContext, cfg)),
 		TLSHandshakeTimeout: cfg.Timeout.Duration,
+		MaxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		DisableKeepAlives:   cfg.DisableKeepAlives,
 	}
 
 	return &http.Client{
 		Timeout:       cfg.Timeout.Duration,
-		Transport:     transport,
+		Transport:     t,
 		CheckRedirect: redirectFunc(cfg.NotFollowRedirect),
 	}, nil
 }
 
+// BaseTransport returns the underlying *http.Transport of the client created by NewHTTPClient.
+func BaseTransport(client *http.Client) (*http.Transport, bool) {
+	switch t := client.Transport.(type) {
+	case *http.Transport:
+		return t, true
+	case *transport:
+		return t.base, true
+	default:
+		return nil, false
+	}
+}
+
+func dialContext(dial dialContextFunc, cfg Client) dialContextFunc {
+	if cfg.UnixSocket != "" {
+		return unixSocketDialContext(dial, cfg.UnixSocket)
+	}
+	return connectToDialContext(dial, cfg.ConnectTo)
+}
+
+func unixSocketDialContext(dial dialContextFunc, path string) dialContextFunc {
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dial(ctx, "unix", path)
+	}
+}
+
+func connectToDialContext(dial dialContextFunc, connectTo string) dialContextFunc {
+	if connectTo == "" {
+		return dial
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		// the proxy and the redirect target hosts are dialed as is
+		if origin, _ := ctx.Value(originAddrKey{}).(string); origin == addr {
+			addr = connectTo
+		}
+		return dial(ctx, network, addr)
+	}
+}
+
+// originAddrKey is the request context key of the origin (host:port) address, see connectToDialContext.
+type originAddrKey struct{}
+
+// originAddr returns the address (host:port) of the first request URL if the request is redirected.
+func originAddr(req *http.Request) string {
+	for req.Response != nil && req.Response.Request != nil {
+		req = req.Response.Request
+	}
+	port := req.URL.Port()
+	if port == "" {
+		port = "80"
+		if req.URL.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(req.URL.Hostname(), port)
+}
+
 func redirectFunc(notFollowRedirect bool) func(req *http.Request, via []*http.Request) error {
 	if follow := !notFollowRedirect; follow {
 		return nil
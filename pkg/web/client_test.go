@@ -3,11 +3,24 @@
 package web
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/netdata/go.d.plugin/pkg/tlscfg"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewHTTPClient(t *testing.T) {
@@ -21,3 +34,234 @@ func TestNewHTTPClient(t *testing.T) {
 	assert.Equal(t, time.Second*5, client.Timeout)
 	assert.NotNil(t, client.CheckRedirect)
 }
+
+func TestNewHTTPClient_AcceptEncoding(t *testing.T) {
+	tests := map[string]struct {
+		acceptEncoding string
+		reqHeaders     map[string]string
+		wantHeader     string
+		wantErr        bool
+	}{
+		"gzip": {
+			acceptEncoding: "gzip",
+			wantHeader:     "gzip",
+		},
+		"gzip, custom headers set": {
+			acceptEncoding: "gzip",
+			reqHeaders:     map[string]string{"X-Api-Key": "secret"},
+			wantHeader:     "gzip",
+		},
+		"gzip, Accept-Encoding set by request": {
+			reqHeaders: map[string]string{"Accept-Encoding": "gzip"},
+			wantHeader: "gzip",
+		},
+		"identity": {
+			acceptEncoding: "identity",
+			wantHeader:     "identity",
+		},
+		"unsupported": {
+			acceptEncoding: "br",
+			wantErr:        true,
+		},
+	}
+
+	data := []byte("metric_name 1\n")
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotHeader string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Get("Accept-Encoding")
+				if gotHeader != "gzip" {
+					_, _ = w.Write(data)
+					return
+				}
+				w.Header().Set("Content-Encoding", "gzip")
+				gw := gzip.NewWriter(w)
+				_, _ = gw.Write(data)
+				_ = gw.Close()
+			}))
+			defer srv.Close()
+
+			client, err := NewHTTPClient(Client{AcceptEncoding: test.acceptEncoding})
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			req, err := NewHTTPRequest(Request{URL: srv.URL, Headers: test.reqHeaders})
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.wantHeader, gotHeader)
+			assert.Equal(t, data, body)
+			assert.Empty(t, resp.Header.Get("Content-Encoding"))
+		})
+	}
+}
+
+func TestNewHTTPClient_ConnectTo(t *testing.T) {
+	var gotHost, gotServerName string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotServerName = r.Host, r.TLS.ServerName
+	}))
+	defer srv.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, caPEM, 0644))
+
+	_, port, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	// the httptest server certificate is valid for "example.com"
+	client, err := NewHTTPClient(Client{
+		ConnectTo: srv.Listener.Addr().String(),
+		TLSConfig: tlscfg.TLSConfig{TLSCA: caFile},
+	})
+	require.NoError(t, err)
+
+	resp, err := client.Get("https://" + net.JoinHostPort("example.com", port))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, net.JoinHostPort("example.com", port), gotHost)
+	assert.Equal(t, "example.com", gotServerName)
+}
+
+func TestNewHTTPClient_ConnectToInvalidAddress(t *testing.T) {
+	_, err := NewHTTPClient(Client{ConnectTo: "127.0.0.1"})
+
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClient_UnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "web.sock")
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	var gotHost string
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+	}))
+	srv.Listener = ln
+	srv.Start()
+	defer srv.Close()
+
+	client, err := NewHTTPClient(Client{UnixSocket: socket})
+	require.NoError(t, err)
+
+	resp, err := client.Get("http://localhost/status")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "localhost", gotHost)
+}
+
+func TestNewHTTPClient_UnixSocketAndConnectTo(t *testing.T) {
+	_, err := NewHTTPClient(Client{UnixSocket: "/run/app.sock", ConnectTo: "127.0.0.1:80"})
+
+	assert.Error(t, err)
+}
+
+func TestNewHTTPClient_ConnectToWithProxy(t *testing.T) {
+	var gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+	}))
+	defer proxy.Close()
+
+	client, err := NewHTTPClient(Client{
+		ProxyURL:  proxy.URL,
+		ConnectTo: "127.0.0.1:1",
+	})
+	require.NoError(t, err)
+
+	resp, err := client.Get("http://metrics.internal/status")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "http://metrics.internal/status", gotURL)
+}
+
+func Test_connectToDialContext(t *testing.T) {
+	var gotAddr string
+	dial := func(_ context.Context, _, addr string) (net.Conn, error) {
+		gotAddr = addr
+		return nil, errors.New("mock")
+	}
+	ctx := context.WithValue(context.Background(), originAddrKey{}, "metrics.internal:443")
+
+	_, _ = connectToDialContext(dial, "10.0.0.1:443")(ctx, "tcp", "metrics.internal:443")
+	assert.Equal(t, "10.0.0.1:443", gotAddr)
+
+	_, _ = connectToDialContext(dial, "10.0.0.1:443")(ctx, "tcp", "proxy.internal:3128")
+	assert.Equal(t, "proxy.internal:3128", gotAddr)
+
+	_, _ = connectToDialContext(dial, "")(ctx, "tcp", "metrics.internal:443")
+	assert.Equal(t, "metrics.internal:443", gotAddr)
+}
+
+func Test_originAddr(t *testing.T) {
+	first, _ := http.NewRequest(http.MethodGet, "https://metrics.internal/status", nil)
+	redirected, _ := http.NewRequest(http.MethodGet, "http://other.internal:8080/status", nil)
+	redirected.Response = &http.Response{Request: first}
+
+	assert.Equal(t, "metrics.internal:443", originAddr(first))
+	assert.Equal(t, "metrics.internal:443", originAddr(redirected))
+}
+
+func TestNewHTTPClient_ConnsReuse(t *testing.T) {
+	tests := map[string]struct {
+		client    Client
+		wantConns int64
+	}{
+		"keep-alives enabled": {
+			client:    Client{KeepAlive: Duration{Duration: time.Second * 30}, MaxIdleConnsPerHost: 4},
+			wantConns: 1,
+		},
+		"keep-alives disabled": {
+			client:    Client{DisableKeepAlives: true},
+			wantConns: 5,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var accepted atomic.Int64
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("metric_name 1\n"))
+			}))
+			srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					accepted.Add(1)
+				}
+			}
+			srv.Start()
+			defer srv.Close()
+
+			client, err := NewHTTPClient(test.client)
+			require.NoError(t, err)
+
+			for i := 0; i < 5; i++ {
+				resp, err := client.Get(srv.URL)
+				require.NoError(t, err)
+				_, _ = io.Copy(io.Discard, resp.Body)
+				_ = resp.Body.Close()
+			}
+
+			conns, ok := ConnsEstablished(client)
+			require.True(t, ok)
+			assert.Equal(t, test.wantConns, conns)
+			assert.Equal(t, test.wantConns, accepted.Load())
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	encodingGzip     = "gzip"
+	encodingIdentity = "identity"
+)
+
+func validateAcceptEncoding(encoding string) error {
+	switch encoding {
+	case "", encodingGzip, encodingIdentity:
+		return nil
+	default:
+		return fmt.Errorf("unsupported accept encoding '%s' (supported: '%s', '%s')", encoding, encodingGzip, encodingIdentity)
+	}
+}
+
+// decodeResponse decompresses a gzip encoded response body.
+// The std transport decompresses a response only if it added the Accept-Encoding header itself,
+// it is not the case when the header is set by the user (or by a module).
+func decodeResponse(req *http.Request, resp *http.Response) {
+	if resp.Uncompressed || req.Method == http.MethodHead || !isGzipEncoded(resp.Header) {
+		return
+	}
+	resp.Body = &gzipReader{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+func isGzipEncoded(h http.Header) bool {
+	return strings.EqualFold(strings.TrimSpace(h.Get("Content-Encoding")), encodingGzip)
+}
+
+// gzipReader lazily wraps a response body with gzip.Reader on the first Read call.
+type gzipReader struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	zerr error
+}
+
+func (r *gzipReader) Read(p []byte) (int, error) {
+	if r.zr == nil && r.zerr == nil {
+		r.zr, r.zerr = gzip.NewReader(r.body)
+	}
+	if r.zerr != nil {
+		return 0, r.zerr
+	}
+	return r.zr.Read(p)
+}
+
+func (r *gzipReader) Close() error {
+	return r.body.Close()
+}
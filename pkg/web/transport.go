@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync/atomic"
+)
+
+// transport wraps the std transport. It sets the Accept-Encoding header, decompresses gzip encoded responses
+// and counts established connections.
+type transport struct {
+	base           *http.Transport
+	acceptEncoding string
+	// connectTo makes the transport pass the origin address to the dialer (see connectToDialContext).
+	connectTo bool
+	conns     atomic.Int64
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.acceptEncoding != "" && req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", t.acceptEncoding)
+	}
+	if t.connectTo {
+		req = req.WithContext(context.WithValue(req.Context(), originAddrKey{}, originAddr(req)))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	decodeResponse(req, resp)
+
+	return resp, nil
+}
+
+func (t *transport) CloseIdleConnections() {
+	t.base.CloseIdleConnections()
+}
+
+func (t *transport) countingDialContext(dial dialContextFunc) dialContextFunc {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err == nil {
+			t.conns.Add(1)
+		}
+		return conn, err
+	}
+}
+
+// ConnsEstablished returns the number of connections established by the client created by NewHTTPClient.
+// It allows to verify connections reuse. The second return value is false if the client is not created by NewHTTPClient.
+func ConnsEstablished(client *http.Client) (int64, bool) {
+	t, ok := client.Transport.(*transport)
+	if !ok {
+		return 0, false
+	}
+	return t.conns.Load(), true
+}